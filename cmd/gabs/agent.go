@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/remote"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// agentTokenEnv names the environment variable that holds the agent token
+// when no --token-file is given.
+const agentTokenEnv = "GABS_AGENT_TOKEN"
+
+// === Agent Command ===
+
+func runAgent(ctx context.Context, log util.Logger, opts options) int {
+	token, err := agentToken(opts.agentTokenFile)
+	if err != nil {
+		log.Errorw("failed to read agent token", "error", err)
+		return 2
+	}
+
+	gamesConfig, err := config.LoadGamesConfigFromDir(opts.configDir)
+	if err != nil {
+		log.Errorw("failed to load games config", "error", err)
+		return 1
+	}
+
+	agent, err := remote.NewAgent(gamesConfig, token, log)
+	if err != nil {
+		log.Errorw("failed to create agent", "error", err)
+		return 2
+	}
+
+	log.Infow("starting agent", "addr", opts.httpAddr, "gameCount", len(gamesConfig.Games))
+	if err := agent.Serve(ctx, opts.httpAddr); err != nil {
+		log.Errorw("agent exited with error", "error", err)
+		return 1
+	}
+	return 0
+}
+
+// agentToken reads the token from tokenFile, or from $GABS_AGENT_TOKEN when
+// no file is given.
+func agentToken(tokenFile string) (string, error) {
+	if tokenFile == "" {
+		token := strings.TrimSpace(os.Getenv(agentTokenEnv))
+		if token == "" {
+			return "", fmt.Errorf("set --token-file or %s", agentTokenEnv)
+		}
+		return token, nil
+	}
+	data, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("%s is empty", tokenFile)
+	}
+	return token, nil
+}
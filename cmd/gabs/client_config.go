@@ -0,0 +1,254 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// clientConfigExcludedFlags are flags of 'gabs server' that do not belong in
+// a client's launch command, because they pick the transport or only affect
+// --print-client-config itself.
+var clientConfigExcludedFlags = map[string]bool{
+	"http":                true,
+	"addr":                true,
+	"print-client-config": true,
+	"client":              true,
+	"quiet":               true,
+	"verbose":             true,
+	"json":                true,
+}
+
+// clientServerEntry is the JSON entry most MCP clients use for a server,
+// with fields in the order their documentation shows them.
+type clientServerEntry struct {
+	Type    string            `json:"type,omitempty"`
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// mcpClient is an MCP client whose configuration --print-client-config can
+// write.
+type mcpClient struct {
+	id    string
+	name  string
+	file  string // Where the snippet goes
+	stdio func(command string, args []string) string
+	http  func(url, apiKey string) string
+}
+
+var mcpClients = []mcpClient{
+	{
+		id:   "claude-desktop",
+		name: "Claude Desktop",
+		file: "claude_desktop_config.json",
+		stdio: func(command string, args []string) string {
+			return clientConfigJSON("mcpServers", clientServerEntry{Command: command, Args: args})
+		},
+		// Claude Desktop only launches local servers, so HTTP goes through mcp-remote
+		http: func(url, apiKey string) string {
+			args := []string{"-y", "mcp-remote", url}
+			if apiKey != "" {
+				args = append(args, "--header", "Authorization: Bearer "+apiKey)
+			}
+			return clientConfigJSON("mcpServers", clientServerEntry{Command: "npx", Args: args})
+		},
+	},
+	{
+		id:   "cursor",
+		name: "Cursor",
+		file: ".cursor/mcp.json",
+		stdio: func(command string, args []string) string {
+			return clientConfigJSON("mcpServers", clientServerEntry{Command: command, Args: args})
+		},
+		http: func(url, apiKey string) string {
+			return clientConfigJSON("mcpServers", clientServerEntry{URL: url, Headers: bearerHeader(apiKey)})
+		},
+	},
+	{
+		id:   "vscode",
+		name: "VS Code",
+		file: ".vscode/mcp.json",
+		stdio: func(command string, args []string) string {
+			return clientConfigJSON("servers", clientServerEntry{Type: "stdio", Command: command, Args: args})
+		},
+		http: func(url, apiKey string) string {
+			return clientConfigJSON("servers", clientServerEntry{Type: "http", URL: url, Headers: bearerHeader(apiKey)})
+		},
+	},
+	{
+		id:   "codex",
+		name: "Codex CLI",
+		file: "~/.codex/config.toml",
+		stdio: func(command string, args []string) string {
+			return fmt.Sprintf("[mcp_servers.gabs]\ncommand = %s\nargs = %s\n", strconv.Quote(command), tomlStrings(args))
+		},
+		http: func(url, apiKey string) string {
+			text := fmt.Sprintf("[mcp_servers.gabs]\nurl = %s\n", strconv.Quote(url))
+			if apiKey != "" {
+				text += fmt.Sprintf("http_headers = { Authorization = %s }\n", strconv.Quote("Bearer "+apiKey))
+			}
+			return text
+		},
+	},
+}
+
+func mcpClientIDs() []string {
+	ids := make([]string, 0, len(mcpClients))
+	for _, client := range mcpClients {
+		ids = append(ids, client.id)
+	}
+	return ids
+}
+
+// printClientConfig writes MCP client configuration that starts or reaches
+// a GABS server with the current options. With clientID set it writes only
+// that client's snippet, ready to pipe into a file.
+func printClientConfig(w io.Writer, opts options, clientID string) int {
+	clients := mcpClients
+	if clientID != "" {
+		clients = nil
+		for _, client := range mcpClients {
+			if client.id == clientID {
+				clients = append(clients, client)
+			}
+		}
+		if len(clients) == 0 {
+			fmt.Fprintf(os.Stderr, "unknown --client %q, must be one of: %s\n", clientID, strings.Join(mcpClientIDs(), ", "))
+			return 2
+		}
+	}
+
+	var render func(mcpClient) string
+	if opts.transport == "http" {
+		gamesConfig, err := config.LoadGamesConfigFromDir(opts.configDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to load games config: %v\n", err)
+			return 1
+		}
+		url, apiKey := clientConfigURL(opts.httpAddr), clientConfigAPIKey(gamesConfig)
+		if opts.apiKey != "" {
+			apiKey = opts.apiKey
+		}
+		render = func(client mcpClient) string { return client.http(url, apiKey) }
+	} else {
+		command, err := gabsExecutable()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to find the gabs binary: %v\n", err)
+			return 1
+		}
+		args := append([]string{"server", "stdio"}, opts.clientConfigFlags...)
+		render = func(client mcpClient) string { return client.stdio(command, args) }
+	}
+
+	if clientID != "" {
+		fmt.Fprint(w, render(clients[0]))
+		return 0
+	}
+	for i, client := range clients {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "# %s (%s, --client %s)\n%s", client.name, client.file, client.id, render(client))
+	}
+	return 0
+}
+
+// clientConfigFlags returns the flags set on the command line as
+// --name=value, for the launch command of a stdio client. configDir is made
+// absolute, since clients start GABS from another directory.
+func clientConfigFlags(set map[string]string) []string {
+	return forwardedFlags(set, clientConfigExcludedFlags)
+}
+
+// forwardedFlags returns the flags in set that are not excluded as sorted
+// --name=value arguments for another gabs command line.
+func forwardedFlags(set map[string]string, excluded map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		if !excluded[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	flags := make([]string, 0, len(names))
+	for _, name := range names {
+		value := set[name]
+		if name == "configDir" {
+			if abs, err := filepath.Abs(value); err == nil {
+				value = abs
+			}
+		}
+		flags = append(flags, "--"+name+"="+value)
+	}
+	return flags
+}
+
+// clientConfigURL turns the HTTP listen address into the MCP endpoint URL a
+// client on this machine uses.
+func clientConfigURL(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "http://" + addr + "/mcp"
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port) + "/mcp"
+}
+
+// clientConfigAPIKey returns the key a client should send: the main apiKey,
+// or else the first of apiKeys.
+func clientConfigAPIKey(gamesConfig *config.GamesConfig) string {
+	if gamesConfig.APIKey != "" {
+		return gamesConfig.APIKey
+	}
+	if len(gamesConfig.APIKeys) > 0 {
+		return gamesConfig.APIKeys[0].Key
+	}
+	return ""
+}
+
+// gabsExecutable returns the path of the running gabs binary, with symlinks
+// resolved so a launch command keeps working when the link changes.
+func gabsExecutable() (string, error) {
+	command, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(command); err == nil {
+		command = resolved
+	}
+	return command, nil
+}
+
+func clientConfigJSON(section string, server clientServerEntry) string {
+	data, _ := json.MarshalIndent(map[string]interface{}{section: map[string]interface{}{"gabs": server}}, "", "  ")
+	return string(data) + "\n"
+}
+
+func bearerHeader(apiKey string) map[string]string {
+	if apiKey == "" {
+		return nil
+	}
+	return map[string]string{"Authorization": "Bearer " + apiKey}
+}
+
+func tomlStrings(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, strconv.Quote(value))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
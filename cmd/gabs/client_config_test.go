@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func TestClientConfigFlagsRepeatServerFlags(t *testing.T) {
+	flags := clientConfigFlags(map[string]string{
+		"configDir":           "gabs-config",
+		"log-level":           "debug",
+		"print-client-config": "true",
+		"client":              "cursor",
+		"http":                "localhost:8080",
+	})
+	abs, _ := filepath.Abs("gabs-config")
+	want := []string{"--configDir=" + abs, "--log-level=debug"}
+	if strings.Join(flags, " ") != strings.Join(want, " ") {
+		t.Fatalf("expected %v, got %v", want, flags)
+	}
+}
+
+func TestPrintClientConfigForHTTPIncludesURLAndAPIKey(t *testing.T) {
+	configDir := t.TempDir()
+	gamesConfig := &config.GamesConfig{Version: "1.0", Games: map[string]config.GameConfig{}, APIKey: "secret"}
+	if err := config.SaveGamesConfigToDir(gamesConfig, configDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	var out bytes.Buffer
+	opts := options{transport: "http", httpAddr: "0.0.0.0:9000", configDir: configDir}
+	if code := printClientConfig(&out, opts, "cursor"); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	var snippet struct {
+		MCPServers map[string]clientServerEntry `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &snippet); err != nil {
+		t.Fatalf("expected pure JSON for one client, got %q: %v", out.String(), err)
+	}
+	entry := snippet.MCPServers["gabs"]
+	if entry.URL != "http://localhost:9000/mcp" || entry.Headers["Authorization"] != "Bearer secret" {
+		t.Fatalf("unexpected entry %#v", entry)
+	}
+
+	if code := printClientConfig(&out, opts, "unknown"); code != 2 {
+		t.Fatalf("expected an unknown client to be rejected, got %d", code)
+	}
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pardeike/gabs/internal/control"
+)
+
+const controlRequestTimeout = 10 * time.Second
+
+type controlStatusResult struct {
+	PID        int                 `json:"pid"`
+	InstanceID string              `json:"instanceId"`
+	Games      []controlGameStatus `json:"games"`
+}
+
+type controlGameStatus struct {
+	GameID            string `json:"gameId"`
+	Name              string `json:"name"`
+	Status            string `json:"status"`
+	StatusDescription string `json:"statusDescription"`
+}
+
+type controlReloadResult struct {
+	GameCount int      `json:"gameCount"`
+	Added     []string `json:"added"`
+	Changed   []string `json:"changed"`
+	Removed   []string `json:"removed"`
+}
+
+// discoverServers finds running servers and prints a hint when there are none.
+func discoverServers(configDir string) ([]string, bool) {
+	paths, err := control.Discover(configDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to look for running GABS servers: %v\n", err)
+		return nil, false
+	}
+	if len(paths) == 0 {
+		fmt.Println("No running GABS server found. Start one with 'gabs server'.")
+		return nil, false
+	}
+	return paths, true
+}
+
+func controlStatus(opts options, args []string) int {
+	gameID := ""
+	if len(args) > 0 {
+		gameID = args[0]
+	}
+
+	paths, ok := discoverServers(opts.configDir)
+	if !ok {
+		return 1
+	}
+
+	exitCode := 0
+	for _, path := range paths {
+		resp, err := control.Call(path, control.Request{Command: control.CommandStatus, GameID: gameID}, controlRequestTimeout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", serverLabel(path), err)
+			exitCode = 1
+			continue
+		}
+		var status controlStatusResult
+		if err := decodeControlResponse(resp, &status); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", serverLabel(path), err)
+			exitCode = 1
+			continue
+		}
+
+		fmt.Printf("GABS server pid %d (instance %s)\n", status.PID, status.InstanceID)
+		if len(status.Games) == 0 {
+			fmt.Println("  No games configured.")
+		}
+		for _, game := range status.Games {
+			fmt.Printf("  %s: %s\n", game.GameID, game.StatusDescription)
+		}
+	}
+	return exitCode
+}
+
+func controlWatch(ctx context.Context, opts options, args []string) int {
+	gameID := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		gameID = args[0]
+		args = args[1:]
+	}
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	interval := fs.Duration("interval", 2*time.Second, "How often to poll the server")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	paths, ok := discoverServers(opts.configDir)
+	if !ok {
+		return 1
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		exitCode int
+	)
+	for _, path := range paths {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			lastStatus := make(map[string]string)
+			err := control.Watch(ctx, path, control.Request{GameID: gameID, IntervalMs: int(interval.Milliseconds())}, func(resp control.Response) error {
+				var status controlStatusResult
+				if err := decodeControlResponse(resp, &status); err != nil {
+					return err
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				for _, game := range status.Games {
+					if lastStatus[game.GameID] == game.StatusDescription {
+						continue
+					}
+					lastStatus[game.GameID] = game.StatusDescription
+					fmt.Printf("%s pid %d %s: %s\n", time.Now().Format("15:04:05"), status.PID, game.GameID, game.StatusDescription)
+				}
+				return nil
+			})
+			if err != nil {
+				mu.Lock()
+				fmt.Fprintf(os.Stderr, "%s: %v\n", serverLabel(path), err)
+				exitCode = 1
+				mu.Unlock()
+			}
+		}(path)
+	}
+	wg.Wait()
+	return exitCode
+}
+
+func controlReload(opts options) int {
+	paths, ok := discoverServers(opts.configDir)
+	if !ok {
+		return 1
+	}
+
+	exitCode := 0
+	for _, path := range paths {
+		resp, err := control.Call(path, control.Request{Command: control.CommandReload}, controlRequestTimeout)
+		var result controlReloadResult
+		if err == nil {
+			err = decodeControlResponse(resp, &result)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: reload failed: %v\n", serverLabel(path), err)
+			exitCode = 1
+			continue
+		}
+
+		fmt.Printf("%s: reloaded %d games", serverLabel(path), result.GameCount)
+		if len(result.Added) > 0 {
+			fmt.Printf(", added %s", strings.Join(result.Added, ", "))
+		}
+		if len(result.Changed) > 0 {
+			fmt.Printf(", changed %s", strings.Join(result.Changed, ", "))
+		}
+		if len(result.Removed) > 0 {
+			fmt.Printf(", removed %s", strings.Join(result.Removed, ", "))
+		}
+		fmt.Println()
+	}
+	return exitCode
+}
+
+// serverLabel names a server by the pid its control socket is named after.
+func serverLabel(socketPath string) string {
+	return "pid " + strings.TrimSuffix(filepath.Base(socketPath), filepath.Ext(socketPath))
+}
+
+func decodeControlResponse(resp control.Response, target interface{}) error {
+	if !resp.OK {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return json.Unmarshal(resp.Result, target)
+}
+
+// controlToolTimeout bounds 'gabs control', which may wait for a game to
+// start and its bridge to connect.
+const controlToolTimeout = 5 * time.Minute
+
+type controlToolResult struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StructuredContent map[string]interface{} `json:"structuredContent"`
+	IsError           bool                   `json:"isError"`
+}
+
+// controlTool runs 'gabs control <tool> [gameId] [--pid n] [--name value...]'
+// against a running server. Flags other than --pid and --json become tool
+// arguments; true, false and whole numbers are passed as such.
+func controlTool(opts options, args []string) int {
+	args, jsonOutput := extractJSONFlag(args)
+	jsonOutput = jsonOutput || opts.jsonOutput
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "control requires a tool: games.start, games.stop, games.kill, games.restart or games.status")
+		return 2
+	}
+	tool := args[0]
+	pid, toolArgs, err := parseControlToolArgs(args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "control %s: %v\n", tool, err)
+		return 2
+	}
+
+	path, ok := controlServer(opts.configDir, pid)
+	if !ok {
+		return 1
+	}
+	resp, err := control.Call(path, control.Request{Command: control.CommandTool, Tool: tool, Arguments: toolArgs}, controlToolTimeout)
+	var result controlToolResult
+	if err == nil {
+		err = decodeControlResponse(resp, &result)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", serverLabel(path), err)
+		return 1
+	}
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		for _, content := range result.Content {
+			if content.Type == "text" {
+				fmt.Println(content.Text)
+			}
+		}
+	}
+	if result.IsError {
+		return 1
+	}
+	return 0
+}
+
+// parseControlToolArgs splits the arguments after the tool name into the
+// --pid of the server to use and the tool arguments. A leading bare word is
+// the gameId.
+func parseControlToolArgs(args []string) (int, map[string]interface{}, error) {
+	toolArgs := make(map[string]interface{})
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		toolArgs["gameId"] = args[0]
+		args = args[1:]
+	}
+	pid := 0
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			return 0, nil, fmt.Errorf("unexpected argument '%s'", arg)
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if name == "" {
+			return 0, nil, fmt.Errorf("invalid flag '%s'", arg)
+		}
+		if !hasValue {
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				i++
+				value = args[i]
+			} else {
+				value = "true"
+			}
+		}
+		if name == "pid" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return 0, nil, fmt.Errorf("--pid must be a process ID, got '%s'", value)
+			}
+			pid = n
+			continue
+		}
+		toolArgs[name] = controlArgValue(value)
+	}
+	return pid, toolArgs, nil
+}
+
+func controlArgValue(value string) interface{} {
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n
+	}
+	return value
+}
+
+// controlServer picks the server 'gabs control' talks to: the one with pid,
+// or the only one running.
+func controlServer(configDir string, pid int) (string, bool) {
+	if pid != 0 {
+		path, err := control.SocketPath(configDir, pid)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to find the GABS server with pid %d: %v\n", pid, err)
+			return "", false
+		}
+		return path, true
+	}
+	paths, ok := discoverServers(configDir)
+	if !ok {
+		return "", false
+	}
+	if len(paths) > 1 {
+		labels := make([]string, 0, len(paths))
+		for _, path := range paths {
+			labels = append(labels, serverLabel(path))
+		}
+		fmt.Fprintf(os.Stderr, "Several GABS servers are running (%s). Pick one with --pid.\n", strings.Join(labels, ", "))
+		return "", false
+	}
+	return paths[0], true
+}
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// clearFieldValue empties an optional field when entered at an edit prompt.
+const clearFieldValue = "-"
+
+// gameEditField is one setting 'gabs games edit' can change.
+type gameEditField struct {
+	flag  string
+	usage string
+	get   func(config.GameConfig) string
+	set   func(*config.GameConfig, string)
+	check func(string) error // Rejects values set cannot read; nil accepts any
+}
+
+var gameEditFields = []gameEditField{
+	{
+		flag:  "name",
+		usage: "Display name",
+		get:   func(g config.GameConfig) string { return g.Name },
+		set:   func(g *config.GameConfig, v string) { g.Name = v },
+	},
+	{
+		flag:  "launchMode",
+		usage: strings.Join(config.LaunchModes, "|"),
+		get:   func(g config.GameConfig) string { return g.LaunchMode },
+		set:   func(g *config.GameConfig, v string) { g.LaunchMode = v },
+	},
+	{
+		flag:  "target",
+		usage: "Executable path, command or launcher app ID",
+		get:   func(g config.GameConfig) string { return g.Target },
+		set:   func(g *config.GameConfig, v string) { g.Target = v },
+	},
+	{
+		flag:  "args",
+		usage: "Launch arguments split like a shell does, so quote arguments with spaces; empty clears them",
+		get:   func(g config.GameConfig) string { return quoteArgs(g.Args) },
+		set:   func(g *config.GameConfig, v string) { g.Args, _ = splitArgs(v) },
+		check: func(v string) error { _, err := splitArgs(v); return err },
+	},
+	{
+		flag:  "workingDir",
+		usage: "Working directory; empty clears it",
+		get:   func(g config.GameConfig) string { return g.WorkingDir },
+		set:   func(g *config.GameConfig, v string) { g.WorkingDir = v },
+	},
+	{
+		flag:  "env",
+		usage: "Space-separated KEY=VALUE environment variables; empty clears them",
+		get:   func(g config.GameConfig) string { return formatEnv(g.Env) },
+		set:   func(g *config.GameConfig, v string) { g.Env = parseEnv(v) },
+	},
+	{
+		flag:  "stopProcessName",
+		usage: "Process name used to stop the game; empty clears it",
+		get:   func(g config.GameConfig) string { return g.StopProcessName },
+		set:   func(g *config.GameConfig, v string) { g.StopProcessName = v },
+	},
+	{
+		flag:  "gabpMode",
+		usage: "GABP connection mode; empty clears it",
+		get:   func(g config.GameConfig) string { return g.GABPMode },
+		set:   func(g *config.GameConfig, v string) { g.GABPMode = v },
+	},
+	{
+		flag:  "bridgeMode",
+		usage: "connect or listen: whether GABS connects to the bridge or the bridge connects in; empty clears it",
+		get:   func(g config.GameConfig) string { return g.BridgeMode },
+		set:   func(g *config.GameConfig, v string) { g.BridgeMode = v },
+	},
+	{
+		flag:  "fixedPort",
+		usage: "GABP port the bridge always gets; empty clears it so GABS picks one",
+		get:   getFixedPort,
+		set:   setFixedPort,
+	},
+	{
+		flag:  "shutdownPolicy",
+		usage: strings.Join(config.ShutdownPolicies, "|") + ": what happens to the running game when GABS shuts down; empty clears it",
+		get:   func(g config.GameConfig) string { return g.ShutdownPolicy },
+		set:   func(g *config.GameConfig, v string) { g.ShutdownPolicy = v },
+	},
+	{
+		flag:  "idleTimeout",
+		usage: "Duration such as 30m after which an idle running game is stopped; empty clears it",
+		get:   func(g config.GameConfig) string { return g.IdleTimeout },
+		set:   func(g *config.GameConfig, v string) { g.IdleTimeout = v },
+	},
+	{
+		flag:  "defaultProfile",
+		usage: "Launch profile games_start uses when none is named; empty clears it",
+		get:   func(g config.GameConfig) string { return g.DefaultProfile },
+		set:   func(g *config.GameConfig, v string) { g.DefaultProfile = v },
+	},
+	{
+		flag:  "savesDir",
+		usage: "Save directory games_saves_backup snapshots, absolute or relative to workingDir; empty clears it",
+		get:   getSavesDir,
+		set:   setSavesDir,
+	},
+	{
+		flag:  "description",
+		usage: "Description; empty clears it",
+		get:   func(g config.GameConfig) string { return g.Description },
+		set:   func(g *config.GameConfig, v string) { g.Description = v },
+	},
+}
+
+// Flags of 'gabs games edit' that pick a launch profile instead of setting a
+// field. With profileFlag, the fields in profileEditFields change that
+// profile, which is created when missing.
+const (
+	profileFlag       = "profile"
+	removeProfileFlag = "removeProfile"
+)
+
+var profileEditFields = map[string]bool{"args": true, "workingDir": true, "env": true}
+
+// parseGameEditFlags returns the fields set on the command line, keyed by
+// flag name. Fields that were not passed are left out, so an explicit empty
+// value can clear a field.
+func parseGameEditFlags(args []string) (map[string]string, error) {
+	fs := flag.NewFlagSet("games edit", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	for _, field := range gameEditFields {
+		fs.String(field.flag, "", field.usage)
+	}
+	fs.String(profileFlag, "", "Launch profile that --args, --workingDir and --env change instead of the game")
+	fs.String(removeProfileFlag, "", "Launch profile to remove")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument %q; flags go after the game ID, e.g. --target /opt/factory/start.sh", fs.Arg(0))
+	}
+
+	changes := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		changes[f.Name] = f.Value.String()
+	})
+	return changes, nil
+}
+
+// checkGameEdits rejects changes a field cannot read, such as args with an
+// unterminated quote.
+func checkGameEdits(changes map[string]string) error {
+	for _, field := range gameEditFields {
+		value, ok := changes[field.flag]
+		if !ok || field.check == nil {
+			continue
+		}
+		if err := field.check(strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("--%s: %w", field.flag, err)
+		}
+	}
+	return nil
+}
+
+// applyGameEdits returns a copy of game with changes applied, and the names of
+// the fields whose value actually changed. A value equal to the current one
+// is not written back, so a field stays exactly as it is saved unless the
+// user changed it.
+func applyGameEdits(game config.GameConfig, changes map[string]string) (config.GameConfig, []string) {
+	var changed []string
+	for _, field := range gameEditFields {
+		value, ok := changes[field.flag]
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		before := field.get(game)
+		if value == before {
+			continue
+		}
+		field.set(&game, value)
+		if field.get(game) != before {
+			changed = append(changed, field.flag)
+		}
+	}
+	return game, changed
+}
+
+// applyProfileEdits applies the profileEditFields in changes to the launch
+// profile name of game, creating it when needed, and removes them from
+// changes. It returns the names of the changed fields as "profile.field".
+func applyProfileEdits(game config.GameConfig, name string, changes map[string]string) (config.GameConfig, []string) {
+	profile, exists := game.Profiles[name]
+	edits := make(map[string]string)
+	for flag, value := range changes {
+		if profileEditFields[flag] {
+			edits[flag] = value
+			delete(changes, flag)
+		}
+	}
+
+	fields := config.GameConfig{Args: profile.Args, WorkingDir: profile.WorkingDir, Env: profile.Env}
+	fields, edited := applyGameEdits(fields, edits)
+	if exists && len(edited) == 0 {
+		return game, nil
+	}
+	game = game.WithLaunchProfile(name, config.LaunchProfile{Args: fields.Args, WorkingDir: fields.WorkingDir, Env: fields.Env})
+
+	changed := make([]string, 0, len(edited)+1)
+	if !exists {
+		changed = append(changed, "profile "+name)
+	}
+	for _, field := range edited {
+		changed = append(changed, name+"."+field)
+	}
+	return game, changed
+}
+
+func getSavesDir(g config.GameConfig) string {
+	if g.Saves == nil {
+		return ""
+	}
+	return g.Saves.Dir
+}
+
+func getFixedPort(g config.GameConfig) string {
+	if g.FixedPort == 0 {
+		return ""
+	}
+	return strconv.Itoa(g.FixedPort)
+}
+
+// setFixedPort sets fixedPort. A value that is not a number becomes -1, which
+// validation rejects.
+func setFixedPort(g *config.GameConfig, value string) {
+	if value == "" {
+		g.FixedPort = 0
+		return
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		port = -1
+	}
+	g.FixedPort = port
+}
+
+// setSavesDir sets saves.dir, keeping the backup limit, or removes the saves
+// config when value is empty.
+func setSavesDir(g *config.GameConfig, value string) {
+	if value == "" {
+		g.Saves = nil
+		return
+	}
+	saves := config.SavesConfig{Dir: value}
+	if g.Saves != nil {
+		saves.Keep = g.Saves.Keep
+	}
+	g.Saves = &saves
+}
+
+// formatEnv writes env as space-separated KEY=VALUE pairs sorted by key.
+func formatEnv(env map[string]string) string {
+	pairs := make([]string, 0, len(env))
+	for key, value := range env {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
+
+// parseEnv reads space-separated KEY=VALUE pairs; a pair without "=" sets
+// an empty value.
+func parseEnv(value string) map[string]string {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return nil
+	}
+	env := make(map[string]string, len(fields))
+	for _, field := range fields {
+		key, val, _ := strings.Cut(field, "=")
+		env[key] = val
+	}
+	return env
+}
+
+// splitArgs splits value into arguments the way a shell splits words: single
+// and double quotes keep spaces, and a backslash escapes a following quote,
+// backslash or space outside single quotes. Any other backslash is kept, so
+// Windows paths such as C:\Games\Factory need no escaping.
+func splitArgs(value string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			if !strings.ContainsRune(`"'\ `+"\t", r) || (quote == '"' && r != '"' && r != '\\') {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				args = append(args, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in %q", quote, value)
+	}
+	if escaped {
+		current.WriteRune('\\')
+	}
+	if inWord {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// quoteArgs joins args so that splitArgs returns them unchanged, quoting only
+// the arguments that need it.
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\") {
+			quoted[i] = arg
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// promptGameEdits asks for every field with the current value as default.
+func promptGameEdits(game config.GameConfig) map[string]string {
+	fmt.Printf("Editing game configuration for '%s'. Press Enter to keep a value, or enter '%s' to clear an optional one.\n", game.ID, clearFieldValue)
+	changes := make(map[string]string)
+	for _, field := range gameEditFields {
+		current := field.get(game)
+		var value string
+		if field.flag == "launchMode" {
+			value = promptChoice("Launch Mode", current, config.LaunchModes)
+		} else {
+			value = promptString(field.flag, current)
+		}
+		if value == clearFieldValue && field.flag != "name" && field.flag != "launchMode" {
+			value = ""
+		}
+		changes[field.flag] = value
+	}
+	return changes
+}
+
+func editGame(log util.Logger, gameID string, configDir string, args []string) int {
+	changes, err := parseGameEditFlags(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 2
+	}
+
+	gamesConfig, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		log.Errorw("failed to load games config", "error", err)
+		return 1
+	}
+
+	game, exists := gamesConfig.GetGame(gameID)
+	if !exists {
+		fmt.Printf("Game '%s' not found. Use 'gabs games add %s' to add it.\n", gameID, gameID)
+		return 1
+	}
+
+	if len(changes) == 0 {
+		if !isInteractive() {
+			fmt.Fprintf(os.Stderr, "games edit needs at least one flag, such as --target or --stopProcessName, when not run in a terminal\n")
+			return 2
+		}
+		changes = promptGameEdits(*game)
+	}
+	if err := checkGameEdits(changes); err != nil {
+		fmt.Fprintf(os.Stderr, "Game '%s' was not changed: %v\n", gameID, err)
+		return 2
+	}
+
+	updated := *game
+	var changed []string
+	if name, ok := changes[removeProfileFlag]; ok {
+		delete(changes, removeProfileFlag)
+		var removed bool
+		if updated, removed = updated.WithoutLaunchProfile(strings.TrimSpace(name)); !removed {
+			fmt.Printf("Game '%s' has no launch profile '%s'.\n", gameID, name)
+			return 1
+		}
+		changed = append(changed, "removed profile "+strings.TrimSpace(name))
+	}
+	if name, ok := changes[profileFlag]; ok {
+		delete(changes, profileFlag)
+		var profileChanged []string
+		updated, profileChanged = applyProfileEdits(updated, strings.TrimSpace(name), changes)
+		changed = append(changed, profileChanged...)
+	}
+	updated, fieldsChanged := applyGameEdits(updated, changes)
+	changed = append(changed, fieldsChanged...)
+	if len(changed) == 0 {
+		fmt.Printf("Game '%s' is unchanged.\n", gameID)
+		return 0
+	}
+
+	if err := gamesConfig.AddGame(updated); err != nil {
+		fmt.Fprintf(os.Stderr, "Game '%s' was not changed: %v\n", gameID, err)
+		return 1
+	}
+
+	if err := config.SaveGamesConfigToDir(gamesConfig, configDir); err != nil {
+		log.Errorw("failed to save games config", "error", err)
+		return 1
+	}
+
+	fmt.Printf("Game '%s' updated: %s.\n", gameID, strings.Join(changed, ", "))
+	fmt.Println("Running GABS servers use the change after 'gabs games reload'.")
+	return 0
+}
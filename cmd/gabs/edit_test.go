@@ -0,0 +1,132 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestParseGameEditFlagsKeepsExplicitEmptyValues(t *testing.T) {
+	changes, err := parseGameEditFlags([]string{"--target", "/opt/factory/start.sh", "--args", "--headless --world main", "--description="})
+	if err != nil {
+		t.Fatalf("parseGameEditFlags failed: %v", err)
+	}
+	want := map[string]string{"target": "/opt/factory/start.sh", "args": "--headless --world main", "description": ""}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("parseGameEditFlags() = %v, want %v", changes, want)
+	}
+
+	if _, err := parseGameEditFlags([]string{"factory"}); err == nil {
+		t.Fatal("expected a stray positional argument to be rejected")
+	}
+}
+
+func TestEditGameUpdatesSavedConfig(t *testing.T) {
+	configDir := t.TempDir()
+	gamesConfig := &config.GamesConfig{Games: map[string]config.GameConfig{
+		"factory": {ID: "factory", Name: "factory", LaunchMode: "DirectPath", Description: "old"},
+	}}
+	if err := config.SaveGamesConfigToDir(gamesConfig, configDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	log := util.NewLogger("error")
+
+	if code := editGame(log, "factory", configDir, []string{"--target", "/opt/factory/start.sh", "--args", "--headless --port 4000", "--description", ""}); code != 0 {
+		t.Fatalf("editGame exited with %d", code)
+	}
+	saved, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	game, _ := saved.GetGame("factory")
+	if game.Target != "/opt/factory/start.sh" || !reflect.DeepEqual(game.Args, []string{"--headless", "--port", "4000"}) || game.Description != "" || game.Name != "factory" {
+		t.Fatalf("unexpected edited game: %#v", game)
+	}
+
+	// An edit that fails validation leaves the file alone.
+	if code := editGame(log, "factory", configDir, []string{"--launchMode", "EpicAppId", "--target", "epic-app"}); code != 1 {
+		t.Fatalf("expected EpicAppId without stopProcessName to be rejected, got exit %d", code)
+	}
+	saved, _ = config.LoadGamesConfigFromDir(configDir)
+	if game, _ := saved.GetGame("factory"); game.LaunchMode != "DirectPath" {
+		t.Fatalf("expected rejected edit not to be saved, got %#v", game)
+	}
+
+	if code := editGame(log, "missing", configDir, []string{"--target", "/x"}); code != 1 {
+		t.Fatalf("expected unknown game to fail, got exit %d", code)
+	}
+}
+
+func TestEditGameProfileFlagEditsLaunchProfile(t *testing.T) {
+	configDir := t.TempDir()
+	gamesConfig := &config.GamesConfig{Games: map[string]config.GameConfig{
+		"factory": {ID: "factory", Name: "factory", LaunchMode: "DirectPath", Target: "/opt/factory/start.sh", Args: []string{"--world", "main"}},
+	}}
+	if err := config.SaveGamesConfigToDir(gamesConfig, configDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	log := util.NewLogger("error")
+
+	if code := editGame(log, "factory", configDir, []string{"--profile", "hardcore", "--args", "--difficulty hard", "--env", "SAVE=hard", "--defaultProfile", "hardcore"}); code != 0 {
+		t.Fatalf("editGame exited with %d", code)
+	}
+	saved, _ := config.LoadGamesConfigFromDir(configDir)
+	game, _ := saved.GetGame("factory")
+	profile := game.Profiles["hardcore"]
+	if !reflect.DeepEqual(game.Args, []string{"--world", "main"}) || !reflect.DeepEqual(profile.Args, []string{"--difficulty", "hard"}) || profile.Env["SAVE"] != "hard" || game.DefaultProfile != "hardcore" {
+		t.Fatalf("expected --args to change only the profile, got %#v", game)
+	}
+
+	if code := editGame(log, "factory", configDir, []string{"--removeProfile", "hardcore"}); code != 0 {
+		t.Fatalf("removing the profile exited with %d", code)
+	}
+	saved, _ = config.LoadGamesConfigFromDir(configDir)
+	if game, _ := saved.GetGame("factory"); len(game.Profiles) != 0 || game.DefaultProfile != "" {
+		t.Fatalf("expected the profile and its default to be removed, got %#v", game)
+	}
+}
+
+func TestSplitArgsFollowsShellQuoting(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{value: `--headless --world main`, want: []string{"--headless", "--world", "main"}},
+		{value: `--save "My World" --name 'Bob''s'`, want: []string{"--save", "My World", "--name", "Bobs"}},
+		{value: `--path C:\Games\Factory --title My\ Game`, want: []string{"--path", `C:\Games\Factory`, "--title", "My Game"}},
+		{value: `--empty ""`, want: []string{"--empty", ""}},
+		{value: "", want: nil},
+	}
+	for _, tt := range tests {
+		got, err := splitArgs(tt.value)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitArgs(%q) = %q, %v, want %q", tt.value, got, err, tt.want)
+		}
+	}
+	if _, err := splitArgs(`--save "My World`); err == nil {
+		t.Error("expected an unterminated quote to be rejected")
+	}
+
+	args := []string{"--save", "My World", "it's", `C:\Games\`, ""}
+	if got, err := splitArgs(quoteArgs(args)); err != nil || !reflect.DeepEqual(got, args) {
+		t.Fatalf("expected quoteArgs to round-trip %q, got %q, %v", args, got, err)
+	}
+}
+
+func TestApplyGameEditsKeepsUnchangedArgs(t *testing.T) {
+	game := config.GameConfig{ID: "factory", Args: []string{"--save", "My World"}, Description: "old"}
+
+	// The prompt passes every field back with its current value.
+	changes := map[string]string{"args": quoteArgs(game.Args), "description": "new"}
+	updated, changed := applyGameEdits(game, changes)
+	if !reflect.DeepEqual(updated.Args, game.Args) || !reflect.DeepEqual(changed, []string{"description"}) {
+		t.Fatalf("expected only the description to change, got %q and %v", updated.Args, changed)
+	}
+
+	updated, changed = applyGameEdits(game, map[string]string{"args": `--save "Other World"`})
+	if !reflect.DeepEqual(updated.Args, []string{"--save", "Other World"}) || !reflect.DeepEqual(changed, []string{"args"}) {
+		t.Fatalf("expected quoted args to be kept together, got %q and %v", updated.Args, changed)
+	}
+}
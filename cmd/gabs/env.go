@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// apiKeyEnv sets the main HTTP API key in place of apiKey in config.json,
+// so containers can pass it as a secret.
+const apiKeyEnv = "GABS_API_KEY"
+
+// httpAddrEnv sets --addr, and makes 'gabs server' without a transport serve
+// HTTP.
+const httpAddrEnv = "GABS_HTTP_ADDR"
+
+// envFlags are the GABS_* environment variables that stand in for flags.
+// A flag given on the command line wins over its variable, and the variable
+// over the flag's default.
+var envFlags = []struct {
+	env  string
+	flag string
+}{
+	{"GABS_CONFIG_DIR", "configDir"},
+	{httpAddrEnv, "addr"},
+	{"GABS_LOG_LEVEL", "log-level"},
+	{"GABS_LOG_FORMAT", "log-format"},
+	{"GABS_LOG_FILE", "log-file"},
+	{"GABS_LOG_FILE_MAX_SIZE", "log-file-max-size"},
+	{"GABS_BACKOFF", "reconnectBackoff"},
+	{"GABS_GRACE", "grace"},
+	{"GABS_STRICT_MCP", "strict-mcp"},
+	{"GABS_WATCH_CONFIG", "watch-config"},
+	{"GABS_MAX_CONCURRENT_CALLS", "max-concurrent-calls"},
+	{"GABS_TOOL_TIMEOUT", "tool-timeout"},
+	{"GABS_API_KEY_FILE", "api-key-file"},
+	{"GABS_SHUTDOWN_POLICY", "shutdown-policy"},
+	{"GABS_SHUTDOWN_TIMEOUT", "shutdown-timeout"},
+}
+
+// applyEnvFlags sets every flag of envFlags that the command line left out
+// from its environment variable. Empty variables are ignored. A value the
+// flag cannot parse is an error naming the variable.
+func applyEnvFlags(fs *flag.FlagSet, lookup func(string) (string, bool)) error {
+	given := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	for _, entry := range envFlags {
+		value, ok := lookup(entry.env)
+		value = strings.TrimSpace(value)
+		if !ok || value == "" || given[entry.flag] || fs.Lookup(entry.flag) == nil {
+			continue
+		}
+		if err := fs.Set(entry.flag, value); err != nil {
+			return fmt.Errorf("invalid %s=%q: %v", entry.env, value, err)
+		}
+	}
+	return nil
+}
+
+// envSet reports whether the environment variable holds a value.
+func envSet(name string) bool {
+	return strings.TrimSpace(os.Getenv(name)) != ""
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/epic"
+)
+
+// chooseEpicApp lists the titles installed through the Epic Games Launcher
+// and lets the user pick one by number or AppName. It returns false when
+// nothing is installed or the user wants to type the target themselves.
+func chooseEpicApp() (epic.App, bool) {
+	apps, err := epic.InstalledApps()
+	if err != nil {
+		fmt.Printf("⚠️  Could not read Epic manifests: %v\n", err)
+		return epic.App{}, false
+	}
+	if len(apps) == 0 {
+		return epic.App{}, false
+	}
+
+	fmt.Println("Installed Epic games:")
+	for i, app := range apps {
+		fmt.Printf("  %d) %s [%s]\n", i+1, app.DisplayName, app.AppName)
+	}
+	return selectEpicApp(apps, promptString("Select a game by number or AppName (empty to enter manually)", ""))
+}
+
+func selectEpicApp(apps []epic.App, choice string) (epic.App, bool) {
+	choice = strings.TrimSpace(choice)
+	if choice == "" {
+		return epic.App{}, false
+	}
+	if n, err := strconv.Atoi(choice); err == nil && n >= 1 && n <= len(apps) {
+		return apps[n-1], true
+	}
+	for _, app := range apps {
+		if strings.EqualFold(app.AppName, choice) {
+			return app, true
+		}
+	}
+	fmt.Printf("⚠️  '%s' is not one of the listed games.\n", choice)
+	return epic.App{}, false
+}
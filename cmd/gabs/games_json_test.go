@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func TestExtractJSONFlagAfterAction(t *testing.T) {
+	args, found := extractJSONFlag([]string{"show", "factory", "--json"})
+	if !found || !reflect.DeepEqual(args, []string{"show", "factory"}) {
+		t.Fatalf("extractJSONFlag() = %v, %v", args, found)
+	}
+	if _, found := extractJSONFlag([]string{"list"}); found {
+		t.Fatal("expected no --json flag")
+	}
+}
+
+func TestGamesJSONOutput(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeGamesJSON(&out, nil); err != nil || out.String() != "[]\n" {
+		t.Fatalf("expected an empty array for no games, got %q (%v)", out.String(), err)
+	}
+
+	factory := config.GameConfig{ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/opt/factory/start.sh", Args: []string{"--headless"}}
+	out.Reset()
+	if err := writeGamesJSON(&out, []config.GameConfig{factory}); err != nil {
+		t.Fatalf("writeGamesJSON failed: %v", err)
+	}
+	var games []config.GameConfig
+	if err := json.Unmarshal(out.Bytes(), &games); err != nil || len(games) != 1 || !reflect.DeepEqual(games[0], factory) {
+		t.Fatalf("expected the full game config, got %s (%v)", out.String(), err)
+	}
+
+	invalid := config.GameConfig{ID: "adventure", Name: "Adventure", LaunchMode: "EpicAppId", Target: "epic-app"}
+	for _, tt := range []struct {
+		game  config.GameConfig
+		valid bool
+	}{{factory, true}, {invalid, false}} {
+		out.Reset()
+		if err := writeGameJSON(&out, tt.game); err != nil {
+			t.Fatalf("writeGameJSON failed: %v", err)
+		}
+		var shown gameShowJSON
+		if err := json.Unmarshal(out.Bytes(), &shown); err != nil {
+			t.Fatalf("show output is not JSON: %v", err)
+		}
+		if shown.Game.ID != tt.game.ID || shown.Validation.Valid != tt.valid || (shown.Validation.Error == "") != tt.valid {
+			t.Fatalf("unexpected show output for %s: %s", tt.game.ID, out.String())
+		}
+	}
+}
@@ -9,19 +9,29 @@ package main
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/pardeike/gabs/internal/chaos"
 	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/control"
+	"github.com/pardeike/gabs/internal/epic"
+	"github.com/pardeike/gabs/internal/gabp"
 	"github.com/pardeike/gabs/internal/mcp"
+	"github.com/pardeike/gabs/internal/process"
+	"github.com/pardeike/gabs/internal/remote"
 	"github.com/pardeike/gabs/internal/steam"
+	"github.com/pardeike/gabs/internal/update"
 	"github.com/pardeike/gabs/internal/util"
 	"github.com/pardeike/gabs/internal/version"
 )
@@ -36,13 +46,54 @@ type options struct {
 	httpAddr  string // address for HTTP mode
 
 	// Config + runtime
-	configDir  string
-	logLevel   string
-	backoffMin time.Duration
-	backoffMax time.Duration
+	configDir       string
+	logLevel        string
+	logFormat       string
+	logFile         string
+	logFileMaxMB    int
+	backoffMin      time.Duration
+	backoffMax      time.Duration
+	recentServerLog *util.LogBuffer // Served as gabs://logs/server
 
 	// Policy
-	graceStop time.Duration
+	graceStop          time.Duration
+	strictMCP          bool
+	watchConfig        bool
+	maxConcurrentCalls int
+	toolTimeout        time.Duration
+	apiKeyFile         string
+	apiKey             string // From GABS_API_KEY, replaces apiKey in config.json
+	shutdownPolicy     string
+	shutdownTimeout    time.Duration
+
+	// Self-update
+	updateChannel   string
+	updateCheckOnly bool
+
+	// Client configuration printing instead of serving
+	printClientConfig bool
+	clientConfigID    string
+	clientConfigFlags []string // Server flags the client's launch command repeats
+
+	// Agent
+	agentTokenFile string
+
+	// Background service
+	serviceAction string   // install, uninstall, status or print
+	serviceName   string   // Service to install, or to answer as on Windows
+	serviceSystem bool     // Install for the whole machine
+	serviceFlags  []string // Server flags the service's command line repeats
+
+	// CLI output
+	verbosity  verbosity
+	jsonOutput bool
+
+	// Developer-only failure injection
+	chaos chaos.Config
+
+	// Developer-only GABP session recording and replay
+	gabpRecord string
+	gabpReplay string
 }
 
 func main() {
@@ -62,6 +113,7 @@ func main() {
 	var remainingArgs []string
 	var transport string
 	var httpAddr string
+	var serviceAction string
 
 	// Handle server subcommands
 	if subcmd == "server" {
@@ -76,10 +128,13 @@ func main() {
 				remainingArgs = os.Args[2:] // Skip only "server"
 			}
 		} else {
-			// Default to stdio when no transport specified
-			transport = "stdio"
+			// Flags or GABS_HTTP_ADDR decide, defaulting to stdio
+			transport = ""
 			remainingArgs = os.Args[2:]
 		}
+	} else if subcmd == "service" && len(os.Args) >= 3 && !strings.HasPrefix(os.Args[2], "-") {
+		serviceAction = os.Args[2]
+		remainingArgs = os.Args[3:]
 	} else {
 		remainingArgs = os.Args[2:]
 	}
@@ -92,13 +147,40 @@ func main() {
 		httpAddrNew  = fs.String("addr", "localhost:8080", "HTTP server address (for 'gabs server http' command)")
 		configDir    = fs.String("configDir", "", "Override GABS config directory")
 		logLevel     = fs.String("log-level", "info", "Log level: trace|debug|info|warn|error")
+		logFormat    = fs.String("log-format", util.LogFormatConsole, "Log format: console|json")
+		logFile      = fs.String("log-file", "", "Also write the log to this file, rotated by size")
+		logFileMaxMB = fs.Int("log-file-max-size", util.DefaultLogFileMaxBytes>>20, "Size in MB at which --log-file is rotated")
 		backoff      = fs.String("reconnectBackoff", defaultBackoff, "Reconnect backoff window, e.g. '100ms..1s'")
 		grace        = fs.Duration("grace", 3*time.Second, "Graceful stop timeout before kill")
+		strictMCP    = fs.Bool("strict-mcp", false, "Reject MCP messages that break the protocol instead of tolerating them")
+		maxCalls     = fs.Int("max-concurrent-calls", mcp.DefaultMaxConcurrentCalls, "How many MCP tool calls run at once")
+		toolTimeout  = fs.Duration("tool-timeout", 0, "Answer MCP tool calls that take longer with a timeout error (0 = no limit)")
+		apiKeyFile   = fs.String("api-key-file", "", "JSON file with further HTTP API keys, shaped like apiKeys in config.json")
+		watchConfig  = fs.Bool("watch-config", true, "Reload game definitions when config.json changes")
+		shutdownPol  = fs.String("shutdown-policy", config.ShutdownPolicyLeaveRunning, "What happens to running games without a shutdownPolicy when GABS exits: "+strings.Join(config.ShutdownPolicies, "|"))
+		shutdownWait = fs.Duration("shutdown-timeout", 30*time.Second, "How long GABS waits for tool calls and game stops when it exits")
+		printClient  = fs.Bool("print-client-config", false, "Print MCP client configuration for these server options and exit")
+		channel      = fs.String("channel", update.ChannelStable, "Release channel for 'gabs update': "+strings.Join(update.Channels, "|"))
+		checkOnly    = fs.Bool("check", false, "Only report whether 'gabs update' would install a newer release")
+		clientID     = fs.String("client", "", "Client for --print-client-config: "+strings.Join(mcpClientIDs(), "|")+" (default: all)")
+		serviceName  = fs.String("service-name", "", "Name for 'gabs service' (default gabs); on a server, answer the Windows service manager as this service")
+		system       = fs.Bool("system", false, "Install 'gabs service' for the whole machine instead of the current user")
+		tokenFile    = fs.String("token-file", "", "File holding the token 'gabs agent' requires (default: $GABS_AGENT_TOKEN)")
+		quiet        = fs.Bool("quiet", false, "Suppress progress output for long CLI operations")
+		verbose      = fs.Bool("verbose", false, "Print detailed progress for long CLI operations")
+		jsonOutput   = fs.Bool("json", false, "Print games list and show output as JSON")
+		chaosSpec    = fs.String("chaos", "", "Developer only: inject failures, e.g. 'disconnect=30s,delay=2s,portfail=0.2,kill=5m'")
+		gabpRecord   = fs.String("gabp-record", "", "Developer only: record every GABP session to this file")
+		gabpReplay   = fs.String("gabp-replay", "", "Developer only: serve the GABP sessions recorded in this file instead of running games")
 	)
 
 	if err := fs.Parse(remainingArgs); err != nil {
 		os.Exit(2)
 	}
+	if err := applyEnvFlags(fs, os.LookupEnv); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
 
 	// Determine final transport and httpAddr
 	if subcmd == "server" {
@@ -107,36 +189,108 @@ func main() {
 			if *httpAddrFlag != "" {
 				transport = "http"
 				httpAddr = *httpAddrFlag
+			} else if envSet(httpAddrEnv) {
+				transport = "http"
+				httpAddr = *httpAddrNew
 			} else {
 				transport = "stdio"
 			}
 		} else if transport == "http" {
 			httpAddr = *httpAddrNew
 		}
+	} else if subcmd == "agent" || subcmd == "service" {
+		httpAddr = *httpAddrNew
+	}
+
+	level, err := parseVerbosity(*quiet, *verbose)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+
+	chaosConfig, err := chaos.ParseSpec(*chaosSpec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid --chaos: %v\n", err)
+		os.Exit(2)
+	}
+
+	if err := config.ValidateShutdownPolicy(*shutdownPol); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid --shutdown-policy or GABS_SHUTDOWN_POLICY: %v\n", err)
+		os.Exit(2)
 	}
 
 	min, max, err := parseBackoff(*backoff)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "invalid --reconnectBackoff: %v\n", err)
+		fmt.Fprintf(os.Stderr, "invalid --reconnectBackoff or GABS_BACKOFF: %v\n", err)
 		os.Exit(2)
 	}
 
 	opts := options{
-		subcmd:     subcmd,
-		transport:  transport,
-		httpAddr:   httpAddr,
-		configDir:  *configDir,
-		logLevel:   *logLevel,
-		backoffMin: min,
-		backoffMax: max,
-		graceStop:  *grace,
+		subcmd:             subcmd,
+		transport:          transport,
+		httpAddr:           httpAddr,
+		configDir:          *configDir,
+		logLevel:           *logLevel,
+		logFormat:          *logFormat,
+		logFile:            *logFile,
+		logFileMaxMB:       *logFileMaxMB,
+		backoffMin:         min,
+		backoffMax:         max,
+		graceStop:          *grace,
+		strictMCP:          *strictMCP,
+		watchConfig:        *watchConfig,
+		maxConcurrentCalls: *maxCalls,
+		toolTimeout:        *toolTimeout,
+		apiKeyFile:         *apiKeyFile,
+		apiKey:             strings.TrimSpace(os.Getenv(apiKeyEnv)),
+		shutdownPolicy:     *shutdownPol,
+		shutdownTimeout:    *shutdownWait,
+		printClientConfig:  *printClient,
+		clientConfigID:     *clientID,
+		updateChannel:      *channel,
+		updateCheckOnly:    *checkOnly,
+		agentTokenFile:     *tokenFile,
+		serviceAction:      serviceAction,
+		serviceName:        *serviceName,
+		serviceSystem:      *system,
+		verbosity:          level,
+		jsonOutput:         *jsonOutput,
+		chaos:              chaosConfig,
+		gabpRecord:         *gabpRecord,
+		gabpReplay:         *gabpReplay,
+	}
+
+	if opts.printClientConfig {
+		set := make(map[string]string)
+		fs.Visit(func(f *flag.Flag) { set[f.Name] = f.Value.String() })
+		opts.clientConfigFlags = clientConfigFlags(set)
+	}
+	if subcmd == "service" {
+		set := make(map[string]string)
+		fs.Visit(func(f *flag.Flag) { set[f.Name] = f.Value.String() })
+		opts.serviceFlags = forwardedFlags(set, serviceExcludedFlags)
+	}
+
+	// Initialize structured logger to stderr only, plus the log file and the
+	// recent entries the server serves over MCP
+	logOptions := util.LogOptions{
+		Level:        opts.logLevel,
+		Format:       opts.logFormat,
+		File:         opts.logFile,
+		MaxFileBytes: int64(opts.logFileMaxMB) << 20,
+	}
+	if subcmd == "server" {
+		opts.recentServerLog = util.NewLogBuffer(util.DefaultRecentLogLines)
+		logOptions.Recent = opts.recentServerLog
+	}
+	log, err := util.NewLoggerWithOptions(logOptions)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid logging options: %v\n", err)
+		os.Exit(2)
 	}
 
-	// Initialize structured logger to stderr only
-	log := util.NewLogger(opts.logLevel)
-
-	// Suppress startup log for "games" and "version" commands to keep output clean for terminal usage
-	if subcmd != "games" && subcmd != "version" {
+	// Only log startup for the servers; CLI commands keep their output clean for terminal usage
+	if (subcmd == "server" && !opts.printClientConfig) || subcmd == "agent" {
 		log.Infow("starting gabs", "version", version.Get(), "commit", version.GetCommit(), "built", version.GetBuildDate(), "subcmd", subcmd)
 	}
 
@@ -147,9 +301,31 @@ func main() {
 	var exitCode int
 	switch subcmd {
 	case "server":
+		if opts.printClientConfig {
+			exitCode = printClientConfig(os.Stdout, opts, opts.clientConfigID)
+			break
+		}
+		if opts.serviceName != "" {
+			exitCode = runAsService(ctx, opts, func(ctx context.Context) int { return runServer(ctx, log, opts) })
+			break
+		}
 		exitCode = runServer(ctx, log, opts)
+	case "agent":
+		exitCode = runAgent(ctx, log, opts)
 	case "games":
 		exitCode = manageGames(ctx, log, opts, fs.Args())
+	case "status":
+		exitCode = controlStatus(opts, fs.Args())
+	case "watch":
+		exitCode = controlWatch(ctx, opts, fs.Args())
+	case "control":
+		exitCode = controlTool(opts, fs.Args())
+	case "service":
+		exitCode = manageService(opts)
+	case "simulate":
+		exitCode = runSimulate(ctx, log, opts, fs.Args())
+	case "update":
+		exitCode = runUpdate(ctx, opts)
 	case "version":
 		fmt.Printf("%s %s (%s)\n", "gabs", version.Get(), version.GetCommit())
 		return
@@ -175,7 +351,14 @@ Subcommands:
   server stdio     Start the GABS MCP server on stdio (default)
   server http      Start the GABS MCP server on HTTP
   server           Start the GABS MCP server (stdio)
+  agent            Let a GABS server on another machine control this machine's games
   games            Manage game configurations
+  status [id]      Show game status from running GABS servers
+  watch [id]       Follow game status changes from running GABS servers
+  control <tool>   Start, stop or check a game through a running GABS server
+  simulate <id>    Serve a scenario file as the game's GABP bridge, for tests without the game
+  service <action> Install, uninstall, check or print GABS as a background service
+  update           Install the newest GABS release in place of this binary
   version          Print version information
 
 Server flags:
@@ -184,15 +367,57 @@ Server flags:
   --configDir <dir>             Override GABS config directory  
   --reconnectBackoff <min..max> Reconnect backoff window (default %s)
   --log-level <lvl>             trace|debug|info|warn|error
+  --log-format <fmt>            console|json (default console)
+  --log-file <file>             Also write the log to a file, rotated by size
+  --log-file-max-size <mb>      Size at which --log-file is rotated (default 10)
   --grace <dur>                 Graceful stop timeout (default 3s)
+  --strict-mcp                  Enforce strict MCP protocol compliance
+  --max-concurrent-calls <n>    MCP tool calls that run at once (default 8)
+  --tool-timeout <dur>          Time limit for each MCP tool call (default: none)
+  --api-key-file <file>         Read further HTTP API keys from a JSON file
+  --watch-config=false          Do not reload game definitions when config.json changes
+  --shutdown-policy <policy>    leave-running|graceful-stop|kill for games on exit (default leave-running)
+  --shutdown-timeout <dur>      Time to finish tool calls and stop games on exit (default 30s)
+  --print-client-config         Print MCP client configuration for these options and exit
+  --client <client>             claude-desktop|cursor|vscode|codex for --print-client-config (default: all)
+
+Agent flags:
+  --addr <addr>                 Agent listen address (default: localhost:8080)
+  --token-file <file>           File holding the agent token (default: $GABS_AGENT_TOKEN)
+
+Service flags (gabs service install|uninstall|status|print [server flags]):
+  --service-name <name>         Service name (default gabs)
+  --system                      Install for the whole machine (needs root or Administrator)
+  --addr <addr>                 HTTP address the service serves MCP on (default: localhost:8080)
+  --log-file <file>             Service log (default <configDir>/logs/<name>.log)
+
+Update flags:
+  --channel <channel>           stable|beta; beta also takes prereleases (default stable)
+  --check                       Only report whether a newer release is available
+
+Output flags:
+  --quiet                       Suppress progress output for long operations
+  --verbose                     Print detailed progress for long operations
+  --json                        Print games list and show output as JSON
+
+Developer flags:
+  --chaos <spec>                Inject failures to test recovery, e.g.
+                                disconnect=30s,delay=2s,portfail=0.2,kill=5m,seed=42
+  --gabp-record <file>          Record every GABP session to a file
+  --gabp-replay <file>          Serve recorded GABP sessions instead of running games
 
 Game management:
-  gabs games list               List configured game IDs (simplified output)
+  gabs games list               List configured game IDs (simplified output, --json for full configs)
   gabs games add <id>           Add a new game configuration (interactive)
+  gabs games edit <id> [flags]  Change a game configuration (interactive without flags)
   gabs games remove <id>        Remove a game configuration
-  gabs games show <id>          Show details for a game
+  gabs games show <id>          Show details for a game (--json adds validation)
   gabs games doctor <id>        Diagnose one game configuration
   gabs games repair <id>        Apply safe repairs for one game configuration
+  gabs games export [file]      Export all game definitions as JSON
+  gabs games import <file>      Import game definitions (--mode skip|overwrite|merge)
+  gabs games schedule <id> [op] List, add or remove timed starts, stops and restarts
+  gabs games reload             Make running servers re-read the configuration
 
 Examples:
   # Start GABS MCP server (stdio)
@@ -205,6 +430,10 @@ Examples:
   
   # Legacy flag syntax
   gabs server --http localhost:8080
+
+  # Print config snippets for MCP clients, or one client's ready to save
+  gabs server --print-client-config
+  gabs server http --addr localhost:8080 --print-client-config --client cursor
   
   # Add a new game configuration
   gabs games add factory
@@ -212,9 +441,33 @@ Examples:
   # List configured games (shows only game IDs)
   gabs games list
 
+  # Start a game through the server an AI client is using
+  gabs control games.start factory
+  gabs control games.stop factory --pid 4242
+
+  # Keep GABS running in the background on a headless game server
+  gabs service install --addr 0.0.0.0:8080 --configDir /srv/gabs --system
+
+  # Pretend to be the game for MCP and agent tests
+  gabs simulate factory --scenario factory.yaml
+
+  # Update to the newest beta, after checking what it would install
+  gabs update --channel beta --check
+  gabs update --channel beta
+
+  # Let another machine control this machine's games
+  GABS_AGENT_TOKEN=secret gabs agent --addr localhost:7777
+
+Environment:
+  GABS_CONFIG_DIR, GABS_HTTP_ADDR, GABS_LOG_LEVEL, GABS_LOG_FORMAT, GABS_LOG_FILE,
+  GABS_BACKOFF, GABS_TOOL_TIMEOUT, GABS_SHUTDOWN_POLICY and other GABS_<FLAG>
+  variables stand in for flags that are not given. GABS_HTTP_ADDR makes
+  'gabs server' serve HTTP. GABS_API_KEY replaces "apiKey" in config.json.
+
 API Key Configuration:
   Add "apiKey": "your-secret-key" to your GABS config file to enable
   HTTP authentication. Clients must include: Authorization: Bearer your-secret-key
+  Keys in "apiKeys" or --api-key-file can carry a name, role and rateLimit.
 
 Once the server is running, use MCP tools to manage games:
   games.list        List configured game IDs (simplified for AI)
@@ -235,45 +488,139 @@ func runServer(ctx context.Context, log util.Logger, opts options) int {
 		return 1
 	}
 
+	if from, backup := gamesConfig.MigratedFrom(); from != "" {
+		log.Infow("migrated games configuration", "from", from, "to", config.CurrentConfigVersion, "backup", backup)
+	}
+
 	log.Debugw("starting per-session GABS server", "transport", opts.transport, "configDir", opts.configDir)
 	log.Infow("loaded games configuration", "gameCount", len(gamesConfig.Games))
 
 	// Create MCP server with game management tools
 	server := mcp.NewServer(log)
 	server.SetConfigDir(opts.configDir)
+	if opts.recentServerLog != nil {
+		server.SetServerLogBuffer(opts.recentServerLog)
+	}
+	server.SetStrictMCP(opts.strictMCP)
+	server.SetToolCallLimits(opts.maxConcurrentCalls, opts.toolTimeout)
+	server.SetShutdownPolicy(opts.shutdownPolicy)
 
 	// Set API key for HTTP authentication if configured
-	if gamesConfig.APIKey != "" {
+	if opts.apiKey != "" {
+		server.SetAPIKey(opts.apiKey)
+		log.Infow("API key authentication enabled for HTTP server", "source", apiKeyEnv)
+	} else if gamesConfig.APIKey != "" {
 		server.SetAPIKey(gamesConfig.APIKey)
 		log.Infow("API key authentication enabled for HTTP server")
 	}
+	if opts.apiKeyFile != "" {
+		keys, err := config.LoadAPIKeyFile(opts.apiKeyFile)
+		if err != nil {
+			log.Errorw("failed to load API key file", "error", err)
+			return 1
+		}
+		server.AddAPIKeys(keys)
+		log.Infow("loaded HTTP API keys", "file", opts.apiKeyFile, "keyCount", len(keys))
+	}
 
 	// Register game management tools
 	server.RegisterGameManagementTools(gamesConfig, opts.backoffMin, opts.backoffMax)
+	defer server.CloseTunnels()
+
+	if opts.chaos.Enabled() {
+		defer server.EnableChaos(chaos.NewInjector(opts.chaos))()
+		go server.RunChaos(ctx)
+	}
 
-	// Start serving MCP according to transport
+	if opts.gabpRecord != "" {
+		recorder, err := gabp.NewRecorder(opts.gabpRecord)
+		if err != nil {
+			log.Errorw("failed to start GABP recording", "error", err)
+			return 1
+		}
+		defer func() {
+			if err := recorder.Close(); err != nil {
+				log.Warnw("GABP recording incomplete", "file", opts.gabpRecord, "error", err)
+			}
+		}()
+		server.SetGABPRecorder(recorder)
+	}
+	if opts.gabpReplay != "" {
+		recording, err := gabp.LoadRecording(opts.gabpReplay)
+		if err != nil {
+			log.Errorw("failed to load GABP recording", "error", err)
+			return 1
+		}
+		go server.ReplayGABP(ctx, recording)
+	}
+
+	if opts.watchConfig {
+		go server.WatchConfig(ctx, mcp.DefaultConfigWatchInterval)
+	}
+
+	// Fire the start, stop and restart schedules of configured games
+	go server.RunSchedules(ctx, opts.backoffMin, opts.backoffMax)
+
+	// Stop games that sat idle for longer than their idleTimeout
+	go server.RunIdleStops(ctx)
+
+	// Replace bridge tokens older than timeouts.bridge.tokenTtlSeconds
+	go server.RunTokenRotation(ctx)
+
+	// Expose the local control socket for 'gabs status', 'gabs watch' and 'gabs games reload'
+	if listener, socketPath, err := control.Listen(opts.configDir); err != nil {
+		log.Warnw("control socket unavailable", "error", err)
+	} else {
+		defer os.Remove(socketPath)
+		log.Debugw("control socket listening", "path", socketPath)
+		go func() {
+			if err := control.Serve(ctx, listener, server, log); err != nil {
+				log.Warnw("control socket stopped", "error", err)
+			}
+		}()
+	}
+
+	// Start serving MCP according to transport. The transport outlives the
+	// signal until Shutdown is done, so running tool calls can answer and
+	// final notifications reach the clients.
+	serveCtx, stopServing := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopServing()
 	errCh := make(chan error, 1)
 	go func() {
 		if opts.transport == "stdio" || (opts.transport == "" && opts.httpAddr == "") {
 			log.Infow("starting MCP server", "transport", "stdio")
-			errCh <- server.ServeStdio(ctx)
+			errCh <- server.ServeStdio(serveCtx)
 		} else {
 			log.Infow("starting MCP server", "transport", "http", "addr", opts.httpAddr)
-			errCh <- server.ServeHTTP(ctx, opts.httpAddr)
+			errCh <- server.ServeHTTP(serveCtx, opts.httpAddr)
 		}
 	}()
 
+	exitCode := 0
+	served := false
 	select {
 	case <-ctx.Done():
 		log.Infow("shutdown signal received")
-		return 0
 	case err := <-errCh:
+		served = true
 		if err != nil {
 			log.Errorw("server exited with error", "error", err)
-			return 1
+			exitCode = 1
 		}
-		return 0
 	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), opts.shutdownTimeout)
+	defer cancel()
+	server.Shutdown(shutdownCtx)
+	stopServing()
+	if !served {
+		// Let HTTP close its streams; stdio may be blocked reading stdin
+		select {
+		case <-errCh:
+		case <-time.After(time.Second):
+		}
+	}
+	return exitCode
 }
 
 // === Games Configuration Management ===
@@ -286,16 +633,28 @@ func manageGames(ctx context.Context, log util.Logger, opts options, args []stri
 	}
 
 	action := args[0]
+	args, jsonAfterAction := extractJSONFlag(args)
+	jsonOutput := opts.jsonOutput || jsonAfterAction
+	if jsonOutput && action != "list" && action != "show" {
+		fmt.Fprintf(os.Stderr, "--json is only supported by games list and games show\n")
+		return 2
+	}
 
 	switch action {
 	case "list":
-		return listGames(log, opts.configDir)
+		return listGames(log, opts.configDir, jsonOutput)
 	case "add":
 		if len(args) < 2 {
 			fmt.Fprintf(os.Stderr, "games add requires a game ID\n")
 			return 2
 		}
-		return addGame(log, args[1], opts.configDir)
+		return addGame(log, args[1], opts.configDir, newStderrProgress(opts.verbosity))
+	case "edit":
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "games edit requires a game ID\n")
+			return 2
+		}
+		return editGame(log, args[1], opts.configDir, args[2:])
 	case "remove":
 		if len(args) < 2 {
 			fmt.Fprintf(os.Stderr, "games remove requires a game ID\n")
@@ -307,26 +666,91 @@ func manageGames(ctx context.Context, log util.Logger, opts options, args []stri
 			fmt.Fprintf(os.Stderr, "games show requires a game ID\n")
 			return 2
 		}
-		return showGame(log, args[1], opts.configDir)
+		return showGame(log, args[1], opts.configDir, jsonOutput)
 	case "doctor":
 		if len(args) < 2 {
 			fmt.Fprintf(os.Stderr, "games doctor requires a game ID\n")
 			return 2
 		}
-		return doctorGame(log, args[1], opts.configDir)
+		return doctorGame(log, args[1], opts.configDir, newStderrProgress(opts.verbosity))
 	case "repair":
 		if len(args) < 2 {
 			fmt.Fprintf(os.Stderr, "games repair requires a game ID\n")
 			return 2
 		}
-		return repairGame(log, args[1], opts.configDir)
+		return repairGame(log, args[1], opts.configDir, newStderrProgress(opts.verbosity))
+	case "export":
+		path := ""
+		if len(args) > 1 {
+			path = args[1]
+		}
+		return exportGames(log, opts.configDir, path)
+	case "import":
+		return importGames(log, opts.configDir, args[1:])
+	case "schedule":
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "games schedule requires a game ID\n")
+			return 2
+		}
+		return scheduleGame(log, args[1], opts.configDir, args[2:])
+	case "reload":
+		return controlReload(opts)
 	default:
 		fmt.Fprintf(os.Stderr, "unknown games action: %s\n", action)
 		return 2
 	}
 }
 
-func listGames(log util.Logger, configDir string) int {
+// extractJSONFlag removes --json given after the games action, as in
+// 'gabs games list --json', and reports whether it was present.
+func extractJSONFlag(args []string) ([]string, bool) {
+	kept := make([]string, 0, len(args))
+	found := false
+	for _, arg := range args {
+		if arg == "--json" || arg == "-json" {
+			found = true
+			continue
+		}
+		kept = append(kept, arg)
+	}
+	return kept, found
+}
+
+// gameShowJSON is what 'gabs games show --json' prints.
+type gameShowJSON struct {
+	Game       config.GameConfig  `json:"game"`
+	Validation gameValidationJSON `json:"validation"`
+}
+
+type gameValidationJSON struct {
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeGamesJSON prints the full configuration of every game as a JSON array.
+func writeGamesJSON(w io.Writer, games []config.GameConfig) error {
+	if games == nil {
+		games = []config.GameConfig{}
+	}
+	return writeJSON(w, games)
+}
+
+// writeGameJSON prints one game's configuration with its validation result.
+func writeGameJSON(w io.Writer, game config.GameConfig) error {
+	result := gameShowJSON{Game: game, Validation: gameValidationJSON{Valid: true}}
+	if err := game.Validate(); err != nil {
+		result.Validation = gameValidationJSON{Error: err.Error()}
+	}
+	return writeJSON(w, result)
+}
+
+func listGames(log util.Logger, configDir string, jsonOutput bool) int {
 	gamesConfig, err := config.LoadGamesConfigFromDir(configDir)
 	if err != nil {
 		log.Errorw("failed to load games config", "error", err)
@@ -334,6 +758,13 @@ func listGames(log util.Logger, configDir string) int {
 	}
 
 	games := gamesConfig.ListGames()
+	if jsonOutput {
+		if err := writeGamesJSON(os.Stdout, games); err != nil {
+			log.Errorw("failed to write games", "error", err)
+			return 1
+		}
+		return 0
+	}
 	if len(games) == 0 {
 		fmt.Println("No games configured. Use 'gabs games add <id>' to add games.")
 		return 0
@@ -345,7 +776,7 @@ func listGames(log util.Logger, configDir string) int {
 	return 0
 }
 
-func addGame(log util.Logger, gameID string, configDir string) int {
+func addGame(log util.Logger, gameID string, configDir string, p *progress) int {
 	gamesConfig, err := config.LoadGamesConfigFromDir(configDir)
 	if err != nil {
 		log.Errorw("failed to load games config", "error", err)
@@ -354,7 +785,7 @@ func addGame(log util.Logger, gameID string, configDir string) int {
 
 	// Check if game already exists
 	if _, exists := gamesConfig.GetGame(gameID); exists {
-		fmt.Printf("Game '%s' already exists. Use 'gabs games show %s' to view it.\n", gameID, gameID)
+		fmt.Printf("Game '%s' already exists. Use 'gabs games show %s' to view it or 'gabs games edit %s' to change it.\n", gameID, gameID, gameID)
 		return 1
 	}
 
@@ -376,7 +807,7 @@ func addGame(log util.Logger, gameID string, configDir string) int {
 			return 1
 		}
 
-		fmt.Printf("Game '%s' added with minimal configuration. Configure it manually or edit the config file.\n", gameID)
+		fmt.Printf("Game '%s' added with minimal configuration. Set it up with 'gabs games edit %s --target <path>'.\n", gameID, gameID)
 		return 0
 	}
 
@@ -385,11 +816,20 @@ func addGame(log util.Logger, gameID string, configDir string) int {
 	game := config.GameConfig{
 		ID:         gameID,
 		Name:       promptString("Game Name", gameID),
-		LaunchMode: promptChoice("Launch Mode", "DirectPath", []string{"DirectPath", "SteamManaged", "SteamAppId", "EpicAppId", "CustomCommand"}),
+		LaunchMode: promptChoice("Launch Mode", "DirectPath", config.LaunchModes),
+	}
+
+	// Offer the installed Epic titles so the AppName does not have to be
+	// looked up by hand
+	var epicApp epic.App
+	var haveEpicApp bool
+	if game.LaunchMode == "EpicAppId" {
+		epicApp, haveEpicApp = chooseEpicApp()
 	}
 
 	// Enhance target prompt for DirectPath mode with platform-specific help
 	var targetPrompt string
+	var targetDefault string
 	switch game.LaunchMode {
 	case "DirectPath":
 		if runtime.GOOS == "darwin" {
@@ -399,11 +839,24 @@ func addGame(log util.Logger, gameID string, configDir string) int {
 		}
 	case "SteamManaged", "SteamAppId":
 		targetPrompt = "Target (Steam App ID)"
+	case "EpicAppId":
+		targetPrompt = "Target (Epic AppName)"
+		targetDefault = epicApp.AppName
+	case "GOGGameId":
+		targetPrompt = "Target (GOG game ID)"
+	case "XboxAppId":
+		targetPrompt = "Target (Xbox app ID, e.g. Publisher.Game_8wekyb3d8bbwe!App)"
+	case "Flatpak":
+		targetPrompt = "Target (Flatpak app ID)"
+	case "Proton":
+		targetPrompt = "Target (Windows executable path)"
+	case "Remote":
+		targetPrompt = "Target (game ID on the agent)"
 	default:
 		targetPrompt = "Target (path/id)"
 	}
 
-	game.Target = promptString(targetPrompt, "")
+	game.Target = promptString(targetPrompt, targetDefault)
 
 	// For DirectPath on macOS, resolve .app bundles to actual executables
 	if game.LaunchMode == "DirectPath" && game.Target != "" {
@@ -413,18 +866,69 @@ func addGame(log util.Logger, gameID string, configDir string) int {
 		}
 	}
 
-	if game.LaunchMode == "DirectPath" || game.LaunchMode == "SteamManaged" || game.LaunchMode == "CustomCommand" {
-		workingDir := promptString("Working Directory (optional)", "")
+	if game.LaunchMode == "Proton" {
+		game.Proton = &config.ProtonConfig{Path: promptString("Proton Path (Proton directory or its proton script)", "")}
+		if compatData := promptString("Proton Prefix Directory (optional)", ""); compatData != "" {
+			game.Proton.CompatDataPath = compatData
+		}
+	}
+
+	if game.LaunchMode == "Remote" {
+		game.Remote = &config.RemoteConfig{
+			URL:   promptString("Agent URL (e.g. http://localhost:7777)", ""),
+			Token: promptString("Agent Token", ""),
+		}
+	}
+
+	// For Steam games, look up the install so the remaining prompts can offer
+	// the resolved working directory and executable as defaults
+	var steamApp *steam.App
+	if (game.LaunchMode == "SteamManaged" || game.LaunchMode == "SteamAppId") && game.Target != "" {
+		if app, err := resolveSteamApp(p, game.Target); err == nil {
+			steamApp = &app
+		} else {
+			fmt.Printf("⚠️  Could not resolve Steam app %s: %v\n", game.Target, err)
+		}
+	}
+
+	if game.LaunchMode == "DirectPath" || game.LaunchMode == "SteamManaged" || game.LaunchMode == "Proton" || game.LaunchMode == "CustomCommand" {
+		defaultWorkingDir := ""
+		if steamApp != nil && game.LaunchMode == "SteamManaged" {
+			defaultWorkingDir = steamApp.WorkingDir
+		}
+		workingDir := promptString("Working Directory (optional)", defaultWorkingDir)
 		if workingDir != "" {
 			game.WorkingDir = workingDir
 		}
 	}
 
+	// Launch profiles are variants such as normal and hardcore that
+	// games_start can choose between
+	if names := strings.Fields(promptString("Launch Profiles (optional, space-separated names such as normal hardcore)", "")); len(names) > 0 {
+		for _, name := range names {
+			game = game.WithLaunchProfile(name, config.LaunchProfile{
+				Args:       strings.Fields(promptString(fmt.Sprintf("Arguments for profile %s (optional)", name), "")),
+				WorkingDir: promptString(fmt.Sprintf("Working Directory for profile %s (optional)", name), ""),
+			})
+		}
+		game.DefaultProfile = promptChoice("Default Profile", names[0], names)
+	}
+
 	// Ask for optional stop process name for better game termination control
-	// For launcher-based games (Steam/Epic), this is required
+	// For store launcher games, this is required
 	var stopProcessName string
-	if game.LaunchMode == "SteamAppId" || game.LaunchMode == "EpicAppId" {
-		stopProcessName = promptString(fmt.Sprintf("Stop Process Name (REQUIRED for %s games)", game.LaunchMode), "")
+	var stopProcessDefault string
+	if haveEpicApp && strings.EqualFold(game.Target, epicApp.AppName) {
+		if candidates := epicApp.StopProcessCandidates(); len(candidates) > 0 {
+			fmt.Printf("Process name candidates from the Epic install: %s\n", strings.Join(candidates, ", "))
+			stopProcessDefault = candidates[0]
+		}
+	}
+	if steamApp != nil && steamApp.Executable != "" {
+		stopProcessDefault = filepath.Base(steamApp.Executable)
+	}
+	if config.IsStoreLaunchMode(game.LaunchMode) {
+		stopProcessName = promptString(fmt.Sprintf("Stop Process Name (REQUIRED for %s games)", game.LaunchMode), stopProcessDefault)
 		for stopProcessName == "" {
 			fmt.Printf("⚠️  Stop Process Name is required for %s games to enable proper game termination.\n", game.LaunchMode)
 			fmt.Printf("   Without it, GABS can only stop the launcher process, not the actual game.\n")
@@ -432,7 +936,7 @@ func addGame(log util.Logger, gameID string, configDir string) int {
 			stopProcessName = promptString(fmt.Sprintf("Stop Process Name (REQUIRED for %s games)", game.LaunchMode), "")
 		}
 	} else {
-		stopProcessName = promptString("Stop Process Name (optional - for better game stopping)", "")
+		stopProcessName = promptString("Stop Process Name (optional - for better game stopping)", stopProcessDefault)
 	}
 	if stopProcessName != "" {
 		game.StopProcessName = stopProcessName
@@ -478,7 +982,7 @@ func removeGame(log util.Logger, gameID string, configDir string) int {
 	return 0
 }
 
-func showGame(log util.Logger, gameID string, configDir string) int {
+func showGame(log util.Logger, gameID string, configDir string, jsonOutput bool) int {
 	gamesConfig, err := config.LoadGamesConfigFromDir(configDir)
 	if err != nil {
 		log.Errorw("failed to load games config", "error", err)
@@ -487,10 +991,23 @@ func showGame(log util.Logger, gameID string, configDir string) int {
 
 	game, exists := gamesConfig.GetGame(gameID)
 	if !exists {
+		if jsonOutput {
+			// Keep stdout parseable; scripts check the exit code.
+			fmt.Fprintf(os.Stderr, "Game '%s' not found.\n", gameID)
+			return 1
+		}
 		fmt.Printf("Game '%s' not found.\n", gameID)
 		return 1
 	}
 
+	if jsonOutput {
+		if err := writeGameJSON(os.Stdout, *game); err != nil {
+			log.Errorw("failed to write game", "error", err)
+			return 1
+		}
+		return 0
+	}
+
 	fmt.Printf("Game Configuration: %s\n", game.ID)
 	fmt.Printf("  Name: %s\n", game.Name)
 	fmt.Printf("  Launch Mode: %s\n", game.LaunchMode)
@@ -499,7 +1016,7 @@ func showGame(log util.Logger, gameID string, configDir string) int {
 		fmt.Printf("  Working Directory: %s\n", game.WorkingDir)
 	}
 	if len(game.Args) > 0 {
-		fmt.Printf("  Arguments: %s\n", strings.Join(game.Args, " "))
+		fmt.Printf("  Arguments: %s\n", quoteArgs(game.Args))
 	}
 	if game.StopProcessName != "" {
 		fmt.Printf("  Stop Process Name: %s\n", game.StopProcessName)
@@ -507,14 +1024,39 @@ func showGame(log util.Logger, gameID string, configDir string) int {
 	if game.GABPMode != "" {
 		fmt.Printf("  GABP Mode: %s\n", game.GABPMode)
 	}
+	if game.BridgeMode != "" {
+		fmt.Printf("  Bridge Mode: %s\n", game.BridgeMode)
+	}
 	if game.Description != "" {
 		fmt.Printf("  Description: %s\n", game.Description)
 	}
+	if len(game.Env) > 0 {
+		names := make([]string, 0, len(game.Env))
+		for name := range game.Env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Printf("  Environment: %s\n", strings.Join(names, ", "))
+	}
+	if game.EnvFile != "" {
+		fmt.Printf("  Env File: %s\n", game.EnvFile)
+	}
+	for _, name := range game.ProfileNames() {
+		profile := game.Profiles[name]
+		marker := ""
+		if name == game.DefaultProfile {
+			marker = " (default)"
+		}
+		fmt.Printf("  Profile %s%s: %s\n", name, marker, strings.Join(profile.Args, " "))
+		if profile.WorkingDir != "" {
+			fmt.Printf("    Working Directory: %s\n", profile.WorkingDir)
+		}
+	}
 
 	return 0
 }
 
-func doctorGame(log util.Logger, gameID string, configDir string) int {
+func doctorGame(log util.Logger, gameID string, configDir string, p *progress) int {
 	gamesConfig, err := config.LoadGamesConfigFromDir(configDir)
 	if err != nil {
 		log.Errorw("failed to load games config", "error", err)
@@ -539,11 +1081,16 @@ func doctorGame(log util.Logger, gameID string, configDir string) int {
 		fmt.Println("Configuration: valid")
 	}
 
+	exitCode := 0
+	if !checkBridgeFile(game.ID, configDir) {
+		exitCode = 1
+	}
+
 	switch game.LaunchMode {
 	case "SteamAppId":
 		fmt.Println("Steam launch: launcher URL mode")
 		fmt.Println("Bridge environment: not guaranteed on the real game process")
-		app, err := steam.ResolveApp(game.Target)
+		app, err := resolveSteamApp(p, game.Target)
 		if err != nil {
 			fmt.Printf("Managed Steam readiness: failed (%v)\n", err)
 			return 1
@@ -552,7 +1099,7 @@ func doctorGame(log util.Logger, gameID string, configDir string) int {
 		fmt.Printf("Recommended repair: gabs games repair %s\n", game.ID)
 	case "SteamManaged":
 		fmt.Println("Steam launch: managed executable mode")
-		app, err := steam.ResolveApp(game.Target)
+		app, err := resolveSteamApp(p, game.Target)
 		if err != nil {
 			fmt.Printf("Managed Steam readiness: failed (%v)\n", err)
 			return 1
@@ -572,6 +1119,41 @@ func doctorGame(log util.Logger, gameID string, configDir string) int {
 			fmt.Printf("Steam app id file: wrong id %q at %s\n", content, app.AppIDFilePath)
 			return 1
 		}
+	case "Flatpak", "Proton":
+		launcher, _ := process.LauncherFor(game.LaunchMode)
+		if _, err := launcher.BuildCommand(process.LaunchSpec{GameId: game.ID, Mode: game.LaunchMode, PathOrId: game.Target, Proton: game.Proton}); err != nil {
+			fmt.Printf("%s readiness: failed (%v)\n", game.LaunchMode, err)
+			return 1
+		}
+		fmt.Printf("%s readiness: ok\n", game.LaunchMode)
+	case "Remote":
+		if game.Remote == nil {
+			return 1
+		}
+		fmt.Printf("Agent: %s\n", game.Remote.URL)
+		controller := remote.NewController(*game.Remote, nil)
+		if err := controller.Configure(process.LaunchSpec{GameId: game.ID, Mode: game.LaunchMode, PathOrId: game.Target}); err != nil {
+			fmt.Printf("Agent readiness: failed (%v)\n", err)
+			return 1
+		}
+		status, err := controller.Status()
+		if err != nil {
+			fmt.Printf("Agent readiness: failed (%v)\n", err)
+			return 1
+		}
+		fmt.Printf("Agent readiness: ok (game running: %t)\n", status.Running)
+		if game.SSHTunnel == nil {
+			fmt.Println("Bridge connection: no sshTunnel configured; the bridge port must be reachable from this machine")
+		}
+	case "GOGGameId", "XboxAppId":
+		launcher, _ := process.LauncherFor(game.LaunchMode)
+		store := launcher.StopHints().Store
+		fmt.Printf("%s launch: store client mode\n", store)
+		fmt.Println("Bridge environment: not guaranteed on the real game process")
+		if _, err := launcher.BuildCommand(process.LaunchSpec{GameId: game.ID, Mode: game.LaunchMode, PathOrId: game.Target}); err != nil {
+			fmt.Printf("%s readiness: failed (%v)\n", store, err)
+			return 1
+		}
 	default:
 		if game.Target != "" {
 			if _, err := os.Stat(game.Target); err != nil {
@@ -582,10 +1164,47 @@ func doctorGame(log util.Logger, gameID string, configDir string) int {
 		}
 	}
 
-	return 0
+	return exitCode
 }
 
-func repairGame(log util.Logger, gameID string, configDir string) int {
+// checkBridgeFile reports whether the game's bridge.json keeps its token
+// private and returns false when other users can read it.
+func checkBridgeFile(gameID string, configDir string) bool {
+	paths, err := config.NewConfigPaths(configDir)
+	if err != nil {
+		fmt.Printf("Bridge file: unknown (%v)\n", err)
+		return false
+	}
+	path := paths.GetBridgeConfigPath(gameID)
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		fmt.Println("Bridge file: not written yet")
+		return true
+	} else if err != nil {
+		fmt.Printf("Bridge file: unreadable (%v)\n", err)
+		return false
+	}
+
+	protection := "token in plain text"
+	if bridge, err := config.ReadBridgeEndpoint(gameID, configDir); err == nil && bridge.TokenEncrypted() {
+		protection = "token encrypted"
+	}
+	if runtime.GOOS == "windows" {
+		// Mode bits do not reflect Windows ACLs; the file inherits the
+		// profile folder's, which only the user can read
+		fmt.Printf("Bridge file: %s, %s\n", path, protection)
+		return true
+	}
+	if mode := info.Mode().Perm(); mode&0o044 != 0 {
+		fmt.Printf("Bridge file: readable by other users (mode %04o, %s): %s\n", mode, protection, path)
+		fmt.Printf("Recommended repair: chmod 600 %s, or start the game once to rewrite it\n", path)
+		return false
+	}
+	fmt.Printf("Bridge file: private, %s\n", protection)
+	return true
+}
+
+func repairGame(log util.Logger, gameID string, configDir string, p *progress) int {
 	gamesConfig, err := config.LoadGamesConfigFromDir(configDir)
 	if err != nil {
 		log.Errorw("failed to load games config", "error", err)
@@ -600,7 +1219,7 @@ func repairGame(log util.Logger, gameID string, configDir string) int {
 
 	switch game.LaunchMode {
 	case "SteamAppId", "SteamManaged":
-		app, err := steam.ResolveApp(game.Target)
+		app, err := resolveSteamApp(p, game.Target)
 		if err != nil {
 			fmt.Printf("Steam repair failed: %v\n", err)
 			return 1
@@ -611,7 +1230,10 @@ func repairGame(log util.Logger, gameID string, configDir string) int {
 		}
 		if game.LaunchMode == "SteamAppId" {
 			game.LaunchMode = "SteamManaged"
-			gamesConfig.Games[game.ID] = *game
+			if err := gamesConfig.AddGame(*game); err != nil {
+				log.Errorw("invalid game configuration", "error", err)
+				return 1
+			}
 			if err := backupGamesConfig(configDir); err != nil {
 				fmt.Printf("Failed to back up config: %v\n", err)
 				return 1
@@ -635,23 +1257,51 @@ func repairGame(log util.Logger, gameID string, configDir string) int {
 
 func showGamesUsage() {
 	fmt.Fprintf(os.Stderr, `Game Management Commands:
-  gabs games list               List configured game IDs (simplified output)
+  gabs games list               List configured game IDs (simplified output, --json for full configs)
   gabs games add <id>           Add a new game configuration (interactive)
+  gabs games edit <id> [flags]  Change a game configuration (interactive without flags)
   gabs games remove <id>        Remove a game configuration
-  gabs games show <id>          Show details for a game
+  gabs games show <id>          Show details for a game (--json adds validation)
   gabs games doctor <id>        Diagnose one game configuration
   gabs games repair <id>        Apply safe repairs for one game configuration
+  gabs games export [file]      Export all game definitions as JSON
+  gabs games import <file>      Import game definitions (--mode skip|overwrite|merge)
+  gabs games schedule <id> [op] List, add or remove timed starts, stops and restarts
+  gabs games reload             Make running servers re-read the configuration
 
 Examples:
   gabs games list               # See game IDs only (AI-friendly)
   gabs games add factory      # Add a new game called 'factory'
   gabs games show factory     # View configuration for 'factory'
+  gabs games show factory --json  # Configuration and validation as JSON
+  gabs games edit factory --target /opt/factory/start.sh --args "--headless"
+  gabs games edit factory --profile hardcore --args "--difficulty hard"  # Add or change a launch profile
   gabs games doctor factory   # Diagnose launch configuration
   gabs games repair factory   # Apply safe launch repairs
   gabs games remove factory   # Remove the 'factory' configuration
+  gabs games export games.json              # Save definitions for another machine
+  gabs games import games.json --mode merge # Load them, merging existing games
+  gabs games schedule factory add --action restart --cron "0 4 * * *"  # Restart nightly at 04:00
+  gabs games schedule factory remove restart  # Remove that schedule
 `)
 }
 
+// resolveSteamApp scans the Steam libraries for appID while reporting
+// progress, since library discovery can stall on slow or network drives.
+func resolveSteamApp(p *progress, appID string) (steam.App, error) {
+	p.Start(fmt.Sprintf("Scanning Steam libraries for app %s", appID))
+	app, err := steam.ResolveAppWithProgress(appID, func(checked, total int, library string) {
+		p.Update(checked, total)
+		p.Verbosef("checked %s", library)
+	})
+	if err != nil {
+		p.Fail("")
+		return app, err
+	}
+	p.Done(app.InstallPath)
+	return app, nil
+}
+
 func printSteamAppResolution(app steam.App) {
 	fmt.Printf("Steam app: %s", app.AppID)
 	if app.Name != "" {
@@ -823,6 +1473,19 @@ func resolveMacOSAppBundle(appPath string) (string, error) {
 	}
 }
 
+func parseVerbosity(quiet, verbose bool) (verbosity, error) {
+	switch {
+	case quiet && verbose:
+		return verbosityNormal, fmt.Errorf("--quiet and --verbose cannot be combined")
+	case quiet:
+		return verbosityQuiet, nil
+	case verbose:
+		return verbosityVerbose, nil
+	default:
+		return verbosityNormal, nil
+	}
+}
+
 func parseBackoff(s string) (time.Duration, time.Duration, error) {
 	// Parse "<min>..<max>" format
 	// Examples: "100ms..1s", "1s..30s", "250ms..inf"
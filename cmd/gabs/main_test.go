@@ -1,8 +1,15 @@
 package main
 
 import (
+	"flag"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/pardeike/gabs/internal/config"
 )
 
 func TestParseBackoffDefault(t *testing.T) {
@@ -17,3 +24,84 @@ func TestParseBackoffDefault(t *testing.T) {
 		t.Fatalf("expected max 1s, got %v", max)
 	}
 }
+
+func TestCheckBridgeFileFlagsWorldReadableFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows permissions are ACLs, not mode bits")
+	}
+	configDir := t.TempDir()
+	if !checkBridgeFile("factory", configDir) {
+		t.Fatal("a game without bridge.json has nothing to flag")
+	}
+
+	path, err := config.WriteBridgeJSONWithEndpoint("factory", configDir, 49234, "secret")
+	if err != nil {
+		t.Fatalf("failed to write bridge.json: %v", err)
+	}
+	if !checkBridgeFile("factory", configDir) {
+		t.Fatal("expected a freshly written bridge.json to be private")
+	}
+
+	if err := os.Chmod(path, 0644); err != nil {
+		t.Fatalf("failed to loosen bridge.json: %v", err)
+	}
+	if checkBridgeFile("factory", configDir) {
+		t.Fatal("expected a world-readable bridge.json to be flagged")
+	}
+}
+
+func TestParseControlToolArgs(t *testing.T) {
+	pid, args, err := parseControlToolArgs([]string{"factory", "--pid", "4242", "--restart", "--profile=hardcore", "--timeout", "30", "--wait=false"})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if pid != 4242 {
+		t.Fatalf("expected pid 4242, got %d", pid)
+	}
+	want := map[string]interface{}{"gameId": "factory", "restart": true, "profile": "hardcore", "timeout": int64(30), "wait": false}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("unexpected tool arguments: %#v", args)
+	}
+
+	if _, _, err := parseControlToolArgs([]string{"factory", "extra"}); err == nil {
+		t.Fatal("expected a second bare argument to be rejected")
+	}
+	if _, _, err := parseControlToolArgs([]string{"--pid", "abc"}); err == nil {
+		t.Fatal("expected a non-numeric --pid to be rejected")
+	}
+}
+
+func TestApplyEnvFlagsPrefersTheCommandLine(t *testing.T) {
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:8080", "")
+	level := fs.String("log-level", "info", "")
+	calls := fs.Int("max-concurrent-calls", 8, "")
+	grace := fs.Duration("grace", 3*time.Second, "")
+	if err := fs.Parse([]string{"--log-level=warn"}); err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	env := map[string]string{
+		"GABS_HTTP_ADDR":            "0.0.0.0:9000",
+		"GABS_LOG_LEVEL":            "debug",
+		"GABS_MAX_CONCURRENT_CALLS": "4",
+		"GABS_GRACE":                " ",
+	}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+	if err := applyEnvFlags(fs, lookup); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if *addr != "0.0.0.0:9000" || *level != "warn" || *calls != 4 || *grace != 3*time.Second {
+		t.Fatalf("unexpected flags addr=%s level=%s calls=%d grace=%s", *addr, *level, *calls, *grace)
+	}
+
+	env["GABS_MAX_CONCURRENT_CALLS"] = "many"
+	fs = flag.NewFlagSet("server", flag.ContinueOnError)
+	fs.Int("max-concurrent-calls", 8, "")
+	fs.Parse(nil)
+	if err := applyEnvFlags(fs, lookup); err == nil || !strings.Contains(err.Error(), "GABS_MAX_CONCURRENT_CALLS") {
+		t.Fatalf("expected the variable to be named in the error, got %v", err)
+	}
+}
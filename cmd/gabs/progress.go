@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// verbosity controls how much progress output CLI commands print. Results and
+// errors are always printed; only progress and detail lines are affected.
+type verbosity int
+
+const (
+	verbosityQuiet verbosity = iota
+	verbosityNormal
+	verbosityVerbose
+)
+
+const progressTickInterval = 100 * time.Millisecond
+
+type progressOutcome int
+
+const (
+	progressAbandoned progressOutcome = iota
+	progressSucceeded
+	progressFailed
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// progress renders the state of one long-running CLI step to stderr. On a
+// terminal it draws a spinner with an optional percentage on a single line;
+// otherwise it prints plain lines at the start, at each 25% step, and at the
+// end so logs and pipes stay readable.
+type progress struct {
+	out   io.Writer
+	tty   bool
+	level verbosity
+
+	mu          sync.Mutex
+	label       string
+	done        int
+	total       int
+	frame       int
+	lastBucket  int
+	active      bool
+	stopSpinner chan struct{}
+	spinnerDone chan struct{}
+}
+
+func newProgress(out io.Writer, tty bool, level verbosity) *progress {
+	return &progress{out: out, tty: tty, level: level}
+}
+
+func newStderrProgress(level verbosity) *progress {
+	return newProgress(os.Stderr, isTerminal(os.Stderr), level)
+}
+
+// Start begins a new step. Any previous step that was not finished is ended
+// silently.
+func (p *progress) Start(label string) {
+	p.finish(progressAbandoned, "")
+	if p.level == verbosityQuiet {
+		return
+	}
+
+	p.mu.Lock()
+	p.label = label
+	p.done, p.total, p.frame, p.lastBucket = 0, 0, 0, 0
+	p.active = true
+	if !p.tty {
+		fmt.Fprintf(p.out, "%s...\n", label)
+		p.mu.Unlock()
+		return
+	}
+	p.renderLocked()
+	p.stopSpinner = make(chan struct{})
+	p.spinnerDone = make(chan struct{})
+	go p.spin(p.stopSpinner, p.spinnerDone)
+	p.mu.Unlock()
+}
+
+// Update records how much of the step is complete. A total of zero keeps the
+// spinner without a percentage.
+func (p *progress) Update(done, total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.active {
+		return
+	}
+	p.done, p.total = done, total
+	if p.tty {
+		p.renderLocked()
+		return
+	}
+	if total <= 0 {
+		return
+	}
+	if bucket := done * 4 / total; bucket > p.lastBucket && done < total {
+		p.lastBucket = bucket
+		fmt.Fprintf(p.out, "%s: %d%%\n", p.label, done*100/total)
+	}
+}
+
+// Verbosef prints a detail line when --verbose is set.
+func (p *progress) Verbosef(format string, args ...interface{}) {
+	if p.level < verbosityVerbose {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.tty && p.active {
+		fmt.Fprint(p.out, "\r\033[K")
+	}
+	fmt.Fprintf(p.out, "  "+format+"\n", args...)
+	if p.tty && p.active {
+		p.renderLocked()
+	}
+}
+
+// Done ends the step successfully with an optional summary.
+func (p *progress) Done(summary string) {
+	p.finish(progressSucceeded, summary)
+}
+
+// Fail ends the step unsuccessfully with an optional summary. Callers still
+// report the underlying error themselves.
+func (p *progress) Fail(summary string) {
+	p.finish(progressFailed, summary)
+}
+
+func (p *progress) finish(outcome progressOutcome, summary string) {
+	p.mu.Lock()
+	if !p.active {
+		p.mu.Unlock()
+		return
+	}
+	p.active = false
+	stop, spinnerDone := p.stopSpinner, p.spinnerDone
+	p.stopSpinner, p.spinnerDone = nil, nil
+	p.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-spinnerDone
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.tty {
+		fmt.Fprint(p.out, "\r\033[K")
+	}
+	var line string
+	switch {
+	case outcome == progressAbandoned:
+		return
+	case p.tty && outcome == progressSucceeded:
+		line = "✓ " + p.label
+	case p.tty:
+		line = "✗ " + p.label
+	case outcome == progressSucceeded:
+		line = p.label + ": done"
+	default:
+		line = p.label + ": failed"
+	}
+	if summary != "" {
+		line += " (" + summary + ")"
+	}
+	fmt.Fprintln(p.out, line)
+}
+
+func (p *progress) spin(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(progressTickInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			p.mu.Lock()
+			p.frame++
+			p.renderLocked()
+			p.mu.Unlock()
+		}
+	}
+}
+
+func (p *progress) renderLocked() {
+	line := fmt.Sprintf("\r\033[K%s %s", spinnerFrames[p.frame%len(spinnerFrames)], p.label)
+	if p.total > 0 {
+		line += fmt.Sprintf(" %d%%", p.done*100/p.total)
+	}
+	fmt.Fprint(p.out, line)
+}
+
+// isTerminal reports whether f is attached to a character device such as a
+// terminal.
+func isTerminal(f *os.File) bool {
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return (fileInfo.Mode() & os.ModeCharDevice) != 0
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestProgressPlainOutputWithoutTerminal(t *testing.T) {
+	var out bytes.Buffer
+	p := newProgress(&out, false, verbosityNormal)
+
+	p.Start("Scanning")
+	for i := 1; i <= 8; i++ {
+		p.Update(i, 8)
+	}
+	p.Done("found")
+
+	want := "Scanning...\nScanning: 25%\nScanning: 50%\nScanning: 75%\nScanning: done (found)\n"
+	if out.String() != want {
+		t.Fatalf("unexpected output:\n%q\nwant:\n%q", out.String(), want)
+	}
+}
+
+func TestProgressQuietPrintsNothing(t *testing.T) {
+	var out bytes.Buffer
+	p := newProgress(&out, true, verbosityQuiet)
+
+	p.Start("Scanning")
+	p.Update(1, 2)
+	p.Verbosef("checked %s", "library")
+	p.Fail("")
+
+	if out.Len() != 0 {
+		t.Fatalf("expected no output, got %q", out.String())
+	}
+}
+
+func TestProgressTerminalRendersSpinnerAndClearsLine(t *testing.T) {
+	var out bytes.Buffer
+	p := newProgress(&out, true, verbosityVerbose)
+
+	p.Start("Scanning")
+	p.Update(1, 2)
+	p.Verbosef("checked %s", "library")
+	p.Fail("")
+
+	output := out.String()
+	for _, want := range []string{"Scanning 50%", "\r\033[K  checked library\n", "\r\033[K✗ Scanning\n"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output %q", want, output)
+		}
+	}
+}
+
+func TestParseVerbosityRejectsQuietAndVerbose(t *testing.T) {
+	if _, err := parseVerbosity(true, true); err == nil {
+		t.Fatal("expected error for --quiet with --verbose")
+	}
+	if level, err := parseVerbosity(false, true); err != nil || level != verbosityVerbose {
+		t.Fatalf("unexpected verbose result: %v %v", level, err)
+	}
+}
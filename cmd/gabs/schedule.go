@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// parseScheduleAddArgs parses the flags of 'gabs games schedule <id> add'.
+func parseScheduleAddArgs(args []string) (config.ScheduleConfig, error) {
+	fs := flag.NewFlagSet("games schedule add", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	id := fs.String("id", "", "Schedule ID; defaults to the action name")
+	action := fs.String("action", "", "start, stop or restart")
+	cron := fs.String("cron", "", "Five-field cron expression in local time, such as \"0 4 * * *\"")
+	every := fs.String("every", "", "Interval such as 6h, aligned to midnight UTC")
+	if err := fs.Parse(args); err != nil {
+		return config.ScheduleConfig{}, err
+	}
+	if fs.NArg() > 0 {
+		return config.ScheduleConfig{}, fmt.Errorf("unexpected argument %q; quote cron expressions, e.g. --cron \"0 4 * * *\"", fs.Arg(0))
+	}
+	return config.ScheduleConfig{
+		ID:     strings.TrimSpace(*id),
+		Action: strings.TrimSpace(*action),
+		Cron:   strings.TrimSpace(*cron),
+		Every:  strings.TrimSpace(*every),
+	}, nil
+}
+
+// printSchedules lists the schedules of game with their next firing time.
+func printSchedules(game config.GameConfig, now time.Time) {
+	if len(game.Schedules) == 0 {
+		fmt.Printf("Game '%s' has no schedules.\n", game.ID)
+		return
+	}
+	fmt.Printf("Schedules for %s:\n", game.ID)
+	for _, schedule := range game.Schedules {
+		timing := "every " + schedule.Every
+		if schedule.Cron != "" {
+			timing = fmt.Sprintf("cron '%s'", schedule.Cron)
+		}
+		next := ""
+		if spec, err := schedule.Spec(); err == nil {
+			if at := spec.Next(now); !at.IsZero() {
+				next = ", next " + at.Format("2006-01-02 15:04 MST")
+			}
+		}
+		fmt.Printf("  %-12s %s, %s%s\n", schedule.ID, schedule.Action, timing, next)
+	}
+}
+
+// scheduleGame handles 'gabs games schedule <id> [list|add|remove]'.
+func scheduleGame(log util.Logger, gameID string, configDir string, args []string) int {
+	operation := "list"
+	if len(args) > 0 {
+		operation = args[0]
+		args = args[1:]
+	}
+
+	gamesConfig, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		log.Errorw("failed to load games config", "error", err)
+		return 1
+	}
+	game, exists := gamesConfig.GetGame(gameID)
+	if !exists {
+		fmt.Printf("Game '%s' not found. Use 'gabs games add %s' to add it.\n", gameID, gameID)
+		return 1
+	}
+
+	var updated config.GameConfig
+	var message string
+	switch operation {
+	case "list":
+		if len(args) > 0 {
+			fmt.Fprintf(os.Stderr, "games schedule list takes no arguments\n")
+			return 2
+		}
+		printSchedules(*game, time.Now())
+		return 0
+	case "add":
+		schedule, err := parseScheduleAddArgs(args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return 2
+		}
+		if schedule.ID == "" {
+			schedule.ID = game.NextScheduleID(schedule.Action)
+		}
+		var replaced bool
+		updated, replaced = game.WithSchedule(schedule)
+		message = fmt.Sprintf("Added schedule '%s' to %s.", schedule.ID, gameID)
+		if replaced {
+			message = fmt.Sprintf("Replaced schedule '%s' of %s.", schedule.ID, gameID)
+		}
+	case "remove":
+		if len(args) != 1 {
+			fmt.Fprintf(os.Stderr, "games schedule remove requires a schedule ID\n")
+			return 2
+		}
+		var removed bool
+		updated, removed = game.WithoutSchedule(args[0])
+		if !removed {
+			fmt.Printf("Game '%s' has no schedule '%s'.\n", gameID, args[0])
+			return 1
+		}
+		message = fmt.Sprintf("Removed schedule '%s' from %s.", args[0], gameID)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown games schedule operation: %s (use list, add or remove)\n", operation)
+		return 2
+	}
+
+	if err := gamesConfig.AddGame(updated); err != nil {
+		fmt.Fprintf(os.Stderr, "Schedules of '%s' were not changed: %v\n", gameID, err)
+		return 1
+	}
+	if err := config.SaveGamesConfigToDir(gamesConfig, configDir); err != nil {
+		log.Errorw("failed to save games config", "error", err)
+		return 1
+	}
+
+	fmt.Println(message)
+	printSchedules(updated, time.Now())
+	fmt.Println("Running GABS servers use the change after 'gabs games reload'.")
+	return 0
+}
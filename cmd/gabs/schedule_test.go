@@ -0,0 +1,54 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestScheduleGameAddsAndRemovesSchedules(t *testing.T) {
+	configDir := t.TempDir()
+	gamesConfig := &config.GamesConfig{Games: map[string]config.GameConfig{
+		"factory": {ID: "factory", Name: "factory", LaunchMode: "DirectPath", Target: "/opt/factory/start.sh"},
+	}}
+	if err := config.SaveGamesConfigToDir(gamesConfig, configDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	log := util.NewLogger("error")
+
+	if code := scheduleGame(log, "factory", configDir, []string{"add", "--action", "restart", "--cron", "0 4 * * *"}); code != 0 {
+		t.Fatalf("schedule add exited with %d", code)
+	}
+	if code := scheduleGame(log, "factory", configDir, []string{"add", "--action", "restart", "--every", "6h"}); code != 0 {
+		t.Fatalf("second schedule add exited with %d", code)
+	}
+	saved, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	game, _ := saved.GetGame("factory")
+	want := []config.ScheduleConfig{
+		{ID: "restart", Action: "restart", Cron: "0 4 * * *"},
+		{ID: "restart-2", Action: "restart", Every: "6h"},
+	}
+	if !reflect.DeepEqual(game.Schedules, want) {
+		t.Fatalf("unexpected schedules: %#v", game.Schedules)
+	}
+
+	// An invalid schedule leaves the file alone.
+	if code := scheduleGame(log, "factory", configDir, []string{"add", "--action", "reboot", "--every", "6h"}); code != 1 {
+		t.Fatalf("expected an invalid action to be rejected, got exit %d", code)
+	}
+	if code := scheduleGame(log, "factory", configDir, []string{"remove", "restart"}); code != 0 {
+		t.Fatalf("schedule remove exited with %d", code)
+	}
+	saved, _ = config.LoadGamesConfigFromDir(configDir)
+	if game, _ := saved.GetGame("factory"); !reflect.DeepEqual(game.Schedules, want[1:]) {
+		t.Fatalf("unexpected schedules after remove: %#v", game.Schedules)
+	}
+	if code := scheduleGame(log, "factory", configDir, []string{"remove", "restart"}); code != 1 {
+		t.Fatalf("expected removing a missing schedule to fail, got exit %d", code)
+	}
+}
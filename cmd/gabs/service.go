@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/service"
+)
+
+// serviceExcludedFlags are flags that 'gabs service install' does not pass on
+// to the installed server, because they belong to the service command itself
+// or are set by it.
+var serviceExcludedFlags = map[string]bool{
+	"http":                true,
+	"addr":                true,
+	"configDir":           true,
+	"log-file":            true,
+	"service-name":        true,
+	"system":              true,
+	"print-client-config": true,
+	"client":              true,
+	"channel":             true,
+	"check":               true,
+	"token-file":          true,
+	"quiet":               true,
+	"verbose":             true,
+	"json":                true,
+}
+
+// manageService runs 'gabs service <install|uninstall|status|print>'.
+func manageService(opts options) int {
+	switch opts.serviceAction {
+	case "install", "uninstall", "status", "print":
+	default:
+		fmt.Fprintf(os.Stderr, "service requires an action: install, uninstall, status or print\n")
+		return 2
+	}
+	plan, spec, err := servicePlan(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "service %s: %v\n", opts.serviceAction, err)
+		return 1
+	}
+
+	switch opts.serviceAction {
+	case "install":
+		if err := os.MkdirAll(filepath.Dir(spec.LogFile), 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to create log directory: %v\n", err)
+			return 1
+		}
+		if err := service.Install(plan); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to install service '%s': %v\n", spec.Name, err)
+			return 1
+		}
+		fmt.Printf("Installed %s service '%s'", plan.Manager, spec.Name)
+		if plan.Path != "" {
+			fmt.Printf(" (%s)", plan.Path)
+		}
+		fmt.Printf(".\nGABS serves MCP on %s and logs to %s.\n", clientConfigURL(opts.httpAddr), spec.LogFile)
+		if plan.Manager == "systemd" && !plan.System {
+			fmt.Println("User services stop when you log out. Run 'loginctl enable-linger' to keep GABS running on a headless machine.")
+		}
+		return 0
+	case "uninstall":
+		if err := service.Uninstall(plan); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to uninstall service '%s': %v\n", spec.Name, err)
+			return 1
+		}
+		fmt.Printf("Removed %s service '%s'.\n", plan.Manager, spec.Name)
+		return 0
+	case "status":
+		out, err := service.Status(plan)
+		fmt.Print(out)
+		if err != nil {
+			if out == "" {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", plan.StatusOf, err)
+			}
+			return 1
+		}
+		return 0
+	default: // print
+		if plan.Path != "" {
+			fmt.Printf("# %s\n%s\n", plan.Path, plan.File)
+		}
+		fmt.Println("# Commands that install the service:")
+		for _, cmd := range plan.Install {
+			fmt.Println(cmd)
+		}
+	}
+	return 0
+}
+
+// servicePlan describes the service for the current options: 'gabs server
+// http' with the given flags, an absolute --configDir since services do not
+// start in the user's directory, and --log-file defaulting to
+// <configDir>/logs/<name>.log.
+func servicePlan(opts options) (service.Plan, service.Spec, error) {
+	spec := service.Spec{Name: opts.serviceName, System: opts.serviceSystem}
+	if spec.Name == "" {
+		spec.Name = service.DefaultName
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return service.Plan{}, spec, err
+	}
+	spec.Home = home
+	if spec.System && runtime.GOOS != "windows" {
+		// Under sudo, run as and read the config of the user who asked
+		spec.User = os.Getenv("SUDO_USER")
+		if spec.User == "" {
+			if current, err := user.Current(); err == nil && current.Username != "root" {
+				spec.User = current.Username
+			}
+		}
+		if spec.User != "" {
+			if account, err := user.Lookup(spec.User); err == nil {
+				spec.Home = account.HomeDir
+			}
+		}
+	}
+
+	configDir := opts.configDir
+	if configDir == "" {
+		configDir = filepath.Join(spec.Home, ".gabs")
+	}
+	paths, err := config.NewConfigPaths(configDir)
+	if err != nil {
+		return service.Plan{}, spec, err
+	}
+	baseDir, err := filepath.Abs(paths.GetBaseDir())
+	if err != nil {
+		return service.Plan{}, spec, err
+	}
+	spec.LogFile = opts.logFile
+	if spec.LogFile == "" {
+		spec.LogFile = filepath.Join(baseDir, "logs", spec.Name+".log")
+	}
+	if spec.LogFile, err = filepath.Abs(spec.LogFile); err != nil {
+		return service.Plan{}, spec, err
+	}
+
+	if spec.Executable, err = gabsExecutable(); err != nil {
+		return service.Plan{}, spec, err
+	}
+	spec.Args = append([]string{"server", "http", "--addr=" + opts.httpAddr, "--configDir=" + baseDir, "--log-file=" + spec.LogFile}, opts.serviceFlags...)
+
+	plan, err := service.NewPlan(runtime.GOOS, spec)
+	return plan, spec, err
+}
+
+// runAsService runs the server under the Windows service manager, which
+// started it through the command line 'gabs service install' registered.
+func runAsService(ctx context.Context, opts options, run func(context.Context) int) int {
+	exitCode, err := service.RunService(ctx, opts.serviceName, run)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "--service-name: %v\n", err)
+	}
+	return exitCode
+}
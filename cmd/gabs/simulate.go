@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+	"github.com/pardeike/gabs/internal/simulate"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// simulatedEndpoint is where a simulated bridge listens and the token it
+// requires.
+type simulatedEndpoint struct {
+	network  string
+	address  string
+	token    string
+	launched bool // Started by GABS as the game, which tracks it already
+}
+
+// runSimulate handles 'gabs simulate <gameId> --scenario <file>'.
+func runSimulate(ctx context.Context, log util.Logger, opts options, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: gabs simulate <gameId> --scenario <file>\n")
+		return 2
+	}
+	gameID := args[0]
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	scenarioPath := fs.String("scenario", "", "YAML file with the tools, resources and events to simulate")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *scenarioPath == "" {
+		fmt.Fprintf(os.Stderr, "--scenario is required\n")
+		return 2
+	}
+
+	scenario, err := simulate.LoadScenario(*scenarioPath)
+	if err != nil {
+		log.Errorw("failed to load scenario", "file", *scenarioPath, "error", err)
+		return 1
+	}
+
+	endpoint, err := simulatedBridgeEndpoint(gameID, opts.configDir)
+	if err != nil {
+		log.Errorw("failed to prepare simulated bridge", "gameId", gameID, "error", err)
+		return 1
+	}
+	if endpoint.network == "unix" {
+		if err := os.Remove(endpoint.address); err != nil && !os.IsNotExist(err) {
+			log.Errorw("failed to remove stale socket", "path", endpoint.address, "error", err)
+			return 1
+		}
+	}
+	listener, err := net.Listen(endpoint.network, endpoint.address)
+	if err != nil {
+		log.Errorw("failed to listen for GABS", "addr", endpoint.address, "error", err)
+		return 1
+	}
+	defer listener.Close()
+
+	if !endpoint.launched {
+		// Register as the running game, so GABS servers can games_connect to it
+		state := process.NewRuntimeState(process.LaunchSpec{GameId: gameID}, process.RuntimeStateStatusRunning)
+		state.OwnerPID = 0
+		state.GamePID = os.Getpid()
+		if err := process.SaveRuntimeState(gameID, opts.configDir, state); err != nil {
+			log.Errorw("failed to register simulated game", "gameId", gameID, "error", err)
+			return 1
+		}
+		defer process.RemoveRuntimeState(gameID, opts.configDir)
+	}
+
+	fmt.Fprintf(os.Stderr, "Simulating %s on %s with %d tools, %d resources and %d events. Press Ctrl+C to stop.\n",
+		gameID, endpoint.address, len(scenario.Tools), len(scenario.Resources), len(scenario.Events))
+	if !endpoint.launched {
+		fmt.Fprintf(os.Stderr, "Use games_connect with gameId %q in a GABS server to attach.\n", gameID)
+	}
+
+	bridge := simulate.NewBridge(gameID, scenario, endpoint.token, log)
+	if err := bridge.Serve(ctx, listener); err != nil {
+		log.Errorw("simulated bridge stopped", "gameId", gameID, "error", err)
+		return 1
+	}
+	return 0
+}
+
+// simulatedBridgeEndpoint takes the endpoint from the bridge environment when
+// GABS launched the simulator as the game. Otherwise it prepares the game's
+// bridge.json the way games_start would; the game must be configured.
+func simulatedBridgeEndpoint(gameID, configDir string) (simulatedEndpoint, error) {
+	if token := os.Getenv("GABP_TOKEN"); token != "" {
+		if os.Getenv("GABS_BRIDGE_MODE") == config.BridgeModeListen {
+			return simulatedEndpoint{}, fmt.Errorf("bridgeMode %s is not supported by simulated bridges", config.BridgeModeListen)
+		}
+		if socket := os.Getenv("GABP_SOCKET"); socket != "" {
+			return simulatedEndpoint{network: "unix", address: socket, token: token, launched: true}, nil
+		}
+		port, err := strconv.Atoi(os.Getenv("GABP_SERVER_PORT"))
+		if err != nil || port <= 0 {
+			return simulatedEndpoint{}, fmt.Errorf("invalid GABP_SERVER_PORT %q", os.Getenv("GABP_SERVER_PORT"))
+		}
+		return simulatedEndpoint{network: "tcp", address: fmt.Sprintf("127.0.0.1:%d", port), token: token, launched: true}, nil
+	}
+
+	gamesConfig, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		return simulatedEndpoint{}, fmt.Errorf("failed to load games config: %w", err)
+	}
+	game, exists := gamesConfig.GetGame(gameID)
+	if !exists {
+		return simulatedEndpoint{}, fmt.Errorf("game '%s' not found; use 'gabs games add %s' to add it", gameID, gameID)
+	}
+	if game.ListensForBridge() {
+		return simulatedEndpoint{}, fmt.Errorf("bridgeMode %s is not supported by simulated bridges", config.BridgeModeListen)
+	}
+	port, token, _, _, err := config.PrepareBridgeEndpointForStart(game.ID, configDir, gamesConfig, false)
+	if err != nil {
+		return simulatedEndpoint{}, err
+	}
+	socket, err := config.BridgeSocket(game.ID, configDir)
+	if err != nil {
+		return simulatedEndpoint{}, err
+	}
+	if socket != "" {
+		return simulatedEndpoint{network: "unix", address: socket, token: token}, nil
+	}
+	return simulatedEndpoint{network: "tcp", address: fmt.Sprintf("127.0.0.1:%d", port), token: token}, nil
+}
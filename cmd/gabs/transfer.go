@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// exportGames writes every game and cluster definition to path, or to stdout
+// when path is empty.
+func exportGames(log util.Logger, configDir string, path string) int {
+	gamesConfig, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		log.Errorw("failed to load games config", "error", err)
+		return 1
+	}
+	doc := gamesConfig.ExportGames()
+
+	if path == "" || path == "-" {
+		if err := writeJSON(os.Stdout, doc); err != nil {
+			log.Errorw("failed to write export", "error", err)
+			return 1
+		}
+		return 0
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create %s: %v\n", path, err)
+		return 1
+	}
+	if err := writeJSON(file, doc); err != nil {
+		file.Close()
+		fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", path, err)
+		return 1
+	}
+	if err := file.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", path, err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d games to %s.\n", len(doc.Games), path)
+	return 0
+}
+
+// parseImportArgs splits 'gabs games import <file> [--mode skip|overwrite|merge]'.
+func parseImportArgs(args []string) (string, config.ImportMode, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") && args[0] != "-" {
+		return "", "", fmt.Errorf("games import requires a file, or - for stdin")
+	}
+	fs := flag.NewFlagSet("games import", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	modeFlag := fs.String("mode", string(config.ImportSkip), "What to do with existing IDs: skip, overwrite or merge")
+	if err := fs.Parse(args[1:]); err != nil {
+		return "", "", err
+	}
+	if fs.NArg() > 0 {
+		return "", "", fmt.Errorf("unexpected argument %q; flags go after the file, e.g. --mode merge", fs.Arg(0))
+	}
+	mode, err := config.ParseImportMode(*modeFlag)
+	if err != nil {
+		return "", "", err
+	}
+	return args[0], mode, nil
+}
+
+func readGamesExport(path string) (config.GamesExport, error) {
+	var doc config.GamesExport
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return doc, err
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return doc, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return doc, nil
+}
+
+// importGames loads a games export into the saved configuration.
+func importGames(log util.Logger, configDir string, args []string) int {
+	path, mode, err := parseImportArgs(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 2
+	}
+	doc, err := readGamesExport(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	gamesConfig, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		log.Errorw("failed to load games config", "error", err)
+		return 1
+	}
+	result, err := gamesConfig.ImportGames(doc, mode)
+	if err != nil {
+		fmt.Printf("Nothing was imported: %v\n", err)
+		return 1
+	}
+
+	if result.Changed() {
+		// A first import has no config to back up yet.
+		if err := backupGamesConfig(configDir); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("Failed to back up config: %v\n", err)
+			return 1
+		}
+		if err := config.SaveGamesConfigToDir(gamesConfig, configDir); err != nil {
+			log.Errorw("failed to save games config", "error", err)
+			return 1
+		}
+	}
+
+	printImportGroup("Added", result.Added)
+	printImportGroup("Overwritten", result.Overwritten)
+	printImportGroup("Merged", result.Merged)
+	printImportGroup("Skipped (already configured)", result.Skipped)
+	if !result.Changed() {
+		fmt.Println("No games were changed.")
+	}
+	return 0
+}
+
+func printImportGroup(label string, ids []string) {
+	if len(ids) > 0 {
+		fmt.Printf("%s: %s\n", label, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestExportAndImportGamesBetweenConfigDirs(t *testing.T) {
+	log := util.NewLogger("error")
+	sourceDir := t.TempDir()
+	source := &config.GamesConfig{Version: "1.0"}
+	if err := source.AddGame(config.GameConfig{ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/opt/factory/start.sh", StopProcessName: "java"}); err != nil {
+		t.Fatalf("add game: %v", err)
+	}
+	if err := config.SaveGamesConfigToDir(source, sourceDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	exportPath := filepath.Join(t.TempDir(), "games.json")
+	if code := exportGames(log, sourceDir, exportPath); code != 0 {
+		t.Fatalf("exportGames exited with %d", code)
+	}
+
+	targetDir := t.TempDir()
+	target := &config.GamesConfig{Version: "1.0"}
+	if err := target.AddGame(config.GameConfig{ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "D:/Games/Factory/start.bat"}); err != nil {
+		t.Fatalf("add game: %v", err)
+	}
+	if err := config.SaveGamesConfigToDir(target, targetDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	if code := importGames(log, targetDir, []string{exportPath}); code != 0 {
+		t.Fatalf("importGames exited with %d", code)
+	}
+	saved, _ := config.LoadGamesConfigFromDir(targetDir)
+	if game, _ := saved.GetGame("factory"); game.Target != "D:/Games/Factory/start.bat" || game.StopProcessName != "" {
+		t.Fatalf("expected skip to keep the local game, got %#v", game)
+	}
+
+	if code := importGames(log, targetDir, []string{exportPath, "--mode", "merge"}); code != 0 {
+		t.Fatalf("importGames exited with %d", code)
+	}
+	saved, _ = config.LoadGamesConfigFromDir(targetDir)
+	if game, _ := saved.GetGame("factory"); game.Target != "/opt/factory/start.sh" || game.StopProcessName != "java" {
+		t.Fatalf("expected merge to apply the imported fields, got %#v", game)
+	}
+
+	if code := importGames(log, targetDir, []string{"--mode", "merge"}); code != 2 {
+		t.Fatalf("expected a missing file to be a usage error, got exit %d", code)
+	}
+	if code := importGames(log, targetDir, []string{exportPath, "--mode", "replace"}); code != 2 {
+		t.Fatalf("expected an unknown mode to be a usage error, got exit %d", code)
+	}
+}
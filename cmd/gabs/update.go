@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/pardeike/gabs/internal/update"
+	"github.com/pardeike/gabs/internal/version"
+)
+
+func runUpdate(ctx context.Context, opts options) int {
+	if err := update.ValidateChannel(opts.updateChannel); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid --channel: %v\n", err)
+		return 2
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to find the gabs binary: %v\n", err)
+		return 1
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	client := &update.Client{HTTP: &http.Client{Timeout: 5 * time.Minute}, APIURL: update.DefaultAPIURL}
+	return selfUpdate(ctx, client, exe, version.Get(), opts.updateChannel, opts.updateCheckOnly, os.Stdout, newStderrProgress(opts.verbosity))
+}
+
+// selfUpdate replaces the binary at exe with the newest release on channel
+// when it is newer than current. With checkOnly it just reports the release.
+// Progress of the check and the download goes to p; results go to out.
+func selfUpdate(ctx context.Context, client *update.Client, exe, current, channel string, checkOnly bool, out io.Writer, p *progress) int {
+	p.Start(fmt.Sprintf("Checking the %s channel for releases", channel))
+	release, err := client.Latest(ctx, channel)
+	if err != nil {
+		p.Fail("")
+		fmt.Fprintf(os.Stderr, "Could not check for updates: %v\n", err)
+		return 1
+	}
+	p.Done(release.Tag)
+	if !update.Newer(current, release.Tag) {
+		fmt.Fprintf(out, "gabs %s is up to date on the %s channel (newest release: %s).\n", current, channel, release.Tag)
+		return 0
+	}
+	if checkOnly {
+		fmt.Fprintf(out, "gabs %s is available on the %s channel (installed: %s). Run 'gabs update --channel %s' to install it.\n", release.Tag, channel, current, channel)
+		return 0
+	}
+
+	staged, err := os.CreateTemp(filepath.Dir(exe), ".gabs-new-*")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot write next to %s: %v. Run the update as a user who can replace the binary.\n", exe, err)
+		return 1
+	}
+	staged.Close()
+	defer os.Remove(staged.Name())
+	p.Start(fmt.Sprintf("Downloading gabs %s for %s/%s", release.Tag, runtime.GOOS, runtime.GOARCH))
+	client.Progress = func(done, total int64) {
+		if total > 0 {
+			p.Update(int(done*100/total), 100)
+		}
+	}
+	err = client.Download(ctx, release, runtime.GOOS, runtime.GOARCH, staged.Name())
+	client.Progress = nil
+	if err != nil {
+		p.Fail("")
+		fmt.Fprintf(os.Stderr, "Update failed, gabs %s was kept: %v\n", current, err)
+		return 1
+	}
+	p.Done("")
+	p.Verbosef("staged the new binary at %s", staged.Name())
+	if err := update.Replace(exe, staged.Name()); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not replace %s, gabs %s was kept: %v\n", exe, current, err)
+		return 1
+	}
+	fmt.Fprintf(out, "Updated gabs from %s to %s. Restart running GABS servers to use the new version.\n", current, release.Tag)
+	return 0
+}
@@ -1,14 +1,163 @@
+// Command mcptest is a client-side test harness for MCP servers such as GABS.
+// It connects over stdio or HTTP, runs initialize, tools/list, tools/call and
+// other requests interactively or from a script, checks expectations and
+// reports latencies.
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/pardeike/gabs/internal/mcp"
 	"github.com/pardeike/gabs/internal/util"
 )
 
 func main() {
+	fs := flag.NewFlagSet("mcptest", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = usage
+	var (
+		httpURL     = fs.String("http", "", "URL of the MCP endpoint of an HTTP server, e.g. http://localhost:8080/mcp")
+		apiKey      = fs.String("api-key", "", "API key sent as bearer token over HTTP (default: $GABS_API_KEY)")
+		scriptPath  = fs.String("script", "", "Run the commands in this file instead of reading them interactively")
+		timeout     = fs.Duration("timeout", 30*time.Second, "Time limit for each request")
+		framing     = fs.String("framing", "content-length", "Stdio framing: content-length|newline")
+		verbose     = fs.Bool("v", false, "Print every response and notification in full, and the server's stderr")
+		serveEcho   = fs.Bool("serve", false, "Serve a test.echo tool on stdio instead, for testing MCP clients")
+		initialized = fs.Bool("init", true, "Send initialize before the first command")
+	)
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+
+	if *serveEcho {
+		serveEchoServer()
+		return
+	}
+
+	if (*httpURL == "") == (fs.NArg() == 0) {
+		fmt.Fprintln(os.Stderr, "give either --http <url> or the command of a stdio server")
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	onMessage := func(msg *mcp.Message) {
+		if *verbose {
+			data, _ := json.Marshal(msg)
+			fmt.Printf("<- %s\n", data)
+			return
+		}
+		fmt.Printf("<- %s\n", msg.Method)
+	}
+
+	var conn transport
+	if *httpURL != "" {
+		key := *apiKey
+		if key == "" {
+			key = os.Getenv("GABS_API_KEY")
+		}
+		conn = newHTTPTransport(*httpURL, key)
+	} else {
+		mode := util.FramingLSP
+		switch *framing {
+		case "content-length":
+		case "newline":
+			mode = util.FramingNewline
+		default:
+			fmt.Fprintf(os.Stderr, "invalid --framing %q\n", *framing)
+			os.Exit(2)
+		}
+		var stderr io.Writer
+		if *verbose {
+			stderr = os.Stderr
+		}
+		stdio, err := startStdioTransport(fs.Args(), mode, stderr, onMessage)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		conn = stdio
+	}
+
+	r := &runner{transport: conn, out: os.Stdout, timeout: *timeout, verbose: *verbose}
+	exitCode := run(ctx, r, *scriptPath, *initialized)
+	if err := conn.close(); err != nil && *verbose {
+		fmt.Fprintf(os.Stderr, "server exited: %v\n", err)
+	}
+	os.Exit(exitCode)
+}
+
+// run executes the script, or commands from stdin, and prints the summary.
+func run(ctx context.Context, r *runner, scriptPath string, initialize bool) int {
+	if initialize {
+		if err := r.run(ctx, "initialize"); err != nil {
+			fmt.Fprintf(os.Stderr, "initialize failed: %v\n", err)
+			return 1
+		}
+	}
+
+	var err error
+	if scriptPath != "" {
+		var script *os.File
+		if script, err = os.Open(scriptPath); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer script.Close()
+		err = r.runScript(ctx, script, nil)
+	} else {
+		fmt.Fprintln(os.Stderr, "Type help for commands.")
+		err = r.runScript(ctx, os.Stdin, func() { fmt.Fprint(os.Stderr, "mcptest> ") })
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Println(r.summary())
+	if r.failures > 0 {
+		return 1
+	}
+	return 0
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `mcptest - test MCP servers from the client side
+
+Usage:
+  mcptest [flags] <server command> [args...]   Talk to a server on its stdio
+  mcptest [flags] --http <url>                 Talk to an HTTP server
+  mcptest --serve                              Serve a test.echo tool on stdio
+
+Flags:
+  --http <url>          MCP endpoint, e.g. http://localhost:8080/mcp
+  --api-key <key>       Bearer key for HTTP (default: $GABS_API_KEY)
+  --script <file>       Run commands from a file; exits 1 if an expectation fails
+  --timeout <dur>       Time limit for each request (default 30s)
+  --framing <mode>      Stdio framing: content-length|newline (default content-length)
+  --init=false          Do not send initialize first
+  -v                    Print full responses, notifications and server stderr
+
+%s
+
+Examples:
+  mcptest gabs server stdio
+  mcptest --http http://localhost:8080/mcp --script smoke.txt
+`, commandHelp)
+}
+
+// serveEchoServer serves a test.echo tool and a test resource on stdio.
+func serveEchoServer() {
 	log := util.NewLogger("info")
 	server := mcp.NewServer(log)
 
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/mcp"
+	"github.com/pardeike/gabs/internal/version"
+)
+
+// defaultProtocolVersion is the MCP version initialize asks for.
+const defaultProtocolVersion = "2025-06-18"
+
+var errQuit = errors.New("quit")
+
+const commandHelp = `Commands:
+  initialize [params]        Start the MCP session and send notifications/initialized
+  list [cursor]              tools/list
+  call <tool> [arguments]    tools/call with JSON arguments
+  request <method> [params]  Any request with JSON params
+  notify <method> [params]   Any notification with JSON params
+  expect ok                  The last response succeeded and is no tool error
+  expect error [code]        The last response is a JSON-RPC error (with code) or tool error
+  expect contains <text>     The last response contains text
+  expect not-contains <text> The last response does not contain text
+  expect under <duration>    The last request took less than duration, e.g. 500ms
+  sleep <duration>           Wait, e.g. for background work
+  help                       Show this help
+  quit                       End the session
+Lines starting with # are comments.`
+
+// runner executes mcptest commands against a server and keeps the score.
+type runner struct {
+	transport transport
+	out       io.Writer
+	timeout   time.Duration
+	verbose   bool // Print every response in full
+
+	nextID       int
+	last         *mcp.Message // Response to the last request
+	lastLatency  time.Duration
+	latencies    []time.Duration
+	expectations int
+	failures     int
+}
+
+// runScript executes the commands read from r until it ends or a quit
+// command. prompt is printed before each command when set.
+func (r *runner) runScript(ctx context.Context, in io.Reader, prompt func()) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for line := 1; ; line++ {
+		if prompt != nil {
+			prompt()
+		}
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+		err := r.run(ctx, scanner.Text())
+		if errors.Is(err, errQuit) {
+			return nil
+		}
+		if err != nil {
+			r.failures++
+			fmt.Fprintf(r.out, "line %d: %v\n", line, err)
+		}
+	}
+}
+
+// run executes one command line. Failed expectations are counted, not
+// returned; errors are for commands that could not run.
+func (r *runner) run(ctx context.Context, line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+	command, rest := splitWord(line)
+	switch command {
+	case "initialize":
+		params := map[string]interface{}{
+			"protocolVersion": defaultProtocolVersion,
+			"capabilities":    map[string]interface{}{},
+			"clientInfo":      map[string]interface{}{"name": "mcptest", "version": version.Get()},
+		}
+		if rest != "" {
+			if err := json.Unmarshal([]byte(rest), &params); err != nil {
+				return fmt.Errorf("invalid initialize params: %w", err)
+			}
+		}
+		if err := r.request(ctx, "initialize", params); err != nil {
+			return err
+		}
+		if r.last.Error != nil {
+			return nil
+		}
+		return r.transport.notify(ctx, mcp.NewNotification("notifications/initialized", nil))
+	case "list":
+		params := map[string]interface{}{}
+		if rest != "" {
+			params["cursor"] = rest
+		}
+		return r.request(ctx, "tools/list", params)
+	case "call":
+		name, arguments := splitWord(rest)
+		if name == "" {
+			return errors.New("usage: call <tool> [arguments]")
+		}
+		args, err := parseParams(arguments)
+		if err != nil {
+			return fmt.Errorf("invalid arguments: %w", err)
+		}
+		if args == nil {
+			args = map[string]interface{}{}
+		}
+		return r.request(ctx, "tools/call", map[string]interface{}{"name": name, "arguments": args})
+	case "request", "notify":
+		method, rawParams := splitWord(rest)
+		if method == "" {
+			return fmt.Errorf("usage: %s <method> [params]", command)
+		}
+		params, err := parseParams(rawParams)
+		if err != nil {
+			return fmt.Errorf("invalid params: %w", err)
+		}
+		if command == "notify" {
+			return r.transport.notify(ctx, mcp.NewNotification(method, params))
+		}
+		return r.request(ctx, method, params)
+	case "expect":
+		return r.expect(rest)
+	case "sleep":
+		delay, err := time.ParseDuration(rest)
+		if err != nil {
+			return fmt.Errorf("invalid duration: %w", err)
+		}
+		select {
+		case <-time.After(delay):
+			return nil
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	case "help":
+		fmt.Fprintln(r.out, commandHelp)
+		return nil
+	case "quit", "exit":
+		return errQuit
+	default:
+		return fmt.Errorf("unknown command %q; try help", command)
+	}
+}
+
+// request sends a request, prints a line with its latency and outcome and
+// remembers the response for expect.
+func (r *runner) request(ctx context.Context, method string, params interface{}) error {
+	r.nextID++
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	started := time.Now()
+	response, err := r.transport.request(ctx, mcp.NewRequest(r.nextID, method, params))
+	latency := time.Since(started)
+	if err != nil {
+		r.last = nil
+		return err
+	}
+	r.last = response
+	r.lastLatency = latency
+	r.latencies = append(r.latencies, latency)
+
+	fmt.Fprintf(r.out, "%s  %s  %s\n", method, formatLatency(latency), describeResponse(response))
+	if r.verbose {
+		data, _ := json.MarshalIndent(responseBody(response), "  ", "  ")
+		fmt.Fprintf(r.out, "  %s\n", data)
+	}
+	return nil
+}
+
+// expect checks an assertion against the last response.
+func (r *runner) expect(assertion string) error {
+	kind, argument := splitWord(assertion)
+	argument = unquote(argument)
+	if r.last == nil {
+		return errors.New("expect needs a response; the last request failed or none was sent")
+	}
+
+	var problem string
+	switch kind {
+	case "ok":
+		if !responseOK(r.last) {
+			problem = "got " + describeResponse(r.last)
+		}
+	case "error":
+		switch {
+		case argument != "":
+			code, err := strconv.Atoi(argument)
+			if err != nil {
+				return fmt.Errorf("invalid error code %q", argument)
+			}
+			if r.last.Error == nil || r.last.Error.Code != code {
+				problem = "got " + describeResponse(r.last)
+			}
+		case responseOK(r.last):
+			problem = "got ok"
+		}
+	case "contains", "not-contains":
+		if argument == "" {
+			return fmt.Errorf("usage: expect %s <text>", kind)
+		}
+		data, _ := json.Marshal(responseBody(r.last))
+		if strings.Contains(string(data), argument) != (kind == "contains") {
+			problem = fmt.Sprintf("response %s", truncate(string(data), 200))
+		}
+	case "under":
+		limit, err := time.ParseDuration(argument)
+		if err != nil {
+			return fmt.Errorf("invalid duration: %w", err)
+		}
+		if r.lastLatency >= limit {
+			problem = "took " + formatLatency(r.lastLatency)
+		}
+	default:
+		return fmt.Errorf("unknown expectation %q; try help", kind)
+	}
+
+	r.expectations++
+	if problem != "" {
+		r.failures++
+		fmt.Fprintf(r.out, "  FAIL expect %s: %s\n", assertion, problem)
+		return nil
+	}
+	fmt.Fprintf(r.out, "  PASS expect %s\n", assertion)
+	return nil
+}
+
+// summary describes the requests, expectations and latencies so far.
+func (r *runner) summary() string {
+	text := fmt.Sprintf("%d requests, %d expectations, %d failed", len(r.latencies), r.expectations, r.failures)
+	if len(r.latencies) == 0 {
+		return text
+	}
+	min, max, total := r.latencies[0], r.latencies[0], time.Duration(0)
+	for _, latency := range r.latencies {
+		if latency < min {
+			min = latency
+		}
+		if latency > max {
+			max = latency
+		}
+		total += latency
+	}
+	return fmt.Sprintf("%s; latency min %s, avg %s, max %s", text, formatLatency(min), formatLatency(total/time.Duration(len(r.latencies))), formatLatency(max))
+}
+
+// responseOK reports whether response succeeded and is no tool error.
+func responseOK(response *mcp.Message) bool {
+	if response.Error != nil {
+		return false
+	}
+	result, _ := response.Result.(map[string]interface{})
+	isError, _ := result["isError"].(bool)
+	return !isError
+}
+
+func describeResponse(response *mcp.Message) string {
+	if response.Error != nil {
+		return fmt.Sprintf("error %d: %s", response.Error.Code, response.Error.Message)
+	}
+	if !responseOK(response) {
+		return "tool error"
+	}
+	if result, ok := response.Result.(map[string]interface{}); ok {
+		if tools, ok := result["tools"].([]interface{}); ok {
+			return fmt.Sprintf("ok, %d tools", len(tools))
+		}
+	}
+	return "ok"
+}
+
+func responseBody(response *mcp.Message) interface{} {
+	if response.Error != nil {
+		return response.Error
+	}
+	return response.Result
+}
+
+func formatLatency(latency time.Duration) string {
+	return latency.Round(100 * time.Microsecond).String()
+}
+
+// splitWord returns the first word of s and the trimmed rest.
+func splitWord(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	word, rest, _ := strings.Cut(s, " ")
+	return word, strings.TrimSpace(rest)
+}
+
+// parseParams decodes JSON params; empty text means none.
+func parseParams(text string) (map[string]interface{}, error) {
+	if text == "" {
+		return nil, nil
+	}
+	var params map[string]interface{}
+	if err := json.Unmarshal([]byte(text), &params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func unquote(text string) string {
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		return unquoted
+	}
+	return text
+}
+
+func truncate(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	return text[:limit] + "..."
+}
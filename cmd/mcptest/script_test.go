@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/mcp"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// startEchoServerForTest serves test.echo in process and returns a transport
+// talking to it.
+func startEchoServerForTest(t *testing.T) transport {
+	t.Helper()
+	server := mcp.NewServerForTesting(util.NewLogger("error"))
+	server.RegisterTool(mcp.Tool{Name: "test.echo", InputSchema: map[string]interface{}{"type": "object"}}, func(args map[string]interface{}) (*mcp.ToolResult, error) {
+		return &mcp.ToolResult{Content: []mcp.Content{{Type: "text", Text: fmt.Sprintf("Echo: %v", args["message"])}}}, nil
+	})
+
+	stdinReader, stdin := io.Pipe()
+	stdoutReader, stdout := io.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(stdinReader, stdout)
+		stdout.Close()
+	}()
+	conn := newStreamTransport(stdoutReader, stdin, util.FramingLSP, func(*mcp.Message) {}, func() error {
+		stdin.Close()
+		return <-served
+	})
+	t.Cleanup(func() { conn.close() })
+	return conn
+}
+
+func TestRunScriptChecksExpectations(t *testing.T) {
+	var out strings.Builder
+	r := &runner{transport: startEchoServerForTest(t), out: &out, timeout: 5 * time.Second}
+	script := `# smoke test
+initialize
+expect ok
+list
+expect contains "test.echo"
+call test.echo {"message": "hi"}
+expect ok
+expect contains "Echo: hi"
+expect under 5s
+call missing.tool
+expect error
+expect contains "Echo"
+`
+	if err := r.runScript(context.Background(), strings.NewReader(script), nil); err != nil {
+		t.Fatalf("runScript: %v", err)
+	}
+
+	if r.expectations != 7 || r.failures != 1 {
+		t.Fatalf("expected 7 expectations with 1 failure, got %d and %d:\n%s", r.expectations, r.failures, out.String())
+	}
+	if !strings.Contains(out.String(), "FAIL expect contains \"Echo\"") {
+		t.Fatalf("expected the failed expectation to be reported, got:\n%s", out.String())
+	}
+	if summary := r.summary(); !strings.HasPrefix(summary, "4 requests, 7 expectations, 1 failed; latency min ") {
+		t.Fatalf("unexpected summary %q", summary)
+	}
+}
+
+func TestRunScriptReportsCommandErrorsAndStopsAtQuit(t *testing.T) {
+	var out strings.Builder
+	r := &runner{transport: startEchoServerForTest(t), out: &out, timeout: 5 * time.Second}
+	script := "expect ok\nbogus\nquit\nlist\n"
+	if err := r.runScript(context.Background(), strings.NewReader(script), nil); err != nil {
+		t.Fatalf("runScript: %v", err)
+	}
+
+	if r.failures != 2 || len(r.latencies) != 0 {
+		t.Fatalf("expected two failed lines and no request after quit, got %d failures and %d requests:\n%s", r.failures, len(r.latencies), out.String())
+	}
+	if !strings.Contains(out.String(), "line 2: unknown command \"bogus\"") {
+		t.Fatalf("expected the line number of the unknown command, got:\n%s", out.String())
+	}
+}
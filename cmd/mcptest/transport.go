@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"sync"
+
+	"github.com/pardeike/gabs/internal/mcp"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// transport carries MCP messages to a server.
+type transport interface {
+	// request sends a request and waits for the response with its ID.
+	request(ctx context.Context, msg *mcp.Message) (*mcp.Message, error)
+	// notify sends a notification, which gets no response.
+	notify(ctx context.Context, msg *mcp.Message) error
+	close() error
+}
+
+// messageKey identifies a JSON-RPC ID regardless of how it was decoded.
+func messageKey(id interface{}) string {
+	data, _ := json.Marshal(id)
+	return string(data)
+}
+
+// streamTransport talks to a server over a pair of streams, such as the
+// stdio of a spawned GABS. Messages the server sends on its own are handed to
+// onMessage; server requests are refused, since mcptest implements none.
+type streamTransport struct {
+	writer    *util.AutoFrameWriter
+	onMessage func(*mcp.Message)
+	closer    func() error
+
+	mu      sync.Mutex
+	pending map[string]chan *mcp.Message
+	readErr error
+	done    chan struct{}
+}
+
+func newStreamTransport(r io.Reader, w io.Writer, framing util.FramingMode, onMessage func(*mcp.Message), closer func() error) *streamTransport {
+	writer := util.NewAutoFrameWriter(w)
+	writer.SetMode(framing)
+	t := &streamTransport{
+		writer:    writer,
+		onMessage: onMessage,
+		closer:    closer,
+		pending:   make(map[string]chan *mcp.Message),
+		done:      make(chan struct{}),
+	}
+	go t.readLoop(util.NewAutoFrameReader(r))
+	return t
+}
+
+// startStdioTransport runs command and talks MCP over its stdin and stdout.
+// The server's stderr goes to stderr, which may be nil to drop it.
+func startStdioTransport(command []string, framing util.FramingMode, stderr io.Writer, onMessage func(*mcp.Message)) (*streamTransport, error) {
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stderr = stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", command[0], err)
+	}
+	return newStreamTransport(stdout, stdin, framing, onMessage, func() error {
+		// Closing stdin asks the server to finish its responses and exit
+		_ = stdin.Close()
+		return cmd.Wait()
+	}), nil
+}
+
+func (t *streamTransport) readLoop(reader *util.AutoFrameReader) {
+	defer close(t.done)
+	for {
+		var msg mcp.Message
+		if err := reader.ReadJSON(&msg); err != nil {
+			t.mu.Lock()
+			t.readErr = err
+			t.mu.Unlock()
+			return
+		}
+		switch {
+		case msg.Method != "" && msg.ID != nil:
+			_ = t.writer.WriteJSON(mcp.NewError(msg.ID, -32601, "mcptest does not handle server requests", nil))
+			t.onMessage(&msg)
+		case msg.Method != "":
+			t.onMessage(&msg)
+		default:
+			t.mu.Lock()
+			ch, exists := t.pending[messageKey(msg.ID)]
+			t.mu.Unlock()
+			if exists {
+				ch <- &msg
+			}
+		}
+	}
+}
+
+func (t *streamTransport) request(ctx context.Context, msg *mcp.Message) (*mcp.Message, error) {
+	key := messageKey(msg.ID)
+	ch := make(chan *mcp.Message, 1)
+	t.mu.Lock()
+	t.pending[key] = ch
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		delete(t.pending, key)
+		t.mu.Unlock()
+	}()
+
+	if err := t.writer.WriteJSON(msg); err != nil {
+		return nil, fmt.Errorf("failed to send %s: %w", msg.Method, err)
+	}
+	select {
+	case response := <-ch:
+		return response, nil
+	case <-t.done:
+		t.mu.Lock()
+		err := t.readErr
+		t.mu.Unlock()
+		return nil, fmt.Errorf("server closed the connection: %w", err)
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%s: %w", msg.Method, context.Cause(ctx))
+	}
+}
+
+func (t *streamTransport) notify(ctx context.Context, msg *mcp.Message) error {
+	return t.writer.WriteJSON(msg)
+}
+
+func (t *streamTransport) close() error {
+	if t.closer == nil {
+		return nil
+	}
+	return t.closer()
+}
+
+// mcpSessionHeader carries the session GABS issues on initialize over HTTP.
+const mcpSessionHeader = "Mcp-Session-Id"
+
+// httpTransport posts each message to the /mcp endpoint of a GABS HTTP
+// server.
+type httpTransport struct {
+	url     string
+	apiKey  string
+	client  *http.Client
+	mu      sync.Mutex
+	session string
+}
+
+func newHTTPTransport(url, apiKey string) *httpTransport {
+	return &httpTransport{url: url, apiKey: apiKey, client: &http.Client{}}
+}
+
+func (t *httpTransport) post(ctx context.Context, msg *mcp.Message) (*http.Response, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json, text/event-stream")
+	if t.apiKey != "" {
+		request.Header.Set("Authorization", "Bearer "+t.apiKey)
+	}
+	t.mu.Lock()
+	if t.session != "" {
+		request.Header.Set(mcpSessionHeader, t.session)
+	}
+	t.mu.Unlock()
+
+	response, err := t.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if session := response.Header.Get(mcpSessionHeader); session != "" {
+		t.mu.Lock()
+		t.session = session
+		t.mu.Unlock()
+	}
+	return response, nil
+}
+
+func (t *httpTransport) request(ctx context.Context, msg *mcp.Message) (*mcp.Message, error) {
+	response, err := t.post(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var reply mcp.Message
+	if err := json.Unmarshal(data, &reply); err != nil || (reply.Result == nil && reply.Error == nil) {
+		return nil, fmt.Errorf("HTTP %d: %s", response.StatusCode, bytes.TrimSpace(data))
+	}
+	return &reply, nil
+}
+
+func (t *httpTransport) notify(ctx context.Context, msg *mcp.Message) error {
+	response, err := t.post(ctx, msg)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusBadRequest {
+		data, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("HTTP %d: %s", response.StatusCode, bytes.TrimSpace(data))
+	}
+	return nil
+}
+
+func (t *httpTransport) close() error {
+	t.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,278 @@
+// Package bridgepkg installs GABP bridge packages into a game's plugin
+// directory and moves them out of it to disable them.
+package bridgepkg
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// Package states reported by State.
+const (
+	StateEnabled      = "enabled"
+	StateDisabled     = "disabled"
+	StateNotInstalled = "notInstalled"
+)
+
+// ErrNotInstalled is returned by SetEnabled for a package that is neither in
+// the plugin directory nor disabled.
+var ErrNotInstalled = errors.New("bridge package is not installed")
+
+// State reports whether package id is in dir, parked in disabledDir, or in
+// neither.
+func State(dir, disabledDir, id string) string {
+	if isDir(filepath.Join(dir, id)) {
+		return StateEnabled
+	}
+	if isDir(filepath.Join(disabledDir, id)) {
+		return StateDisabled
+	}
+	return StateNotInstalled
+}
+
+// Install fetches pkg and puts it into dir/<id>, replacing an earlier
+// install and removing a disabled copy from disabledDir. A .zip source is
+// unpacked, dropping a single top-level folder the archive may wrap its files
+// in; any other source is copied as one file. The package is assembled next
+// to its final place and swapped in only once complete.
+func Install(ctx context.Context, client *http.Client, pkg config.BridgePackage, dir, disabledDir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	download, err := os.CreateTemp(dir, "."+pkg.ID+"-download-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(download.Name())
+	sum, err := fetch(ctx, client, pkg, download)
+	if closeErr := download.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("fetch %s: %w", pkg.Source, err)
+	}
+	if pkg.SHA256 != "" && !strings.EqualFold(sum, pkg.SHA256) {
+		return fmt.Errorf("checksum of %s is %s, expected %s", pkg.Source, sum, pkg.SHA256)
+	}
+
+	staging, err := os.MkdirTemp(dir, "."+pkg.ID+"-install-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+	name := sourceName(pkg.Source)
+	if strings.EqualFold(filepath.Ext(name), ".zip") {
+		err = unzip(download.Name(), staging)
+	} else {
+		err = copyFile(download.Name(), filepath.Join(staging, name))
+	}
+	if err != nil {
+		return fmt.Errorf("unpack %s: %w", pkg.Source, err)
+	}
+
+	target := filepath.Join(dir, pkg.ID)
+	previous := staging + "-previous"
+	if err := os.Rename(target, previous); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Rename(staging, target); err != nil {
+		// Put the old install back
+		os.Rename(previous, target)
+		return err
+	}
+	os.RemoveAll(previous)
+	return os.RemoveAll(filepath.Join(disabledDir, pkg.ID))
+}
+
+// SetEnabled moves package id from disabledDir back into dir, or from dir
+// into disabledDir, so the game no longer loads it.
+func SetEnabled(dir, disabledDir, id string, enabled bool) error {
+	from, to := filepath.Join(disabledDir, id), filepath.Join(dir, id)
+	if !enabled {
+		from, to = to, from
+	}
+	if isDir(to) && !isDir(from) {
+		return nil
+	}
+	if !isDir(from) {
+		return ErrNotInstalled
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(to); err != nil {
+		return err
+	}
+	if err := os.Rename(from, to); err == nil {
+		return nil
+	}
+	// The directories may be on different drives
+	if err := copyTree(from, to); err != nil {
+		os.RemoveAll(to)
+		return err
+	}
+	return os.RemoveAll(from)
+}
+
+// fetch writes the package source to w and returns its hex SHA-256.
+func fetch(ctx context.Context, client *http.Client, pkg config.BridgePackage, w io.Writer) (string, error) {
+	var body io.ReadCloser
+	if pkg.IsURL() {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, pkg.Source, nil)
+		if err != nil {
+			return "", err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return "", fmt.Errorf("download answered %s", resp.Status)
+		}
+		body = resp.Body
+	} else {
+		file, err := os.Open(pkg.Source)
+		if err != nil {
+			return "", err
+		}
+		body = file
+	}
+	defer body.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(w, hash), body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// sourceName is the file name at the end of a source path or URL.
+func sourceName(source string) string {
+	if u, err := url.Parse(source); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		return path.Base(u.Path)
+	}
+	return filepath.Base(source)
+}
+
+func unzip(archivePath, root string) error {
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	prefix := commonFolder(archive.File)
+	for _, file := range archive.File {
+		name := strings.TrimPrefix(file.Name, prefix)
+		if name == "" {
+			continue
+		}
+		name = filepath.FromSlash(name)
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("entry %q points outside the package", file.Name)
+		}
+		target := filepath.Join(root, name)
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := extractFile(file, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commonFolder returns "name/" when every entry of the archive is inside the
+// same top-level folder, and "" otherwise.
+func commonFolder(files []*zip.File) string {
+	prefix := ""
+	for _, file := range files {
+		first, _, nested := strings.Cut(file.Name, "/")
+		if !nested {
+			return ""
+		}
+		if prefix == "" {
+			prefix = first + "/"
+		} else if prefix != first+"/" {
+			return ""
+		}
+	}
+	return prefix
+}
+
+func extractFile(file *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	in, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode().Perm()|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func copyFile(from, to string) error {
+	in, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func copyTree(from, to string) error {
+	return filepath.WalkDir(from, func(current string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(from, current)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(to, rel)
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		return copyFile(current, target)
+	})
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,97 @@
+package bridgepkg
+
+import (
+	"archive/zip"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// writeZipForTest writes an archive holding files, keyed by slash path.
+func writeZipForTest(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	out, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(out)
+	for name, content := range files {
+		entry, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	out.Close()
+}
+
+func TestInstallUnpacksWrappedArchiveAndReplacesEarlierInstall(t *testing.T) {
+	root := t.TempDir()
+	archive := filepath.Join(root, "bridge.zip")
+	writeZipForTest(t, archive, map[string]string{"Bridge-1.2/About/About.xml": "v2", "Bridge-1.2/Bridge.dll": "dll"})
+	dir := filepath.Join(root, "plugins")
+	if err := os.MkdirAll(filepath.Join(dir, "bridge"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "bridge", "old.dll"), []byte("old"), 0644)
+
+	pkg := config.BridgePackage{ID: "bridge", Source: archive}
+	if err := Install(context.Background(), http.DefaultClient, pkg, dir, filepath.Join(root, "disabled")); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "bridge", "About", "About.xml")); err != nil || string(data) != "v2" {
+		t.Fatalf("expected the archive's folder to be unwrapped, got %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bridge", "old.dll")); !os.IsNotExist(err) {
+		t.Fatalf("expected the earlier install to be replaced, got %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("expected no temporary files to be left behind, got %d entries", len(entries))
+	}
+}
+
+func TestInstallRejectsChecksumMismatch(t *testing.T) {
+	root := t.TempDir()
+	source := filepath.Join(root, "Bridge.dll")
+	os.WriteFile(source, []byte("dll"), 0644)
+	pkg := config.BridgePackage{ID: "bridge", Source: source, SHA256: strings.Repeat("0", 64)}
+	err := Install(context.Background(), http.DefaultClient, pkg, filepath.Join(root, "plugins"), filepath.Join(root, "disabled"))
+	if err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Fatalf("expected a checksum error, got %v", err)
+	}
+	if State(filepath.Join(root, "plugins"), filepath.Join(root, "disabled"), "bridge") != StateNotInstalled {
+		t.Fatal("expected nothing to be installed")
+	}
+}
+
+func TestSetEnabledMovesPackageOutOfPluginDirectory(t *testing.T) {
+	root := t.TempDir()
+	dir, disabledDir := filepath.Join(root, "plugins"), filepath.Join(root, "disabled")
+	if err := SetEnabled(dir, disabledDir, "bridge", true); err != ErrNotInstalled {
+		t.Fatalf("expected ErrNotInstalled, got %v", err)
+	}
+	os.MkdirAll(filepath.Join(dir, "bridge"), 0755)
+	os.WriteFile(filepath.Join(dir, "bridge", "Bridge.dll"), []byte("dll"), 0644)
+
+	if err := SetEnabled(dir, disabledDir, "bridge", false); err != nil {
+		t.Fatalf("disable: %v", err)
+	}
+	if state := State(dir, disabledDir, "bridge"); state != StateDisabled {
+		t.Fatalf("expected disabled, got %s", state)
+	}
+	if err := SetEnabled(dir, disabledDir, "bridge", true); err != nil {
+		t.Fatalf("enable: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "bridge", "Bridge.dll")); string(data) != "dll" {
+		t.Fatalf("expected the package back in the plugin directory, got %q", data)
+	}
+}
@@ -0,0 +1,164 @@
+// Package chaos injects failures into a running GABS server so developers can
+// check that reconnect, cleanup and notification paths recover. It is only
+// enabled through the developer --chaos flag and must never be on by default.
+package chaos
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config selects which failures to inject. The zero value injects nothing.
+type Config struct {
+	Seed               int64         // Random seed; zero picks one from the clock
+	DisconnectInterval time.Duration // Average time between dropped GABP connections
+	MaxToolDelay       time.Duration // Upper bound for extra latency before each GABP tool call
+	PortFailureRate    float64       // Probability in [0, 1] that a bridge port allocation fails
+	KillInterval       time.Duration // Average time between killing a random running game
+}
+
+// ParseSpec parses a comma-separated list such as
+// "disconnect=30s,delay=2s,portfail=0.2,kill=5m,seed=42".
+func ParseSpec(spec string) (Config, error) {
+	var cfg Config
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Config{}, fmt.Errorf("chaos option '%s' must be key=value", part)
+		}
+
+		var err error
+		switch key {
+		case "disconnect":
+			cfg.DisconnectInterval, err = parsePositiveDuration(value)
+		case "delay":
+			cfg.MaxToolDelay, err = parsePositiveDuration(value)
+		case "kill":
+			cfg.KillInterval, err = parsePositiveDuration(value)
+		case "portfail":
+			cfg.PortFailureRate, err = strconv.ParseFloat(value, 64)
+			if err == nil && (cfg.PortFailureRate < 0 || cfg.PortFailureRate > 1) {
+				err = fmt.Errorf("must be between 0 and 1")
+			}
+		case "seed":
+			cfg.Seed, err = strconv.ParseInt(value, 10, 64)
+		default:
+			return Config{}, fmt.Errorf("unknown chaos option '%s'", key)
+		}
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid chaos option '%s': %w", part, err)
+		}
+	}
+	return cfg, nil
+}
+
+func parsePositiveDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return d, nil
+}
+
+// Enabled reports whether any failure is configured.
+func (c Config) Enabled() bool {
+	return c.DisconnectInterval > 0 || c.MaxToolDelay > 0 || c.PortFailureRate > 0 || c.KillInterval > 0
+}
+
+// Injector makes the random decisions for a Config. A nil Injector injects
+// nothing, so callers can hold one unconditionally.
+type Injector struct {
+	cfg Config
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewInjector returns an injector for cfg.
+func NewInjector(cfg Config) *Injector {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Injector{cfg: cfg, rng: rand.New(rand.NewSource(seed))}
+}
+
+// Config returns the injector's configuration.
+func (i *Injector) Config() Config {
+	if i == nil {
+		return Config{}
+	}
+	return i.cfg
+}
+
+// ToolDelay returns the extra latency to add before a GABP tool call.
+func (i *Injector) ToolDelay() time.Duration {
+	if i == nil || i.cfg.MaxToolDelay <= 0 {
+		return 0
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return time.Duration(i.rng.Int63n(int64(i.cfg.MaxToolDelay) + 1))
+}
+
+// PortAllocationFault returns an error when a port allocation should fail.
+func (i *Injector) PortAllocationFault() error {
+	if i == nil || i.cfg.PortFailureRate <= 0 {
+		return nil
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.rng.Float64() < i.cfg.PortFailureRate {
+		return fmt.Errorf("chaos: injected port allocation failure")
+	}
+	return nil
+}
+
+// Pick returns a random index below n, or -1 when n is zero.
+func (i *Injector) Pick(n int) int {
+	if i == nil || n <= 0 {
+		return -1
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.rng.Intn(n)
+}
+
+// NextDisconnect returns the wait before the next dropped connection, or zero
+// when disconnects are disabled.
+func (i *Injector) NextDisconnect() time.Duration {
+	if i == nil {
+		return 0
+	}
+	return i.jittered(i.cfg.DisconnectInterval)
+}
+
+// NextKill returns the wait before the next killed game, or zero when kills
+// are disabled.
+func (i *Injector) NextKill() time.Duration {
+	if i == nil {
+		return 0
+	}
+	return i.jittered(i.cfg.KillInterval)
+}
+
+// jittered spreads waits between half and one and a half times the interval
+// so injected failures do not line up with periodic work.
+func (i *Injector) jittered(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return 0
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return interval/2 + time.Duration(i.rng.Int63n(int64(interval)+1))
+}
@@ -0,0 +1,65 @@
+package chaos
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSpec(t *testing.T) {
+	cfg, err := ParseSpec("disconnect=30s, delay=2s,portfail=0.25,kill=5m,seed=42")
+	if err != nil {
+		t.Fatalf("ParseSpec failed: %v", err)
+	}
+	want := Config{Seed: 42, DisconnectInterval: 30 * time.Second, MaxToolDelay: 2 * time.Second, PortFailureRate: 0.25, KillInterval: 5 * time.Minute}
+	if cfg != want {
+		t.Fatalf("unexpected config %+v, want %+v", cfg, want)
+	}
+	if !cfg.Enabled() {
+		t.Fatal("expected parsed config to be enabled")
+	}
+
+	if cfg, err := ParseSpec(""); err != nil || cfg.Enabled() {
+		t.Fatalf("empty spec should disable chaos, got %+v %v", cfg, err)
+	}
+
+	for _, spec := range []string{"disconnect", "disconnect=-1s", "portfail=1.5", "explode=1s", "seed=abc"} {
+		if _, err := ParseSpec(spec); err == nil {
+			t.Errorf("expected error for spec %q", spec)
+		}
+	}
+}
+
+func TestNilInjectorInjectsNothing(t *testing.T) {
+	var injector *Injector
+	if injector.ToolDelay() != 0 || injector.PortAllocationFault() != nil || injector.Pick(3) != -1 ||
+		injector.NextDisconnect() != 0 || injector.NextKill() != 0 || injector.Config().Enabled() {
+		t.Fatal("nil injector should not inject failures")
+	}
+}
+
+func TestInjectorDecisionsStayWithinConfig(t *testing.T) {
+	injector := NewInjector(Config{Seed: 1, MaxToolDelay: 100 * time.Millisecond, PortFailureRate: 1, DisconnectInterval: time.Second})
+
+	for i := 0; i < 100; i++ {
+		if delay := injector.ToolDelay(); delay < 0 || delay > 100*time.Millisecond {
+			t.Fatalf("tool delay %v out of range", delay)
+		}
+		if wait := injector.NextDisconnect(); wait < 500*time.Millisecond || wait > 1500*time.Millisecond {
+			t.Fatalf("disconnect wait %v out of range", wait)
+		}
+		if index := injector.Pick(3); index < 0 || index > 2 {
+			t.Fatalf("pick %d out of range", index)
+		}
+		if injector.PortAllocationFault() == nil {
+			t.Fatal("port failure rate 1 should always fail")
+		}
+	}
+	if injector.NextKill() != 0 {
+		t.Fatal("kills are not configured")
+	}
+
+	never := NewInjector(Config{Seed: 1, PortFailureRate: 0})
+	if never.PortAllocationFault() != nil {
+		t.Fatal("port failure rate 0 should never fail")
+	}
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Built-in access roles. Additional role names can be assigned through APIKeys.
+const (
+	// AccessRoleLocal is used for stdio clients, which run as the local user.
+	AccessRoleLocal = "local"
+	// AccessRoleAdmin is used for HTTP requests authenticated with the main apiKey.
+	AccessRoleAdmin = "admin"
+	// AccessRoleAnonymous is used for HTTP requests when no API key is configured.
+	AccessRoleAnonymous = "anonymous"
+)
+
+// APIKeyConfig defines an additional HTTP API key and the role it grants.
+type APIKeyConfig struct {
+	Key       string `json:"key"`
+	Role      string `json:"role"`
+	Name      string `json:"name,omitempty"`      // Label used in logs and errors instead of the secret key
+	RateLimit int    `json:"rateLimit,omitempty"` // Requests allowed per minute, 0 for no limit
+	// ToolProfile limits the sessions of this key to the tools of one of the
+	// toolProfiles. Empty leaves them every tool their role allows.
+	ToolProfile string `json:"toolProfile,omitempty"`
+}
+
+// Label returns the key's name, or its role when it has none.
+func (k APIKeyConfig) Label() string {
+	if k.Name != "" {
+		return k.Name
+	}
+	return k.Role + " key"
+}
+
+// Validate checks that the key grants a role and has a sane rate limit.
+func (k APIKeyConfig) Validate() error {
+	if k.Key == "" || k.Role == "" {
+		return fmt.Errorf("key and role are required")
+	}
+	if k.RateLimit < 0 {
+		return fmt.Errorf("key '%s' needs a rateLimit of 0 or more requests per minute", k.Label())
+	}
+	return nil
+}
+
+// LoadAPIKeyFile reads HTTP API keys kept outside config.json, so the secrets
+// can live in a file with tighter permissions. The file holds a JSON array of
+// entries shaped like apiKeys.
+func LoadAPIKeyFile(path string) ([]APIKeyConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API key file: %w", err)
+	}
+	var keys []APIKeyConfig
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse API key file %s: %w", path, err)
+	}
+	for i, key := range keys {
+		if err := key.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid entry %d in API key file %s: %w", i+1, path, err)
+		}
+	}
+	return keys, nil
+}
+
+// MatchAPIKey returns the entry in keys whose key equals key. Keys are
+// compared in constant time.
+func MatchAPIKey(keys []APIKeyConfig, key string) (APIKeyConfig, bool) {
+	if key == "" {
+		return APIKeyConfig{}, false
+	}
+	for _, candidate := range keys {
+		if candidate.Key != "" && subtle.ConstantTimeCompare([]byte(candidate.Key), []byte(key)) == 1 {
+			return candidate, true
+		}
+	}
+	return APIKeyConfig{}, false
+}
+
+// ResourceAccessRule allows or denies MCP resources by URI pattern. A '*' in
+// the pattern matches any run of characters, including '/'. Role lists may
+// contain "*" to match every role.
+type ResourceAccessRule struct {
+	URI   string   `json:"uri"`
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// Validate checks that the rule has a pattern and at least one role list.
+func (r ResourceAccessRule) Validate() error {
+	if strings.TrimSpace(r.URI) == "" {
+		return fmt.Errorf("resource access rule requires a uri pattern")
+	}
+	if len(r.Allow) == 0 && len(r.Deny) == 0 {
+		return fmt.Errorf("resource access rule for '%s' needs allow or deny roles", r.URI)
+	}
+	return nil
+}
+
+// RoleForAPIKey returns the role granted by an HTTP bearer key. The main
+// APIKey grants AccessRoleAdmin.
+func (c *GamesConfig) RoleForAPIKey(key string) (string, bool) {
+	apiKey, ok := c.LookupAPIKey(key)
+	return apiKey.Role, ok
+}
+
+// LookupAPIKey returns the configured entry for an HTTP bearer key. The main
+// APIKey is returned as an unlimited entry named "apiKey" with the admin role.
+func (c *GamesConfig) LookupAPIKey(key string) (APIKeyConfig, bool) {
+	if c == nil {
+		return APIKeyConfig{}, false
+	}
+	keys := c.APIKeys
+	if c.APIKey != "" {
+		keys = append([]APIKeyConfig{{Key: c.APIKey, Role: AccessRoleAdmin, Name: "apiKey"}}, keys...)
+	}
+	return MatchAPIKey(keys, key)
+}
+
+// ResourceAllowed reports whether role may list and read the resource at uri.
+// The first rule whose pattern matches decides; resources without a matching
+// rule are allowed.
+func (c *GamesConfig) ResourceAllowed(role, uri string) bool {
+	if c == nil {
+		return true
+	}
+	for _, rule := range c.ResourceAccess {
+		if !MatchURIPattern(rule.URI, uri) {
+			continue
+		}
+		if roleListContains(rule.Deny, role) {
+			return false
+		}
+		if len(rule.Allow) > 0 {
+			return roleListContains(rule.Allow, role)
+		}
+		return true
+	}
+	return true
+}
+
+// MatchURIPattern matches uri against a pattern where '*' matches any run of
+// characters.
+func MatchURIPattern(pattern, uri string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == uri
+	}
+	if !strings.HasPrefix(uri, parts[0]) {
+		return false
+	}
+	uri = uri[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		index := strings.Index(uri, part)
+		if index < 0 {
+			return false
+		}
+		uri = uri[index+len(part):]
+	}
+	return strings.HasSuffix(uri, parts[len(parts)-1])
+}
+
+func roleListContains(roles []string, role string) bool {
+	for _, candidate := range roles {
+		if candidate == "*" || candidate == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMatchURIPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		uri     string
+		want    bool
+	}{
+		{"gab://factory/state", "gab://factory/state", true},
+		{"gab://factory/state", "gab://factory/state/x", false},
+		{"gab://*/files/*", "gab://factory/files/saves/world.dat", true},
+		{"gab://*/files/*", "gab://factory/state", false},
+		{"gab://factory/*", "gab://adventure/state", false},
+		{"*", "gabs://health", true},
+		{"gab://*/events/*/stream", "gab://factory/events/chat/stream", true},
+	}
+	for _, tt := range tests {
+		if got := MatchURIPattern(tt.pattern, tt.uri); got != tt.want {
+			t.Errorf("MatchURIPattern(%q, %q) = %v, want %v", tt.pattern, tt.uri, got, tt.want)
+		}
+	}
+}
+
+func TestResourceAllowedUsesFirstMatchingRule(t *testing.T) {
+	cfg := &GamesConfig{
+		APIKey: "main-key",
+		APIKeys: []APIKeyConfig{
+			{Key: "viewer-key", Role: "readonly"},
+		},
+		ResourceAccess: []ResourceAccessRule{
+			{URI: "gab://*/files/*", Deny: []string{"readonly", AccessRoleAnonymous}},
+			{URI: "gab://*/secrets/*", Allow: []string{AccessRoleLocal}},
+			{URI: "gab://*", Allow: []string{"*"}},
+		},
+	}
+
+	role, ok := cfg.RoleForAPIKey("viewer-key")
+	if !ok || role != "readonly" {
+		t.Fatalf("expected readonly role, got %q %v", role, ok)
+	}
+	if role, ok := cfg.RoleForAPIKey("main-key"); !ok || role != AccessRoleAdmin {
+		t.Fatalf("expected admin role for main key, got %q %v", role, ok)
+	}
+	if _, ok := cfg.RoleForAPIKey("unknown"); ok {
+		t.Fatal("unknown key should not resolve to a role")
+	}
+
+	checks := []struct {
+		role string
+		uri  string
+		want bool
+	}{
+		{"readonly", "gab://factory/files/world.dat", false},
+		{AccessRoleAdmin, "gab://factory/files/world.dat", true},
+		{"readonly", "gab://factory/state", true},
+		{AccessRoleAdmin, "gab://factory/secrets/token", false},
+		{AccessRoleLocal, "gab://factory/secrets/token", true},
+		{"readonly", "gabs://health", true},
+	}
+	for _, check := range checks {
+		if got := cfg.ResourceAllowed(check.role, check.uri); got != check.want {
+			t.Errorf("ResourceAllowed(%q, %q) = %v, want %v", check.role, check.uri, got, check.want)
+		}
+	}
+}
+
+func TestResourceAccessRuleValidate(t *testing.T) {
+	if err := (ResourceAccessRule{URI: "gab://*"}).Validate(); err == nil {
+		t.Fatal("expected rule without roles to fail validation")
+	}
+	if err := (ResourceAccessRule{Deny: []string{"readonly"}}).Validate(); err == nil {
+		t.Fatal("expected rule without uri to fail validation")
+	}
+}
+
+func TestLoadAPIKeyFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "keys.json")
+	if err := os.WriteFile(path, []byte(`[{"key":"ci-key","role":"readonly","name":"ci","rateLimit":30}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := LoadAPIKeyFile(path)
+	if err != nil {
+		t.Fatalf("LoadAPIKeyFile: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Label() != "ci" || keys[0].RateLimit != 30 {
+		t.Fatalf("unexpected keys: %#v", keys)
+	}
+	if key, ok := MatchAPIKey(keys, "ci-key"); !ok || key.Role != "readonly" {
+		t.Fatalf("expected the file key to match, got %#v %v", key, ok)
+	}
+
+	if err := os.WriteFile(path, []byte(`[{"key":"ci-key","role":"readonly","rateLimit":-1}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAPIKeyFile(path); err == nil || !strings.Contains(err.Error(), "rateLimit") {
+		t.Fatalf("expected a negative rateLimit to be rejected, got %v", err)
+	}
+}
+
+func TestToolAllowedCombinesGlobalAndGamePolicies(t *testing.T) {
+	cfg := &GamesConfig{
+		Games: map[string]GameConfig{
+			"factory": {ID: "factory", ToolPolicy: []ToolPolicyConfig{{Allow: []string{"inventory.*", "world.get_*"}}}},
+		},
+		ToolPolicy: []ToolPolicyConfig{
+			{Roles: []string{"readonly"}, Deny: []string{"games.kill", "*.world.*"}},
+		},
+	}
+
+	checks := []struct {
+		role, gameID, name string
+		want               bool
+	}{
+		{"readonly", "", "games.kill", false},
+		{AccessRoleAdmin, "", "games.kill", true},
+		{AccessRoleLocal, "factory", "inventory/get", true},
+		{AccessRoleLocal, "factory", "world/set_block", false},
+		{AccessRoleLocal, "factory", "world/get_block", true},
+		{"readonly", "factory", "world/get_block", false},
+		{"readonly", "adventure", "quest/list", true},
+	}
+	for _, check := range checks {
+		if got := cfg.ToolAllowed(check.role, check.gameID, check.name); got != check.want {
+			t.Errorf("ToolAllowed(%q, %q, %q) = %v, want %v", check.role, check.gameID, check.name, got, check.want)
+		}
+	}
+
+	if err := (ToolPolicyConfig{Roles: []string{"readonly"}}).Validate(); err == nil {
+		t.Fatal("expected a policy without patterns to fail validation")
+	}
+}
+
+func TestToolInProfile(t *testing.T) {
+	cfg := &GamesConfig{
+		ToolProfiles: map[string]ToolProfile{
+			"readonly": {Allow: []string{"games.list", "games.status", "*.inventory.*"}, Deny: []string{"*.inventory.clear"}},
+		},
+	}
+
+	checks := []struct {
+		profile, gameID, name string
+		want                  bool
+	}{
+		{"", "", "games.kill", true},
+		{"readonly", "", "games.status", true},
+		{"readonly", "", "games.kill", false},
+		{"readonly", "factory", "inventory/get", true},
+		{"readonly", "factory", "inventory/clear", false},
+		{"missing", "", "games.status", false},
+	}
+	for _, check := range checks {
+		if got := cfg.ToolInProfile(check.profile, check.gameID, check.name); got != check.want {
+			t.Errorf("ToolInProfile(%q, %q, %q) = %v, want %v", check.profile, check.gameID, check.name, got, check.want)
+		}
+	}
+
+	cfg.APIKeys = []APIKeyConfig{{Key: "k", Role: "viewer", ToolProfile: "missing"}}
+	if err := cfg.validateToolProfiles(); err == nil {
+		t.Fatal("expected an API key with an unknown toolProfile to fail validation")
+	}
+}
+
+func TestRequiresConfirmationMatchesToolsAndTags(t *testing.T) {
+	cfg := &GamesConfig{Confirmation: &ConfirmationConfig{Tools: []string{"games.kill", "factory.world.*"}, Tags: []string{"destructive"}}}
+
+	checks := []struct {
+		gameID, name string
+		tags         []string
+		want         bool
+	}{
+		{"", "games.kill", nil, true},
+		{"", "games.stop", nil, false},
+		{"factory", "world/reset", nil, true},
+		{"factory", "inventory/get", nil, false},
+		{"adventure", "save/delete", []string{"destructive"}, true},
+	}
+	for _, check := range checks {
+		if got := cfg.RequiresConfirmation(check.gameID, check.name, check.tags); got != check.want {
+			t.Errorf("RequiresConfirmation(%q, %q, %v) = %v, want %v", check.gameID, check.name, check.tags, got, check.want)
+		}
+	}
+
+	if err := (ConfirmationConfig{Tools: []string{"games.kill"}, TTL: "soon"}).Validate(); err == nil {
+		t.Fatal("expected an unparseable ttl to fail validation")
+	}
+	if err := (ConfirmationConfig{TTL: "1m"}).Validate(); err == nil {
+		t.Fatal("expected confirmation settings without tools or tags to fail validation")
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultAlertLevel is the MCP logging level of alerts that name none.
+const DefaultAlertLevel = "warning"
+
+// alertLevels are the MCP logging levels an alert can be sent with.
+var alertLevels = []string{"debug", "info", "notice", "warning", "error", "critical", "alert", "emergency"}
+
+// AlertConfig turns GABP events of a game, such as player_died or low_tps,
+// into alerts that are pushed to agents as MCP notifications instead of
+// waiting for them to poll games_events.
+type AlertConfig struct {
+	Channels []string `json:"channels"`           // GABP event channel patterns, '*' matches any run of characters
+	Level    string   `json:"level,omitempty"`    // MCP logging level, default "warning"
+	Message  string   `json:"message,omitempty"`  // Text of the alert; empty names the game and channel
+	Throttle string   `json:"throttle,omitempty"` // Minimum time between alerts of one channel, such as "30s"; empty sends every event
+}
+
+// Matches reports whether events of the GABP channel raise this alert.
+func (a AlertConfig) Matches(channel string) bool {
+	for _, pattern := range a.Channels {
+		if MatchURIPattern(pattern, channel) {
+			return true
+		}
+	}
+	return false
+}
+
+// AlertLevel returns the logging level the alert is sent with.
+func (a AlertConfig) AlertLevel() string {
+	if a.Level == "" {
+		return DefaultAlertLevel
+	}
+	return a.Level
+}
+
+// ThrottleDuration returns the minimum time between alerts of one channel,
+// or 0 when every event raises one.
+func (a AlertConfig) ThrottleDuration() (time.Duration, error) {
+	if a.Throttle == "" {
+		return 0, nil
+	}
+	throttle, err := time.ParseDuration(a.Throttle)
+	if err != nil {
+		return 0, fmt.Errorf("alert for %s has an invalid throttle '%s': use a duration such as \"30s\"", strings.Join(a.Channels, ","), a.Throttle)
+	}
+	if throttle < 0 {
+		return 0, fmt.Errorf("alert for %s needs a throttle of 0s or more", strings.Join(a.Channels, ","))
+	}
+	return throttle, nil
+}
+
+// Validate checks the channel patterns, level and throttle.
+func (a AlertConfig) Validate() error {
+	if len(a.Channels) == 0 {
+		return fmt.Errorf("alerts need at least one channel pattern")
+	}
+	for _, pattern := range a.Channels {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("alert channel patterns must not be empty")
+		}
+	}
+	if a.Level != "" && !isAlertLevel(a.Level) {
+		return fmt.Errorf("alert for %s has an unknown level '%s': use one of %s", strings.Join(a.Channels, ","), a.Level, strings.Join(alertLevels, ", "))
+	}
+	_, err := a.ThrottleDuration()
+	return err
+}
+
+func isAlertLevel(level string) bool {
+	for _, known := range alertLevels {
+		if level == known {
+			return true
+		}
+	}
+	return false
+}
+
+// AlertFor returns the first alert that events of the GABP channel raise.
+func (g GameConfig) AlertFor(channel string) (AlertConfig, bool) {
+	for _, alert := range g.Alerts {
+		if alert.Matches(channel) {
+			return alert, true
+		}
+	}
+	return AlertConfig{}, false
+}
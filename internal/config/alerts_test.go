@@ -0,0 +1,26 @@
+package config
+
+import "testing"
+
+func TestAlertConfigValidate(t *testing.T) {
+	for _, alert := range []AlertConfig{
+		{},
+		{Channels: []string{"player/died"}, Level: "loud"},
+		{Channels: []string{"player/died"}, Throttle: "soon"},
+	} {
+		if err := alert.Validate(); err == nil {
+			t.Errorf("expected %#v to fail validation", alert)
+		}
+	}
+	if err := (AlertConfig{Channels: []string{"server/*"}, Throttle: "1m"}).Validate(); err != nil {
+		t.Fatalf("expected a valid alert, got %v", err)
+	}
+
+	game := GameConfig{Alerts: []AlertConfig{{Channels: []string{"server/*"}}, {Channels: []string{"server/crashed"}, Level: "critical"}}}
+	if alert, ok := game.AlertFor("server/crashed"); !ok || alert.AlertLevel() != DefaultAlertLevel {
+		t.Fatalf("expected the first matching alert to win, got %#v", alert)
+	}
+	if _, ok := game.AlertFor("player/joined"); ok {
+		t.Fatal("expected no alert for an unmatched channel")
+	}
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// BridgePackagesConfig tells GABS where a game loads plugins from and which
+// GABP bridge packages can be installed there.
+type BridgePackagesConfig struct {
+	Dir      string          `json:"dir"`                // Plugin directory, absolute or relative to WorkingDir
+	Packages []BridgePackage `json:"packages,omitempty"` // Known bridge packages
+}
+
+// BridgePackage is one installable GABP bridge. Source is an http(s) URL or
+// an absolute path of a .zip archive, which is unpacked, or of a single file.
+type BridgePackage struct {
+	ID          string `json:"id"` // Folder name inside Dir
+	Source      string `json:"source"`
+	SHA256      string `json:"sha256,omitempty"` // Expected checksum of Source, hex encoded
+	Description string `json:"description,omitempty"`
+}
+
+// BridgePackagesDir returns the game's plugin directory, or "" when bridge
+// packages are not configured.
+func (g GameConfig) BridgePackagesDir() string {
+	if g.BridgePackages == nil {
+		return ""
+	}
+	dir := strings.TrimSpace(g.BridgePackages.Dir)
+	if dir == "" || filepath.IsAbs(dir) || g.WorkingDir == "" {
+		return dir
+	}
+	return filepath.Join(g.WorkingDir, dir)
+}
+
+// BridgePackage returns the known bridge package with the given ID.
+func (g GameConfig) BridgePackage(id string) (BridgePackage, bool) {
+	if g.BridgePackages == nil {
+		return BridgePackage{}, false
+	}
+	for _, pkg := range g.BridgePackages.Packages {
+		if pkg.ID == id {
+			return pkg, true
+		}
+	}
+	return BridgePackage{}, false
+}
+
+// IsURL reports whether the package is downloaded rather than copied.
+func (p BridgePackage) IsURL() bool {
+	return strings.HasPrefix(p.Source, "https://") || strings.HasPrefix(p.Source, "http://")
+}
+
+func (g GameConfig) validateBridgePackages() error {
+	if g.BridgePackages == nil {
+		return nil
+	}
+	dir := strings.TrimSpace(g.BridgePackages.Dir)
+	if dir == "" {
+		return fmt.Errorf("bridgePackages requires a dir")
+	}
+	if !filepath.IsAbs(dir) && strings.TrimSpace(g.WorkingDir) == "" {
+		return fmt.Errorf("bridgePackages.dir '%s' is relative, which requires workingDir to be set", dir)
+	}
+
+	ids := make(map[string]bool, len(g.BridgePackages.Packages))
+	for _, pkg := range g.BridgePackages.Packages {
+		if pkg.ID == "" || !filepath.IsLocal(pkg.ID) || filepath.Base(pkg.ID) != pkg.ID {
+			return fmt.Errorf("bridge package id '%s' must be a plain folder name", pkg.ID)
+		}
+		if ids[pkg.ID] {
+			return fmt.Errorf("bridge package id '%s' is used twice", pkg.ID)
+		}
+		ids[pkg.ID] = true
+
+		if pkg.IsURL() {
+			if _, err := url.Parse(pkg.Source); err != nil {
+				return fmt.Errorf("bridge package '%s' has an invalid source URL: %w", pkg.ID, err)
+			}
+		} else if !filepath.IsAbs(pkg.Source) {
+			return fmt.Errorf("bridge package '%s' needs a source that is an http(s) URL or an absolute path", pkg.ID)
+		}
+		if pkg.SHA256 != "" {
+			if sum, err := hex.DecodeString(pkg.SHA256); err != nil || len(sum) != 32 {
+				return fmt.Errorf("bridge package '%s' sha256 must be 64 hex digits", pkg.ID)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// encryptedTokenPrefix marks a bridge.json token that is encrypted with the
+// machine key. The version lets the scheme change without misreading old
+// files.
+const encryptedTokenPrefix = "enc:v1:"
+
+// bridgeMachineID is the secret the token key is derived from; tests replace
+// it because containers often have no machine ID.
+var bridgeMachineID = machineID
+
+// bridgeTokenKey derives the AES-256 key for bridge tokens from the machine
+// ID. Files encrypted with it can only be read on the same installation, so
+// a copied bridge.json or backup does not leak a usable token.
+func bridgeTokenKey() ([]byte, error) {
+	id, err := bridgeMachineID()
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, []byte(id))
+	mac.Write([]byte("gabs bridge token v1"))
+	return mac.Sum(nil), nil
+}
+
+func bridgeTokenCipher() (cipher.AEAD, error) {
+	key, err := bridgeTokenKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptBridgeToken seals token for gameID's bridge.json. The game ID is
+// authenticated too, so a token cannot be moved to another game's file.
+func encryptBridgeToken(gameID, token string) (string, error) {
+	aead, err := bridgeTokenCipher()
+	if err != nil {
+		return "", fmt.Errorf("failed to derive the bridge token key: %w", err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(token), []byte(gameID))
+	return encryptedTokenPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptBridgeToken opens a token written by encryptBridgeToken. Tokens
+// without the prefix are returned as they are.
+func decryptBridgeToken(gameID, stored string) (string, bool, error) {
+	encoded, encrypted := strings.CutPrefix(stored, encryptedTokenPrefix)
+	if !encrypted {
+		return stored, false, nil
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", true, fmt.Errorf("invalid encrypted token: %w", err)
+	}
+	aead, err := bridgeTokenCipher()
+	if err != nil {
+		return "", true, fmt.Errorf("failed to derive the bridge token key: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", true, fmt.Errorf("invalid encrypted token: too short")
+	}
+	token, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(gameID))
+	if err != nil {
+		return "", true, fmt.Errorf("the token was encrypted on another machine or for another game")
+	}
+	return string(token), true, nil
+}
@@ -10,14 +10,45 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 )
 
 type BridgeJSON struct {
 	Port   int    `json:"port"`
 	Token  string `json:"token"`
 	GameId string `json:"gameId"`
+	// Transport selects how GABS and the bridge talk: "tcp" (default) on
+	// 127.0.0.1:Port or "unix" on the Unix domain socket at Socket. Users
+	// choose it by editing bridge.json; GABS keeps it when it rotates the
+	// endpoint.
+	Transport string `json:"transport,omitempty"`
+	Socket    string `json:"socket,omitempty"`
+	// IssuedAt is when Token was generated; timeouts.bridge.tokenTtlSeconds
+	// counts from here. Files written before tokens expired lack it and count
+	// as expired once a TTL is configured.
+	IssuedAt time.Time `json:"issuedAt"`
+
+	encrypted bool  // Token is stored encrypted; see bridgeFiles.encryptTokens
+	tokenErr  error // Why an encrypted token could not be read
 }
 
+// TokenEncrypted reports whether the file stores the token encrypted.
+func (b BridgeJSON) TokenEncrypted() bool {
+	return b.encrypted
+}
+
+// TokenExpired reports whether the token is older than ttl. A ttl of 0 never
+// expires.
+func (b BridgeJSON) TokenExpired(ttl time.Duration, now time.Time) bool {
+	return ttl > 0 && now.Sub(b.IssuedAt) >= ttl
+}
+
+// Bridge transports, see BridgeJSON.Transport.
+const (
+	BridgeTransportTCP  = "tcp"
+	BridgeTransportUnix = "unix"
+)
+
 type BridgeEndpointInUseError struct {
 	GameID     string
 	Port       int
@@ -39,9 +70,10 @@ func WriteBridgeJSON(gameID, configDir string) (int, string, string, error) {
 // Returns (port, token, configPath, error)
 // Each game gets its own directory, ensuring concurrent launches of different games are properly isolated.
 // If gamesConfig is provided, uses custom port ranges from config; otherwise uses defaults.
+// The game's fixedPort, or else the port it leased last time, is used when it is free.
 func WriteBridgeJSONWithConfig(gameID, configDir string, gamesConfig *GamesConfig) (int, string, string, error) {
 	// Assign an available local port using config or fallback ranges.
-	port, err := assignPortWithConfig(gamesConfig)
+	port, err := assignBridgePort(gameID, configDir, gamesConfig)
 	if err != nil {
 		return 0, "", "", fmt.Errorf("failed to assign port: %w", err)
 	}
@@ -52,10 +84,13 @@ func WriteBridgeJSONWithConfig(gameID, configDir string, gamesConfig *GamesConfi
 		return 0, "", "", fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	cfgPath, err := WriteBridgeJSONWithEndpoint(gameID, configDir, port, token)
+	cfgPath, err := writeBridgeEndpoint(gameID, configDir, port, token, gamesConfig.EncryptsBridgeTokens())
 	if err != nil {
 		return 0, "", "", err
 	}
+	if err := writePortLease(gameID, configDir, port); err != nil {
+		return 0, "", "", err
+	}
 
 	return port, token, cfgPath, nil
 }
@@ -100,15 +135,30 @@ func PrepareBridgeEndpointForStart(gameID, configDir string, gamesConfig *GamesC
 	}
 
 	cfgPath := cp.GetBridgeConfigPath(gameID)
-	if bridge, err := readBridgeJSONFile(cfgPath); err == nil && validBridgeEndpoint(gameID, bridge) {
-		if !isPortAvailable(bridge.Port) {
+	if bridge, err := readBridgeJSONFile(cfgPath); err == nil && validBridgeEndpoint(gameID, bridge) && !movedToFixedPort(gameID, gamesConfig, bridge) {
+		if bridge.Transport != BridgeTransportUnix && !isPortAvailable(bridge.Port) {
 			return 0, "", cfgPath, false, &BridgeEndpointInUseError{
 				GameID:     gameID,
 				Port:       bridge.Port,
 				ConfigPath: cfgPath,
 			}
 		}
-		return bridge.Port, bridge.Token, cfgPath, true, nil
+		token := bridge.Token
+		if bridge.TokenExpired(gamesConfig.GetBridgeTokenTTL(), time.Now()) {
+			// Keep the port so firewall rules and tunnels still fit
+			if token, err = generateToken(); err != nil {
+				return 0, "", "", false, fmt.Errorf("failed to generate token: %w", err)
+			}
+		}
+		if token != bridge.Token || (gamesConfig.EncryptsBridgeTokens() && !bridge.encrypted) {
+			if _, err := writeBridgeEndpoint(gameID, configDir, bridge.Port, token, gamesConfig.EncryptsBridgeTokens()); err != nil {
+				return 0, "", "", false, err
+			}
+		} else if err := os.Chmod(cfgPath, 0600); err != nil {
+			// Files from older versions were readable by everyone
+			return 0, "", "", false, fmt.Errorf("failed to restrict bridge config: %w", err)
+		}
+		return bridge.Port, token, cfgPath, true, nil
 	}
 
 	port, token, path, err := WriteBridgeJSONWithConfig(gameID, configDir, gamesConfig)
@@ -118,8 +168,25 @@ func PrepareBridgeEndpointForStart(gameID, configDir string, gamesConfig *GamesC
 	return port, token, path, false, nil
 }
 
+// movedToFixedPort reports whether the game's fixedPort was set or changed
+// after bridge was written, so the endpoint has to move to it.
+func movedToFixedPort(gameID string, gamesConfig *GamesConfig, bridge BridgeJSON) bool {
+	if gamesConfig == nil || bridge.Transport == BridgeTransportUnix {
+		return false
+	}
+	game, exists := gamesConfig.GetGame(gameID)
+	return exists && game.FixedPort != 0 && game.FixedPort != bridge.Port
+}
+
 // WriteBridgeJSONWithEndpoint writes a specific bridge endpoint atomically.
+// The token stays encrypted if it was before.
 func WriteBridgeJSONWithEndpoint(gameID, configDir string, port int, token string) (string, error) {
+	return writeBridgeEndpoint(gameID, configDir, port, token, false)
+}
+
+// writeBridgeEndpoint writes a bridge endpoint atomically, encrypting the
+// token when encrypt is set or the previous file was encrypted.
+func writeBridgeEndpoint(gameID, configDir string, port int, token string, encrypt bool) (string, error) {
 	if port <= 0 || port > 65535 {
 		return "", fmt.Errorf("invalid bridge port %d", port)
 	}
@@ -136,12 +203,29 @@ func WriteBridgeJSONWithEndpoint(gameID, configDir string, port int, token strin
 	}
 
 	bridge := BridgeJSON{
-		Port:   port,
-		Token:  token,
-		GameId: gameID,
+		Port:     port,
+		Token:    token,
+		GameId:   gameID,
+		IssuedAt: time.Now().UTC(),
 	}
 
 	cfgPath := cp.GetBridgeConfigPath(gameID)
+	if previous, err := readBridgeJSONFile(cfgPath); err == nil {
+		// A new port or token does not change the transport the user chose
+		bridge.Transport = previous.Transport
+		bridge.Socket = previous.Socket
+		if previous.Token == token {
+			bridge.IssuedAt = previous.IssuedAt
+		}
+		encrypt = encrypt || previous.encrypted
+	}
+	if encrypt {
+		sealed, err := encryptBridgeToken(gameID, token)
+		if err != nil {
+			return "", err
+		}
+		bridge.Token = sealed
+	}
 	if err := writeBridgeJSONFile(cfgPath, bridge); err != nil {
 		return "", err
 	}
@@ -159,6 +243,9 @@ func readBridgeJSONFile(cfgPath string) (BridgeJSON, error) {
 	if err := json.Unmarshal(data, &bridge); err != nil {
 		return BridgeJSON{}, err
 	}
+	// An unreadable encrypted token leaves Token empty, so the endpoint is
+	// replaced like any other invalid one
+	bridge.Token, bridge.encrypted, bridge.tokenErr = decryptBridgeToken(bridge.GameId, bridge.Token)
 
 	return bridge, nil
 }
@@ -171,9 +258,15 @@ func writeBridgeJSONFile(cfgPath string, bridge BridgeJSON) error {
 		return fmt.Errorf("failed to marshal bridge config: %w", err)
 	}
 
-	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+	// The token lets any local process drive the game, so only the user may
+	// read the file. WriteFile keeps the mode of a left-over temp file.
+	if err := os.WriteFile(tempPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write temp config: %w", err)
 	}
+	if err := os.Chmod(tempPath, 0600); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("failed to restrict temp config: %w", err)
+	}
 
 	if err := os.Rename(tempPath, cfgPath); err != nil {
 		os.Remove(tempPath) // cleanup
@@ -190,6 +283,15 @@ func validBridgeEndpoint(gameID string, bridge BridgeJSON) bool {
 	return bridge.GameId == "" || bridge.GameId == gameID
 }
 
+// ReadBridgeEndpoint returns the game's bridge.json with its token decrypted.
+func ReadBridgeEndpoint(gameID, configDir string) (BridgeJSON, error) {
+	cp, err := NewConfigPaths(configDir)
+	if err != nil {
+		return BridgeJSON{}, fmt.Errorf("failed to create config paths: %w", err)
+	}
+	return readBridgeJSONFile(cp.GetBridgeConfigPath(gameID))
+}
+
 // ReadBridgeJSON reads existing bridge.json and returns connection info
 // Returns (host, port, token, error) - host is always 127.0.0.1 for GABS
 func ReadBridgeJSON(gameID, configDir string) (string, int, string, error) {
@@ -199,14 +301,12 @@ func ReadBridgeJSON(gameID, configDir string) (string, int, string, error) {
 	}
 
 	cfgPath := cp.GetBridgeConfigPath(gameID)
-	data, err := os.ReadFile(cfgPath)
+	bridge, err := readBridgeJSONFile(cfgPath)
 	if err != nil {
 		return "", 0, "", fmt.Errorf("failed to read bridge.json: %w", err)
 	}
-
-	var bridge BridgeJSON
-	if err := json.Unmarshal(data, &bridge); err != nil {
-		return "", 0, "", fmt.Errorf("failed to parse bridge.json: %w", err)
+	if bridge.tokenErr != nil {
+		return "", 0, "", fmt.Errorf("failed to decrypt the token in bridge.json: %w", bridge.tokenErr)
 	}
 
 	// GABS always uses localhost for communication
@@ -215,6 +315,66 @@ func ReadBridgeJSON(gameID, configDir string) (string, int, string, error) {
 	return host, bridge.Port, bridge.Token, nil
 }
 
+// BridgeSocket returns the Unix socket a game's bridge.json selects, or ""
+// when the game uses TCP. The socket's directory is created and restricted to
+// the current user, so only their processes can reach the bridge even before
+// the token is checked.
+func BridgeSocket(gameID, configDir string) (string, error) {
+	cp, err := NewConfigPaths(configDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to create config paths: %w", err)
+	}
+	bridge, err := readBridgeJSONFile(cp.GetBridgeConfigPath(gameID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read bridge.json: %w", err)
+	}
+
+	switch bridge.Transport {
+	case "", BridgeTransportTCP:
+		return "", nil
+	case BridgeTransportUnix:
+	default:
+		return "", fmt.Errorf("invalid transport '%s' in bridge.json, must be %s or %s", bridge.Transport, BridgeTransportTCP, BridgeTransportUnix)
+	}
+
+	socket := bridge.Socket
+	if socket == "" {
+		socket = cp.GetBridgeSocketPath(gameID)
+	}
+	if err := os.MkdirAll(filepath.Dir(socket), 0700); err != nil {
+		return "", fmt.Errorf("failed to create GABP socket directory: %w", err)
+	}
+	if bridge.Socket == "" {
+		// Only the directory GABS owns is restricted; a socket the user put
+		// elsewhere keeps the permissions they gave its directory
+		if err := os.Chmod(filepath.Dir(socket), 0700); err != nil {
+			return "", fmt.Errorf("failed to restrict GABP socket directory: %w", err)
+		}
+	}
+	return socket, nil
+}
+
+// RemoveBridgeSocket removes the Unix socket file a game's bridge left
+// behind, so the next bridge can bind it again. It returns the removed path,
+// or "" when the game uses TCP or no socket file exists. bridge.json itself
+// stays, since it holds the transport settings.
+func RemoveBridgeSocket(gameID, configDir string) (string, error) {
+	socket, err := BridgeSocket(gameID, configDir)
+	if err != nil || socket == "" {
+		return "", err
+	}
+	if err := os.Remove(socket); err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to remove GABP socket: %w", err)
+	}
+	return socket, nil
+}
+
 // GetBridgeConfigPath returns the path to the bridge.json file for a given game
 func GetBridgeConfigPath(gameID string) string {
 	cp, err := NewConfigPaths("")
@@ -225,6 +385,11 @@ func GetBridgeConfigPath(gameID string) string {
 	return cp.GetBridgeConfigPath(gameID)
 }
 
+// NewBridgeToken returns a fresh random bridge token.
+func NewBridgeToken() (string, error) {
+	return generateToken()
+}
+
 // generateToken creates a random 64-character hex token
 func generateToken() (string, error) {
 	bytes := make([]byte, 32) // 32 bytes = 64 hex chars
@@ -235,8 +400,61 @@ func generateToken() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
-// assignPortWithConfig assigns an available loopback port from the configured ranges.
+// portAllocationFault lets the developer chaos mode fail port allocation on
+// purpose. It is nil in normal operation.
+var (
+	portAllocationFaultMu sync.RWMutex
+	portAllocationFault   func() error
+)
+
+// SetPortAllocationFault installs a hook that can make bridge port allocation
+// fail. It returns a function that restores the previous hook.
+func SetPortAllocationFault(fault func() error) func() {
+	portAllocationFaultMu.Lock()
+	defer portAllocationFaultMu.Unlock()
+	prev := portAllocationFault
+	portAllocationFault = fault
+	return func() {
+		portAllocationFaultMu.Lock()
+		defer portAllocationFaultMu.Unlock()
+		portAllocationFault = prev
+	}
+}
+
+// assignBridgePort returns the game's preferred port when it is free and no
+// other game reserved it, and otherwise an available port from the
+// configured ranges.
+func assignBridgePort(gameID, configDir string, gamesConfig *GamesConfig) (int, error) {
+	if err := portAllocationFailure(); err != nil {
+		return 0, err
+	}
+	if preferred := PreferredBridgePort(gameID, configDir, gamesConfig); preferred != 0 {
+		if _, taken := gamesConfig.fixedPortOwner(preferred, gameID); !taken && isPortAvailable(preferred) {
+			return preferred, nil
+		}
+	}
+	return assignPortWithConfig(gamesConfig)
+}
+
+func portAllocationFailure() error {
+	portAllocationFaultMu.RLock()
+	fault := portAllocationFault
+	portAllocationFaultMu.RUnlock()
+	if fault != nil {
+		if err := fault(); err != nil {
+			return fmt.Errorf("no available bridge port found: %w", err)
+		}
+	}
+	return nil
+}
+
+// assignPortWithConfig assigns an available loopback port from the configured
+// ranges, leaving out the fixedPort of every game.
 func assignPortWithConfig(gamesConfig *GamesConfig) (int, error) {
+	if err := portAllocationFailure(); err != nil {
+		return 0, err
+	}
+
 	ranges := make([]PortRange, 0, 8)
 
 	// Check for custom port ranges from configuration
@@ -255,9 +473,10 @@ func assignPortWithConfig(gamesConfig *GamesConfig) (int, error) {
 		)
 	}
 
+	reserved := gamesConfig.reservedPorts()
 	var lastErr error
 	for _, portRange := range ranges {
-		port, err := findAvailablePortInRange(portRange.Min, portRange.Max)
+		port, err := findAvailablePortInRangeExcept(portRange.Min, portRange.Max, reserved)
 		if err == nil {
 			return port, nil
 		}
@@ -283,6 +502,10 @@ var (
 )
 
 func findAvailablePortInRange(minPort, maxPort int) (int, error) {
+	return findAvailablePortInRangeExcept(minPort, maxPort, nil)
+}
+
+func findAvailablePortInRangeExcept(minPort, maxPort int, reserved map[int]bool) (int, error) {
 	if minPort <= 0 || maxPort > 65535 || minPort > maxPort {
 		return 0, fmt.Errorf("invalid port range %d-%d", minPort, maxPort)
 	}
@@ -292,7 +515,7 @@ func findAvailablePortInRange(minPort, maxPort int) (int, error) {
 
 	for i := 0; i < rangeSize; i++ {
 		port := minPort + ((offset + i) % rangeSize)
-		if isPortAvailable(port) {
+		if !reserved[port] && isPortAvailable(port) {
 			return port, nil
 		}
 	}
@@ -313,6 +536,12 @@ func nextPortOffset(rangeSize int) int {
 	return offset
 }
 
+// BridgePortAvailable reports whether nothing listens on the loopback port
+// yet, so a game's bridge can still bind it.
+func BridgePortAvailable(port int) bool {
+	return isPortAvailable(port)
+}
+
 func isPortAvailable(port int) bool {
 	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
 	if err != nil {
@@ -6,8 +6,10 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestWriteBridgeJSON(t *testing.T) {
@@ -533,3 +535,178 @@ func TestPrepareBridgeEndpointForStartCanResetOccupiedEndpointCache(t *testing.T
 		t.Fatalf("expected reset endpoint to rotate away from occupied endpoint, got port=%d token=%q", port, token)
 	}
 }
+
+func TestPrepareBridgeEndpointForStartReplacesExpiredToken(t *testing.T) {
+	tempDir := t.TempDir()
+	gameID := "expiring-token"
+	gamesConfig := &GamesConfig{Timeouts: &TimeoutsConfig{Bridge: &BridgeTimeoutsConfig{TokenTTLSeconds: 3600}}}
+
+	port, token, _, err := WriteBridgeJSONWithConfig(gameID, tempDir, gamesConfig)
+	if err != nil {
+		t.Fatalf("failed to write bridge endpoint: %v", err)
+	}
+
+	gotPort, gotToken, _, reused, err := PrepareBridgeEndpointForStart(gameID, tempDir, gamesConfig, false)
+	if err != nil {
+		t.Fatalf("expected fresh endpoint to be reused: %v", err)
+	}
+	if !reused || gotPort != port || gotToken != token {
+		t.Fatalf("expected fresh token to be kept, got port=%d token=%q reused=%v", gotPort, gotToken, reused)
+	}
+
+	// Age the token past its TTL
+	bridge, err := ReadBridgeEndpoint(gameID, tempDir)
+	if err != nil {
+		t.Fatalf("failed to read bridge endpoint: %v", err)
+	}
+	bridge.IssuedAt = time.Now().Add(-2 * time.Hour)
+	cp, _ := NewConfigPaths(tempDir)
+	if err := writeBridgeJSONFile(cp.GetBridgeConfigPath(gameID), bridge); err != nil {
+		t.Fatalf("failed to age bridge endpoint: %v", err)
+	}
+
+	gotPort, gotToken, _, reused, err = PrepareBridgeEndpointForStart(gameID, tempDir, gamesConfig, false)
+	if err != nil {
+		t.Fatalf("expected expired endpoint to be renewed: %v", err)
+	}
+	if !reused || gotPort != port || gotToken == token {
+		t.Fatalf("expected a new token on the same port, got port=%d token=%q reused=%v", gotPort, gotToken, reused)
+	}
+	renewed, err := ReadBridgeEndpoint(gameID, tempDir)
+	if err != nil {
+		t.Fatalf("failed to read renewed endpoint: %v", err)
+	}
+	if renewed.Token != gotToken || renewed.TokenExpired(gamesConfig.GetBridgeTokenTTL(), time.Now()) {
+		t.Fatalf("expected bridge.json to hold the new, unexpired token, got %#v", renewed)
+	}
+}
+
+func TestPortAllocationFaultFailsBridgeWrite(t *testing.T) {
+	restore := SetPortAllocationFault(func() error {
+		return errors.New("injected")
+	})
+	defer restore()
+
+	_, _, _, err := WriteBridgeJSON("factory", t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "injected") {
+		t.Fatalf("expected injected port allocation failure, got %v", err)
+	}
+
+	restore()
+	if _, _, _, err := WriteBridgeJSON("factory", t.TempDir()); err != nil {
+		t.Fatalf("expected allocation to work after restore, got %v", err)
+	}
+}
+
+func TestBridgeSocketFollowsBridgeJSONTransport(t *testing.T) {
+	tempDir := t.TempDir()
+	gameID := "socket-game"
+	cfgPath, err := WriteBridgeJSONWithEndpoint(gameID, tempDir, 23456, "existing-token")
+	if err != nil {
+		t.Fatalf("failed to write bridge endpoint: %v", err)
+	}
+
+	if socket, err := BridgeSocket(gameID, tempDir); err != nil || socket != "" {
+		t.Fatalf("expected TCP by default, got socket %q, err %v", socket, err)
+	}
+
+	if err := os.WriteFile(cfgPath, []byte(`{"port":23456,"token":"existing-token","gameId":"socket-game","transport":"unix"}`), 0644); err != nil {
+		t.Fatalf("failed to select the unix transport: %v", err)
+	}
+	socket, err := BridgeSocket(gameID, tempDir)
+	if err != nil {
+		t.Fatalf("BridgeSocket failed: %v", err)
+	}
+	if want := filepath.Join(tempDir, gameID, "gabp", "bridge.sock"); socket != want {
+		t.Fatalf("expected default socket %s, got %s", want, socket)
+	}
+	if info, err := os.Stat(filepath.Dir(socket)); err != nil {
+		t.Fatalf("socket directory missing: %v", err)
+	} else if runtime.GOOS != "windows" && info.Mode().Perm() != 0700 {
+		t.Errorf("expected socket directory 0700, got %o", info.Mode().Perm())
+	}
+
+	// Rotating the endpoint keeps the transport the user chose
+	if _, _, _, err := WriteBridgeJSONWithConfig(gameID, tempDir, nil); err != nil {
+		t.Fatalf("failed to rotate bridge endpoint: %v", err)
+	}
+	if rotated, err := BridgeSocket(gameID, tempDir); err != nil || rotated != socket {
+		t.Fatalf("expected the unix transport to survive rotation, got %q, err %v", rotated, err)
+	}
+
+	if err := os.WriteFile(cfgPath, []byte(`{"port":23456,"token":"existing-token","transport":"pipe"}`), 0644); err != nil {
+		t.Fatalf("failed to write bridge.json: %v", err)
+	}
+	if _, err := BridgeSocket(gameID, tempDir); err == nil || !strings.Contains(err.Error(), "invalid transport") {
+		t.Fatalf("expected an unknown transport to be rejected, got %v", err)
+	}
+}
+
+func TestBridgeJSONIsPrivateToTheUser(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows permissions are ACLs, not mode bits")
+	}
+	tempDir := t.TempDir()
+	cfgPath, err := WriteBridgeJSONWithEndpoint("private-game", tempDir, 23456, "existing-token")
+	if err != nil {
+		t.Fatalf("failed to write bridge.json: %v", err)
+	}
+	if info, err := os.Stat(cfgPath); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("expected bridge.json to be written 0600, got %v (%v)", info.Mode().Perm(), err)
+	}
+
+	// A file from an older version is restricted when the game next starts
+	if err := os.Chmod(cfgPath, 0644); err != nil {
+		t.Fatalf("failed to loosen bridge.json: %v", err)
+	}
+	if _, _, _, reused, err := PrepareBridgeEndpointForStart("private-game", tempDir, nil, false); err != nil || !reused {
+		t.Fatalf("expected the endpoint to be reused, got reused=%v err=%v", reused, err)
+	}
+	if info, err := os.Stat(cfgPath); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("expected the reused bridge.json to be restricted, got %v (%v)", info.Mode().Perm(), err)
+	}
+}
+
+func TestBridgeTokenEncryption(t *testing.T) {
+	machine := "machine-a"
+	previous := bridgeMachineID
+	bridgeMachineID = func() (string, error) { return machine, nil }
+	defer func() { bridgeMachineID = previous }()
+
+	tempDir := t.TempDir()
+	gamesConfig := &GamesConfig{BridgeFiles: &BridgeFilesConfig{EncryptTokens: true}}
+	port, token, cfgPath, err := WriteBridgeJSONWithConfig("sealed-game", tempDir, gamesConfig)
+	if err != nil {
+		t.Fatalf("failed to write bridge.json: %v", err)
+	}
+
+	data, err := os.ReadFile(cfgPath)
+	if err != nil {
+		t.Fatalf("failed to read bridge.json: %v", err)
+	}
+	if strings.Contains(string(data), token) || !strings.Contains(string(data), encryptedTokenPrefix) {
+		t.Fatalf("expected the token to be stored encrypted, got %s", data)
+	}
+	if _, gotPort, gotToken, err := ReadBridgeJSON("sealed-game", tempDir); err != nil || gotPort != port || gotToken != token {
+		t.Fatalf("expected the token to be decrypted on read, got port=%d token=%q err=%v", gotPort, gotToken, err)
+	}
+
+	// Rewriting the endpoint without the config keeps it encrypted
+	if _, err := WriteBridgeJSONWithEndpoint("sealed-game", tempDir, port, "rotated-token"); err != nil {
+		t.Fatalf("failed to rotate token: %v", err)
+	}
+	bridge, err := ReadBridgeEndpoint("sealed-game", tempDir)
+	if err != nil || !bridge.TokenEncrypted() || bridge.Token != "rotated-token" {
+		t.Fatalf("expected the rotated token to stay encrypted, got %#v (%v)", bridge, err)
+	}
+
+	// On another machine the file cannot be read and the endpoint is replaced
+	machine = "machine-b"
+	if _, _, _, err := ReadBridgeJSON("sealed-game", tempDir); err == nil {
+		t.Fatal("expected a token encrypted on another machine to be unreadable")
+	}
+	_, newToken, _, reused, err := PrepareBridgeEndpointForStart("sealed-game", tempDir, gamesConfig, false)
+	if err != nil || reused || newToken == "rotated-token" {
+		t.Fatalf("expected a new endpoint, got token=%q reused=%v err=%v", newToken, reused, err)
+	}
+}
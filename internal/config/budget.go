@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BudgetWindowSession makes a budget count the calls of each MCP client
+// session on its own, from initialize until the session ends.
+const BudgetWindowSession = "session"
+
+// ToolBudgetConfig caps how many mirrored tool calls agents may make to a game,
+// so a runaway agent loop cannot hammer it. A call counts against every budget
+// it matches.
+type ToolBudgetConfig struct {
+	Name     string   `json:"name,omitempty"`   // Label used in errors and system.budgets
+	Tools    []string `json:"tools,omitempty"`  // GABP tool name patterns, '*' matches any run of characters; empty matches every tool
+	Tags     []string `json:"tags,omitempty"`   // Only count tools carrying one of these GABP tags
+	MaxCalls int      `json:"maxCalls"`         // Calls allowed per window
+	Window   string   `json:"window,omitempty"` // "session" (default) or a duration such as "1h"
+}
+
+// Label returns the budget's name, or a description built from its filters.
+func (b ToolBudgetConfig) Label() string {
+	if b.Name != "" {
+		return b.Name
+	}
+	scope := "all tools"
+	if len(b.Tools) > 0 {
+		scope = strings.Join(b.Tools, ",")
+	}
+	if len(b.Tags) > 0 {
+		scope += " tagged " + strings.Join(b.Tags, ",")
+	}
+	return fmt.Sprintf("%d calls per %s for %s", b.MaxCalls, b.windowLabel(), scope)
+}
+
+func (b ToolBudgetConfig) windowLabel() string {
+	if b.Window == "" {
+		return BudgetWindowSession
+	}
+	return b.Window
+}
+
+// WindowDuration returns the sliding window length, or 0 for a session budget.
+func (b ToolBudgetConfig) WindowDuration() (time.Duration, error) {
+	if b.Window == "" || b.Window == BudgetWindowSession {
+		return 0, nil
+	}
+	window, err := time.ParseDuration(b.Window)
+	if err != nil {
+		return 0, fmt.Errorf("budget '%s' has an invalid window '%s': use \"session\" or a duration such as \"1h\"", b.Label(), b.Window)
+	}
+	if window <= 0 {
+		return 0, fmt.Errorf("budget '%s' needs a positive window", b.Label())
+	}
+	return window, nil
+}
+
+// Matches reports whether a call to the GABP tool toolName with the given
+// tags counts against this budget.
+func (b ToolBudgetConfig) Matches(toolName string, tags []string) bool {
+	if len(b.Tools) > 0 {
+		matched := false
+		for _, pattern := range b.Tools {
+			if MatchURIPattern(pattern, toolName) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(b.Tags) == 0 {
+		return true
+	}
+	for _, want := range b.Tags {
+		for _, tag := range tags {
+			if tag == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Validate checks the budget limits and window.
+func (b ToolBudgetConfig) Validate() error {
+	if b.MaxCalls <= 0 {
+		return fmt.Errorf("budget '%s' needs a positive maxCalls", b.Label())
+	}
+	_, err := b.WindowDuration()
+	return err
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CacheableTagPrefix starts the GABP tool tag with which a bridge marks a
+// read-only tool whose answers may be reused, followed by a duration such as
+// "cacheable:5s".
+const CacheableTagPrefix = "cacheable:"
+
+// ToolCacheConfig lets repeated calls of read-only mirrored tools with the
+// same arguments reuse the game's last answer for a while instead of asking
+// the game again. It overrides the cacheable tag of the tools it matches.
+type ToolCacheConfig struct {
+	Tools []string `json:"tools"` // GABP tool name patterns, '*' matches any run of characters
+	TTL   string   `json:"ttl"`   // How long an answer is reused, such as "5s"; "0s" turns caching off
+}
+
+// Matches reports whether the GABP tool toolName is covered by this entry.
+func (c ToolCacheConfig) Matches(toolName string) bool {
+	for _, pattern := range c.Tools {
+		if MatchURIPattern(pattern, toolName) {
+			return true
+		}
+	}
+	return false
+}
+
+// Duration returns how long answers are reused.
+func (c ToolCacheConfig) Duration() (time.Duration, error) {
+	ttl, err := time.ParseDuration(c.TTL)
+	if err != nil {
+		return 0, fmt.Errorf("cache entry for %s has an invalid ttl '%s': use a duration such as \"5s\"", strings.Join(c.Tools, ","), c.TTL)
+	}
+	if ttl < 0 {
+		return 0, fmt.Errorf("cache entry for %s needs a ttl of 0s or more", strings.Join(c.Tools, ","))
+	}
+	return ttl, nil
+}
+
+// Validate checks the tool patterns and ttl.
+func (c ToolCacheConfig) Validate() error {
+	if len(c.Tools) == 0 {
+		return fmt.Errorf("cache entries need at least one tool pattern")
+	}
+	for _, pattern := range c.Tools {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("cache tool patterns must not be empty")
+		}
+	}
+	_, err := c.Duration()
+	return err
+}
+
+// ToolCacheTTL returns how long answers of the GABP tool toolName with the
+// given tags may be reused: the ttl of the first cache entry that matches it,
+// else the duration of its cacheable tag, else 0 for not cached.
+func (g GameConfig) ToolCacheTTL(toolName string, tags []string) time.Duration {
+	for _, entry := range g.Cache {
+		if entry.Matches(toolName) {
+			ttl, _ := entry.Duration()
+			return ttl
+		}
+	}
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, CacheableTagPrefix) {
+			continue
+		}
+		if ttl, err := time.ParseDuration(strings.TrimPrefix(tag, CacheableTagPrefix)); err == nil && ttl > 0 {
+			return ttl
+		}
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ClusterConfig groups several configured games that run together, such as a
+// proxy in front of a few backend servers, under one logical ID. Members start
+// in the listed order and stop in reverse order.
+type ClusterConfig struct {
+	ID                string   `json:"id"`
+	Name              string   `json:"name,omitempty"`
+	Members           []string `json:"members"`                     // Game IDs in start order
+	StartDelaySeconds int      `json:"startDelaySeconds,omitempty"` // Pause between member starts
+	Description       string   `json:"description,omitempty"`
+}
+
+// validateClusters fills in cluster IDs from their keys and checks that every
+// cluster refers to existing games without clashing with a game ID.
+func (c *GamesConfig) validateClusters() error {
+	c.gamesMu.Lock()
+	defer c.gamesMu.Unlock()
+	for id, cluster := range c.Clusters {
+		if cluster.ID == "" {
+			cluster.ID = id
+		}
+		if cluster.ID != id {
+			return fmt.Errorf("cluster '%s' has mismatched id '%s'", id, cluster.ID)
+		}
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("cluster IDs must not be empty")
+		}
+		if _, exists := c.Games[id]; exists {
+			return fmt.Errorf("cluster '%s' uses the same ID as a game", id)
+		}
+		if len(cluster.Members) == 0 {
+			return fmt.Errorf("cluster '%s' needs at least one member", id)
+		}
+		if cluster.StartDelaySeconds < 0 {
+			return fmt.Errorf("cluster '%s' has a negative startDelaySeconds", id)
+		}
+		seen := make(map[string]bool, len(cluster.Members))
+		for _, member := range cluster.Members {
+			if _, exists := c.Games[member]; !exists {
+				return fmt.Errorf("cluster '%s' member '%s' is not a configured game", id, member)
+			}
+			if seen[member] {
+				return fmt.Errorf("cluster '%s' lists member '%s' more than once", id, member)
+			}
+			seen[member] = true
+		}
+		c.Clusters[id] = cluster
+	}
+	return nil
+}
+
+// removeClusterMemberLocked drops a removed game from every cluster so the
+// saved config stays loadable. Clusters left without members are removed.
+// The caller must hold gamesMu.
+func (c *GamesConfig) removeClusterMemberLocked(gameID string) {
+	for id, cluster := range c.Clusters {
+		members := make([]string, 0, len(cluster.Members))
+		for _, member := range cluster.Members {
+			if member != gameID {
+				members = append(members, member)
+			}
+		}
+		if len(members) == 0 {
+			delete(c.Clusters, id)
+			continue
+		}
+		cluster.Members = members
+		c.Clusters[id] = cluster
+	}
+}
+
+// GetCluster returns a cluster configuration by ID
+func (c *GamesConfig) GetCluster(clusterID string) (*ClusterConfig, bool) {
+	c.gamesMu.RLock()
+	defer c.gamesMu.RUnlock()
+	cluster, exists := c.Clusters[clusterID]
+	if !exists {
+		return nil, false
+	}
+	cluster.Members = append([]string(nil), cluster.Members...)
+	return &cluster, true
+}
+
+// ListClusters returns all configured clusters ordered by ID
+func (c *GamesConfig) ListClusters() []ClusterConfig {
+	c.gamesMu.RLock()
+	defer c.gamesMu.RUnlock()
+	clusters := make([]ClusterConfig, 0, len(c.Clusters))
+	for _, cluster := range c.Clusters {
+		cluster.Members = append([]string(nil), cluster.Members...)
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].ID < clusters[j].ID
+	})
+	return clusters
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeClusterConfigForTest(t *testing.T, clusters string) string {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	data := `{
+  "version": "1.0",
+  "games": {
+    "proxy": {"id": "proxy", "name": "Proxy", "launchMode": "DirectPath", "target": "/opt/proxy"},
+    "factory-a": {"id": "factory-a", "name": "Factory A", "launchMode": "DirectPath", "target": "/opt/factory"},
+    "factory-b": {"id": "factory-b", "name": "Factory B", "launchMode": "DirectPath", "target": "/opt/factory"}
+  },
+  "clusters": ` + clusters + `
+}`
+	if err := os.WriteFile(configPath, []byte(data), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return configPath
+}
+
+func TestLoadClusters(t *testing.T) {
+	configPath := writeClusterConfigForTest(t, `{"network": {"members": ["factory-a", "factory-b", "proxy"], "startDelaySeconds": 2}}`)
+
+	cfg, err := LoadGamesConfigFromPath(configPath)
+	if err != nil {
+		t.Fatalf("LoadGamesConfigFromPath failed: %v", err)
+	}
+	cluster, ok := cfg.GetCluster("network")
+	if !ok {
+		t.Fatal("expected cluster 'network'")
+	}
+	if cluster.ID != "network" {
+		t.Errorf("expected ID filled in from key, got %q", cluster.ID)
+	}
+	if strings.Join(cluster.Members, ",") != "factory-a,factory-b,proxy" {
+		t.Errorf("expected member order to be kept, got %v", cluster.Members)
+	}
+	if cluster.StartDelaySeconds != 2 {
+		t.Errorf("expected start delay 2, got %d", cluster.StartDelaySeconds)
+	}
+}
+
+func TestLoadClustersRejectsInvalidDefinitions(t *testing.T) {
+	tests := map[string]string{
+		"unknown member":   `{"network": {"members": ["proxy", "missing"]}}`,
+		"duplicate member": `{"network": {"members": ["proxy", "proxy"]}}`,
+		"no members":       `{"network": {"members": []}}`,
+		"game id clash":    `{"proxy": {"members": ["factory-a"]}}`,
+		"mismatched id":    `{"network": {"id": "other", "members": ["proxy"]}}`,
+	}
+	for name, clusters := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := LoadGamesConfigFromPath(writeClusterConfigForTest(t, clusters)); err == nil {
+				t.Fatal("expected invalid clusters to be rejected")
+			}
+		})
+	}
+}
+
+func TestRemoveGameDropsClusterMember(t *testing.T) {
+	cfg, err := LoadGamesConfigFromPath(writeClusterConfigForTest(t, `{
+    "network": {"members": ["factory-a", "proxy"]},
+    "solo": {"members": ["factory-b"]}
+  }`))
+	if err != nil {
+		t.Fatalf("LoadGamesConfigFromPath failed: %v", err)
+	}
+
+	cfg.RemoveGame("factory-a")
+	cfg.RemoveGame("factory-b")
+
+	cluster, ok := cfg.GetCluster("network")
+	if !ok || strings.Join(cluster.Members, ",") != "proxy" {
+		t.Fatalf("expected network to keep only proxy, got %+v", cluster)
+	}
+	if _, ok := cfg.GetCluster("solo"); ok {
+		t.Fatal("expected cluster without members to be removed")
+	}
+}
+
+func TestAddGameRejectsClusterID(t *testing.T) {
+	cfg, err := LoadGamesConfigFromPath(writeClusterConfigForTest(t, `{"network": {"members": ["proxy"]}}`))
+	if err != nil {
+		t.Fatalf("LoadGamesConfigFromPath failed: %v", err)
+	}
+	err = cfg.AddGame(GameConfig{ID: "network", Name: "Network", LaunchMode: "DirectPath", Target: "/opt/network"})
+	if err == nil {
+		t.Fatal("expected AddGame to reject an ID used by a cluster")
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EventQueueConfig bounds how many GABP events of each channel may wait for
+// GABS to handle them when a bridge sends them faster than they are
+// processed. When a channel's queue is full its oldest waiting event is
+// dropped; coalescing channels keep only their newest waiting event, which
+// suits channels that report state, such as world/tick or player/position.
+type EventQueueConfig struct {
+	Size     int      `json:"size,omitempty"`     // Waiting events per channel, default 256
+	Coalesce []string `json:"coalesce,omitempty"` // Channel patterns that keep only their newest waiting event, '*' matches any run of characters
+}
+
+// Coalesces reports whether the GABP channel keeps only its newest waiting
+// event.
+func (q *EventQueueConfig) Coalesces(channel string) bool {
+	if q == nil {
+		return false
+	}
+	for _, pattern := range q.Coalesce {
+		if MatchURIPattern(pattern, channel) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the size and channel patterns.
+func (q *EventQueueConfig) Validate() error {
+	if q.Size < 0 {
+		return fmt.Errorf("eventQueue size %d must be positive, or 0 for the default", q.Size)
+	}
+	for _, pattern := range q.Coalesce {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("eventQueue coalesce patterns must not be empty")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestEventQueueConfig(t *testing.T) {
+	for _, queue := range []EventQueueConfig{
+		{Size: -1},
+		{Coalesce: []string{" "}},
+	} {
+		if err := queue.Validate(); err == nil {
+			t.Errorf("expected %#v to fail validation", queue)
+		}
+	}
+
+	queue := &EventQueueConfig{Size: 10, Coalesce: []string{"world/tick", "player/position*"}}
+	if err := queue.Validate(); err != nil {
+		t.Fatalf("expected a valid event queue, got %v", err)
+	}
+	if !queue.Coalesces("player/position/ada") || queue.Coalesces("player/died") {
+		t.Fatal("expected only matching channels to coalesce")
+	}
+	if (*EventQueueConfig)(nil).Coalesces("world/tick") {
+		t.Fatal("expected no channel to coalesce without an event queue")
+	}
+}
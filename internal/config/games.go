@@ -3,9 +3,12 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -13,13 +16,138 @@ import (
 type GameConfig struct {
 	ID              string   `json:"id"`
 	Name            string   `json:"name"`
-	LaunchMode      string   `json:"launchMode"` // DirectPath|SteamAppId|SteamManaged|EpicAppId|CustomCommand
+	LaunchMode      string   `json:"launchMode"` // DirectPath|SteamManaged|SteamAppId|EpicAppId|GOGGameId|XboxAppId|Flatpak|Proton|CustomCommand|Remote
 	Target          string   `json:"target"`     // path or id
 	Args            []string `json:"args,omitempty"`
 	WorkingDir      string   `json:"workingDir,omitempty"`
 	StopProcessName string   `json:"stopProcessName,omitempty"` // Optional process name for stopping the game
 	GABPMode        string   `json:"gabpMode,omitempty"`
 	Description     string   `json:"description,omitempty"`
+	// BridgeMode says who opens the GABP connection: "connect" (default), GABS
+	// connects to the bridge; "listen", GABS listens on the bridge port and
+	// the bridge connects in, for bridges that can only dial out.
+	BridgeMode string `json:"bridgeMode,omitempty"`
+	// FixedPort is the GABP port the game's bridge always gets, for firewall
+	// rules and bridges that cache the port. 0 lets GABS pick one and keep
+	// reusing it.
+	FixedPort int `json:"fixedPort,omitempty"`
+	// ShutdownPolicy says what happens to the running game when GABS shuts
+	// down: "leave-running" (default), "graceful-stop" or "kill".
+	ShutdownPolicy string `json:"shutdownPolicy,omitempty"`
+	// IdleTimeout, a duration such as "30m", gracefully stops the running
+	// game once no MCP tool call touched it and no GABP event arrived for
+	// that long. Empty never stops it.
+	IdleTimeout string `json:"idleTimeout,omitempty"`
+	// AllowedCommands lists helper commands games.exec may run in WorkingDir, keyed by name.
+	AllowedCommands map[string]AllowedCommandConfig `json:"allowedCommands,omitempty"`
+	// SSHTunnel reaches a GABP bridge on a remote machine through an SSH port-forward.
+	SSHTunnel *SSHTunnelConfig `json:"sshTunnel,omitempty"`
+	// Proton runs the Windows executable in Target through Proton on Linux.
+	Proton *ProtonConfig `json:"proton,omitempty"`
+	// Remote starts and stops the game through a 'gabs agent' on another
+	// machine; Target is the game's ID in the agent's configuration.
+	Remote *RemoteConfig `json:"remote,omitempty"`
+	// Budgets cap mirrored tool calls to this game per session or time window.
+	Budgets []ToolBudgetConfig `json:"budgets,omitempty"`
+	// ToolPolicy allows or denies this game's mirrored tools by GABP name.
+	ToolPolicy []ToolPolicyConfig `json:"toolPolicy,omitempty"`
+	// Cache reuses answers of read-only mirrored tools for repeated calls
+	// with the same arguments.
+	Cache []ToolCacheConfig `json:"cache,omitempty"`
+	// Alerts push chosen GABP events to agents as MCP notifications.
+	Alerts []AlertConfig `json:"alerts,omitempty"`
+	// EventQueue bounds the GABP events waiting to be handled per channel.
+	EventQueue *EventQueueConfig `json:"eventQueue,omitempty"`
+	// Schedules start, stop or restart the game at fixed times.
+	Schedules []ScheduleConfig `json:"schedules,omitempty"`
+	// Env adds variables to the game's environment. Values may reference
+	// variables of the GABS environment as ${NAME}, so secrets stay out of
+	// this file.
+	Env map[string]string `json:"env,omitempty"`
+	// EnvFile is a KEY=VALUE file, absolute or relative to WorkingDir, read
+	// each time the game starts. Env entries override it.
+	EnvFile string `json:"envFile,omitempty"`
+	// Profiles are named variants of Args, WorkingDir and Env that
+	// games_start can pick, keyed by name.
+	Profiles map[string]LaunchProfile `json:"profiles,omitempty"`
+	// DefaultProfile is the profile used when games_start names none. Empty
+	// starts the game with its own settings.
+	DefaultProfile string `json:"defaultProfile,omitempty"`
+	// Saves is the game's save directory, which games_saves_backup and
+	// games_saves_restore snapshot and roll back.
+	Saves *SavesConfig `json:"saves,omitempty"`
+	// BridgePackages names the game's plugin directory and the GABP bridge
+	// packages games_bridges_install can put there.
+	BridgePackages *BridgePackagesConfig `json:"bridgePackages,omitempty"`
+}
+
+// ProtonConfig selects the Proton build a Proton game runs with and where its
+// Wine prefix lives.
+type ProtonConfig struct {
+	Path           string `json:"path"`                     // Proton directory or its proton script
+	CompatDataPath string `json:"compatDataPath,omitempty"` // STEAM_COMPAT_DATA_PATH, default ~/.gabs/<gameId>/proton
+	SteamPath      string `json:"steamPath,omitempty"`      // STEAM_COMPAT_CLIENT_INSTALL_PATH, default ~/.steam/steam
+}
+
+// RemoteConfig points a Remote game at the 'gabs agent' that controls it.
+type RemoteConfig struct {
+	URL   string `json:"url"`   // Agent address, e.g. "http://gaming-pc:7777"
+	Token string `json:"token"` // Shared secret the agent was started with
+}
+
+// Validate checks the agent address and token.
+func (r RemoteConfig) Validate() error {
+	parsed, err := url.Parse(r.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("remote.url must be an http or https address such as \"http://gaming-pc:7777\"")
+	}
+	if strings.TrimSpace(r.Token) == "" {
+		return fmt.Errorf("remote.token is required")
+	}
+	return nil
+}
+
+// SSHTunnelConfig describes the SSH port-forward GABS opens before connecting
+// to a game's GABP bridge on another machine.
+type SSHTunnelConfig struct {
+	Host         string `json:"host"`
+	User         string `json:"user,omitempty"`
+	Port         int    `json:"port,omitempty"`         // SSH port, default 22
+	IdentityFile string `json:"identityFile,omitempty"` // Private key passed to ssh -i
+	RemoteHost   string `json:"remoteHost,omitempty"`   // Bridge host as seen from the SSH server, default 127.0.0.1
+	RemotePort   int    `json:"remotePort,omitempty"`   // Bridge port on the remote side, default the bridge.json port
+}
+
+// Validate checks the tunnel settings.
+func (t SSHTunnelConfig) Validate() error {
+	if strings.TrimSpace(t.Host) == "" {
+		return fmt.Errorf("sshTunnel requires a host")
+	}
+	// Host and user end up on the ssh command line; a leading "-" would be
+	// parsed as an option such as -oProxyCommand and run arbitrary commands.
+	if strings.HasPrefix(strings.TrimSpace(t.Host), "-") {
+		return fmt.Errorf("sshTunnel host must not start with '-'")
+	}
+	if strings.HasPrefix(strings.TrimSpace(t.User), "-") {
+		return fmt.Errorf("sshTunnel user must not start with '-'")
+	}
+	if t.Port < 0 || t.Port > 65535 {
+		return fmt.Errorf("sshTunnel port %d is out of range", t.Port)
+	}
+	if t.RemotePort < 0 || t.RemotePort > 65535 {
+		return fmt.Errorf("sshTunnel remotePort %d is out of range", t.RemotePort)
+	}
+	return nil
+}
+
+// AllowedCommandConfig describes one whitelisted helper command for games.exec.
+type AllowedCommandConfig struct {
+	Command        string   `json:"command"`
+	Args           []string `json:"args,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	TimeoutSeconds int      `json:"timeoutSeconds,omitempty"`
+	// AllowExtraArgs lets callers append arguments after the configured Args.
+	AllowExtraArgs bool `json:"allowExtraArgs,omitempty"`
 }
 
 // ToolNormalizationConfig configures how MCP tool names are normalized for different clients
@@ -57,10 +185,29 @@ type SessionTimeoutsConfig struct {
 	OwnerLeaseSeconds int `json:"ownerLeaseSeconds,omitempty"`
 }
 
+// BridgeTimeoutsConfig configures how long bridge credentials stay valid.
+type BridgeTimeoutsConfig struct {
+	// TokenTTLSeconds is how old a bridge token may get before GABS replaces
+	// it. 0 keeps tokens until the endpoint is reset.
+	TokenTTLSeconds int `json:"tokenTtlSeconds,omitempty"`
+	// RequestSeconds is how long GABS waits for a bridge to answer a request
+	// that sets no timeout of its own. It overrides the requestTimeout limit
+	// bridges announce; 0 keeps that limit, or 30 seconds without one.
+	RequestSeconds int `json:"requestSeconds,omitempty"`
+}
+
+// BridgeFilesConfig configures how GABS stores bridge.json files.
+type BridgeFilesConfig struct {
+	// EncryptTokens stores tokens encrypted with a key derived from this
+	// machine's ID, so a copied file or backup does not reveal them.
+	EncryptTokens bool `json:"encryptTokens,omitempty"`
+}
+
 // TimeoutsConfig groups configurable timeout settings.
 type TimeoutsConfig struct {
 	Startup *StartupTimeoutsConfig `json:"startup,omitempty"`
 	Session *SessionTimeoutsConfig `json:"session,omitempty"`
+	Bridge  *BridgeTimeoutsConfig  `json:"bridge,omitempty"`
 }
 
 // GamesConfig represents the main GABS configuration
@@ -72,6 +219,24 @@ type GamesConfig struct {
 	PortRanges        *PortRangeConfig         `json:"portRanges,omitempty"`        // Custom port ranges for bridge connections
 	Timeouts          *TimeoutsConfig          `json:"timeouts,omitempty"`          // Configurable timeout settings
 	StripOutputSchema bool                     `json:"stripOutputSchema,omitempty"` // Strip outputSchema from tools/list for MCP clients that reject non-standard fields (e.g. Claude Code)
+	EnableExec        bool                     `json:"enableExec,omitempty"`        // Register games.exec for per-game allowedCommands
+	APIKeys           []APIKeyConfig           `json:"apiKeys,omitempty"`           // Additional HTTP API keys with named roles
+	ResourceAccess    []ResourceAccessRule     `json:"resourceAccess,omitempty"`    // Resource URI access rules evaluated per role
+	Clusters          map[string]ClusterConfig `json:"clusters,omitempty"`          // Games that start, stop and report status together
+	ToolPolicy        []ToolPolicyConfig       `json:"toolPolicy,omitempty"`        // Tool allow and deny patterns evaluated per role
+	ToolProfiles      map[string]ToolProfile   `json:"toolProfiles,omitempty"`      // Named tool sets an API key or session can be limited to
+	Webhooks          []WebhookConfig          `json:"webhooks,omitempty"`          // URLs that game lifecycle and GABP events are posted to
+	Confirmation      *ConfirmationConfig      `json:"confirmation,omitempty"`      // Tools whose calls wait for games.confirm
+	BridgeFiles       *BridgeFilesConfig       `json:"bridgeFiles,omitempty"`       // How bridge.json files are stored
+	ToolsListPageSize int                      `json:"toolsListPageSize,omitempty"` // Tools per tools/list page, 0 for a single page
+	AdvertiseTools    []string                 `json:"advertiseTools,omitempty"`    // Games whose mirrored tools tools/list includes, "*" for all
+	LenientArguments  bool                     `json:"lenientArguments,omitempty"`  // Forward tool arguments that break the tool's inputSchema instead of rejecting the call
+	MaxMessageBytes   int                      `json:"maxMessageBytes,omitempty"`   // Largest MCP or GABP message GABS accepts, 0 for the defaults
+
+	gamesMu sync.RWMutex // Guards Games for accessors used while a server is running
+
+	migratedFrom    string // Version config.json had before it was migrated on load
+	migrationBackup string // Copy of config.json from before the migration
 }
 
 const (
@@ -100,7 +265,7 @@ func LoadGamesConfigFromPath(configPath string) (*GamesConfig, error) {
 	// If config doesn't exist, return empty config with defaults
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		return &GamesConfig{
-			Version: "1.0",
+			Version: CurrentConfigVersion,
 			Games:   make(map[string]GameConfig),
 			ToolNormalization: &ToolNormalizationConfig{
 				EnableOpenAINormalization: true, // Strict-safe by default
@@ -116,10 +281,17 @@ func LoadGamesConfigFromPath(configPath string) (*GamesConfig, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	// Bring configs written by older GABS versions up to date first
+	data, migratedFrom, backupPath, err := migrateConfigFile(configPath, data)
+	if err != nil {
+		return nil, err
+	}
+
 	var config GamesConfig
 	if err := json.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	config.migratedFrom, config.migrationBackup = migratedFrom, backupPath
 
 	// Ensure tool normalization defaults are set if not present in config
 	if config.ToolNormalization == nil {
@@ -159,6 +331,55 @@ func LoadGamesConfigFromPath(configPath string) (*GamesConfig, error) {
 		config.Timeouts = nil
 	}
 
+	for _, apiKey := range config.APIKeys {
+		if err := apiKey.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid apiKeys entry: %w", err)
+		}
+	}
+	for _, rule := range config.ResourceAccess {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid resourceAccess: %w", err)
+		}
+	}
+	for _, policy := range config.ToolPolicy {
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid toolPolicy: %w", err)
+		}
+	}
+	if err := config.validateToolProfiles(); err != nil {
+		return nil, err
+	}
+	for _, webhook := range config.Webhooks {
+		if err := webhook.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid webhooks entry: %w", err)
+		}
+	}
+	if config.Confirmation != nil {
+		if err := config.Confirmation.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid confirmation: %w", err)
+		}
+	}
+	if err := config.validateClusters(); err != nil {
+		return nil, fmt.Errorf("invalid clusters: %w", err)
+	}
+	if err := config.validateFixedPorts(); err != nil {
+		return nil, err
+	}
+	if config.Timeouts != nil && config.Timeouts.Bridge != nil && config.Timeouts.Bridge.RequestSeconds < 0 {
+		return nil, fmt.Errorf("invalid timeouts.bridge.requestSeconds: must be 0 or more")
+	}
+	if config.ToolsListPageSize < 0 {
+		return nil, fmt.Errorf("invalid toolsListPageSize: must be 0 or more")
+	}
+	if config.MaxMessageBytes < 0 {
+		return nil, fmt.Errorf("invalid maxMessageBytes: must be 0 or more")
+	}
+	for _, gameID := range config.AdvertiseTools {
+		if strings.TrimSpace(gameID) == "" {
+			return nil, fmt.Errorf("invalid advertiseTools: game IDs must not be empty")
+		}
+	}
+
 	return &config, nil
 }
 
@@ -185,6 +406,10 @@ func SaveGamesConfigToPath(config *GamesConfig, configPath string) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
+	if config.Version == "" {
+		config.Version = CurrentConfigVersion
+	}
+
 	// Marshal with pretty printing
 	data, err := json.MarshalIndent(config, "", "  ")
 	if err != nil {
@@ -207,6 +432,8 @@ func SaveGamesConfigToPath(config *GamesConfig, configPath string) error {
 
 // GetGame returns a game configuration by ID
 func (c *GamesConfig) GetGame(gameID string) (*GameConfig, bool) {
+	c.gamesMu.RLock()
+	defer c.gamesMu.RUnlock()
 	if game, exists := c.Games[gameID]; exists {
 		// Return a pointer to the map value directly to maintain linkage
 		// Note: This requires changing the map to store pointers instead of values
@@ -222,6 +449,16 @@ func (c *GamesConfig) AddGame(game GameConfig) error {
 	if err := game.Validate(); err != nil {
 		return err
 	}
+	c.gamesMu.Lock()
+	defer c.gamesMu.Unlock()
+	if _, exists := c.Clusters[game.ID]; exists {
+		return fmt.Errorf("game ID '%s' is already used by a cluster", game.ID)
+	}
+	for id, other := range c.Games {
+		if id != game.ID && game.FixedPort != 0 && other.FixedPort == game.FixedPort {
+			return fmt.Errorf("fixedPort %d is already the fixedPort of game '%s'", game.FixedPort, id)
+		}
+	}
 	if c.Games == nil {
 		c.Games = make(map[string]GameConfig)
 	}
@@ -229,6 +466,75 @@ func (c *GamesConfig) AddGame(game GameConfig) error {
 	return nil
 }
 
+// LaunchModes are the valid launchMode values, in the order setup prompts
+// offer them.
+var LaunchModes = []string{"DirectPath", "SteamManaged", "SteamAppId", "EpicAppId", "GOGGameId", "XboxAppId", "Flatpak", "Proton", "CustomCommand", "Remote"}
+
+// Bridge modes, see GameConfig.BridgeMode.
+const (
+	BridgeModeConnect = "connect"
+	BridgeModeListen  = "listen"
+)
+
+// Shutdown policies, see GameConfig.ShutdownPolicy.
+const (
+	ShutdownPolicyLeaveRunning = "leave-running"
+	ShutdownPolicyGracefulStop = "graceful-stop"
+	ShutdownPolicyKill         = "kill"
+)
+
+// ShutdownPolicies are the valid shutdownPolicy values.
+var ShutdownPolicies = []string{ShutdownPolicyLeaveRunning, ShutdownPolicyGracefulStop, ShutdownPolicyKill}
+
+// ValidateShutdownPolicy checks a shutdownPolicy value; empty means the
+// default.
+func ValidateShutdownPolicy(policy string) error {
+	if policy == "" {
+		return nil
+	}
+	for _, valid := range ShutdownPolicies {
+		if policy == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid shutdownPolicy '%s', must be one of: %s", policy, strings.Join(ShutdownPolicies, ", "))
+}
+
+// IdleTimeoutDuration returns the parsed idleTimeout, or 0 when it is not
+// set.
+func (g GameConfig) IdleTimeoutDuration() (time.Duration, error) {
+	if g.IdleTimeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(g.IdleTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid idleTimeout '%s': use a duration such as \"30m\"", g.IdleTimeout)
+	}
+	if timeout < time.Minute {
+		return 0, fmt.Errorf("idleTimeout must be at least 1m")
+	}
+	return timeout, nil
+}
+
+// ListensForBridge reports whether GABS waits for the game's bridge to
+// connect in instead of connecting to it.
+func (g GameConfig) ListensForBridge() bool {
+	return g.BridgeMode == BridgeModeListen
+}
+
+// IsStoreLaunchMode reports whether games in mode are started by handing the
+// launch to a store client such as Steam or GOG Galaxy. The process GABS
+// starts exits soon after, so the game itself is found and stopped through
+// stopProcessName.
+func IsStoreLaunchMode(mode string) bool {
+	switch mode {
+	case "SteamAppId", "EpicAppId", "GOGGameId", "XboxAppId":
+		return true
+	default:
+		return false
+	}
+}
+
 // Validate checks if the game configuration is valid
 func (g *GameConfig) Validate() error {
 	if g.ID == "" {
@@ -247,34 +553,149 @@ func (g *GameConfig) Validate() error {
 	}
 
 	// Validate launch mode
-	validModes := []string{"DirectPath", "SteamAppId", "SteamManaged", "EpicAppId", "CustomCommand"}
 	isValidMode := false
-	for _, mode := range validModes {
+	for _, mode := range LaunchModes {
 		if g.LaunchMode == mode {
 			isValidMode = true
 			break
 		}
 	}
 	if !isValidMode {
-		return fmt.Errorf("invalid launch mode '%s', must be one of: %s", g.LaunchMode, strings.Join(validModes, ", "))
+		return fmt.Errorf("invalid launch mode '%s', must be one of: %s", g.LaunchMode, strings.Join(LaunchModes, ", "))
 	}
 
-	// For launcher-based games (Steam/Epic), require stopProcessName for proper game control.
+	// For store launcher games, require stopProcessName for proper game control.
 	// SteamManaged launches the resolved game executable directly, so it can be
 	// tracked like DirectPath while still using the Steam app id for discovery.
-	if g.LaunchMode == "SteamAppId" || g.LaunchMode == "EpicAppId" {
+	if IsStoreLaunchMode(g.LaunchMode) {
 		if g.StopProcessName == "" {
 			return fmt.Errorf("stopProcessName is required for %s games to enable proper game termination. Without it, GABS can only stop the launcher process, not the actual game", g.LaunchMode)
 		}
 	}
 
+	if g.LaunchMode == "Proton" && (g.Proton == nil || strings.TrimSpace(g.Proton.Path) == "") {
+		return fmt.Errorf("proton.path is required for Proton games")
+	}
+	if g.Proton != nil && g.LaunchMode != "Proton" {
+		return fmt.Errorf("proton settings only apply to the Proton launch mode, not %s", g.LaunchMode)
+	}
+	if g.LaunchMode == "Remote" {
+		if g.Remote == nil {
+			return fmt.Errorf("remote settings are required for Remote games")
+		}
+		if err := g.Remote.Validate(); err != nil {
+			return err
+		}
+	} else if g.Remote != nil {
+		return fmt.Errorf("remote settings only apply to the Remote launch mode, not %s", g.LaunchMode)
+	}
+
+	switch g.BridgeMode {
+	case "", BridgeModeConnect:
+	case BridgeModeListen:
+		if g.SSHTunnel != nil {
+			return fmt.Errorf("bridgeMode listen cannot be combined with sshTunnel, which forwards connections to the bridge")
+		}
+	default:
+		return fmt.Errorf("invalid bridgeMode '%s', must be %s or %s", g.BridgeMode, BridgeModeConnect, BridgeModeListen)
+	}
+	if err := ValidateShutdownPolicy(g.ShutdownPolicy); err != nil {
+		return err
+	}
+	if _, err := g.IdleTimeoutDuration(); err != nil {
+		return err
+	}
+
+	if len(g.AllowedCommands) > 0 && strings.TrimSpace(g.WorkingDir) == "" {
+		return fmt.Errorf("allowedCommands requires workingDir to be set")
+	}
+	for name, command := range g.AllowedCommands {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("allowed command names must not be empty")
+		}
+		if strings.TrimSpace(command.Command) == "" {
+			return fmt.Errorf("allowed command '%s' requires a command", name)
+		}
+		if command.TimeoutSeconds < 0 {
+			return fmt.Errorf("allowed command '%s' has a negative timeoutSeconds", name)
+		}
+	}
+
+	if g.SSHTunnel != nil {
+		if err := g.SSHTunnel.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, budget := range g.Budgets {
+		if err := budget.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, policy := range g.ToolPolicy {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range g.Cache {
+		if err := entry.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, alert := range g.Alerts {
+		if err := alert.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if g.EventQueue != nil {
+		if err := g.EventQueue.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for key := range g.Env {
+		if key == "" || strings.ContainsAny(key, "= \t") {
+			return fmt.Errorf("env key '%s' must be non-empty and contain no '=' or spaces", key)
+		}
+	}
+	if g.FixedPort < 0 || g.FixedPort > 65535 {
+		return fmt.Errorf("fixedPort %d must be between 1 and 65535, or 0 to let GABS pick one", g.FixedPort)
+	}
+	if err := g.validateProfiles(); err != nil {
+		return err
+	}
+	if err := g.validateSaves(); err != nil {
+		return err
+	}
+	if err := g.validateBridgePackages(); err != nil {
+		return err
+	}
+
+	scheduleIDs := make(map[string]bool, len(g.Schedules))
+	for _, schedule := range g.Schedules {
+		if err := schedule.Validate(); err != nil {
+			return err
+		}
+		if scheduleIDs[schedule.ID] {
+			return fmt.Errorf("schedule ID '%s' is used more than once", schedule.ID)
+		}
+		scheduleIDs[schedule.ID] = true
+	}
+
 	return nil
 }
 
 // RemoveGame removes a game configuration
 func (c *GamesConfig) RemoveGame(gameID string) bool {
+	c.gamesMu.Lock()
+	defer c.gamesMu.Unlock()
 	if _, exists := c.Games[gameID]; exists {
 		delete(c.Games, gameID)
+		c.removeClusterMemberLocked(gameID)
 		return true
 	}
 	return false
@@ -282,6 +703,8 @@ func (c *GamesConfig) RemoveGame(gameID string) bool {
 
 // ListGames returns all configured games
 func (c *GamesConfig) ListGames() []GameConfig {
+	c.gamesMu.RLock()
+	defer c.gamesMu.RUnlock()
 	games := make([]GameConfig, 0, len(c.Games))
 	for _, game := range c.Games {
 		games = append(games, game)
@@ -289,6 +712,60 @@ func (c *GamesConfig) ListGames() []GameConfig {
 	return games
 }
 
+// ReplaceDefinitions swaps in new game and cluster definitions under one
+// lock, for example after the config file was reloaded, so readers never see
+// clusters that refer to games from the previous set.
+func (c *GamesConfig) ReplaceDefinitions(games map[string]GameConfig, clusters map[string]ClusterConfig) {
+	gameReplacement := make(map[string]GameConfig, len(games))
+	for id, game := range games {
+		gameReplacement[id] = game
+	}
+	clusterReplacement := make(map[string]ClusterConfig, len(clusters))
+	for id, cluster := range clusters {
+		clusterReplacement[id] = cluster
+	}
+	c.gamesMu.Lock()
+	defer c.gamesMu.Unlock()
+	c.Games = gameReplacement
+	c.Clusters = clusterReplacement
+}
+
+// RestartRequiredChanges lists the top-level settings that differ between c
+// and other. These are read once when the server starts, so a reload cannot
+// apply them.
+func (c *GamesConfig) RestartRequiredChanges(other *GamesConfig) []string {
+	settings := []struct {
+		name          string
+		current, next interface{}
+	}{
+		{"toolNormalization", c.ToolNormalization, other.ToolNormalization},
+		{"apiKey", c.APIKey, other.APIKey},
+		{"apiKeys", c.APIKeys, other.APIKeys},
+		{"resourceAccess", c.ResourceAccess, other.ResourceAccess},
+		{"toolPolicy", c.ToolPolicy, other.ToolPolicy},
+		{"toolProfiles", c.ToolProfiles, other.ToolProfiles},
+		{"webhooks", c.Webhooks, other.Webhooks},
+		{"confirmation", c.Confirmation, other.Confirmation},
+		{"portRanges", c.PortRanges, other.PortRanges},
+		{"timeouts", c.Timeouts, other.Timeouts},
+		{"bridgeFiles", c.BridgeFiles, other.BridgeFiles},
+		{"stripOutputSchema", c.StripOutputSchema, other.StripOutputSchema},
+		{"toolsListPageSize", c.ToolsListPageSize, other.ToolsListPageSize},
+		{"advertiseTools", c.AdvertiseTools, other.AdvertiseTools},
+		{"lenientArguments", c.LenientArguments, other.LenientArguments},
+		{"maxMessageBytes", c.MaxMessageBytes, other.MaxMessageBytes},
+		{"enableExec", c.EnableExec, other.EnableExec},
+	}
+
+	var changed []string
+	for _, setting := range settings {
+		if !reflect.DeepEqual(setting.current, setting.next) {
+			changed = append(changed, setting.name)
+		}
+	}
+	return changed
+}
+
 // GetToolNormalization returns tool normalization settings with defaults
 func (c *GamesConfig) GetToolNormalization() *ToolNormalizationConfig {
 	if c.ToolNormalization == nil {
@@ -341,3 +818,27 @@ func (c *GamesConfig) GetSessionOwnerLease() time.Duration {
 
 	return time.Duration(session.OwnerLeaseSeconds) * time.Second
 }
+
+// EncryptsBridgeTokens reports whether bridge.json tokens are stored
+// encrypted.
+func (c *GamesConfig) EncryptsBridgeTokens() bool {
+	return c != nil && c.BridgeFiles != nil && c.BridgeFiles.EncryptTokens
+}
+
+// GetBridgeTokenTTL returns how long a bridge token stays valid, or 0 when
+// tokens do not expire.
+func (c *GamesConfig) GetBridgeTokenTTL() time.Duration {
+	if c == nil || c.Timeouts == nil || c.Timeouts.Bridge == nil || c.Timeouts.Bridge.TokenTTLSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(c.Timeouts.Bridge.TokenTTLSeconds) * time.Second
+}
+
+// GetBridgeRequestTimeout returns the configured timeout of bridge requests
+// that set none of their own, or 0 to use each bridge's limit.
+func (c *GamesConfig) GetBridgeRequestTimeout() time.Duration {
+	if c == nil || c.Timeouts == nil || c.Timeouts.Bridge == nil || c.Timeouts.Bridge.RequestSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(c.Timeouts.Bridge.RequestSeconds) * time.Second
+}
@@ -588,6 +588,87 @@ func TestGameConfigValidation(t *testing.T) {
 		}
 	})
 
+	t.Run("ProtonGameNeedsProtonPath", func(t *testing.T) {
+		game := GameConfig{
+			ID:         "adventure",
+			Name:       "AdventureGame",
+			LaunchMode: "Proton",
+			Target:     "/games/Adventure/Adventure.exe",
+		}
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "proton.path is required") {
+			t.Errorf("Expected error about required proton.path, got: %v", err)
+		}
+
+		game.Proton = &ProtonConfig{Path: "/opt/proton"}
+		if err := game.Validate(); err != nil {
+			t.Errorf("Expected Proton game to pass validation, got: %v", err)
+		}
+
+		game.LaunchMode = "DirectPath"
+		if err := game.Validate(); err == nil {
+			t.Error("Expected proton settings on a DirectPath game to fail validation")
+		}
+	})
+
+	t.Run("RemoteGameNeedsAgentSettings", func(t *testing.T) {
+		game := GameConfig{
+			ID:         "factory",
+			Name:       "FactorySim",
+			LaunchMode: "Remote",
+			Target:     "factory",
+		}
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "remote settings are required") {
+			t.Errorf("Expected error about required remote settings, got: %v", err)
+		}
+
+		game.Remote = &RemoteConfig{URL: "gaming-pc:7777", Token: "secret"}
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "remote.url") {
+			t.Errorf("Expected error about the agent URL, got: %v", err)
+		}
+
+		game.Remote = &RemoteConfig{URL: "http://gaming-pc:7777"}
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "remote.token is required") {
+			t.Errorf("Expected error about the agent token, got: %v", err)
+		}
+
+		game.Remote.Token = "secret"
+		if err := game.Validate(); err != nil {
+			t.Errorf("Expected Remote game to pass validation, got: %v", err)
+		}
+
+		game.LaunchMode = "DirectPath"
+		if err := game.Validate(); err == nil {
+			t.Error("Expected remote settings on a DirectPath game to fail validation")
+		}
+	})
+
+	t.Run("BridgeModeIsConnectOrListen", func(t *testing.T) {
+		game := GameConfig{
+			ID:         "factory",
+			Name:       "FactorySim",
+			LaunchMode: "DirectPath",
+			Target:     "/opt/factory/start.sh",
+			BridgeMode: "listen",
+		}
+		if err := game.Validate(); err != nil {
+			t.Errorf("Expected bridgeMode listen to pass validation, got: %v", err)
+		}
+		if !game.ListensForBridge() {
+			t.Error("Expected a listen game to listen for its bridge")
+		}
+
+		game.SSHTunnel = &SSHTunnelConfig{Host: "gaming-pc"}
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "sshTunnel") {
+			t.Errorf("Expected bridgeMode listen with sshTunnel to fail validation, got: %v", err)
+		}
+
+		game.SSHTunnel = nil
+		game.BridgeMode = "dial"
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "invalid bridgeMode") {
+			t.Errorf("Expected an unknown bridgeMode to fail validation, got: %v", err)
+		}
+	})
+
 	t.Run("MissingRequiredFields", func(t *testing.T) {
 		tests := []struct {
 			name        string
@@ -629,6 +710,146 @@ func TestGameConfigValidation(t *testing.T) {
 		}
 	})
 
+	t.Run("AllowedCommandsRequireCommand", func(t *testing.T) {
+		game := GameConfig{
+			ID:         "factory",
+			Name:       "FactorySim",
+			LaunchMode: "DirectPath",
+			Target:     "/path/to/factory",
+			AllowedCommands: map[string]AllowedCommandConfig{
+				"backup": {Args: []string{"--world", "main"}},
+			},
+		}
+
+		err := game.Validate()
+		if err == nil || !strings.Contains(err.Error(), "allowedCommands requires workingDir") {
+			t.Errorf("Expected error about missing workingDir, got: %v", err)
+		}
+
+		game.WorkingDir = "/path/to"
+		err = game.Validate()
+		if err == nil || !strings.Contains(err.Error(), "allowed command 'backup' requires a command") {
+			t.Errorf("Expected error about missing allowed command, got: %v", err)
+		}
+
+		game.AllowedCommands["backup"] = AllowedCommandConfig{Command: "./backup.sh"}
+		if err := game.Validate(); err != nil {
+			t.Errorf("Expected allowed command to pass validation, got: %v", err)
+		}
+	})
+
+	t.Run("BudgetsNeedLimitAndWindow", func(t *testing.T) {
+		game := GameConfig{
+			ID:         "factory",
+			Name:       "FactorySim",
+			LaunchMode: "DirectPath",
+			Target:     "/path/to/factory",
+			Budgets:    []ToolBudgetConfig{{Name: "hourly", Window: "1h"}},
+		}
+
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "budget 'hourly' needs a positive maxCalls") {
+			t.Errorf("Expected error about missing maxCalls, got: %v", err)
+		}
+
+		game.Budgets[0] = ToolBudgetConfig{Name: "hourly", MaxCalls: 200, Window: "hourly"}
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "invalid window") {
+			t.Errorf("Expected error about the window, got: %v", err)
+		}
+
+		game.Budgets[0].Window = "1h"
+		game.Budgets = append(game.Budgets, ToolBudgetConfig{Tags: []string{"world-modifying"}, MaxCalls: 20, Window: BudgetWindowSession})
+		if err := game.Validate(); err != nil {
+			t.Errorf("Expected budgets to pass validation, got: %v", err)
+		}
+		if label := game.Budgets[1].Label(); label != "20 calls per session for all tools tagged world-modifying" {
+			t.Errorf("Unexpected budget label %q", label)
+		}
+	})
+
+	t.Run("CacheNeedsToolsAndTTL", func(t *testing.T) {
+		game := GameConfig{
+			ID:         "factory",
+			Name:       "FactorySim",
+			LaunchMode: "DirectPath",
+			Target:     "/path/to/factory",
+			Cache:      []ToolCacheConfig{{Tools: []string{"world/*"}, TTL: "soon"}},
+		}
+
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "invalid ttl 'soon'") {
+			t.Errorf("Expected error about the ttl, got: %v", err)
+		}
+
+		game.Cache[0].TTL = "10s"
+		if err := game.Validate(); err != nil {
+			t.Errorf("Expected cache entries to pass validation, got: %v", err)
+		}
+		if ttl := game.ToolCacheTTL("world/scan", []string{CacheableTagPrefix + "2s"}); ttl != 10*time.Second {
+			t.Errorf("Expected the cache entry to win over the tag, got %s", ttl)
+		}
+		if ttl := game.ToolCacheTTL("inventory/get", []string{CacheableTagPrefix + "2s"}); ttl != 2*time.Second {
+			t.Errorf("Expected the tag's ttl, got %s", ttl)
+		}
+		if ttl := game.ToolCacheTTL("inventory/set", nil); ttl != 0 {
+			t.Errorf("Expected untagged tools not to be cached, got %s", ttl)
+		}
+	})
+
+	t.Run("SchedulesNeedActionAndOneTiming", func(t *testing.T) {
+		game := GameConfig{
+			ID:         "factory",
+			Name:       "FactorySim",
+			LaunchMode: "DirectPath",
+			Target:     "/path/to/factory",
+			Schedules:  []ScheduleConfig{{ID: "nightly", Action: "reboot", Cron: "0 4 * * *"}},
+		}
+
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "invalid action 'reboot'") {
+			t.Errorf("Expected error about the action, got: %v", err)
+		}
+
+		game.Schedules[0] = ScheduleConfig{ID: "nightly", Action: ScheduleActionRestart, Cron: "0 4 * * *", Every: "24h"}
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "exactly one of cron or every") {
+			t.Errorf("Expected error about cron and every, got: %v", err)
+		}
+
+		game.Schedules[0].Every = ""
+		game.Schedules[0].Cron = "0 25 * * *"
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "cron") {
+			t.Errorf("Expected error about the cron expression, got: %v", err)
+		}
+
+		game.Schedules[0].Cron = "0 4 * * *"
+		game.Schedules = append(game.Schedules, ScheduleConfig{ID: "nightly", Action: ScheduleActionStart, Every: "6h"})
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "used more than once") {
+			t.Errorf("Expected error about the duplicate ID, got: %v", err)
+		}
+
+		game.Schedules[1].ID = "warm"
+		if err := game.Validate(); err != nil {
+			t.Errorf("Expected schedules to pass validation, got: %v", err)
+		}
+	})
+
+	t.Run("EnvKeysMustBeNames", func(t *testing.T) {
+		game := GameConfig{
+			ID:         "factory",
+			Name:       "FactorySim",
+			LaunchMode: "DirectPath",
+			Target:     "/path/to/factory",
+			Env:        map[string]string{"RCON PASSWORD": "${RCON_PASSWORD}"},
+		}
+
+		if err := game.Validate(); err == nil || !strings.Contains(err.Error(), "env key 'RCON PASSWORD'") {
+			t.Errorf("Expected error about the env key, got: %v", err)
+		}
+
+		game.Env = map[string]string{"RCON_PASSWORD": "${RCON_PASSWORD}"}
+		game.EnvFile = "server.env"
+		if err := game.Validate(); err != nil {
+			t.Errorf("Expected env to pass validation, got: %v", err)
+		}
+	})
+
 	t.Run("InvalidLaunchMode", func(t *testing.T) {
 		game := GameConfig{
 			ID:         "test",
@@ -660,3 +881,88 @@ func TestGameConfigValidation(t *testing.T) {
 		}
 	})
 }
+
+func TestIdleTimeoutValidation(t *testing.T) {
+	game := GameConfig{ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/bin/sleep"}
+	for _, value := range []string{"soon", "30s"} {
+		game.IdleTimeout = value
+		if err := game.Validate(); err == nil {
+			t.Fatalf("expected idleTimeout %q to be rejected", value)
+		}
+	}
+	game.IdleTimeout = "45m"
+	if err := game.Validate(); err != nil {
+		t.Fatalf("expected idleTimeout 45m to be valid: %v", err)
+	}
+}
+
+func TestWithProfileAppliesProfileOverGameSettings(t *testing.T) {
+	game := GameConfig{
+		ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/opt/factory/start.sh",
+		Args: []string{"--world", "main"}, WorkingDir: "/opt/factory",
+		Env: map[string]string{"LANG": "en", "SAVE": "normal"},
+		Profiles: map[string]LaunchProfile{
+			"hardcore": {Args: []string{"--difficulty", "hard"}, Env: map[string]string{"SAVE": "hard"}},
+		},
+		DefaultProfile: "hardcore",
+	}
+
+	applied, name, err := game.WithProfile("")
+	if err != nil || name != "hardcore" {
+		t.Fatalf("expected the default profile, got %q, %v", name, err)
+	}
+	if strings.Join(applied.Args, " ") != "--difficulty hard" || applied.WorkingDir != "/opt/factory" {
+		t.Fatalf("expected profile args and the game's workingDir, got %#v", applied)
+	}
+	if applied.Env["LANG"] != "en" || applied.Env["SAVE"] != "hard" || game.Env["SAVE"] != "normal" {
+		t.Fatalf("expected merged env without changing the game, got %v and %v", applied.Env, game.Env)
+	}
+	if _, _, err := game.WithProfile("creative"); err == nil {
+		t.Fatal("expected an unknown profile to fail")
+	}
+
+	game.DefaultProfile = "creative"
+	if err := game.Validate(); err == nil {
+		t.Fatal("expected a defaultProfile that names no profile to be rejected")
+	}
+	removed, ok := game.WithoutLaunchProfile("hardcore")
+	if !ok || removed.Profiles != nil || game.Profiles["hardcore"].Args == nil {
+		t.Fatalf("expected the profile to be removed from a copy, got %#v", removed)
+	}
+}
+
+func TestSavesDirIsResolvedAgainstWorkingDir(t *testing.T) {
+	game := GameConfig{ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/bin/sleep", Saves: &SavesConfig{Dir: "saves"}}
+	if err := game.Validate(); err == nil {
+		t.Fatal("expected a relative saves.dir without workingDir to be rejected")
+	}
+	game.WorkingDir = "/opt/factory"
+	if err := game.Validate(); err != nil {
+		t.Fatalf("expected saves to be valid: %v", err)
+	}
+	if dir := game.SavesDir(); dir != filepath.Join("/opt/factory", "saves") {
+		t.Fatalf("expected saves.dir relative to workingDir, got %q", dir)
+	}
+	if keep := game.Saves.KeepCount(); keep != DefaultSavesKeep {
+		t.Fatalf("expected the default keep count, got %d", keep)
+	}
+}
+
+func TestBridgePackagesValidation(t *testing.T) {
+	game := GameConfig{ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/bin/sleep",
+		BridgePackages: &BridgePackagesConfig{Dir: "/opt/factory/plugins"}}
+	for _, pkg := range []BridgePackage{
+		{ID: "../escape", Source: "/tmp/bridge.zip"},
+		{ID: "bridge", Source: "bridge.zip"},
+		{ID: "bridge", Source: "https://example.com/bridge.zip", SHA256: "abc"},
+	} {
+		game.BridgePackages.Packages = []BridgePackage{pkg}
+		if err := game.Validate(); err == nil {
+			t.Fatalf("expected bridge package %#v to be rejected", pkg)
+		}
+	}
+	game.BridgePackages.Packages = []BridgePackage{{ID: "bridge", Source: "https://example.com/bridge.zip", SHA256: strings.Repeat("ab", 32)}}
+	if err := game.Validate(); err != nil {
+		t.Fatalf("expected the bridge package to be valid: %v", err)
+	}
+}
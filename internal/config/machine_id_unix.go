@@ -0,0 +1,45 @@
+//go:build !windows
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// machineID returns an identifier that stays the same for this installation
+// of the operating system: the systemd or D-Bus machine ID on Linux and the
+// hardware UUID on macOS.
+func machineID() (string, error) {
+	if runtime.GOOS == "darwin" {
+		output, err := exec.Command("ioreg", "-rd1", "-c", "IOPlatformExpertDevice").Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to query IOPlatformUUID: %w", err)
+		}
+		for _, line := range strings.Split(string(output), "\n") {
+			if !strings.Contains(line, "IOPlatformUUID") {
+				continue
+			}
+			if _, value, found := strings.Cut(line, "="); found {
+				if id := strings.Trim(strings.TrimSpace(value), `"`); id != "" {
+					return id, nil
+				}
+			}
+		}
+		return "", fmt.Errorf("IOPlatformUUID not found")
+	}
+
+	for _, path := range []string{"/etc/machine-id", "/var/lib/dbus/machine-id"} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if id := strings.TrimSpace(string(data)); id != "" {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("no machine ID in /etc/machine-id or /var/lib/dbus/machine-id")
+}
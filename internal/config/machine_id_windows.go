@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// machineID returns the MachineGuid Windows generates when it is installed.
+func machineID() (string, error) {
+	path, err := syscall.UTF16PtrFromString(`SOFTWARE\Microsoft\Cryptography`)
+	if err != nil {
+		return "", err
+	}
+	var key syscall.Handle
+	// KEY_WOW64_64KEY reads the real key from 32-bit builds too
+	if err := syscall.RegOpenKeyEx(syscall.HKEY_LOCAL_MACHINE, path, 0, syscall.KEY_READ|0x0100, &key); err != nil {
+		return "", fmt.Errorf("failed to open the Cryptography registry key: %w", err)
+	}
+	defer syscall.RegCloseKey(key)
+
+	name, err := syscall.UTF16PtrFromString("MachineGuid")
+	if err != nil {
+		return "", err
+	}
+	buffer := make([]uint16, 64)
+	size := uint32(len(buffer) * 2)
+	var valueType uint32
+	if err := syscall.RegQueryValueEx(key, name, nil, &valueType, (*byte)(unsafe.Pointer(&buffer[0])), &size); err != nil {
+		return "", fmt.Errorf("failed to read MachineGuid: %w", err)
+	}
+	if valueType != syscall.REG_SZ {
+		return "", fmt.Errorf("MachineGuid has unexpected registry type %d", valueType)
+	}
+	id := syscall.UTF16ToString(buffer)
+	if id == "" {
+		return "", fmt.Errorf("MachineGuid is empty")
+	}
+	return id, nil
+}
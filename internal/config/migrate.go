@@ -0,0 +1,192 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CurrentConfigVersion is the config.json schema version this GABS reads and
+// writes. Raise it together with a new entry in configMigrations whenever a
+// field is renamed, moved or changes meaning.
+const CurrentConfigVersion = "1.0"
+
+// unversionedConfig is the version of a config.json without one.
+const unversionedConfig = "0"
+
+// configMigration turns a config.json document of version From into version
+// To. It works on the raw document so it can still see fields the current
+// GamesConfig no longer has.
+type configMigration struct {
+	From        string
+	To          string
+	Description string
+	Migrate     func(doc map[string]interface{}) error
+}
+
+// configMigrations run in order, each one's To being the next one's From,
+// until the document reaches CurrentConfigVersion.
+var configMigrations = []configMigration{
+	{
+		From:        unversionedConfig,
+		To:          "1.0",
+		Description: "copy each game's key in games into a missing id",
+		Migrate:     migrateUnversionedConfig,
+	},
+}
+
+// migrateUnversionedConfig fills in the id of games written by hand before
+// config.json carried a version, where the key in games was enough.
+func migrateUnversionedConfig(doc map[string]interface{}) error {
+	games, _ := doc["games"].(map[string]interface{})
+	for key, value := range games {
+		game, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("game '%s' is not an object", key)
+		}
+		if id, _ := game["id"].(string); id == "" {
+			game["id"] = key
+		}
+	}
+	return nil
+}
+
+// migrateConfigData brings a config.json document up to
+// CurrentConfigVersion. It returns the data unchanged, and from empty, when
+// the document is current, and refuses documents newer than this GABS.
+func migrateConfigData(data []byte) (migrated []byte, from string, err error) {
+	return migrateConfigDataTo(data, configMigrations, CurrentConfigVersion)
+}
+
+func migrateConfigDataTo(data []byte, migrations []configMigration, target string) (migrated []byte, from string, err error) {
+	var header struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, "", fmt.Errorf("failed to parse config file: %w", err)
+	}
+	version := strings.TrimSpace(header.Version)
+	if version == "" {
+		version = unversionedConfig
+	}
+	order, err := compareConfigVersions(version, target)
+	if err != nil {
+		return nil, "", err
+	}
+	if order < 0 && len(migrations) > 0 && migrations[0].From == unversionedConfig {
+		// Versions from before the first versioned schema count as unversioned
+		if older, _ := compareConfigVersions(version, migrations[0].To); older < 0 {
+			version = unversionedConfig
+		}
+	}
+	if order == 0 {
+		return data, "", nil
+	}
+	if order > 0 {
+		return nil, "", fmt.Errorf("config version %s is newer than %s, the newest this GABS understands; update GABS", version, target)
+	}
+
+	// Keep numbers as written, so large integers survive the round trip
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, "", fmt.Errorf("failed to parse config file: %w", err)
+	}
+	from = version
+	for version != target {
+		step, ok := findConfigMigration(migrations, version)
+		if !ok {
+			return nil, "", fmt.Errorf("no migration from config version %s to %s", version, target)
+		}
+		if err := step.Migrate(doc); err != nil {
+			return nil, "", fmt.Errorf("migrate config from version %s to %s (%s): %w", step.From, step.To, step.Description, err)
+		}
+		version = step.To
+		doc["version"] = version
+	}
+	migrated, err = json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal migrated config: %w", err)
+	}
+	return migrated, from, nil
+}
+
+func findConfigMigration(migrations []configMigration, from string) (configMigration, bool) {
+	for _, step := range migrations {
+		if step.From == from {
+			return step, true
+		}
+	}
+	return configMigration{}, false
+}
+
+// migrateConfigFile migrates the config.json at configPath in place. The old
+// file is kept as config.json.v<version>-<time>.bak first. It returns the
+// data to load, the version migrated from and the backup path; from is empty
+// when nothing changed.
+func migrateConfigFile(configPath string, data []byte) ([]byte, string, string, error) {
+	migrated, from, err := migrateConfigData(data)
+	if err != nil || from == "" {
+		return migrated, "", "", err
+	}
+
+	backupPath := fmt.Sprintf("%s.v%s-%s.bak", configPath, from, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+		return nil, "", "", fmt.Errorf("failed to back up config before migrating it: %w", err)
+	}
+	tempPath := configPath + ".tmp"
+	if err := os.WriteFile(tempPath, migrated, 0644); err != nil {
+		return nil, "", "", fmt.Errorf("failed to write migrated config: %w", err)
+	}
+	if err := os.Rename(tempPath, configPath); err != nil {
+		os.Remove(tempPath)
+		return nil, "", "", fmt.Errorf("failed to replace config with migrated version: %w", err)
+	}
+	return migrated, from, backupPath, nil
+}
+
+// compareConfigVersions orders dotted numeric versions such as 1.0 and 1.2;
+// missing parts count as 0.
+func compareConfigVersions(a, b string) (int, error) {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		na, err := configVersionPart(as, i, a)
+		if err != nil {
+			return 0, err
+		}
+		nb, err := configVersionPart(bs, i, b)
+		if err != nil {
+			return 0, err
+		}
+		if na != nb {
+			if na < nb {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func configVersionPart(parts []string, i int, version string) (int, error) {
+	if i >= len(parts) {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(parts[i])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid config version '%s'", version)
+	}
+	return n, nil
+}
+
+// MigratedFrom reports the config version this configuration was migrated
+// from when it was loaded, and where the old file was backed up. Both are
+// empty when no migration ran.
+func (c *GamesConfig) MigratedFrom() (version, backupPath string) {
+	return c.migratedFrom, c.migrationBackup
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnversionedConfigIsMigratedAndBackedUp(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	original := `{
+  "games": {
+    "factory": {"name": "Factory", "launchMode": "DirectPath", "target": "/bin/true"},
+    "puzzle": {"id": "puzzle", "name": "Puzzle", "launchMode": "DirectPath", "target": "/bin/true"}
+  },
+  "toolsListPageSize": 9007199254740993
+}`
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	gamesConfig, err := LoadGamesConfigFromPath(configPath)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if gamesConfig.Version != CurrentConfigVersion {
+		t.Fatalf("expected version %s, got %q", CurrentConfigVersion, gamesConfig.Version)
+	}
+	if game, _ := gamesConfig.GetGame("factory"); game == nil || game.ID != "factory" {
+		t.Fatalf("expected the missing id to be filled from the key, got %#v", game)
+	}
+	from, backup := gamesConfig.MigratedFrom()
+	if from != "0" || !strings.HasPrefix(filepath.Base(backup), "config.json.v0-") {
+		t.Fatalf("unexpected migration report %q %q", from, backup)
+	}
+	if data, err := os.ReadFile(backup); err != nil || string(data) != original {
+		t.Fatalf("backup does not hold the original file: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read migrated config: %v", err)
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("parse migrated config: %v", err)
+	}
+	if string(doc["version"]) != `"1.0"` || string(doc["toolsListPageSize"]) != "9007199254740993" {
+		t.Fatalf("unexpected migrated config:\n%s", data)
+	}
+
+	// The migrated file loads as is
+	gamesConfig, err = LoadGamesConfigFromPath(configPath)
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	if from, _ := gamesConfig.MigratedFrom(); from != "" {
+		t.Fatalf("expected no second migration, got one from %s", from)
+	}
+}
+
+func TestCurrentConfigIsNotRewritten(t *testing.T) {
+	data := []byte(`{"version": "1.0", "games": {}}`)
+	migrated, from, err := migrateConfigData(data)
+	if err != nil || from != "" || string(migrated) != string(data) {
+		t.Fatalf("expected current config untouched, got %q %q %v", migrated, from, err)
+	}
+}
+
+func TestNewerConfigIsRefused(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"version": "2.3", "games": {}}`), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := LoadGamesConfigFromPath(configPath); err == nil || !strings.Contains(err.Error(), "update GABS") {
+		t.Fatalf("expected newer config to be refused, got %v", err)
+	}
+	if _, _, err := migrateConfigData([]byte(`{"version": "one"}`)); err == nil {
+		t.Fatal("expected an invalid version to be refused")
+	}
+}
+
+func TestMigrationsRunStepwise(t *testing.T) {
+	var steps []string
+	migrations := []configMigration{
+		{From: "0", To: "1.0", Migrate: func(doc map[string]interface{}) error {
+			steps = append(steps, "0")
+			return nil
+		}},
+		{From: "1.0", To: "1.1", Migrate: func(doc map[string]interface{}) error {
+			steps = append(steps, "1.0")
+			doc["portRanges"] = doc["ports"]
+			delete(doc, "ports")
+			return nil
+		}},
+		{From: "1.1", To: "2.0", Migrate: func(doc map[string]interface{}) error {
+			steps = append(steps, "1.1")
+			return nil
+		}},
+	}
+
+	migrated, from, err := migrateConfigDataTo([]byte(`{"version": "1.0", "ports": {"customRanges": []}}`), migrations, "2.0")
+	if err != nil || from != "1.0" {
+		t.Fatalf("migration failed: %q %v", from, err)
+	}
+	if strings.Join(steps, ",") != "1.0,1.1" {
+		t.Fatalf("unexpected steps %v", steps)
+	}
+	var doc map[string]interface{}
+	json.Unmarshal(migrated, &doc)
+	if doc["version"] != "2.0" || doc["ports"] != nil || doc["portRanges"] == nil {
+		t.Fatalf("unexpected migrated document %s", migrated)
+	}
+
+	// Versions from before the first schema start at the beginning
+	steps = nil
+	if _, from, err := migrateConfigDataTo([]byte(`{"version": "0.9"}`), migrations, "2.0"); err != nil || from != "0" || len(steps) != 3 {
+		t.Fatalf("expected 0.9 to migrate from the start, got %q %v %v", from, steps, err)
+	}
+
+	if _, _, err := migrateConfigDataTo([]byte(`{"version": "1.0"}`), migrations[:1], "2.0"); err == nil {
+		t.Fatal("expected a gap in the migrations to be reported")
+	}
+}
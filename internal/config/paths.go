@@ -49,11 +49,44 @@ func (cp *ConfigPaths) GetBridgeConfigPath(gameID string) string {
 	return filepath.Join(cp.GetGameDir(gameID), "bridge.json")
 }
 
+// GetBridgeSocketPath returns the default path of a game's GABP Unix socket.
+// It lives in its own directory so access can be limited to the current user.
+func (cp *ConfigPaths) GetBridgeSocketPath(gameID string) string {
+	return filepath.Join(cp.GetGameDir(gameID), "gabp", "bridge.sock")
+}
+
 // GetRuntimeStatePath returns the path to a game's shared runtime state file.
 func (cp *ConfigPaths) GetRuntimeStatePath(gameID string) string {
 	return filepath.Join(cp.GetGameDir(gameID), "runtime.json")
 }
 
+// GetPortLeasePath returns the path of the file remembering the GABP port a
+// game last used
+func (cp *ConfigPaths) GetPortLeasePath(gameID string) string {
+	return filepath.Join(cp.GetGameDir(gameID), "port-lease.json")
+}
+
+// GetGameLogDir returns the directory holding a game's captured output logs
+func (cp *ConfigPaths) GetGameLogDir(gameID string) string {
+	return filepath.Join(cp.GetGameDir(gameID), "logs")
+}
+
+// GetSavesBackupDir returns the directory holding backups of a game's saves
+func (cp *ConfigPaths) GetSavesBackupDir(gameID string) string {
+	return filepath.Join(cp.GetGameDir(gameID), "saves")
+}
+
+// GetDisabledBridgePackagesDir returns the directory holding a game's
+// disabled bridge packages, out of reach of the game's plugin loader
+func (cp *ConfigPaths) GetDisabledBridgePackagesDir(gameID string) string {
+	return filepath.Join(cp.GetGameDir(gameID), "disabled-bridge-packages")
+}
+
+// GetControlDir returns the directory holding control sockets of running servers
+func (cp *ConfigPaths) GetControlDir() string {
+	return filepath.Join(cp.baseDir, "control")
+}
+
 // EnsureGameDir creates the game-specific directory if it doesn't exist
 func (cp *ConfigPaths) EnsureGameDir(gameID string) error {
 	gameDir := cp.GetGameDir(gameID)
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultConfirmationTTL is how long a confirmation token stays valid unless
+// confirmation.ttl says otherwise.
+const DefaultConfirmationTTL = 2 * time.Minute
+
+// ToolPolicyConfig allows or denies tools by name pattern, so operators can
+// give agents read-only tools while keeping destructive ones out of reach. A
+// '*' in a pattern matches any run of characters. '.' and '/' are the same
+// separator, so "world.*" also matches the GABP tool "world/set_block".
+type ToolPolicyConfig struct {
+	Roles []string `json:"roles,omitempty"` // Access roles the policy applies to; empty or "*" means every role
+	Allow []string `json:"allow,omitempty"` // When set, only matching tools may be called
+	Deny  []string `json:"deny,omitempty"`  // Matching tools may not be called; deny wins over allow
+}
+
+// Validate checks that the policy has at least one non-empty pattern list.
+func (p ToolPolicyConfig) Validate() error {
+	if len(p.Allow) == 0 && len(p.Deny) == 0 {
+		return fmt.Errorf("tool policy needs allow or deny patterns")
+	}
+	for _, pattern := range append(append([]string(nil), p.Allow...), p.Deny...) {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("tool policy patterns must not be empty")
+		}
+	}
+	return nil
+}
+
+// appliesTo reports whether the policy covers role.
+func (p ToolPolicyConfig) appliesTo(role string) bool {
+	return len(p.Roles) == 0 || roleListContains(p.Roles, role)
+}
+
+// permits reports whether the policy lets role call the tool called name. A
+// policy that does not apply to role permits everything.
+func (p ToolPolicyConfig) permits(role, name string) bool {
+	if !p.appliesTo(role) {
+		return true
+	}
+	if matchesAnyToolPattern(p.Deny, name) {
+		return false
+	}
+	return len(p.Allow) == 0 || matchesAnyToolPattern(p.Allow, name)
+}
+
+func matchesAnyToolPattern(patterns []string, name string) bool {
+	name = strings.ReplaceAll(name, ".", "/")
+	for _, pattern := range patterns {
+		if MatchURIPattern(strings.ReplaceAll(pattern, ".", "/"), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToolAllowed reports whether role may call a tool. For GABS's own tools
+// gameID is empty and name is the dotted tool name, such as "games.kill". For
+// a game's mirrored tools name is the GABP tool name; top-level policies see
+// it as "<gameId>.<name>" and the game's own policies see it unchanged. Every
+// policy that applies must permit the call.
+func (c *GamesConfig) ToolAllowed(role, gameID, name string) bool {
+	if c == nil {
+		return true
+	}
+	globalName := name
+	if gameID != "" {
+		globalName = gameID + "." + name
+	}
+	for _, policy := range c.ToolPolicy {
+		if !policy.permits(role, globalName) {
+			return false
+		}
+	}
+	if gameID == "" {
+		return true
+	}
+	game, exists := c.GetGame(gameID)
+	if !exists {
+		return true
+	}
+	for _, policy := range game.ToolPolicy {
+		if !policy.permits(role, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// ToolProfile is a named set of tools, such as "readonly", that an API key or
+// a single MCP session can be limited to. Tools outside the profile are left
+// out of tools/list and refused at call time. Patterns name tools as the
+// top-level toolPolicy does.
+type ToolProfile struct {
+	Allow []string `json:"allow,omitempty"` // When set, only matching tools are in the profile
+	Deny  []string `json:"deny,omitempty"`  // Matching tools are not in the profile; deny wins over allow
+}
+
+// validateToolProfiles checks every profile and that API keys only name
+// profiles that exist.
+func (c *GamesConfig) validateToolProfiles() error {
+	for name, profile := range c.ToolProfiles {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid toolProfiles: profile names must not be empty")
+		}
+		if err := (ToolPolicyConfig{Allow: profile.Allow, Deny: profile.Deny}).Validate(); err != nil {
+			return fmt.Errorf("invalid toolProfiles entry '%s': %w", name, err)
+		}
+	}
+	for _, apiKey := range c.APIKeys {
+		if _, exists := c.ToolProfiles[apiKey.ToolProfile]; apiKey.ToolProfile != "" && !exists {
+			return fmt.Errorf("invalid apiKeys entry: key '%s' uses unknown toolProfile '%s'", apiKey.Label(), apiKey.ToolProfile)
+		}
+	}
+	return nil
+}
+
+// HasToolProfile reports whether profile is one of the toolProfiles.
+func (c *GamesConfig) HasToolProfile(profile string) bool {
+	if c == nil {
+		return false
+	}
+	_, exists := c.ToolProfiles[profile]
+	return exists
+}
+
+// ToolInProfile reports whether the tool gameID and name describe, as for
+// ToolAllowed, belongs to profile. An empty profile holds every tool and an
+// unknown one none, so a profile removed from the config locks sessions out
+// instead of opening everything.
+func (c *GamesConfig) ToolInProfile(profile, gameID, name string) bool {
+	if profile == "" {
+		return true
+	}
+	if c == nil {
+		return false
+	}
+	toolProfile, exists := c.ToolProfiles[profile]
+	if !exists {
+		return false
+	}
+	if gameID != "" {
+		name = gameID + "." + name
+	}
+	return ToolPolicyConfig{Allow: toolProfile.Allow, Deny: toolProfile.Deny}.permits("", name)
+}
+
+// ConfirmationConfig holds back calls to destructive tools until the agent
+// confirms them with games.confirm, so operators can keep agents on a leash.
+type ConfirmationConfig struct {
+	Tools []string `json:"tools,omitempty"` // Tool name patterns, named as in top-level toolPolicy
+	Tags  []string `json:"tags,omitempty"`  // Mirrored tools the bridge tagged with one of these, e.g. "destructive"
+	TTL   string   `json:"ttl,omitempty"`   // How long a confirmation token stays valid, default "2m"
+}
+
+// TTLDuration returns how long a confirmation token stays valid.
+func (c ConfirmationConfig) TTLDuration() (time.Duration, error) {
+	if c.TTL == "" {
+		return DefaultConfirmationTTL, nil
+	}
+	ttl, err := time.ParseDuration(c.TTL)
+	if err != nil {
+		return 0, fmt.Errorf("confirmation ttl '%s' is not a duration such as \"2m\"", c.TTL)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("confirmation ttl must be positive")
+	}
+	return ttl, nil
+}
+
+// Validate checks that the confirmation settings select some tools and have a
+// usable TTL.
+func (c ConfirmationConfig) Validate() error {
+	if len(c.Tools) == 0 && len(c.Tags) == 0 {
+		return fmt.Errorf("confirmation needs tools or tags")
+	}
+	_, err := c.TTLDuration()
+	return err
+}
+
+// RequiresConfirmation reports whether calls to a tool wait for games.confirm.
+// gameID and name describe the tool as for ToolAllowed; tags are the GABP
+// tags of a mirrored tool.
+func (c *GamesConfig) RequiresConfirmation(gameID, name string, tags []string) bool {
+	if c == nil || c.Confirmation == nil {
+		return false
+	}
+	globalName := name
+	if gameID != "" {
+		globalName = gameID + "." + name
+	}
+	if matchesAnyToolPattern(c.Confirmation.Tools, globalName) {
+		return true
+	}
+	for _, want := range c.Confirmation.Tags {
+		for _, tag := range tags {
+			if tag == want {
+				return true
+			}
+		}
+	}
+	return false
+}
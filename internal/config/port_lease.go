@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// portLease remembers the GABP port a game's bridge last used. It is kept
+// apart from bridge.json so the port survives an endpoint reset.
+type portLease struct {
+	Port     int       `json:"port"`
+	LeasedAt time.Time `json:"leasedAt"`
+}
+
+// PreferredBridgePort returns the port a new GABP endpoint of gameID tries
+// first: the game's fixedPort, else the port it leased last time, else 0.
+func PreferredBridgePort(gameID, configDir string, gamesConfig *GamesConfig) int {
+	if gamesConfig != nil {
+		if game, exists := gamesConfig.GetGame(gameID); exists && game.FixedPort != 0 {
+			return game.FixedPort
+		}
+	}
+	cp, err := NewConfigPaths(configDir)
+	if err != nil {
+		return 0
+	}
+	data, err := os.ReadFile(cp.GetPortLeasePath(gameID))
+	if err != nil {
+		return 0
+	}
+	var lease portLease
+	if err := json.Unmarshal(data, &lease); err != nil || lease.Port <= 0 || lease.Port > 65535 {
+		return 0
+	}
+	return lease.Port
+}
+
+// writePortLease records port as the one gameID uses.
+func writePortLease(gameID, configDir string, port int) error {
+	cp, err := NewConfigPaths(configDir)
+	if err != nil {
+		return fmt.Errorf("failed to create config paths: %w", err)
+	}
+	data, err := json.MarshalIndent(portLease{Port: port, LeasedAt: time.Now().UTC()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	leasePath := cp.GetPortLeasePath(gameID)
+	tempPath := leasePath + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write port lease: %w", err)
+	}
+	if err := os.Rename(tempPath, leasePath); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("failed to write port lease: %w", err)
+	}
+	return nil
+}
+
+// fixedPortOwner returns the game other than gameID that claims port as its
+// fixedPort.
+func (c *GamesConfig) fixedPortOwner(port int, gameID string) (string, bool) {
+	if c == nil || port == 0 {
+		return "", false
+	}
+	c.gamesMu.RLock()
+	defer c.gamesMu.RUnlock()
+	for id, game := range c.Games {
+		if id != gameID && game.FixedPort == port {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// reservedPorts returns the fixedPort of every game, which port allocation
+// for other games leaves alone.
+func (c *GamesConfig) reservedPorts() map[int]bool {
+	if c == nil {
+		return nil
+	}
+	c.gamesMu.RLock()
+	defer c.gamesMu.RUnlock()
+	reserved := make(map[int]bool)
+	for _, game := range c.Games {
+		if game.FixedPort != 0 {
+			reserved[game.FixedPort] = true
+		}
+	}
+	return reserved
+}
+
+// validateFixedPorts rejects two games with the same fixedPort.
+func (c *GamesConfig) validateFixedPorts() error {
+	ids := make([]string, 0, len(c.Games))
+	for id := range c.Games {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		port := c.Games[id].FixedPort
+		if port < 0 || port > 65535 {
+			return fmt.Errorf("invalid fixedPort of game '%s': %d must be between 1 and 65535", id, port)
+		}
+		if owner, taken := c.fixedPortOwner(port, id); taken && owner < id {
+			return fmt.Errorf("invalid fixedPort of game '%s': port %d is already the fixedPort of '%s'", id, port, owner)
+		}
+	}
+	return nil
+}
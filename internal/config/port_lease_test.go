@@ -0,0 +1,102 @@
+package config
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestBridgeUsesFixedPort(t *testing.T) {
+	tempDir := t.TempDir()
+	port := freePortForTest(t)
+	gamesConfig := &GamesConfig{Games: map[string]GameConfig{
+		"factory": {ID: "factory", FixedPort: port},
+	}}
+
+	got, _, _, _, err := PrepareBridgeEndpointForStart("factory", tempDir, gamesConfig, false)
+	if err != nil {
+		t.Fatalf("PrepareBridgeEndpointForStart failed: %v", err)
+	}
+	if got != port {
+		t.Fatalf("expected fixed port %d, got %d", port, got)
+	}
+}
+
+func TestBridgeReusesLeasedPortAfterReset(t *testing.T) {
+	tempDir := t.TempDir()
+	port, token, _, err := WriteBridgeJSON("factory", tempDir)
+	if err != nil {
+		t.Fatalf("WriteBridgeJSON failed: %v", err)
+	}
+	if leased := PreferredBridgePort("factory", tempDir, nil); leased != port {
+		t.Fatalf("expected lease of port %d, got %d", port, leased)
+	}
+
+	got, newToken, _, reused, err := PrepareBridgeEndpointForStart("factory", tempDir, nil, true)
+	if err != nil {
+		t.Fatalf("PrepareBridgeEndpointForStart failed: %v", err)
+	}
+	if reused || got != port || newToken == token {
+		t.Fatalf("expected a new token on leased port %d, got port=%d reused=%v", port, got, reused)
+	}
+}
+
+func TestBridgeFallsBackWhenPreferredPortIsBusy(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve busy port: %v", err)
+	}
+	defer listener.Close()
+
+	busyPort := listener.Addr().(*net.TCPAddr).Port
+	tempDir := t.TempDir()
+	gamesConfig := &GamesConfig{Games: map[string]GameConfig{
+		"factory": {ID: "factory", FixedPort: busyPort},
+	}}
+	port, _, _, err := WriteBridgeJSONWithConfig("factory", tempDir, gamesConfig)
+	if err != nil {
+		t.Fatalf("WriteBridgeJSONWithConfig failed: %v", err)
+	}
+	if port == busyPort || port <= 0 {
+		t.Fatalf("expected another port than busy %d, got %d", busyPort, port)
+	}
+	if preferred := PreferredBridgePort("factory", tempDir, gamesConfig); preferred != busyPort {
+		t.Fatalf("expected the fixed port to stay preferred, got %d", preferred)
+	}
+}
+
+func TestPortAllocationLeavesOutFixedPorts(t *testing.T) {
+	port := freePortForTest(t)
+	gamesConfig := &GamesConfig{
+		Games:      map[string]GameConfig{"factory": {ID: "factory", FixedPort: port}},
+		PortRanges: &PortRangeConfig{CustomRanges: []PortRange{{Min: port, Max: port}}},
+	}
+	if got, _, _, err := WriteBridgeJSONWithConfig("adventure", t.TempDir(), gamesConfig); err == nil {
+		t.Fatalf("expected the fixed port of factory to be left out, got %d", got)
+	}
+}
+
+func TestDuplicateFixedPortIsRejected(t *testing.T) {
+	gamesConfig := &GamesConfig{Games: map[string]GameConfig{
+		"factory": {ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/opt/factory/start.sh", FixedPort: 41000},
+	}}
+	err := gamesConfig.AddGame(GameConfig{ID: "adventure", Name: "Adventure", LaunchMode: "DirectPath", Target: "/opt/adventure/run", FixedPort: 41000})
+	if err == nil || !strings.Contains(err.Error(), "factory") {
+		t.Fatalf("expected duplicate fixedPort to be rejected, got %v", err)
+	}
+
+	gamesConfig.Games["adventure"] = GameConfig{ID: "adventure", FixedPort: 41000}
+	if err := gamesConfig.validateFixedPorts(); err == nil || !strings.Contains(err.Error(), "adventure") {
+		t.Fatalf("expected validation to name the second game, got %v", err)
+	}
+}
+
+func freePortForTest(t *testing.T) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
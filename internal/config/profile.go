@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LaunchProfile is a named variant of a game's launch settings, such as a
+// harder difficulty or a particular world. Settings it leaves empty keep the
+// game's own.
+type LaunchProfile struct {
+	Args       []string          `json:"args,omitempty"`       // Replaces the game's args
+	WorkingDir string            `json:"workingDir,omitempty"` // Replaces the game's workingDir
+	Env        map[string]string `json:"env,omitempty"`        // Added to the game's env, winning over same-named entries
+}
+
+// ProfileNames returns the names of the game's launch profiles, sorted.
+func (g GameConfig) ProfileNames() []string {
+	names := make([]string, 0, len(g.Profiles))
+	for name := range g.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithProfile returns a copy of g with the launch profile name applied, and
+// the name of the profile that was used. An empty name selects
+// DefaultProfile; when that is empty too, g is returned unchanged with no
+// profile.
+func (g GameConfig) WithProfile(name string) (GameConfig, string, error) {
+	if name == "" {
+		name = g.DefaultProfile
+	}
+	if name == "" {
+		return g, "", nil
+	}
+	profile, exists := g.Profiles[name]
+	if !exists {
+		if len(g.Profiles) == 0 {
+			return g, "", fmt.Errorf("game '%s' has no launch profiles", g.ID)
+		}
+		return g, "", fmt.Errorf("game '%s' has no launch profile '%s', must be one of: %s", g.ID, name, strings.Join(g.ProfileNames(), ", "))
+	}
+
+	if len(profile.Args) > 0 {
+		g.Args = profile.Args
+	}
+	if profile.WorkingDir != "" {
+		g.WorkingDir = profile.WorkingDir
+	}
+	if len(profile.Env) > 0 {
+		env := make(map[string]string, len(g.Env)+len(profile.Env))
+		for key, value := range g.Env {
+			env[key] = value
+		}
+		for key, value := range profile.Env {
+			env[key] = value
+		}
+		g.Env = env
+	}
+	return g, name, nil
+}
+
+// WithLaunchProfile returns a copy of g with the launch profile name added or
+// replaced.
+func (g GameConfig) WithLaunchProfile(name string, profile LaunchProfile) GameConfig {
+	profiles := make(map[string]LaunchProfile, len(g.Profiles)+1)
+	for existing, p := range g.Profiles {
+		profiles[existing] = p
+	}
+	profiles[name] = profile
+	g.Profiles = profiles
+	return g
+}
+
+// WithoutLaunchProfile returns a copy of g without the launch profile name,
+// and clears DefaultProfile when it named that profile. It reports whether
+// the game had the profile.
+func (g GameConfig) WithoutLaunchProfile(name string) (GameConfig, bool) {
+	if _, exists := g.Profiles[name]; !exists {
+		return g, false
+	}
+	profiles := make(map[string]LaunchProfile, len(g.Profiles))
+	for existing, p := range g.Profiles {
+		if existing != name {
+			profiles[existing] = p
+		}
+	}
+	if len(profiles) == 0 {
+		profiles = nil
+	}
+	g.Profiles = profiles
+	if g.DefaultProfile == name {
+		g.DefaultProfile = ""
+	}
+	return g, true
+}
+
+// validateProfiles checks profile names, their env keys and that
+// DefaultProfile names one of them.
+func (g GameConfig) validateProfiles() error {
+	for name, profile := range g.Profiles {
+		if strings.TrimSpace(name) == "" || strings.ContainsAny(name, " \t") {
+			return fmt.Errorf("launch profile names must be non-empty and contain no spaces, got '%s'", name)
+		}
+		for key := range profile.Env {
+			if key == "" || strings.ContainsAny(key, "= \t") {
+				return fmt.Errorf("launch profile '%s' env key '%s' must be non-empty and contain no '=' or spaces", name, key)
+			}
+		}
+	}
+	if g.DefaultProfile != "" {
+		if _, exists := g.Profiles[g.DefaultProfile]; !exists {
+			return fmt.Errorf("defaultProfile '%s' is not one of the game's launch profiles", g.DefaultProfile)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultSavesKeep is how many backups of a game's saves GABS keeps when
+// saves.keep is not set.
+const DefaultSavesKeep = 10
+
+// SavesConfig points GABS at a game's save directory, so games_saves_backup
+// can snapshot it and games_saves_restore roll it back.
+type SavesConfig struct {
+	Dir  string `json:"dir"`            // Save directory, absolute or relative to WorkingDir
+	Keep int    `json:"keep,omitempty"` // Backups to keep; older ones are removed. Default DefaultSavesKeep
+}
+
+// KeepCount returns how many backups to keep.
+func (s SavesConfig) KeepCount() int {
+	if s.Keep <= 0 {
+		return DefaultSavesKeep
+	}
+	return s.Keep
+}
+
+// SavesDir returns the game's save directory, or "" when saves are not
+// configured.
+func (g GameConfig) SavesDir() string {
+	if g.Saves == nil {
+		return ""
+	}
+	dir := strings.TrimSpace(g.Saves.Dir)
+	if dir == "" || filepath.IsAbs(dir) || g.WorkingDir == "" {
+		return dir
+	}
+	return filepath.Join(g.WorkingDir, dir)
+}
+
+func (g GameConfig) validateSaves() error {
+	if g.Saves == nil {
+		return nil
+	}
+	dir := strings.TrimSpace(g.Saves.Dir)
+	if dir == "" {
+		return fmt.Errorf("saves requires a dir")
+	}
+	if !filepath.IsAbs(dir) && strings.TrimSpace(g.WorkingDir) == "" {
+		return fmt.Errorf("saves.dir '%s' is relative, which requires workingDir to be set", dir)
+	}
+	if g.Saves.Keep < 0 {
+		return fmt.Errorf("saves.keep must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pardeike/gabs/internal/scheduler"
+)
+
+// Actions a schedule can run.
+const (
+	ScheduleActionStart   = "start"
+	ScheduleActionStop    = "stop"
+	ScheduleActionRestart = "restart"
+)
+
+// ScheduleConfig starts, stops or restarts a game at the times a cron
+// expression or a fixed interval gives, for example a nightly server restart.
+type ScheduleConfig struct {
+	ID     string `json:"id"`              // Unique within the game; letters, digits, '-' and '_'
+	Action string `json:"action"`          // start, stop or restart
+	Cron   string `json:"cron,omitempty"`  // Five-field cron expression in local time, such as "0 4 * * *"
+	Every  string `json:"every,omitempty"` // Interval such as "6h", aligned to the Unix epoch
+}
+
+// Spec returns when the schedule fires.
+func (s ScheduleConfig) Spec() (scheduler.Spec, error) {
+	if s.Cron != "" {
+		cron, err := scheduler.ParseCron(s.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("schedule '%s': %w", s.ID, err)
+		}
+		return cron, nil
+	}
+	every, err := time.ParseDuration(s.Every)
+	if err != nil {
+		return nil, fmt.Errorf("schedule '%s' has an invalid every '%s': use a duration such as \"6h\"", s.ID, s.Every)
+	}
+	if every < time.Minute {
+		return nil, fmt.Errorf("schedule '%s' needs an every of at least 1m", s.ID)
+	}
+	return scheduler.Interval{Period: every}, nil
+}
+
+// Validate checks the ID and action, and that exactly one of cron and every
+// is set.
+func (s ScheduleConfig) Validate() error {
+	if s.ID == "" {
+		return fmt.Errorf("schedule ID is required")
+	}
+	for _, r := range s.ID {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("schedule ID '%s' may only contain letters, digits, '-' and '_'", s.ID)
+		}
+	}
+	switch s.Action {
+	case ScheduleActionStart, ScheduleActionStop, ScheduleActionRestart:
+	default:
+		return fmt.Errorf("schedule '%s' has an invalid action '%s', must be one of: start, stop, restart", s.ID, s.Action)
+	}
+	if (s.Cron == "") == (s.Every == "") {
+		return fmt.Errorf("schedule '%s' needs exactly one of cron or every", s.ID)
+	}
+	_, err := s.Spec()
+	return err
+}
+
+// NextScheduleID returns action, or action-2, action-3 and so on if a schedule
+// of the game already uses that ID.
+func (g GameConfig) NextScheduleID(action string) string {
+	taken := make(map[string]bool, len(g.Schedules))
+	for _, schedule := range g.Schedules {
+		taken[schedule.ID] = true
+	}
+	id := action
+	for n := 2; taken[id]; n++ {
+		id = fmt.Sprintf("%s-%d", action, n)
+	}
+	return id
+}
+
+// WithSchedule returns a copy of g with schedule added, or replacing the
+// schedule with the same ID in place. It reports whether one was replaced.
+func (g GameConfig) WithSchedule(schedule ScheduleConfig) (GameConfig, bool) {
+	schedules := make([]ScheduleConfig, 0, len(g.Schedules)+1)
+	replaced := false
+	for _, existing := range g.Schedules {
+		if existing.ID == schedule.ID {
+			existing = schedule
+			replaced = true
+		}
+		schedules = append(schedules, existing)
+	}
+	if !replaced {
+		schedules = append(schedules, schedule)
+	}
+	g.Schedules = schedules
+	return g, replaced
+}
+
+// WithoutSchedule returns a copy of g without the schedule id. It reports
+// whether the game had that schedule.
+func (g GameConfig) WithoutSchedule(id string) (GameConfig, bool) {
+	var schedules []ScheduleConfig
+	for _, existing := range g.Schedules {
+		if existing.ID != id {
+			schedules = append(schedules, existing)
+		}
+	}
+	removed := len(schedules) != len(g.Schedules)
+	g.Schedules = schedules
+	return g, removed
+}
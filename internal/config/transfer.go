@@ -0,0 +1,255 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GamesExportFormat marks a document written by ExportGames.
+const GamesExportFormat = "gabs-games"
+
+// GamesExportVersion is the version of the export document layout.
+const GamesExportVersion = "1"
+
+// GamesExport is a portable document with the game and cluster definitions of
+// one GABS installation, for moving them to another machine. Server-wide
+// settings such as API keys are not included.
+type GamesExport struct {
+	Format   string                   `json:"format"`
+	Version  string                   `json:"version"`
+	Games    map[string]GameConfig    `json:"games"`
+	Clusters map[string]ClusterConfig `json:"clusters,omitempty"`
+}
+
+// ImportMode decides what ImportGames does with a game or cluster whose ID is
+// already configured.
+type ImportMode string
+
+const (
+	ImportSkip      ImportMode = "skip"      // Keep the existing definition
+	ImportOverwrite ImportMode = "overwrite" // Replace it with the imported one
+	ImportMerge     ImportMode = "merge"     // Apply the imported non-empty fields on top of it
+)
+
+// ParseImportMode parses skip, overwrite or merge. An empty value means skip.
+func ParseImportMode(value string) (ImportMode, error) {
+	switch mode := ImportMode(strings.TrimSpace(value)); mode {
+	case "":
+		return ImportSkip, nil
+	case ImportSkip, ImportOverwrite, ImportMerge:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid import mode '%s', must be one of: skip, overwrite, merge", value)
+	}
+}
+
+// ImportResult lists the IDs of games and clusters by what ImportGames did
+// with them.
+type ImportResult struct {
+	Added       []string `json:"added"`
+	Overwritten []string `json:"overwritten"`
+	Merged      []string `json:"merged"`
+	Skipped     []string `json:"skipped"`
+}
+
+// Changed reports whether the import changed any definition.
+func (r ImportResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Overwritten) > 0 || len(r.Merged) > 0
+}
+
+func (r *ImportResult) record(id string, existed bool, mode ImportMode) {
+	switch {
+	case !existed:
+		r.Added = append(r.Added, id)
+	case mode == ImportOverwrite:
+		r.Overwritten = append(r.Overwritten, id)
+	case mode == ImportMerge:
+		r.Merged = append(r.Merged, id)
+	default:
+		r.Skipped = append(r.Skipped, id)
+	}
+}
+
+// ExportGames returns every game and cluster definition as a portable
+// document.
+func (c *GamesConfig) ExportGames() GamesExport {
+	c.gamesMu.RLock()
+	defer c.gamesMu.RUnlock()
+	doc := GamesExport{
+		Format:  GamesExportFormat,
+		Version: GamesExportVersion,
+		Games:   make(map[string]GameConfig, len(c.Games)),
+	}
+	for id, game := range c.Games {
+		doc.Games[id] = game
+	}
+	if len(c.Clusters) > 0 {
+		doc.Clusters = make(map[string]ClusterConfig, len(c.Clusters))
+		for id, cluster := range c.Clusters {
+			cluster.Members = append([]string(nil), cluster.Members...)
+			doc.Clusters[id] = cluster
+		}
+	}
+	return doc
+}
+
+// ImportGames adds the games and clusters of doc, resolving IDs that already
+// exist with mode. Every resulting definition is validated first; if one is
+// invalid, nothing is changed.
+func (c *GamesConfig) ImportGames(doc GamesExport, mode ImportMode) (ImportResult, error) {
+	candidate, result, err := c.PlanImport(doc, mode)
+	if err != nil {
+		return result, err
+	}
+	if result.Changed() {
+		c.ReplaceDefinitions(candidate.Games, candidate.Clusters)
+	}
+	return result, nil
+}
+
+// PlanImport returns the definitions ImportGames would leave behind without
+// changing c, so callers can inspect them before committing the import with
+// ReplaceDefinitions.
+func (c *GamesConfig) PlanImport(doc GamesExport, mode ImportMode) (*GamesConfig, ImportResult, error) {
+	result := ImportResult{Added: []string{}, Overwritten: []string{}, Merged: []string{}, Skipped: []string{}}
+	if doc.Format != GamesExportFormat {
+		return nil, result, fmt.Errorf("not a GABS games export: format is '%s', want '%s'", doc.Format, GamesExportFormat)
+	}
+	if doc.Version != GamesExportVersion {
+		return nil, result, fmt.Errorf("unsupported games export version '%s'", doc.Version)
+	}
+
+	c.gamesMu.RLock()
+	candidate := &GamesConfig{
+		Games:    make(map[string]GameConfig, len(c.Games)+len(doc.Games)),
+		Clusters: make(map[string]ClusterConfig, len(c.Clusters)+len(doc.Clusters)),
+	}
+	for id, game := range c.Games {
+		candidate.Games[id] = game
+	}
+	for id, cluster := range c.Clusters {
+		candidate.Clusters[id] = cluster
+	}
+	c.gamesMu.RUnlock()
+
+	for _, id := range sortedKeys(doc.Games) {
+		imported := doc.Games[id]
+		if imported.ID == "" {
+			imported.ID = id
+		}
+		if imported.ID != id {
+			return nil, ImportResult{}, fmt.Errorf("game '%s' has mismatched id '%s'", id, imported.ID)
+		}
+		existing, existed := candidate.Games[id]
+		result.record(id, existed, mode)
+		switch {
+		case existed && mode == ImportSkip:
+			continue
+		case existed && mode == ImportMerge:
+			merged, err := mergeGameConfig(existing, imported)
+			if err != nil {
+				return nil, ImportResult{}, fmt.Errorf("game '%s': %w", id, err)
+			}
+			imported = merged
+		}
+		if err := imported.Validate(); err != nil {
+			return nil, ImportResult{}, fmt.Errorf("game '%s': %w", id, err)
+		}
+		candidate.Games[id] = imported
+	}
+
+	for _, id := range sortedKeys(doc.Clusters) {
+		imported := doc.Clusters[id]
+		existing, existed := candidate.Clusters[id]
+		result.record(id, existed, mode)
+		switch {
+		case existed && mode == ImportSkip:
+			continue
+		case existed && mode == ImportMerge:
+			imported = mergeClusterConfig(existing, imported)
+		}
+		candidate.Clusters[id] = imported
+	}
+
+	if err := candidate.validateClusters(); err != nil {
+		return nil, ImportResult{}, err
+	}
+	if err := candidate.validateFixedPorts(); err != nil {
+		return nil, ImportResult{}, err
+	}
+	return candidate, result, nil
+}
+
+// mergeGameConfig applies the fields imported sets on top of existing. Empty
+// fields of imported keep the existing value.
+func mergeGameConfig(existing, imported GameConfig) (GameConfig, error) {
+	base, err := json.Marshal(existing)
+	if err != nil {
+		return existing, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(base, &fields); err != nil {
+		return existing, err
+	}
+	overlay, err := json.Marshal(imported)
+	if err != nil {
+		return existing, err
+	}
+	var updates map[string]interface{}
+	if err := json.Unmarshal(overlay, &updates); err != nil {
+		return existing, err
+	}
+	for key, value := range updates {
+		if value == "" {
+			continue
+		}
+		fields[key] = value
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return existing, err
+	}
+	var merged GameConfig
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return existing, err
+	}
+	return merged, nil
+}
+
+// mergeClusterConfig keeps the existing members in order and appends new
+// ones. Non-empty imported settings replace the existing ones.
+func mergeClusterConfig(existing, imported ClusterConfig) ClusterConfig {
+	merged := existing
+	merged.Members = append([]string(nil), existing.Members...)
+	seen := make(map[string]bool, len(merged.Members))
+	for _, member := range merged.Members {
+		seen[member] = true
+	}
+	for _, member := range imported.Members {
+		if !seen[member] {
+			merged.Members = append(merged.Members, member)
+			seen[member] = true
+		}
+	}
+	if imported.Name != "" {
+		merged.Name = imported.Name
+	}
+	if imported.Description != "" {
+		merged.Description = imported.Description
+	}
+	if imported.StartDelaySeconds > 0 {
+		merged.StartDelaySeconds = imported.StartDelaySeconds
+	}
+	return merged
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTransferTestConfig(t *testing.T) *GamesConfig {
+	t.Helper()
+	cfg := &GamesConfig{Version: "1.0"}
+	for _, game := range []GameConfig{
+		{ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/opt/factory/start.sh", Args: []string{"--headless"}, Description: "main world"},
+		{ID: "adventure", Name: "Adventure", LaunchMode: "DirectPath", Target: "/opt/adventure/run"},
+	} {
+		if err := cfg.AddGame(game); err != nil {
+			t.Fatalf("add game: %v", err)
+		}
+	}
+	cfg.Clusters = map[string]ClusterConfig{"servers": {ID: "servers", Members: []string{"factory"}}}
+	return cfg
+}
+
+func TestExportGamesRoundTripsThroughJSON(t *testing.T) {
+	source := newTransferTestConfig(t)
+	data, err := json.Marshal(source.ExportGames())
+	if err != nil {
+		t.Fatalf("marshal export: %v", err)
+	}
+	var doc GamesExport
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("unmarshal export: %v", err)
+	}
+
+	target := &GamesConfig{Version: "1.0"}
+	result, err := target.ImportGames(doc, ImportSkip)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.Added, []string{"adventure", "factory", "servers"}) {
+		t.Fatalf("unexpected import result: %#v", result)
+	}
+	if !reflect.DeepEqual(target.ExportGames(), source.ExportGames()) {
+		t.Fatalf("imported definitions differ:\n%#v\n%#v", target.ExportGames(), source.ExportGames())
+	}
+}
+
+func TestImportGamesResolvesConflictsByMode(t *testing.T) {
+	doc := GamesExport{
+		Format:  GamesExportFormat,
+		Version: GamesExportVersion,
+		Games: map[string]GameConfig{
+			"factory": {Name: "Factory Server", LaunchMode: "DirectPath", Target: "", StopProcessName: "java"},
+			"puzzle":  {Name: "Puzzle", LaunchMode: "DirectPath", Target: "/opt/puzzle/run"},
+		},
+		Clusters: map[string]ClusterConfig{"servers": {Members: []string{"puzzle"}}},
+	}
+
+	cfg := newTransferTestConfig(t)
+	result, err := cfg.ImportGames(doc, ImportSkip)
+	if err != nil {
+		t.Fatalf("skip import failed: %v", err)
+	}
+	factory, _ := cfg.GetGame("factory")
+	if !reflect.DeepEqual(result.Skipped, []string{"factory", "servers"}) || factory.Name != "Factory" {
+		t.Fatalf("expected existing definitions to be kept, got %#v and %#v", result, factory)
+	}
+
+	cfg = newTransferTestConfig(t)
+	result, err = cfg.ImportGames(doc, ImportMerge)
+	if err != nil {
+		t.Fatalf("merge import failed: %v", err)
+	}
+	factory, _ = cfg.GetGame("factory")
+	servers, _ := cfg.GetCluster("servers")
+	if !reflect.DeepEqual(result.Merged, []string{"factory", "servers"}) || factory.Name != "Factory Server" || factory.Target != "/opt/factory/start.sh" || factory.StopProcessName != "java" || factory.Description != "main world" {
+		t.Fatalf("expected imported fields on top of the existing game, got %#v and %#v", result, factory)
+	}
+	if !reflect.DeepEqual(servers.Members, []string{"factory", "puzzle"}) {
+		t.Fatalf("expected merged cluster members, got %v", servers.Members)
+	}
+
+	cfg = newTransferTestConfig(t)
+	result, err = cfg.ImportGames(doc, ImportOverwrite)
+	if err != nil {
+		t.Fatalf("overwrite import failed: %v", err)
+	}
+	factory, _ = cfg.GetGame("factory")
+	if !reflect.DeepEqual(result.Overwritten, []string{"factory", "servers"}) || factory.Target != "" || factory.Description != "" || len(factory.Args) != 0 {
+		t.Fatalf("expected the imported game to replace the existing one, got %#v and %#v", result, factory)
+	}
+}
+
+func TestImportGamesChangesNothingWhenADefinitionIsInvalid(t *testing.T) {
+	cfg := newTransferTestConfig(t)
+	before := cfg.ExportGames()
+	_, err := cfg.ImportGames(GamesExport{
+		Format:  GamesExportFormat,
+		Version: GamesExportVersion,
+		Games: map[string]GameConfig{
+			"puzzle": {Name: "Puzzle", LaunchMode: "DirectPath", Target: "/opt/puzzle/run"},
+			"racer":  {Name: "Racer", LaunchMode: "EpicAppId", Target: "racer-app"},
+		},
+	}, ImportOverwrite)
+	if err == nil {
+		t.Fatal("expected an EpicAppId game without stopProcessName to be rejected")
+	}
+	if !reflect.DeepEqual(cfg.ExportGames(), before) {
+		t.Fatal("expected a failed import to leave the config unchanged")
+	}
+
+	if _, err := cfg.ImportGames(GamesExport{Games: map[string]GameConfig{}}, ImportSkip); err == nil {
+		t.Fatal("expected a document without the export format to be rejected")
+	}
+	if _, err := ParseImportMode("replace"); err == nil {
+		t.Fatal("expected an unknown import mode to be rejected")
+	}
+}
+
+func TestImportGamesRejectsDuplicateFixedPorts(t *testing.T) {
+	cfg := newTransferTestConfig(t)
+	before := cfg.ExportGames()
+	_, err := cfg.ImportGames(GamesExport{
+		Format:  GamesExportFormat,
+		Version: GamesExportVersion,
+		Games: map[string]GameConfig{
+			"puzzle": {Name: "Puzzle", LaunchMode: "DirectPath", Target: "/opt/puzzle/run", FixedPort: 41000},
+			"racer":  {Name: "Racer", LaunchMode: "DirectPath", Target: "/opt/racer/run", FixedPort: 41000},
+		},
+	}, ImportOverwrite)
+	if err == nil || !strings.Contains(err.Error(), "41000") {
+		t.Fatalf("expected the shared fixedPort to be rejected, got %v", err)
+	}
+	if !reflect.DeepEqual(cfg.ExportGames(), before) {
+		t.Fatal("expected a failed import to leave the config unchanged")
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Webhook events of a game's lifecycle. GABP events use WebhookEventGABP and
+// are selected with WebhookConfig.Channels.
+const (
+	WebhookEventGameStarted = "game.started"
+	WebhookEventGameStopped = "game.stopped" // GABS stopped the game, on request or on a schedule
+	WebhookEventGameCrashed = "game.crashed" // The game exited without GABS stopping it
+	WebhookEventGABP        = "gabp.event"
+)
+
+// Webhook payload formats.
+const (
+	WebhookFormatJSON    = "json"    // GABS's own payload with event, gameId, time and data
+	WebhookFormatSlack   = "slack"   // {"text": ...} for Slack incoming webhooks
+	WebhookFormatDiscord = "discord" // {"content": ...} for Discord webhooks
+)
+
+// DefaultWebhookRetries is how often a failed delivery is retried unless the
+// webhook says otherwise.
+const DefaultWebhookRetries = 3
+
+// WebhookConfig POSTs game lifecycle and GABP events to a URL, so chat
+// channels and home automation can follow games without an agent.
+type WebhookConfig struct {
+	URL string `json:"url"`
+	// Events are the lifecycle events sent: game.started, game.stopped and
+	// game.crashed.
+	Events []string `json:"events,omitempty"`
+	// Channels are GABP event channel patterns whose events are sent as
+	// gabp.event, '*' matches any run of characters.
+	Channels []string `json:"channels,omitempty"`
+	// Games limits the webhook to these game ID patterns; empty covers every game.
+	Games []string `json:"games,omitempty"`
+	// Secret signs each body with HMAC-SHA256 in the X-GABS-Signature header.
+	// It may reference variables of the GABS environment as ${NAME}.
+	Secret  string `json:"secret,omitempty"`
+	Format  string `json:"format,omitempty"`  // json (default), slack or discord
+	Retries *int   `json:"retries,omitempty"` // Retries of a failed delivery, default 3
+}
+
+// Validate checks the URL, events, channel patterns and format.
+func (w WebhookConfig) Validate() error {
+	parsed, err := url.Parse(w.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("webhook url '%s' must be an http or https URL", w.URL)
+	}
+	if len(w.Events) == 0 && len(w.Channels) == 0 {
+		return fmt.Errorf("webhook %s needs events or channels to send", w.URL)
+	}
+	for _, event := range w.Events {
+		switch event {
+		case WebhookEventGameStarted, WebhookEventGameStopped, WebhookEventGameCrashed:
+		default:
+			return fmt.Errorf("webhook %s has an unknown event '%s': use %s, %s or %s", w.URL, event, WebhookEventGameStarted, WebhookEventGameStopped, WebhookEventGameCrashed)
+		}
+	}
+	for _, pattern := range append(append([]string(nil), w.Channels...), w.Games...) {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("webhook %s has an empty channel or game pattern", w.URL)
+		}
+	}
+	switch w.Format {
+	case "", WebhookFormatJSON, WebhookFormatSlack, WebhookFormatDiscord:
+	default:
+		return fmt.Errorf("webhook %s has an unknown format '%s': use %s, %s or %s", w.URL, w.Format, WebhookFormatJSON, WebhookFormatSlack, WebhookFormatDiscord)
+	}
+	if w.Retries != nil && *w.Retries < 0 {
+		return fmt.Errorf("webhook %s needs retries of 0 or more", w.URL)
+	}
+	return nil
+}
+
+// RetryCount returns how often a failed delivery is retried.
+func (w WebhookConfig) RetryCount() int {
+	if w.Retries == nil {
+		return DefaultWebhookRetries
+	}
+	return *w.Retries
+}
+
+// Wants reports whether the webhook sends event of gameID. For
+// WebhookEventGABP, channel is the GABP event channel.
+func (w WebhookConfig) Wants(event, gameID, channel string) bool {
+	if len(w.Games) > 0 && !matchesAnyPattern(w.Games, gameID) {
+		return false
+	}
+	if event == WebhookEventGABP {
+		return matchesAnyPattern(w.Channels, channel)
+	}
+	for _, wanted := range w.Events {
+		if wanted == event {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyPattern(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if MatchURIPattern(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package config
+
+import "testing"
+
+func TestWebhookConfigValidateAndWants(t *testing.T) {
+	negative := -1
+	for _, webhook := range []WebhookConfig{
+		{URL: "ftp://example.com/hook", Events: []string{WebhookEventGameStarted}},
+		{URL: "https://example.com/hook"},
+		{URL: "https://example.com/hook", Events: []string{"game.exploded"}},
+		{URL: "https://example.com/hook", Events: []string{WebhookEventGameStarted}, Format: "teams"},
+		{URL: "https://example.com/hook", Events: []string{WebhookEventGameStarted}, Retries: &negative},
+	} {
+		if err := webhook.Validate(); err == nil {
+			t.Errorf("expected %#v to fail validation", webhook)
+		}
+	}
+
+	webhook := WebhookConfig{URL: "https://example.com/hook", Events: []string{WebhookEventGameCrashed}, Channels: []string{"server/*"}, Games: []string{"factory*"}}
+	if err := webhook.Validate(); err != nil {
+		t.Fatalf("expected a valid webhook, got %v", err)
+	}
+	checks := []struct {
+		event, gameID, channel string
+		want                   bool
+	}{
+		{WebhookEventGameCrashed, "factory", "", true},
+		{WebhookEventGameStarted, "factory", "", false},
+		{WebhookEventGABP, "factory-2", "server/low_tps", true},
+		{WebhookEventGABP, "factory", "player/died", false},
+		{WebhookEventGameCrashed, "colony", "", false},
+	}
+	for _, check := range checks {
+		if got := webhook.Wants(check.event, check.gameID, check.channel); got != check.want {
+			t.Errorf("Wants(%q, %q, %q) = %v, want %v", check.event, check.gameID, check.channel, got, check.want)
+		}
+	}
+	if webhook.RetryCount() != DefaultWebhookRetries {
+		t.Fatalf("expected %d retries by default, got %d", DefaultWebhookRetries, webhook.RetryCount())
+	}
+}
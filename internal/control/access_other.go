@@ -0,0 +1,14 @@
+//go:build !windows
+
+package control
+
+import "os"
+
+// restrictToCurrentUser limits path to the user GABS runs as through its
+// permission bits: 0700 for the control directory, 0600 for a socket.
+func restrictToCurrentUser(path string, dir bool) error {
+	if dir {
+		return os.Chmod(path, 0700)
+	}
+	return os.Chmod(path, 0600)
+}
@@ -0,0 +1,86 @@
+//go:build windows
+
+package control
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	writeDAC                         = 0x40000
+	fileFlagOpenReparsePoint         = 0x200000
+	fileFlagBackupSemantics          = 0x2000000
+	daclSecurityInformation          = 0x4
+	protectedDACLSecurityInformation = 0x80000000
+	sddlRevision1                    = 1
+)
+
+var (
+	modadvapi32 = syscall.NewLazyDLL("advapi32.dll")
+	modkernel32 = syscall.NewLazyDLL("kernel32.dll")
+
+	procConvertStringSecurityDescriptorToSecurityDescriptorW = modadvapi32.NewProc("ConvertStringSecurityDescriptorToSecurityDescriptorW")
+	procSetKernelObjectSecurity                              = modadvapi32.NewProc("SetKernelObjectSecurity")
+	procLocalFree                                            = modkernel32.NewProc("LocalFree")
+)
+
+// restrictToCurrentUser replaces the DACL of path with one that only grants
+// the user GABS runs as, and stops it from inheriting entries of the parent
+// folder. Windows ignores permission bits on AF_UNIX socket files, but it
+// does check the socket's DACL when a client connects. On the control
+// directory the entry is inherited, so a socket created in it is restricted
+// from the start.
+func restrictToCurrentUser(path string, dir bool) error {
+	sid, err := currentUserSID()
+	if err != nil {
+		return err
+	}
+	inherit := ""
+	if dir {
+		inherit = "OICI"
+	}
+	sddl, err := syscall.UTF16PtrFromString(fmt.Sprintf("D:P(A;%s;FA;;;%s)", inherit, sid))
+	if err != nil {
+		return err
+	}
+	var descriptor uintptr
+	r, _, err := procConvertStringSecurityDescriptorToSecurityDescriptorW.Call(uintptr(unsafe.Pointer(sddl)), sddlRevision1, uintptr(unsafe.Pointer(&descriptor)), 0)
+	if r == 0 {
+		return fmt.Errorf("build security descriptor: %w", err)
+	}
+	defer procLocalFree.Call(descriptor)
+
+	// A socket file is a reparse point, so open it rather than what it
+	// would point to. Backup semantics allow opening the directory.
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	handle, err := syscall.CreateFile(pathPtr, writeDAC, syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE, nil, syscall.OPEN_EXISTING, fileFlagOpenReparsePoint|fileFlagBackupSemantics, 0)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	defer syscall.CloseHandle(handle)
+	r, _, err = procSetKernelObjectSecurity.Call(uintptr(handle), daclSecurityInformation|protectedDACLSecurityInformation, descriptor)
+	if r == 0 {
+		return fmt.Errorf("set security of %s: %w", path, err)
+	}
+	return nil
+}
+
+// currentUserSID returns the SID of the user the process runs as, such as
+// S-1-5-21-...-1001.
+func currentUserSID() (string, error) {
+	token, err := syscall.OpenCurrentProcessToken()
+	if err != nil {
+		return "", err
+	}
+	defer token.Close()
+	user, err := token.GetTokenUser()
+	if err != nil {
+		return "", err
+	}
+	return user.User.Sid.String()
+}
@@ -0,0 +1,297 @@
+// Package control implements the local control socket that a running GABS
+// server exposes so CLI commands can query and steer it without going through
+// MCP or the HTTP transport.
+//
+// Each server listens on its own Unix domain socket under the config
+// directory's control/ folder, named after the server's process ID. Windows 10
+// and later support the same AF_UNIX sockets, so no named-pipe dependency is
+// needed. Access is limited to the current user: on Unix the directory is
+// 0700 and the socket 0600; on Windows, which ignores permission bits, both
+// get a DACL granting only the current user.
+//
+// The protocol is line-delimited JSON: the client writes one Request and reads
+// one Response, or a stream of Responses for the watch command.
+package control
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// Control commands understood by Serve.
+const (
+	CommandStatus = "status"
+	CommandWatch  = "watch"
+	CommandReload = "reload"
+	CommandTool   = "tool"
+)
+
+const (
+	socketSuffix         = ".sock"
+	defaultWatchInterval = 2 * time.Second
+	minWatchInterval     = 250 * time.Millisecond
+)
+
+// Request is a single control command.
+type Request struct {
+	Command    string                 `json:"command"`
+	GameID     string                 `json:"gameId,omitempty"`
+	IntervalMs int                    `json:"intervalMs,omitempty"` // watch only
+	Tool       string                 `json:"tool,omitempty"`       // tool only
+	Arguments  map[string]interface{} `json:"arguments,omitempty"`  // tool only
+}
+
+// Response answers a Request. Watch sends one Response per interval.
+type Response struct {
+	OK     bool            `json:"ok"`
+	Error  string          `json:"error,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+// Handler provides the server-side behaviour behind the control commands.
+type Handler interface {
+	ControlStatus(gameID string) (interface{}, error)
+	ControlReload() (interface{}, error)
+	// ControlCallTool runs one of the server's game management tools, such
+	// as games.start, the way an MCP client would.
+	ControlCallTool(tool string, args map[string]interface{}) (interface{}, error)
+}
+
+// SocketPath returns the control socket path for the server with the given pid.
+func SocketPath(configDir string, pid int) (string, error) {
+	cp, err := config.NewConfigPaths(configDir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cp.GetControlDir(), strconv.Itoa(pid)+socketSuffix), nil
+}
+
+// Listen opens the control socket for the current process. A leftover socket
+// file from an earlier process with the same pid is replaced.
+func Listen(configDir string) (net.Listener, string, error) {
+	path, err := SocketPath(configDir, os.Getpid())
+	if err != nil {
+		return nil, "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, "", fmt.Errorf("failed to create control directory: %w", err)
+	}
+	if err := restrictToCurrentUser(filepath.Dir(path), true); err != nil {
+		return nil, "", fmt.Errorf("failed to restrict control directory permissions: %w", err)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, "", fmt.Errorf("failed to remove stale control socket: %w", err)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to listen on control socket: %w", err)
+	}
+	if err := restrictToCurrentUser(path, false); err != nil {
+		listener.Close()
+		return nil, "", fmt.Errorf("failed to restrict control socket permissions: %w", err)
+	}
+	return listener, path, nil
+}
+
+// Serve accepts control connections until ctx is cancelled or the listener
+// fails. It closes the listener before returning.
+func Serve(ctx context.Context, listener net.Listener, handler Handler, log util.Logger) error {
+	var wg sync.WaitGroup
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+	defer wg.Wait()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			serveConn(ctx, conn, handler, log)
+		}()
+	}
+}
+
+func serveConn(ctx context.Context, conn net.Conn, handler Handler, log util.Logger) {
+	defer conn.Close()
+
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-connCtx.Done()
+		conn.Close()
+	}()
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil && len(line) == 0 {
+		return
+	}
+
+	encoder := json.NewEncoder(conn)
+	var req Request
+	if err := json.Unmarshal(line, &req); err != nil {
+		encoder.Encode(errorResponse(fmt.Errorf("invalid control request: %w", err)))
+		return
+	}
+	log.Debugw("control request", "command", req.Command, "gameId", req.GameID)
+
+	switch req.Command {
+	case CommandStatus:
+		encoder.Encode(resultResponse(handler.ControlStatus(req.GameID)))
+	case CommandReload:
+		encoder.Encode(resultResponse(handler.ControlReload()))
+	case CommandTool:
+		encoder.Encode(resultResponse(handler.ControlCallTool(req.Tool, req.Arguments)))
+	case CommandWatch:
+		interval := time.Duration(req.IntervalMs) * time.Millisecond
+		if interval <= 0 {
+			interval = defaultWatchInterval
+		}
+		if interval < minWatchInterval {
+			interval = minWatchInterval
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := encoder.Encode(resultResponse(handler.ControlStatus(req.GameID))); err != nil {
+				return
+			}
+			select {
+			case <-connCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	default:
+		encoder.Encode(errorResponse(fmt.Errorf("unknown control command '%s'", req.Command)))
+	}
+}
+
+func resultResponse(result interface{}, err error) Response {
+	if err != nil {
+		return errorResponse(err)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(err)
+	}
+	return Response{OK: true, Result: data}
+}
+
+func errorResponse(err error) Response {
+	return Response{OK: false, Error: err.Error()}
+}
+
+// Discover returns the control sockets of running servers, ordered by path.
+// Sockets left behind by servers that exited are removed.
+func Discover(configDir string) ([]string, error) {
+	cp, err := config.NewConfigPaths(configDir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(cp.GetControlDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), socketSuffix) {
+			continue
+		}
+		path := filepath.Join(cp.GetControlDir(), entry.Name())
+		conn, err := net.DialTimeout("unix", path, time.Second)
+		if err != nil {
+			// Only a refused or vanished socket means its server is gone; a
+			// busy server that is slow to accept keeps its socket.
+			if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, fs.ErrNotExist) {
+				os.Remove(path)
+			}
+			continue
+		}
+		conn.Close()
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Call sends req to the server at path and returns its single response.
+func Call(path string, req Request, timeout time.Duration) (Response, error) {
+	conn, err := net.DialTimeout("unix", path, timeout)
+	if err != nil {
+		return Response{}, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return Response{}, err
+	}
+	var resp Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return Response{}, err
+	}
+	return resp, nil
+}
+
+// Watch sends a watch request and calls fn for each response until ctx is
+// cancelled, fn returns an error, or the server closes the connection.
+func Watch(ctx context.Context, path string, req Request, fn func(Response) error) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", path)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	req.Command = CommandWatch
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(conn)
+	for {
+		var resp Response
+		if err := decoder.Decode(&resp); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err := fn(resp); err != nil {
+			return err
+		}
+	}
+}
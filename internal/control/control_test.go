@@ -0,0 +1,184 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+type fakeHandler struct {
+	reloads int
+}
+
+func (h *fakeHandler) ControlStatus(gameID string) (interface{}, error) {
+	if gameID == "missing" {
+		return nil, errors.New("game 'missing' not found")
+	}
+	return map[string]interface{}{"gameId": gameID}, nil
+}
+
+func (h *fakeHandler) ControlReload() (interface{}, error) {
+	h.reloads++
+	return map[string]interface{}{"reloads": h.reloads}, nil
+}
+
+func (h *fakeHandler) ControlCallTool(tool string, args map[string]interface{}) (interface{}, error) {
+	if tool != "games.start" {
+		return nil, errors.New("unsupported tool " + tool)
+	}
+	return map[string]interface{}{"tool": tool, "gameId": args["gameId"]}, nil
+}
+
+func startTestServer(t *testing.T) (string, string, *fakeHandler) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("unix socket paths under the test temp dir may exceed Windows limits")
+	}
+
+	configDir := t.TempDir()
+	listener, path, err := Listen(configDir)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	handler := &fakeHandler{}
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(ctx, listener, handler, util.NewLogger("error"))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("serve returned error: %v", err)
+		}
+	})
+	return configDir, path, handler
+}
+
+func TestListenRestrictsSocketToCurrentUser(t *testing.T) {
+	_, path, _ := startTestServer(t)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat socket: %v", err)
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		t.Fatalf("socket should not be accessible to other users, mode %v", info.Mode().Perm())
+	}
+	dirInfo, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("stat control dir: %v", err)
+	}
+	if dirInfo.Mode().Perm()&0077 != 0 {
+		t.Fatalf("control dir should not be accessible to other users, mode %v", dirInfo.Mode().Perm())
+	}
+}
+
+func TestListenTightensExistingControlDirectory(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows access is set through a DACL, not permission bits")
+	}
+	configDir := t.TempDir()
+	path, err := SocketPath(configDir, os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	listener, _, err := Listen(configDir)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	info, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("stat control dir: %v", err)
+	}
+	if info.Mode().Perm() != 0700 {
+		t.Fatalf("expected the existing control dir to become 0700, got %v", info.Mode().Perm())
+	}
+}
+
+func TestCallStatusReloadAndErrors(t *testing.T) {
+	_, path, handler := startTestServer(t)
+
+	resp, err := Call(path, Request{Command: CommandStatus, GameID: "factory"}, time.Second)
+	if err != nil || !resp.OK || string(resp.Result) != `{"gameId":"factory"}` {
+		t.Fatalf("unexpected status response: %+v %v", resp, err)
+	}
+
+	resp, err = Call(path, Request{Command: CommandReload}, time.Second)
+	if err != nil || !resp.OK || handler.reloads != 1 {
+		t.Fatalf("unexpected reload response: %+v %v", resp, err)
+	}
+
+	resp, err = Call(path, Request{Command: CommandTool, Tool: "games.start", Arguments: map[string]interface{}{"gameId": "factory"}}, time.Second)
+	if err != nil || !resp.OK || string(resp.Result) != `{"gameId":"factory","tool":"games.start"}` {
+		t.Fatalf("unexpected tool response: %+v %v", resp, err)
+	}
+
+	resp, err = Call(path, Request{Command: CommandStatus, GameID: "missing"}, time.Second)
+	if err != nil || resp.OK || resp.Error != "game 'missing' not found" {
+		t.Fatalf("expected handler error, got %+v %v", resp, err)
+	}
+
+	resp, err = Call(path, Request{Command: "selfdestruct"}, time.Second)
+	if err != nil || resp.OK || resp.Error != "unknown control command 'selfdestruct'" {
+		t.Fatalf("expected unknown command error, got %+v %v", resp, err)
+	}
+}
+
+func TestWatchStreamsStatusUntilCancelled(t *testing.T) {
+	_, path, _ := startTestServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	received := 0
+	err := Watch(ctx, path, Request{GameID: "factory", IntervalMs: 1}, func(resp Response) error {
+		var result map[string]interface{}
+		if err := json.Unmarshal(resp.Result, &result); err != nil {
+			return err
+		}
+		received++
+		if received == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+	if received < 2 {
+		t.Fatalf("expected at least two status updates, got %d", received)
+	}
+}
+
+func TestDiscoverFindsLiveServersAndRemovesStaleSockets(t *testing.T) {
+	configDir, path, _ := startTestServer(t)
+
+	stale := filepath.Join(filepath.Dir(path), "1.sock")
+	if err := os.WriteFile(stale, nil, 0600); err != nil {
+		t.Fatalf("write stale socket: %v", err)
+	}
+
+	paths, err := Discover(configDir)
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	if len(paths) != 1 || paths[0] != path {
+		t.Fatalf("expected only %s, got %v", path, paths)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("expected stale socket to be removed, stat err: %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package epic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// App is a title installed through the Epic Games Launcher, read from its
+// .item manifest.
+type App struct {
+	AppName          string // The ID used as the EpicAppId target
+	DisplayName      string
+	InstallLocation  string
+	LaunchExecutable string // Relative to InstallLocation
+	ManifestPath     string
+}
+
+type itemManifest struct {
+	AppName             string   `json:"AppName"`
+	DisplayName         string   `json:"DisplayName"`
+	InstallLocation     string   `json:"InstallLocation"`
+	LaunchExecutable    string   `json:"LaunchExecutable"`
+	IsIncompleteInstall bool     `json:"bIsIncompleteInstall"`
+	MainGameAppName     string   `json:"MainGameAppName"`
+	AppCategories       []string `json:"AppCategories"`
+}
+
+// InstalledApps returns the completely installed Epic titles sorted by display
+// name. Add-ons and partial downloads are left out. It returns no apps and no
+// error when the launcher is not installed.
+func InstalledApps() ([]App, error) {
+	var apps []App
+	var readErrors []string
+	seen := make(map[string]bool)
+
+	for _, dir := range candidateManifestDirs() {
+		items, err := filepath.Glob(filepath.Join(dir, "*.item"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(items)
+		for _, item := range items {
+			app, ok, err := appFromManifest(item)
+			if err != nil {
+				readErrors = append(readErrors, err.Error())
+				continue
+			}
+			if !ok || seen[app.AppName] {
+				continue
+			}
+			seen[app.AppName] = true
+			apps = append(apps, app)
+		}
+	}
+
+	if len(apps) == 0 && len(readErrors) > 0 {
+		return nil, fmt.Errorf("failed to read Epic manifests: %s", strings.Join(readErrors, "; "))
+	}
+	sort.SliceStable(apps, func(i, j int) bool {
+		return strings.ToLower(apps[i].DisplayName) < strings.ToLower(apps[j].DisplayName)
+	})
+	return apps, nil
+}
+
+// FindApp returns the installed title whose AppName is appName.
+func FindApp(appName string) (App, error) {
+	appName = strings.TrimSpace(appName)
+	if appName == "" {
+		return App{}, errors.New("Epic app name is required")
+	}
+	apps, err := InstalledApps()
+	if err != nil {
+		return App{}, err
+	}
+	for _, app := range apps {
+		if strings.EqualFold(app.AppName, appName) {
+			return app, nil
+		}
+	}
+	return App{}, fmt.Errorf("Epic app %s was not found; checked manifests in: %s", appName, strings.Join(candidateManifestDirs(), ", "))
+}
+
+// StopProcessCandidates suggests stopProcessName values for the app, most
+// likely first. Unreal Engine titles start a small bootstrap executable that
+// hands over to a *-Shipping binary, so those binaries are listed before the
+// launch executable itself.
+func (a App) StopProcessCandidates() []string {
+	var candidates []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[strings.ToLower(name)] {
+			seen[strings.ToLower(name)] = true
+			candidates = append(candidates, name)
+		}
+	}
+
+	if a.InstallLocation != "" {
+		for _, pattern := range []string{
+			filepath.Join(a.InstallLocation, "*", "Binaries", "*", "*-Shipping.exe"),
+			filepath.Join(a.InstallLocation, "*", "Binaries", "*", "*-Shipping"),
+		} {
+			matches, _ := filepath.Glob(pattern)
+			sort.Strings(matches)
+			for _, match := range matches {
+				add(filepath.Base(match))
+			}
+		}
+	}
+	add(executableName(a.LaunchExecutable))
+	return candidates
+}
+
+func appFromManifest(path string) (App, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return App{}, false, fmt.Errorf("%s: %w", path, err)
+	}
+	var manifest itemManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return App{}, false, fmt.Errorf("%s: %w", path, err)
+	}
+	if manifest.AppName == "" || manifest.IsIncompleteInstall {
+		return App{}, false, nil
+	}
+	if manifest.MainGameAppName != "" && manifest.MainGameAppName != manifest.AppName {
+		return App{}, false, nil
+	}
+	for _, category := range manifest.AppCategories {
+		if category == "addons" {
+			return App{}, false, nil
+		}
+	}
+	name := manifest.DisplayName
+	if name == "" {
+		name = manifest.AppName
+	}
+	return App{
+		AppName:          manifest.AppName,
+		DisplayName:      name,
+		InstallLocation:  manifest.InstallLocation,
+		LaunchExecutable: manifest.LaunchExecutable,
+		ManifestPath:     path,
+	}, true, nil
+}
+
+// executableName returns the file name of a manifest LaunchExecutable, which
+// uses the separator of the platform the manifest was written on. For a macOS
+// bundle it is the binary inside Contents/MacOS.
+func executableName(launchExecutable string) string {
+	launchExecutable = strings.TrimSpace(launchExecutable)
+	if launchExecutable == "" {
+		return ""
+	}
+	name := launchExecutable[strings.LastIndexAny(launchExecutable, `/\`)+1:]
+	if strings.HasSuffix(name, ".app") {
+		return strings.TrimSuffix(name, ".app")
+	}
+	return name
+}
+
+func candidateManifestDirs() []string {
+	if override := os.Getenv("GABS_EPIC_MANIFESTS"); strings.TrimSpace(override) != "" {
+		parts := filepath.SplitList(override)
+		dirs := make([]string, 0, len(parts))
+		for _, part := range parts {
+			if strings.TrimSpace(part) != "" {
+				dirs = append(dirs, part)
+			}
+		}
+		return dirs
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		home, _ := os.UserHomeDir()
+		if home == "" {
+			return nil
+		}
+		return []string{
+			filepath.Join(home, "Library", "Application Support", "Epic", "EpicGamesLauncher", "Data", "Manifests"),
+		}
+	case "windows":
+		programData := os.Getenv("ProgramData")
+		if programData == "" {
+			programData = `C:\ProgramData`
+		}
+		return []string{
+			filepath.Join(programData, "Epic", "EpicGamesLauncher", "Data", "Manifests"),
+		}
+	default:
+		return nil
+	}
+}
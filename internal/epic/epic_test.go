@@ -0,0 +1,116 @@
+package epic
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeManifest(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+}
+
+func TestInstalledAppsReadsCompleteGameManifests(t *testing.T) {
+	manifests := t.TempDir()
+	t.Setenv("GABS_EPIC_MANIFESTS", manifests)
+
+	writeManifest(t, manifests, "A1.item", `{
+		"AppName": "Falcon",
+		"DisplayName": "Factory Sim",
+		"InstallLocation": "D:\\Epic\\FactorySim",
+		"LaunchExecutable": "FactorySim.exe",
+		"MainGameAppName": "Falcon"
+	}`)
+	writeManifest(t, manifests, "B2.item", `{
+		"AppName": "Heron",
+		"DisplayName": "Adventure Game",
+		"InstallLocation": "/Users/Shared/Epic Games/Adventure",
+		"LaunchExecutable": "Adventure.app/Contents/MacOS/Adventure"
+	}`)
+	writeManifest(t, manifests, "C3.item", `{
+		"AppName": "HeronSoundtrack",
+		"DisplayName": "Adventure Soundtrack",
+		"MainGameAppName": "Heron",
+		"AppCategories": ["addons"]
+	}`)
+	writeManifest(t, manifests, "D4.item", `{
+		"AppName": "Osprey",
+		"DisplayName": "Half Downloaded",
+		"bIsIncompleteInstall": true
+	}`)
+	writeManifest(t, manifests, "notes.txt", "not a manifest")
+
+	apps, err := InstalledApps()
+	if err != nil {
+		t.Fatalf("InstalledApps failed: %v", err)
+	}
+	var names []string
+	for _, app := range apps {
+		names = append(names, app.AppName)
+	}
+	if !reflect.DeepEqual(names, []string{"Heron", "Falcon"}) {
+		t.Fatalf("expected the two complete games sorted by display name, got %v", names)
+	}
+	if apps[1].InstallLocation != `D:\Epic\FactorySim` || apps[1].ManifestPath != filepath.Join(manifests, "A1.item") {
+		t.Fatalf("unexpected app: %#v", apps[1])
+	}
+
+	app, err := FindApp("falcon")
+	if err != nil || app.DisplayName != "Factory Sim" {
+		t.Fatalf("expected FindApp to match case-insensitively, got %#v, %v", app, err)
+	}
+	if _, err := FindApp("Osprey"); err == nil || !strings.Contains(err.Error(), manifests) {
+		t.Fatalf("expected not-found error naming the manifest dir, got %v", err)
+	}
+}
+
+func TestInstalledAppsWithoutLauncher(t *testing.T) {
+	t.Setenv("GABS_EPIC_MANIFESTS", filepath.Join(t.TempDir(), "missing"))
+
+	apps, err := InstalledApps()
+	if err != nil || len(apps) != 0 {
+		t.Fatalf("expected no apps and no error, got %v, %v", apps, err)
+	}
+}
+
+func TestInstalledAppsReportsUnreadableManifests(t *testing.T) {
+	manifests := t.TempDir()
+	t.Setenv("GABS_EPIC_MANIFESTS", manifests)
+	writeManifest(t, manifests, "broken.item", "{")
+
+	if _, err := InstalledApps(); err == nil || !strings.Contains(err.Error(), "broken.item") {
+		t.Fatalf("expected manifest read error, got %v", err)
+	}
+}
+
+func TestStopProcessCandidatesPreferShippingBinary(t *testing.T) {
+	install := t.TempDir()
+	binaries := filepath.Join(install, "Adventure", "Binaries", "Win64")
+	if err := os.MkdirAll(binaries, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(binaries, "Adventure-Win64-Shipping.exe"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := App{InstallLocation: install, LaunchExecutable: `Adventure.exe`}
+	if got := app.StopProcessCandidates(); !reflect.DeepEqual(got, []string{"Adventure-Win64-Shipping.exe", "Adventure.exe"}) {
+		t.Fatalf("unexpected candidates %v", got)
+	}
+
+	for launch, want := range map[string]string{
+		`Game\Binaries\Win64\Factory.exe`:     "Factory.exe",
+		"Adventure.app/Contents/MacOS/Runner": "Runner",
+		"Adventure.app":                       "Adventure",
+		"":                                    "",
+	} {
+		if got := executableName(launch); got != want {
+			t.Fatalf("executableName(%q) = %q, want %q", launch, got, want)
+		}
+	}
+}
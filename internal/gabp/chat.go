@@ -0,0 +1,51 @@
+package gabp
+
+import "time"
+
+// Chat is the GABP convention for bridges of multiplayer games that can post
+// to and report the game's chat: a chat/send method and a chat/message event.
+const (
+	ChatSendMethod     = "chat/send"
+	ChatMessageChannel = "chat/message"
+)
+
+// ChatSendParams are the parameters of chat/send. Without Channel the
+// message goes to the game's default chat; with Recipient it is whispered to
+// that player only.
+type ChatSendParams struct {
+	Message   string `json:"message"`
+	Channel   string `json:"channel,omitempty"`
+	Recipient string `json:"recipient,omitempty"`
+}
+
+// ChatMessage is the payload of a chat/message event.
+type ChatMessage struct {
+	Sender    string `json:"sender"`
+	Message   string `json:"message"`
+	Channel   string `json:"channel,omitempty"`
+	Recipient string `json:"recipient,omitempty"`
+}
+
+// SupportsChatSend reports whether the bridge can post chat messages.
+func SupportsChatSend(capabilities Capabilities) bool {
+	return hasCapabilityEntry(capabilities.Methods, ChatSendMethod)
+}
+
+// SupportsChatEvents reports whether the bridge reports chat messages that
+// GABS can subscribe to.
+func SupportsChatEvents(capabilities Capabilities) bool {
+	return SupportsEventSubscription(capabilities) && hasCapabilityEntry(capabilities.Events, ChatMessageChannel)
+}
+
+// SendChatWithTimeout posts a chat message through the bridge and returns
+// what the bridge answered.
+func (c *Client) SendChatWithTimeout(params ChatSendParams, timeout time.Duration) (interface{}, error) {
+	return c.sendRequestWithTimeout(ChatSendMethod, params, timeout)
+}
+
+// ParseChatMessage reads the payload of a chat/message event.
+func ParseChatMessage(payload interface{}) (ChatMessage, error) {
+	var message ChatMessage
+	err := mapToStruct(payload, &message)
+	return message, err
+}
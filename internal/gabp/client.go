@@ -10,6 +10,8 @@ import (
 	"math/rand"
 	"net"
 	goruntime "runtime"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,27 +23,59 @@ import (
 
 // Client speaks framed GABP messages over TCP.
 type Client struct {
-	conn           net.Conn
-	writer         *util.LSPFrameWriter
-	reader         *util.LSPFrameReader
-	token          string
-	agentId        string
-	capabilities   Capabilities
-	pendingReqs    map[string]chan *util.GABPMessage
-	mu             sync.RWMutex
-	log            util.Logger
-	eventHandlers  map[string][]EventHandler
-	sequences      map[string]int
-	connected      bool
-	disconnected   chan struct{}
-	disconnectErr  error
-	disconnectOnce sync.Once
-	onDisconnect   func(error)
+	conn            net.Conn
+	writer          *util.LSPFrameWriter
+	reader          *util.LSPFrameReader
+	token           string
+	agentId         string
+	capabilities    Capabilities
+	app             AppInfo
+	schemaVersion   string
+	profile         protocolProfile // How to talk to a bridge of schemaVersion
+	serverInfo      *ServerInfo
+	pendingReqs     map[string]chan *util.GABPMessage
+	mu              sync.RWMutex
+	log             util.Logger
+	eventHandlers   map[string][]EventHandler
+	eventHistory    map[string][]BufferedEvent
+	historySize     int
+	sequences       map[string]int
+	connected       bool
+	disconnected    chan struct{}
+	disconnectErr   error
+	disconnectOnce  sync.Once
+	onDisconnect    func(error)
+	clock           util.Clock
+	callDelay       func() time.Duration
+	requestSlots    chan struct{} // One per request in flight when the bridge limits them
+	bridgeTimeout   time.Duration // The bridge's requestTimeout limit
+	timeoutOverride time.Duration // SetRequestTimeout, which wins over bridgeTimeout
+	onDial          func(error)
+	maxMessageSize  int             // SetMaxMessageSize, 0 for util.DefaultMaxMessageSize
+	messageLimit    int             // Largest message of the current session, which the bridge may lower
+	recorder        *Recorder       // Set by SetRecorder to record the session
+	recordAs        string          // Game ID the recorded messages are filed under
+	ctx             context.Context // Cancelled when the client is closed or its owner goes away
+	cancel          context.CancelCauseFunc
+	queueMu         sync.Mutex             // Guards the event queues, apart from mu so reading never waits on handlers
+	eventQueues     map[string]*eventQueue // Events of each channel waiting for their handlers
+	eventQueueSize  int
+	coalesceEvents  func(channel string) bool
+	droppedEvents   map[string]int
+	onEventDropped  func(channel string)
 }
 
 // EventHandler is a function that handles events
 type EventHandler func(channel string, seq int, payload interface{})
 
+// BufferedEvent is a received event kept for replay to late subscribers.
+type BufferedEvent struct {
+	Channel    string
+	Seq        int
+	Payload    interface{}
+	ReceivedAt time.Time
+}
+
 var (
 	ErrClientNotConnected = errors.New("GABP client is not connected")
 	ErrClientClosed       = errors.New("GABP client connection closed")
@@ -49,6 +83,24 @@ var (
 
 const defaultRequestTimeout = 30 * time.Second
 
+// DefaultEventHistorySize is how many recent events the client keeps per
+// channel for replay.
+const DefaultEventHistorySize = 32
+
+// EventsSubscribeMethod is the GABP request that subscribes to event channels.
+const EventsSubscribeMethod = "events/subscribe"
+
+// ToolsListChangedChannel is the event channel on which a bridge announces
+// that it registered or removed tools, such as after a save was loaded.
+const ToolsListChangedChannel = "tools/list_changed"
+
+// sessionHello is the session/hello GABS sends: the schema's parameters and
+// the architecture GABS runs on, which bridges that do not know it ignore.
+type sessionHello struct {
+	SessionHelloParams
+	Arch string `json:"arch,omitempty"`
+}
+
 type Capabilities = gabpruntime.Capabilities
 type Limits = gabpruntime.Limits
 type SessionHelloParams = gabpruntime.SessionHelloParams
@@ -59,25 +111,100 @@ type ServerInfo = gabpruntime.ServerInfo
 
 // NewClient creates a new GABP client
 func NewClient(log util.Logger) *Client {
+	return NewClientWithContext(context.Background(), log)
+}
+
+// NewClientWithContext creates a GABP client that lives no longer than ctx.
+// Cancelling ctx aborts a Connect in progress, fails in-flight requests and
+// closes the connection, the same as Close.
+func NewClientWithContext(ctx context.Context, log util.Logger) *Client {
 	// Seed the global random number generator for backoff jitter
 	// Use current time with nanosecond precision to avoid identical seeds
 	rand.Seed(time.Now().UnixNano())
 
-	return &Client{
-		pendingReqs:   make(map[string]chan *util.GABPMessage),
-		eventHandlers: make(map[string][]EventHandler),
-		sequences:     make(map[string]int),
-		log:           log,
-		disconnected:  make(chan struct{}),
+	lifetime, cancel := context.WithCancelCause(ctx)
+	c := &Client{
+		pendingReqs:    make(map[string]chan *util.GABPMessage),
+		eventHandlers:  make(map[string][]EventHandler),
+		eventHistory:   make(map[string][]BufferedEvent),
+		historySize:    DefaultEventHistorySize,
+		sequences:      make(map[string]int),
+		eventQueues:    make(map[string]*eventQueue),
+		eventQueueSize: DefaultEventQueueSize,
+		droppedEvents:  make(map[string]int),
+		log:            log,
+		disconnected:   make(chan struct{}),
+		clock:          util.NewRealClock(),
+		profile:        negotiateProfile(""),
+		ctx:            lifetime,
+		cancel:         cancel,
+	}
+	context.AfterFunc(lifetime, func() {
+		c.markDisconnected(context.Cause(lifetime), false)
+	})
+	return c
+}
+
+// SetClock replaces the time source used for reconnect backoff. Call it
+// before Connect.
+func (c *Client) SetClock(clock util.Clock) {
+	if clock != nil {
+		c.clock = clock
+	}
+}
+
+// backoffDelay returns the wait before retry attempt+1: backoffMin * 2^attempt
+// capped at backoffMax, with jitter in [-1, 1] applied as up to ±25%. The
+// result always stays within [backoffMin, backoffMax].
+func backoffDelay(attempt int, backoffMin, backoffMax time.Duration, jitter float64) (time.Duration, time.Duration) {
+	multiplier := math.Pow(2, float64(attempt))
+	baseDelay := time.Duration(float64(backoffMin) * multiplier)
+	if baseDelay > backoffMax || baseDelay < 0 {
+		baseDelay = backoffMax
+	}
+
+	finalDelay := baseDelay + time.Duration(float64(baseDelay)*0.25*jitter)
+	if finalDelay < backoffMin {
+		finalDelay = backoffMin
+	}
+	if finalDelay > backoffMax {
+		finalDelay = backoffMax
+	}
+	return finalDelay, baseDelay
+}
+
+// unixAddressPrefix marks addresses of Unix domain sockets; see UnixAddress.
+const unixAddressPrefix = "unix:"
+
+// UnixAddress returns the address Connect and Accept use for the Unix domain
+// socket at path. Other addresses are TCP host:port pairs.
+func UnixAddress(path string) string {
+	return unixAddressPrefix + path
+}
+
+// splitAddress returns the network and address to dial for addr.
+func splitAddress(addr string) (string, string) {
+	if path, isUnix := strings.CutPrefix(addr, unixAddressPrefix); isUnix {
+		return "unix", path
 	}
+	return "tcp", addr
 }
 
 // Connect dials the GABP server and performs the handshake.
-// Retries with exponential backoff until ctx is cancelled.
+// Retries with exponential backoff until ctx is cancelled or the client is
+// closed.
 func (c *Client) Connect(ctx context.Context, addr string, token string, backoffMin, backoffMax time.Duration) error {
 	c.token = token
 
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stop := context.AfterFunc(c.ctx, func() {
+		cancel(context.Cause(c.ctx))
+	})
+	defer stop()
+
 	// Connect with retry/backoff
+	network, address := splitAddress(addr)
 	var conn net.Conn
 	var err error
 
@@ -87,103 +214,99 @@ func (c *Client) Connect(ctx context.Context, addr string, token string, backoff
 	// try to connect simultaneously.
 	for attempts := 0; ; attempts++ {
 		if ctx.Err() != nil {
-			return fmt.Errorf("connect cancelled: %w", ctx.Err())
+			return fmt.Errorf("connect cancelled: %w", context.Cause(ctx))
 		}
 
 		var d net.Dialer
-		conn, err = d.DialContext(ctx, "tcp", addr)
+		conn, err = d.DialContext(ctx, network, address)
+		c.mu.RLock()
+		onDial := c.onDial
+		c.mu.RUnlock()
+		if onDial != nil {
+			onDial(err)
+		}
 		if err == nil {
 			break
 		}
 		c.log.Debugw("connection attempt failed", "attempt", attempts+1, "error", err)
 
 		if ctx.Err() != nil {
-			return fmt.Errorf("connect cancelled after %d attempts: %w", attempts+1, ctx.Err())
+			return fmt.Errorf("connect cancelled after %d attempts: %w", attempts+1, context.Cause(ctx))
 		}
 
-		// Calculate exponential backoff: backoffMin * 2^attempts
-		multiplier := math.Pow(2, float64(attempts))
-		backoffDelay := time.Duration(float64(backoffMin) * multiplier)
-		// Cap at backoffMax
-		if backoffDelay > backoffMax {
-			backoffDelay = backoffMax
-		}
+		// Exponential backoff with ±25% jitter to prevent thundering herd
+		finalDelay, baseDelay := backoffDelay(attempts, backoffMin, backoffMax, rand.Float64()*2-1)
 
-		// Add jitter: ±25% randomization to prevent thundering herd
-		jitterRange := float64(backoffDelay) * 0.25
-		jitter := time.Duration(rand.Float64()*2*jitterRange - jitterRange)
-		finalDelay := backoffDelay + jitter
-		// Ensure we never go below backoffMin or above backoffMax
-		if finalDelay < backoffMin {
-			finalDelay = backoffMin
-		}
-		if finalDelay > backoffMax {
-			finalDelay = backoffMax
-		}
-
-		c.log.Debugw("backing off before retry", "attempt", attempts+1, "delay", finalDelay, "baseDelay", backoffDelay)
+		c.log.Debugw("backing off before retry", "attempt", attempts+1, "delay", finalDelay, "baseDelay", baseDelay)
 		select {
-		case <-time.After(finalDelay):
+		case <-c.clock.After(finalDelay):
 		case <-ctx.Done():
-			return fmt.Errorf("connect cancelled during backoff: %w", ctx.Err())
+			return fmt.Errorf("connect cancelled during backoff: %w", context.Cause(ctx))
 		}
 	}
 
+	return c.startSession(ctx, conn, util.NewLSPFrameReader(conn))
+}
+
+// startSession takes over conn, read through reader, and performs the
+// handshake on it.
+func (c *Client) startSession(ctx context.Context, conn net.Conn, reader *util.LSPFrameReader) error {
+	c.mu.Lock()
+	if c.ctx.Err() != nil {
+		// Closed while the dial was completing.
+		c.mu.Unlock()
+		_ = conn.Close()
+		return fmt.Errorf("connect cancelled: %w", context.Cause(c.ctx))
+	}
 	c.conn = conn
 	c.writer = util.NewLSPFrameWriter(conn)
-	c.reader = util.NewLSPFrameReader(conn)
+	c.reader = reader
 	c.connected = true
+	c.applyLimitsLocked(nil)
+	c.mu.Unlock()
 
 	// Start the reader loop before the handshake so the welcome response can
 	// be delivered to the pending request channel.
 	go c.messageHandler()
 
-	// Perform handshake in a way that observes ctx cancellation.
-	handshakeTimeout := timeoutFromContextOrDefault(ctx, defaultRequestTimeout)
-	handshakeErrCh := make(chan error, 1)
-	go func() {
-		handshakeErrCh <- c.handshakeWithTimeout(handshakeTimeout)
-	}()
-
-	select {
-	case <-ctx.Done():
-		// Context was cancelled while waiting for handshake; close the
-		// connection to abort any in-flight operations and return the error.
+	if err := c.handshakeContext(ctx, timeoutFromContextOrDefault(ctx, defaultRequestTimeout)); err != nil {
+		// Do not leave the client connected after a failed or cancelled
+		// handshake.
+		c.mu.Lock()
 		c.connected = false
+		c.mu.Unlock()
 		_ = conn.Close()
-		return ctx.Err()
-	case err := <-handshakeErrCh:
-		if err != nil {
-			// Handshake failed; ensure we do not leave the client in a
-			// connected state and that the connection is cleaned up.
-			c.connected = false
-			_ = conn.Close()
-			return err
+		if ctx.Err() != nil {
+			return fmt.Errorf("connect cancelled during handshake: %w", context.Cause(ctx))
 		}
+		return err
 	}
 
 	return nil
 }
 
 func (c *Client) handshake() error {
-	return c.handshakeWithTimeout(defaultRequestTimeout)
+	return c.handshakeContext(c.ctx, defaultRequestTimeout)
 }
 
-func (c *Client) handshakeWithTimeout(timeout time.Duration) error {
+func (c *Client) handshakeContext(ctx context.Context, timeout time.Duration) error {
 	// Send session/hello
 	launchId := uuid.New().String()
-	params := SessionHelloParams{
-		Token:         c.token,
-		BridgeVersion: version.Get(),  // Use actual runtime version
-		Platform:      goruntime.GOOS, // Detect actual platform
-		LaunchID:      launchId,
-		ClientInfo: &ClientInfo{
-			Name:    "gabs",
-			Version: version.Get(),
+	params := sessionHello{
+		SessionHelloParams: SessionHelloParams{
+			Token:         c.token,
+			BridgeVersion: version.Get(),
+			Platform:      goruntime.GOOS,
+			LaunchID:      launchId,
+			ClientInfo: &ClientInfo{
+				Name:    "gabs",
+				Version: version.Get(),
+			},
 		},
+		Arch: goruntime.GOARCH,
 	}
 
-	result, err := c.sendRequestWithTimeout(gabpruntime.MethodSessionHello, params, timeout)
+	result, err := c.sendRequestContext(ctx, gabpruntime.MethodSessionHello, params, timeout)
 	if err != nil {
 		return fmt.Errorf("handshake failed: %w", err)
 	}
@@ -194,10 +317,21 @@ func (c *Client) handshakeWithTimeout(timeout time.Duration) error {
 		return fmt.Errorf("failed to parse welcome: %w", err)
 	}
 
+	c.mu.Lock()
 	c.agentId = welcome.AgentID
 	c.capabilities = welcome.Capabilities
+	c.app = welcome.App
+	c.schemaVersion = welcome.SchemaVersion
+	c.serverInfo = welcome.ServerInfo
+	c.profile = negotiateProfile(welcome.SchemaVersion)
+	c.applyLimitsLocked(welcome.Capabilities.Limits)
+	profile := c.profile
+	c.mu.Unlock()
 
-	c.log.Infow("GABP handshake complete", "agentId", c.agentId, "methods", len(c.capabilities.Methods))
+	c.log.Infow("GABP handshake complete", "agentId", welcome.AgentID, "app", welcome.App.Name, "appVersion", welcome.App.Version, "schemaVersion", welcome.SchemaVersion, "compatibility", profile.name, "methods", len(welcome.Capabilities.Methods))
+	if profile.newerMajor {
+		c.log.Warnw("bridge speaks a newer GABP schema than GABS targets; some features may not work", "schemaVersion", welcome.SchemaVersion, "targetSchemaVersion", gabpruntime.TargetGabpSchemaVersion)
+	}
 	return nil
 }
 
@@ -221,10 +355,17 @@ func timeoutFromContextOrDefault(ctx context.Context, fallback time.Duration) ti
 
 func (c *Client) messageHandler() {
 	var loopErr error
-	defer c.markDisconnected(loopErr, true)
+	defer func() {
+		c.markDisconnected(loopErr, c.ctx.Err() == nil)
+	}()
 
 	for c.IsConnected() {
 		data, err := c.reader.ReadMessage()
+		var tooLarge *util.MessageTooLargeError
+		if errors.As(err, &tooLarge) {
+			c.rejectOversizedMessage(tooLarge)
+			continue
+		}
 		if err != nil {
 			if !errors.Is(err, net.ErrClosed) && !errors.Is(err, io.EOF) {
 				c.log.Errorw("failed to read message", "error", err)
@@ -241,6 +382,7 @@ func (c *Client) messageHandler() {
 			continue
 		}
 
+		c.recordMessage(DirectionReceived, &msg)
 		c.handleMessage(&msg)
 	}
 }
@@ -264,6 +406,7 @@ func (c *Client) handleResponse(msg *util.GABPMessage) {
 	if exists {
 		select {
 		case ch <- msg:
+		case <-c.ctx.Done():
 		case <-time.After(5 * time.Second):
 			c.log.Warnw("response channel timeout", "id", msg.ID)
 		}
@@ -273,26 +416,65 @@ func (c *Client) handleResponse(msg *util.GABPMessage) {
 }
 
 func (c *Client) handleEvent(msg *util.GABPMessage) {
-	c.mu.RLock()
+	c.mu.Lock()
 	handlers := c.eventHandlers[msg.Channel]
-	c.mu.RUnlock()
+	if c.historySize > 0 {
+		history := append(c.eventHistory[msg.Channel], BufferedEvent{
+			Channel:    msg.Channel,
+			Seq:        msg.Seq,
+			Payload:    msg.Payload,
+			ReceivedAt: c.clock.Now(),
+		})
+		if len(history) > c.historySize {
+			history = append([]BufferedEvent(nil), history[len(history)-c.historySize:]...)
+		}
+		c.eventHistory[msg.Channel] = history
+	}
+	c.mu.Unlock()
 
-	for _, handler := range handlers {
-		go handler(msg.Channel, msg.Seq, msg.Payload)
+	if len(handlers) > 0 {
+		c.queueEvent(queuedEvent{channel: msg.Channel, seq: msg.Seq, payload: msg.Payload, handlers: handlers})
 	}
 }
 
 func (c *Client) sendRequest(method string, params interface{}) (interface{}, error) {
-	return c.sendRequestWithTimeout(method, params, defaultRequestTimeout)
+	return c.sendRequestWithTimeout(method, params, 0)
 }
 
 func (c *Client) sendRequestWithTimeout(method string, params interface{}, timeout time.Duration) (interface{}, error) {
+	return c.sendRequestContext(c.ctx, method, params, timeout)
+}
+
+// sendRequestContext sends a request and waits for its response, the timeout,
+// a disconnect or the end of ctx, whichever comes first. A timeout of 0 means
+// RequestTimeout. When the bridge limits concurrent requests, the wait for a
+// free slot counts toward the timeout.
+func (c *Client) sendRequestContext(ctx context.Context, method string, params interface{}, timeout time.Duration) (interface{}, error) {
+	if timeout <= 0 {
+		timeout = c.RequestTimeout()
+	}
 	req := util.NewGABPRequest(method, params)
-	writer, disconnected, err := c.prepareRequest()
+	writer, disconnected, slots, err := c.prepareRequest()
 	if err != nil {
 		return nil, err
 	}
 
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+		case <-disconnected:
+			return nil, c.connectionUnavailableError()
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%s cancelled: %w", method, context.Cause(ctx))
+		case <-timer.C:
+			return nil, fmt.Errorf("%w: it handles %d requests at a time and none finished within %s; retry later or raise the timeout", ErrBridgeBusy, cap(slots), timeout)
+		}
+	}
+
 	// Register response channel
 	respCh := make(chan *util.GABPMessage, 1)
 	c.mu.Lock()
@@ -306,26 +488,36 @@ func (c *Client) sendRequestWithTimeout(method string, params interface{}, timeo
 		c.mu.Unlock()
 	}()
 
-	// Send request
+	// Send request, recorded first so its response cannot come before it
+	c.recordMessage(DirectionSent, req)
 	if err := writer.WriteJSON(req); err != nil {
+		var tooLarge *util.MessageTooLargeError
+		if errors.As(err, &tooLarge) {
+			// Nothing was sent, so the connection is still good.
+			return nil, fmt.Errorf("%s request not sent: %w", method, err)
+		}
 		c.markDisconnected(fmt.Errorf("failed to write request: %w", err), true)
 		return nil, c.connectionUnavailableError()
 	}
 
 	// Wait for response
-	timer := time.NewTimer(timeout)
-	defer timer.Stop()
-
 	select {
 	case resp := <-respCh:
 		if resp.Error != nil {
-			return nil, fmt.Errorf("GABP error %d: %s", resp.Error.Code, resp.Error.Message)
+			return nil, &RequestError{Code: resp.Error.Code, Message: resp.Error.Message, Data: resp.Error.Data}
 		}
 		return resp.Result, nil
 	case <-disconnected:
 		return nil, c.connectionUnavailableError()
+	case <-ctx.Done():
+		select {
+		case <-disconnected:
+			return nil, c.connectionUnavailableError()
+		default:
+		}
+		return nil, fmt.Errorf("%s cancelled: %w", method, context.Cause(ctx))
 	case <-timer.C:
-		return nil, fmt.Errorf("request timeout after %s", timeout)
+		return nil, fmt.Errorf("%w after %s", ErrRequestTimeout, timeout)
 	}
 }
 
@@ -431,7 +623,7 @@ func mapTypeToJSONSchema(typeName string) string {
 }
 
 func (c *Client) ListTools() ([]ToolDescriptor, error) {
-	return c.ListToolsWithTimeout(defaultRequestTimeout)
+	return c.ListToolsWithTimeout(0)
 }
 
 func (c *Client) ListToolsWithTimeout(timeout time.Duration) ([]ToolDescriptor, error) {
@@ -467,17 +659,37 @@ func (c *Client) ListToolsWithTimeout(timeout time.Duration) ([]ToolDescriptor,
 }
 
 func (c *Client) CallTool(name string, args map[string]any) (map[string]any, bool, error) {
-	return c.CallToolWithTimeout(name, args, defaultRequestTimeout)
+	return c.CallToolWithTimeout(name, args, 0)
 }
 
-// CallToolWithTimeout calls a tool with a custom timeout
+// CallToolWithTimeout calls a tool with a custom timeout, or with
+// RequestTimeout when timeout is 0.
 func (c *Client) CallToolWithTimeout(name string, args map[string]any, timeout time.Duration) (map[string]any, bool, error) {
-	params := map[string]interface{}{
-		"name":       name,
-		"parameters": args,
+	return c.CallToolContext(c.ctx, name, args, timeout)
+}
+
+// CallToolContext calls a tool like CallToolWithTimeout and gives up early
+// when ctx ends, such as when the MCP client cancels the request.
+func (c *Client) CallToolContext(ctx context.Context, name string, args map[string]any, timeout time.Duration) (map[string]any, bool, error) {
+	if timeout <= 0 {
+		timeout = c.RequestTimeout()
+	}
+	c.mu.RLock()
+	params := c.profile.toolsCallParams(name, args)
+	callDelay := c.callDelay
+	c.mu.RUnlock()
+	if callDelay != nil {
+		if delay := callDelay(); delay > 0 {
+			if delay >= timeout {
+				c.clock.Sleep(timeout)
+				return nil, true, fmt.Errorf("%w after %s", ErrRequestTimeout, timeout)
+			}
+			c.clock.Sleep(delay)
+			timeout -= delay
+		}
 	}
 
-	result, err := c.sendRequestWithTimeout(gabpruntime.MethodToolsCall, params, timeout)
+	result, err := c.sendRequestContext(ctx, gabpruntime.MethodToolsCall, params, timeout)
 	if err != nil {
 		return nil, true, err
 	}
@@ -491,9 +703,15 @@ func (c *Client) CallToolWithTimeout(name string, args map[string]any, timeout t
 	return resultMap, false, nil
 }
 
+// SupportsEventSubscription reports whether the bridge advertises
+// events/subscribe and at least one event channel.
+func SupportsEventSubscription(capabilities Capabilities) bool {
+	return hasCapabilityEntry(capabilities.Methods, EventsSubscribeMethod) && len(capabilities.Events) > 0
+}
+
 // SubscribeEvents subscribes to event channels
 func (c *Client) SubscribeEvents(channels []string, handler EventHandler) error {
-	return c.SubscribeEventsWithTimeout(channels, handler, defaultRequestTimeout)
+	return c.SubscribeEventsWithTimeout(channels, handler, 0)
 }
 
 // SubscribeEventsWithTimeout subscribes to event channels with an explicit request timeout.
@@ -509,10 +727,78 @@ func (c *Client) SubscribeEventsWithTimeout(channels []string, handler EventHand
 	params := map[string]interface{}{
 		"channels": channels,
 	}
-	_, err := c.sendRequestWithTimeout("events/subscribe", params, timeout)
+	_, err := c.sendRequestWithTimeout(EventsSubscribeMethod, params, timeout)
 	return err
 }
 
+// SubscribeEventsWithReplay subscribes like SubscribeEventsWithTimeout and
+// then hands the handler any buffered events on those channels that were
+// received after since, oldest first. Pass the zero time to replay the whole
+// buffer. Replayed and live events are delivered on separate goroutines, so
+// handlers that care about order should compare sequence numbers.
+func (c *Client) SubscribeEventsWithReplay(channels []string, handler EventHandler, since time.Time, timeout time.Duration) error {
+	c.mu.Lock()
+	var replay []BufferedEvent
+	for _, ch := range channels {
+		c.eventHandlers[ch] = append(c.eventHandlers[ch], handler)
+		replay = append(replay, c.recentEventsLocked(ch, since)...)
+	}
+	c.mu.Unlock()
+
+	params := map[string]interface{}{
+		"channels": channels,
+	}
+	if _, err := c.sendRequestWithTimeout(EventsSubscribeMethod, params, timeout); err != nil {
+		return err
+	}
+
+	sort.SliceStable(replay, func(i, j int) bool {
+		return replay[i].ReceivedAt.Before(replay[j].ReceivedAt)
+	})
+	if len(replay) > 0 {
+		go func() {
+			for _, event := range replay {
+				handler(event.Channel, event.Seq, event.Payload)
+			}
+		}()
+	}
+	return nil
+}
+
+// RecentEvents returns the buffered events for channel received after since,
+// oldest first.
+func (c *Client) RecentEvents(channel string, since time.Time) []BufferedEvent {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.recentEventsLocked(channel, since)
+}
+
+func (c *Client) recentEventsLocked(channel string, since time.Time) []BufferedEvent {
+	var events []BufferedEvent
+	for _, event := range c.eventHistory[channel] {
+		if event.ReceivedAt.After(since) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// setEventHistorySize sets how many events per channel are kept for replay.
+// Zero disables buffering. Existing buffers are trimmed to the new size.
+func (c *Client) setEventHistorySize(size int) {
+	if size < 0 {
+		size = 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.historySize = size
+	for channel, history := range c.eventHistory {
+		if len(history) > size {
+			c.eventHistory[channel] = append([]BufferedEvent(nil), history[len(history)-size:]...)
+		}
+	}
+}
+
 // GetCapabilities returns the server capabilities from the welcome response
 func (c *Client) GetCapabilities() Capabilities {
 	c.mu.RLock()
@@ -520,11 +806,40 @@ func (c *Client) GetCapabilities() Capabilities {
 	return c.capabilities
 }
 
+// SessionInfo describes the bridge as reported in its session/welcome.
+type SessionInfo struct {
+	AgentID       string
+	App           AppInfo
+	SchemaVersion string
+	Compatibility string // ProfileLegacy, ProfileSchema10 or ProfileCurrent
+	ServerInfo    *ServerInfo
+	Capabilities  Capabilities
+}
+
+// GetSessionInfo returns what the bridge reported during the handshake.
+func (c *Client) GetSessionInfo() SessionInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	info := SessionInfo{
+		AgentID:       c.agentId,
+		App:           c.app,
+		SchemaVersion: c.schemaVersion,
+		Compatibility: c.profile.name,
+		Capabilities:  c.capabilities,
+	}
+	if c.serverInfo != nil {
+		serverInfo := *c.serverInfo
+		info.ServerInfo = &serverInfo
+	}
+	return info
+}
+
 // IsConnected reports whether the underlying GABP transport is still active.
 func (c *Client) IsConnected() bool {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return c.connected
+	return c.connected && c.ctx.Err() == nil
 }
 
 // DisconnectError returns the reason the GABP transport last disconnected.
@@ -541,6 +856,44 @@ func (c *Client) SetDisconnectHandler(handler func(error)) {
 	c.onDisconnect = handler
 }
 
+// SetCallDelay installs a hook that returns extra latency to wait before each
+// tool call is sent. The developer chaos mode uses it to simulate slow bridges.
+func (c *Client) SetCallDelay(delay func() time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.callDelay = delay
+}
+
+// SetDialObserver installs a hook that is called after every dial attempt of
+// Connect with the dial error, or nil once the dial succeeded.
+func (c *Client) SetDialObserver(observer func(error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onDial = observer
+}
+
+// SetRecorder records every message of the session to recorder, filed under
+// gameID. Call it before Connect.
+func (c *Client) SetRecorder(recorder *Recorder, gameID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recorder = recorder
+	c.recordAs = gameID
+}
+
+func (c *Client) recordMessage(direction string, msg *util.GABPMessage) {
+	c.mu.RLock()
+	recorder, gameID := c.recorder, c.recordAs
+	c.mu.RUnlock()
+	recorder.record(gameID, direction, msg)
+}
+
+// SimulateDisconnect drops the transport as if the bridge had gone away,
+// including the disconnect handler callback.
+func (c *Client) SimulateDisconnect(err error) {
+	c.markDisconnected(err, true)
+}
+
 // Close gracefully closes the GABP connection
 func (c *Client) Close() error {
 	return c.markDisconnected(nil, false)
@@ -555,15 +908,15 @@ func mapToStruct(src interface{}, dst interface{}) error {
 	return json.Unmarshal(data, dst)
 }
 
-func (c *Client) prepareRequest() (*util.LSPFrameWriter, <-chan struct{}, error) {
+func (c *Client) prepareRequest() (*util.LSPFrameWriter, <-chan struct{}, chan struct{}, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	if !c.connected || c.writer == nil {
-		return nil, nil, c.connectionUnavailableErrorLocked()
+		return nil, nil, nil, c.connectionUnavailableErrorLocked()
 	}
 
-	return c.writer, c.disconnected, nil
+	return c.writer, c.disconnected, c.requestSlots, nil
 }
 
 func (c *Client) connectionUnavailableError() error {
@@ -594,11 +947,11 @@ func (c *Client) markDisconnected(err error, notify bool) error {
 		callback = c.onDisconnect
 		conn := c.conn
 		c.mu.Unlock()
-
 		if conn != nil {
 			closeErr = conn.Close()
 		}
 		close(c.disconnected)
+		c.cancel(disconnectErr)
 	})
 
 	if notify && callback != nil {
@@ -3,15 +3,21 @@ package gabp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"net"
+	"os"
+	"path/filepath"
 	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/pardeike/gabs/internal/util"
+	"github.com/pardeike/gabs/internal/version"
 )
 
 // Test exponential backoff behavior by connecting to a non-existent server
@@ -263,6 +269,11 @@ func TestConnectCompletesHandshakeWhenServerResponds(t *testing.T) {
 			serverDone <- fmt.Errorf("unexpected method: %s", request.Method)
 			return
 		}
+		hello, _ := request.Params.(map[string]interface{})
+		if hello["bridgeVersion"] != version.Get() || hello["platform"] != runtime.GOOS || hello["arch"] != runtime.GOARCH {
+			serverDone <- fmt.Errorf("hello does not report the GABS build: %v", hello)
+			return
+		}
 
 		response := util.NewGABPResponse(request.ID, SessionWelcomeResult{
 			AgentID: "adventure",
@@ -385,3 +396,1014 @@ func TestCallToolFailsFastWhenConnectionDrops(t *testing.T) {
 		t.Fatalf("server goroutine failed: %v", err)
 	}
 }
+
+func TestBackoffDelayBounds(t *testing.T) {
+	backoffMin := 10 * time.Millisecond
+	backoffMax := 100 * time.Millisecond
+
+	tests := []struct {
+		attempt int
+		jitter  float64
+		want    time.Duration
+	}{
+		{attempt: 0, jitter: 0, want: 10 * time.Millisecond},
+		{attempt: 0, jitter: -1, want: 10 * time.Millisecond},
+		{attempt: 2, jitter: 0, want: 40 * time.Millisecond},
+		{attempt: 2, jitter: 1, want: 50 * time.Millisecond},
+		{attempt: 2, jitter: -1, want: 30 * time.Millisecond},
+		{attempt: 10, jitter: 1, want: 100 * time.Millisecond},
+		{attempt: 100, jitter: 0, want: 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		got, _ := backoffDelay(tt.attempt, backoffMin, backoffMax, tt.jitter)
+		if got != tt.want {
+			t.Errorf("backoffDelay(%d, jitter %.1f) = %v, want %v", tt.attempt, tt.jitter, got, tt.want)
+		}
+	}
+}
+
+func TestConnectBackoffWaitsOnClock(t *testing.T) {
+	client := NewClient(util.NewLogger("error"))
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	client.SetClock(clock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		// Port 0 is never dialable, so every attempt fails immediately.
+		done <- client.Connect(ctx, "127.0.0.1:0", "test-token", time.Hour, 4*time.Hour)
+	}()
+
+	for i := 0; i < 3; i++ {
+		clock.BlockUntil(1)
+		select {
+		case err := <-done:
+			t.Fatalf("Connect returned before the fake backoff elapsed: %v", err)
+		default:
+		}
+		clock.Advance(4 * time.Hour)
+	}
+
+	clock.BlockUntil(1)
+	cancel()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "cancelled") {
+			t.Fatalf("expected cancellation error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Connect did not stop after cancellation")
+	}
+}
+
+func TestCloseStopsConnectRetries(t *testing.T) {
+	client := NewClient(util.NewLogger("error"))
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	client.SetClock(clock)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.Connect(context.Background(), "127.0.0.1:0", "test-token", time.Hour, 4*time.Hour)
+	}()
+
+	clock.BlockUntil(1)
+	client.Close()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "cancelled") || !errors.Is(err, ErrClientClosed) {
+			t.Fatalf("expected Connect to be cancelled by Close, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Connect kept retrying after the client was closed")
+	}
+}
+
+func TestCancellingClientContextAbortsInFlightRequest(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	client := NewClientWithContext(ctx, util.NewLogger("error"))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	called := make(chan struct{})
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := util.NewLSPFrameReader(conn)
+		writer := util.NewLSPFrameWriter(conn)
+		data, err := reader.ReadMessage()
+		if err != nil {
+			return
+		}
+		var hello util.GABPMessage
+		if json.Unmarshal(data, &hello) != nil {
+			return
+		}
+		if writer.WriteJSON(util.NewGABPResponse(hello.ID, SessionWelcomeResult{AgentID: "adventure", SchemaVersion: "1.0"})) != nil {
+			return
+		}
+		// Read the tool call and never answer it.
+		if _, err := reader.ReadMessage(); err == nil {
+			close(called)
+		}
+		reader.ReadMessage()
+	}()
+
+	if err := client.Connect(context.Background(), listener.Addr().String(), "test-token", 10*time.Millisecond, 50*time.Millisecond); err != nil {
+		t.Fatalf("expected handshake to succeed, got: %v", err)
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		_, _, err := client.CallToolWithTimeout("corebridge/core/ping", map[string]any{}, 30*time.Second)
+		result <- err
+	}()
+
+	<-called
+	stopped := errors.New("game stopped")
+	cancel(stopped)
+	select {
+	case err := <-result:
+		if !errors.Is(err, stopped) {
+			t.Fatalf("expected the call to fail with the cancellation cause, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("tool call kept waiting after the client context was cancelled")
+	}
+	if client.IsConnected() {
+		t.Fatal("expected the client to disconnect once its context was cancelled")
+	}
+}
+
+func TestSubscribeEventsWithReplayDeliversBufferedEvents(t *testing.T) {
+	client := NewClient(util.NewLogger("error"))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	serverDone := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverDone <- err
+			return
+		}
+		defer conn.Close()
+
+		reader := util.NewLSPFrameReader(conn)
+		writer := util.NewLSPFrameWriter(conn)
+
+		var hello util.GABPMessage
+		data, err := reader.ReadMessage()
+		if err == nil {
+			err = json.Unmarshal(data, &hello)
+		}
+		if err == nil {
+			err = writer.WriteJSON(util.NewGABPResponse(hello.ID, SessionWelcomeResult{
+				AgentID:       "adventure",
+				Capabilities:  Capabilities{Events: []string{"world/loaded"}},
+				SchemaVersion: "1.0",
+			}))
+		}
+		for seq := 1; err == nil && seq <= 2; seq++ {
+			err = writer.WriteJSON(util.NewGABPEvent("world/loaded", seq, map[string]any{"n": seq}))
+		}
+		if err != nil {
+			serverDone <- err
+			return
+		}
+
+		var subscribe util.GABPMessage
+		data, err = reader.ReadMessage()
+		if err == nil {
+			err = json.Unmarshal(data, &subscribe)
+		}
+		if err == nil && subscribe.Method != "events/subscribe" {
+			err = fmt.Errorf("unexpected method: %s", subscribe.Method)
+		}
+		if err == nil {
+			err = writer.WriteJSON(util.NewGABPResponse(subscribe.ID, map[string]any{"channels": []string{"world/loaded"}}))
+		}
+		if err == nil {
+			err = writer.WriteJSON(util.NewGABPEvent("world/loaded", 3, map[string]any{"n": 3}))
+		}
+		if err == nil {
+			_, err = reader.ReadMessage()
+			if err == io.EOF {
+				err = nil
+			}
+		}
+		serverDone <- err
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx, listener.Addr().String(), "test-token", 10*time.Millisecond, 50*time.Millisecond); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(client.RecentEvents("world/loaded", time.Time{})) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("events sent before subscribing were not buffered")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	received := make(chan int, 3)
+	if err := client.SubscribeEventsWithReplay([]string{"world/loaded"}, func(channel string, seq int, payload interface{}) {
+		received <- seq
+	}, time.Time{}, time.Second); err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+
+	seen := map[int]bool{}
+	for len(seen) < 3 {
+		select {
+		case seq := <-received:
+			seen[seq] = true
+		case <-time.After(2 * time.Second):
+			t.Fatalf("expected replayed and live events, got %v", seen)
+		}
+	}
+
+	client.Close()
+	if err := <-serverDone; err != nil {
+		t.Fatalf("server goroutine failed: %v", err)
+	}
+}
+
+func TestEventHistoryKeepsMostRecentEventsPerChannel(t *testing.T) {
+	client := NewClient(util.NewLogger("error"))
+	clock := util.NewFakeClock(time.Unix(1000, 0))
+	client.SetClock(clock)
+	client.setEventHistorySize(2)
+
+	for seq := 1; seq <= 3; seq++ {
+		clock.Advance(time.Second)
+		client.handleEvent(&util.GABPMessage{Type: "event", Channel: "world/loaded", Seq: seq})
+	}
+	client.handleEvent(&util.GABPMessage{Type: "event", Channel: "system/log", Seq: 1})
+
+	events := client.RecentEvents("world/loaded", time.Time{})
+	if len(events) != 2 || events[0].Seq != 2 || events[1].Seq != 3 {
+		t.Fatalf("unexpected buffered events: %+v", events)
+	}
+	if recent := client.RecentEvents("world/loaded", time.Unix(1002, 0)); len(recent) != 1 || recent[0].Seq != 3 {
+		t.Fatalf("unexpected events since cutoff: %+v", recent)
+	}
+
+	client.setEventHistorySize(0)
+	if events := client.RecentEvents("world/loaded", time.Time{}); len(events) != 0 {
+		t.Fatalf("expected buffer to be cleared, got %+v", events)
+	}
+}
+
+func TestSimulateDisconnectNotifiesHandler(t *testing.T) {
+	client := NewClient(util.NewLogger("error"))
+	injected := fmt.Errorf("injected drop")
+	notified := make(chan error, 1)
+	client.SetDisconnectHandler(func(err error) {
+		notified <- err
+	})
+
+	client.SimulateDisconnect(injected)
+
+	select {
+	case err := <-notified:
+		if err != injected {
+			t.Fatalf("unexpected disconnect error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("disconnect handler was not called")
+	}
+	if client.IsConnected() || client.DisconnectError() != injected {
+		t.Fatalf("client should report the injected disconnect, got connected=%v err=%v", client.IsConnected(), client.DisconnectError())
+	}
+}
+
+func TestCallDelayCountsAgainstTimeout(t *testing.T) {
+	client := NewClient(util.NewLogger("error"))
+	client.SetCallDelay(func() time.Duration { return time.Hour })
+
+	start := time.Now()
+	_, isError, err := client.CallToolWithTimeout("corebridge/core/ping", map[string]any{}, 20*time.Millisecond)
+	if err == nil || !isError || !strings.Contains(err.Error(), "request timeout after 20ms") {
+		t.Fatalf("expected delayed call to time out, got isError=%v err=%v", isError, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("delay should be capped by the timeout, took %v", elapsed)
+	}
+}
+
+// dialOutBridge connects to GABS like a bridge in listen mode: it dials until
+// GABS listens, says hello with token and returns the connection and GABS'
+// answer.
+func dialOutBridge(addr, token string) (net.Conn, *util.LSPFrameReader, *util.GABPMessage, error) {
+	var conn net.Conn
+	var err error
+	for attempt := 0; attempt < 50; attempt++ {
+		if conn, err = net.Dial("tcp", addr); err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	reader := util.NewLSPFrameReader(conn)
+	writer := util.NewLSPFrameWriter(conn)
+	if err := writer.WriteJSON(util.NewGABPRequest("session/hello", SessionHelloParams{Token: token, Platform: "linux", LaunchID: "bridge"})); err != nil {
+		conn.Close()
+		return nil, nil, nil, err
+	}
+	data, err := reader.ReadMessage()
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, err
+	}
+	var answer util.GABPMessage
+	if err := json.Unmarshal(data, &answer); err != nil {
+		conn.Close()
+		return nil, nil, nil, err
+	}
+	return conn, reader, &answer, nil
+}
+
+func TestAcceptAdmitsOnlyBridgeWithToken(t *testing.T) {
+	client := NewClient(util.NewLogger("error"))
+	defer client.Close()
+
+	probe, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	addr := probe.Addr().String()
+	probe.Close()
+
+	bridgeDone := make(chan error, 1)
+	go func() {
+		intruder, _, answer, err := dialOutBridge(addr, "wrong-token")
+		if err != nil {
+			bridgeDone <- err
+			return
+		}
+		intruder.Close()
+		if answer.Error == nil {
+			bridgeDone <- fmt.Errorf("expected the wrong token to be rejected, got %#v", answer)
+			return
+		}
+
+		conn, reader, answer, err := dialOutBridge(addr, "test-token")
+		if err != nil {
+			bridgeDone <- err
+			return
+		}
+		defer conn.Close()
+		if answer.Error != nil {
+			bridgeDone <- fmt.Errorf("expected the bridge to be admitted, got %v", answer.Error.Message)
+			return
+		}
+
+		// GABS now runs the usual handshake on the connection
+		data, err := reader.ReadMessage()
+		if err != nil {
+			bridgeDone <- err
+			return
+		}
+		var hello util.GABPMessage
+		if err := json.Unmarshal(data, &hello); err != nil {
+			bridgeDone <- err
+			return
+		}
+		if hello.Method != "session/hello" {
+			bridgeDone <- fmt.Errorf("expected session/hello from GABS, got %q", hello.Method)
+			return
+		}
+		bridgeDone <- util.NewLSPFrameWriter(conn).WriteJSON(util.NewGABPResponse(hello.ID, SessionWelcomeResult{
+			AgentID:       "factory",
+			Capabilities:  Capabilities{Methods: []string{"tools/list"}},
+			SchemaVersion: "1.0",
+		}))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Accept(ctx, addr, "test-token"); err != nil {
+		t.Fatalf("expected the bridge to connect in, got: %v", err)
+	}
+	if err := <-bridgeDone; err != nil {
+		t.Fatalf("bridge failed: %v", err)
+	}
+	if info := client.GetSessionInfo(); info.AgentID != "factory" {
+		t.Errorf("expected the bridge's welcome after accept, got agent %q", info.AgentID)
+	}
+}
+
+func TestAcceptStopsWhenCancelled(t *testing.T) {
+	client := NewClient(util.NewLogger("error"))
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := client.Accept(ctx, "127.0.0.1:0", "test-token"); err == nil || !strings.Contains(err.Error(), "accept cancelled") {
+		t.Fatalf("expected accept to stop with the context, got: %v", err)
+	}
+}
+
+func TestConnectAndAcceptOverUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "bridge.sock")
+
+	// GABS listens, the bridge connects in over the socket
+	client := NewClient(util.NewLogger("error"))
+	defer client.Close()
+	bridgeDone := make(chan error, 1)
+	go func() {
+		var conn net.Conn
+		var err error
+		for attempt := 0; attempt < 50; attempt++ {
+			if conn, err = net.Dial("unix", socket); err == nil {
+				break
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		if err != nil {
+			bridgeDone <- err
+			return
+		}
+		defer conn.Close()
+		if runtime.GOOS != "windows" {
+			if info, err := os.Stat(socket); err != nil || info.Mode().Perm() != 0600 {
+				bridgeDone <- fmt.Errorf("expected a socket only the user can use, got %v, %v", info, err)
+				return
+			}
+		}
+
+		reader := util.NewLSPFrameReader(conn)
+		writer := util.NewLSPFrameWriter(conn)
+		if err := writer.WriteJSON(util.NewGABPRequest("session/hello", SessionHelloParams{Token: "test-token"})); err != nil {
+			bridgeDone <- err
+			return
+		}
+		for i := 0; i < 2; i++ {
+			data, err := reader.ReadMessage()
+			if err != nil {
+				bridgeDone <- err
+				return
+			}
+			var msg util.GABPMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				bridgeDone <- err
+				return
+			}
+			if msg.Method == "session/hello" {
+				bridgeDone <- writer.WriteJSON(util.NewGABPResponse(msg.ID, SessionWelcomeResult{AgentID: "factory", SchemaVersion: "1.0"}))
+				return
+			}
+		}
+		bridgeDone <- errors.New("GABS did not say hello")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Accept(ctx, UnixAddress(socket), "test-token"); err != nil {
+		t.Fatalf("expected the bridge to connect over the socket, got: %v", err)
+	}
+	if err := <-bridgeDone; err != nil {
+		t.Fatalf("bridge failed: %v", err)
+	}
+	if _, err := os.Stat(socket); !os.IsNotExist(err) {
+		t.Errorf("expected the socket to be removed once the bridge connected, got %v", err)
+	}
+
+	// The bridge listens, GABS connects over the socket
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("failed to listen on socket: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		data, err := util.NewLSPFrameReader(conn).ReadMessage()
+		if err != nil {
+			return
+		}
+		var hello util.GABPMessage
+		if json.Unmarshal(data, &hello) == nil {
+			_ = util.NewLSPFrameWriter(conn).WriteJSON(util.NewGABPResponse(hello.ID, SessionWelcomeResult{AgentID: "adventure", SchemaVersion: "1.0"}))
+		}
+		time.Sleep(200 * time.Millisecond)
+	}()
+
+	dialer := NewClient(util.NewLogger("error"))
+	defer dialer.Close()
+	if err := dialer.Connect(ctx, UnixAddress(socket), "test-token", 10*time.Millisecond, 50*time.Millisecond); err != nil {
+		t.Fatalf("expected to connect over the socket, got: %v", err)
+	}
+	if info := dialer.GetSessionInfo(); info.AgentID != "adventure" {
+		t.Errorf("expected the bridge's welcome, got agent %q", info.AgentID)
+	}
+}
+
+func TestReauthenticateSwitchesTokenWithoutReconnecting(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	// The bridge accepts one rotation and refuses any request that does not
+	// carry the token it currently expects
+	serverDone := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverDone <- err
+			return
+		}
+		defer conn.Close()
+		reader := util.NewLSPFrameReader(conn)
+		writer := util.NewLSPFrameWriter(conn)
+		expected := "first-token"
+		for {
+			data, err := reader.ReadMessage()
+			if err != nil {
+				serverDone <- nil
+				return
+			}
+			var request util.GABPMessage
+			if err := json.Unmarshal(data, &request); err != nil {
+				serverDone <- err
+				return
+			}
+			params, _ := request.Params.(map[string]interface{})
+			if token, _ := params["token"].(string); token != expected {
+				err = writer.WriteJSON(util.NewGABPError(request.ID, -32001, "invalid token", nil))
+			} else if request.Method == "session/hello" {
+				err = writer.WriteJSON(util.NewGABPResponse(request.ID, SessionWelcomeResult{
+					AgentID:      "adventure",
+					Capabilities: Capabilities{Methods: []string{SessionReauthMethod}},
+				}))
+			} else if newToken, _ := params["newToken"].(string); expected == "first-token" && newToken != "" {
+				expected = newToken
+				err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{}))
+			} else {
+				err = writer.WriteJSON(util.NewGABPError(request.ID, -32601, "no more rotations", nil))
+			}
+			if err != nil {
+				serverDone <- err
+				return
+			}
+		}
+	}()
+
+	client := NewClient(util.NewLogger("error"))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx, listener.Addr().String(), "first-token", 10*time.Millisecond, 50*time.Millisecond); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	if !SupportsReauth(client.GetCapabilities()) {
+		t.Fatal("expected the bridge to advertise session/reauth")
+	}
+
+	if err := client.Reauthenticate("second-token", time.Second); err != nil {
+		t.Fatalf("expected re-authentication to succeed: %v", err)
+	}
+	// The second rotation must present the rotated token; the bridge refuses
+	// it, but the session stays up
+	if err := client.Reauthenticate("third-token", time.Second); err == nil || !strings.Contains(err.Error(), "no more rotations") {
+		t.Fatalf("expected the bridge to refuse the second rotation, got %v", err)
+	}
+	if !client.IsConnected() {
+		t.Fatal("a refused re-authentication must not drop the connection")
+	}
+
+	client.Close()
+	if err := <-serverDone; err != nil {
+		t.Fatalf("bridge failed: %v", err)
+	}
+}
+
+func TestListResourcesAsksBridgeThatAdvertisesResourcesList(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	serverDone := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverDone <- err
+			return
+		}
+		defer conn.Close()
+		reader := util.NewLSPFrameReader(conn)
+		writer := util.NewLSPFrameWriter(conn)
+		for {
+			data, err := reader.ReadMessage()
+			if err != nil {
+				serverDone <- nil
+				return
+			}
+			var request util.GABPMessage
+			if err := json.Unmarshal(data, &request); err != nil {
+				serverDone <- err
+				return
+			}
+			switch request.Method {
+			case "session/hello":
+				err = writer.WriteJSON(util.NewGABPResponse(request.ID, SessionWelcomeResult{
+					AgentID:      "adventure",
+					Capabilities: Capabilities{Methods: []string{ResourcesListMethod}, Resources: []string{"world/save_data"}},
+				}))
+			case ResourcesListMethod:
+				err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{
+					"resources": []map[string]interface{}{{"uri": "world/save_data", "name": "Save data", "mimeType": "application/json"}},
+				}))
+			default:
+				err = writer.WriteJSON(util.NewGABPError(request.ID, -32601, "method not found", nil))
+			}
+			if err != nil {
+				serverDone <- err
+				return
+			}
+		}
+	}()
+
+	client := NewClient(util.NewLogger("error"))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx, listener.Addr().String(), "token", 10*time.Millisecond, 50*time.Millisecond); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	if !SupportsResources(client.GetCapabilities()) {
+		t.Fatal("expected declared resources to count as resource support")
+	}
+	resources, err := client.ListResources(time.Second)
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	if len(resources) != 1 || resources[0].Name != "Save data" || resources[0].MimeType != "application/json" {
+		t.Fatalf("expected the bridge's own descriptor, got %#v", resources)
+	}
+
+	client.Close()
+	if err := <-serverDone; err != nil {
+		t.Fatalf("bridge failed: %v", err)
+	}
+}
+
+func TestNegotiateProfileAdaptsToolsCallToSchemaVersion(t *testing.T) {
+	cases := []struct {
+		schemaVersion string
+		profile       string
+		keys          []string
+		newerMajor    bool
+	}{
+		{"", ProfileLegacy, []string{"parameters"}, false},
+		{"0.9", ProfileLegacy, []string{"parameters"}, false},
+		{"1.0", ProfileSchema10, []string{"arguments", "parameters"}, false},
+		{"1.2.1", ProfileCurrent, []string{"arguments"}, false},
+		{"2.0", ProfileCurrent, []string{"arguments"}, true},
+	}
+	for _, tc := range cases {
+		profile := negotiateProfile(tc.schemaVersion)
+		if profile.name != tc.profile || profile.newerMajor != tc.newerMajor {
+			t.Errorf("schema %q: got profile %s (newer major %v), want %s (%v)", tc.schemaVersion, profile.name, profile.newerMajor, tc.profile, tc.newerMajor)
+		}
+		params := profile.toolsCallParams("inventory/get", map[string]any{"playerId": "p1"})
+		if len(params) != len(tc.keys)+1 || params["name"] != "inventory/get" {
+			t.Errorf("schema %q: unexpected tools/call params %#v", tc.schemaVersion, params)
+		}
+		for _, key := range tc.keys {
+			if args, _ := params[key].(map[string]any); args["playerId"] != "p1" {
+				t.Errorf("schema %q: expected the arguments under %q, got %#v", tc.schemaVersion, key, params)
+			}
+		}
+	}
+}
+
+func TestBridgeLimitsBoundConcurrentRequestsAndTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	release := make(chan struct{})
+	calls := make(chan string, 4)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := util.NewLSPFrameReader(conn)
+		writer := util.NewLSPFrameWriter(conn)
+		for {
+			data, err := reader.ReadMessage()
+			if err != nil {
+				return
+			}
+			var request util.GABPMessage
+			if err := json.Unmarshal(data, &request); err != nil {
+				return
+			}
+			if request.Method == "session/hello" {
+				one, five := 1, 5
+				_ = writer.WriteJSON(util.NewGABPResponse(request.ID, SessionWelcomeResult{
+					AgentID: "adventure",
+					Capabilities: Capabilities{
+						Methods: []string{"tools/call"},
+						Limits:  &Limits{MaxConcurrentRequests: &one, RequestTimeout: &five},
+					},
+					SchemaVersion: "1.1",
+				}))
+				continue
+			}
+			params, _ := request.Params.(map[string]interface{})
+			name, _ := params["name"].(string)
+			calls <- name
+			// Answer the slow call only once the test lets it finish
+			go func(id string) {
+				<-release
+				_ = writer.WriteJSON(util.NewGABPResponse(id, map[string]interface{}{"ok": true}))
+			}(request.ID)
+		}
+	}()
+
+	client := NewClient(util.NewLogger("error"))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx, listener.Addr().String(), "token", 10*time.Millisecond, 50*time.Millisecond); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	if client.MaxConcurrentRequests() != 1 || client.RequestTimeout() != 5*time.Second {
+		t.Fatalf("expected the welcome limits, got %d requests and %s", client.MaxConcurrentRequests(), client.RequestTimeout())
+	}
+	client.SetRequestTimeout(2 * time.Second)
+	if client.RequestTimeout() != 2*time.Second {
+		t.Fatalf("expected the override to win, got %s", client.RequestTimeout())
+	}
+
+	slowDone := make(chan error, 1)
+	go func() {
+		_, _, err := client.CallTool("world/slow", nil)
+		slowDone <- err
+	}()
+	if name := <-calls; name != "world/slow" {
+		t.Fatalf("expected the slow call first, got %q", name)
+	}
+
+	_, _, err = client.CallToolWithTimeout("world/fast", nil, 100*time.Millisecond)
+	if !errors.Is(err, ErrBridgeBusy) || !strings.Contains(err.Error(), "1 requests at a time") {
+		t.Fatalf("expected the queued call to fail as busy, got %v", err)
+	}
+	select {
+	case name := <-calls:
+		t.Fatalf("the bridge received %q beyond its limit", name)
+	default:
+	}
+
+	close(release)
+	if err := <-slowDone; err != nil {
+		t.Fatalf("slow call failed: %v", err)
+	}
+	if _, _, err := client.CallToolWithTimeout("world/fast", nil, time.Second); err != nil {
+		t.Fatalf("expected a free slot after the slow call finished, got %v", err)
+	}
+}
+
+func TestBridgeMessageSizeLimitRejectsOversizedMessages(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := util.NewLSPFrameReader(conn)
+		writer := util.NewLSPFrameWriter(conn)
+		for {
+			data, err := reader.ReadMessage()
+			if err != nil {
+				return
+			}
+			var request util.GABPMessage
+			if err := json.Unmarshal(data, &request); err != nil {
+				return
+			}
+			if request.Method == "session/hello" {
+				limit := 4096
+				_ = writer.WriteJSON(util.NewGABPResponse(request.ID, SessionWelcomeResult{
+					AgentID:       "adventure",
+					Capabilities:  Capabilities{Methods: []string{"tools/call"}, Limits: &Limits{MaxMessageSize: &limit}},
+					SchemaVersion: "1.1",
+				}))
+				continue
+			}
+			params, _ := request.Params.(map[string]interface{})
+			if name, _ := params["name"].(string); name == "world/dump" {
+				_ = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"map": strings.Repeat("#", 5000)}))
+				continue
+			}
+			_ = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"ok": true}))
+		}
+	}()
+
+	client := NewClient(util.NewLogger("error"))
+	client.SetMaxMessageSize(1 << 20)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx, listener.Addr().String(), "token", 10*time.Millisecond, 50*time.Millisecond); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Close()
+
+	if client.MaxMessageSize() != 4096 {
+		t.Fatalf("expected the bridge's lower limit, got %d", client.MaxMessageSize())
+	}
+	if _, _, err := client.CallToolWithTimeout("world/dump", nil, 5*time.Second); err == nil || !strings.Contains(err.Error(), "exceeds the 4096 byte limit") {
+		t.Fatalf("expected the oversized response to fail the call, got %v", err)
+	}
+	var tooLarge *util.MessageTooLargeError
+	if _, _, err := client.CallToolWithTimeout("world/echo", map[string]interface{}{"text": strings.Repeat("a", 5000)}, time.Second); !errors.As(err, &tooLarge) {
+		t.Fatalf("expected the oversized request to be refused, got %v", err)
+	}
+	if _, _, err := client.CallToolWithTimeout("world/echo", nil, time.Second); err != nil {
+		t.Fatalf("expected the connection to survive oversized messages, got %v", err)
+	}
+}
+
+func TestErrorKindClassifiesBridgeErrors(t *testing.T) {
+	cases := []struct {
+		err  error
+		kind string
+		code int
+	}{
+		{&RequestError{Code: -32601, Message: "method not found"}, ErrorKindNotFound, -32601},
+		{fmt.Errorf("game adventure could not read world: %w", &RequestError{Code: -32000, Message: "Tool 'world/save' not found"}), ErrorKindNotFound, -32000},
+		{&RequestError{Code: -32602, Message: "playerId is required"}, ErrorKindInvalidArgs, -32602},
+		{&RequestError{Code: -32001, Message: "bad token"}, ErrorKindUnauthorized, -32001},
+		{&RequestError{Code: -32004, Message: "saving"}, ErrorKindGameBusy, -32004},
+		{fmt.Errorf("%w after 1s", ErrRequestTimeout), ErrorKindGameBusy, 0},
+		{fmt.Errorf("%w: it handles 1 requests at a time", ErrBridgeBusy), ErrorKindGameBusy, 0},
+		{&RequestError{Code: -32000, Message: "NullReferenceException"}, ErrorKindInternal, -32000},
+		{ErrClientNotConnected, ErrorKindInternal, 0},
+	}
+	for _, tc := range cases {
+		if kind := ErrorKind(tc.err); kind != tc.kind {
+			t.Errorf("%v: expected kind %s, got %s", tc.err, tc.kind, kind)
+		}
+		if code := ErrorCode(tc.err); code != tc.code {
+			t.Errorf("%v: expected code %d, got %d", tc.err, tc.code, code)
+		}
+	}
+}
+
+func TestRecordedSessionReplaysWithoutBridge(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := util.NewLSPFrameReader(conn)
+		writer := util.NewLSPFrameWriter(conn)
+		for {
+			data, err := reader.ReadMessage()
+			if err != nil {
+				return
+			}
+			var request util.GABPMessage
+			if err := json.Unmarshal(data, &request); err != nil {
+				return
+			}
+			switch request.Method {
+			case "session/hello":
+				err = writer.WriteJSON(util.NewGABPResponse(request.ID, SessionWelcomeResult{
+					AgentID:       "adventure",
+					Capabilities:  Capabilities{Methods: []string{"tools/list", "tools/call"}},
+					SchemaVersion: "1.0",
+				}))
+			case "tools/call":
+				params, _ := request.Params.(map[string]interface{})
+				err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"echo": params["arguments"]}))
+				if err == nil {
+					err = writer.WriteJSON(util.NewGABPEvent("world/changed", 1, map[string]interface{}{"turn": 7}))
+				}
+			default:
+				err = writer.WriteJSON(util.NewGABPError(request.ID, -32601, "method not found", nil))
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	recorder, err := NewRecorder(path)
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+	recorded := NewClient(util.NewLogger("error"))
+	recorded.SetRecorder(recorder, "adventure")
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := recorded.Connect(ctx, listener.Addr().String(), "secret-token", 10*time.Millisecond, 50*time.Millisecond); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	if _, _, err := recorded.CallTool("move", map[string]any{"x": 1}); err != nil {
+		t.Fatalf("recorded call failed: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond) // Let the event reach the recorder
+	recorded.Close()
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("recorder close failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read recording: %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Fatal("expected the recording to leave out the session token")
+	}
+
+	recording, err := LoadRecording(path)
+	if err != nil {
+		t.Fatalf("LoadRecording failed: %v", err)
+	}
+	if gameIDs := recording.GameIDs(); !reflect.DeepEqual(gameIDs, []string{"adventure"}) {
+		t.Fatalf("expected the recorded game, got %v", gameIDs)
+	}
+
+	replayed := NewClient(util.NewLogger("error"))
+	defer replayed.Close()
+	if err := replayed.Replay(ctx, recording, "adventure"); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if methods := replayed.GetCapabilities().Methods; len(methods) != 2 {
+		t.Fatalf("expected the recorded welcome, got %v", methods)
+	}
+	for i := 0; i < 2; i++ {
+		result, _, err := replayed.CallTool("move", map[string]any{"x": 1})
+		if err != nil {
+			t.Fatalf("replayed call %d failed: %v", i+1, err)
+		}
+		if echo, _ := result["echo"].(map[string]interface{}); echo["x"] != float64(1) {
+			t.Fatalf("expected the recorded answer, got %#v", result)
+		}
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(replayed.RecentEvents("world/changed", time.Time{})) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the recorded event to be replayed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if event := replayed.RecentEvents("world/changed", time.Time{})[0]; event.Payload.(map[string]interface{})["turn"] != float64(7) {
+		t.Fatalf("unexpected replayed event: %#v", event)
+	}
+
+	if _, _, err := replayed.CallTool("move", map[string]any{"x": 2}); ErrorCode(err) != errorCodeNotRecorded {
+		t.Fatalf("expected an unrecorded call to fail, got %v", err)
+	}
+	if err := NewClient(util.NewLogger("error")).Replay(ctx, recording, "other"); err == nil {
+		t.Fatal("expected replaying a game without a recorded session to fail")
+	}
+}
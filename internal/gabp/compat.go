@@ -0,0 +1,73 @@
+package gabp
+
+import (
+	"strconv"
+	"strings"
+
+	gabpruntime "github.com/pardeike/gabp-runtime/runtime"
+)
+
+// Compatibility profiles GABS picks from the schemaVersion of a welcome.
+const (
+	// ProfileLegacy is for bridges that report no schema version or a 0.x
+	// one. They read tools/call arguments from "parameters".
+	ProfileLegacy = "legacy"
+	// ProfileSchema10 is for schema 1.0 bridges. The schema names tools/call
+	// arguments "arguments", but bridges of that era often read "parameters",
+	// so both are sent.
+	ProfileSchema10 = "1.0"
+	// ProfileCurrent is for schema 1.1 and later, which use "arguments" only.
+	ProfileCurrent = "current"
+)
+
+// protocolProfile holds what differs between GABP schema versions.
+type protocolProfile struct {
+	name             string
+	callArgumentKeys []string // Keys of the tools/call params that carry the tool arguments
+	newerMajor       bool     // The bridge speaks a schema major GABS does not know yet
+}
+
+// negotiateProfile picks the compatibility profile for a bridge that reported
+// schemaVersion. Schemas of a newer major get the current profile, which is
+// the closest GABS knows.
+func negotiateProfile(schemaVersion string) protocolProfile {
+	major, minor, ok := parseSchemaVersion(schemaVersion)
+	targetMajor, _, _ := parseSchemaVersion(gabpruntime.TargetGabpSchemaVersion)
+	switch {
+	case !ok || major == 0:
+		return protocolProfile{name: ProfileLegacy, callArgumentKeys: []string{"parameters"}}
+	case major == targetMajor && minor == 0:
+		return protocolProfile{name: ProfileSchema10, callArgumentKeys: []string{"arguments", "parameters"}}
+	default:
+		return protocolProfile{name: ProfileCurrent, callArgumentKeys: []string{"arguments"}, newerMajor: major > targetMajor}
+	}
+}
+
+// parseSchemaVersion reads the major and minor of a version such as "1.0" or
+// "1.2.3".
+func parseSchemaVersion(version string) (int, int, bool) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".")
+	major, err := strconv.Atoi(parts[0])
+	if err != nil || major < 0 {
+		return 0, 0, false
+	}
+	minor := 0
+	if len(parts) > 1 {
+		if minor, err = strconv.Atoi(parts[1]); err != nil || minor < 0 {
+			return 0, 0, false
+		}
+	}
+	return major, minor, true
+}
+
+// toolsCallParams builds the tools/call params for the profile.
+func (p protocolProfile) toolsCallParams(name string, args map[string]any) map[string]interface{} {
+	if args == nil {
+		args = map[string]any{}
+	}
+	params := map[string]interface{}{"name": name}
+	for _, key := range p.callArgumentKeys {
+		params[key] = args
+	}
+	return params
+}
@@ -0,0 +1,93 @@
+package gabp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kinds of failed GABP requests, named for what the caller can do about them.
+const (
+	// ErrorKindNotFound: the bridge has no such tool, method or resource.
+	ErrorKindNotFound = "not-found"
+	// ErrorKindInvalidArgs: the bridge rejected the request's arguments.
+	ErrorKindInvalidArgs = "invalid-args"
+	// ErrorKindGameBusy: the bridge did not get to the request in time;
+	// retrying later may work.
+	ErrorKindGameBusy = "game-busy"
+	// ErrorKindUnauthorized: the bridge refused the session's token.
+	ErrorKindUnauthorized = "unauthorized"
+	// ErrorKindInternal: anything else, such as a failure inside the game or
+	// a lost connection.
+	ErrorKindInternal = "internal"
+)
+
+// JSON-RPC error codes bridges answer with. Codes from -32000 to -32099 are
+// left to the bridge and count as ErrorKindInternal unless listed here.
+const (
+	errorCodeParseError     = -32700
+	errorCodeMethodNotFound = -32601
+	errorCodeInvalidParams  = -32602
+	errorCodeForbidden      = -32003
+	errorCodeBusy           = -32004
+)
+
+// ErrRequestTimeout is returned when a bridge does not answer a request
+// within its timeout.
+var ErrRequestTimeout = errors.New("request timeout")
+
+// RequestError is an error response of a bridge to a request.
+type RequestError struct {
+	Code    int
+	Message string
+	Data    interface{}
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("GABP error %d: %s", e.Code, e.Message)
+}
+
+// Kind classifies the bridge's error code. Bridges that answer an unknown
+// tool with a generic code still name it in the message, so that counts as
+// ErrorKindNotFound too.
+func (e *RequestError) Kind() string {
+	switch e.Code {
+	case errorCodeMethodNotFound:
+		return ErrorKindNotFound
+	case errorCodeParseError, errorCodeInvalidRequest, errorCodeInvalidParams:
+		return ErrorKindInvalidArgs
+	case errorCodeUnauthorized, errorCodeForbidden:
+		return ErrorKindUnauthorized
+	case errorCodeBusy:
+		return ErrorKindGameBusy
+	}
+	message := strings.ToLower(e.Message)
+	if strings.Contains(message, "not found") {
+		return ErrorKindNotFound
+	}
+	return ErrorKindInternal
+}
+
+// ErrorKind classifies an error of a GABP request: the bridge's own error
+// response, or a request that timed out or waited in vain for a free slot.
+func ErrorKind(err error) string {
+	var requestErr *RequestError
+	switch {
+	case errors.As(err, &requestErr):
+		return requestErr.Kind()
+	case errors.Is(err, ErrBridgeBusy), errors.Is(err, ErrRequestTimeout):
+		return ErrorKindGameBusy
+	default:
+		return ErrorKindInternal
+	}
+}
+
+// ErrorCode returns the bridge's error code for err, or 0 when the bridge did
+// not answer with an error.
+func ErrorCode(err error) int {
+	var requestErr *RequestError
+	if errors.As(err, &requestErr) {
+		return requestErr.Code
+	}
+	return 0
+}
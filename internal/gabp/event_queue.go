@@ -0,0 +1,117 @@
+package gabp
+
+// DefaultEventQueueSize is how many events of one channel may wait for their
+// handlers before the oldest waiting one is dropped.
+const DefaultEventQueueSize = 256
+
+// Events are handed to their handlers by one goroutine per channel, in the
+// order they arrived, so a bridge that floods a channel cannot start
+// unbounded goroutines and a slow handler never stalls reading the
+// connection. Each channel has a bounded queue: when it is full the oldest
+// waiting event is dropped, and on coalescing channels a new event replaces
+// the one still waiting, so handlers only see the latest state.
+
+// queuedEvent is an event waiting for the handlers subscribed when it arrived.
+type queuedEvent struct {
+	channel  string
+	seq      int
+	payload  interface{}
+	handlers []EventHandler
+}
+
+// eventQueue holds a channel's waiting events and whether a goroutine is
+// handing them out.
+type eventQueue struct {
+	pending  []queuedEvent
+	draining bool
+}
+
+// SetEventQueue bounds each channel's queue to size waiting events, or
+// DefaultEventQueueSize when size is 0 or less, and makes channels for which
+// coalesce returns true keep only their newest waiting event. coalesce may
+// be nil.
+func (c *Client) SetEventQueue(size int, coalesce func(channel string) bool) {
+	if size <= 0 {
+		size = DefaultEventQueueSize
+	}
+	c.queueMu.Lock()
+	defer c.queueMu.Unlock()
+	c.eventQueueSize = size
+	c.coalesceEvents = coalesce
+}
+
+// SetEventDropObserver calls observe with the channel of every event that was
+// dropped or replaced before its handlers saw it.
+func (c *Client) SetEventDropObserver(observe func(channel string)) {
+	c.queueMu.Lock()
+	defer c.queueMu.Unlock()
+	c.onEventDropped = observe
+}
+
+// DroppedEvents returns how many events of each channel were dropped or
+// replaced before their handlers saw them.
+func (c *Client) DroppedEvents() map[string]int {
+	c.queueMu.Lock()
+	defer c.queueMu.Unlock()
+	dropped := make(map[string]int, len(c.droppedEvents))
+	for channel, count := range c.droppedEvents {
+		dropped[channel] = count
+	}
+	return dropped
+}
+
+// queueEvent queues event for its handlers without blocking.
+func (c *Client) queueEvent(event queuedEvent) {
+	c.queueMu.Lock()
+	queue := c.eventQueues[event.channel]
+	if queue == nil {
+		queue = &eventQueue{}
+		c.eventQueues[event.channel] = queue
+	}
+	dropped := true
+	switch {
+	case len(queue.pending) > 0 && c.coalesceEvents != nil && c.coalesceEvents(event.channel):
+		queue.pending[len(queue.pending)-1] = event
+	case len(queue.pending) >= c.eventQueueSize:
+		queue.pending = append(queue.pending[1:], event)
+	default:
+		queue.pending = append(queue.pending, event)
+		dropped = false
+	}
+	if dropped {
+		c.droppedEvents[event.channel]++
+	}
+	observe := c.onEventDropped
+	start := !queue.draining
+	queue.draining = true
+	c.queueMu.Unlock()
+
+	if dropped {
+		c.log.Debugw("GABP event dropped, handlers are behind", "channel", event.channel, "seq", event.seq)
+		if observe != nil {
+			observe(event.channel)
+		}
+	}
+	if start {
+		go c.drainEvents(queue)
+	}
+}
+
+// drainEvents hands queue's events to their handlers until it is empty.
+func (c *Client) drainEvents(queue *eventQueue) {
+	for {
+		c.queueMu.Lock()
+		if len(queue.pending) == 0 {
+			queue.draining = false
+			c.queueMu.Unlock()
+			return
+		}
+		event := queue.pending[0]
+		queue.pending = queue.pending[1:]
+		c.queueMu.Unlock()
+
+		for _, handler := range event.handlers {
+			handler(event.channel, event.seq, event.payload)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package gabp
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// blockedEventHandler records the sequence numbers it handles and holds the
+// first event until release is closed, like a handler stuck on a slow client.
+type blockedEventHandler struct {
+	mu      sync.Mutex
+	seqs    []int
+	started chan struct{}
+	release chan struct{}
+	done    chan struct{}
+	want    int
+}
+
+func newBlockedEventHandler(want int) *blockedEventHandler {
+	return &blockedEventHandler{started: make(chan struct{}), release: make(chan struct{}), done: make(chan struct{}), want: want}
+}
+
+func (h *blockedEventHandler) handle(channel string, seq int, payload interface{}) {
+	h.mu.Lock()
+	h.seqs = append(h.seqs, seq)
+	count := len(h.seqs)
+	h.mu.Unlock()
+	if count == 1 {
+		close(h.started)
+		<-h.release
+	}
+	if count == h.want {
+		close(h.done)
+	}
+}
+
+func (h *blockedEventHandler) handled(t *testing.T) []int {
+	t.Helper()
+	select {
+	case <-h.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler did not see the expected events")
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]int(nil), h.seqs...)
+}
+
+func floodEventsForTest(t *testing.T, client *Client, channel string, handler *blockedEventHandler, count int) {
+	t.Helper()
+	client.mu.Lock()
+	client.eventHandlers[channel] = []EventHandler{handler.handle}
+	client.mu.Unlock()
+
+	client.handleEvent(&util.GABPMessage{Type: "event", Channel: channel, Seq: 1})
+	select {
+	case <-handler.started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler did not start")
+	}
+
+	flooded := make(chan struct{})
+	go func() {
+		for seq := 2; seq <= count; seq++ {
+			client.handleEvent(&util.GABPMessage{Type: "event", Channel: channel, Seq: seq})
+		}
+		close(flooded)
+	}()
+	select {
+	case <-flooded:
+	case <-time.After(2 * time.Second):
+		t.Fatal("a blocked handler stalled reading events")
+	}
+	close(handler.release)
+}
+
+func TestEventQueueDropsOldestWhenFull(t *testing.T) {
+	client := NewClient(util.NewLogger("error"))
+	client.SetEventQueue(3, nil)
+	var observed []string
+	client.SetEventDropObserver(func(channel string) { observed = append(observed, channel) })
+
+	handler := newBlockedEventHandler(4)
+	floodEventsForTest(t, client, "player/moved", handler, 10)
+
+	if seqs := handler.handled(t); !reflect.DeepEqual(seqs, []int{1, 8, 9, 10}) {
+		t.Fatalf("expected the first event and the three newest, got %v", seqs)
+	}
+	if dropped := client.DroppedEvents()["player/moved"]; dropped != 6 || len(observed) != 6 {
+		t.Fatalf("expected 6 dropped events, counted %d and observed %d", dropped, len(observed))
+	}
+}
+
+func TestEventQueueCoalescesToNewestEvent(t *testing.T) {
+	client := NewClient(util.NewLogger("error"))
+	client.SetEventQueue(0, func(channel string) bool { return channel == "world/tick" })
+
+	handler := newBlockedEventHandler(2)
+	floodEventsForTest(t, client, "world/tick", handler, 5)
+
+	if seqs := handler.handled(t); !reflect.DeepEqual(seqs, []int{1, 5}) {
+		t.Fatalf("expected the first and the newest event, got %v", seqs)
+	}
+	if dropped := client.DroppedEvents(); dropped["world/tick"] != 3 {
+		t.Fatalf("expected 3 coalesced events, got %v", dropped)
+	}
+}
@@ -0,0 +1,119 @@
+package gabp
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// ErrBridgeBusy is returned when a request waited for its whole timeout
+// because the bridge already had as many requests in flight as its
+// maxConcurrentRequests limit allows.
+var ErrBridgeBusy = errors.New("GABP bridge is busy")
+
+// applyLimitsLocked adopts the limits of a bridge's welcome: requests beyond
+// maxConcurrentRequests wait for a free slot, requestTimeout replaces
+// defaultRequestTimeout and a maxMessageSize below SetMaxMessageSize's
+// limits messages either way. nil drops the limits of a previous session.
+// Callers hold c.mu.
+func (c *Client) applyLimitsLocked(limits *Limits) {
+	c.requestSlots = nil
+	c.bridgeTimeout = 0
+	c.messageLimit = c.maxMessageSize
+	if c.messageLimit <= 0 {
+		c.messageLimit = util.DefaultMaxMessageSize
+	}
+	defer func() {
+		if c.reader != nil {
+			c.reader.SetMaxMessageSize(c.messageLimit)
+		}
+		if c.writer != nil {
+			c.writer.SetMaxMessageSize(c.messageLimit)
+		}
+	}()
+	if limits == nil {
+		return
+	}
+	if limits.MaxMessageSize != nil && *limits.MaxMessageSize > 0 && *limits.MaxMessageSize < c.messageLimit {
+		c.messageLimit = *limits.MaxMessageSize
+	}
+	if limits.MaxConcurrentRequests != nil && *limits.MaxConcurrentRequests > 0 {
+		c.requestSlots = make(chan struct{}, *limits.MaxConcurrentRequests)
+	}
+	if limits.RequestTimeout != nil && *limits.RequestTimeout > 0 {
+		c.bridgeTimeout = time.Duration(*limits.RequestTimeout) * time.Second
+	}
+}
+
+// SetRequestTimeout overrides the timeout of requests sent without one of
+// their own, including the requestTimeout limit of the bridge. 0 removes the
+// override.
+func (c *Client) SetRequestTimeout(timeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.timeoutOverride = timeout
+}
+
+// RequestTimeout returns how long requests sent without a timeout of their
+// own wait for a response: the SetRequestTimeout override, else the bridge's
+// requestTimeout limit, else 30 seconds.
+func (c *Client) RequestTimeout() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	switch {
+	case c.timeoutOverride > 0:
+		return c.timeoutOverride
+	case c.bridgeTimeout > 0:
+		return c.bridgeTimeout
+	default:
+		return defaultRequestTimeout
+	}
+}
+
+// MaxConcurrentRequests returns the bridge's maxConcurrentRequests limit, or
+// 0 when it has none.
+func (c *Client) MaxConcurrentRequests() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return cap(c.requestSlots)
+}
+
+// SetMaxMessageSize limits the messages GABS accepts from and sends to the
+// bridge to size bytes, or util.DefaultMaxMessageSize for 0. A bridge that
+// announces a lower maxMessageSize lowers it for its session.
+func (c *Client) SetMaxMessageSize(size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxMessageSize = size
+}
+
+// MaxMessageSize returns the largest message the current session exchanges
+// with the bridge.
+func (c *Client) MaxMessageSize() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.messageLimit <= 0 {
+		return util.DefaultMaxMessageSize
+	}
+	return c.messageLimit
+}
+
+// rejectOversizedMessage drops a message of the bridge that exceeds the
+// session's message limit. When it answers a pending request, the request
+// fails with an error instead of waiting for its timeout.
+func (c *Client) rejectOversizedMessage(tooLarge *util.MessageTooLargeError) {
+	c.log.Warnw("dropping GABP message larger than the message limit", "size", tooLarge.Size, "limit", tooLarge.Limit)
+	rawID, found := util.LeadingMessageID(tooLarge.Head)
+	var id string
+	if !found || json.Unmarshal(rawID, &id) != nil {
+		return
+	}
+	c.mu.RLock()
+	_, pending := c.pendingReqs[id]
+	c.mu.RUnlock()
+	if pending {
+		c.handleResponse(util.NewGABPError(id, errorCodeInvalidRequest, "GABS rejected the response: "+tooLarge.Error(), nil))
+	}
+}
@@ -0,0 +1,144 @@
+package gabp
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	gabpruntime "github.com/pardeike/gabp-runtime/runtime"
+	"github.com/pardeike/gabs/internal/util"
+	"github.com/pardeike/gabs/internal/version"
+)
+
+// bridgeHelloTimeout bounds how long a bridge that connected in may take to
+// send its session/hello.
+const bridgeHelloTimeout = 10 * time.Second
+
+// GABP error codes GABS answers a rejected bridge hello with.
+const (
+	errorCodeInvalidRequest = -32600
+	errorCodeUnauthorized   = -32001
+)
+
+// Accept listens on addr until a bridge connects in and proves it knows
+// token, then performs the usual handshake on that connection. It is the
+// counterpart of Connect for bridges that can only dial out.
+//
+// The bridge speaks first: its first message must be a session/hello request
+// carrying token. GABS answers it with a welcome naming itself and only then
+// sends its own session/hello, so the token is never sent to a peer that has
+// not shown it already. Connections that fail this are closed and Accept
+// keeps listening until ctx is cancelled or the client is closed.
+func (c *Client) Accept(ctx context.Context, addr string, token string) error {
+	c.token = token
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stop := context.AfterFunc(c.ctx, func() {
+		cancel(context.Cause(c.ctx))
+	})
+	defer stop()
+
+	listener, err := listenForBridge(ctx, addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for the GABP bridge on %s: %w", addr, err)
+	}
+	defer listener.Close()
+	closeOnCancel := context.AfterFunc(ctx, func() {
+		_ = listener.Close()
+	})
+	defer closeOnCancel()
+
+	c.log.Debugw("waiting for GABP bridge to connect", "addr", addr)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("accept cancelled: %w", context.Cause(ctx))
+			}
+			return fmt.Errorf("failed to accept GABP bridge connection: %w", err)
+		}
+
+		reader := util.NewLSPFrameReader(conn)
+		if err := c.admitBridge(conn, reader); err != nil {
+			c.log.Warnw("rejected incoming GABP connection", "addr", addr, "remote", conn.RemoteAddr().String(), "error", err)
+			_ = conn.Close()
+			continue
+		}
+
+		// One bridge per client; later ones have to wait for a reconnect.
+		_ = listener.Close()
+		c.log.Infow("GABP bridge connected in", "addr", addr, "remote", conn.RemoteAddr().String())
+		return c.startSession(ctx, conn, reader)
+	}
+}
+
+// listenForBridge listens on addr. A Unix socket replaces a leftover socket
+// file and is restricted to the current user; closing the listener removes
+// it.
+func listenForBridge(ctx context.Context, addr string) (net.Listener, error) {
+	network, address := splitAddress(addr)
+	if network == "unix" {
+		if err := os.Remove(address); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	var lc net.ListenConfig
+	listener, err := lc.Listen(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	if network == "unix" {
+		if err := os.Chmod(address, 0600); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+		}
+	}
+	return listener, nil
+}
+
+// admitBridge reads the session/hello of a bridge that connected in and
+// answers it, with an error when the token does not match.
+func (c *Client) admitBridge(conn net.Conn, reader *util.LSPFrameReader) error {
+	writer := util.NewLSPFrameWriter(conn)
+	if err := conn.SetReadDeadline(time.Now().Add(bridgeHelloTimeout)); err != nil {
+		return err
+	}
+	defer conn.SetReadDeadline(time.Time{})
+
+	data, err := reader.ReadMessage()
+	if err != nil {
+		return fmt.Errorf("no session/hello from bridge: %w", err)
+	}
+	var msg util.GABPMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return fmt.Errorf("invalid bridge message: %w", err)
+	}
+	if msg.Type != gabpruntime.MessageTypeRequest || msg.Method != gabpruntime.MethodSessionHello {
+		_ = writer.WriteJSON(util.NewGABPError(msg.ID, errorCodeInvalidRequest, "expected session/hello", nil))
+		return fmt.Errorf("bridge sent %s %q before session/hello", msg.Type, msg.Method)
+	}
+
+	var hello SessionHelloParams
+	if err := mapToStruct(msg.Params, &hello); err != nil {
+		_ = writer.WriteJSON(util.NewGABPError(msg.ID, errorCodeInvalidRequest, "invalid session/hello params", nil))
+		return fmt.Errorf("invalid session/hello params: %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(hello.Token), []byte(c.token)) != 1 {
+		_ = writer.WriteJSON(util.NewGABPError(msg.ID, errorCodeUnauthorized, "invalid token", nil))
+		return errors.New("bridge sent an invalid token")
+	}
+
+	return writer.WriteJSON(util.NewGABPResponse(msg.ID, SessionWelcomeResult{
+		AgentID:       "gabs",
+		App:           AppInfo{Name: "gabs", Version: version.Get()},
+		SchemaVersion: "1.0",
+		ServerInfo:    &ServerInfo{Name: "gabs", Version: version.Get()},
+	}))
+}
@@ -0,0 +1,40 @@
+package gabp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// FuzzHandleMessage feeds arbitrary GABP messages from a bridge to a client
+// with a pending request and an event subscriber, which must survive them.
+func FuzzHandleMessage(f *testing.F) {
+	for _, seed := range []string{
+		`{"v":"gabp/1","id":"pending","type":"response","result":{"tools":[]}}`,
+		`{"v":"gabp/1","id":"pending","type":"response","error":{"code":-32601,"message":"nope","data":[1]}}`,
+		`{"v":"gabp/1","id":"e1","type":"event","channel":"chat/message","seq":1,"payload":{"sender":"ada","message":"hi"}}`,
+		`{"v":"gabp/1","id":"e2","type":"event","channel":"chat/message","seq":-1,"payload":"not an object"}`,
+		`{"v":"gabp/1","type":"event","channel":"","payload":null}`,
+		`{"v":"gabp/1","id":"x","type":"request","method":"tools/list"}`,
+		`{"type":"response","id":7}`,
+		`{"seq":1.5}`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var msg util.GABPMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return
+		}
+		client := NewClient(util.NewLogger("error"))
+		defer client.Close()
+		client.pendingReqs["pending"] = make(chan *util.GABPMessage, 1)
+		client.eventHandlers[msg.Channel] = []EventHandler{func(channel string, seq int, payload interface{}) {
+			ParseChatMessage(payload)
+		}}
+		client.handleMessage(&msg)
+		client.RecentEvents(msg.Channel, client.clock.Now())
+	})
+}
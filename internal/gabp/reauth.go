@@ -0,0 +1,43 @@
+package gabp
+
+import (
+	"fmt"
+	"time"
+)
+
+// SessionReauthMethod is the GABP request that hands a connected bridge a new
+// token. The bridge accepts it on the authenticated session and requires it
+// from then on, for example when GABS reconnects, so rotating the token does
+// not drop the connection.
+const SessionReauthMethod = "session/reauth"
+
+// SessionReauthParams are the parameters of session/reauth. Token is the
+// token the session was opened with.
+type SessionReauthParams struct {
+	Token    string `json:"token"`
+	NewToken string `json:"newToken"`
+}
+
+// SupportsReauth reports whether the bridge advertises session/reauth.
+func SupportsReauth(capabilities Capabilities) bool {
+	return hasCapabilityEntry(capabilities.Methods, SessionReauthMethod)
+}
+
+// Reauthenticate asks the bridge to accept newToken in place of the current
+// token. The connection stays open either way; on success the client uses
+// newToken from then on.
+func (c *Client) Reauthenticate(newToken string, timeout time.Duration) error {
+	c.mu.RLock()
+	current := c.token
+	c.mu.RUnlock()
+
+	if _, err := c.sendRequestWithTimeout(SessionReauthMethod, SessionReauthParams{Token: current, NewToken: newToken}, timeout); err != nil {
+		return fmt.Errorf("re-authentication failed: %w", err)
+	}
+
+	c.mu.Lock()
+	c.token = newToken
+	c.mu.Unlock()
+	c.log.Infow("GABP session re-authenticated")
+	return nil
+}
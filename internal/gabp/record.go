@@ -0,0 +1,100 @@
+package gabp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	gabpruntime "github.com/pardeike/gabp-runtime/runtime"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// Directions of a RecordedMessage.
+const (
+	DirectionSent     = "sent"     // From GABS to the bridge
+	DirectionReceived = "received" // From the bridge to GABS
+)
+
+// RecordedMessage is one GABP message of a recording, which holds one JSON
+// object per line.
+type RecordedMessage struct {
+	GameID    string           `json:"gameId"`
+	Direction string           `json:"direction"`
+	At        time.Time        `json:"at"`
+	Message   util.GABPMessage `json:"message"`
+}
+
+// Recorder writes the GABP messages of game sessions to a file so they can be
+// replayed later without the game. It is safe for concurrent use by the
+// clients of several games.
+type Recorder struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+	clock   util.Clock
+	err     error
+}
+
+// NewRecorder creates or truncates the recording at path. The file is
+// readable by the current user only, as messages can carry game data.
+func NewRecorder(path string) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GABP recording: %w", err)
+	}
+	return &Recorder{file: file, encoder: json.NewEncoder(file), clock: util.NewRealClock()}, nil
+}
+
+// secretParams are the parameters of requests that carry tokens. They are
+// left out of recordings, so recordings can be shared.
+var secretParams = map[string][]string{
+	gabpruntime.MethodSessionHello: {"token"},
+	SessionReauthMethod:            {"token", "newToken"},
+}
+
+// record appends msg without its secretParams. A failed write stops the
+// recording; Close reports it.
+func (r *Recorder) record(gameID, direction string, msg *util.GABPMessage) {
+	if r == nil || msg == nil {
+		return
+	}
+	var entry RecordedMessage
+	if err := mapToStruct(msg, &entry.Message); err != nil {
+		return
+	}
+	stripSecretParams(&entry.Message)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	entry.GameID = gameID
+	entry.Direction = direction
+	entry.At = r.clock.Now().UTC()
+	r.err = r.encoder.Encode(entry)
+}
+
+// stripSecretParams removes the secretParams of msg's method from its params.
+func stripSecretParams(msg *util.GABPMessage) {
+	params, ok := msg.Params.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, key := range secretParams[msg.Method] {
+		delete(params, key)
+	}
+}
+
+// Close finishes the recording and returns the first write error, if any.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	closeErr := r.file.Close()
+	if r.err != nil {
+		return fmt.Errorf("failed to write GABP recording: %w", r.err)
+	}
+	return closeErr
+}
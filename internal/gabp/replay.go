@@ -0,0 +1,214 @@
+package gabp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+
+	gabpruntime "github.com/pardeike/gabp-runtime/runtime"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// errorCodeNotRecorded answers replayed requests the recording has no
+// answer for.
+const errorCodeNotRecorded = -32000
+
+// Recording is a recording written by Recorder, loaded for replay.
+type Recording struct {
+	messages []RecordedMessage
+}
+
+// LoadRecording reads the recording at path.
+func LoadRecording(path string) (*Recording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GABP recording: %w", err)
+	}
+	defer file.Close()
+
+	recording := &Recording{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry RecordedMessage
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid GABP recording line %d: %w", line, err)
+		}
+		recording.messages = append(recording.messages, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read GABP recording: %w", err)
+	}
+	return recording, nil
+}
+
+// GameIDs returns the sorted IDs of the games the recording has sessions of.
+func (r *Recording) GameIDs() []string {
+	seen := make(map[string]bool)
+	var gameIDs []string
+	for _, entry := range r.messages {
+		if !seen[entry.GameID] {
+			seen[entry.GameID] = true
+			gameIDs = append(gameIDs, entry.GameID)
+		}
+	}
+	sort.Strings(gameIDs)
+	return gameIDs
+}
+
+// replayExchange is a recorded request with its answer and the events the
+// bridge sent until GABS sent its next request.
+type replayExchange struct {
+	request  util.GABPMessage
+	response *util.GABPMessage
+	events   []util.GABPMessage
+}
+
+// exchanges pairs the recorded requests of gameID with their answers.
+// Requests that were never answered are left out.
+func (r *Recording) exchanges(gameID string) []*replayExchange {
+	var exchanges []*replayExchange
+	byID := make(map[string]*replayExchange)
+	var latest *replayExchange
+	for i := range r.messages {
+		entry := &r.messages[i]
+		if entry.GameID != gameID {
+			continue
+		}
+		msg := entry.Message
+		switch {
+		case entry.Direction == DirectionSent && msg.Type == gabpruntime.MessageTypeRequest:
+			latest = &replayExchange{request: msg}
+			byID[msg.ID] = latest
+			exchanges = append(exchanges, latest)
+		case entry.Direction == DirectionReceived && msg.Type == gabpruntime.MessageTypeResponse:
+			if exchange, exists := byID[msg.ID]; exists && exchange.response == nil {
+				exchange.response = &msg
+			}
+		case entry.Direction == DirectionReceived && msg.Type == gabpruntime.MessageTypeEvent:
+			if latest != nil {
+				latest.events = append(latest.events, msg)
+			}
+		}
+	}
+
+	answered := exchanges[:0]
+	for _, exchange := range exchanges {
+		if exchange.response != nil {
+			answered = append(answered, exchange)
+		}
+	}
+	return answered
+}
+
+// Replay connects the client to a stand-in for the bridge of gameID that
+// answers from recording instead of a running game, and performs the
+// handshake. Each request gets the answer to the first recorded request of
+// the same method and parameters that was not replayed yet, or the last one
+// again once they are used up, followed by the events the bridge sent after
+// it. Session requests match on the method alone, as their parameters change
+// with every launch. Recorded timing is not reproduced.
+func (c *Client) Replay(ctx context.Context, recording *Recording, gameID string) error {
+	exchanges := recording.exchanges(gameID)
+	if len(exchanges) == 0 {
+		return fmt.Errorf("GABP recording has no session of game %q", gameID)
+	}
+
+	bridgeConn, clientConn := net.Pipe()
+	bridge := &replayBridge{exchanges: exchanges, replayed: make([]bool, len(exchanges)), log: c.log}
+	go bridge.serve(c.ctx, bridgeConn)
+	return c.startSession(ctx, clientConn, util.NewLSPFrameReader(clientConn))
+}
+
+// replayBridge answers the requests of one replayed session.
+type replayBridge struct {
+	exchanges []*replayExchange
+	replayed  []bool
+	log       util.Logger
+}
+
+// serve answers requests on conn until the client hangs up or ctx ends.
+func (b *replayBridge) serve(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	reader := util.NewLSPFrameReader(conn)
+	writer := util.NewLSPFrameWriter(conn)
+	for {
+		data, err := reader.ReadMessage()
+		if err != nil {
+			return
+		}
+		var request util.GABPMessage
+		if err := json.Unmarshal(data, &request); err != nil || request.Type != gabpruntime.MessageTypeRequest {
+			continue
+		}
+
+		exchange := b.answer(&request)
+		if exchange == nil {
+			b.log.Warnw("no recorded answer for replayed GABP request", "method", request.Method)
+			if err := writer.WriteJSON(util.NewGABPError(request.ID, errorCodeNotRecorded, fmt.Sprintf("recording has no answer for %s with these parameters", request.Method), nil)); err != nil {
+				return
+			}
+			continue
+		}
+
+		response := *exchange.response
+		response.ID = request.ID
+		if err := writer.WriteJSON(&response); err != nil {
+			return
+		}
+		for i := range exchange.events {
+			if err := writer.WriteJSON(&exchange.events[i]); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// answer picks the recorded exchange that answers request, or nil.
+func (b *replayBridge) answer(request *util.GABPMessage) *replayExchange {
+	stripSecretParams(request)
+	var last *replayExchange
+	for i, exchange := range b.exchanges {
+		if !sameRequest(&exchange.request, request) {
+			continue
+		}
+		if !b.replayed[i] {
+			b.replayed[i] = true
+			return exchange
+		}
+		last = exchange
+	}
+	return last
+}
+
+// sameRequest reports whether request asks what recorded asked.
+func sameRequest(recorded, request *util.GABPMessage) bool {
+	if recorded.Method != request.Method {
+		return false
+	}
+	if recorded.Method == gabpruntime.MethodSessionHello || recorded.Method == SessionReauthMethod {
+		return true
+	}
+	recordedParams, err := json.Marshal(recorded.Params)
+	if err != nil {
+		return false
+	}
+	requestParams, err := json.Marshal(request.Params)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(recordedParams, requestParams)
+}
@@ -0,0 +1,79 @@
+package gabp
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// ResourcesListMethod is the GABP request that describes the resources a
+	// bridge serves.
+	ResourcesListMethod = "resources/list"
+	// ResourcesReadMethod is the GABP request that reads one resource.
+	ResourcesReadMethod = "resources/read"
+)
+
+// ResourceDescriptor describes a resource the bridge serves. URI is the
+// bridge's own name for it, such as "world/save_data".
+type ResourceDescriptor struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// ResourceContent is one part of a resources/read result. It holds Text, or
+// Blob as base64 for binary data.
+type ResourceContent struct {
+	URI      string `json:"uri,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
+}
+
+// SupportsResources reports whether the bridge can serve resources: it
+// declares some in its welcome or advertises resources/read.
+func SupportsResources(capabilities Capabilities) bool {
+	return len(capabilities.Resources) > 0 || hasCapabilityEntry(capabilities.Methods, ResourcesReadMethod)
+}
+
+// ListResources describes the bridge's resources. A bridge that does not
+// advertise resources/list gets a descriptor for each URI it declared in its
+// welcome.
+func (c *Client) ListResources(timeout time.Duration) ([]ResourceDescriptor, error) {
+	capabilities := c.GetCapabilities()
+	if !hasCapabilityEntry(capabilities.Methods, ResourcesListMethod) {
+		resources := make([]ResourceDescriptor, 0, len(capabilities.Resources))
+		for _, uri := range capabilities.Resources {
+			resources = append(resources, ResourceDescriptor{URI: uri})
+		}
+		return resources, nil
+	}
+
+	result, err := c.sendRequestWithTimeout(ResourcesListMethod, map[string]interface{}{}, timeout)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Resources []ResourceDescriptor `json:"resources"`
+	}
+	if err := mapToStruct(result, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse resources: %w", err)
+	}
+	return response.Resources, nil
+}
+
+// ReadResource reads the resource the bridge calls uri.
+func (c *Client) ReadResource(uri string, timeout time.Duration) ([]ResourceContent, error) {
+	result, err := c.sendRequestWithTimeout(ResourcesReadMethod, map[string]interface{}{"uri": uri}, timeout)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Contents []ResourceContent `json:"contents"`
+	}
+	if err := mapToStruct(result, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse resource contents: %w", err)
+	}
+	return response.Contents, nil
+}
@@ -0,0 +1,296 @@
+package mcp
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+// argumentError is one way tool arguments break the tool's inputSchema.
+type argumentError struct {
+	Field   string `json:"field"` // Path such as "position.x" or "items[2]", empty for the arguments as a whole
+	Message string `json:"message"`
+}
+
+// validateArguments checks args against a tool's inputSchema. It covers the
+// JSON Schema keywords tool schemas use: type, properties, required,
+// additionalProperties, items, enum, const, minimum, maximum,
+// exclusiveMinimum, exclusiveMaximum, minLength, maxLength, pattern, minItems
+// and maxItems. Other keywords are not checked.
+func validateArguments(schema map[string]interface{}, args map[string]interface{}) []argumentError {
+	if len(schema) == 0 {
+		return nil
+	}
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+	var errs []argumentError
+	validateValue(schema, args, "", &errs)
+	return errs
+}
+
+func validateValue(schema map[string]interface{}, value interface{}, path string, errs *[]argumentError) {
+	fail := func(format string, a ...interface{}) {
+		*errs = append(*errs, argumentError{Field: path, Message: fmt.Sprintf(format, a...)})
+	}
+
+	if types := schemaStrings(schema["type"]); len(types) > 0 {
+		matched := false
+		for _, schemaType := range types {
+			if jsonValueHasType(value, schemaType) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("must be %s, got %s", strings.Join(types, " or "), jsonTypeName(value))
+			return
+		}
+	}
+
+	if constant, ok := schema["const"]; ok && !jsonValuesEqual(constant, value) {
+		fail("must be %v", constant)
+	}
+	if options, ok := schema["enum"].([]interface{}); ok && len(options) > 0 {
+		found := false
+		for _, option := range options {
+			if jsonValuesEqual(option, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("must be one of %v", options)
+		}
+	} else if options := schemaStrings(schema["enum"]); len(options) > 0 {
+		if text, ok := value.(string); !ok || !containsString(options, text) {
+			fail("must be one of %v", options)
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		validateObject(schema, typed, path, errs)
+	case []interface{}:
+		if minItems, ok := schemaNumber(schema["minItems"]); ok && float64(len(typed)) < minItems {
+			fail("must have at least %v items", minItems)
+		}
+		if maxItems, ok := schemaNumber(schema["maxItems"]); ok && float64(len(typed)) > maxItems {
+			fail("must have at most %v items", maxItems)
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range typed {
+				validateValue(items, item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	case string:
+		length := float64(len([]rune(typed)))
+		if minLength, ok := schemaNumber(schema["minLength"]); ok && length < minLength {
+			fail("must be at least %v characters long", minLength)
+		}
+		if maxLength, ok := schemaNumber(schema["maxLength"]); ok && length > maxLength {
+			fail("must be at most %v characters long", maxLength)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			// A pattern Go cannot compile is left to the game to check
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(typed) {
+				fail("must match %s", pattern)
+			}
+		}
+	case float64:
+		if minimum, ok := schemaNumber(schema["minimum"]); ok && typed < minimum {
+			fail("must be at least %v", minimum)
+		}
+		if maximum, ok := schemaNumber(schema["maximum"]); ok && typed > maximum {
+			fail("must be at most %v", maximum)
+		}
+		if minimum, ok := schemaNumber(schema["exclusiveMinimum"]); ok && typed <= minimum {
+			fail("must be greater than %v", minimum)
+		}
+		if maximum, ok := schemaNumber(schema["exclusiveMaximum"]); ok && typed >= maximum {
+			fail("must be less than %v", maximum)
+		}
+	}
+}
+
+func validateObject(schema map[string]interface{}, object map[string]interface{}, path string, errs *[]argumentError) {
+	for _, name := range schemaStrings(schema["required"]) {
+		if _, ok := object[name]; !ok {
+			*errs = append(*errs, argumentError{Field: joinFieldPath(path, name), Message: "is required"})
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			validateValue(property, object[name], joinFieldPath(path, name), errs)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				*errs = append(*errs, argumentError{Field: joinFieldPath(path, name), Message: "is not a known argument"})
+			}
+		case map[string]interface{}:
+			validateValue(additional, object[name], joinFieldPath(path, name), errs)
+		}
+	}
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// jsonValueHasType reports whether a decoded JSON value is of a JSON Schema
+// type. Numbers without a fraction count as integers.
+func jsonValueHasType(value interface{}, schemaType string) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number) && !math.IsInf(number, 0)
+	default:
+		// Unknown types are left to the game to check
+		return true
+	}
+}
+
+func jsonTypeName(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if typed == math.Trunc(typed) {
+			return "integer"
+		}
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// jsonValuesEqual compares decoded JSON values, treating the numbers of Go
+// built schemas like the float64 of decoded arguments.
+func jsonValuesEqual(a, b interface{}) bool {
+	if left, ok := schemaNumber(a); ok {
+		right, ok := schemaNumber(b)
+		return ok && left == right
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// schemaStrings reads a keyword that holds a string or a list of strings, as
+// decoded from JSON or written in Go.
+func schemaStrings(raw interface{}) []string {
+	switch typed := raw.(type) {
+	case string:
+		return []string{typed}
+	case []string:
+		return typed
+	case []interface{}:
+		values := make([]string, 0, len(typed))
+		for _, item := range typed {
+			if value, ok := item.(string); ok {
+				values = append(values, value)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func schemaNumber(raw interface{}) (float64, bool) {
+	switch typed := raw.(type) {
+	case float64:
+		return typed, true
+	case float32:
+		return float64(typed), true
+	case int:
+		return float64(typed), true
+	case int64:
+		return float64(typed), true
+	default:
+		return 0, false
+	}
+}
+
+// checkToolArguments validates the arguments of a call to the game tool name
+// before they are forwarded to its bridge. It returns the error result to
+// answer with, or nil when the arguments are valid or lenientArguments
+// forwards them anyway. GABS's own tools check their arguments themselves.
+func (s *Server) checkToolArguments(name string, schema map[string]interface{}, args map[string]interface{}) *ToolResult {
+	errs := validateArguments(schema, args)
+	if len(errs) == 0 {
+		return nil
+	}
+	if s.lenientArguments {
+		s.log.Warnw("forwarding tool arguments that do not match the input schema", "tool", name, "errors", errs)
+		return nil
+	}
+
+	message := fmt.Sprintf("Invalid arguments for tool '%s': %s. Use games_tool_detail to see its input schema.", name, describeArgumentErrors(errs))
+	return &ToolResult{
+		Content: []Content{{Type: "text", Text: message}},
+		StructuredContent: map[string]interface{}{
+			"tool": name,
+			"error": map[string]interface{}{
+				"kind":    gabp.ErrorKindInvalidArgs,
+				"message": message,
+				"fields":  errs,
+			},
+		},
+		IsError: true,
+	}
+}
+
+// describeArgumentErrors joins schema violations into one line, naming the
+// field of each.
+func describeArgumentErrors(errs []argumentError) string {
+	problems := make([]string, 0, len(errs))
+	for _, err := range errs {
+		if err.Field == "" {
+			problems = append(problems, err.Message)
+			continue
+		}
+		problems = append(problems, err.Field+" "+err.Message)
+	}
+	return strings.Join(problems, "; ")
+}
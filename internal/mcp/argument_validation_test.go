@@ -0,0 +1,98 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func TestValidateArgumentsReportsOffendingFields(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"item":  map[string]interface{}{"type": "string", "minLength": float64(1)},
+			"count": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 64},
+			"mode":  map[string]interface{}{"type": "string", "enum": []string{"craft", "drop"}},
+			"position": map[string]interface{}{
+				"type":                 "object",
+				"properties":           map[string]interface{}{"x": map[string]interface{}{"type": "number"}},
+				"required":             []interface{}{"x"},
+				"additionalProperties": false,
+			},
+			"tags": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		},
+		"required": []string{"item"},
+	}
+
+	valid := map[string]interface{}{"item": "gear", "count": float64(3), "mode": "craft", "position": map[string]interface{}{"x": 1.5}, "tags": []interface{}{"a"}}
+	if errs := validateArguments(schema, valid); len(errs) != 0 {
+		t.Fatalf("expected valid arguments, got %v", errs)
+	}
+
+	errs := validateArguments(schema, map[string]interface{}{
+		"count":    2.5,
+		"mode":     "sell",
+		"position": map[string]interface{}{"y": float64(2)},
+		"tags":     []interface{}{"a", float64(1)},
+		"extra":    true,
+	})
+	got := map[string]string{}
+	for _, err := range errs {
+		got[err.Field] = err.Message
+	}
+	want := map[string]string{
+		"item":       "is required",
+		"count":      "must be integer, got number",
+		"mode":       "must be one of [craft drop]",
+		"position.x": "is required",
+		"position.y": "is not a known argument",
+		"tags[1]":    "must be string, got integer",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for field, message := range want {
+		if got[field] != message {
+			t.Errorf("%s: expected %q, got %q", field, message, got[field])
+		}
+	}
+}
+
+func TestToolsCallRejectsGameToolArgumentsThatBreakTheSchema(t *testing.T) {
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{"factory": sleepingGameForTest("factory", "Factory")},
+	})
+	forwarded := 0
+	server.RegisterGameTool("factory", Tool{
+		Name: "factory.inventory.give",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"count": map[string]interface{}{"type": "integer", "minimum": 1}},
+			"required":   []interface{}{"count"},
+		},
+		Meta: map[string]interface{}{toolMetaGABPName: "inventory/give"},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		forwarded++
+		return &ToolResult{Content: []Content{{Type: "text", Text: "ok"}}}, nil
+	}, nil)
+
+	result := callToolForTest(t, server, "factory.inventory.give", map[string]interface{}{"count": 0})
+	errorDetail, _ := result.StructuredContent["error"].(map[string]interface{})
+	fields, _ := errorDetail["fields"].([]interface{})
+	if !result.IsError || errorDetail["kind"] != "invalid-args" || len(fields) != 1 || !strings.Contains(result.Content[0].Text, "count must be at least 1") {
+		t.Fatalf("expected a validation error naming count, got %#v", result)
+	}
+	if forwarded != 0 {
+		t.Fatal("expected invalid arguments to stay away from the game")
+	}
+
+	if result := callToolForTest(t, server, "factory.inventory.give", map[string]interface{}{"count": 2}); result.IsError || forwarded != 1 {
+		t.Fatalf("expected valid arguments to be forwarded, got %#v", result)
+	}
+
+	server.lenientArguments = true
+	if result := callToolForTest(t, server, "factory.inventory.give", map[string]interface{}{}); result.IsError || forwarded != 2 {
+		t.Fatalf("expected lenient mode to forward invalid arguments, got %#v", result)
+	}
+}
@@ -155,6 +155,49 @@ func TestUnmirroredDirectMCPToolCallUsesGABPFallback(t *testing.T) {
 	assertAsyncMirroringServerDone(t, serverDone)
 }
 
+func TestUnmirroredDirectMCPToolCallValidatesArguments(t *testing.T) {
+	server, port, bridgeToken, serverDone := newAsyncMirroringTestServer(t)
+
+	connector := newServerGABPConnector(server, 5*time.Millisecond, 10*time.Millisecond, false, 250*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := connector.AttemptConnection(ctx, "adventure", port, bridgeToken); err != nil {
+		t.Fatalf("expected async connector to connect: %v", err)
+	}
+
+	invalidText := marshalMessage(t, server.HandleMessage(&Message{
+		JSONRPC: "2.0",
+		Method:  "tools/call",
+		ID:      json.RawMessage(`"unmirrored-invalid"`),
+		Params: map[string]interface{}{
+			"name":      "adventure.corebridge.core.ping",
+			"arguments": map[string]interface{}{"count": "many"},
+		},
+	}))
+	if !strings.Contains(invalidText, `"isError":true`) || !strings.Contains(invalidText, "Invalid arguments for tool 'adventure.corebridge.core.ping'") {
+		t.Fatalf("expected a validation error, got: %s", invalidText)
+	}
+	select {
+	case err := <-serverDone:
+		t.Fatalf("expected the invalid call not to reach the bridge, got %v", err)
+	default:
+	}
+
+	callText := marshalMessage(t, server.HandleMessage(&Message{
+		JSONRPC: "2.0",
+		Method:  "tools/call",
+		ID:      json.RawMessage(`"unmirrored-valid"`),
+		Params: map[string]interface{}{
+			"name":      "adventure.corebridge.core.ping",
+			"arguments": map[string]interface{}{"count": 2},
+		},
+	}))
+
+	assertPongToolResult(t, callText)
+	assertAsyncMirroringServerDone(t, serverDone)
+}
+
 func TestUnmirroredStrictSafeDirectMCPToolCallUsesDescriptorAliasFallback(t *testing.T) {
 	server, port, bridgeToken, serverDone := newAsyncMirroringDescriptorAliasTestServer(t)
 
@@ -227,7 +270,7 @@ func newAsyncMirroringTestServer(t *testing.T) (*Server, int, string, <-chan err
 
 	bridgeToken := "async-mirroring-token"
 	serverDone := make(chan error, 1)
-	go serveTestGabpSessionExpectToolCallBeforeList(listener, bridgeToken, serverDone)
+	go serveTestGabpSessionWithPingTool(listener, bridgeToken, serverDone)
 
 	gamesConfig := &config.GamesConfig{
 		Games: map[string]config.GameConfig{
@@ -305,7 +348,7 @@ func assertAsyncMirroringServerDone(t *testing.T, serverDone <-chan error) {
 	}
 }
 
-func serveTestGabpSessionExpectToolCallBeforeList(listener net.Listener, expectedToken string, done chan<- error) {
+func serveTestGabpSessionWithPingTool(listener net.Listener, expectedToken string, done chan<- error) {
 	conn, err := listener.Accept()
 	if err != nil {
 		done <- err
@@ -359,8 +402,22 @@ func serveTestGabpSessionExpectToolCallBeforeList(listener net.Listener, expecte
 				return
 			}
 		case "tools/list":
-			done <- fmt.Errorf("tools/list arrived before first tool call")
-			return
+			response := util.NewGABPResponse(request.ID, map[string]interface{}{
+				"tools": []map[string]interface{}{
+					{
+						"name":        "corebridge/core/ping",
+						"description": "Ping bridge",
+						"inputSchema": map[string]interface{}{
+							"type":       "object",
+							"properties": map[string]interface{}{"count": map[string]interface{}{"type": "integer"}},
+						},
+					},
+				},
+			})
+			if err := writer.WriteJSON(response); err != nil {
+				done <- err
+				return
+			}
 		case "tools/call":
 			requestParams, ok := request.Params.(map[string]interface{})
 			if !ok {
@@ -152,6 +152,9 @@ func (s *Server) setupGABPAttention(gameID string, client *gabp.Client, timeout
 
 	s.setGameAttentionSupport(gameID, true)
 
+	// Attention events that arrive while the current item is being queried are
+	// buffered by the client and replayed once the subscription is in place.
+	queriedAt := time.Now()
 	if current, err := client.GetCurrentAttentionWithTimeout(timeout); err != nil {
 		s.log.Warnw("failed to query current attention state during setup", "gameId", gameID, "error", err)
 	} else {
@@ -163,9 +166,9 @@ func (s *Server) setupGABPAttention(gameID string, client *gabp.Client, timeout
 		return
 	}
 
-	if err := client.SubscribeEventsWithTimeout(channels, func(channel string, seq int, payload interface{}) {
+	if err := client.SubscribeEventsWithReplay(channels, func(channel string, seq int, payload interface{}) {
 		s.handleGABPAttentionEvent(gameID, channel, seq, payload)
-	}, timeout); err != nil {
+	}, queriedAt, timeout); err != nil {
 		s.log.Warnw("failed to subscribe to GABP attention events", "gameId", gameID, "error", err)
 	}
 }
@@ -219,7 +222,7 @@ func (s *Server) refreshCurrentAttention(gameID string, client *gabp.Client, tim
 
 	current, err := client.GetCurrentAttentionWithTimeout(timeout)
 	if err != nil {
-		if strings.Contains(err.Error(), "attention/current") || strings.Contains(err.Error(), "GABP error 32601") {
+		if strings.Contains(err.Error(), "attention/current") || gabp.ErrorCode(err) == -32601 {
 			s.setGameAttentionSupport(gameID, false)
 		}
 		return s.getCurrentBlockingAttention(gameID), err
@@ -266,7 +269,7 @@ func (s *Server) shouldBypassAttentionGateForRequest(gameID string, toolNames ..
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	for _, trackedToolName := range s.gameTools[gameID] {
+	for _, trackedToolName := range s.sessions[gameID].toolNames() {
 		handler, exists := s.tools[trackedToolName]
 		if !exists {
 			continue
@@ -363,7 +366,7 @@ func (s *Server) resolveAttentionClient(gamesConfig *config.GamesConfig, request
 		}
 
 		s.mu.RLock()
-		client, connected := s.gabpClients[game.ID]
+		client, connected := s.sessions[game.ID].gabpClient()
 		s.mu.RUnlock()
 		if !connected || !client.IsConnected() {
 			disconnectNote := s.describeLastGABPDisconnect(game.ID)
@@ -380,9 +383,9 @@ func (s *Server) resolveAttentionClient(gamesConfig *config.GamesConfig, request
 	}
 
 	s.mu.RLock()
-	connectedGameIDs := make([]string, 0, len(s.gabpClients))
-	for gameID, client := range s.gabpClients {
-		if client != nil && client.IsConnected() {
+	connectedGameIDs := make([]string, 0, len(s.sessions))
+	for gameID, session := range s.sessions {
+		if client, exists := session.gabpClient(); exists && client.IsConnected() {
 			connectedGameIDs = append(connectedGameIDs, gameID)
 		}
 	}
@@ -397,7 +400,7 @@ func (s *Server) resolveAttentionClient(gamesConfig *config.GamesConfig, request
 		}
 	case 1:
 		gameID := connectedGameIDs[0]
-		game, exists := gamesConfig.GetGame(gameID)
+		game, exists := s.lookupGame(gamesConfig, gameID)
 		if !exists {
 			return nil, nil, &ToolResult{
 				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' is connected via GABP but is not present in the loaded game configuration.", gameID)}},
@@ -406,7 +409,7 @@ func (s *Server) resolveAttentionClient(gamesConfig *config.GamesConfig, request
 		}
 
 		s.mu.RLock()
-		client := s.gabpClients[gameID]
+		client, _ := s.sessions[gameID].gabpClient()
 		s.mu.RUnlock()
 		return game, client, nil
 	default:
@@ -0,0 +1,74 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// handleBatch answers a JSON-RPC batch with an array holding one response per
+// request in it, in the order of the batch. Notifications get no entry, and a
+// batch of only notifications gets no answer at all.
+//
+// Entries are decoded and checked in order, so an initialize early in the
+// batch counts for the entries after it. Tool calls then run concurrently,
+// while every other entry runs in order on the caller's goroutine, since
+// subscriptions and cancellations must see the state the entries before them
+// left behind.
+func (s *Server) handleBatch(data []byte, session *mcpSession, role string) (interface{}, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		if !s.strictMCPEnabled() {
+			return nil, err
+		}
+		return NewError(nullID, jsonRPCParseError, "Parse error", err.Error()), nil
+	}
+	if len(items) == 0 {
+		return NewError(nullID, jsonRPCInvalidRequest, "Invalid Request", "batch must not be empty"), nil
+	}
+
+	responses := make([]*Message, len(items))
+	var calls sync.WaitGroup
+	for i, item := range items {
+		if trimmed := bytes.TrimSpace(item); len(trimmed) == 0 || trimmed[0] != '{' {
+			responses[i] = NewError(nullID, jsonRPCInvalidRequest, "Invalid Request", "batch entries must be JSON objects")
+			continue
+		}
+		msg, response, err := s.decodeMessage(item, session)
+		if err != nil {
+			responses[i] = NewError(nullID, jsonRPCInvalidRequest, "Invalid Request", err.Error())
+			continue
+		}
+		if msg == nil {
+			responses[i] = response
+			continue
+		}
+		if msg.Method == "tools/call" && msg.ID != nil {
+			calls.Add(1)
+			go func(i int, msg *Message) {
+				defer calls.Done()
+				responses[i] = s.dispatchMessage(msg, session, role)
+			}(i, msg)
+			continue
+		}
+		responses[i] = s.dispatchMessage(msg, session, role)
+	}
+	calls.Wait()
+
+	answered := make([]*Message, 0, len(responses))
+	for _, response := range responses {
+		if response != nil {
+			answered = append(answered, response)
+		}
+	}
+	if len(answered) == 0 {
+		return nil, nil
+	}
+	return answered, nil
+}
+
+// isBatch reports whether raw is a JSON-RPC batch.
+func isBatch(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestBatchRunsToolCallsConcurrently(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+
+	// Each call waits until both have started, so the batch only finishes if
+	// its tool calls run at the same time.
+	var started sync.WaitGroup
+	started.Add(2)
+	server.RegisterTool(Tool{Name: "test.rendezvous", InputSchema: map[string]interface{}{"type": "object"}}, func(args map[string]interface{}) (*ToolResult, error) {
+		started.Done()
+		started.Wait()
+		return &ToolResult{Content: []Content{{Type: "text", Text: "met"}}}, nil
+	})
+
+	done := make(chan []interface{}, 1)
+	go func() {
+		done <- serveLinesForTest(t, server,
+			`[{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test.rendezvous"}},{"jsonrpc":"2.0","method":"notifications/initialized"},{"jsonrpc":"2.0","id":2,"method":"tools/list"},{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"test.rendezvous"}}]`,
+		)
+	}()
+
+	var responses []interface{}
+	select {
+	case responses = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the tool calls in the batch to run concurrently")
+	}
+	if len(responses) != 1 {
+		t.Fatalf("expected one batch response, got %#v", responses)
+	}
+	batch, ok := responses[0].([]interface{})
+	if !ok || len(batch) != 3 {
+		t.Fatalf("expected a response per request and none for the notification, got %#v", responses[0])
+	}
+	for i, wantID := range []float64{1, 2, 3} {
+		entry := batch[i].(map[string]interface{})
+		if entry["id"] != wantID || entry["error"] != nil {
+			t.Errorf("expected batch entry %d to answer request %v, got %#v", i, wantID, entry)
+		}
+	}
+}
+
+func TestBatchOverHTTP(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		server.handleMCPHTTPRequest(recorder, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body)))
+		return recorder
+	}
+
+	recorder := post(`[{"jsonrpc":"2.0","id":"a","method":"tools/list"},{"jsonrpc":"2.0","id":"b","method":"no/such/method"}]`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200 for a batch, got %d %s", recorder.Code, recorder.Body.String())
+	}
+	var batch []Message
+	if err := json.Unmarshal(recorder.Body.Bytes(), &batch); err != nil {
+		t.Fatalf("expected a JSON array, got %s: %v", recorder.Body.String(), err)
+	}
+	if len(batch) != 2 || batch[0].ID != "a" || batch[0].Error != nil || batch[1].ID != "b" || batch[1].Error == nil {
+		t.Fatalf("expected tools/list to succeed and the unknown method to fail, got %s", recorder.Body.String())
+	}
+
+	if recorder = post(`[{"jsonrpc":"2.0","method":"notifications/initialized"}]`); recorder.Code != http.StatusNoContent {
+		t.Fatalf("expected no content for a batch of notifications, got %d %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+// bridgeResourceTimeout bounds the resources/list and resources/read requests
+// GABS sends to a bridge.
+const bridgeResourceTimeout = 10 * time.Second
+
+// bridgeResourceURI names a bridge resource in MCP: gab://<gameId>/ followed
+// by the bridge's URI without its scheme, such as gab://factory/world/save_data
+// for "world/save_data". It is empty when nothing is left of the URI.
+func bridgeResourceURI(gameID, uri string) string {
+	if _, rest, hasScheme := strings.Cut(uri, "://"); hasScheme {
+		uri = rest
+	}
+	path := strings.Trim(uri, "/")
+	if path == "" {
+		return ""
+	}
+	return fmt.Sprintf("gab://%s/%s", gameID, path)
+}
+
+// exposeBridgeResources mirrors the resources the bridge declares as
+// gab://<gameId>/<path> MCP resources whose reads pass through to the bridge.
+// Paths GABS serves itself, such as state and logs, are skipped. It returns
+// the mirrored paths.
+func (s *Server) exposeBridgeResources(client *gabp.Client, gameID string) []string {
+	if !gabp.SupportsResources(client.GetCapabilities()) {
+		return nil
+	}
+	descriptors, err := client.ListResources(bridgeResourceTimeout)
+	if err != nil {
+		s.gabpLog.Warnw("failed to list bridge resources", "gameId", gameID, "error", err)
+		return nil
+	}
+
+	reserved := map[string]bool{
+		gameStateURI(gameID):   true,
+		gameEventsURI(gameID):  true,
+		gameLogsURI(gameID):    true,
+		gameMetricsURI(gameID): true,
+		gameAlertsURI(gameID):  true,
+		gameChatURI(gameID):    true,
+	}
+	var exposed []string
+	for _, descriptor := range descriptors {
+		uri := bridgeResourceURI(gameID, descriptor.URI)
+		if uri == "" || reserved[uri] {
+			s.gabpLog.Debugw("skipped bridge resource", "gameId", gameID, "uri", descriptor.URI)
+			continue
+		}
+		name := descriptor.Name
+		if name == "" {
+			name = fmt.Sprintf("%s %s", gameID, descriptor.URI)
+		}
+		description := descriptor.Description
+		if description == "" {
+			description = fmt.Sprintf("Resource %s of game %s, read from its GABP bridge", descriptor.URI, gameID)
+		}
+
+		bridgeURI, mimeType := descriptor.URI, descriptor.MimeType
+		s.RegisterGameResource(gameID, Resource{
+			URI:         uri,
+			Name:        name,
+			Description: description,
+			MimeType:    mimeType,
+		}, func() ([]Content, error) {
+			contents, err := client.ReadResource(bridgeURI, bridgeResourceTimeout)
+			if err != nil {
+				return nil, fmt.Errorf("game %s could not read %s: %w", gameID, bridgeURI, err)
+			}
+			return bridgeResourceContents(uri, mimeType, contents), nil
+		})
+		exposed = append(exposed, strings.TrimPrefix(uri, fmt.Sprintf("gab://%s/", gameID)))
+	}
+	return exposed
+}
+
+// bridgeResourceContents converts what the bridge read into MCP resource
+// contents under the mirrored uri. Parts without a MIME type use the one the
+// resource was listed with.
+func bridgeResourceContents(uri, mimeType string, contents []gabp.ResourceContent) []Content {
+	converted := make([]Content, 0, len(contents))
+	for _, content := range contents {
+		item := Content{Type: "text", URI: uri, MimeType: content.MimeType, Text: content.Text}
+		if item.MimeType == "" {
+			item.MimeType = mimeType
+		}
+		if content.Blob != "" {
+			item.Type = "blob"
+			item.Blob = content.Blob
+		}
+		converted = append(converted, item)
+	}
+	return converted
+}
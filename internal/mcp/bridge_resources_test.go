@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// serveTestGabpResourceSession answers the handshake with resources declared
+// in the welcome, an empty tools/list and resources/read for save_data.
+func serveTestGabpResourceSession(listener net.Listener, token string, done chan<- error) {
+	conn, err := listener.Accept()
+	if err != nil {
+		done <- err
+		return
+	}
+	defer conn.Close()
+
+	reader := util.NewLSPFrameReader(conn)
+	writer := util.NewLSPFrameWriter(conn)
+	for {
+		data, err := reader.ReadMessage()
+		if err != nil {
+			done <- nil
+			return
+		}
+		var request util.GABPMessage
+		if err := json.Unmarshal(data, &request); err != nil {
+			done <- err
+			return
+		}
+		params, _ := request.Params.(map[string]interface{})
+
+		switch request.Method {
+		case "session/hello":
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, gabp.SessionWelcomeResult{
+				AgentID: "adventure",
+				Capabilities: gabp.Capabilities{
+					Methods:   []string{"tools/list", gabp.ResourcesReadMethod},
+					Resources: []string{"gabp://world/save_data", "state"},
+				},
+				SchemaVersion: "1.0",
+			}))
+		case "tools/list":
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"tools": []interface{}{}}))
+		case gabp.ResourcesReadMethod:
+			if uri, _ := params["uri"].(string); uri != "gabp://world/save_data" {
+				err = writer.WriteJSON(util.NewGABPError(request.ID, -32602, "unknown resource", nil))
+				break
+			}
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{
+				"contents": []map[string]interface{}{{"uri": "gabp://world/save_data", "mimeType": "application/json", "text": `{"seed":42}`}},
+			}))
+		default:
+			err = fmt.Errorf("unexpected method: %s", request.Method)
+		}
+		if err != nil {
+			done <- err
+			return
+		}
+	}
+}
+
+func TestBridgeResourceURIDropsSchemeAndSlashes(t *testing.T) {
+	cases := map[string]string{
+		"world/save_data":        "gab://factory/world/save_data",
+		"gabp://world/save_data": "gab://factory/world/save_data",
+		"/config/settings/":      "gab://factory/config/settings",
+		"gabp://":                "",
+	}
+	for uri, want := range cases {
+		if got := bridgeResourceURI("factory", uri); got != want {
+			t.Errorf("bridgeResourceURI(%q) = %q, want %q", uri, got, want)
+		}
+	}
+}
+
+func TestDeclaredBridgeResourcesAreMirroredWithPassThroughReads(t *testing.T) {
+	server, configDir := newGamesTestServer(t, roamingGamesConfig())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	writeBridgeJSONForTest(t, configDir, "adventure", listener.Addr().(*net.TCPAddr).Port, "launch-token")
+
+	session := make(chan error, 1)
+	go serveTestGabpResourceSession(listener, "launch-token", session)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_connect failed: %#v", result)
+	}
+
+	server.mu.RLock()
+	mirrored, exists := server.resources["gab://adventure/world/save_data"]
+	state := server.resources[gameStateURI("adventure")]
+	server.mu.RUnlock()
+	if !exists {
+		t.Fatal("expected the declared resource to be mirrored")
+	}
+	if state == nil || state.Resource.Name != "adventure Game State" {
+		t.Fatalf("expected the bridge's state resource not to replace GABS' own, got %#v", state)
+	}
+
+	response := server.HandleMessage(&Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "resources/read", Params: map[string]interface{}{"uri": mirrored.Resource.URI}})
+	var read struct {
+		Contents []Content `json:"contents"`
+	}
+	if response.Error != nil || decodeResult(response.Result, &read) != nil || len(read.Contents) != 1 {
+		t.Fatalf("unexpected read of the mirrored resource: %#v", response)
+	}
+	if content := read.Contents[0]; content.Text != `{"seed":42}` || content.MimeType != "application/json" || content.URI != "gab://adventure/world/save_data" {
+		t.Fatalf("expected the bridge's content under the mirrored URI, got %#v", content)
+	}
+
+	server.CleanupGABPConnection("adventure")
+	if err := <-session; err != nil {
+		t.Fatalf("GABP session failed: %v", err)
+	}
+}
@@ -0,0 +1,257 @@
+package mcp
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// budgetUsageKey names the calls counted against one budget. Session budgets
+// count each client session on its own, so their key ends with the session
+// ID; calls without a session share the key ending in an empty ID.
+func budgetUsageKey(gameID string, index int, budget config.ToolBudgetConfig, sessionID string) string {
+	key := gameID + "\x00" + strconv.Itoa(index) + "\x00" + budget.Label()
+	if window, _ := budget.WindowDuration(); window <= 0 {
+		key += "\x00" + sessionID
+	}
+	return key
+}
+
+// forgetBudgetUsage drops the session budget counts of the session id once
+// it ended.
+func (s *Server) forgetBudgetUsage(id string) {
+	if id == "" {
+		return
+	}
+	s.budgetMu.Lock()
+	defer s.budgetMu.Unlock()
+	for key := range s.budgetUsage {
+		if strings.HasSuffix(key, "\x00"+id) {
+			delete(s.budgetUsage, key)
+		}
+	}
+}
+
+// pruneBudgetCalls drops calls that fell out of a sliding window. Session
+// budgets (window 0) keep every call.
+func pruneBudgetCalls(calls []time.Time, now time.Time, window time.Duration) []time.Time {
+	if window <= 0 {
+		return calls
+	}
+	cutoff := now.Add(-window)
+	kept := calls[:0]
+	for _, at := range calls {
+		if at.After(cutoff) {
+			kept = append(kept, at)
+		}
+	}
+	return kept
+}
+
+// gabpToolTags returns the GABP tags of a mirrored tool, looked up by its GABP
+// name, so direct calls are budgeted like calls through games_call_tool.
+func (s *Server) gabpToolTags(gameID, gabpToolName string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, trackedToolName := range s.sessions[gameID].toolNames() {
+		handler, exists := s.tools[trackedToolName]
+		if !exists {
+			continue
+		}
+		if canonicalGABPToolName(gabpToolNameFromTool(gameID, handler.Tool)) == canonicalGABPToolName(gabpToolName) {
+			return toolMetaStringSlice(handler.Tool, toolMetaTags)
+		}
+	}
+	return nil
+}
+
+// enforceToolBudget counts a mirrored tool call of session against the
+// game's budgets. It returns an error result without counting anything when
+// one of them is used up.
+func (s *Server) enforceToolBudget(session *mcpSession, gameID, gabpToolName string, tags []string) *ToolResult {
+	s.mu.RLock()
+	gamesConfig := s.gamesConfig
+	now := s.clock.Now()
+	s.mu.RUnlock()
+	if gamesConfig == nil {
+		return nil
+	}
+	game, exists := s.lookupGame(gamesConfig, gameID)
+	if !exists || len(game.Budgets) == 0 {
+		return nil
+	}
+	sessionID := session.sessionID()
+
+	s.budgetMu.Lock()
+	defer s.budgetMu.Unlock()
+	if s.budgetUsage == nil {
+		s.budgetUsage = make(map[string][]time.Time)
+	}
+
+	var matched []string
+	for i, budget := range game.Budgets {
+		if !budget.Matches(gabpToolName, tags) {
+			continue
+		}
+		window, _ := budget.WindowDuration()
+		key := budgetUsageKey(gameID, i, budget, sessionID)
+		calls := pruneBudgetCalls(s.budgetUsage[key], now, window)
+		s.budgetUsage[key] = calls
+		if len(calls) >= budget.MaxCalls {
+			s.log.Warnw("tool budget exceeded", "gameId", gameID, "tool", gabpToolName, "budget", budget.Label())
+			return budgetExceededResult(gameID, gabpToolName, budget, calls, now, window)
+		}
+		matched = append(matched, key)
+	}
+	for _, key := range matched {
+		s.budgetUsage[key] = append(s.budgetUsage[key], now)
+	}
+	return nil
+}
+
+func budgetExceededResult(gameID, toolName string, budget config.ToolBudgetConfig, calls []time.Time, now time.Time, window time.Duration) *ToolResult {
+	text := fmt.Sprintf("Budget '%s' for game '%s' is used up (%d of %d calls), so tool '%s' was not called.", budget.Label(), gameID, len(calls), budget.MaxCalls, toolName)
+	structured := map[string]interface{}{
+		"gameId":   gameID,
+		"tool":     toolName,
+		"budget":   budget.Label(),
+		"maxCalls": budget.MaxCalls,
+		"used":     len(calls),
+		"window":   budgetWindowName(budget),
+		"nextActions": []map[string]interface{}{
+			mcpNextAction("system_budgets", map[string]interface{}{"gameId": gameID}, "Check the remaining quota before retrying."),
+		},
+	}
+	if window > 0 && len(calls) > 0 {
+		retryAfter := calls[0].Add(window).Sub(now)
+		structured["retryAfterSeconds"] = int(math.Ceil(retryAfter.Seconds()))
+		text += fmt.Sprintf(" The next call is allowed in %s.", retryAfter.Round(time.Second))
+	} else {
+		text += " Session budgets reset when the client starts a new MCP session."
+	}
+	return &ToolResult{
+		Content:           []Content{{Type: "text", Text: text}},
+		StructuredContent: structured,
+		IsError:           true,
+	}
+}
+
+func budgetWindowName(budget config.ToolBudgetConfig) string {
+	if budget.Window == "" {
+		return config.BudgetWindowSession
+	}
+	return budget.Window
+}
+
+// budgetStatus reports the usage of each budget configured for game, with
+// session budgets as seen by session.
+func (s *Server) budgetStatus(game config.GameConfig, session *mcpSession) []map[string]interface{} {
+	sessionID := session.sessionID()
+	s.mu.RLock()
+	now := s.clock.Now()
+	s.mu.RUnlock()
+
+	s.budgetMu.Lock()
+	defer s.budgetMu.Unlock()
+
+	items := make([]map[string]interface{}, 0, len(game.Budgets))
+	for i, budget := range game.Budgets {
+		window, _ := budget.WindowDuration()
+		key := budgetUsageKey(game.ID, i, budget, sessionID)
+		calls := pruneBudgetCalls(s.budgetUsage[key], now, window)
+		if s.budgetUsage != nil {
+			s.budgetUsage[key] = calls
+		}
+
+		item := map[string]interface{}{
+			"budget":    budget.Label(),
+			"maxCalls":  budget.MaxCalls,
+			"used":      len(calls),
+			"remaining": budget.MaxCalls - len(calls),
+			"window":    budgetWindowName(budget),
+		}
+		if len(budget.Tools) > 0 {
+			item["tools"] = budget.Tools
+		}
+		if len(budget.Tags) > 0 {
+			item["tags"] = budget.Tags
+		}
+		if window > 0 && len(calls) > 0 {
+			item["nextReleaseSeconds"] = int(math.Ceil(calls[0].Add(window).Sub(now).Seconds()))
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (s *Server) registerBudgetTool(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	s.registerCallTool(Tool{
+		Name:        "system.budgets",
+		Description: "Show the mirrored tool call budgets configured for games and how much of each is left",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": map[string]interface{}{
+					"type":        "string",
+					"description": "Game ID to inspect (optional, defaults to every game with budgets)",
+				},
+			},
+		},
+	}, func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
+		gameID, hasGameID, invalidArg := parseOptionalStringArg(args, "gameId")
+		if invalidArg != nil {
+			return invalidArg, nil
+		}
+
+		var games []config.GameConfig
+		if hasGameID {
+			game, exists := s.resolveGameId(gamesConfig, gameID)
+			if !exists {
+				return &ToolResult{
+					Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' not found. Use games_list to see available games.", gameID)}},
+					IsError: true,
+				}, nil
+			}
+			games = append(games, *game)
+		} else {
+			for _, game := range gamesConfig.ListGames() {
+				if len(game.Budgets) > 0 {
+					games = append(games, game)
+				}
+			}
+		}
+
+		var text strings.Builder
+		items := make([]map[string]interface{}, 0, len(games))
+		for _, game := range games {
+			budgets := s.budgetStatus(game, call.clientSession())
+			items = append(items, map[string]interface{}{
+				"gameId":  game.ID,
+				"budgets": budgets,
+			})
+			if text.Len() > 0 {
+				text.WriteString("\n")
+			}
+			if len(budgets) == 0 {
+				text.WriteString(fmt.Sprintf("%s: no budgets configured", game.ID))
+				continue
+			}
+			text.WriteString(fmt.Sprintf("%s:", game.ID))
+			for _, budget := range budgets {
+				text.WriteString(fmt.Sprintf("\n  - %s: %d of %d left", budget["budget"], budget["remaining"], budget["maxCalls"]))
+			}
+		}
+		if len(games) == 0 {
+			text.WriteString("No game has tool budgets configured.")
+		}
+
+		return &ToolResult{
+			Content:           []Content{{Type: "text", Text: text.String()}},
+			StructuredContent: map[string]interface{}{"games": items},
+		}, nil
+	}, normalizationConfig)
+}
@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func newBudgetTestServer(t *testing.T) (*Server, *util.FakeClock) {
+	t.Helper()
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"factory": {
+				ID:         "factory",
+				Name:       "Factory",
+				LaunchMode: "DirectPath",
+				Target:     "/opt/factory/start.sh",
+				Budgets: []config.ToolBudgetConfig{
+					{Name: "hourly", MaxCalls: 3, Window: "1h"},
+					{Name: "world edits", Tags: []string{"world-modifying"}, MaxCalls: 1},
+				},
+			},
+		},
+	})
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	server.SetClock(clock)
+	return server, clock
+}
+
+func TestToolBudgetRejectsCallsOnceUsedUp(t *testing.T) {
+	server, clock := newBudgetTestServer(t)
+
+	if exceeded := server.enforceToolBudget(nil, "factory", "world.spawn", []string{"world-modifying"}); exceeded != nil {
+		t.Fatalf("first world edit should pass, got %#v", exceeded)
+	}
+	exceeded := server.enforceToolBudget(nil, "factory", "world.despawn", []string{"world-modifying"})
+	if exceeded == nil || !exceeded.IsError || exceeded.StructuredContent["budget"] != "world edits" {
+		t.Fatalf("expected the session budget to stop a second world edit, got %#v", exceeded)
+	}
+	if !strings.Contains(exceeded.Content[0].Text, "reset when the client starts a new MCP session") {
+		t.Fatalf("expected session reset hint, got %q", exceeded.Content[0].Text)
+	}
+
+	// The rejected call must not count against the hourly budget.
+	for i := 0; i < 2; i++ {
+		if exceeded := server.enforceToolBudget(nil, "factory", "inventory.get", nil); exceeded != nil {
+			t.Fatalf("call %d should fit in the hourly budget, got %#v", i, exceeded)
+		}
+	}
+	exceeded = server.enforceToolBudget(nil, "factory", "inventory.get", nil)
+	if exceeded == nil || exceeded.StructuredContent["budget"] != "hourly" || exceeded.StructuredContent["retryAfterSeconds"] != 3600 {
+		t.Fatalf("expected the hourly budget to be used up, got %#v", exceeded)
+	}
+
+	clock.Advance(time.Hour)
+	if exceeded := server.enforceToolBudget(nil, "factory", "inventory.get", nil); exceeded != nil {
+		t.Fatalf("expected the hourly window to slide, got %#v", exceeded)
+	}
+	if exceeded := server.enforceToolBudget(nil, "other", "inventory.get", nil); exceeded != nil {
+		t.Fatalf("games without budgets must not be limited, got %#v", exceeded)
+	}
+}
+
+func TestSystemBudgetsReportsRemainingQuota(t *testing.T) {
+	server, _ := newBudgetTestServer(t)
+	server.enforceToolBudget(nil, "factory", "world.spawn", []string{"world-modifying"})
+
+	result := callToolForTest(t, server, "system_budgets", map[string]interface{}{"gameId": "factory"})
+	if result.IsError {
+		t.Fatalf("system_budgets failed: %#v", result)
+	}
+	games := result.StructuredContent["games"].([]interface{})
+	budgets := games[0].(map[string]interface{})["budgets"].([]interface{})
+	hourly := budgets[0].(map[string]interface{})
+	edits := budgets[1].(map[string]interface{})
+	if hourly["remaining"] != float64(2) || hourly["nextReleaseSeconds"] != float64(3600) {
+		t.Fatalf("unexpected hourly budget status: %#v", hourly)
+	}
+	if edits["remaining"] != float64(0) || edits["window"] != config.BudgetWindowSession {
+		t.Fatalf("unexpected world edit budget status: %#v", edits)
+	}
+	if !strings.Contains(result.Content[0].Text, "world edits: 0 of 1 left") {
+		t.Fatalf("expected a readable summary, got %q", result.Content[0].Text)
+	}
+}
+
+func TestSessionBudgetsCountEachClientSession(t *testing.T) {
+	server, _ := newBudgetTestServer(t)
+	first := &mcpSession{info: sessionInfo{ID: "first"}}
+	second := &mcpSession{info: sessionInfo{ID: "second"}}
+	game, _ := server.gamesConfig.GetGame("factory")
+
+	if exceeded := server.enforceToolBudget(first, "factory", "world.spawn", []string{"world-modifying"}); exceeded != nil {
+		t.Fatalf("first session's world edit should pass, got %#v", exceeded)
+	}
+	if exceeded := server.enforceToolBudget(first, "factory", "world.spawn", []string{"world-modifying"}); exceeded == nil || exceeded.StructuredContent["budget"] != "world edits" {
+		t.Fatalf("expected the first session's world edits to be used up, got %#v", exceeded)
+	}
+	if exceeded := server.enforceToolBudget(second, "factory", "world.spawn", []string{"world-modifying"}); exceeded != nil {
+		t.Fatalf("a second session should get its own world edit, got %#v", exceeded)
+	}
+	// The hourly budget is shared, so it counts both sessions' accepted calls.
+	if status := server.budgetStatus(*game, second); status[0]["used"] != 2 || status[1]["used"] != 1 {
+		t.Fatalf("unexpected budget status for the second session: %#v", status)
+	}
+
+	server.closeSession(first)
+	if status := server.budgetStatus(*game, first); status[1]["used"] != 0 {
+		t.Fatalf("expected the ended session's count to be dropped, got %#v", status)
+	}
+	if status := server.budgetStatus(*game, second); status[1]["used"] != 1 {
+		t.Fatalf("expected the second session's count to stay, got %#v", status)
+	}
+}
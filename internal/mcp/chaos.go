@@ -0,0 +1,157 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/pardeike/gabs/internal/chaos"
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+var errChaosDisconnect = errors.New("chaos: injected GABP disconnect")
+
+// EnableChaos turns on developer failure injection. It must be called before
+// the server starts serving; RunChaos drives the timed failures. The returned
+// function removes the process-wide port allocation hook again.
+func (s *Server) EnableChaos(injector *chaos.Injector) func() {
+	s.mu.Lock()
+	s.chaos = injector
+	s.mu.Unlock()
+	restorePortFault := config.SetPortAllocationFault(injector.PortAllocationFault)
+	s.log.Warnw("chaos mode enabled: failures will be injected on purpose", "config", fmt.Sprintf("%+v", injector.Config()))
+	return restorePortFault
+}
+
+// newGABPClient creates a GABP client that is closed when the game stops,
+// with the configured request timeout, the session recorder if any and, when
+// chaos mode is on, injected tool latency.
+func (s *Server) newGABPClient(gameID string) *gabp.Client {
+	client := gabp.NewClientWithContext(s.gameContext(gameID), util.WithFields(s.gabpLog, "gameId", gameID))
+	client.SetDialObserver(s.metrics.observeGABPDial(gameID))
+	client.SetEventDropObserver(s.metrics.observeGABPEventDrop(gameID))
+	s.mu.RLock()
+	injector := s.chaos
+	gamesConfig := s.gamesConfig
+	client.SetRequestTimeout(s.bridgeTimeout)
+	client.SetMaxMessageSize(s.maxMessageSize)
+	if s.gabpRecorder != nil {
+		client.SetRecorder(s.gabpRecorder, gameID)
+	}
+	s.mu.RUnlock()
+	if injector.Config().MaxToolDelay > 0 {
+		client.SetCallDelay(injector.ToolDelay)
+	}
+	if gamesConfig != nil {
+		if game, exists := s.lookupGame(gamesConfig, gameID); exists && game.EventQueue != nil {
+			client.SetEventQueue(game.EventQueue.Size, game.EventQueue.Coalesces)
+		}
+	}
+	return client
+}
+
+// RunChaos drops GABP connections and kills games at the configured intervals
+// until ctx is cancelled. It returns immediately when chaos mode is off.
+func (s *Server) RunChaos(ctx context.Context) {
+	s.mu.RLock()
+	injector := s.chaos
+	s.mu.RUnlock()
+
+	// Check the configuration rather than calling NextDisconnect/NextKill, which
+	// would consume random draws and shift a seeded run.
+	cfg := injector.Config()
+	done := make(chan struct{}, 2)
+	loops := 0
+	if cfg.DisconnectInterval > 0 {
+		loops++
+		go s.chaosLoop(ctx, injector.NextDisconnect, s.chaosDisconnectRandomGABP, done)
+	}
+	if cfg.KillInterval > 0 {
+		loops++
+		go s.chaosLoop(ctx, injector.NextKill, s.chaosKillRandomGame, done)
+	}
+	for i := 0; i < loops; i++ {
+		<-done
+	}
+}
+
+func (s *Server) chaosLoop(ctx context.Context, next func() time.Duration, inject func() string, done chan<- struct{}) {
+	defer func() { done <- struct{}{} }()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(next()):
+			inject()
+		}
+	}
+}
+
+// chaosDisconnectRandomGABP drops one live GABP connection as if the bridge
+// had gone away and returns the affected game ID, or "" if none was connected.
+func (s *Server) chaosDisconnectRandomGABP() string {
+	s.mu.RLock()
+	injector := s.chaos
+	gameIDs := make([]string, 0, len(s.sessions))
+	for gameID, session := range s.sessions {
+		if client, exists := session.gabpClient(); exists && client.IsConnected() {
+			gameIDs = append(gameIDs, gameID)
+		}
+	}
+	sort.Strings(gameIDs)
+	var client *gabp.Client
+	gameID := ""
+	if index := injector.Pick(len(gameIDs)); index >= 0 {
+		gameID = gameIDs[index]
+		client, _ = s.sessions[gameID].gabpClient()
+	}
+	s.mu.RUnlock()
+
+	if client == nil {
+		return ""
+	}
+	s.log.Warnw("chaos: dropping GABP connection", "gameId", gameID)
+	client.SimulateDisconnect(errChaosDisconnect)
+	return gameID
+}
+
+// chaosKillRandomGame kills the process of one running game behind the
+// controller's back, like a crash, and returns the affected game ID.
+func (s *Server) chaosKillRandomGame() string {
+	s.mu.RLock()
+	injector := s.chaos
+	gameIDs := make([]string, 0, len(s.sessions))
+	pids := make(map[string]int)
+	for gameID, session := range s.sessions {
+		controller, tracked := session.process()
+		if !tracked {
+			continue
+		}
+		if pid := controller.GetPID(); pid > 0 && controller.IsRunning() {
+			gameIDs = append(gameIDs, gameID)
+			pids[gameID] = pid
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Strings(gameIDs)
+	index := injector.Pick(len(gameIDs))
+	if index < 0 {
+		return ""
+	}
+	gameID := gameIDs[index]
+	s.log.Warnw("chaos: killing game process", "gameId", gameID, "pid", pids[gameID])
+	proc, err := os.FindProcess(pids[gameID])
+	if err == nil {
+		err = proc.Kill()
+	}
+	if err != nil {
+		s.log.Warnw("chaos: failed to kill game process", "gameId", gameID, "pid", pids[gameID], "error", err)
+	}
+	return gameID
+}
@@ -0,0 +1,127 @@
+package mcp
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/chaos"
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+)
+
+func newChaosTestServer(t *testing.T, cfg chaos.Config) (*Server, string) {
+	t.Helper()
+	server, configDir := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"adventure": sleepingGameForTest("adventure", "AdventureGame"),
+		},
+	})
+	t.Cleanup(server.EnableChaos(chaos.NewInjector(cfg)))
+	return server, configDir
+}
+
+func TestChaosDisconnectIsRecordedAndGABSReconnects(t *testing.T) {
+	server, configDir := newChaosTestServer(t, chaos.Config{Seed: 1, DisconnectInterval: time.Hour})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	writeBridgeJSONForTest(t, configDir, "adventure", listener.Addr().(*net.TCPAddr).Port, "chaos-token")
+
+	firstSession := make(chan error, 1)
+	go serveTestGabpSession(listener, "chaos-token", firstSession)
+	result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"})
+	if result.IsError {
+		t.Fatalf("initial connect failed: %#v", result)
+	}
+	waitForGameStatus(t, server, "adventure", "connected")
+
+	if dropped := server.chaosDisconnectRandomGABP(); dropped != "adventure" {
+		t.Fatalf("expected chaos to drop adventure, dropped %q", dropped)
+	}
+	waitForGameStatus(t, server, "adventure", "disconnected")
+	if note := server.describeLastGABPDisconnect("adventure"); !strings.Contains(note, "chaos: injected GABP disconnect") {
+		t.Fatalf("expected injected disconnect to be recorded, got %q", note)
+	}
+	if tools := server.getGameSpecificTools("adventure"); len(tools) != 0 {
+		t.Fatalf("expected mirrored tools to be cleaned up after disconnect, got %d", len(tools))
+	}
+	if err := <-firstSession; err != nil {
+		t.Fatalf("first GABP session failed: %v", err)
+	}
+
+	// GABS keeps dialing the bridge endpoint on its own and picks the bridge
+	// up again once it accepts.
+	secondSession := make(chan error, 1)
+	go serveTestGabpSession(listener, "chaos-token", secondSession)
+	waitForGameStatus(t, server, "adventure", "connected")
+	deadline := time.Now().Add(2 * time.Second)
+	for len(server.getGameSpecificTools("adventure")) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected tools to be mirrored again after reconnect")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if note := server.describeLastGABPDisconnect("adventure"); note != "" {
+		t.Fatalf("expected the disconnect record to be cleared after reconnect, got %q", note)
+	}
+
+	server.chaosDisconnectRandomGABP()
+	if err := <-secondSession; err != nil {
+		t.Fatalf("second GABP session failed: %v", err)
+	}
+}
+
+func TestChaosPortFailureLeavesNoHalfStartedGame(t *testing.T) {
+	server, configDir := newChaosTestServer(t, chaos.Config{Seed: 1, PortFailureRate: 1})
+
+	result := callToolForTest(t, server, "games_start", map[string]interface{}{"gameId": "adventure"})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "injected port allocation failure") {
+		t.Fatalf("expected injected port failure, got %#v", result)
+	}
+
+	server.mu.RLock()
+	_, tracked := server.sessions["adventure"].process()
+	server.mu.RUnlock()
+	if tracked {
+		t.Fatal("failed start should not leave a tracked controller")
+	}
+	if state, err := process.LoadRuntimeState("adventure", configDir); err != nil || state != nil {
+		t.Fatalf("failed start should not leave runtime state, got %+v %v", state, err)
+	}
+	if status := server.checkGameStatus("adventure"); status != "stopped" {
+		t.Fatalf("expected stopped status after failed start, got %q", status)
+	}
+}
+
+func TestChaosKillIsObservedAsStoppedGame(t *testing.T) {
+	requireSleepForTest(t)
+
+	server, _ := newChaosTestServer(t, chaos.Config{Seed: 1, KillInterval: time.Hour})
+
+	controller := process.NewController()
+	if err := controller.Configure(process.LaunchSpec{GameId: "adventure", Mode: "DirectPath", PathOrId: "/bin/sleep", Args: []string{"30"}}); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	if err := controller.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer controller.Kill()
+
+	server.mu.Lock()
+	server.trackGameLocked("adventure", controller)
+	server.mu.Unlock()
+	waitForGameStatus(t, server, "adventure", "running")
+
+	if killed := server.chaosKillRandomGame(); killed != "adventure" {
+		t.Fatalf("expected chaos to kill adventure, killed %q", killed)
+	}
+	waitForGameStatus(t, server, "adventure", "stopped")
+	if server.chaosKillRandomGame() != "" {
+		t.Fatal("no running game should be left to kill")
+	}
+}
@@ -61,11 +61,11 @@ func TestGameResourceCleanup(t *testing.T) {
 	if len(server.resources) != 2 {
 		t.Errorf("Expected 2 resources, got %d", len(server.resources))
 	}
-	if len(server.gameTools[gameId]) != 2 {
-		t.Errorf("Expected 2 game tools tracked, got %d", len(server.gameTools[gameId]))
+	if len(server.sessions[gameId].toolNames()) != 2 {
+		t.Errorf("Expected 2 game tools tracked, got %d", len(server.sessions[gameId].toolNames()))
 	}
-	if len(server.gameResources[gameId]) != 2 {
-		t.Errorf("Expected 2 game resources tracked, got %d", len(server.gameResources[gameId]))
+	if len(server.sessions[gameId].resourceURIs()) != 2 {
+		t.Errorf("Expected 2 game resources tracked, got %d", len(server.sessions[gameId].resourceURIs()))
 	}
 	server.mu.RUnlock()
 
@@ -80,11 +80,11 @@ func TestGameResourceCleanup(t *testing.T) {
 	if len(server.resources) != 0 {
 		t.Errorf("Expected 0 resources after cleanup, got %d", len(server.resources))
 	}
-	if len(server.gameTools) != 0 {
-		t.Errorf("Expected 0 game tools tracking after cleanup, got %d", len(server.gameTools))
+	if gamesWithToolsForTest(server) != 0 {
+		t.Errorf("Expected 0 game tools tracking after cleanup, got %d", gamesWithToolsForTest(server))
 	}
-	if len(server.gameResources) != 0 {
-		t.Errorf("Expected 0 game resources tracking after cleanup, got %d", len(server.gameResources))
+	if gamesWithResourcesForTest(server) != 0 {
+		t.Errorf("Expected 0 game resources tracking after cleanup, got %d", gamesWithResourcesForTest(server))
 	}
 	server.mu.RUnlock()
 }
@@ -146,8 +146,8 @@ func TestMixedGameCleanup(t *testing.T) {
 	if len(server.tools) != 2 {
 		t.Errorf("Expected 2 tools total, got %d", len(server.tools))
 	}
-	if len(server.gameTools) != 2 {
-		t.Errorf("Expected 2 games tracked, got %d", len(server.gameTools))
+	if gamesWithToolsForTest(server) != 2 {
+		t.Errorf("Expected 2 games tracked, got %d", gamesWithToolsForTest(server))
 	}
 	server.mu.RUnlock()
 
@@ -159,8 +159,8 @@ func TestMixedGameCleanup(t *testing.T) {
 	if len(server.tools) != 1 {
 		t.Errorf("Expected 1 tool remaining after game1 cleanup, got %d", len(server.tools))
 	}
-	if len(server.gameTools) != 1 {
-		t.Errorf("Expected 1 game tracked after game1 cleanup, got %d", len(server.gameTools))
+	if gamesWithToolsForTest(server) != 1 {
+		t.Errorf("Expected 1 game tracked after game1 cleanup, got %d", gamesWithToolsForTest(server))
 	}
 	if _, exists := server.tools[game1+".tool"]; exists {
 		t.Error("Game1 tool should have been removed")
@@ -178,8 +178,8 @@ func TestMixedGameCleanup(t *testing.T) {
 	if len(server.tools) != 0 {
 		t.Errorf("Expected 0 tools after complete cleanup, got %d", len(server.tools))
 	}
-	if len(server.gameTools) != 0 {
-		t.Errorf("Expected 0 games tracked after complete cleanup, got %d", len(server.gameTools))
+	if gamesWithToolsForTest(server) != 0 {
+		t.Errorf("Expected 0 games tracked after complete cleanup, got %d", gamesWithToolsForTest(server))
 	}
 	server.mu.RUnlock()
 }
@@ -222,7 +222,7 @@ func TestRegisterGameToolCleanupWithNormalization(t *testing.T) {
 	if len(server.tools) != 0 {
 		t.Errorf("Expected normalized game tool to be removed during cleanup, got %d tools remaining", len(server.tools))
 	}
-	if len(server.gameTools) != 0 {
-		t.Errorf("Expected normalized game tool tracking to be removed during cleanup, got %d tracked games remaining", len(server.gameTools))
+	if gamesWithToolsForTest(server) != 0 {
+		t.Errorf("Expected normalized game tool tracking to be removed during cleanup, got %d tracked games remaining", gamesWithToolsForTest(server))
 	}
 }
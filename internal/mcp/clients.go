@@ -0,0 +1,175 @@
+package mcp
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// clientOutboxSize is how many notifications may wait for a slow client.
+// When a client falls further behind, an SSE stream is dropped, since it can
+// reconnect and resume, while a stdio client only misses the notification, as
+// its connection cannot be reopened. Either way it cannot hold up
+// notifications to everyone else.
+const clientOutboxSize = 64
+
+// Notification transports recorded in clientConn.transport.
+const (
+	clientTransportStdio = "stdio"
+	clientTransportSSE   = "sse"
+)
+
+// clientConn is one connected client that receives notifications: a stdio
+// connection or an HTTP Server-Sent Events stream. Notifications are queued
+// and written by the connection's own goroutine.
+type clientConn struct {
+	id        string
+	transport string
+	session   *mcpSession // nil for SSE streams opened without a session ID
+	outbox    chan *Message
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// enqueue queues msg without blocking. It reports false when the outbox is
+// full or the client is gone.
+func (c *clientConn) enqueue(msg *Message) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+	select {
+	case c.outbox <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// subscribed reports whether the client's session subscribed to uri.
+func (c *clientConn) subscribed(uri string) bool {
+	return c.session != nil && c.session.subscribed(uri)
+}
+
+// subscribe records a resource subscription for the session.
+func (m *mcpSession) subscribe(uri string) {
+	m.subsMu.Lock()
+	defer m.subsMu.Unlock()
+	if m.subscriptions == nil {
+		m.subscriptions = make(map[string]bool)
+	}
+	m.subscriptions[uri] = true
+}
+
+// unsubscribe removes a resource subscription from the session.
+func (m *mcpSession) unsubscribe(uri string) {
+	m.subsMu.Lock()
+	defer m.subsMu.Unlock()
+	delete(m.subscriptions, uri)
+}
+
+func (m *mcpSession) subscribed(uri string) bool {
+	m.subsMu.Lock()
+	defer m.subsMu.Unlock()
+	return m.subscriptions[uri]
+}
+
+// addClient registers a client for notifications.
+func (s *Server) addClient(transport string, session *mcpSession) *clientConn {
+	client := &clientConn{
+		id:        uuid.New().String(),
+		transport: transport,
+		session:   session,
+		outbox:    make(chan *Message, clientOutboxSize),
+		done:      make(chan struct{}),
+	}
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	if s.clients == nil {
+		s.clients = make(map[string]*clientConn)
+	}
+	s.clients[client.id] = client
+	return client
+}
+
+// removeClient unregisters a client and closes its done channel. It is safe to
+// call more than once.
+func (s *Server) removeClient(client *clientConn) {
+	s.clientsMu.Lock()
+	delete(s.clients, client.id)
+	s.clientsMu.Unlock()
+	client.closeOnce.Do(func() { close(client.done) })
+}
+
+// pumpClient writes queued notifications with write until the client is
+// removed. A failed write removes the client.
+func (s *Server) pumpClient(client *clientConn, write func(*Message) error) {
+	for {
+		select {
+		case <-client.done:
+			return
+		case msg := <-client.outbox:
+			if err := write(msg); err != nil {
+				s.log.Warnw("dropping client after failed notification write", "clientId", client.id, "transport", client.transport, "method", msg.Method, "error", err)
+				s.metrics.notificationErrors.Inc(client.transport)
+				s.removeClient(client)
+				return
+			}
+		}
+	}
+}
+
+// notifyClients queues a notification for every client accepted by match.
+// SSE streams whose outbox is full are dropped; stdio clients keep their
+// connection and miss this notification.
+func (s *Server) notifyClients(method string, params interface{}, match func(*clientConn) bool) {
+	notification := NewNotification(method, params)
+
+	s.clientsMu.RLock()
+	s.recordSessionEvents(notification, match)
+	var stalled []*clientConn
+	for _, client := range s.clients {
+		if match != nil && !match(client) {
+			continue
+		}
+		if !client.enqueue(notification) {
+			stalled = append(stalled, client)
+		}
+	}
+	s.clientsMu.RUnlock()
+
+	for _, client := range stalled {
+		s.metrics.notificationErrors.Inc(client.transport)
+		if client.transport != clientTransportSSE {
+			s.log.Warnw("dropping notification for a client that is behind on reading", "clientId", client.id, "transport", client.transport, "method", method)
+			continue
+		}
+		s.log.Warnw("dropping client that stopped reading notifications", "clientId", client.id, "transport", client.transport, "method", method)
+		s.removeClient(client)
+	}
+}
+
+// notifySubscribers sends a notification about uri only to clients whose
+// session subscribed to it.
+func (s *Server) notifySubscribers(uri, method string, params interface{}) {
+	s.notifyClients(method, params, func(client *clientConn) bool {
+		return client.subscribed(uri)
+	})
+}
+
+// closeClients removes every client of the given transport.
+func (s *Server) closeClients(transport string) {
+	s.clientsMu.RLock()
+	var matched []*clientConn
+	for _, client := range s.clients {
+		if client.transport == transport {
+			matched = append(matched, client)
+		}
+	}
+	s.clientsMu.RUnlock()
+
+	for _, client := range matched {
+		s.removeClient(client)
+	}
+}
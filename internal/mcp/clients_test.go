@@ -0,0 +1,286 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestNotificationsDropStalledClientWithoutBlockingOthers(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+
+	var mu sync.Mutex
+	var received []string
+	reader := server.addClient(clientTransportStdio, nil)
+	go server.pumpClient(reader, func(msg *Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, msg.Method)
+		return nil
+	})
+	defer server.removeClient(reader)
+
+	// Nothing ever drains this client's outbox, and it is already full.
+	stalled := server.addClient(clientTransportSSE, nil)
+	for i := 0; i < clientOutboxSize; i++ {
+		stalled.enqueue(NewNotification("notifications/message", nil))
+	}
+
+	server.SendToolsListChangedNotification()
+
+	select {
+	case <-stalled.done:
+	default:
+		t.Fatal("expected the stalled client to be dropped")
+	}
+	server.clientsMu.RLock()
+	_, stillRegistered := server.clients[stalled.id]
+	server.clientsMu.RUnlock()
+	if stillRegistered {
+		t.Fatal("expected the stalled client to be unregistered")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		count := len(received)
+		mu.Unlock()
+		if count == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected one notification for the reading client, got %d", count)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestNotificationsKeepStalledStdioClient(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+
+	stdio := server.addClient(clientTransportStdio, nil)
+	defer server.removeClient(stdio)
+	for i := 0; i < clientOutboxSize; i++ {
+		stdio.enqueue(NewNotification("notifications/message", nil))
+	}
+
+	server.SendToolsListChangedNotification()
+
+	select {
+	case <-stdio.done:
+		t.Fatal("expected the stdio client to stay connected")
+	default:
+	}
+	// The stdio client missed only the notification that did not fit
+	<-stdio.outbox
+	server.SendToolsListChangedNotification()
+	var last *Message
+	for len(stdio.outbox) > 0 {
+		last = <-stdio.outbox
+	}
+	if last == nil || last.Method != "notifications/tools/list_changed" {
+		t.Fatalf("expected later notifications to reach the stdio client, got %#v", last)
+	}
+}
+
+func TestSubscriptionNotificationsOnlyReachSubscribedSessions(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+
+	subscriber := &mcpSession{}
+	subscriber.subscribe("gab://factory/state")
+	interested := server.addClient(clientTransportStdio, subscriber)
+	other := server.addClient(clientTransportStdio, &mcpSession{})
+	anonymous := server.addClient(clientTransportSSE, nil)
+
+	server.notifySubscribers("gab://factory/state", "notifications/resources/updated", map[string]interface{}{"uri": "gab://factory/state"})
+
+	if len(interested.outbox) != 1 || len(other.outbox) != 0 || len(anonymous.outbox) != 0 {
+		t.Fatalf("expected only the subscribed session to be notified, got %d/%d/%d", len(interested.outbox), len(other.outbox), len(anonymous.outbox))
+	}
+
+	subscriber.unsubscribe("gab://factory/state")
+	server.notifySubscribers("gab://factory/state", "notifications/resources/updated", map[string]interface{}{"uri": "gab://factory/state"})
+	if len(interested.outbox) != 1 {
+		t.Fatalf("expected no notification after unsubscribe, got %d queued", len(interested.outbox))
+	}
+}
+
+func TestSSEStreamReceivesNotificationsAndUnregistersOnDisconnect(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	httpServer := httptest.NewServer(http.HandlerFunc(server.handleSSEConnection))
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL, nil)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("open SSE stream: %v", err)
+	}
+	defer response.Body.Close()
+
+	events := bufio.NewScanner(response.Body)
+	nextEvent := func() string {
+		t.Helper()
+		var lines []string
+		for events.Scan() {
+			if events.Text() == "" {
+				return strings.Join(lines, "\n")
+			}
+			lines = append(lines, events.Text())
+		}
+		t.Fatalf("SSE stream ended: %v", events.Err())
+		return ""
+	}
+
+	if event := nextEvent(); !strings.HasPrefix(event, "event: connected") {
+		t.Fatalf("expected connected event, got %q", event)
+	}
+	server.SendToolsListChangedNotification()
+	if event := nextEvent(); !strings.Contains(event, "event: notification") || !strings.Contains(event, "notifications/tools/list_changed") {
+		t.Fatalf("expected tools/list_changed event, got %q", event)
+	}
+
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		server.clientsMu.RLock()
+		remaining := len(server.clients)
+		server.clientsMu.RUnlock()
+		if remaining == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the SSE client to be unregistered, %d left", remaining)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSSERejectsUnknownSession(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	request := httptest.NewRequest(http.MethodGet, "/mcp/events", nil)
+	request.Header.Set(mcpSessionHeader, "missing")
+	recorder := httptest.NewRecorder()
+	server.handleSSEConnection(recorder, request)
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown session, got %d", recorder.Code)
+	}
+}
+
+// openSSEStreamForTest opens an SSE stream with the given request headers and
+// returns a function reading its next event.
+func openSSEStreamForTest(t *testing.T, ctx context.Context, url string, headers map[string]string) func() string {
+	t.Helper()
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("open SSE stream: %v", err)
+	}
+	t.Cleanup(func() { response.Body.Close() })
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 for the SSE stream, got %d", response.StatusCode)
+	}
+
+	events := bufio.NewScanner(response.Body)
+	return func() string {
+		t.Helper()
+		var lines []string
+		for events.Scan() {
+			if events.Text() == "" {
+				return strings.Join(lines, "\n")
+			}
+			lines = append(lines, events.Text())
+		}
+		t.Fatalf("SSE stream ended: %v", events.Err())
+		return ""
+	}
+}
+
+func TestSSEStreamResumesSessionWithLastEventID(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	httpServer := httptest.NewServer(http.HandlerFunc(server.handleSSEConnection))
+	defer httpServer.Close()
+	session := &mcpSession{}
+	sessionID := server.registerHTTPSession(session)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	nextEvent := openSSEStreamForTest(t, ctx, httpServer.URL, map[string]string{mcpSessionHeader: sessionID})
+	if event := nextEvent(); !strings.HasPrefix(event, "event: connected\nretry: ") {
+		t.Fatalf("expected connected event with a retry delay, got %q", event)
+	}
+	server.SendToolsListChangedNotification()
+	if event := nextEvent(); !strings.HasPrefix(event, "event: notification\nid: 1\n") {
+		t.Fatalf("expected the first notification with event ID 1, got %q", event)
+	}
+
+	// Notifications sent while no stream is open are kept for the session
+	cancel()
+	server.SendResourcesListChangedNotification()
+	session.subscribe("gab://factory/state")
+	server.SendResourceUpdatedNotification("gab://factory/state")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	nextEvent = openSSEStreamForTest(t, ctx, httpServer.URL, map[string]string{mcpSessionHeader: sessionID, "Last-Event-ID": "1"})
+	nextEvent() // connected
+	if event := nextEvent(); !strings.Contains(event, "\nid: 2\n") || !strings.Contains(event, "notifications/resources/list_changed") {
+		t.Fatalf("expected the missed resources/list_changed as event 2, got %q", event)
+	}
+	if event := nextEvent(); !strings.Contains(event, "\nid: 3\n") || !strings.Contains(event, "notifications/resources/updated") {
+		t.Fatalf("expected the missed resources/updated as event 3, got %q", event)
+	}
+	server.SendToolsListChangedNotification()
+	if event := nextEvent(); !strings.Contains(event, "\nid: 4\n") {
+		t.Fatalf("expected live notifications to continue with event 4, got %q", event)
+	}
+}
+
+func TestSSEStreamResumingBeyondTheLogAsksClientToRefetch(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	httpServer := httptest.NewServer(http.HandlerFunc(server.handleSSEConnection))
+	defer httpServer.Close()
+	sessionID := server.registerHTTPSession(&mcpSession{})
+	for i := 0; i < sessionEventLogSize+2; i++ {
+		server.SendNotification("notifications/message", map[string]interface{}{"n": i})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	nextEvent := openSSEStreamForTest(t, ctx, httpServer.URL, map[string]string{mcpSessionHeader: sessionID, "Last-Event-ID": "0"})
+	nextEvent() // connected
+	for _, method := range []string{"notifications/tools/list_changed", "notifications/resources/list_changed"} {
+		if event := nextEvent(); strings.Contains(event, "\nid: ") || !strings.Contains(event, method) {
+			t.Fatalf("expected %s without an event ID, got %q", method, event)
+		}
+	}
+	if event := nextEvent(); !strings.Contains(event, "\nid: 3\n") {
+		t.Fatalf("expected the replay to start at the oldest kept event 3, got %q", event)
+	}
+}
+
+func TestSSEStreamSendsKeepAlivePings(t *testing.T) {
+	defer func(previous time.Duration) { sseKeepAlive = previous }(sseKeepAlive)
+	sseKeepAlive = 20 * time.Millisecond
+	server := NewServerForTesting(util.NewLogger("error"))
+	httpServer := httptest.NewServer(http.HandlerFunc(server.handleSSEConnection))
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	nextEvent := openSSEStreamForTest(t, ctx, httpServer.URL, nil)
+	nextEvent() // connected
+	if event := nextEvent(); !strings.HasPrefix(event, "event: ping") {
+		t.Fatalf("expected a keep-alive ping, got %q", event)
+	}
+}
@@ -0,0 +1,252 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// Aggregated cluster states. A cluster is degraded while some, but not all,
+// of its members are down.
+const (
+	clusterStatusRunning  = "running"
+	clusterStatusDegraded = "degraded"
+	clusterStatusStopped  = "stopped"
+)
+
+// clusterToolName namespaces a member tool as cluster.member.tool.
+func clusterToolName(clusterID, memberID, localName string) string {
+	return clusterID + "." + memberID + "." + localName
+}
+
+// resolveClusterTool maps a cluster.member.tool or member.tool name to the
+// member game and its local tool name. Only calls whose gameId names the
+// cluster are resolved, so a game tool that happens to start with a cluster ID
+// is never redirected. handled is false when the call does not involve a
+// cluster.
+func resolveClusterTool(gamesConfig *config.GamesConfig, gameID string, hasGameID bool, toolName string) (string, string, bool, *ToolResult) {
+	if !hasGameID {
+		return "", "", false, nil
+	}
+	cluster, ok := gamesConfig.GetCluster(gameID)
+	if !ok {
+		return "", "", false, nil
+	}
+	rest := strings.TrimPrefix(toolName, cluster.ID+".")
+
+	for _, member := range cluster.Members {
+		if local := strings.TrimPrefix(rest, member+"."); local != rest && local != "" {
+			return member, local, true, nil
+		}
+	}
+	return "", "", true, &ToolResult{
+		Content: []Content{{Type: "text", Text: fmt.Sprintf("Tool '%s' does not name a member of cluster '%s'. Use names of the form '%s.<member>.<tool>' as listed by games_tool_names with gameId '%s', and pass the same gameId.", toolName, cluster.ID, cluster.ID, cluster.ID)}},
+		IsError: true,
+	}
+}
+
+// clusterMemberUp reports whether a member status counts as up for cluster
+// health. Transitional and failure states such as starting or disconnected
+// count as down.
+func clusterMemberUp(status string) bool {
+	switch status {
+	case "connected", "running", "launcher-running":
+		return true
+	default:
+		return false
+	}
+}
+
+// clusterStatusStructured aggregates member status into one cluster state.
+func (s *Server) clusterStatusStructured(gamesConfig *config.GamesConfig, cluster config.ClusterConfig) map[string]interface{} {
+	members := make([]map[string]interface{}, 0, len(cluster.Members))
+	up := 0
+	toolCount := 0
+	for _, memberID := range cluster.Members {
+		game, exists := gamesConfig.GetGame(memberID)
+		if !exists {
+			members = append(members, map[string]interface{}{
+				"gameId": memberID,
+				"status": "stopped",
+				"error":  "not a configured game",
+			})
+			continue
+		}
+		status := s.checkGameStatus(memberID)
+		if clusterMemberUp(status) {
+			up++
+		}
+		item := s.gameStatusStructured(*game, status)
+		if count, ok := item["toolCount"].(int); ok {
+			toolCount += count
+		}
+		members = append(members, item)
+	}
+
+	status := clusterStatusDegraded
+	switch up {
+	case 0:
+		status = clusterStatusStopped
+	case len(cluster.Members):
+		status = clusterStatusRunning
+	}
+
+	var nextActions []map[string]interface{}
+	switch status {
+	case clusterStatusStopped, clusterStatusDegraded:
+		nextActions = append(nextActions, mcpNextAction("games_start", map[string]interface{}{"gameId": cluster.ID}, "Start the cluster members that are down, in order."))
+	}
+	if toolCount > 0 {
+		nextActions = append(nextActions, mcpNextAction("games_tool_names", map[string]interface{}{"gameId": cluster.ID, "brief": true}, "Discover member tools named cluster.member.tool."))
+	}
+
+	return map[string]interface{}{
+		"clusterId":   cluster.ID,
+		"name":        cluster.Name,
+		"status":      status,
+		"membersUp":   up,
+		"memberCount": len(cluster.Members),
+		"toolCount":   toolCount,
+		"members":     members,
+		"nextActions": nextActions,
+	}
+}
+
+func (s *Server) clusterStatusResult(gamesConfig *config.GamesConfig, cluster config.ClusterConfig) *ToolResult {
+	structured := s.clusterStatusStructured(gamesConfig, cluster)
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("**%s** (cluster): %s, %d of %d members up\n", cluster.ID, structured["status"], structured["membersUp"], len(cluster.Members)))
+	for _, member := range structured["members"].([]map[string]interface{}) {
+		description, _ := member["statusDescription"].(string)
+		if description == "" {
+			description, _ = member["error"].(string)
+		}
+		content.WriteString(fmt.Sprintf("• **%s**: %s\n", member["gameId"], description))
+	}
+
+	return &ToolResult{
+		Content:           []Content{{Type: "text", Text: content.String()}},
+		StructuredContent: structured,
+	}
+}
+
+// startCluster starts the members of a cluster in order. Members that are
+// already running are left alone. The first member that fails to start stops
+// the sequence so later members never come up without the ones they depend on.
+// Cancelling ctx during a start delay stops the sequence the same way.
+func (s *Server) startCluster(ctx context.Context, gamesConfig *config.GamesConfig, cluster config.ClusterConfig, backoffMin, backoffMax, startupGABPTimeout time.Duration) *ToolResult {
+	s.mu.RLock()
+	clock := s.clock
+	s.mu.RUnlock()
+
+	results := make([]map[string]interface{}, 0, len(cluster.Members))
+	var failure string
+	for i, memberID := range cluster.Members {
+		game, exists := gamesConfig.GetGame(memberID)
+		if !exists {
+			failure = fmt.Sprintf("member '%s' is not a configured game", memberID)
+			break
+		}
+
+		startResult, err := s.startGame(*game, "", gamesConfig, backoffMin, backoffMax, startupGABPTimeout, false, false, nil)
+		var activeErr *gameAlreadyActiveError
+		switch {
+		case errors.As(err, &activeErr):
+			results = append(results, map[string]interface{}{"gameId": memberID, "result": "already-running"})
+			continue
+		case err != nil:
+			results = append(results, map[string]interface{}{"gameId": memberID, "result": "failed", "error": err.Error()})
+			failure = fmt.Sprintf("member '%s' failed to start: %v", memberID, err)
+		default:
+			item := map[string]interface{}{"gameId": memberID, "result": "started", "gabpConnected": true}
+			if startResult != nil && !startResult.GABPConnected {
+				item["gabpConnected"] = false
+			}
+			results = append(results, item)
+		}
+		if failure != "" {
+			break
+		}
+
+		if cluster.StartDelaySeconds > 0 && i < len(cluster.Members)-1 {
+			select {
+			case <-ctx.Done():
+				failure = fmt.Sprintf("start was cancelled before member '%s': %v", cluster.Members[i+1], ctx.Err())
+			case <-clock.After(time.Duration(cluster.StartDelaySeconds) * time.Second):
+			}
+			if failure != "" {
+				break
+			}
+		}
+	}
+
+	structured := s.clusterStatusStructured(gamesConfig, cluster)
+	structured["start"] = results
+
+	var content strings.Builder
+	if failure != "" {
+		content.WriteString(fmt.Sprintf("Cluster '%s' did not start completely: %s. Later members were not started.\n", cluster.ID, failure))
+	} else {
+		content.WriteString(fmt.Sprintf("Cluster '%s' started in order: %s.\n", cluster.ID, strings.Join(cluster.Members, ", ")))
+	}
+	for _, item := range results {
+		content.WriteString(fmt.Sprintf("• %s: %s\n", item["gameId"], item["result"]))
+	}
+	content.WriteString(fmt.Sprintf("Cluster status: %s", structured["status"]))
+
+	return &ToolResult{
+		Content:           []Content{{Type: "text", Text: content.String()}},
+		StructuredContent: structured,
+		IsError:           failure != "",
+	}
+}
+
+// stopCluster stops the members of a cluster in reverse start order. It keeps
+// going after a failure so one stuck member does not leave the rest running.
+func (s *Server) stopCluster(gamesConfig *config.GamesConfig, cluster config.ClusterConfig, force bool) *ToolResult {
+	results := make([]map[string]interface{}, 0, len(cluster.Members))
+	failed := 0
+	for i := len(cluster.Members) - 1; i >= 0; i-- {
+		memberID := cluster.Members[i]
+		game, exists := gamesConfig.GetGame(memberID)
+		if !exists || s.checkGameStatus(memberID) == "stopped" {
+			results = append(results, map[string]interface{}{"gameId": memberID, "result": "not-running"})
+			continue
+		}
+		if err := s.stopGame(*game, force); err != nil {
+			failed++
+			results = append(results, map[string]interface{}{"gameId": memberID, "result": "failed", "error": err.Error()})
+			continue
+		}
+		results = append(results, map[string]interface{}{"gameId": memberID, "result": "stopped"})
+	}
+
+	structured := s.clusterStatusStructured(gamesConfig, cluster)
+	structured["stop"] = results
+
+	var content strings.Builder
+	if failed > 0 {
+		content.WriteString(fmt.Sprintf("Cluster '%s' stopped with %d failing member(s):\n", cluster.ID, failed))
+	} else {
+		content.WriteString(fmt.Sprintf("Cluster '%s' stopped in reverse order:\n", cluster.ID))
+	}
+	for _, item := range results {
+		line := fmt.Sprintf("• %s: %s", item["gameId"], item["result"])
+		if errText, ok := item["error"].(string); ok {
+			line += " (" + errText + ")"
+		}
+		content.WriteString(line + "\n")
+	}
+	content.WriteString(fmt.Sprintf("Cluster status: %s", structured["status"]))
+
+	return &ToolResult{
+		Content:           []Content{{Type: "text", Text: content.String()}},
+		StructuredContent: structured,
+		IsError:           failed > 0,
+	}
+}
@@ -0,0 +1,228 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func newClusterTestServer(t *testing.T) (*Server, *config.GamesConfig, string) {
+	t.Helper()
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"proxy":     sleepingGameForTest("proxy", "proxy"),
+			"factory-a": sleepingGameForTest("factory-a", "factory-a"),
+			"factory-b": sleepingGameForTest("factory-b", "factory-b"),
+		},
+		Clusters: map[string]config.ClusterConfig{
+			"network": {ID: "network", Members: []string{"factory-a", "factory-b", "proxy"}},
+		},
+	}
+
+	server, configDir := newGamesTestServer(t, gamesConfig)
+	return server, gamesConfig, configDir
+}
+
+func TestClusterStatusAggregatesMembersAndStopsInReverseOrder(t *testing.T) {
+	requireSleepForTest(t)
+
+	server, _, _ := newClusterTestServer(t)
+
+	result := callToolForTest(t, server, "games_status", map[string]interface{}{"gameId": "network"})
+	if status := result.StructuredContent["status"]; status != clusterStatusStopped {
+		t.Fatalf("expected stopped cluster, got %v", status)
+	}
+
+	trackSleepingGameForTest(t, server, "factory-a")
+	trackSleepingGameForTest(t, server, "proxy")
+	result = callToolForTest(t, server, "games_status", map[string]interface{}{"gameId": "network"})
+	structured := result.StructuredContent
+	if structured["status"] != clusterStatusDegraded || structured["membersUp"] != float64(2) {
+		t.Fatalf("expected degraded cluster with two members up, got %v", structured)
+	}
+
+	trackSleepingGameForTest(t, server, "factory-b")
+	result = callToolForTest(t, server, "games_status", map[string]interface{}{"gameId": "network"})
+	if status := result.StructuredContent["status"]; status != clusterStatusRunning {
+		t.Fatalf("expected running cluster, got %v", status)
+	}
+
+	result = callToolForTest(t, server, "games_stop", map[string]interface{}{"gameId": "network"})
+	if result.IsError {
+		t.Fatalf("cluster stop failed: %#v", result)
+	}
+	structured = result.StructuredContent
+	var order []string
+	for _, item := range structured["stop"].([]interface{}) {
+		order = append(order, item.(map[string]interface{})["gameId"].(string))
+	}
+	if strings.Join(order, ",") != "proxy,factory-b,factory-a" {
+		t.Fatalf("expected reverse stop order, got %v", order)
+	}
+	if structured["status"] != clusterStatusStopped {
+		t.Fatalf("expected stopped cluster after stop, got %v", structured["status"])
+	}
+}
+
+func TestClusterStartStopsAtFirstFailingMember(t *testing.T) {
+	server, gamesConfig, _ := newClusterTestServer(t)
+	broken, _ := gamesConfig.GetGame("factory-a")
+	broken.Target = "/nonexistent/factory"
+	if err := gamesConfig.AddGame(*broken); err != nil {
+		t.Fatalf("add game: %v", err)
+	}
+
+	result := callToolForTest(t, server, "games_start", map[string]interface{}{"gameId": "network"})
+	if !result.IsError {
+		t.Fatalf("expected cluster start to fail, got %#v", result)
+	}
+	structured := result.StructuredContent
+	started := structured["start"].([]interface{})
+	if len(started) != 1 || started[0].(map[string]interface{})["gameId"] != "factory-a" || started[0].(map[string]interface{})["result"] != "failed" {
+		t.Fatalf("expected only the first member to be attempted, got %v", started)
+	}
+	for _, memberID := range []string{"factory-b", "proxy"} {
+		if status := server.checkGameStatus(memberID); status != "stopped" {
+			t.Fatalf("expected %s not to be started, got %q", memberID, status)
+		}
+	}
+}
+
+func TestClusterToolNamesAreNamespacedByMember(t *testing.T) {
+	server, gamesConfig, configDir := newClusterTestServer(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	writeBridgeJSONForTest(t, configDir, "proxy", listener.Addr().(*net.TCPAddr).Port, "cluster-token")
+
+	session := make(chan error, 1)
+	go serveTestGabpSession(listener, "cluster-token", session)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "proxy"}); result.IsError {
+		t.Fatalf("connect failed: %#v", result)
+	}
+	result := callToolForTest(t, server, "games_tool_names", map[string]interface{}{"gameId": "network"})
+	if result.IsError {
+		t.Fatalf("tool names failed: %#v", result)
+	}
+	tools := result.StructuredContent["tools"].([]interface{})
+	if len(tools) != 1 {
+		t.Fatalf("expected one cluster tool, got %v", tools)
+	}
+	tool := tools[0].(map[string]interface{})
+	name := tool["name"].(string)
+	if !strings.HasPrefix(name, "network.proxy.") || tool["gameId"] != "proxy" {
+		t.Fatalf("expected tool namespaced as network.proxy.<tool>, got %v", tool)
+	}
+
+	memberID, localName, handled, errResult := resolveClusterTool(gamesConfig, "network", true, name)
+	if !handled || errResult != nil || memberID != "proxy" || localName != tool["localName"] {
+		t.Fatalf("expected %s to resolve to proxy/%v, got %s/%s handled=%v err=%#v", name, tool["localName"], memberID, localName, handled, errResult)
+	}
+	if _, _, handled, _ := resolveClusterTool(gamesConfig, "", false, name); handled {
+		t.Fatal("a tool name without the cluster gameId must not be redirected to a cluster")
+	}
+	if _, _, handled, _ := resolveClusterTool(gamesConfig, "", false, "network.unrelated"); handled {
+		t.Fatal("a game tool that starts with a cluster ID must not be hijacked")
+	}
+	if _, _, handled, errResult := resolveClusterTool(gamesConfig, "network", true, "unknown.tool"); !handled || errResult == nil {
+		t.Fatal("expected a tool outside the cluster members to be rejected")
+	}
+	if _, _, handled, _ := resolveClusterTool(gamesConfig, "proxy", true, "core.ping"); handled {
+		t.Fatal("plain game IDs should not be treated as clusters")
+	}
+	if err := <-session; err != nil {
+		t.Fatalf("GABP session failed: %v", err)
+	}
+}
+
+func TestClusterCountsDisconnectedMembersAsDown(t *testing.T) {
+	for status, up := range map[string]bool{
+		"connected":            true,
+		"running":              true,
+		"launcher-running":     true,
+		"disconnected":         false,
+		"running-disconnected": false,
+		"starting":             false,
+		"launcher-triggered":   false,
+		"stopped":              false,
+	} {
+		if got := clusterMemberUp(status); got != up {
+			t.Errorf("clusterMemberUp(%q) = %v, want %v", status, got, up)
+		}
+	}
+
+	requireSleepForTest(t)
+	server, gamesConfig, configDir := newClusterTestServer(t)
+	for _, memberID := range []string{"factory-a", "factory-b"} {
+		trackSleepingGameForTest(t, server, memberID)
+	}
+
+	// proxy's bridge went away: GABS still remembers a client for it, but the
+	// connection is down.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	writeBridgeJSONForTest(t, configDir, "proxy", listener.Addr().(*net.TCPAddr).Port, "cluster-token")
+	session := make(chan error, 1)
+	go serveTestGabpSession(listener, "cluster-token", session)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "proxy"}); result.IsError {
+		t.Fatalf("connect proxy: %#v", result)
+	}
+	server.mu.RLock()
+	client, _ := server.sessions["proxy"].gabpClient()
+	server.mu.RUnlock()
+	client.SimulateDisconnect(errors.New("bridge went away"))
+	waitForGameStatus(t, server, "proxy", "disconnected")
+
+	cluster, _ := gamesConfig.GetCluster("network")
+	structured := server.clusterStatusStructured(gamesConfig, *cluster)
+	if structured["status"] != clusterStatusDegraded || structured["membersUp"] != 2 {
+		t.Fatalf("expected a disconnected member to degrade the cluster, got %v with %v up", structured["status"], structured["membersUp"])
+	}
+	if err := <-session; err != nil {
+		t.Fatalf("GABP session failed: %v", err)
+	}
+}
+
+func TestClusterStartDelayUsesClockAndStopsOnCancel(t *testing.T) {
+	requireSleepForTest(t)
+	server, gamesConfig, _ := newClusterTestServer(t)
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	server.SetClock(clock)
+	cluster, _ := gamesConfig.GetCluster("network")
+	cluster.Members = []string{"factory-a", "factory-b"}
+	cluster.StartDelaySeconds = 30
+	t.Cleanup(func() { server.stopCluster(gamesConfig, *cluster, true) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan *ToolResult, 1)
+	go func() {
+		done <- server.startCluster(ctx, gamesConfig, *cluster, 10*time.Millisecond, 100*time.Millisecond, time.Millisecond)
+	}()
+
+	clock.BlockUntil(1)
+	cancel()
+	var result *ToolResult
+	select {
+	case result = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("cluster start did not return after cancellation")
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "cancelled before member 'factory-b'") {
+		t.Fatalf("expected cancellation during the start delay, got %#v", result)
+	}
+	if status := server.checkGameStatus("factory-b"); status != "stopped" {
+		t.Fatalf("expected factory-b not to be started, got %q", status)
+	}
+}
@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"os"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// DefaultConfigWatchInterval is how often WatchConfig checks config.json.
+const DefaultConfigWatchInterval = 2 * time.Second
+
+// configFileHash returns a hash of the config file contents, or nil if the
+// file cannot be read.
+func configFileHash(path string) []byte {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// WatchConfig polls config.json every interval and reloads the game and
+// cluster definitions, as 'gabs games reload' does, whenever its contents
+// change. A file that fails to load, or that changes a setting which needs a
+// restart, is logged once and retried after the next change. It returns when
+// ctx is cancelled.
+func (s *Server) WatchConfig(ctx context.Context, interval time.Duration) {
+	paths, err := config.NewConfigPaths(s.configDir)
+	if err != nil {
+		s.log.Warnw("config watching disabled", "error", err)
+		return
+	}
+	path := paths.GetMainConfigPath()
+	last := configFileHash(path)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+
+		current := configFileHash(path)
+		if current == nil || bytes.Equal(current, last) {
+			continue
+		}
+		last = current
+		if _, err := s.ControlReload(); err != nil {
+			s.log.Warnw("config file changed but could not be reloaded", "path", path, "error", err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestWatchConfigReloadsChangedGameDefinitions(t *testing.T) {
+	configDir := t.TempDir()
+	gamesConfig := &config.GamesConfig{Version: "1.0"}
+	if err := gamesConfig.AddGame(config.GameConfig{ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/bin/true"}); err != nil {
+		t.Fatalf("add game: %v", err)
+	}
+	if err := config.SaveGamesConfigToDir(gamesConfig, configDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	gamesConfig, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.SetConfigDir(configDir)
+	server.RegisterGameManagementTools(gamesConfig, 0, 0)
+	client := server.addClient(clientTransportStdio, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.WatchConfig(ctx, 10*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+
+	updated, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if err := updated.AddGame(config.GameConfig{ID: "adventure", Name: "Adventure", LaunchMode: "DirectPath", Target: "/bin/true"}); err != nil {
+		t.Fatalf("add game: %v", err)
+	}
+	if err := config.SaveGamesConfigToDir(updated, configDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	select {
+	case msg := <-client.outbox:
+		if msg.Method != "notifications/tools/list_changed" {
+			t.Fatalf("expected tools/list_changed, got %s", msg.Method)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("config change was not picked up")
+	}
+	if _, exists := gamesConfig.GetGame("adventure"); !exists {
+		t.Fatal("expected the added game to be usable without a restart")
+	}
+
+	// Rewriting identical definitions is not a relevant change.
+	if err := config.SaveGamesConfigToDir(updated, configDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	result, err := server.ControlReload()
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	summary := result.(map[string]interface{})
+	if len(summary["added"].([]string)) != 0 || len(summary["changed"].([]string)) != 0 || len(summary["removed"].([]string)) != 0 {
+		t.Fatalf("expected no definition changes, got %#v", summary)
+	}
+	select {
+	case msg := <-client.outbox:
+		t.Fatalf("unexpected notification for an unchanged config: %s", msg.Method)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,185 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// pendingConfirm is a tool call held back until the agent confirms it with
+// games.confirm.
+type pendingConfirm struct {
+	request ToolCallParams
+	gameID  string
+	tool    string
+	role    string
+	session *mcpSession
+	expires time.Time
+}
+
+// holdForConfirmation returns a result asking the agent to confirm the call
+// when the confirmation settings cover the tool, and nil when the call may go
+// ahead. gameID and name describe the tool as for tool policies. Calls GABS
+// makes itself, which have no *toolCall, never wait.
+func (s *Server) holdForConfirmation(call *toolCall, gameID, name string, tags []string) *ToolResult {
+	if call == nil || call.confirmed || (gameID == "" && name == "games.confirm") {
+		return nil
+	}
+	if !s.gamesConfig.RequiresConfirmation(gameID, name, tags) {
+		return nil
+	}
+	ttl, err := s.gamesConfig.Confirmation.TTLDuration()
+	if err != nil {
+		ttl = config.DefaultConfirmationTTL
+	}
+
+	s.mu.RLock()
+	now := s.clock.Now()
+	s.mu.RUnlock()
+
+	token := uuid.New().String()
+	s.confirmMu.Lock()
+	if s.confirmations == nil {
+		s.confirmations = make(map[string]pendingConfirm)
+	}
+	for existing, pending := range s.confirmations {
+		if !now.Before(pending.expires) {
+			delete(s.confirmations, existing)
+		}
+	}
+	s.confirmations[token] = pendingConfirm{
+		request: call.request,
+		gameID:  gameID,
+		tool:    name,
+		role:    call.accessRole(),
+		session: call.session,
+		expires: now.Add(ttl),
+	}
+	s.confirmMu.Unlock()
+
+	s.log.Infow("tool call waiting for confirmation", "gameId", gameID, "tool", name, "role", call.accessRole())
+
+	subject := fmt.Sprintf("Tool '%s'", name)
+	if gameID != "" {
+		subject = fmt.Sprintf("Tool '%s' of game '%s'", name, gameID)
+	}
+	structured := map[string]interface{}{
+		"confirmationRequired": true,
+		"token":                token,
+		"tool":                 name,
+		"expiresInSeconds":     int(math.Ceil(ttl.Seconds())),
+		"nextActions": []map[string]interface{}{
+			mcpNextAction("games_confirm", map[string]interface{}{"token": token}, "Run the held call once you are sure it should happen."),
+		},
+	}
+	if gameID != "" {
+		structured["gameId"] = gameID
+	}
+	return &ToolResult{
+		Content:           []Content{{Type: "text", Text: fmt.Sprintf("%s needs confirmation and has NOT run yet. To run it, call games_confirm with token \"%s\" within %s. If the call was a mistake, do nothing and the token expires.", subject, token, ttl)}},
+		StructuredContent: structured,
+	}
+}
+
+// takeConfirmation removes and returns the call held under token. A token
+// works once, and only for the client that made the call.
+func (s *Server) takeConfirmation(token string, call *toolCall) (pendingConfirm, *ToolResult) {
+	s.mu.RLock()
+	now := s.clock.Now()
+	s.mu.RUnlock()
+
+	var session *mcpSession
+	if call != nil {
+		session = call.session
+	}
+
+	s.confirmMu.Lock()
+	pending, exists := s.confirmations[token]
+	if exists && (pending.role != call.accessRole() || pending.session != session) {
+		exists = false
+	}
+	if exists {
+		delete(s.confirmations, token)
+	}
+	s.confirmMu.Unlock()
+
+	if !exists {
+		return pendingConfirm{}, &ToolResult{
+			Content: []Content{{Type: "text", Text: fmt.Sprintf("No call is waiting for confirmation token '%s'. Tokens work once and only for the client that made the call; repeat the original call to get a new one.", token)}},
+			IsError: true,
+		}
+	}
+	if !now.Before(pending.expires) {
+		return pendingConfirm{}, &ToolResult{
+			Content: []Content{{Type: "text", Text: fmt.Sprintf("Confirmation token '%s' for tool '%s' expired. Repeat the original call to get a new one.", token, pending.tool)}},
+			IsError: true,
+		}
+	}
+	return pending, nil
+}
+
+// runConfirmedCall replays a held tools/call request on behalf of call.
+func (s *Server) runConfirmedCall(pending pendingConfirm, call *toolCall) (*ToolResult, error) {
+	replay := toolCall{ctx: context.Background()}
+	if call != nil {
+		replay = *call
+	}
+	replay.request = pending.request
+	replay.confirmed = true
+
+	s.log.Infow("running confirmed tool call", "gameId", pending.gameID, "tool", pending.tool, "role", pending.role)
+
+	s.mu.RLock()
+	handler, exists := s.findToolHandlerLocked(pending.request.Name)
+	s.mu.RUnlock()
+	if !exists {
+		if result, handled := s.callUnmirroredGABPTool(&replay, pending.request.Name, pending.request.Arguments); handled {
+			return result, nil
+		}
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: fmt.Sprintf("Tool '%s' is no longer available, so the confirmed call did not run.", pending.request.Name)}},
+			IsError: true,
+		}, nil
+	}
+	if handler.CallHandler != nil {
+		return handler.CallHandler(pending.request.Arguments, &replay)
+	}
+	return handler.Handler(pending.request.Arguments)
+}
+
+func (s *Server) registerConfirmTool(normalizationConfig *config.ToolNormalizationConfig) {
+	s.registerCallTool(Tool{
+		Name:        "games.confirm",
+		Description: "Run a tool call that was held back for confirmation, using the token its result returned",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"token": map[string]interface{}{
+					"type":        "string",
+					"description": "Confirmation token from the held call's result",
+				},
+			},
+			"required": []string{"token"},
+		},
+	}, func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
+		token, _ := args["token"].(string)
+		token = strings.TrimSpace(token)
+		if token == "" {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "token parameter is required"}},
+				IsError: true,
+			}, nil
+		}
+
+		pending, invalid := s.takeConfirmation(token, call)
+		if invalid != nil {
+			return invalid, nil
+		}
+		return s.runConfirmedCall(pending, call)
+	}, normalizationConfig)
+}
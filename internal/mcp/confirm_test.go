@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func newConfirmTestServer(t *testing.T) (*Server, *util.FakeClock, *int) {
+	t.Helper()
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{"factory": sleepingGameForTest("factory", "Factory")},
+		Confirmation: &config.ConfirmationConfig{
+			Tools: []string{"games.kill"},
+			Tags:  []string{"destructive"},
+			TTL:   "1m",
+		},
+	})
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	server.SetClock(clock)
+
+	wipes := 0
+	server.RegisterGameTool("factory", Tool{
+		Name:        "factory.world.wipe",
+		InputSchema: map[string]interface{}{"type": "object"},
+		Meta:        map[string]interface{}{toolMetaTags: []string{"destructive"}},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		wipes++
+		return &ToolResult{Content: []Content{{Type: "text", Text: "wiped"}}}, nil
+	}, nil)
+	return server, clock, &wipes
+}
+
+func TestConfirmationHoldsDestructiveCallsUntilConfirmed(t *testing.T) {
+	server, _, wipes := newConfirmTestServer(t)
+
+	held := callToolForTest(t, server, "factory.world.wipe", nil)
+	token, _ := held.StructuredContent["token"].(string)
+	if held.IsError || held.StructuredContent["confirmationRequired"] != true || token == "" {
+		t.Fatalf("expected the tagged tool to wait for confirmation, got %#v", held)
+	}
+	if *wipes != 0 {
+		t.Fatal("expected the held call not to run")
+	}
+
+	if result := callToolForTest(t, server, "games_confirm", map[string]interface{}{"token": "not-a-token"}); !result.IsError {
+		t.Fatalf("expected an unknown token to be rejected, got %#v", result)
+	}
+	if result := callToolForTest(t, server, "games_confirm", map[string]interface{}{"token": token}); result.IsError || *wipes != 1 {
+		t.Fatalf("expected confirming to run the held call once, got %#v after %d runs", result, *wipes)
+	}
+	if result := callToolForTest(t, server, "games_confirm", map[string]interface{}{"token": token}); !result.IsError || *wipes != 1 {
+		t.Fatalf("expected a used token to be rejected, got %#v", result)
+	}
+
+	viaCallTool := callToolForTest(t, server, "games_call_tool", map[string]interface{}{"gameId": "factory", "tool": "world.wipe"})
+	if viaCallTool.StructuredContent["confirmationRequired"] != true {
+		t.Fatalf("expected games_call_tool to hold the tagged tool too, got %#v", viaCallTool)
+	}
+}
+
+func TestConfirmationTokensExpire(t *testing.T) {
+	server, clock, _ := newConfirmTestServer(t)
+
+	held := callToolForTest(t, server, "games_kill", map[string]interface{}{"gameId": "factory"})
+	token, _ := held.StructuredContent["token"].(string)
+	if token == "" {
+		t.Fatalf("expected games_kill to wait for confirmation, got %#v", held)
+	}
+
+	clock.Advance(time.Minute)
+	if result := callToolForTest(t, server, "games_confirm", map[string]interface{}{"token": token}); !result.IsError {
+		t.Fatalf("expected an expired token to be rejected, got %#v", result)
+	}
+
+	list := server.HandleMessage(&Message{JSONRPC: "2.0", ID: 1, Method: "tools/list"})
+	for _, tool := range list.Result.(ToolsListResult).Tools {
+		if requires, _ := tool.Meta[toolMetaRequiresConfirmation].(bool); requires != (toolCanonicalName(tool) == "games.kill") {
+			t.Errorf("unexpected requiresConfirmation %v on %s", requires, tool.Name)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// controlTools are the tools 'gabs control' may call, by short name.
+var controlTools = map[string]string{
+	"start":   "games.start",
+	"stop":    "games.stop",
+	"kill":    "games.kill",
+	"restart": "games.restart",
+	"status":  "games.status",
+}
+
+// controlCallID numbers tool calls made through the control socket.
+var controlCallID atomic.Int64
+
+// ControlStatus reports the server and per-game status for the local control
+// socket. An empty gameID reports every configured game.
+func (s *Server) ControlStatus(gameID string) (interface{}, error) {
+	s.mu.RLock()
+	gamesConfig := s.gamesConfig
+	s.mu.RUnlock()
+	if gamesConfig == nil {
+		return nil, fmt.Errorf("no games configuration is loaded")
+	}
+
+	var games []config.GameConfig
+	if gameID != "" {
+		game, exists := s.resolveGameId(gamesConfig, gameID)
+		if !exists {
+			return nil, fmt.Errorf("game '%s' not found", gameID)
+		}
+		games = []config.GameConfig{*game}
+	} else {
+		games = gamesConfig.ListGames()
+		sort.Slice(games, func(i, j int) bool {
+			return games[i].ID < games[j].ID
+		})
+	}
+
+	items := make([]map[string]interface{}, 0, len(games))
+	for _, game := range games {
+		items = append(items, s.gameStatusStructured(game, s.checkGameStatus(game.ID)))
+	}
+
+	return map[string]interface{}{
+		"pid":        os.Getpid(),
+		"instanceId": s.instanceID,
+		"games":      items,
+	}, nil
+}
+
+// ControlReload re-reads the games configuration from disk and replaces the
+// game and cluster definitions used by the running server. Games that are
+// already running keep their controllers; removed games can still be stopped
+// until this server exits. If a setting that is only read at startup changed,
+// nothing is applied and the error names the settings that need a restart.
+// Clients get tools/list_changed when a game or cluster definition changed.
+func (s *Server) ControlReload() (interface{}, error) {
+	s.mu.RLock()
+	gamesConfig := s.gamesConfig
+	configDir := s.configDir
+	s.mu.RUnlock()
+	if gamesConfig == nil {
+		return nil, fmt.Errorf("no games configuration is loaded")
+	}
+
+	reloaded, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		return nil, err
+	}
+	if changed := gamesConfig.RestartRequiredChanges(reloaded); len(changed) > 0 {
+		return nil, fmt.Errorf("changed settings require a server restart: %s", strings.Join(changed, ", "))
+	}
+
+	previous := make(map[string]config.GameConfig)
+	for _, game := range gamesConfig.ListGames() {
+		previous[game.ID] = game
+	}
+	added := []string{}
+	changed := []string{}
+	for id, game := range reloaded.Games {
+		if old, exists := previous[id]; !exists {
+			added = append(added, id)
+		} else if !reflect.DeepEqual(old, game) {
+			changed = append(changed, id)
+		}
+		delete(previous, id)
+	}
+	removed := []string{}
+	for id := range previous {
+		removed = append(removed, id)
+	}
+	sort.Strings(added)
+	sort.Strings(changed)
+	sort.Strings(removed)
+	clustersChanged := !reflect.DeepEqual(gamesConfig.ListClusters(), reloaded.ListClusters())
+
+	gamesConfig.ReplaceDefinitions(reloaded.Games, reloaded.Clusters)
+	s.log.Infow("reloaded games configuration", "gameCount", len(reloaded.Games), "added", added, "changed", changed, "removed", removed)
+	if len(added) > 0 || len(changed) > 0 || len(removed) > 0 || clustersChanged {
+		s.SendToolsListChangedNotification()
+	}
+
+	return map[string]interface{}{
+		"gameCount": len(reloaded.Games),
+		"added":     added,
+		"changed":   changed,
+		"removed":   removed,
+	}, nil
+}
+
+// ControlCallTool runs one of controlTools for 'gabs control'. tool may be
+// given as games.start, games_start or start. The call goes through the same
+// path as an MCP client's tools/call, so game resolution, tool policy,
+// confirmation and shutdown handling all apply.
+func (s *Server) ControlCallTool(tool string, args map[string]interface{}) (interface{}, error) {
+	short := strings.TrimPrefix(strings.TrimPrefix(tool, "games."), "games_")
+	name, ok := controlTools[short]
+	if !ok {
+		names := make([]string, 0, len(controlTools))
+		for _, name := range controlTools {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("tool '%s' cannot be called from the control socket, use one of: %s", tool, strings.Join(names, ", "))
+	}
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+
+	response := s.HandleMessage(&Message{
+		JSONRPC: "2.0",
+		ID:      fmt.Sprintf("control-%d", controlCallID.Add(1)),
+		Method:  "tools/call",
+		Params:  map[string]interface{}{"name": name, "arguments": args},
+	})
+	if response == nil {
+		return nil, fmt.Errorf("%s returned no response", name)
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("%s", response.Error.Message)
+	}
+	return response.Result, nil
+}
@@ -0,0 +1,136 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestControlReloadReplacesGameDefinitions(t *testing.T) {
+	configDir := t.TempDir()
+	gamesConfig := &config.GamesConfig{Version: "1.0"}
+	for _, id := range []string{"factory", "adventure"} {
+		if err := gamesConfig.AddGame(config.GameConfig{ID: id, Name: id, LaunchMode: "DirectPath", Target: "/bin/true"}); err != nil {
+			t.Fatalf("add game: %v", err)
+		}
+	}
+	if err := config.SaveGamesConfigToDir(gamesConfig, configDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	gamesConfig, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.SetConfigDir(configDir)
+	server.RegisterGameManagementTools(gamesConfig, 0, 0)
+
+	updated, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	updated.RemoveGame("adventure")
+	if err := updated.AddGame(config.GameConfig{ID: "puzzle", Name: "Puzzle", LaunchMode: "DirectPath", Target: "/bin/true"}); err != nil {
+		t.Fatalf("add game: %v", err)
+	}
+	if err := config.SaveGamesConfigToDir(updated, configDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	result, err := server.ControlReload()
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	summary := result.(map[string]interface{})
+	if summary["gameCount"] != 2 || summary["added"].([]string)[0] != "puzzle" || summary["removed"].([]string)[0] != "adventure" {
+		t.Fatalf("unexpected reload summary: %#v", summary)
+	}
+
+	result, err = server.ControlStatus("")
+	if err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	games := result.(map[string]interface{})["games"].([]map[string]interface{})
+	if len(games) != 2 || games[0]["gameId"] != "factory" || games[1]["gameId"] != "puzzle" {
+		t.Fatalf("unexpected status after reload: %#v", games)
+	}
+
+	if _, err := server.ControlStatus("adventure"); err == nil {
+		t.Fatal("expected removed game to be unknown after reload")
+	}
+}
+
+func TestControlReloadRejectsSettingsThatNeedARestart(t *testing.T) {
+	configDir := t.TempDir()
+	initial := &config.GamesConfig{Version: "1.0"}
+	if err := initial.AddGame(config.GameConfig{ID: "factory", Name: "factory", LaunchMode: "DirectPath", Target: "/bin/true"}); err != nil {
+		t.Fatalf("add game: %v", err)
+	}
+	if err := config.SaveGamesConfigToDir(initial, configDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	gamesConfig, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.SetConfigDir(configDir)
+	server.RegisterGameManagementTools(gamesConfig, 0, 0)
+
+	updated, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	updated.EnableExec = true
+	updated.APIKey = "rotated-key"
+	if err := updated.AddGame(config.GameConfig{ID: "puzzle", Name: "Puzzle", LaunchMode: "DirectPath", Target: "/bin/true"}); err != nil {
+		t.Fatalf("add game: %v", err)
+	}
+	if err := config.SaveGamesConfigToDir(updated, configDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	_, err = server.ControlReload()
+	if err == nil || !strings.Contains(err.Error(), "require a server restart: apiKey, enableExec") {
+		t.Fatalf("expected restart-required error, got %v", err)
+	}
+	if _, exists := gamesConfig.GetGame("puzzle"); exists {
+		t.Fatal("a rejected reload must not apply game changes")
+	}
+}
+
+func TestControlCallToolRunsGameTools(t *testing.T) {
+	requireSleepForTest(t)
+	gamesConfig := &config.GamesConfig{Version: "1.0", Games: map[string]config.GameConfig{
+		"factory": sleepingGameForTest("factory", "Factory"),
+	}}
+	server, _ := newGamesTestServer(t, gamesConfig)
+	trackSleepingGameForTest(t, server, "factory")
+
+	var result ToolResult
+	response, err := server.ControlCallTool("stop", map[string]interface{}{"gameId": "factory"})
+	if err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+	if err := decodeResult(response, &result); err != nil || result.IsError {
+		t.Fatalf("unexpected stop result: %#v %v", result, err)
+	}
+	waitForGameStatus(t, server, "factory", "stopped")
+
+	response, err = server.ControlCallTool("games_status", map[string]interface{}{"gameId": "missing"})
+	if err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	result = ToolResult{}
+	if err := decodeResult(response, &result); err != nil || !result.IsError {
+		t.Fatalf("expected an unknown game to be reported as a tool error, got %#v %v", result, err)
+	}
+
+	if _, err := server.ControlCallTool("games.connect", map[string]interface{}{"gameId": "factory"}); err == nil || !strings.Contains(err.Error(), "games.start") {
+		t.Fatalf("expected games.connect to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package mcp
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+const (
+	// dashboardURI is the resource with a one-shot overview of every game.
+	dashboardURI = "gabs://dashboard"
+	// dashboardRecentEvents is how many of a game's latest GABP events the
+	// dashboard shows.
+	dashboardRecentEvents = 10
+)
+
+// dashboardGame summarizes one game for the dashboard: its state, GABP
+// connection, tools, latest events, blocking attention and resource usage.
+func (s *Server) dashboardGame(game config.GameConfig) map[string]interface{} {
+	status := s.checkGameStatus(game.ID)
+	item := map[string]interface{}{
+		"gameId":       game.ID,
+		"name":         game.Name,
+		"status":       status,
+		"toolCount":    len(s.getGameSpecificTools(game.ID)),
+		"recentEvents": []map[string]interface{}{},
+	}
+	for key, value := range s.gameStateStructured(game.ID, status) {
+		item[key] = value
+	}
+
+	s.mu.RLock()
+	client, _ := s.sessions[game.ID].gabpClient()
+	s.mu.RUnlock()
+	if client != nil && client.IsConnected() {
+		events := recentGameEvents(client, nil, time.Time{}, time.Time{})
+		// recentGameEvents lists events per channel; show the latest overall
+		sort.SliceStable(events, func(i, j int) bool {
+			left, _ := time.Parse(time.RFC3339Nano, events[i]["receivedAt"].(string))
+			right, _ := time.Parse(time.RFC3339Nano, events[j]["receivedAt"].(string))
+			return left.Before(right)
+		})
+		if len(events) > dashboardRecentEvents {
+			events = events[len(events)-dashboardRecentEvents:]
+		}
+		item["recentEvents"] = events
+	}
+	if attention := s.getCurrentBlockingAttention(game.ID); attention != nil {
+		item["attention"] = map[string]interface{}{
+			"attentionId": attention.AttentionID,
+			"severity":    attention.Severity,
+			"summary":     attention.Summary,
+		}
+	}
+	if disconnectNote := s.describeLastGABPDisconnect(game.ID); disconnectNote != "" {
+		item["lastDisconnect"] = disconnectNote
+	}
+	if status != "stopped" {
+		if resources := s.gameResourceUsage(game); resources != nil {
+			item["resources"] = resources
+		}
+	}
+	return item
+}
+
+// dashboardReport aggregates every configured game into one document. It is
+// built on each read, so it is always current.
+func (s *Server) dashboardReport(gamesConfig *config.GamesConfig) map[string]interface{} {
+	games := make([]map[string]interface{}, 0)
+	running, connected, tools := 0, 0, 0
+	if gamesConfig != nil {
+		for _, game := range gamesConfig.ListGames() {
+			item := s.dashboardGame(game)
+			if item["state"] == gameStateRunning {
+				running++
+			}
+			if item["gabpConnected"] == true {
+				connected++
+			}
+			tools += item["toolCount"].(int)
+			games = append(games, item)
+		}
+	}
+
+	s.mu.RLock()
+	now := s.clock.Now()
+	s.mu.RUnlock()
+	return map[string]interface{}{
+		"generatedAt": now.UTC().Format(time.RFC3339),
+		"summary": map[string]interface{}{
+			"games":         len(games),
+			"running":       running,
+			"gabpConnected": connected,
+			"toolCount":     tools,
+		},
+		"games": games,
+	}
+}
+
+func (s *Server) registerDashboardResource(gamesConfig *config.GamesConfig) {
+	s.RegisterResource(Resource{
+		URI:         dashboardURI,
+		Name:        "GABS Dashboard",
+		Description: "Overview of every game: state, GABP connection, tool count, latest events, blocking attention and CPU and memory use",
+		MimeType:    "application/json",
+	}, func() ([]Content, error) {
+		data, err := json.Marshal(s.dashboardReport(gamesConfig))
+		if err != nil {
+			return nil, err
+		}
+		return []Content{{Type: "text", Text: string(data)}}, nil
+	})
+}
@@ -0,0 +1,56 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func TestDashboardResourceSummarizesEveryGame(t *testing.T) {
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"adventure": sleepingGameForTest("adventure", "Adventure"),
+			"factory":   sleepingGameForTest("factory", "Factory"),
+		},
+	})
+	registerFactoryToolForTest(server)
+
+	response := server.HandleMessage(&Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "resources/read", Params: map[string]interface{}{"uri": dashboardURI}})
+	var read struct {
+		Contents []Content `json:"contents"`
+	}
+	if response.Error != nil || decodeResult(response.Result, &read) != nil || len(read.Contents) != 1 {
+		t.Fatalf("unexpected %s read: %#v", dashboardURI, response)
+	}
+	var dashboard struct {
+		GeneratedAt string `json:"generatedAt"`
+		Summary     struct {
+			Games         int `json:"games"`
+			Running       int `json:"running"`
+			GABPConnected int `json:"gabpConnected"`
+			ToolCount     int `json:"toolCount"`
+		} `json:"summary"`
+		Games []struct {
+			GameID        string        `json:"gameId"`
+			State         string        `json:"state"`
+			GABPConnected bool          `json:"gabpConnected"`
+			ToolCount     int           `json:"toolCount"`
+			RecentEvents  []interface{} `json:"recentEvents"`
+		} `json:"games"`
+	}
+	if err := json.Unmarshal([]byte(read.Contents[0].Text), &dashboard); err != nil {
+		t.Fatalf("failed to decode dashboard: %v", err)
+	}
+	if dashboard.GeneratedAt == "" || dashboard.Summary.Games != 2 || dashboard.Summary.Running != 0 || dashboard.Summary.GABPConnected != 0 || dashboard.Summary.ToolCount != 1 {
+		t.Fatalf("unexpected summary: %#v", dashboard)
+	}
+	for _, game := range dashboard.Games {
+		if game.State != gameStateStopped || game.GABPConnected || game.RecentEvents == nil {
+			t.Fatalf("expected a stopped game with an empty event list, got %#v", game)
+		}
+		if wantTools := map[string]int{"adventure": 0, "factory": 1}[game.GameID]; game.ToolCount != wantTools {
+			t.Fatalf("expected %d tools for %s, got %d", wantTools, game.GameID, game.ToolCount)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+var dispatchFuzzSeeds = []string{
+	strictInitializeLine,
+	`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+	`{"jsonrpc":"2.0","id":2,"method":"tools/list","params":{"cursor":"bogus"}}`,
+	`{"jsonrpc":"2.0","id":"x","method":"tools/call","params":{"name":"test.echo","arguments":{"text":"hi","count":3}}}`,
+	`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"test.echo","arguments":"not an object"}}`,
+	`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":[1,2]}`,
+	`{"jsonrpc":"2.0","id":5,"method":"resources/read","params":{"uri":"gab://missing?since=%zz"}}`,
+	`{"jsonrpc":"2.0","id":6,"method":"logging/setLevel","params":{"level":7}}`,
+	`{"jsonrpc":"2.0","id":{"nested":true},"method":"ping"}`,
+	`{"jsonrpc":"2.0","id":null,"method":"ping"}`,
+	`{"jsonrpc":"2.0","id":7,"result":{}}`,
+	`[{"jsonrpc":"2.0","id":8,"method":"ping"},{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":8}},3]`,
+	`[]`,
+	`{"jsonrpc":"2.0","id":9,"method":"\xff\xfe"}`,
+	`{"jsonrpc":"1.0","id":10,"method":"ping"`,
+	`"just a string"`,
+	`null`,
+}
+
+// FuzzHandleRawMessage feeds arbitrary bytes to the MCP dispatcher in strict
+// and lenient mode. Whatever comes in, GABS must not panic, strict mode must
+// answer instead of failing, and every answer must be valid JSON.
+func FuzzHandleRawMessage(f *testing.F) {
+	for _, seed := range dispatchFuzzSeeds {
+		f.Add([]byte(seed), false)
+		f.Add([]byte(seed), true)
+	}
+
+	servers := map[bool]*Server{}
+	for _, strict := range []bool{false, true} {
+		server := NewServerForTesting(util.NewLogger("error"))
+		server.SetStrictMCP(strict)
+		server.RegisterTool(Tool{
+			Name: "test.echo",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"text":  map[string]interface{}{"type": "string"},
+					"count": map[string]interface{}{"type": "integer", "minimum": 0},
+				},
+				"required": []string{"text"},
+			},
+		}, func(args map[string]interface{}) (*ToolResult, error) {
+			text, _ := args["text"].(string)
+			return &ToolResult{Content: []Content{{Type: "text", Text: text}}}, nil
+		})
+		servers[strict] = server
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, strict bool) {
+		server := servers[strict]
+		session := &mcpSession{transport: clientTransportStdio}
+		defer server.closeSession(session)
+
+		// Go through initialize first half of the time, so strict mode gets
+		// past its handshake check.
+		if len(data)%2 == 0 {
+			if _, err := server.handleRawMessage([]byte(strictInitializeLine), session, config.AccessRoleLocal); err != nil {
+				t.Fatalf("initialize failed: %v", err)
+			}
+		}
+
+		response, err := server.handleRawMessage(data, session, config.AccessRoleLocal)
+		if err != nil {
+			if strict {
+				t.Fatalf("strict mode should answer %q instead of failing: %v", data, err)
+			}
+			return
+		}
+		if response == nil {
+			return
+		}
+		encoded, err := json.Marshal(response)
+		if err != nil {
+			t.Fatalf("response to %q does not encode: %v", data, err)
+		}
+		if !json.Valid(encoded) {
+			t.Fatalf("response to %q is not valid JSON: %s", data, encoded)
+		}
+	})
+}
@@ -76,7 +76,28 @@ func TestDynamicToolDiscoveryWorkflow(t *testing.T) {
 			"games.connect",
 			"games.get_attention",
 			"games.ack_attention",
+			"games.events",
+			"games.infer_stop_process",
+			"games.update",
+			"games.rotate_token",
+			"games.enable_tools",
+			"games.disable_tools",
+			"games.validate",
+			"games.export",
+			"games.import",
+			"games.schedule",
+			"games.saves.list",
+			"games.saves.backup",
+			"games.saves.restore",
+			"games.bridges.list",
+			"games.bridges.install",
+			"games.bridges.enable",
+			"games.restart",
+			"games.logs",
+			"games.health",
 			"games.call_tool",
+			"system.budgets",
+			"server.set_log_level",
 		}
 		for _, tool := range expectedCoreTools {
 			if !strings.Contains(responseStr, tool) {
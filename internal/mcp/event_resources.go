@@ -0,0 +1,172 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+// gameEventUpdateDelay coalesces a burst of GABP events into a single
+// resources/updated notification for the game's events resource.
+const gameEventUpdateDelay = 250 * time.Millisecond
+
+func gameEventsURI(gameID string) string {
+	return fmt.Sprintf("gab://%s/events/recent", gameID)
+}
+
+// gameEventBridge is the GABP client whose events are mirrored for a game and
+// whether an update notification for them is already scheduled.
+type gameEventBridge struct {
+	client  *gabp.Client
+	pending bool
+}
+
+// exposeGABPEvents registers gab://<gameId>/events/recent when the bridge
+// advertises event channels and subscribes to all of them, so new events reach
+// clients as notifications/resources/updated for that URI. It reports whether
+// the resource was registered. Calling it again for the same client does not
+// subscribe twice. Events on channels the game's alerts cover are also
+// pushed to clients as alerts.
+func (s *Server) exposeGABPEvents(client *gabp.Client, gameID string) bool {
+	capabilities := client.GetCapabilities()
+	if len(capabilities.Events) == 0 {
+		return false
+	}
+
+	s.RegisterGameQueryResource(gameID, Resource{
+		URI:         gameEventsURI(gameID),
+		Name:        fmt.Sprintf("%s Recent Events", gameID),
+		Description: fmt.Sprintf("Recent GABP events of game %s, the last %d per channel. Filter with ?channel=<name>&since=<RFC 3339>&until=<RFC 3339> or ?sinceSeconds=<n>.", gameID, gabp.DefaultEventHistorySize),
+		MimeType:    "application/json",
+	}, func(query url.Values) ([]Content, error) {
+		return gameEventsResourceContent(client, gameID, query)
+	})
+
+	if !gabp.SupportsEventSubscription(capabilities) {
+		return true
+	}
+	if s.gamesConfig != nil {
+		if game, exists := s.lookupGame(s.gamesConfig, gameID); exists {
+			s.registerGameAlertsResource(*game)
+		}
+	}
+
+	s.mu.Lock()
+	if bridge := s.gabpEvents[gameID]; bridge != nil && bridge.client == client {
+		s.mu.Unlock()
+		return true
+	}
+	if s.gabpEvents == nil {
+		s.gabpEvents = make(map[string]*gameEventBridge)
+	}
+	s.gabpEvents[gameID] = &gameEventBridge{client: client}
+	s.mu.Unlock()
+
+	go func() {
+		if err := client.SubscribeEvents(capabilities.Events, func(channel string, seq int, payload interface{}) {
+			s.touchGame(gameID)
+			switch channel {
+			case gabpProgressChannel:
+				s.forwardGameProgress(gameID, payload)
+			case gabp.ChatMessageChannel:
+				s.recordChatMessage(gameID, seq, payload)
+			case gabp.ToolsListChangedChannel:
+				if err := s.resyncGABPTools(client, gameID); err != nil {
+					s.gabpLog.Warnw("failed to resync GABP tools", "gameId", gameID, "error", err)
+				}
+			}
+			s.raiseGameAlert(gameID, channel, seq, payload)
+			s.emitWebhookEvent(config.WebhookEventGABP, gameID, channel, map[string]interface{}{"seq": seq, "payload": payload})
+			s.scheduleGameEventUpdate(gameID, client)
+		}); err != nil {
+			s.log.Warnw("failed to subscribe to GABP events", "gameId", gameID, "channels", capabilities.Events, "error", err)
+
+			// Let the next resource sync try again.
+			s.mu.Lock()
+			if bridge := s.gabpEvents[gameID]; bridge != nil && bridge.client == client {
+				delete(s.gabpEvents, gameID)
+			}
+			s.mu.Unlock()
+		}
+	}()
+	return true
+}
+
+// scheduleGameEventUpdate sends resources/updated for the game's events
+// resource after gameEventUpdateDelay, unless a notification is already due.
+func (s *Server) scheduleGameEventUpdate(gameID string, client *gabp.Client) {
+	s.mu.Lock()
+	bridge := s.gabpEvents[gameID]
+	if bridge == nil || bridge.client != client || bridge.pending {
+		s.mu.Unlock()
+		return
+	}
+	bridge.pending = true
+	clock := s.clock
+	s.mu.Unlock()
+
+	go func() {
+		<-clock.After(gameEventUpdateDelay)
+		s.mu.Lock()
+		bridge.pending = false
+		s.mu.Unlock()
+		s.SendResourceUpdatedNotification(gameEventsURI(gameID))
+	}()
+}
+
+func gameEventsResourceContent(client *gabp.Client, gameID string, query url.Values) ([]Content, error) {
+	since, until, err := parseEventTimeRange(query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	content := map[string]interface{}{
+		"gameId":   gameID,
+		"channels": client.GetCapabilities().Events,
+		"events":   recentGameEvents(client, query["channel"], since, until),
+	}
+	if dropped := client.DroppedEvents(); len(dropped) > 0 {
+		content["droppedEvents"] = dropped
+	}
+	data, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	return []Content{{Type: "text", Text: string(data)}}, nil
+}
+
+// parseEventTimeRange reads since and until as RFC 3339 times, or sinceSeconds
+// as a window ending at now. Missing bounds are returned as the zero time.
+func parseEventTimeRange(query url.Values, now time.Time) (time.Time, time.Time, error) {
+	var since, until time.Time
+	if raw := query.Get("since"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return since, until, fmt.Errorf("since must be an RFC 3339 time: %w", err)
+		}
+		since = parsed
+	}
+	if raw := query.Get("sinceSeconds"); raw != "" {
+		if !since.IsZero() {
+			return since, until, fmt.Errorf("use either since or sinceSeconds, not both")
+		}
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds <= 0 {
+			return since, until, fmt.Errorf("sinceSeconds must be a positive integer")
+		}
+		since = now.Add(-time.Duration(seconds) * time.Second)
+	}
+	if raw := query.Get("until"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return since, until, fmt.Errorf("until must be an RFC 3339 time: %w", err)
+		}
+		until = parsed
+	}
+	return since, until, nil
+}
@@ -0,0 +1,177 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// serveTestGabpSessionWithEventSubscription advertises two event channels and
+// pushes one event on each once GABS subscribes to both.
+func serveTestGabpSessionWithEventSubscription(listener net.Listener, expectedToken string, done chan<- error) {
+	conn, err := listener.Accept()
+	if err != nil {
+		done <- err
+		return
+	}
+	defer conn.Close()
+
+	reader := util.NewLSPFrameReader(conn)
+	writer := util.NewLSPFrameWriter(conn)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+			done <- err
+			return
+		}
+		data, err := reader.ReadMessage()
+		if err != nil {
+			var netErr net.Error
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || (errors.As(err, &netErr) && netErr.Timeout()) {
+				done <- nil
+				return
+			}
+			done <- err
+			return
+		}
+
+		var request util.GABPMessage
+		if err := json.Unmarshal(data, &request); err != nil {
+			done <- err
+			return
+		}
+
+		switch request.Method {
+		case "session/hello":
+			params, _ := request.Params.(map[string]interface{})
+			if token, _ := params["token"].(string); token != expectedToken {
+				done <- fmt.Errorf("unexpected handshake token: %q", token)
+				return
+			}
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, gabp.SessionWelcomeResult{
+				AgentID:       "adventure",
+				App:           gabp.AppInfo{Name: "ExampleGameBridge", Version: "0.1.0"},
+				Capabilities:  gabp.Capabilities{Methods: []string{"tools/list", gabp.EventsSubscribeMethod}, Events: []string{"world/loaded", "system/log"}},
+				SchemaVersion: "1.0",
+			}))
+		case "tools/list":
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"tools": []map[string]interface{}{}}))
+		case gabp.EventsSubscribeMethod:
+			params, _ := request.Params.(map[string]interface{})
+			if channels, _ := params["channels"].([]interface{}); len(channels) != 2 {
+				done <- fmt.Errorf("expected a subscription to both advertised channels, got %#v", params)
+				return
+			}
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"channels": params["channels"]}))
+			if err == nil {
+				err = writer.WriteJSON(util.NewGABPEvent("world/loaded", 1, map[string]interface{}{"world": "main"}))
+			}
+			if err == nil {
+				err = writer.WriteJSON(util.NewGABPEvent("system/log", 1, map[string]interface{}{"message": "autosave"}))
+			}
+		default:
+			err = fmt.Errorf("unexpected method: %s", request.Method)
+		}
+		if err != nil {
+			done <- err
+			return
+		}
+	}
+}
+
+func readGameEventsForTest(t *testing.T, server *Server, uri string) ([]interface{}, *Message) {
+	t.Helper()
+	response := server.HandleMessage(&Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "resources/read", Params: map[string]interface{}{"uri": uri}})
+	if response == nil || response.Error != nil {
+		return nil, response
+	}
+	var result struct {
+		Contents []Content `json:"contents"`
+	}
+	if err := decodeResult(response.Result, &result); err != nil || len(result.Contents) != 1 {
+		t.Fatalf("unexpected resources/read result: %#v (%v)", response.Result, err)
+	}
+	var body struct {
+		Events []interface{} `json:"events"`
+	}
+	if err := json.Unmarshal([]byte(result.Contents[0].Text), &body); err != nil {
+		t.Fatalf("events resource is not JSON: %v", err)
+	}
+	return body.Events, response
+}
+
+func TestGABPEventsAreMirroredAsRecentEventsResource(t *testing.T) {
+	server, configDir := newGamesTestServer(t, roamingGamesConfig())
+	session := &mcpSession{}
+	session.subscribe("gab://adventure/events/recent")
+	client := server.addClient(clientTransportStdio, session)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	writeBridgeJSONForTest(t, configDir, "adventure", listener.Addr().(*net.TCPAddr).Port, "events-token")
+
+	serverDone := make(chan error, 1)
+	go serveTestGabpSessionWithEventSubscription(listener, "events-token", serverDone)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_connect failed: %#v", result)
+	}
+
+	uri := "gab://adventure/events/recent"
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		events, response := readGameEventsForTest(t, server, uri)
+		if response != nil && response.Error != nil {
+			t.Fatalf("reading %s failed: %#v", uri, response.Error)
+		}
+		if len(events) == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected both subscribed events in %s, got %#v", uri, events)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	gotUpdate := false
+	timeout := time.After(2 * time.Second)
+	for !gotUpdate {
+		select {
+		case msg := <-client.outbox:
+			params, _ := msg.Params.(map[string]interface{})
+			gotUpdate = msg.Method == "notifications/resources/updated" && params["uri"] == uri
+		case <-timeout:
+			t.Fatal("expected a resources/updated notification for the events resource")
+		}
+	}
+
+	events, _ := readGameEventsForTest(t, server, uri+"?channel=world/loaded")
+	if len(events) != 1 || events[0].(map[string]interface{})["channel"] != "world/loaded" {
+		t.Fatalf("expected the channel filter to keep only world/loaded, got %#v", events)
+	}
+	if events, _ := readGameEventsForTest(t, server, uri+"?until=2000-01-01T00:00:00Z"); len(events) != 0 {
+		t.Fatalf("expected no events before 2000, got %#v", events)
+	}
+	if events, _ := readGameEventsForTest(t, server, uri+"?sinceSeconds=60"); len(events) != 2 {
+		t.Fatalf("expected both events within the last minute, got %#v", events)
+	}
+	if _, response := readGameEventsForTest(t, server, uri+"?since=yesterday"); response == nil || response.Error == nil {
+		t.Fatalf("expected an invalid since value to fail, got %#v", response)
+	}
+	if _, response := readGameEventsForTest(t, server, "gab://adventure/state?channel=x"); response == nil || response.Error == nil {
+		t.Fatalf("expected a query on a resource without query support to be not found, got %#v", response)
+	}
+
+	server.CleanupGameResources("adventure")
+	if err := <-serverDone; err != nil {
+		t.Fatalf("GABP session failed: %v", err)
+	}
+}
@@ -2,7 +2,6 @@ package mcp
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/pardeike/gabs/internal/gabp"
@@ -42,7 +41,7 @@ func newServerGABPConnector(server *Server, backoffMin, backoffMax time.Duration
 
 	return &ServerGABPConnector{
 		server:              server,
-		log:                 server.log,
+		log:                 server.gabpLog,
 		backoffMin:          backoffMin,
 		backoffMax:          backoffMax,
 		mirrorSynchronously: mirrorSynchronously,
@@ -52,35 +51,46 @@ func newServerGABPConnector(server *Server, backoffMin, backoffMax time.Duration
 
 // AttemptConnection implements the GABPConnector interface
 func (c *ServerGABPConnector) AttemptConnection(ctx context.Context, gameID string, port int, token string) error {
-	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	addr, err := c.server.gabpDialAddress(ctx, gameID, port)
+	if err != nil {
+		c.log.Debugw("GABP tunnel setup failed", "gameId", gameID, "port", port, "error", err)
+		return err
+	}
 	c.log.Debugw("attempting GABP connection for game", "gameId", gameID, "addr", addr)
 
 	// Create GABP client
-	client := gabp.NewClient(c.log)
+	client := c.server.newGABPClient(gameID)
 	client.SetDisconnectHandler(func(err error) {
 		c.server.HandleUnexpectedGABPDisconnect(gameID, client, err)
 	})
 
 	// Store client reference for cleanup
 	c.server.mu.Lock()
-	c.server.gabpClients[gameID] = client
+	c.server.sessionLocked(gameID).attach(client, c.server.clock.Now())
 	delete(c.server.gabpDisconnects, gameID)
 	c.server.mu.Unlock()
 
-	err := client.Connect(ctx, addr, token, c.backoffMin, c.backoffMax)
+	err = c.server.openGABPConnection(ctx, gameID, client, addr, token, c.backoffMin, c.backoffMax)
 	if err != nil {
 		c.log.Debugw("GABP connection failed", "gameId", gameID, "addr", addr, "error", err)
 
 		// Clean up client reference on failure
 		c.server.mu.Lock()
-		if current, exists := c.server.gabpClients[gameID]; exists && current == client {
-			delete(c.server.gabpClients, gameID)
+		if session := c.server.sessionLocked(gameID); session.client == client {
+			session.detach()
 		}
 		c.server.mu.Unlock()
+		c.server.closeFailedTunnel(gameID, addr)
 		return err
 	}
 
 	c.log.Infow("GABP connection established", "gameId", gameID, "addr", addr)
+	c.server.rememberGABPTarget(gameID, gabpReconnectTarget{
+		port:       port,
+		token:      token,
+		backoffMin: c.backoffMin,
+		backoffMax: c.backoffMax,
+	})
 
 	if !c.mirrorSynchronously {
 		c.startAsyncToolMirroring(gameID, client)
@@ -88,13 +98,27 @@ func (c *ServerGABPConnector) AttemptConnection(ctx context.Context, gameID stri
 	}
 
 	if err := c.setupToolMirroring(ctx, gameID, client); err != nil {
-		c.server.HandleUnexpectedGABPDisconnect(gameID, client, err)
+		c.server.handleGABPDisconnect(gameID, client, err, false)
 		return err
 	}
 
 	return nil
 }
 
+// openGABPConnection connects client to the game's bridge at addr, or for
+// games with bridgeMode listen waits there for the bridge to connect in.
+func (s *Server) openGABPConnection(ctx context.Context, gameID string, client *gabp.Client, addr string, token string, backoffMin, backoffMax time.Duration) error {
+	s.mu.RLock()
+	gamesConfig := s.gamesConfig
+	s.mu.RUnlock()
+	if gamesConfig != nil {
+		if game, exists := gamesConfig.GetGame(gameID); exists && game.ListensForBridge() {
+			return client.Accept(ctx, addr, token)
+		}
+	}
+	return client.Connect(ctx, addr, token, backoffMin, backoffMax)
+}
+
 func (c *ServerGABPConnector) startAsyncToolMirroring(gameID string, client *gabp.Client) {
 	go func() {
 		if c.asyncMirrorDelay > 0 {
@@ -104,7 +128,7 @@ func (c *ServerGABPConnector) startAsyncToolMirroring(gameID string, client *gab
 			return
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(c.server.gameContext(gameID), 30*time.Second)
 		defer cancel()
 
 		if err := c.setupToolMirroring(ctx, gameID, client); err != nil {
@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+// jsonRPCGameBusy is the JSON-RPC error code for requests a game's bridge did
+// not get to in time.
+const jsonRPCGameBusy = -32004
+
+// gabpErrorStructured describes a failed GABP request for StructuredContent,
+// so agents can react to its kind rather than parse the message.
+func gabpErrorStructured(gameID string, err error) map[string]interface{} {
+	detail := map[string]interface{}{
+		"kind":    gabp.ErrorKind(err),
+		"message": err.Error(),
+	}
+	if code := gabp.ErrorCode(err); code != 0 {
+		detail["gabpCode"] = code
+	}
+	return map[string]interface{}{
+		"gameId": gameID,
+		"error":  detail,
+	}
+}
+
+// gabpErrorResponse answers a request that failed with err. GABP errors get
+// the JSON-RPC code of their kind; other errors, and GABP errors of kind
+// internal, get fallbackCode and fallbackMessage.
+func gabpErrorResponse(id interface{}, err error, fallbackCode int, fallbackMessage string) *Message {
+	kind := gabp.ErrorKind(err)
+	data := map[string]interface{}{"kind": kind, "message": err.Error()}
+	if code := gabp.ErrorCode(err); code != 0 {
+		data["gabpCode"] = code
+	}
+	switch kind {
+	case gabp.ErrorKindNotFound:
+		return NewError(id, -32601, "Not found", data)
+	case gabp.ErrorKindInvalidArgs:
+		return NewError(id, -32602, "Invalid params", data)
+	case gabp.ErrorKindUnauthorized:
+		return NewError(id, jsonRPCUnauthorized, "Unauthorized", data)
+	case gabp.ErrorKindGameBusy:
+		return NewError(id, jsonRPCGameBusy, "Game busy", data)
+	default:
+		return NewError(id, fallbackCode, fallbackMessage, err.Error())
+	}
+}
@@ -0,0 +1,34 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+func TestGABPErrorResponseUsesCodeOfKind(t *testing.T) {
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{&gabp.RequestError{Code: -32602, Message: "count must be positive"}, -32602},
+		{fmt.Errorf("game adventure could not read world: %w", &gabp.RequestError{Code: -32601, Message: "method not found"}), -32601},
+		{fmt.Errorf("%w after 1s", gabp.ErrRequestTimeout), jsonRPCGameBusy},
+		{&gabp.RequestError{Code: -32001, Message: "bad token"}, jsonRPCUnauthorized},
+		{errors.New("handler failed"), -32603},
+	}
+	for _, tc := range cases {
+		response := gabpErrorResponse(1, tc.err, -32603, "Tool execution failed")
+		if response.Error == nil || response.Error.Code != tc.code {
+			t.Errorf("%v: expected code %d, got %+v", tc.err, tc.code, response.Error)
+		}
+	}
+
+	structured := gabpErrorStructured("adventure", &gabp.RequestError{Code: -32602, Message: "count must be positive"})
+	detail := structured["error"].(map[string]interface{})
+	if structured["gameId"] != "adventure" || detail["kind"] != gabp.ErrorKindInvalidArgs || detail["gabpCode"] != -32602 {
+		t.Fatalf("unexpected structured error: %#v", structured)
+	}
+}
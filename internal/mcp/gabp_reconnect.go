@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+// gabpReconnectWindow is how long GABS keeps trying to reach a bridge after an
+// unexpected disconnect, such as the bridge restarting its GABP server on a level
+// reload.
+const gabpReconnectWindow = 2 * time.Minute
+
+// gabpReconnectTarget is the bridge endpoint a game was last connected to and
+// the backoff to use when reconnecting to it.
+type gabpReconnectTarget struct {
+	port       int
+	token      string
+	backoffMin time.Duration
+	backoffMax time.Duration
+}
+
+func (s *Server) rememberGABPTarget(gameID string, target gabpReconnectTarget) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.gabpTargets == nil {
+		s.gabpTargets = make(map[string]gabpReconnectTarget)
+	}
+	s.gabpTargets[gameID] = target
+}
+
+// reconnectGABP dials the bridge again after dropped disconnected, or waits
+// for it to connect in again with bridgeMode listen, and re-mirrors its tools
+// and resources. It gives up after gabpReconnectWindow,
+// when the game stops, or when something else replaced dropped in the
+// meantime, such as games_connect. Agents get tools/list_changed when the
+// reconnected bridge offers a different tool set than before.
+func (s *Server) reconnectGABP(gameID string, dropped *gabp.Client, target gabpReconnectTarget, previousTools []string) {
+	ctx, cancel := context.WithTimeout(s.gameContext(gameID), gabpReconnectWindow)
+	defer cancel()
+
+	replaced := func() bool {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		client, _ := s.sessions[gameID].gabpClient()
+		return client != dropped
+	}
+
+	addr, err := s.gabpDialAddress(ctx, gameID, target.port)
+	if err != nil {
+		s.gabpLog.Warnw("cannot reconnect to GABP bridge", "gameId", gameID, "port", target.port, "error", err)
+		return
+	}
+
+	s.gabpLog.Infow("reconnecting to GABP bridge", "gameId", gameID, "addr", addr)
+	client := s.newGABPClient(gameID)
+	client.SetDisconnectHandler(func(err error) {
+		s.HandleUnexpectedGABPDisconnect(gameID, client, err)
+	})
+	if err := s.openGABPConnection(ctx, gameID, client, addr, target.token, target.backoffMin, target.backoffMax); err != nil {
+		if !replaced() {
+			s.gabpLog.Warnw("gave up reconnecting to GABP bridge", "gameId", gameID, "addr", addr, "error", err)
+		}
+		client.Close()
+		return
+	}
+
+	s.mu.Lock()
+	session := s.sessionLocked(gameID)
+	if session.client != dropped {
+		s.mu.Unlock()
+		client.Close()
+		return
+	}
+	session.attach(client, s.clock.Now())
+	s.clearGABPDisconnectLocked(gameID)
+	s.mu.Unlock()
+
+	connector := newServerGABPConnector(s, target.backoffMin, target.backoffMax, true, 0)
+	if err := connector.setupToolMirroring(ctx, gameID, client); err != nil {
+		s.handleGABPDisconnect(gameID, client, err, false)
+		client.Close()
+		return
+	}
+	s.gabpLog.Infow("reconnected to GABP bridge", "gameId", gameID, "addr", addr)
+
+	s.mu.RLock()
+	currentTools := append([]string(nil), s.sessions[gameID].toolNames()...)
+	s.mu.RUnlock()
+	if !sameToolNames(previousTools, currentTools) {
+		s.SendToolsListChangedNotification()
+	}
+}
+
+func sameToolNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// serveTestGabpToolsSession answers the handshake and lists the given tools.
+// Unless keepOpen is set it then closes the connection, as a bridge restarting
+// on a level reload would.
+func serveTestGabpToolsSession(listener net.Listener, expectedToken string, tools []string, keepOpen bool, done chan<- error) {
+	conn, err := listener.Accept()
+	if err != nil {
+		done <- err
+		return
+	}
+	defer conn.Close()
+
+	reader := util.NewLSPFrameReader(conn)
+	writer := util.NewLSPFrameWriter(conn)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+			done <- err
+			return
+		}
+		data, err := reader.ReadMessage()
+		if err != nil {
+			var netErr net.Error
+			if keepOpen && (errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || (errors.As(err, &netErr) && netErr.Timeout())) {
+				err = nil
+			}
+			done <- err
+			return
+		}
+		var request util.GABPMessage
+		if err := json.Unmarshal(data, &request); err != nil {
+			done <- err
+			return
+		}
+
+		switch request.Method {
+		case "session/hello":
+			params, _ := request.Params.(map[string]interface{})
+			if token, _ := params["token"].(string); token != expectedToken {
+				done <- fmt.Errorf("unexpected handshake token: %q", token)
+				return
+			}
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, gabp.SessionWelcomeResult{
+				AgentID:       "adventure",
+				App:           gabp.AppInfo{Name: "ExampleGameBridge", Version: "0.1.0"},
+				Capabilities:  gabp.Capabilities{Methods: []string{"tools/list", "tools/call"}},
+				SchemaVersion: "1.0",
+			}))
+		case "tools/list":
+			descriptors := []map[string]interface{}{}
+			for _, name := range tools {
+				descriptors = append(descriptors, map[string]interface{}{
+					"name":        name,
+					"inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+				})
+			}
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"tools": descriptors}))
+			if err == nil && !keepOpen {
+				done <- nil
+				return
+			}
+		default:
+			err = fmt.Errorf("unexpected method: %s", request.Method)
+		}
+		if err != nil {
+			done <- err
+			return
+		}
+	}
+}
+
+func TestGABPReconnectsAfterBridgeRestartAndResyncsTools(t *testing.T) {
+	server, configDir := newGamesTestServer(t, roamingGamesConfig())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	writeBridgeJSONForTest(t, configDir, "adventure", listener.Addr().(*net.TCPAddr).Port, "reload-token")
+
+	firstSession := make(chan error, 1)
+	go serveTestGabpToolsSession(listener, "reload-token", []string{"world/status"}, false, firstSession)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_connect failed: %#v", result)
+	}
+	if err := <-firstSession; err != nil {
+		t.Fatalf("first GABP session failed: %v", err)
+	}
+	client := server.addClient(clientTransportStdio, nil)
+	waitForGameStatus(t, server, "adventure", "disconnected")
+
+	secondSession := make(chan error, 1)
+	go serveTestGabpToolsSession(listener, "reload-token", []string{"world/status", "world/reload"}, true, secondSession)
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case msg := <-client.outbox:
+			if msg.Method != "notifications/tools/list_changed" {
+				continue
+			}
+		case <-timeout:
+			t.Fatalf("expected tools/list_changed after the bridge came back with a new tool, tools: %v", server.getGameSpecificTools("adventure"))
+		}
+		break
+	}
+	if tools := server.getGameSpecificTools("adventure"); len(tools) != 2 {
+		t.Fatalf("expected both tools of the restarted bridge to be mirrored, got %v", tools)
+	}
+
+	server.CleanupGABPConnection("adventure")
+	if err := <-secondSession; err != nil {
+		t.Fatalf("second GABP session failed: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"context"
+	"time"
+
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+// gabpReplayTimeout bounds the handshake and mirroring of a replayed session.
+const gabpReplayTimeout = 30 * time.Second
+
+// SetGABPRecorder records the GABP sessions of every game to recorder from
+// now on, for replay with ReplayGABP.
+func (s *Server) SetGABPRecorder(recorder *gabp.Recorder) {
+	s.mu.Lock()
+	s.gabpRecorder = recorder
+	s.mu.Unlock()
+	s.log.Infow("recording GABP sessions")
+}
+
+// ReplayGABP connects every game of recording to its recorded session instead
+// of a running game and mirrors its tools and resources, so agents can work
+// against them offline. The replayed games show as connected without a
+// process. It returns once all sessions are set up or ctx ends.
+func (s *Server) ReplayGABP(ctx context.Context, recording *gabp.Recording) {
+	for _, gameID := range recording.GameIDs() {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := s.replayGame(ctx, gameID, recording); err != nil {
+			s.log.Warnw("failed to replay GABP session", "gameId", gameID, "error", err)
+			continue
+		}
+		s.log.Infow("replaying recorded GABP session", "gameId", gameID, "tools", len(s.getGameSpecificTools(gameID)))
+	}
+}
+
+func (s *Server) replayGame(ctx context.Context, gameID string, recording *gabp.Recording) error {
+	client := s.newGABPClient(gameID)
+	client.SetDisconnectHandler(func(err error) {
+		s.HandleUnexpectedGABPDisconnect(gameID, client, err)
+	})
+
+	s.mu.Lock()
+	s.sessionLocked(gameID).attach(client, s.clock.Now())
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, gabpReplayTimeout)
+	defer cancel()
+	connector := NewServerGABPConnector(s, 0, 0)
+	err := client.Replay(ctx, recording, gameID)
+	if err == nil {
+		err = connector.setupToolMirroring(ctx, gameID, client)
+	}
+	if err != nil {
+		s.handleGABPDisconnect(gameID, client, err, false)
+		s.mu.Lock()
+		if session := s.sessionLocked(gameID); session.client == client {
+			session.detach()
+		}
+		s.mu.Unlock()
+		client.Close()
+		return err
+	}
+	s.notifyGameResourcesUpdated(gameID)
+	return nil
+}
@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+func TestReplayGABPMirrorsRecordedToolsWithoutGame(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	recorded := `{"gameId":"adventure","direction":"sent","at":"2026-01-02T03:04:05Z","message":{"v":"gabp/1","id":"1","type":"request","method":"session/hello","params":{"platform":"linux"}}}
+{"gameId":"adventure","direction":"received","at":"2026-01-02T03:04:05Z","message":{"v":"gabp/1","id":"1","type":"response","result":{"agentId":"adventure","app":{"name":"Adventure","version":"1.0"},"capabilities":{"methods":["tools/list","tools/call"]},"schemaVersion":"1.0"}}}
+{"gameId":"adventure","direction":"sent","at":"2026-01-02T03:04:06Z","message":{"v":"gabp/1","id":"2","type":"request","method":"tools/list","params":{}}}
+{"gameId":"adventure","direction":"received","at":"2026-01-02T03:04:06Z","message":{"v":"gabp/1","id":"2","type":"response","result":{"tools":[{"name":"inventory/get","description":"Read the inventory"}]}}}
+`
+	if err := os.WriteFile(path, []byte(recorded), 0600); err != nil {
+		t.Fatalf("write recording: %v", err)
+	}
+	recording, err := gabp.LoadRecording(path)
+	if err != nil {
+		t.Fatalf("LoadRecording failed: %v", err)
+	}
+
+	server, _ := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{}})
+	server.ReplayGABP(context.Background(), recording)
+	server.mu.RLock()
+	client, attached := server.sessions["adventure"].gabpClient()
+	server.mu.RUnlock()
+	if !attached {
+		t.Fatal("expected a GABP client for the replayed game")
+	}
+	defer client.Close()
+
+	if status := server.checkGameStatus("adventure"); status != "connected" {
+		t.Fatalf("expected the replayed game to show as connected, got %q", status)
+	}
+	tools := server.getGameSpecificTools("adventure")
+	if len(tools) != 1 {
+		t.Fatalf("expected the recorded tool to be mirrored, got %#v", tools)
+	}
+}
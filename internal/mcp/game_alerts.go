@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// gameAlertHistory is how many alerts gab://<gameId>/alerts keeps per game.
+const gameAlertHistory = 50
+
+// gameAlertsLogger is the logger name of alert notifications/message, so
+// clients can tell them from other server messages.
+const gameAlertsLogger = "gabs.alerts"
+
+func gameAlertsURI(gameID string) string {
+	return fmt.Sprintf("gab://%s/alerts", gameID)
+}
+
+// gameAlert is a GABP event that one of the game's alerts turned into a
+// notification.
+type gameAlert struct {
+	Channel    string      `json:"channel"`
+	Seq        int         `json:"seq"`
+	Level      string      `json:"level"`
+	Message    string      `json:"message"`
+	Payload    interface{} `json:"payload,omitempty"`
+	Suppressed int         `json:"suppressed,omitempty"` // Events of the channel held back by the throttle since the last alert
+	RaisedAt   time.Time   `json:"raisedAt"`
+}
+
+// gameAlertLog holds a game's recent alerts and the throttle state of each
+// channel. It outlives GABP connections, so alerts raised just before a crash
+// can still be read.
+type gameAlertLog struct {
+	recent     []gameAlert
+	lastRaised map[string]time.Time
+	suppressed map[string]int
+}
+
+// registerGameAlertsResource exposes gab://<gameId>/alerts for a game with
+// alerts configured.
+func (s *Server) registerGameAlertsResource(game config.GameConfig) {
+	uri := gameAlertsURI(game.ID)
+	s.mu.RLock()
+	_, exists := s.resources[uri]
+	s.mu.RUnlock()
+	if exists || len(game.Alerts) == 0 {
+		return
+	}
+
+	gameID := game.ID
+	s.RegisterResource(Resource{
+		URI:         uri,
+		Name:        fmt.Sprintf("%s Alerts", gameID),
+		Description: fmt.Sprintf("The last %d alerts GABP events of game %s raised. Subscribe to be told about new ones.", gameAlertHistory, gameID),
+		MimeType:    "application/json",
+	}, func() ([]Content, error) {
+		data, err := json.MarshalIndent(map[string]interface{}{
+			"gameId": gameID,
+			"alerts": s.recentGameAlerts(gameID),
+		}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return []Content{{Type: "text", Text: string(data)}}, nil
+	})
+	s.SendResourcesListChangedNotification()
+}
+
+func (s *Server) recentGameAlerts(gameID string) []gameAlert {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	alerts := []gameAlert{}
+	if log := s.gameAlerts[gameID]; log != nil {
+		alerts = append(alerts, log.recent...)
+	}
+	return alerts
+}
+
+// raiseGameAlert turns a GABP event into an alert when one of the game's
+// alerts matches its channel and the channel's throttle has passed. Events
+// held back by the throttle are counted in the next alert of the channel.
+// Alerts are sent as notifications/message to every client and announced as
+// an update of gab://<gameId>/alerts to its subscribers.
+func (s *Server) raiseGameAlert(gameID, channel string, seq int, payload interface{}) {
+	s.mu.RLock()
+	gamesConfig := s.gamesConfig
+	s.mu.RUnlock()
+	if gamesConfig == nil {
+		return
+	}
+	game, exists := s.lookupGame(gamesConfig, gameID)
+	if !exists {
+		return
+	}
+	alertConfig, matched := game.AlertFor(channel)
+	if !matched {
+		return
+	}
+	throttle, _ := alertConfig.ThrottleDuration()
+
+	s.mu.Lock()
+	now := s.clock.Now()
+	if s.gameAlerts == nil {
+		s.gameAlerts = make(map[string]*gameAlertLog)
+	}
+	log := s.gameAlerts[gameID]
+	if log == nil {
+		log = &gameAlertLog{lastRaised: make(map[string]time.Time), suppressed: make(map[string]int)}
+		s.gameAlerts[gameID] = log
+	}
+	if last, raised := log.lastRaised[channel]; raised && throttle > 0 && now.Sub(last) < throttle {
+		log.suppressed[channel]++
+		s.mu.Unlock()
+		return
+	}
+
+	message := alertConfig.Message
+	if message == "" {
+		message = fmt.Sprintf("Game '%s' reported %s.", gameID, channel)
+	}
+	alert := gameAlert{
+		Channel:    channel,
+		Seq:        seq,
+		Level:      alertConfig.AlertLevel(),
+		Message:    message,
+		Payload:    payload,
+		Suppressed: log.suppressed[channel],
+		RaisedAt:   now,
+	}
+	log.lastRaised[channel] = now
+	delete(log.suppressed, channel)
+	log.recent = append(log.recent, alert)
+	if len(log.recent) > gameAlertHistory {
+		log.recent = log.recent[len(log.recent)-gameAlertHistory:]
+	}
+	s.mu.Unlock()
+
+	s.registerGameAlertsResource(*game)
+	data := map[string]interface{}{
+		"message": message,
+		"gameId":  gameID,
+		"channel": channel,
+		"seq":     seq,
+		"payload": payload,
+		"uri":     gameAlertsURI(gameID),
+	}
+	if alert.Suppressed > 0 {
+		data["suppressed"] = alert.Suppressed
+	}
+	s.SendNotification("notifications/message", map[string]interface{}{
+		"level":  alert.Level,
+		"logger": gameAlertsLogger,
+		"data":   data,
+	})
+	s.SendResourceUpdatedNotification(gameAlertsURI(gameID))
+	s.gabpLog.Infow("game alert raised", "gameId", gameID, "channel", channel, "seq", seq, "level", alert.Level, "suppressed", alert.Suppressed)
+}
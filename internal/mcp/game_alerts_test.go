@@ -0,0 +1,53 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestGameAlertsAreThrottledPerChannel(t *testing.T) {
+	game := sleepingGameForTest("factory", "Factory")
+	game.Alerts = []config.AlertConfig{
+		{Channels: []string{"player/died"}, Level: "critical", Message: "A player died.", Throttle: "30s"},
+	}
+	server, _ := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{"factory": game}})
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	server.SetClock(clock)
+	client := server.addClient(clientTransportStdio, nil)
+	defer server.removeClient(client)
+
+	server.raiseGameAlert("factory", "world/saved", 1, nil)
+	server.raiseGameAlert("factory", "player/died", 2, map[string]interface{}{"player": "ada"})
+	server.raiseGameAlert("factory", "player/died", 3, nil)
+	clock.Advance(10 * time.Second)
+	server.raiseGameAlert("factory", "player/died", 4, nil)
+	clock.Advance(30 * time.Second)
+	server.raiseGameAlert("factory", "player/died", 5, nil)
+
+	alerts := server.recentGameAlerts("factory")
+	if len(alerts) != 2 || alerts[0].Seq != 2 || alerts[1].Seq != 5 {
+		t.Fatalf("expected alerts for events 2 and 5, got %#v", alerts)
+	}
+	if alerts[0].Level != "critical" || alerts[0].Message != "A player died." || alerts[1].Suppressed != 2 {
+		t.Fatalf("unexpected alerts: %#v", alerts)
+	}
+
+	messages := 0
+	for len(client.outbox) > 0 {
+		msg := <-client.outbox
+		if msg.Method == "notifications/message" {
+			messages++
+		}
+	}
+	if messages != 2 {
+		t.Fatalf("expected one notifications/message per alert, got %d", messages)
+	}
+
+	contents, err := server.resources[gameAlertsURI("factory")].Handler()
+	if err != nil || len(contents) != 1 {
+		t.Fatalf("expected the alerts resource to be readable, got %v, %v", contents, err)
+	}
+}
@@ -0,0 +1,285 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+// gameChatHistory is how many chat messages GABS keeps per game.
+const gameChatHistory = 200
+
+// gameChatHistoryDefaultLimit is how many messages the chat history tool
+// returns unless asked for more.
+const gameChatHistoryDefaultLimit = 50
+
+// The GABP names of the chat tools GABS adds for bridges that support chat.
+// chat/history is answered by GABS from the messages it buffered.
+const (
+	chatSendToolName    = "chat/send"
+	chatHistoryToolName = "chat/history"
+)
+
+func gameChatURI(gameID string) string {
+	return fmt.Sprintf("gab://%s/chat", gameID)
+}
+
+// chatEntry is a chat message the bridge reported.
+type chatEntry struct {
+	gabp.ChatMessage
+	Seq        int       `json:"seq"`
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+// recordChatMessage buffers the chat message of a chat/message event and
+// tells subscribers of gab://<gameId>/chat about it. The buffer outlives
+// GABP connections, so agents can read what was said before a restart.
+func (s *Server) recordChatMessage(gameID string, seq int, payload interface{}) {
+	message, err := gabp.ParseChatMessage(payload)
+	if err != nil || message.Message == "" {
+		s.gabpLog.Debugw("ignoring malformed chat message", "gameId", gameID, "seq", seq, "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	if s.gameChats == nil {
+		s.gameChats = make(map[string][]chatEntry)
+	}
+	entries := append(s.gameChats[gameID], chatEntry{ChatMessage: message, Seq: seq, ReceivedAt: s.clock.Now()})
+	if len(entries) > gameChatHistory {
+		entries = entries[len(entries)-gameChatHistory:]
+	}
+	s.gameChats[gameID] = entries
+	s.mu.Unlock()
+
+	s.SendResourceUpdatedNotification(gameChatURI(gameID))
+}
+
+// recentChatMessages returns up to limit of the game's buffered chat
+// messages received after since, oldest first, optionally only those of
+// sender.
+func (s *Server) recentChatMessages(gameID string, since time.Time, sender string, limit int) []chatEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	matched := []chatEntry{}
+	for _, entry := range s.gameChats[gameID] {
+		if !since.IsZero() && entry.ReceivedAt.Before(since) {
+			continue
+		}
+		if sender != "" && !strings.EqualFold(entry.Sender, sender) {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+	if limit > 0 && len(matched) > limit {
+		matched = matched[len(matched)-limit:]
+	}
+	return matched
+}
+
+// registerGameChatResource exposes gab://<gameId>/chat for a game whose
+// bridge reports chat messages.
+func (s *Server) registerGameChatResource(gameID string) {
+	uri := gameChatURI(gameID)
+	s.mu.RLock()
+	_, exists := s.resources[uri]
+	s.mu.RUnlock()
+	if exists {
+		return
+	}
+
+	s.RegisterResource(Resource{
+		URI:         uri,
+		Name:        fmt.Sprintf("%s Chat", gameID),
+		Description: fmt.Sprintf("The last %d chat messages of game %s. Subscribe to be told about each new message.", gameChatHistory, gameID),
+		MimeType:    "application/json",
+	}, func() ([]Content, error) {
+		data, err := json.MarshalIndent(map[string]interface{}{
+			"gameId":   gameID,
+			"messages": s.recentChatMessages(gameID, time.Time{}, "", 0),
+		}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return []Content{{Type: "text", Text: string(data)}}, nil
+	})
+}
+
+// chatToolMeta describes a chat tool GABS adds the way mirrored tools are
+// described, so tool policies, budgets and games_enable_tools treat it as one
+// of the game's tools.
+func chatToolMeta(gameID, gabpName string) map[string]interface{} {
+	legacyName := legacyMCPToolName(gameID, gabpName)
+	qualifiedName := qualifiedGABPToolName(gameID, gabpName)
+	return map[string]interface{}{
+		toolMetaGABPName:          gabpName,
+		toolMetaQualifiedGABPName: qualifiedName,
+		toolMetaLegacyName:        legacyName,
+		toolMetaAliases:           []string{legacyName, qualifiedName, localLegacyMCPToolName(gabpName), gabpName},
+		"originalName":            legacyName,
+		toolMetaGameID:            gameID,
+		toolMetaCategory:          "chat",
+	}
+}
+
+// registerGABPChatTools adds <gameId>.chat.send when the bridge supports
+// chat/send and <gameId>.chat.history when it reports chat messages, unless
+// the bridge already has tools of these names, which are in mirrored. It
+// returns the names the tools were registered under.
+func (s *Server) registerGABPChatTools(client *gabp.Client, gameID string, mirrored []string) []string {
+	capabilities := client.GetCapabilities()
+	normalizationConfig := &config.ToolNormalizationConfig{}
+	var registered []string
+
+	if sendName := s.safeMCPToolNameForGABPTool(gameID, chatSendToolName); gabp.SupportsChatSend(capabilities) && !containsString(mirrored, sendName) {
+		s.registerGameCallTool(gameID, Tool{
+			Name:        sendName,
+			Description: fmt.Sprintf("Post a message to the in-game chat, for example to answer or warn players (Game: %s)", gameID),
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"message": map[string]interface{}{
+						"type":        "string",
+						"description": "Text to post",
+					},
+					"channel": map[string]interface{}{
+						"type":        "string",
+						"description": "Chat channel, such as team or global (optional, defaults to the game's main chat)",
+					},
+					"recipient": map[string]interface{}{
+						"type":        "string",
+						"description": "Player to whisper the message to instead of posting it for everyone (optional)",
+					},
+				},
+				"required": []string{"message"},
+			},
+			Meta: chatToolMeta(gameID, chatSendToolName),
+		}, func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
+			return s.sendGameChat(client, gameID, args, call.clientSession()), nil
+		}, normalizationConfig)
+		registered = append(registered, sendName)
+	}
+
+	if historyName := s.safeMCPToolNameForGABPTool(gameID, chatHistoryToolName); gabp.SupportsChatEvents(capabilities) && !containsString(mirrored, historyName) {
+		s.registerGameChatResource(gameID)
+		s.RegisterGameTool(gameID, Tool{
+			Name:        historyName,
+			Description: fmt.Sprintf("Return recent in-game chat messages, oldest first, to see what players said. GABS keeps the last %d. Subscribe to %s to hear about new ones (Game: %s)", gameChatHistory, gameChatURI(gameID), gameID),
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Most recent messages to return (optional, default %d)", gameChatHistoryDefaultLimit),
+					},
+					"sinceSeconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Only return messages received within this many seconds (optional)",
+					},
+					"sender": map[string]interface{}{
+						"type":        "string",
+						"description": "Only return messages of this player (optional)",
+					},
+				},
+			},
+			Meta: chatToolMeta(gameID, chatHistoryToolName),
+		}, func(args map[string]interface{}) (*ToolResult, error) {
+			return s.gameChatHistoryResult(gameID, args), nil
+		}, normalizationConfig)
+		registered = append(registered, historyName)
+	}
+	return registered
+}
+
+func (s *Server) sendGameChat(client *gabp.Client, gameID string, args map[string]interface{}, session *mcpSession) *ToolResult {
+	message, _ := args["message"].(string)
+	if strings.TrimSpace(message) == "" {
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: "message parameter is required"}},
+			IsError: true,
+		}
+	}
+	params := gabp.ChatSendParams{Message: message}
+	var invalidArg *ToolResult
+	if params.Channel, _, invalidArg = parseOptionalStringArg(args, "channel"); invalidArg != nil {
+		return invalidArg
+	}
+	if params.Recipient, _, invalidArg = parseOptionalStringArg(args, "recipient"); invalidArg != nil {
+		return invalidArg
+	}
+	if exceeded := s.enforceToolBudget(session, gameID, chatSendToolName, nil); exceeded != nil {
+		return exceeded
+	}
+
+	result, err := client.SendChatWithTimeout(params, client.RequestTimeout())
+	if err != nil {
+		return &ToolResult{
+			Content:           []Content{{Type: "text", Text: err.Error()}},
+			StructuredContent: gabpErrorStructured(gameID, err),
+			IsError:           true,
+		}
+	}
+	s.gabpLog.Infow("chat message sent", "gameId", gameID, "channel", params.Channel, "recipient", params.Recipient)
+
+	text := fmt.Sprintf("Posted to the chat of '%s'.", gameID)
+	if params.Recipient != "" {
+		text = fmt.Sprintf("Whispered to %s in '%s'.", params.Recipient, gameID)
+	}
+	return &ToolResult{
+		Content: []Content{{Type: "text", Text: text}},
+		StructuredContent: map[string]interface{}{
+			"gameId":    gameID,
+			"sent":      true,
+			"channel":   params.Channel,
+			"recipient": params.Recipient,
+			"result":    result,
+		},
+	}
+}
+
+func (s *Server) gameChatHistoryResult(gameID string, args map[string]interface{}) *ToolResult {
+	limit, hasLimit, invalidArg := parseOptionalPositiveIntValue(args["limit"], "limit")
+	if invalidArg != nil {
+		return invalidArg
+	}
+	if !hasLimit {
+		limit = gameChatHistoryDefaultLimit
+	}
+	sinceSeconds, hasSince, invalidArg := parseOptionalPositiveIntValue(args["sinceSeconds"], "sinceSeconds")
+	if invalidArg != nil {
+		return invalidArg
+	}
+	sender, _, invalidArg := parseOptionalStringArg(args, "sender")
+	if invalidArg != nil {
+		return invalidArg
+	}
+
+	var since time.Time
+	if hasSince {
+		s.mu.RLock()
+		since = s.clock.Now().Add(-time.Duration(sinceSeconds) * time.Second)
+		s.mu.RUnlock()
+	}
+	messages := s.recentChatMessages(gameID, since, sender, limit)
+
+	var text strings.Builder
+	if len(messages) == 0 {
+		text.WriteString(fmt.Sprintf("No chat messages of '%s' match.", gameID))
+	} else {
+		text.WriteString(fmt.Sprintf("%d chat message(s) of '%s':", len(messages), gameID))
+		for _, entry := range messages {
+			text.WriteString(fmt.Sprintf("\n[%s] %s: %s", entry.ReceivedAt.UTC().Format(time.RFC3339), entry.Sender, entry.Message))
+		}
+	}
+	return &ToolResult{
+		Content: []Content{{Type: "text", Text: text.String()}},
+		StructuredContent: map[string]interface{}{
+			"gameId":   gameID,
+			"messages": messages,
+		},
+	}
+}
@@ -0,0 +1,134 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// serveTestGabpChatSession advertises chat/send and chat/message, reports one
+// chat message once GABS subscribes and passes every chat/send on to sent.
+func serveTestGabpChatSession(listener net.Listener, expectedToken string, sent chan<- map[string]interface{}, done chan<- error) {
+	conn, err := listener.Accept()
+	if err != nil {
+		done <- err
+		return
+	}
+	defer conn.Close()
+
+	reader := util.NewLSPFrameReader(conn)
+	writer := util.NewLSPFrameWriter(conn)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+			done <- err
+			return
+		}
+		data, err := reader.ReadMessage()
+		if err != nil {
+			var netErr net.Error
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || (errors.As(err, &netErr) && netErr.Timeout()) {
+				done <- nil
+				return
+			}
+			done <- err
+			return
+		}
+
+		var request util.GABPMessage
+		if err := json.Unmarshal(data, &request); err != nil {
+			done <- err
+			return
+		}
+
+		switch request.Method {
+		case "session/hello":
+			params, _ := request.Params.(map[string]interface{})
+			if token, _ := params["token"].(string); token != expectedToken {
+				done <- fmt.Errorf("unexpected handshake token: %q", token)
+				return
+			}
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, gabp.SessionWelcomeResult{
+				AgentID:       "adventure",
+				App:           gabp.AppInfo{Name: "ExampleGameBridge", Version: "0.1.0"},
+				Capabilities:  gabp.Capabilities{Methods: []string{"tools/list", gabp.EventsSubscribeMethod, gabp.ChatSendMethod}, Events: []string{gabp.ChatMessageChannel}},
+				SchemaVersion: "1.0",
+			}))
+		case "tools/list":
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"tools": []map[string]interface{}{}}))
+		case gabp.EventsSubscribeMethod:
+			params, _ := request.Params.(map[string]interface{})
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"channels": params["channels"]}))
+			if err == nil {
+				err = writer.WriteJSON(util.NewGABPEvent(gabp.ChatMessageChannel, 1, map[string]interface{}{"sender": "ada", "message": "anyone seen my pickaxe?"}))
+			}
+		case gabp.ChatSendMethod:
+			params, _ := request.Params.(map[string]interface{})
+			sent <- params
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"delivered": true}))
+		default:
+			err = fmt.Errorf("unexpected method: %s", request.Method)
+		}
+		if err != nil {
+			done <- err
+			return
+		}
+	}
+}
+
+func TestChatToolsForBridgesThatSupportChat(t *testing.T) {
+	server, configDir := newGamesTestServer(t, roamingGamesConfig())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	writeBridgeJSONForTest(t, configDir, "adventure", listener.Addr().(*net.TCPAddr).Port, "chat-token")
+
+	sent := make(chan map[string]interface{}, 1)
+	serverDone := make(chan error, 1)
+	go serveTestGabpChatSession(listener, "chat-token", sent, serverDone)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_connect failed: %#v", result)
+	}
+
+	historyTool := server.safeMCPToolNameForGABPTool("adventure", chatHistoryToolName)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		result := callToolForTest(t, server, historyTool, map[string]interface{}{"sender": "ADA"})
+		if messages, _ := result.StructuredContent["messages"].([]interface{}); len(messages) == 1 {
+			if message := messages[0].(map[string]interface{}); message["message"] != "anyone seen my pickaxe?" {
+				t.Fatalf("unexpected chat message: %#v", message)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the chat message in %s, got %#v", historyTool, result)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, exists := server.resources[gameChatURI("adventure")]; !exists {
+		t.Fatal("expected the chat resource to be registered")
+	}
+
+	sendTool := server.safeMCPToolNameForGABPTool("adventure", chatSendToolName)
+	result := callToolForTest(t, server, sendTool, map[string]interface{}{"message": "check the chest", "recipient": "ada"})
+	if result.IsError {
+		t.Fatalf("%s failed: %#v", sendTool, result)
+	}
+	select {
+	case params := <-sent:
+		if params["message"] != "check the chest" || params["recipient"] != "ada" {
+			t.Fatalf("unexpected chat/send params: %#v", params)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the bridge to receive chat/send")
+	}
+}
@@ -0,0 +1,197 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+)
+
+// Where games_force_cleanup found a lingering process.
+const (
+	cleanupSourceTracked      = "tracked"
+	cleanupSourceRuntimeState = "runtimeState"
+	cleanupSourceProcessName  = "stopProcessName"
+)
+
+// cleanedProcess is one process games_force_cleanup went after.
+type cleanedProcess struct {
+	process.Escalation
+	Name   string `json:"name,omitempty"`
+	Source string `json:"source"`
+}
+
+// cleanupCandidates returns the PIDs that may still belong to game: the
+// process this session tracks, the game PID recorded in its runtime state and
+// every process named like its stopProcessName. GABS itself is never one.
+func (s *Server) cleanupCandidates(game config.GameConfig, runtimeState *process.RuntimeState) (map[int]string, []int) {
+	sources := make(map[int]string)
+	add := func(pid int, source string) {
+		if pid <= 0 || pid == os.Getpid() {
+			return
+		}
+		if _, seen := sources[pid]; !seen {
+			sources[pid] = source
+		}
+	}
+
+	s.mu.RLock()
+	if controller, tracked := s.sessions[game.ID].process(); tracked {
+		add(controller.GetPID(), cleanupSourceTracked)
+	}
+	s.mu.RUnlock()
+
+	names := []string{game.StopProcessName}
+	if runtimeState != nil {
+		add(runtimeState.GamePID, cleanupSourceRuntimeState)
+		names = append(names, runtimeState.StopProcessName)
+	}
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		pids, err := process.FindProcessesByName(name)
+		if err != nil {
+			s.processLog.Warnw("failed to look up processes for cleanup", "gameId", game.ID, "processName", name, "error", err)
+			continue
+		}
+		for _, pid := range pids {
+			add(pid, cleanupSourceProcessName)
+		}
+	}
+
+	pids := make([]int, 0, len(sources))
+	for pid := range sources {
+		if process.IsProcessAlive(pid) {
+			pids = append(pids, pid)
+		}
+	}
+	sort.Ints(pids)
+	return sources, pids
+}
+
+// forceCleanupGame terminates every lingering process of game, escalating
+// from a termination request to a kill, and once none is left forgets the
+// game's session and removes its runtime state and leftover bridge socket.
+func (s *Server) forceCleanupGame(game config.GameConfig) *ToolResult {
+	runtimeState, err := process.LoadRuntimeState(game.ID, s.configDir)
+	if err != nil {
+		s.log.Warnw("failed to read runtime state for cleanup", "gameId", game.ID, "error", err)
+	}
+	sources, pids := s.cleanupCandidates(game, runtimeState)
+
+	var names map[int]string
+	if len(pids) > 0 {
+		names, _ = process.ListProcesses()
+	}
+	s.mu.RLock()
+	clock := s.clock
+	s.mu.RUnlock()
+
+	cleaned := make([]cleanedProcess, 0, len(pids))
+	survivors := 0
+	for _, pid := range pids {
+		escalation := process.TerminateEscalating(pid, stopGracePeriod, clock)
+		if !escalation.Exited {
+			survivors++
+		}
+		s.processLog.Infow("force cleanup terminated process", "gameId", game.ID, "pid", pid, "source", sources[pid], "step", escalation.Step, "exited", escalation.Exited, "error", escalation.Error)
+		cleaned = append(cleaned, cleanedProcess{Escalation: escalation, Name: names[pid], Source: sources[pid]})
+	}
+
+	removed := []string{}
+	if survivors == 0 {
+		s.mu.Lock()
+		// An operator stopped the game, so it is not reported as crashed
+		s.sessionLocked(game.ID).stopping = true
+		s.cleanupStoppedGameLocked(game.ID)
+		s.mu.Unlock()
+		if runtimeState != nil {
+			if cp, err := config.NewConfigPaths(s.configDir); err == nil {
+				removed = append(removed, cp.GetRuntimeStatePath(game.ID))
+			}
+		}
+		if socket, err := config.RemoveBridgeSocket(game.ID, s.configDir); err != nil {
+			s.log.Warnw("failed to remove stale GABP socket", "gameId", game.ID, "error", err)
+		} else if socket != "" {
+			removed = append(removed, socket)
+		}
+	}
+
+	var text strings.Builder
+	if len(cleaned) == 0 {
+		text.WriteString(fmt.Sprintf("No lingering processes of '%s' were found.", game.ID))
+	} else {
+		text.WriteString(fmt.Sprintf("Cleaned up %d process(es) of '%s':", len(cleaned), game.ID))
+		for _, p := range cleaned {
+			label := fmt.Sprintf("pid %d", p.PID)
+			if p.Name != "" {
+				label = fmt.Sprintf("%s (pid %d)", p.Name, p.PID)
+			}
+			outcome := "exited after " + p.Step
+			if !p.Exited {
+				outcome = "still running after " + p.Step
+				if p.Error != "" {
+					outcome += ": " + p.Error
+				}
+			}
+			text.WriteString(fmt.Sprintf("\n- %s, found by %s, %s", label, p.Source, outcome))
+		}
+	}
+	if survivors > 0 {
+		text.WriteString(fmt.Sprintf("\n%d process(es) could not be stopped, so the game's state was kept. They may belong to another user or be stuck in the kernel.", survivors))
+	} else {
+		text.WriteString(fmt.Sprintf("\nThe game's session was reset and %d stale file(s) removed.", len(removed)))
+	}
+
+	return &ToolResult{
+		Content: []Content{{Type: "text", Text: text.String()}},
+		StructuredContent: map[string]interface{}{
+			"gameId":       game.ID,
+			"processes":    cleaned,
+			"survivors":    survivors,
+			"removedFiles": removed,
+			"nextActions": []map[string]interface{}{
+				mcpNextAction("games_status", map[string]interface{}{"gameId": game.ID}, "Confirm the game is reported stopped."),
+			},
+		},
+		IsError: survivors > 0,
+	}
+}
+
+func (s *Server) registerForceCleanupTool(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.force_cleanup",
+		Description: "Last resort when a game refuses to stop or GABS still thinks it runs: terminate every lingering process of the game (tracked PID, recorded PID and stopProcessName matches), killing those that ignore the request, then reset its session and remove stale runtime files. Reports exactly what it cleaned.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": map[string]interface{}{
+					"type":        "string",
+					"description": "Game ID or launch target to clean up",
+				},
+			},
+			"required": []string{"gameId"},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		gameIdOrTarget, ok := args["gameId"].(string)
+		if !ok {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "gameId parameter is required"}},
+				IsError: true,
+			}, nil
+		}
+
+		game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
+		if !exists {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' not found. Use games_list to see available games.", gameIdOrTarget)}},
+				IsError: true,
+			}, nil
+		}
+		return s.forceCleanupGame(*game), nil
+	}, normalizationConfig)
+}
@@ -0,0 +1,63 @@
+package mcp
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+)
+
+func TestForceCleanupKillsRecordedProcessAndRemovesRuntimeState(t *testing.T) {
+	requireSleepForTest(t)
+	game := sleepingGameForTest("factory", "Factory")
+	gamesConfig := &config.GamesConfig{Games: map[string]config.GameConfig{game.ID: game}}
+	server, configDir := newGamesTestServer(t, gamesConfig)
+
+	// A game left behind by an earlier GABS that never cleaned up after it
+	cmd := exec.Command("/bin/sleep", "30")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start sleep: %v", err)
+	}
+	waited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(waited)
+	}()
+	t.Cleanup(func() { _ = cmd.Process.Kill() })
+
+	state := process.NewRuntimeState(launchSpecFromGame(game), process.RuntimeStateStatusRunning)
+	state.OwnerPID = 1
+	state.GamePID = cmd.Process.Pid
+	if err := process.SaveRuntimeState(game.ID, configDir, state); err != nil {
+		t.Fatalf("failed to save runtime state: %v", err)
+	}
+
+	result := callToolForTest(t, server, "games_force_cleanup", map[string]interface{}{"gameId": game.ID})
+	if result.IsError {
+		t.Fatalf("force cleanup failed: %#v", result)
+	}
+	<-waited
+
+	cleaned, ok := result.StructuredContent["processes"].([]interface{})
+	if !ok || len(cleaned) != 1 {
+		t.Fatalf("expected one cleaned process, got %#v", result.StructuredContent["processes"])
+	}
+	entry := cleaned[0].(map[string]interface{})
+	if entry["pid"] != float64(cmd.Process.Pid) || entry["source"] != cleanupSourceRuntimeState || entry["exited"] != true {
+		t.Fatalf("unexpected cleaned process: %#v", entry)
+	}
+	if remaining, err := process.LoadRuntimeState(game.ID, configDir); err != nil || remaining != nil {
+		t.Fatalf("expected runtime state to be removed, got %#v, %v", remaining, err)
+	}
+}
+
+func TestForceCleanupReportsNothingToClean(t *testing.T) {
+	game := sleepingGameForTest("factory", "Factory")
+	server, _ := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{game.ID: game}})
+
+	result := callToolForTest(t, server, "games_force_cleanup", map[string]interface{}{"gameId": game.ID})
+	if result.IsError || result.StructuredContent["survivors"] != float64(0) {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+// recentGameEvents collects the events the GABP client buffered for the given
+// channels received after since and before until, oldest first per channel.
+// An empty channel list uses every event channel the bridge advertised, and a
+// zero until leaves the range open.
+func recentGameEvents(client *gabp.Client, channels []string, since, until time.Time) []map[string]interface{} {
+	if len(channels) == 0 {
+		channels = client.GetCapabilities().Events
+	}
+
+	events := []map[string]interface{}{}
+	for _, channel := range channels {
+		for _, event := range client.RecentEvents(channel, since) {
+			if !until.IsZero() && !event.ReceivedAt.Before(until) {
+				continue
+			}
+			events = append(events, map[string]interface{}{
+				"channel":    event.Channel,
+				"seq":        event.Seq,
+				"payload":    event.Payload,
+				"receivedAt": event.ReceivedAt.UTC().Format(time.RFC3339Nano),
+			})
+		}
+	}
+	return events
+}
+
+func (s *Server) registerGameEventsTool(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.events",
+		Description: fmt.Sprintf("Return recent GABP events for a connected game, such as a world/loaded event that fired just before you connected. GABS keeps the last %d events per channel.", gabp.DefaultEventHistorySize),
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": map[string]interface{}{
+					"type":        "string",
+					"description": "Game ID to inspect (optional if exactly one game is connected via GABP)",
+				},
+				"channel": map[string]interface{}{
+					"type":        "string",
+					"description": "Only return events from this channel (optional, defaults to every channel the bridge advertises)",
+				},
+				"sinceSeconds": map[string]interface{}{
+					"type":        "integer",
+					"description": "Only return events received within this many seconds (optional, defaults to the whole buffer)",
+				},
+			},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		gameID, hasGameID, invalidArg := parseOptionalStringArg(args, "gameId")
+		if invalidArg != nil {
+			return invalidArg, nil
+		}
+		channel, hasChannel, invalidArg := parseOptionalStringArg(args, "channel")
+		if invalidArg != nil {
+			return invalidArg, nil
+		}
+		sinceSeconds, hasSince, invalidArg := parseOptionalPositiveIntValue(args["sinceSeconds"], "sinceSeconds")
+		if invalidArg != nil {
+			return invalidArg, nil
+		}
+
+		game, client, resolveErr := s.resolveAttentionClient(gamesConfig, gameID, hasGameID)
+		if resolveErr != nil {
+			return resolveErr, nil
+		}
+
+		var channels []string
+		if hasChannel {
+			channels = []string{channel}
+		}
+		var since time.Time
+		if hasSince {
+			since = time.Now().Add(-time.Duration(sinceSeconds) * time.Second)
+		}
+
+		events := recentGameEvents(client, channels, since, time.Time{})
+		text := fmt.Sprintf("Game '%s' has %d recent GABP events.", game.ID, len(events))
+		if len(events) == 0 {
+			text = fmt.Sprintf("Game '%s' has no recent GABP events.", game.ID)
+		}
+		for _, event := range events {
+			text += fmt.Sprintf("\n- %v #%v at %v", event["channel"], event["seq"], event["receivedAt"])
+		}
+
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: text}},
+			StructuredContent: map[string]interface{}{
+				"gameId": game.ID,
+				"events": events,
+			},
+		}, nil
+	}, normalizationConfig)
+}
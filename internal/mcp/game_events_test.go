@@ -0,0 +1,130 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// serveTestGabpSessionWithEvent answers the handshake and tool listing and
+// pushes one world/loaded event before GABS has subscribed to anything.
+func serveTestGabpSessionWithEvent(listener net.Listener, expectedToken string, done chan<- error) {
+	conn, err := listener.Accept()
+	if err != nil {
+		done <- err
+		return
+	}
+	defer conn.Close()
+
+	reader := util.NewLSPFrameReader(conn)
+	writer := util.NewLSPFrameWriter(conn)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+			done <- err
+			return
+		}
+		data, err := reader.ReadMessage()
+		if err != nil {
+			var netErr net.Error
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || (errors.As(err, &netErr) && netErr.Timeout()) {
+				done <- nil
+				return
+			}
+			done <- err
+			return
+		}
+
+		var request util.GABPMessage
+		if err := json.Unmarshal(data, &request); err != nil {
+			done <- err
+			return
+		}
+
+		switch request.Method {
+		case "session/hello":
+			params, _ := request.Params.(map[string]interface{})
+			if token, _ := params["token"].(string); token != expectedToken {
+				done <- fmt.Errorf("unexpected handshake token: %q", token)
+				return
+			}
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, gabp.SessionWelcomeResult{
+				AgentID:       "adventure",
+				App:           gabp.AppInfo{Name: "ExampleGameBridge", Version: "0.1.0"},
+				Capabilities:  gabp.Capabilities{Methods: []string{"tools/list"}, Events: []string{"world/loaded", "system/log"}},
+				SchemaVersion: "1.0",
+			}))
+			if err == nil {
+				err = writer.WriteJSON(util.NewGABPEvent("world/loaded", 1, map[string]interface{}{"world": "main"}))
+			}
+		case "tools/list":
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"tools": []map[string]interface{}{}}))
+		default:
+			err = fmt.Errorf("unexpected method: %s", request.Method)
+		}
+		if err != nil {
+			done <- err
+			return
+		}
+	}
+}
+
+func TestGamesEventsReturnsEventsBufferedBeforeTheCall(t *testing.T) {
+	server, configDir := newGamesTestServer(t, roamingGamesConfig())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	writeBridgeJSONForTest(t, configDir, "adventure", listener.Addr().(*net.TCPAddr).Port, "events-token")
+
+	serverDone := make(chan error, 1)
+	go serveTestGabpSessionWithEvent(listener, "events-token", serverDone)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_connect failed: %#v", result)
+	}
+
+	var result ToolResult
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		result = callToolForTest(t, server, "games_events", map[string]interface{}{"gameId": "adventure"})
+		if result.IsError {
+			t.Fatalf("games_events failed: %#v", result)
+		}
+		if events, _ := result.StructuredContent["events"].([]interface{}); len(events) > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the world/loaded event to be buffered, got %#v", result.StructuredContent)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	events := result.StructuredContent["events"].([]interface{})
+	event, _ := events[0].(map[string]interface{})
+	payload, _ := event["payload"].(map[string]interface{})
+	if len(events) != 1 || event["channel"] != "world/loaded" || event["seq"] != float64(1) || payload["world"] != "main" {
+		t.Fatalf("unexpected buffered events: %#v", events)
+	}
+	if !strings.Contains(result.Content[0].Text, "world/loaded #1") {
+		t.Fatalf("expected event summary in text, got: %s", result.Content[0].Text)
+	}
+
+	result = callToolForTest(t, server, "games_events", map[string]interface{}{"gameId": "adventure", "channel": "system/log"})
+	if events, _ := result.StructuredContent["events"].([]interface{}); result.IsError || len(events) != 0 {
+		t.Fatalf("expected channel filter to exclude world/loaded, got %#v", result)
+	}
+
+	server.CleanupGameResources("adventure")
+	if err := <-serverDone; err != nil {
+		t.Fatalf("GABP session failed: %v", err)
+	}
+}
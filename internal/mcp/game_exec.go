@@ -0,0 +1,304 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+const (
+	defaultGameExecTimeout = 60 * time.Second
+	gameExecOutputLimit    = 64 * 1024
+	gameExecWaitDelay      = 2 * time.Second
+)
+
+// limitedBuffer keeps the first limit bytes written to it and remembers
+// whether anything was dropped, so a chatty helper cannot flood a tool result.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	remaining := b.limit - b.buf.Len()
+	if remaining <= 0 {
+		b.truncated = b.truncated || len(p) > 0
+		return len(p), nil
+	}
+	if len(p) > remaining {
+		b.buf.Write(p[:remaining])
+		b.truncated = true
+		return len(p), nil
+	}
+	b.buf.Write(p)
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
+
+type gameExecResult struct {
+	ExitCode        int
+	TimedOut        bool
+	Duration        time.Duration
+	Stdout          string
+	Stderr          string
+	StdoutTruncated bool
+	StderrTruncated bool
+}
+
+func allowedCommandNames(game config.GameConfig) []string {
+	names := make([]string, 0, len(game.AllowedCommands))
+	for name := range game.AllowedCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func parseOptionalStringSliceArg(args map[string]interface{}, key string) ([]string, *ToolResult) {
+	raw, exists := args[key]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+
+	invalid := &ToolResult{
+		Content: []Content{{Type: "text", Text: fmt.Sprintf("Argument '%s' must be an array of strings", key)}},
+		IsError: true,
+	}
+
+	switch typed := raw.(type) {
+	case []string:
+		return append([]string(nil), typed...), nil
+	case []interface{}:
+		values := make([]string, 0, len(typed))
+		for _, item := range typed {
+			value, ok := item.(string)
+			if !ok {
+				return nil, invalid
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	default:
+		return nil, invalid
+	}
+}
+
+// gameCommandPath resolves a configured helper command against the game's
+// working directory. Bare names are not looked up on PATH, so an allowlisted
+// "backup" always means the script next to the game and not a system binary.
+func gameCommandPath(game config.GameConfig, command string) string {
+	if filepath.IsAbs(command) {
+		return command
+	}
+	return filepath.Join(game.WorkingDir, command)
+}
+
+// runGameCommand runs a whitelisted helper command in the game's working
+// directory. The configured command path is resolved relative to that
+// directory when it is not absolute.
+func runGameCommand(game config.GameConfig, command config.AllowedCommandConfig, extraArgs []string, timeout time.Duration) (*gameExecResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	args := append(append([]string(nil), command.Args...), extraArgs...)
+	cmd := exec.CommandContext(ctx, gameCommandPath(game, command.Command), args...)
+	cmd.Dir = game.WorkingDir
+	cmd.Env = append(os.Environ(), "GABS_GAME_ID="+game.ID)
+	cmd.WaitDelay = gameExecWaitDelay
+
+	stdout := &limitedBuffer{limit: gameExecOutputLimit}
+	stderr := &limitedBuffer{limit: gameExecOutputLimit}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	started := time.Now()
+	err := cmd.Run()
+	result := &gameExecResult{
+		ExitCode:        0,
+		Duration:        time.Since(started),
+		Stdout:          stdout.String(),
+		Stderr:          stderr.String(),
+		StdoutTruncated: stdout.truncated,
+		StderrTruncated: stderr.truncated,
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		result.TimedOut = true
+		result.ExitCode = -1
+		return result, nil
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+			return result, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *Server) registerGameExecTool(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.exec",
+		Description: "Run a helper command that is whitelisted in the game's allowedCommands, inside the game's working directory, and return its output",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": map[string]interface{}{
+					"type":        "string",
+					"description": "Game ID or launch target whose helper command should run",
+				},
+				"command": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of an entry in the game's allowedCommands",
+				},
+				"args": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Extra arguments appended to the configured ones. Only accepted when the command sets allowExtraArgs.",
+				},
+				"timeout": map[string]interface{}{
+					"type":        "integer",
+					"description": "Optional timeout in seconds. Defaults to and is capped at the command's timeoutSeconds, or 60.",
+				},
+			},
+			"required": []string{"gameId", "command"},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		gameIdOrTarget, ok := args["gameId"].(string)
+		if !ok {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "gameId parameter is required"}},
+				IsError: true,
+			}, nil
+		}
+
+		game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
+		if !exists {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' not found. Use games_list to see available games.", gameIdOrTarget)}},
+				IsError: true,
+			}, nil
+		}
+
+		commandName, ok := args["command"].(string)
+		commandName = strings.TrimSpace(commandName)
+		if !ok || commandName == "" {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "command parameter is required"}},
+				IsError: true,
+			}, nil
+		}
+
+		command, allowed := game.AllowedCommands[commandName]
+		if !allowed {
+			names := allowedCommandNames(*game)
+			message := fmt.Sprintf("Command '%s' is not in the allowedCommands list for game '%s'.", commandName, game.ID)
+			if len(names) == 0 {
+				message += " No helper commands are configured for this game."
+			} else {
+				message += fmt.Sprintf(" Allowed commands: %s", strings.Join(names, ", "))
+			}
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: message}},
+				StructuredContent: map[string]interface{}{
+					"gameId":          game.ID,
+					"command":         commandName,
+					"allowedCommands": names,
+				},
+				IsError: true,
+			}, nil
+		}
+
+		if game.WorkingDir == "" {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' has no workingDir configured. games_exec only runs helper commands inside the game's working directory.", game.ID)}},
+				IsError: true,
+			}, nil
+		}
+
+		extraArgs, invalidArgs := parseOptionalStringSliceArg(args, "args")
+		if invalidArgs != nil {
+			return invalidArgs, nil
+		}
+		if len(extraArgs) > 0 && !command.AllowExtraArgs {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Command '%s' does not accept extra arguments. Set allowExtraArgs in its configuration to permit them.", commandName)}},
+				IsError: true,
+			}, nil
+		}
+
+		maxTimeout := defaultGameExecTimeout
+		if command.TimeoutSeconds > 0 {
+			maxTimeout = time.Duration(command.TimeoutSeconds) * time.Second
+		}
+		timeout, invalidTimeout := parseOptionalTimeoutSecondsArg(args, "timeout", maxTimeout)
+		if invalidTimeout != nil {
+			return invalidTimeout, nil
+		}
+		// The configured timeout is an upper bound; callers may only shorten it.
+		if timeout > maxTimeout {
+			timeout = maxTimeout
+		}
+
+		s.log.Infow("running game helper command", "gameId", game.ID, "command", commandName, "workingDir", game.WorkingDir)
+		result, err := runGameCommand(*game, command, extraArgs, timeout)
+		if err != nil {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Failed to run command '%s' for %s: %v", commandName, game.ID, err)}},
+				IsError: true,
+			}, nil
+		}
+
+		var content strings.Builder
+		switch {
+		case result.TimedOut:
+			content.WriteString(fmt.Sprintf("Command '%s' for %s timed out after %s.\n", commandName, game.ID, timeout))
+		default:
+			content.WriteString(fmt.Sprintf("Command '%s' for %s exited with code %d in %s.\n", commandName, game.ID, result.ExitCode, result.Duration.Round(time.Millisecond)))
+		}
+		if result.Stdout != "" {
+			content.WriteString("\nstdout:\n")
+			content.WriteString(result.Stdout)
+		}
+		if result.Stderr != "" {
+			content.WriteString("\nstderr:\n")
+			content.WriteString(result.Stderr)
+		}
+		if result.StdoutTruncated || result.StderrTruncated {
+			content.WriteString(fmt.Sprintf("\n(output truncated to %d bytes per stream)\n", gameExecOutputLimit))
+		}
+
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: content.String()}},
+			StructuredContent: map[string]interface{}{
+				"gameId":          game.ID,
+				"command":         commandName,
+				"workingDir":      game.WorkingDir,
+				"exitCode":        result.ExitCode,
+				"timedOut":        result.TimedOut,
+				"durationMs":      result.Duration.Milliseconds(),
+				"stdout":          result.Stdout,
+				"stderr":          result.Stderr,
+				"stdoutTruncated": result.StdoutTruncated,
+				"stderrTruncated": result.StderrTruncated,
+			},
+			IsError: result.TimedOut || result.ExitCode != 0,
+		}, nil
+	}, normalizationConfig)
+}
@@ -0,0 +1,149 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func newGameExecTestServer(t *testing.T, enableExec bool) (*Server, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a POSIX shell helper script")
+	}
+
+	workingDir := t.TempDir()
+	script := "#!/bin/sh\necho \"backup of $GABS_GAME_ID in $(pwd) $*\"\necho warn >&2\n"
+	if err := os.WriteFile(filepath.Join(workingDir, "backup.sh"), []byte(script), 0755); err != nil {
+		t.Fatalf("write helper script: %v", err)
+	}
+
+	gamesConfig := &config.GamesConfig{EnableExec: enableExec}
+	if err := gamesConfig.AddGame(config.GameConfig{
+		ID:         "factory",
+		Name:       "Example Game",
+		LaunchMode: "DirectPath",
+		Target:     "/bin/true",
+		WorkingDir: workingDir,
+		AllowedCommands: map[string]config.AllowedCommandConfig{
+			"backup": {Command: "./backup.sh", Args: []string{"--world", "main"}},
+			"render": {Command: "/bin/sh", Args: []string{"-c", "echo render $0"}, AllowExtraArgs: true},
+			"fail":   {Command: "/bin/sh", Args: []string{"-c", "exit 3"}},
+			"slow":   {Command: "/bin/sh", Args: []string{"-c", "sleep 5"}, TimeoutSeconds: 1},
+		},
+	}); err != nil {
+		t.Fatalf("add game: %v", err)
+	}
+
+	server, _ := newGamesTestServer(t, gamesConfig)
+	return server, workingDir
+}
+
+func TestGamesExecIsNotRegisteredUnlessEnabled(t *testing.T) {
+	server, _ := newGameExecTestServer(t, false)
+	if _, exists := server.tools["games_exec"]; exists {
+		t.Fatal("games_exec should not be registered without enableExec")
+	}
+}
+
+func TestGamesExecRunsAllowedCommandInWorkingDir(t *testing.T) {
+	server, workingDir := newGameExecTestServer(t, true)
+
+	result := callToolForTest(t, server, "games_exec", map[string]interface{}{
+		"gameId":  "factory",
+		"command": "backup",
+	})
+	if result.IsError {
+		t.Fatalf("expected success, got %#v", result)
+	}
+	stdout, _ := result.StructuredContent["stdout"].(string)
+	resolvedDir, _ := filepath.EvalSymlinks(workingDir)
+	if !strings.Contains(stdout, "backup of factory") || !strings.Contains(stdout, "--world main") {
+		t.Fatalf("unexpected stdout: %q", stdout)
+	}
+	if !strings.Contains(stdout, workingDir) && !strings.Contains(stdout, resolvedDir) {
+		t.Fatalf("command did not run in working directory %s: %q", workingDir, stdout)
+	}
+	if stderr, _ := result.StructuredContent["stderr"].(string); strings.TrimSpace(stderr) != "warn" {
+		t.Fatalf("unexpected stderr: %q", stderr)
+	}
+}
+
+func TestGamesExecRejectsUnlistedCommandsAndExtraArgs(t *testing.T) {
+	server, _ := newGameExecTestServer(t, true)
+
+	result := callToolForTest(t, server, "games_exec", map[string]interface{}{
+		"gameId":  "factory",
+		"command": "rm",
+	})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "Allowed commands: backup, fail, render, slow") {
+		t.Fatalf("expected allowlist rejection, got %#v", result)
+	}
+
+	result = callToolForTest(t, server, "games_exec", map[string]interface{}{
+		"gameId":  "factory",
+		"command": "backup",
+		"args":    []interface{}{"--delete"},
+	})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "does not accept extra arguments") {
+		t.Fatalf("expected extra args rejection, got %#v", result)
+	}
+
+	result = callToolForTest(t, server, "games_exec", map[string]interface{}{
+		"gameId":  "factory",
+		"command": "render",
+		"args":    []interface{}{"overworld"},
+	})
+	if result.IsError || !strings.Contains(result.StructuredContent["stdout"].(string), "render overworld") {
+		t.Fatalf("expected extra args to be passed, got %#v", result)
+	}
+}
+
+func TestGamesExecReportsExitCodeAndTimeout(t *testing.T) {
+	server, _ := newGameExecTestServer(t, true)
+
+	result := callToolForTest(t, server, "games_exec", map[string]interface{}{
+		"gameId":  "factory",
+		"command": "fail",
+	})
+	if !result.IsError || result.StructuredContent["exitCode"] != float64(3) {
+		t.Fatalf("expected exit code 3, got %#v", result)
+	}
+
+	result = callToolForTest(t, server, "games_exec", map[string]interface{}{
+		"gameId":  "factory",
+		"command": "slow",
+		"timeout": 30,
+	})
+	if !result.IsError || result.StructuredContent["timedOut"] != true {
+		t.Fatalf("expected caller timeout to be capped at timeoutSeconds, got %#v", result)
+	}
+}
+
+func TestGameCommandPathResolvesAgainstWorkingDir(t *testing.T) {
+	game := config.GameConfig{WorkingDir: filepath.Join("opt", "factory")}
+	if got := gameCommandPath(game, "backup"); got != filepath.Join("opt", "factory", "backup") {
+		t.Fatalf("expected bare name to resolve in workingDir, got %q", got)
+	}
+	if got := gameCommandPath(game, "./tools/render.sh"); got != filepath.Join("opt", "factory", "tools", "render.sh") {
+		t.Fatalf("expected relative path to resolve in workingDir, got %q", got)
+	}
+	absolute, _ := filepath.Abs("map-renderer")
+	if got := gameCommandPath(game, absolute); got != absolute {
+		t.Fatalf("expected absolute path to be kept, got %q", got)
+	}
+}
+
+func TestLimitedBufferTruncates(t *testing.T) {
+	buffer := &limitedBuffer{limit: 4}
+	if n, err := buffer.Write([]byte("abcdef")); n != 6 || err != nil {
+		t.Fatalf("unexpected write result: %d %v", n, err)
+	}
+	if buffer.String() != "abcd" || !buffer.truncated {
+		t.Fatalf("unexpected buffer state: %q truncated=%v", buffer.String(), buffer.truncated)
+	}
+}
@@ -0,0 +1,200 @@
+package mcp
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+)
+
+// defaultGameLogTail is how many lines games_logs and the logs resource return
+// when no count is given.
+const defaultGameLogTail = 100
+
+func gameLogsURI(gameID string) string {
+	return fmt.Sprintf("gab://%s/logs", gameID)
+}
+
+// gameLogFor returns the output capture of a game, creating it and its
+// gab://<gameId>/logs resource on first use. The capture outlives the process
+// so crash output can still be read after the game exits.
+func (s *Server) gameLogFor(gameID string) *process.GameLog {
+	s.mu.Lock()
+	if gameLog, exists := s.gameLogs[gameID]; exists {
+		s.mu.Unlock()
+		return gameLog
+	}
+	if s.gameLogs == nil {
+		s.gameLogs = make(map[string]*process.GameLog)
+	}
+	gameLog := process.NewGameLog(s.gameLogDir(gameID), process.DefaultLogLines)
+	s.gameLogs[gameID] = gameLog
+	s.mu.Unlock()
+
+	s.RegisterResource(Resource{
+		URI:         gameLogsURI(gameID),
+		Name:        fmt.Sprintf("%s Output Log", gameID),
+		Description: fmt.Sprintf("Recent stdout and stderr of game %s", gameID),
+		MimeType:    "text/plain",
+	}, func() ([]Content, error) {
+		lines, _ := gameLog.Tail(defaultGameLogTail, 0)
+		return []Content{{Type: "text", Text: formatGameLogLines(lines)}}, nil
+	})
+	return gameLog
+}
+
+func (s *Server) gameLogDir(gameID string) string {
+	paths, err := config.NewConfigPaths(s.configDir)
+	if err != nil {
+		s.log.Warnw("failed to resolve log directory, keeping game output in memory only", "gameId", gameID, "error", err)
+		return ""
+	}
+	return paths.GetGameLogDir(gameID)
+}
+
+func (s *Server) existingGameLog(gameID string) *process.GameLog {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.gameLogs[gameID]
+}
+
+func formatGameLogLines(lines []process.LogLine) string {
+	var text strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			text.WriteString("\n")
+		}
+		text.WriteString(fmt.Sprintf("%s [%s] %s", line.Time.UTC().Format(time.RFC3339), line.Stream, line.Text))
+	}
+	return text.String()
+}
+
+// readGameLogFileTail returns the last n lines of a game's log file, for games
+// whose output was captured by an earlier GABS run.
+func readGameLogFileTail(dir string, n int) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, process.GameLogFileName))
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimRight(data, "\n")
+	if len(data) == 0 {
+		return nil, nil
+	}
+	lines := strings.Split(string(data), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines, nil
+}
+
+func (s *Server) registerGameLogsTool(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.logs",
+		Description: fmt.Sprintf("Return the recent stdout and stderr of a game started by GABS, for example to see why it crashed. GABS keeps the last %d lines in memory and writes all output to log files.", process.DefaultLogLines),
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": map[string]interface{}{
+					"type":        "string",
+					"description": "Game ID or launch target",
+				},
+				"lines": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Number of lines to return (optional, defaults to %d)", defaultGameLogTail),
+				},
+				"since": map[string]interface{}{
+					"type":        "integer",
+					"description": "Only return lines after this sequence number; pass the previous result's next value to tail new output",
+				},
+			},
+			"required": []string{"gameId"},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		gameIdOrTarget, ok := args["gameId"].(string)
+		if !ok {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "gameId parameter is required"}},
+				IsError: true,
+			}, nil
+		}
+		count, hasCount, invalidArg := parseOptionalPositiveIntValue(args["lines"], "lines")
+		if invalidArg != nil {
+			return invalidArg, nil
+		}
+		if !hasCount {
+			count = defaultGameLogTail
+		}
+		if count > process.DefaultLogLines {
+			count = process.DefaultLogLines
+		}
+		since, _, invalidArg := parseOptionalPositiveIntValue(args["since"], "since")
+		if invalidArg != nil {
+			return invalidArg, nil
+		}
+
+		game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
+		if !exists {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' not found. Use games_list to see available games.", gameIdOrTarget)}},
+				IsError: true,
+			}, nil
+		}
+
+		gameLog := s.existingGameLog(game.ID)
+		if gameLog == nil {
+			return s.gameLogFileResult(*game, count), nil
+		}
+
+		lines, next := gameLog.Tail(count, int64(since))
+		text := formatGameLogLines(lines)
+		if len(lines) == 0 {
+			text = fmt.Sprintf("No new output from game '%s'.", game.ID)
+		}
+		structured := map[string]interface{}{
+			"gameId": game.ID,
+			"lines":  lines,
+			"next":   next,
+			"logDir": gameLog.Dir(),
+		}
+		if err := gameLog.FileError(); err != nil {
+			structured["logFileError"] = err.Error()
+		}
+		return &ToolResult{
+			Content:           []Content{{Type: "text", Text: text}},
+			StructuredContent: structured,
+		}, nil
+	}, normalizationConfig)
+}
+
+// gameLogFileResult answers games_logs from the log file when this GABS run
+// has not started the game.
+func (s *Server) gameLogFileResult(game config.GameConfig, count int) *ToolResult {
+	dir := s.gameLogDir(game.ID)
+	lines, err := readGameLogFileTail(dir, count)
+	if err != nil || len(lines) == 0 {
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: fmt.Sprintf("No output has been captured for game '%s'. GABS captures output of games it starts with games_start.", game.ID)}},
+			StructuredContent: map[string]interface{}{
+				"gameId": game.ID,
+				"lines":  []process.LogLine{},
+				"next":   0,
+				"nextActions": []map[string]interface{}{
+					mcpNextAction("games_start", map[string]interface{}{"gameId": game.ID}, "Start the game through GABS to capture its output."),
+				},
+			},
+		}
+	}
+	return &ToolResult{
+		Content: []Content{{Type: "text", Text: fmt.Sprintf("Output of an earlier run of '%s', from %s:\n%s", game.ID, filepath.Join(dir, process.GameLogFileName), strings.Join(lines, "\n"))}},
+		StructuredContent: map[string]interface{}{
+			"gameId":    game.ID,
+			"fileLines": lines,
+			"logDir":    dir,
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestGamesLogsReturnsCapturedOutputAndTailsNewLines(t *testing.T) {
+	requireSleepForTest(t)
+
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"factory": {ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/bin/sh", Args: []string{"-c", "echo factory ready; echo missing texture >&2; exec sleep 30"}},
+		},
+	}
+	server, configDir := newGamesTestServer(t, gamesConfig)
+
+	result := callToolForTest(t, server, "games_logs", map[string]interface{}{"gameId": "factory"})
+	if result.IsError || !strings.Contains(result.Content[0].Text, "No output has been captured") {
+		t.Fatalf("expected no output before the first start, got %#v", result)
+	}
+
+	if result := callToolForTest(t, server, "games_start", map[string]interface{}{"gameId": "factory", "timeout": 1}); result.IsError {
+		t.Fatalf("start failed: %#v", result)
+	}
+	game, _ := gamesConfig.GetGame("factory")
+	t.Cleanup(func() { server.stopGame(*game, true) })
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		result = callToolForTest(t, server, "games_logs", map[string]interface{}{"gameId": "factory"})
+		if strings.Contains(result.Content[0].Text, "missing texture") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected captured output, got %#v", result)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	for _, want := range []string{"[stdout] factory ready", "[stderr] missing texture", "[gabs] started /bin/sh"} {
+		if !strings.Contains(result.Content[0].Text, want) {
+			t.Fatalf("expected %q in logs, got %q", want, result.Content[0].Text)
+		}
+	}
+
+	next := result.StructuredContent["next"]
+	result = callToolForTest(t, server, "games_logs", map[string]interface{}{"gameId": "factory", "since": next})
+	if lines, _ := result.StructuredContent["lines"].([]interface{}); len(lines) != 0 {
+		t.Fatalf("expected no new lines since %v, got %v", next, lines)
+	}
+
+	read := server.HandleMessage(&Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "resources/read", Params: map[string]interface{}{"uri": "gab://factory/logs"}})
+	if read == nil || read.Error != nil {
+		t.Fatalf("expected logs resource to be readable, got %#v", read)
+	}
+	if data, _ := json.Marshal(read.Result); !strings.Contains(string(data), "factory ready") {
+		t.Fatalf("expected logs resource to contain the output, got %s", data)
+	}
+
+	// A later GABS run without the in-memory capture falls back to the file.
+	server.stopGame(*game, true)
+	restarted := NewServerForTesting(util.NewLogger("error"))
+	restarted.SetConfigDir(configDir)
+	restarted.RegisterGameManagementTools(gamesConfig, 10*time.Millisecond, 100*time.Millisecond)
+	result = callToolForTest(t, restarted, "games_logs", map[string]interface{}{"gameId": "factory", "lines": 5})
+	if result.IsError || !strings.Contains(result.Content[0].Text, "earlier run") || !strings.Contains(result.Content[0].Text, "missing texture") {
+		t.Fatalf("expected output from the log file, got %#v", result)
+	}
+}
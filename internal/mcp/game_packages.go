@@ -0,0 +1,316 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/bridgepkg"
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// bridgePackageClient downloads bridge packages with an http(s) source.
+var bridgePackageClient = &http.Client{Timeout: 5 * time.Minute}
+
+func (s *Server) registerBridgePackageTools(gamesConfig *config.GamesConfig, backoffMin, backoffMax time.Duration, normalizationConfig *config.ToolNormalizationConfig) {
+	gameIdSchema := map[string]interface{}{
+		"type":        "string",
+		"description": "Game ID or launch target",
+	}
+	idSchema := map[string]interface{}{
+		"type":        "string",
+		"description": "Bridge package ID from games_bridges_list",
+	}
+	restartSchema := map[string]interface{}{
+		"type":        "boolean",
+		"description": "Stop the game if it runs, then start it and report whether its GABP bridge connects (optional, defaults to false)",
+	}
+
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.bridges.list",
+		Description: "List the GABP bridge packages configured for a game and whether each is enabled, disabled or not installed in the game's plugin directory.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": gameIdSchema,
+			},
+			"required": []string{"gameId"},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		game, failure := s.bridgePackagesGame(gamesConfig, args)
+		if failure != nil {
+			return failure, nil
+		}
+		disabledDir, err := s.disabledBridgePackagesDir(game.ID)
+		if err != nil {
+			return bridgePackageErrorResult(game.ID, "list bridge packages", err), nil
+		}
+
+		dir := game.BridgePackagesDir()
+		packages := make([]map[string]interface{}, 0, len(game.BridgePackages.Packages))
+		var text strings.Builder
+		text.WriteString(fmt.Sprintf("Bridge packages of '%s' (%s):", game.ID, dir))
+		if len(game.BridgePackages.Packages) == 0 {
+			text.WriteString(" none are configured.")
+		}
+		for _, pkg := range game.BridgePackages.Packages {
+			state := bridgepkg.State(dir, disabledDir, pkg.ID)
+			packages = append(packages, map[string]interface{}{
+				"id":          pkg.ID,
+				"source":      pkg.Source,
+				"description": pkg.Description,
+				"verified":    pkg.SHA256 != "",
+				"state":       state,
+			})
+			text.WriteString(fmt.Sprintf("\n- %s: %s", pkg.ID, state))
+			if pkg.Description != "" {
+				text.WriteString(" - " + pkg.Description)
+			}
+		}
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: text.String()}},
+			StructuredContent: map[string]interface{}{
+				"gameId":   game.ID,
+				"dir":      dir,
+				"packages": packages,
+			},
+		}, nil
+	}, normalizationConfig)
+
+	s.registerCallTool(Tool{
+		Name:        "games.bridges.install",
+		Description: "Download or copy a configured GABP bridge package into the game's plugin directory, replacing an earlier install. A running game must be restarted to load it; pass restart to do that and check that the bridge connects.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId":  gameIdSchema,
+				"id":      idSchema,
+				"restart": restartSchema,
+			},
+			"required": []string{"gameId", "id"},
+		},
+	}, func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
+		game, pkg, restart, failure := s.bridgePackageArgs(gamesConfig, args)
+		if failure != nil {
+			return failure, nil
+		}
+		disabledDir, err := s.disabledBridgePackagesDir(game.ID)
+		if err != nil {
+			return bridgePackageErrorResult(game.ID, "install bridge package "+pkg.ID, err), nil
+		}
+		return s.changeBridgePackage(*game, gamesConfig, restart, backoffMin, backoffMax, call, bridgePackageChange{
+			tool:    "games_bridges_install",
+			args:    args,
+			id:      pkg.ID,
+			action:  "install",
+			message: fmt.Sprintf("Installed bridge package '%s' for '%s'.", pkg.ID, game.ID),
+			apply: func() error {
+				call.reporter().step(fmt.Sprintf("Installing %s from %s", pkg.ID, pkg.Source))
+				return bridgepkg.Install(call.context(), bridgePackageClient, pkg, game.BridgePackagesDir(), disabledDir)
+			},
+		}), nil
+	}, normalizationConfig)
+
+	s.registerCallTool(Tool{
+		Name:        "games.bridges.enable",
+		Description: "Enable or disable an installed GABP bridge package. Disabled packages are moved out of the game's plugin directory so the game does not load them.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": gameIdSchema,
+				"id":     idSchema,
+				"enabled": map[string]interface{}{
+					"type":        "boolean",
+					"description": "false disables the package (optional, defaults to true)",
+				},
+				"restart": restartSchema,
+			},
+			"required": []string{"gameId", "id"},
+		},
+	}, func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
+		game, pkg, restart, failure := s.bridgePackageArgs(gamesConfig, args)
+		if failure != nil {
+			return failure, nil
+		}
+		enabled, hasEnabled, invalid := parseOptionalBoolArg(args, "enabled")
+		if invalid != nil {
+			return invalid, nil
+		}
+		if !hasEnabled {
+			enabled = true
+		}
+		disabledDir, err := s.disabledBridgePackagesDir(game.ID)
+		if err != nil {
+			return bridgePackageErrorResult(game.ID, "change bridge package "+pkg.ID, err), nil
+		}
+		if bridgepkg.State(game.BridgePackagesDir(), disabledDir, pkg.ID) == bridgepkg.StateNotInstalled {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Bridge package '%s' of '%s' is not installed. Use games_bridges_install first.", pkg.ID, game.ID)}},
+				StructuredContent: map[string]interface{}{
+					"gameId": game.ID,
+					"id":     pkg.ID,
+					"nextActions": []map[string]interface{}{
+						mcpNextAction("games_bridges_install", map[string]interface{}{"gameId": game.ID, "id": pkg.ID}, "Install the bridge package."),
+					},
+				},
+				IsError: true,
+			}, nil
+		}
+
+		action, message := "enable", fmt.Sprintf("Enabled bridge package '%s' for '%s'.", pkg.ID, game.ID)
+		if !enabled {
+			action, message = "disable", fmt.Sprintf("Disabled bridge package '%s' for '%s'.", pkg.ID, game.ID)
+		}
+		return s.changeBridgePackage(*game, gamesConfig, restart, backoffMin, backoffMax, call, bridgePackageChange{
+			tool:    "games_bridges_enable",
+			args:    args,
+			id:      pkg.ID,
+			action:  action,
+			message: message,
+			apply: func() error {
+				return bridgepkg.SetEnabled(game.BridgePackagesDir(), disabledDir, pkg.ID, enabled)
+			},
+		}), nil
+	}, normalizationConfig)
+}
+
+// bridgePackageChange is one change games_bridges_install or games_bridges_enable
+// makes to a game's plugin directory.
+type bridgePackageChange struct {
+	tool    string                 // Tool to call again with restart=true
+	args    map[string]interface{} // Arguments of the call
+	id      string                 // Bridge package ID
+	action  string                 // install, enable or disable
+	message string                 // Reported once apply succeeded
+	apply   func() error
+}
+
+// changeBridgePackage applies change to the game's plugin directory. A
+// running game is stopped first when restart is set and refused otherwise,
+// since it may hold the package files open. With restart the game is then
+// started, and the result reports whether GABP connected.
+func (s *Server) changeBridgePackage(game config.GameConfig, gamesConfig *config.GamesConfig, restart bool, backoffMin, backoffMax time.Duration, call *toolCall, change bridgePackageChange) *ToolResult {
+	id, action := change.id, change.action
+	if status := s.checkGameStatus(game.ID); status != "stopped" {
+		if !restart {
+			retryArgs := make(map[string]interface{}, len(change.args)+1)
+			for key, value := range change.args {
+				retryArgs[key] = value
+			}
+			retryArgs["restart"] = true
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' is %s. Pass restart=true to stop it, %s the bridge package and start it again.", game.ID, status, action)}},
+				StructuredContent: map[string]interface{}{
+					"gameId": game.ID,
+					"status": status,
+					"nextActions": []map[string]interface{}{
+						mcpNextAction(change.tool, retryArgs, "Restart the game around the change."),
+					},
+				},
+				IsError: true,
+			}
+		}
+		call.reporter().step(fmt.Sprintf("Stopping %s", game.ID))
+		if err := s.stopGame(game, false); err != nil {
+			return bridgePackageErrorResult(game.ID, "stop the game to "+action+" bridge package "+id, err)
+		}
+	}
+
+	if err := change.apply(); err != nil {
+		if errors.Is(err, bridgepkg.ErrNotInstalled) {
+			err = fmt.Errorf("%w; use games_bridges_install first", err)
+		}
+		return bridgePackageErrorResult(game.ID, action+" bridge package "+id, err)
+	}
+	s.log.Infow("changed bridge package", "gameId", game.ID, "package", id, "action", action)
+
+	if !restart {
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: change.message + " Start the game and connect to check that the bridge loads."}},
+			StructuredContent: map[string]interface{}{
+				"gameId": game.ID,
+				"id":     id,
+				"action": action,
+				"nextActions": []map[string]interface{}{
+					mcpNextAction("games_start", map[string]interface{}{"gameId": game.ID}, "Start the game so it loads the bridge package."),
+				},
+			},
+		}
+	}
+
+	result := s.restartGame(game, gamesConfig, backoffMin, backoffMax, 0, call)
+	if result.StructuredContent == nil {
+		result.StructuredContent = map[string]interface{}{}
+	}
+	result.StructuredContent["id"] = id
+	result.StructuredContent["action"] = action
+	if len(result.Content) > 0 {
+		result.Content[0].Text = change.message + " " + result.Content[0].Text
+	}
+	return result
+}
+
+// bridgePackagesGame resolves the gameId argument to a game with bridge
+// packages configured, or returns the result to answer with.
+func (s *Server) bridgePackagesGame(gamesConfig *config.GamesConfig, args map[string]interface{}) (*config.GameConfig, *ToolResult) {
+	gameIdOrTarget, ok := args["gameId"].(string)
+	if !ok {
+		return nil, &ToolResult{
+			Content: []Content{{Type: "text", Text: "gameId parameter is required"}},
+			IsError: true,
+		}
+	}
+	game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
+	if !exists {
+		return nil, &ToolResult{
+			Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' not found. Use games_list to see available games.", gameIdOrTarget)}},
+			IsError: true,
+		}
+	}
+	if game.BridgePackages == nil {
+		return nil, &ToolResult{
+			Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' has no bridge packages configured. Add bridgePackages with the game's plugin directory and the known packages to its config, then run 'gabs games reload'.", game.ID)}},
+			IsError: true,
+		}
+	}
+	return game, nil
+}
+
+// bridgePackageArgs resolves the gameId, id and restart arguments shared by
+// games_bridges_install and games_bridges_enable.
+func (s *Server) bridgePackageArgs(gamesConfig *config.GamesConfig, args map[string]interface{}) (*config.GameConfig, config.BridgePackage, bool, *ToolResult) {
+	game, failure := s.bridgePackagesGame(gamesConfig, args)
+	if failure != nil {
+		return nil, config.BridgePackage{}, false, failure
+	}
+	id, _ := args["id"].(string)
+	pkg, exists := game.BridgePackage(strings.TrimSpace(id))
+	if !exists {
+		return nil, config.BridgePackage{}, false, &ToolResult{
+			Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' has no bridge package '%s'. Use games_bridges_list to see its packages.", game.ID, id)}},
+			IsError: true,
+		}
+	}
+	restart, _, invalid := parseOptionalBoolArg(args, "restart")
+	if invalid != nil {
+		return nil, config.BridgePackage{}, false, invalid
+	}
+	return game, pkg, restart, nil
+}
+
+func (s *Server) disabledBridgePackagesDir(gameID string) (string, error) {
+	paths, err := config.NewConfigPaths(s.configDir)
+	if err != nil {
+		return "", err
+	}
+	return paths.GetDisabledBridgePackagesDir(gameID), nil
+}
+
+func bridgePackageErrorResult(gameID, action string, err error) *ToolResult {
+	return &ToolResult{
+		Content: []Content{{Type: "text", Text: fmt.Sprintf("Failed to %s for game '%s': %v", action, gameID, err)}},
+		IsError: true,
+	}
+}
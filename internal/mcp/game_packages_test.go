@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func TestBridgePackageInstallDisableAndList(t *testing.T) {
+	root := t.TempDir()
+	source := filepath.Join(root, "Bridge.dll")
+	if err := os.WriteFile(source, []byte("dll"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	pluginDir := filepath.Join(root, "plugins")
+	game := sleepingGameForTest("factory", "Factory")
+	game.BridgePackages = &config.BridgePackagesConfig{
+		Dir:      pluginDir,
+		Packages: []config.BridgePackage{{ID: "bridge", Source: source, Description: "GABP bridge"}},
+	}
+	server, _ := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{"factory": game}})
+
+	notInstalled := callToolForTest(t, server, "games_bridges_enable", map[string]interface{}{"gameId": "factory", "id": "bridge"})
+	if !notInstalled.IsError || !strings.Contains(notInstalled.Content[0].Text, "games_bridges_install") {
+		t.Fatalf("expected enable to ask for an install first, got %#v", notInstalled)
+	}
+
+	installed := callToolForTest(t, server, "games_bridges_install", map[string]interface{}{"gameId": "factory", "id": "bridge"})
+	if installed.IsError {
+		t.Fatalf("install failed: %s", installed.Content[0].Text)
+	}
+	if data, _ := os.ReadFile(filepath.Join(pluginDir, "bridge", "Bridge.dll")); string(data) != "dll" {
+		t.Fatalf("expected the package in the plugin directory, got %q", data)
+	}
+
+	disabled := callToolForTest(t, server, "games_bridges_enable", map[string]interface{}{"gameId": "factory", "id": "bridge", "enabled": false})
+	if disabled.IsError {
+		t.Fatalf("disable failed: %s", disabled.Content[0].Text)
+	}
+	listed := callToolForTest(t, server, "games_bridges_list", map[string]interface{}{"gameId": "factory"})
+	if !strings.Contains(listed.Content[0].Text, "bridge: disabled") {
+		t.Fatalf("expected the package to be listed as disabled, got %q", listed.Content[0].Text)
+	}
+	if _, err := os.Stat(filepath.Join(pluginDir, "bridge")); !os.IsNotExist(err) {
+		t.Fatalf("expected the disabled package to leave the plugin directory, got %v", err)
+	}
+}
+
+func TestBridgePackageInstallRefusesRunningGameWithoutRestart(t *testing.T) {
+	requireSleepForTest(t)
+	game := sleepingGameForTest("factory", "Factory")
+	game.BridgePackages = &config.BridgePackagesConfig{
+		Dir:      t.TempDir(),
+		Packages: []config.BridgePackage{{ID: "bridge", Source: "/nonexistent/Bridge.dll"}},
+	}
+	server, _ := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{"factory": game}})
+	trackSleepingGameForTest(t, server, "factory")
+
+	refused := callToolForTest(t, server, "games_bridges_install", map[string]interface{}{"gameId": "factory", "id": "bridge"})
+	if !refused.IsError || !strings.Contains(refused.Content[0].Text, "restart=true") {
+		t.Fatalf("expected the install to be refused while the game runs, got %#v", refused)
+	}
+	if status := server.checkGameStatus("factory"); status != "running" {
+		t.Fatalf("expected the game to keep running, got %q", status)
+	}
+}
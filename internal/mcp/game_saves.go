@@ -0,0 +1,260 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/saves"
+)
+
+// restoreSafetyLabel labels the backup games_saves_restore takes of the
+// current saves before replacing them, so a restore can itself be undone.
+const restoreSafetyLabel = "before-restore"
+
+func (s *Server) registerGameSavesTools(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	gameIdSchema := map[string]interface{}{
+		"type":        "string",
+		"description": "Game ID or launch target",
+	}
+
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.saves.list",
+		Description: "List the backups of a game's save directory, newest first.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": gameIdSchema,
+			},
+			"required": []string{"gameId"},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		game, failure := s.savesGame(gamesConfig, args)
+		if failure != nil {
+			return failure, nil
+		}
+		backupDir, err := s.savesBackupDir(game.ID)
+		if err != nil {
+			return savesErrorResult(game.ID, "list backups", err), nil
+		}
+		backups, err := saves.List(backupDir)
+		if err != nil {
+			return savesErrorResult(game.ID, "list backups", err), nil
+		}
+
+		var text strings.Builder
+		if len(backups) == 0 {
+			text.WriteString(fmt.Sprintf("Game '%s' has no save backups yet. Use games_saves_backup to take one.", game.ID))
+		} else {
+			text.WriteString(fmt.Sprintf("Save backups of '%s' (%s), newest first:", game.ID, game.SavesDir()))
+			for _, backup := range backups {
+				text.WriteString(fmt.Sprintf("\n- %s (%d bytes)", backup.Name, backup.Size))
+			}
+		}
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: text.String()}},
+			StructuredContent: map[string]interface{}{
+				"gameId":    game.ID,
+				"savesDir":  game.SavesDir(),
+				"backupDir": backupDir,
+				"keep":      game.Saves.KeepCount(),
+				"backups":   backups,
+			},
+		}, nil
+	}, normalizationConfig)
+
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.saves.backup",
+		Description: "Zip a game's save directory into a timestamped backup, for example before a risky action. The oldest backups beyond the game's saves.keep limit are removed.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": gameIdSchema,
+				"label": map[string]interface{}{
+					"type":        "string",
+					"description": "Short note added to the backup name, such as before-reset (optional)",
+				},
+			},
+			"required": []string{"gameId"},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		game, failure := s.savesGame(gamesConfig, args)
+		if failure != nil {
+			return failure, nil
+		}
+		label, _ := args["label"].(string)
+
+		s.savesMu.Lock()
+		defer s.savesMu.Unlock()
+		backup, pruned, err := s.backupSaves(game, label)
+		if err != nil {
+			return savesErrorResult(game.ID, "back up saves", err), nil
+		}
+
+		text := fmt.Sprintf("Backed up the saves of '%s' to %s.", game.ID, backup.Name)
+		if len(pruned) > 0 {
+			text += fmt.Sprintf(" Removed %d older backup(s) beyond the limit of %d.", len(pruned), game.Saves.KeepCount())
+		}
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: text}},
+			StructuredContent: map[string]interface{}{
+				"gameId": game.ID,
+				"backup": backup,
+				"pruned": backupNames(pruned),
+			},
+		}, nil
+	}, normalizationConfig)
+
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.saves.restore",
+		Description: "Replace a game's save directory with one of its backups. The game must be stopped. The current saves are backed up first, so the restore can be undone.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": gameIdSchema,
+				"backup": map[string]interface{}{
+					"type":        "string",
+					"description": "Backup name from games_saves_list",
+				},
+			},
+			"required": []string{"gameId", "backup"},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		game, failure := s.savesGame(gamesConfig, args)
+		if failure != nil {
+			return failure, nil
+		}
+		name, _ := args["backup"].(string)
+		if strings.TrimSpace(name) == "" {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "backup parameter is required"}},
+				IsError: true,
+			}, nil
+		}
+		if status := s.checkGameStatus(game.ID); status != "stopped" {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' is %s. Stop it before restoring saves, since a running game may overwrite them.", game.ID, status)}},
+				StructuredContent: map[string]interface{}{
+					"gameId": game.ID,
+					"status": status,
+					"nextActions": []map[string]interface{}{
+						mcpNextAction("games_stop", map[string]interface{}{"gameId": game.ID}, "Stop the game, then restore the saves."),
+					},
+				},
+				IsError: true,
+			}, nil
+		}
+
+		s.savesMu.Lock()
+		defer s.savesMu.Unlock()
+		backupDir, err := s.savesBackupDir(game.ID)
+		if err != nil {
+			return savesErrorResult(game.ID, "restore saves", err), nil
+		}
+		backup, err := saves.Find(backupDir, name)
+		if errors.Is(err, saves.ErrNotFound) {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' has no save backup '%s'. Use games_saves_list to see its backups.", game.ID, name)}},
+				IsError: true,
+			}, nil
+		}
+		if err != nil {
+			return savesErrorResult(game.ID, "restore saves", err), nil
+		}
+
+		// Prune only after restoring, so a low keep limit cannot remove the
+		// backup being restored.
+		safety, err := saves.Create(game.SavesDir(), backupDir, restoreSafetyLabel, s.clock.Now())
+		if err != nil {
+			return savesErrorResult(game.ID, "back up the current saves before restoring", err), nil
+		}
+		if err := saves.Restore(game.SavesDir(), backup); err != nil {
+			return savesErrorResult(game.ID, "restore saves", err), nil
+		}
+		s.log.Infow("restored game saves", "gameId", game.ID, "backup", backup.Name, "safetyBackup", safety.Name)
+		s.pruneSaves(game, backupDir)
+
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: fmt.Sprintf("Restored the saves of '%s' from %s. The previous saves are kept as %s.", game.ID, backup.Name, safety.Name)}},
+			StructuredContent: map[string]interface{}{
+				"gameId":       game.ID,
+				"restored":     backup,
+				"safetyBackup": safety,
+			},
+		}, nil
+	}, normalizationConfig)
+}
+
+// savesGame resolves the gameId argument to a game with saves configured, or
+// returns the result to answer with.
+func (s *Server) savesGame(gamesConfig *config.GamesConfig, args map[string]interface{}) (*config.GameConfig, *ToolResult) {
+	gameIdOrTarget, ok := args["gameId"].(string)
+	if !ok {
+		return nil, &ToolResult{
+			Content: []Content{{Type: "text", Text: "gameId parameter is required"}},
+			IsError: true,
+		}
+	}
+	game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
+	if !exists {
+		return nil, &ToolResult{
+			Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' not found. Use games_list to see available games.", gameIdOrTarget)}},
+			IsError: true,
+		}
+	}
+	if game.Saves == nil {
+		return nil, &ToolResult{
+			Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' has no save directory configured. Set saves.dir in its config, for example with 'gabs games edit %s --savesDir <dir>', then run 'gabs games reload'.", game.ID, game.ID)}},
+			IsError: true,
+		}
+	}
+	return game, nil
+}
+
+func (s *Server) savesBackupDir(gameID string) (string, error) {
+	paths, err := config.NewConfigPaths(s.configDir)
+	if err != nil {
+		return "", err
+	}
+	return paths.GetSavesBackupDir(gameID), nil
+}
+
+// backupSaves zips the game's saves and prunes backups beyond its limit. The
+// caller holds s.savesMu.
+func (s *Server) backupSaves(game *config.GameConfig, label string) (saves.Backup, []saves.Backup, error) {
+	backupDir, err := s.savesBackupDir(game.ID)
+	if err != nil {
+		return saves.Backup{}, nil, err
+	}
+	backup, err := saves.Create(game.SavesDir(), backupDir, label, s.clock.Now())
+	if err != nil {
+		return saves.Backup{}, nil, err
+	}
+	s.log.Infow("backed up game saves", "gameId", game.ID, "backup", backup.Name)
+	return backup, s.pruneSaves(game, backupDir), nil
+}
+
+// pruneSaves removes the game's backups beyond its limit and returns them.
+func (s *Server) pruneSaves(game *config.GameConfig, backupDir string) []saves.Backup {
+	pruned, err := saves.Prune(backupDir, game.Saves.KeepCount())
+	if err != nil {
+		s.log.Warnw("failed to remove old save backups", "gameId", game.ID, "error", err)
+	}
+	return pruned
+}
+
+func savesErrorResult(gameID, action string, err error) *ToolResult {
+	return &ToolResult{
+		Content: []Content{{Type: "text", Text: fmt.Sprintf("Failed to %s for game '%s': %v", action, gameID, err)}},
+		IsError: true,
+	}
+}
+
+func backupNames(backups []saves.Backup) []string {
+	names := make([]string, 0, len(backups))
+	for _, backup := range backups {
+		names = append(names, backup.Name)
+	}
+	return names
+}
@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestGameSavesBackupAndRestore(t *testing.T) {
+	savesDir := filepath.Join(t.TempDir(), "saves")
+	if err := os.MkdirAll(savesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	savePath := filepath.Join(savesDir, "world.sav")
+	if err := os.WriteFile(savePath, []byte("before"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	game := sleepingGameForTest("factory", "Factory")
+	game.Saves = &config.SavesConfig{Dir: savesDir, Keep: 2}
+	server, _ := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{"factory": game}})
+	clock := util.NewFakeClock(time.Date(2026, 3, 14, 10, 17, 30, 0, time.UTC))
+	server.SetClock(clock)
+
+	backedUp := callToolForTest(t, server, "games_saves_backup", map[string]interface{}{"gameId": "factory", "label": "before reset"})
+	if backedUp.IsError {
+		t.Fatalf("backup failed: %s", backedUp.Content[0].Text)
+	}
+	if !strings.Contains(backedUp.Content[0].Text, "20260314-101730-before-reset.zip") {
+		t.Fatalf("expected a timestamped, labelled backup, got %q", backedUp.Content[0].Text)
+	}
+
+	if err := os.WriteFile(savePath, []byte("after"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Minute)
+	restored := callToolForTest(t, server, "games_saves_restore", map[string]interface{}{"gameId": "factory", "backup": "20260314-101730-before-reset"})
+	if restored.IsError {
+		t.Fatalf("restore failed: %s", restored.Content[0].Text)
+	}
+	if data, _ := os.ReadFile(savePath); string(data) != "before" {
+		t.Fatalf("expected the saves to be rolled back, got %q", data)
+	}
+
+	clock.Advance(time.Minute)
+	callToolForTest(t, server, "games_saves_backup", map[string]interface{}{"gameId": "factory"})
+	listed := callToolForTest(t, server, "games_saves_list", map[string]interface{}{"gameId": "factory"})
+	text := listed.Content[0].Text
+	if !strings.Contains(text, "20260314-101930.zip") || !strings.Contains(text, "20260314-101830-before-restore.zip") {
+		t.Fatalf("expected the newest backups to be listed, got %q", text)
+	}
+	if strings.Contains(text, "before-reset") {
+		t.Fatalf("expected the oldest backup to be pruned beyond keep 2, got %q", text)
+	}
+}
+
+func TestGameSavesRestoreRefusesRunningGame(t *testing.T) {
+	requireSleepForTest(t)
+	game := sleepingGameForTest("factory", "Factory")
+	game.Saves = &config.SavesConfig{Dir: t.TempDir()}
+	server, _ := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{"factory": game}})
+	trackSleepingGameForTest(t, server, "factory")
+
+	refused := callToolForTest(t, server, "games_saves_restore", map[string]interface{}{"gameId": "factory", "backup": "any.zip"})
+	if !refused.IsError || !strings.Contains(refused.Content[0].Text, "Stop it before restoring") {
+		t.Fatalf("expected the restore to be refused while the game runs, got %#v", refused)
+	}
+}
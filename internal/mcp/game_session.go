@@ -0,0 +1,293 @@
+package mcp
+
+import (
+	"time"
+
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/process"
+)
+
+// gameSession is what GABS tracks for one game ID: the process it started,
+// the GABP connection and the MCP tools and resources mirrored from the
+// bridge. Keeping them together means a game is never half tracked, for
+// example with tools left behind by a process that is gone. Server.mu guards
+// every field.
+//
+// The readers work on a nil session, so s.sessions[gameID].gabpClient() is
+// safe for a game GABS knows nothing about. Changes go through
+// Server.sessionLocked, which creates the session.
+type gameSession struct {
+	controller  process.ControllerInterface // nil unless GABS started the game and it was not cleaned up yet
+	client      *gabp.Client                // nil unless GABP is connected or connecting
+	tools       []string                    // MCP names of the game's registered tools
+	resources   []string                    // URIs of the game's registered resources
+	bridgePort  int                         // Bridge port the running process was started with
+	generation  uint64                      // Bumped when a controller is tracked or cleaned up; see lifecycle.go
+	stopping    bool                        // A stop is under way
+	startedAt   time.Time                   // When the current controller was tracked
+	connectedAt time.Time                   // When the current GABP client was attached
+	stoppedAt   time.Time                   // When the game was last cleaned up
+	profile     string                      // Launch profile the current controller was started with
+	// lastActivity is the last tool call touching the game or GABP event
+	// from it; see idle.go.
+	lastActivity time.Time
+}
+
+// sessionLocked returns gameID's session, creating it when needed. Callers
+// hold s.mu for writing.
+func (s *Server) sessionLocked(gameID string) *gameSession {
+	if s.sessions == nil {
+		s.sessions = make(map[string]*gameSession)
+	}
+	session, exists := s.sessions[gameID]
+	if !exists {
+		session = &gameSession{}
+		s.sessions[gameID] = session
+	}
+	return session
+}
+
+// process returns the controller of the game process GABS tracks.
+func (g *gameSession) process() (process.ControllerInterface, bool) {
+	if g == nil || g.controller == nil {
+		return nil, false
+	}
+	return g.controller, true
+}
+
+// gabpClient returns the game's GABP client.
+func (g *gameSession) gabpClient() (*gabp.Client, bool) {
+	if g == nil || g.client == nil {
+		return nil, false
+	}
+	return g.client, true
+}
+
+// toolNames returns the MCP names of the game's registered tools.
+func (g *gameSession) toolNames() []string {
+	if g == nil {
+		return nil
+	}
+	return g.tools
+}
+
+// resourceURIs returns the URIs of the game's registered resources.
+func (g *gameSession) resourceURIs() []string {
+	if g == nil {
+		return nil
+	}
+	return g.resources
+}
+
+// currentGeneration returns the generation exit events are checked against.
+func (g *gameSession) currentGeneration() uint64 {
+	if g == nil {
+		return 0
+	}
+	return g.generation
+}
+
+// isStopping reports whether a stop of the game is under way.
+func (g *gameSession) isStopping() bool {
+	return g != nil && g.stopping
+}
+
+// lastActive returns when the game was last started, connected or active.
+func (g *gameSession) lastActive() time.Time {
+	last := g.lastActivity
+	for _, at := range []time.Time{g.startedAt, g.connectedAt} {
+		if at.After(last) {
+			last = at
+		}
+	}
+	return last
+}
+
+// track records controller as the game's running process and returns its
+// generation.
+func (g *gameSession) track(controller process.ControllerInterface, now time.Time) uint64 {
+	g.controller = controller
+	g.stopping = false
+	g.startedAt = now
+	g.generation++
+	return g.generation
+}
+
+// untrack forgets the game's process and reports whether there was one. It
+// always starts a new generation, so exits reported before are dropped.
+func (g *gameSession) untrack(now time.Time) bool {
+	tracked := g.controller != nil
+	g.controller = nil
+	g.bridgePort = 0
+	g.profile = ""
+	g.stopping = false
+	g.stoppedAt = now
+	g.generation++
+	return tracked
+}
+
+// attach records client as the game's GABP client.
+func (g *gameSession) attach(client *gabp.Client, now time.Time) {
+	g.client = client
+	g.connectedAt = now
+}
+
+// detach forgets the game's GABP client and returns it so the caller can
+// close it.
+func (g *gameSession) detach() (*gabp.Client, bool) {
+	client := g.client
+	g.client = nil
+	g.connectedAt = time.Time{}
+	return client, client != nil
+}
+
+// addTool records a registered tool and reports whether it was new.
+func (g *gameSession) addTool(name string) bool {
+	for _, existing := range g.tools {
+		if existing == name {
+			return false
+		}
+	}
+	g.tools = append(g.tools, name)
+	return true
+}
+
+// addResource records a registered resource and reports whether it was new.
+func (g *gameSession) addResource(uri string) bool {
+	for _, existing := range g.resources {
+		if existing == uri {
+			return false
+		}
+	}
+	g.resources = append(g.resources, uri)
+	return true
+}
+
+// takeTools forgets the game's tools and returns them for unregistering.
+func (g *gameSession) takeTools() []string {
+	tools := g.tools
+	g.tools = nil
+	return tools
+}
+
+// takeResources forgets the game's resources and returns them for
+// unregistering.
+func (g *gameSession) takeResources() []string {
+	resources := g.resources
+	g.resources = nil
+	return resources
+}
+
+// gameSessionStructured reports when GABS started the game, when it connected
+// to the bridge and when it last cleaned up after the game, for games_status.
+func (s *Server) gameSessionStructured(gameID string) map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	session := s.sessions[gameID]
+	if session == nil {
+		return nil
+	}
+
+	item := map[string]interface{}{}
+	if session.controller != nil {
+		item["startedAt"] = session.startedAt.UTC().Format(time.RFC3339)
+		if session.bridgePort > 0 {
+			item["bridgePort"] = session.bridgePort
+		}
+	} else if !session.stoppedAt.IsZero() {
+		item["stoppedAt"] = session.stoppedAt.UTC().Format(time.RFC3339)
+	}
+	if session.client != nil {
+		item["connectedAt"] = session.connectedAt.UTC().Format(time.RFC3339)
+	}
+	return item
+}
+
+// activeProfile returns the launch profile gameID's tracked process was
+// started with, or "" when it runs with the game's own settings.
+func (s *Server) activeProfile(gameID string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if session := s.sessions[gameID]; session != nil && session.controller != nil {
+		return session.profile
+	}
+	return ""
+}
+
+// Values of the state field in games_status and games_list results. Unlike
+// status, which names every phase GABS tells apart, state only ever takes
+// these values, so agents can rely on it.
+const (
+	gameStateRunning           = "running"
+	gameStateStopped           = "stopped"
+	gameStateLauncherTriggered = "launcher-triggered"
+	gameStateUnknown           = "unknown"
+)
+
+// gameStateFromStatus folds a checkGameStatus result into a game state.
+func gameStateFromStatus(status string) string {
+	switch status {
+	case "running", "running-disconnected", "connected", "shared-running", "stopping", process.RuntimeStateStatusStarting:
+		return gameStateRunning
+	case "stopped":
+		return gameStateStopped
+	case "launcher-running", "launcher-triggered":
+		return gameStateLauncherTriggered
+	default:
+		return gameStateUnknown
+	}
+}
+
+// gameStateStructured reports the machine-readable part of a game's status:
+// state, pid, uptimeSeconds and gabpConnected. pid and uptimeSeconds are nil
+// unless the game runs and GABS knows them.
+func (s *Server) gameStateStructured(gameID, status string) map[string]interface{} {
+	state := gameStateFromStatus(status)
+	item := map[string]interface{}{
+		"state":         state,
+		"pid":           nil,
+		"uptimeSeconds": nil,
+		"gabpConnected": false,
+	}
+
+	s.mu.RLock()
+	session := s.sessions[gameID]
+	controller, tracked := session.process()
+	client, hasClient := session.gabpClient()
+	var startedAt time.Time
+	if tracked {
+		startedAt = session.startedAt
+	}
+	var profile string
+	if tracked {
+		profile = session.profile
+	}
+	now := s.clock.Now()
+	s.mu.RUnlock()
+
+	item["gabpConnected"] = hasClient && client.IsConnected()
+	if state != gameStateRunning {
+		return item
+	}
+	var runtimeState *process.RuntimeState
+	if !tracked || controller.GetPID() <= 0 {
+		runtimeState, _ = process.LoadRuntimeState(gameID, s.configDir)
+	}
+	if tracked && controller.GetPID() > 0 {
+		item["pid"] = controller.GetPID()
+	} else if runtimeState != nil && runtimeState.GamePID > 0 {
+		item["pid"] = runtimeState.GamePID
+	}
+	// Another GABS session may have started the game; its runtime state
+	// still says with which profile
+	if !tracked && runtimeState != nil {
+		profile = runtimeState.Profile
+	}
+	if profile != "" {
+		item["profile"] = profile
+	}
+	if !startedAt.IsZero() {
+		item["uptimeSeconds"] = int64(now.Sub(startedAt) / time.Second)
+	}
+	return item
+}
@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestGameSessionKeepsGameStateTogether(t *testing.T) {
+	game := sleepingGameForTest("factory", "Factory")
+	server, _ := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{"factory": game}})
+	clock := util.NewFakeClock(time.Unix(0, 0))
+
+	proc := trackFakeGameForTest(t, server, game, clock)
+	registerLifecycleToolForTest(server)
+	registerLifecycleToolForTest(server)
+
+	server.mu.Lock()
+	session := server.sessions["factory"]
+	if tools := session.toolNames(); len(tools) != 1 {
+		server.mu.Unlock()
+		t.Fatalf("expected the tool to be recorded once, got %v", tools)
+	}
+	if !session.addResource("gabs://factory/world") || session.addResource("gabs://factory/world") {
+		server.mu.Unlock()
+		t.Fatalf("expected the resource to be recorded once, got %v", session.resourceURIs())
+	}
+	server.mu.Unlock()
+
+	if item := server.gameStatusStructured(game, "running"); item["startedAt"] == nil {
+		t.Fatalf("expected the game status to report when the game was started, got %#v", item)
+	}
+
+	generation := server.gameGeneration("factory")
+	proc.Exit(nil)
+	server.cleanupStoppedGame("factory", generation)
+
+	server.mu.RLock()
+	defer server.mu.RUnlock()
+	session = server.sessions["factory"]
+	if _, tracked := session.process(); tracked {
+		t.Fatal("expected the exited game's process to be forgotten")
+	}
+	if len(session.toolNames()) != 0 || len(session.resourceURIs()) != 0 {
+		t.Fatalf("expected cleanup to take the game's tools and resources, got %v and %v", session.toolNames(), session.resourceURIs())
+	}
+	if session.currentGeneration() <= generation || session.stoppedAt.IsZero() {
+		t.Fatal("expected cleanup to start a new generation and record when the game stopped")
+	}
+}
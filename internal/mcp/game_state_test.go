@@ -0,0 +1,85 @@
+package mcp
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestGameStateFromStatus(t *testing.T) {
+	for status, want := range map[string]string{
+		"running":              gameStateRunning,
+		"running-disconnected": gameStateRunning,
+		"connected":            gameStateRunning,
+		"shared-running":       gameStateRunning,
+		"starting":             gameStateRunning,
+		"stopped":              gameStateStopped,
+		"launcher-running":     gameStateLauncherTriggered,
+		"launcher-triggered":   gameStateLauncherTriggered,
+		"disconnected":         gameStateUnknown,
+	} {
+		if got := gameStateFromStatus(status); got != want {
+			t.Errorf("status %s: expected state %s, got %s", status, want, got)
+		}
+	}
+}
+
+func TestGamesStatusAndListReportMachineReadableState(t *testing.T) {
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"adventure": {ID: "adventure", Name: "Adventure", LaunchMode: "DirectPath", Target: "/opt/adventure/run"},
+			"factory":   {ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/opt/factory/run"},
+		},
+	}
+	server, _ := newGamesTestServer(t, gamesConfig)
+	clock := util.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	server.SetClock(clock)
+
+	gamePID := os.Getpid()
+	controller := process.NewControllerWithRunner(process.NewFakeProcessRunner(gamePID), nil)
+	if err := controller.Configure(process.LaunchSpec{GameId: "adventure", Mode: "DirectPath", PathOrId: "/opt/adventure/run"}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	server.mu.Lock()
+	server.trackGameLocked("adventure", controller)
+	server.mu.Unlock()
+	clock.Advance(90 * time.Second)
+
+	status := callToolForTest(t, server, "games_status", map[string]interface{}{"gameId": "adventure"})
+	if status.IsError {
+		t.Fatalf("games_status failed: %#v", status)
+	}
+	structured := status.StructuredContent
+	if structured["state"] != gameStateRunning || structured["pid"] != float64(gamePID) || structured["uptimeSeconds"] != float64(90) || structured["gabpConnected"] != false || structured["toolCount"] != float64(0) {
+		t.Fatalf("unexpected state fields %#v", structured)
+	}
+
+	list := callToolForTest(t, server, "games_list", map[string]interface{}{})
+	games, _ := list.StructuredContent["games"].([]interface{})
+	if len(games) != 2 {
+		t.Fatalf("expected two games, got %#v", list.StructuredContent)
+	}
+	for _, raw := range games {
+		game, _ := raw.(map[string]interface{})
+		switch game["gameId"] {
+		case "adventure":
+			if game["state"] != gameStateRunning || game["pid"] != float64(gamePID) {
+				t.Fatalf("expected adventure to be running, got %#v", game)
+			}
+		case "factory":
+			if game["state"] != gameStateStopped || game["pid"] != nil || game["uptimeSeconds"] != nil || game["gabpConnected"] != false {
+				t.Fatalf("expected factory to be stopped, got %#v", game)
+			}
+			if _, present := game["pid"]; !present {
+				t.Fatal("expected pid to be present even when the game is stopped")
+			}
+		}
+	}
+}
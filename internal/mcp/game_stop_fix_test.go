@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
 	"github.com/pardeike/gabs/internal/util"
 )
 
@@ -493,3 +494,64 @@ func TestImprovedStatusReporting(t *testing.T) {
 		t.Log("✓ Status descriptions work correctly for stopped games")
 	})
 }
+
+// trackFakeGameForTest starts a fake process for game on clock and tracks it
+// as if games_start had launched it.
+func trackFakeGameForTest(t *testing.T, server *Server, game config.GameConfig, clock util.Clock) *process.FakeProcess {
+	t.Helper()
+	runner := process.NewFakeProcessRunner(4300)
+	controller := process.NewControllerWithRunner(runner, clock)
+	if err := controller.Configure(launchSpecFromGame(game)); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	server.mu.Lock()
+	server.trackGameLocked(game.ID, controller)
+	server.mu.Unlock()
+	return runner.Processes()[0]
+}
+
+func TestGamesStopVerifiesTheGameExited(t *testing.T) {
+	game := sleepingGameForTest("factory", "Factory")
+	server, _ := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{"factory": game}})
+	clock := util.NewFakeClock(time.Unix(0, 0))
+
+	stopAfterGrace := func(args map[string]interface{}) ToolResult {
+		done := make(chan ToolResult, 1)
+		go func() { done <- callToolForTest(t, server, "games_stop", args) }()
+		clock.BlockUntil(2)
+		if status := server.checkGameStatus("factory"); status != "stopping" {
+			t.Errorf("expected status stopping while waiting for the game to exit, got %q", status)
+		}
+		clock.Advance(stopGracePeriod)
+		return <-done
+	}
+
+	proc := trackFakeGameForTest(t, server, game, clock)
+	if result := callToolForTest(t, server, "games_stop", map[string]interface{}{"gameId": "factory"}); result.IsError || result.StructuredContent["escalated"] != false {
+		t.Fatalf("expected a game that exits on the signal to stop without a kill, got %#v", result)
+	}
+	if proc.Alive() || server.checkGameStatus("factory") != "stopped" {
+		t.Fatal("expected the game to be stopped and untracked")
+	}
+
+	proc = trackFakeGameForTest(t, server, game, clock)
+	proc.IgnoreSignals = true
+	result := stopAfterGrace(map[string]interface{}{"gameId": "factory", "escalate": false})
+	if !result.IsError || result.StructuredContent["status"] != "failed to stop" || result.StructuredContent["killed"] != false {
+		t.Fatalf("expected a game that ignores the signal to be reported as still running, got %#v", result)
+	}
+	if !proc.Alive() || server.checkGameStatus("factory") != "running" {
+		t.Fatal("expected the game to keep running and stay tracked")
+	}
+
+	result = stopAfterGrace(map[string]interface{}{"gameId": "factory"})
+	if result.IsError || result.StructuredContent["escalated"] != true || !strings.Contains(result.Content[0].Text, "was killed") {
+		t.Fatalf("expected the game to be killed after the grace period, got %#v", result)
+	}
+	if proc.Alive() || server.checkGameStatus("factory") != "stopped" {
+		t.Fatal("expected the killed game to be stopped and untracked")
+	}
+}
@@ -0,0 +1,229 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// importRestrictedFields lists game settings games.import refuses, for the
+// same reason games.update cannot change them: an agent must not widen what
+// GABS executes or installs, which machines it reaches or which directories
+// games_saves_restore overwrites, and environment variables such as
+// LD_PRELOAD can change what a game runs. Use 'gabs games import' from a
+// shell to import them.
+func importRestrictedFields(game config.GameConfig) []string {
+	var fields []string
+	if len(game.AllowedCommands) > 0 {
+		fields = append(fields, "allowedCommands")
+	}
+	if game.SSHTunnel != nil {
+		fields = append(fields, "sshTunnel")
+	}
+	if game.Proton != nil {
+		fields = append(fields, "proton")
+	}
+	if game.Remote != nil {
+		fields = append(fields, "remote")
+	}
+	if len(game.Env) > 0 {
+		fields = append(fields, "env")
+	}
+	if game.EnvFile != "" {
+		fields = append(fields, "envFile")
+	}
+	if game.Saves != nil {
+		fields = append(fields, "saves")
+	}
+	if game.BridgePackages != nil {
+		fields = append(fields, "bridgePackages")
+	}
+	for _, name := range game.ProfileNames() {
+		if len(game.Profiles[name].Env) > 0 {
+			fields = append(fields, "profiles."+name+".env")
+		}
+	}
+	return fields
+}
+
+// importLaunchFields lists the launch settings games.import would set for a
+// new game or change for an existing one. Like games.update, the MCP tool
+// leaves what GABS runs to the user: a new game always brings a launchMode and
+// target, so only changes to existing games without launch changes get in.
+func importLaunchFields(existing *config.GameConfig, planned config.GameConfig) []string {
+	var current config.GameConfig
+	if existing != nil {
+		current = *existing
+	}
+	var fields []string
+	if planned.LaunchMode != current.LaunchMode {
+		fields = append(fields, "launchMode")
+	}
+	if planned.Target != current.Target {
+		fields = append(fields, "target")
+	}
+	if !slices.Equal(planned.Args, current.Args) {
+		fields = append(fields, "args")
+	}
+	if planned.WorkingDir != current.WorkingDir {
+		fields = append(fields, "workingDir")
+	}
+	return fields
+}
+
+func decodeGamesExport(value interface{}) (config.GamesExport, error) {
+	var doc config.GamesExport
+	data, err := json.Marshal(value)
+	if err != nil {
+		return doc, err
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return doc, fmt.Errorf("document is not a games export: %w", err)
+	}
+	return doc, nil
+}
+
+func importResultText(result config.ImportResult) string {
+	var parts []string
+	for _, group := range []struct {
+		label string
+		ids   []string
+	}{
+		{"added", result.Added},
+		{"overwrote", result.Overwritten},
+		{"merged", result.Merged},
+		{"skipped", result.Skipped},
+	} {
+		if len(group.ids) > 0 {
+			parts = append(parts, fmt.Sprintf("%s %s", group.label, strings.Join(group.ids, ", ")))
+		}
+	}
+	if len(parts) == 0 {
+		return "The document contained no games."
+	}
+	return "Imported games: " + strings.Join(parts, "; ") + "."
+}
+
+func (s *Server) registerGameTransferTools(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.export",
+		Description: "Export every game and cluster definition as a portable JSON document that games_import or 'gabs games import' can load on another machine",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		doc := gamesConfig.ExportGames()
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: string(data)}},
+			StructuredContent: map[string]interface{}{
+				"document":  doc,
+				"gameCount": len(doc.Games),
+			},
+		}, nil
+	}, normalizationConfig)
+
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.import",
+		Description: "Import game and cluster definitions from a games_export document and save them to the GABS config. New games, launch setting changes and games with allowedCommands, sshTunnel, env or envFile must be imported with 'gabs games import' instead.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"document": map[string]interface{}{
+					"type":        "object",
+					"description": "The document returned by games_export",
+				},
+				"mode": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{string(config.ImportSkip), string(config.ImportOverwrite), string(config.ImportMerge)},
+					"description": "What to do with IDs that already exist: skip keeps them (default), overwrite replaces them, merge applies the imported non-empty fields",
+				},
+			},
+			"required": []string{"document"},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		modeArg, _ := args["mode"].(string)
+		mode, err := config.ParseImportMode(modeArg)
+		if err != nil {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: err.Error()}},
+				IsError: true,
+			}, nil
+		}
+		if _, ok := args["document"].(map[string]interface{}); !ok {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "document parameter is required; pass the result of games_export"}},
+				IsError: true,
+			}, nil
+		}
+		doc, err := decodeGamesExport(args["document"])
+		if err != nil {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: err.Error()}},
+				IsError: true,
+			}, nil
+		}
+
+		var restricted []string
+		for id, game := range doc.Games {
+			if fields := importRestrictedFields(game); len(fields) > 0 {
+				restricted = append(restricted, fmt.Sprintf("%s (%s)", id, strings.Join(fields, ", ")))
+			}
+		}
+		var candidate *config.GamesConfig
+		var result config.ImportResult
+		if len(restricted) == 0 {
+			candidate, result, err = gamesConfig.PlanImport(doc, mode)
+			if err != nil {
+				return &ToolResult{
+					Content: []Content{{Type: "text", Text: fmt.Sprintf("Nothing was imported: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+			for id := range doc.Games {
+				existing, _ := gamesConfig.GetGame(id)
+				if fields := importLaunchFields(existing, candidate.Games[id]); len(fields) > 0 {
+					restricted = append(restricted, fmt.Sprintf("%s (%s)", id, strings.Join(fields, ", ")))
+				}
+			}
+		}
+		if len(restricted) > 0 {
+			sort.Strings(restricted)
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Nothing was imported. These games set fields games_import cannot change: %s. Run 'gabs games import' from a shell to import them.", strings.Join(restricted, "; "))}},
+				IsError: true,
+			}, nil
+		}
+
+		if result.Changed() {
+			gamesConfig.ReplaceDefinitions(candidate.Games, candidate.Clusters)
+			if err := config.SaveGamesConfigToDir(gamesConfig, s.configDir); err != nil {
+				return &ToolResult{
+					Content: []Content{{Type: "text", Text: fmt.Sprintf("Imported in memory, but failed to save the config: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+			s.log.Infow("imported game configuration", "mode", mode, "added", result.Added, "overwritten", result.Overwritten, "merged", result.Merged)
+			s.SendToolsListChangedNotification()
+		}
+
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: importResultText(result)}},
+			StructuredContent: map[string]interface{}{
+				"mode":        mode,
+				"added":       result.Added,
+				"overwritten": result.Overwritten,
+				"merged":      result.Merged,
+				"skipped":     result.Skipped,
+			},
+		}, nil
+	}, normalizationConfig)
+}
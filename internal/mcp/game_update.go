@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// gameUpdateStringFields maps the plain string settings games.update may
+// change to their setters. Settings that decide what GABS executes or where it
+// connects, such as launchMode, target, args, workingDir, allowedCommands and
+// sshTunnel, are deliberately left out; they are changed with gabs games edit.
+var gameUpdateStringFields = map[string]func(*config.GameConfig, string){
+	"name":            func(g *config.GameConfig, v string) { g.Name = v },
+	"stopProcessName": func(g *config.GameConfig, v string) { g.StopProcessName = v },
+	"gabpMode":        func(g *config.GameConfig, v string) { g.GABPMode = v },
+	"bridgeMode":      func(g *config.GameConfig, v string) { g.BridgeMode = v },
+	"description":     func(g *config.GameConfig, v string) { g.Description = v },
+	"defaultProfile":  func(g *config.GameConfig, v string) { g.DefaultProfile = v },
+}
+
+func gameUpdateFieldNames() []string {
+	names := make([]string, 0, len(gameUpdateStringFields))
+	for name := range gameUpdateStringFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyGamePatch returns game with patch applied. A null value clears a field.
+func applyGamePatch(game config.GameConfig, patch map[string]interface{}) (config.GameConfig, []string, error) {
+	keys := make([]string, 0, len(patch))
+	for key := range patch {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := patch[key]
+		set, ok := gameUpdateStringFields[key]
+		if !ok {
+			return game, nil, fmt.Errorf("patch.%s cannot be changed with games_update; allowed fields: %s. Launch settings are changed with gabs games edit", key, strings.Join(gameUpdateFieldNames(), ", "))
+		}
+		switch typed := value.(type) {
+		case nil:
+			set(&game, "")
+		case string:
+			set(&game, strings.TrimSpace(typed))
+		default:
+			return game, nil, fmt.Errorf("patch.%s must be a string or null", key)
+		}
+	}
+	return game, keys, nil
+}
+
+// persistGameConfig validates game, stores it in gamesConfig and writes the
+// config file.
+func (s *Server) persistGameConfig(gamesConfig *config.GamesConfig, game config.GameConfig) error {
+	if err := gamesConfig.AddGame(game); err != nil {
+		return err
+	}
+	if err := config.SaveGamesConfigToDir(gamesConfig, s.configDir); err != nil {
+		return fmt.Errorf("updated in memory, but failed to save the config: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) registerGameUpdateTool(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	stringField := func(description string) map[string]interface{} {
+		return map[string]interface{}{"type": []string{"string", "null"}, "description": description}
+	}
+
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.update",
+		Description: "Change settings of a configured game, such as a missing stopProcessName, and save them to the GABS config",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": map[string]interface{}{
+					"type":        "string",
+					"description": "Game ID or launch target",
+				},
+				"patch": map[string]interface{}{
+					"type":                 "object",
+					"description":          "Fields to change. Fields that are left out keep their value; null clears an optional field.",
+					"additionalProperties": false,
+					"properties": map[string]interface{}{
+						"name":            stringField("Display name"),
+						"stopProcessName": stringField("Process name used to stop launcher-started games"),
+						"gabpMode":        stringField("GABP connection mode"),
+						"bridgeMode":      stringField(config.BridgeModeConnect + "|" + config.BridgeModeListen + ": whether GABS connects to the bridge or the bridge connects to GABS"),
+						"description":     stringField("Description"),
+					},
+				},
+			},
+			"required": []string{"gameId", "patch"},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		gameIdOrTarget, ok := args["gameId"].(string)
+		if !ok || strings.TrimSpace(gameIdOrTarget) == "" {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "gameId parameter is required"}},
+				IsError: true,
+			}, nil
+		}
+		patch, ok := args["patch"].(map[string]interface{})
+		if !ok || len(patch) == 0 {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("patch must be an object with at least one of: %s", strings.Join(gameUpdateFieldNames(), ", "))}},
+				IsError: true,
+			}, nil
+		}
+
+		game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
+		if !exists {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' not found. Use games_list to see available games.", gameIdOrTarget)}},
+				IsError: true,
+			}, nil
+		}
+		gameID := game.ID
+
+		updated, fields, err := applyGamePatch(*game, patch)
+		if err == nil {
+			err = s.persistGameConfig(gamesConfig, updated)
+		}
+		if err != nil {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' was not updated: %v", gameID, err)}},
+				IsError: true,
+			}, nil
+		}
+		s.log.Infow("updated game configuration", "gameId", gameID, "fields", fields)
+
+		text := fmt.Sprintf("Updated game '%s': %s.", gameID, strings.Join(fields, ", "))
+		status := s.checkGameStatus(gameID)
+		if status != "stopped" {
+			text += " The game is running; launch settings apply from its next start."
+			s.SendToolsListChangedNotification()
+		}
+
+		structured := gameConfigStructured(updated)
+		structured["updatedFields"] = fields
+		structured["status"] = status
+		return &ToolResult{
+			Content:           []Content{{Type: "text", Text: text}},
+			StructuredContent: structured,
+		}, nil
+	}, normalizationConfig)
+}
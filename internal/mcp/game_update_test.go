@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func TestGamesUpdatePatchesAndSavesConfig(t *testing.T) {
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"adventure": {ID: "adventure", Name: "Adventure", LaunchMode: "DirectPath", Target: "/opt/adventure/start.sh", Args: []string{"-windowed"}, Description: "old"},
+		},
+	}
+	server, configDir := newGamesTestServer(t, gamesConfig)
+
+	result := callToolForTest(t, server, "games_update", map[string]interface{}{
+		"gameId": "adventure",
+		"patch": map[string]interface{}{
+			"name":            "Adventure Deluxe",
+			"stopProcessName": "AdventureGame.exe",
+			"description":     nil,
+		},
+	})
+	if result.IsError {
+		t.Fatalf("expected update to succeed, got %#v", result)
+	}
+	fields, _ := result.StructuredContent["updatedFields"].([]interface{})
+	if len(fields) != 3 {
+		t.Fatalf("expected three updated fields, got %#v", result.StructuredContent["updatedFields"])
+	}
+
+	saved, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		t.Fatalf("load saved config: %v", err)
+	}
+	game, ok := saved.GetGame("adventure")
+	if !ok {
+		t.Fatal("expected adventure in the saved config")
+	}
+	if game.Name != "Adventure Deluxe" || game.StopProcessName != "AdventureGame.exe" || game.Description != "" {
+		t.Fatalf("expected patched name and stopProcessName and a cleared description, got %#v", game)
+	}
+	if game.Target != "/opt/adventure/start.sh" || len(game.Args) != 1 {
+		t.Fatalf("expected launch settings to be kept, got %#v", game)
+	}
+}
+
+func TestGamesUpdateRefusesLaunchSettings(t *testing.T) {
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"adventure": {ID: "adventure", Name: "Adventure", LaunchMode: "DirectPath", Target: "/opt/adventure/start.sh", Args: []string{"-windowed"}, WorkingDir: "/opt/adventure"},
+		},
+	}
+	server, _ := newGamesTestServer(t, gamesConfig)
+
+	for _, patch := range []map[string]interface{}{
+		{"launchMode": "CustomCommand"},
+		{"target": "/bin/sh"},
+		{"args": []interface{}{"-c", "echo pwned"}},
+		{"workingDir": "/tmp"},
+	} {
+		result := callToolForTest(t, server, "games_update", map[string]interface{}{"gameId": "adventure", "patch": patch})
+		if !result.IsError || !strings.Contains(result.Content[0].Text, "cannot be changed") {
+			t.Fatalf("expected %v to be refused, got %#v", patch, result)
+		}
+	}
+
+	game, _ := gamesConfig.GetGame("adventure")
+	if game.LaunchMode != "DirectPath" || game.Target != "/opt/adventure/start.sh" || len(game.Args) != 1 || game.WorkingDir != "/opt/adventure" {
+		t.Fatalf("expected launch settings to stay unchanged, got %#v", game)
+	}
+}
+
+func TestGamesUpdateRejectsInvalidPatchWithoutSaving(t *testing.T) {
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"adventure": {ID: "adventure", Name: "Adventure", LaunchMode: "SteamAppId", Target: "123456", StopProcessName: "AdventureGame.exe"},
+		},
+	}
+	server, configDir := newGamesTestServer(t, gamesConfig)
+
+	tests := []struct {
+		name  string
+		patch map[string]interface{}
+		want  string
+	}{
+		{name: "ValidationFails", patch: map[string]interface{}{"stopProcessName": nil}, want: "stopProcessName"},
+		{name: "UnknownField", patch: map[string]interface{}{"allowedCommands": map[string]interface{}{}}, want: "cannot be changed"},
+		{name: "WrongType", patch: map[string]interface{}{"description": []interface{}{"old"}}, want: "string or null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callToolForTest(t, server, "games_update", map[string]interface{}{"gameId": "adventure", "patch": tt.patch})
+			if !result.IsError || !strings.Contains(result.Content[0].Text, tt.want) {
+				t.Fatalf("expected error mentioning %q, got %#v", tt.want, result)
+			}
+		})
+	}
+
+	if game, _ := gamesConfig.GetGame("adventure"); game.StopProcessName != "AdventureGame.exe" {
+		t.Fatalf("expected in-memory config to stay unchanged, got %#v", game)
+	}
+	if saved, err := config.LoadGamesConfigFromDir(configDir); err == nil {
+		if _, ok := saved.GetGame("adventure"); ok {
+			t.Fatal("expected nothing to be saved for rejected patches")
+		}
+	}
+}
+
+func TestGamesUpdateNotifiesClientsWhenGameIsRunning(t *testing.T) {
+	requireSleepForTest(t)
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"factory": sleepingGameForTest("factory", "Factory"),
+		},
+	}
+	server, _ := newGamesTestServer(t, gamesConfig)
+	trackSleepingGameForTest(t, server, "factory")
+
+	client := server.addClient(clientTransportStdio, nil)
+	t.Cleanup(func() { server.removeClient(client) })
+
+	result := callToolForTest(t, server, "games_update", map[string]interface{}{
+		"gameId": "factory",
+		"patch":  map[string]interface{}{"description": "Factory with bridge packages"},
+	})
+	if result.IsError || result.StructuredContent["status"] != "running" {
+		t.Fatalf("expected update of a running game to succeed, got %#v", result)
+	}
+	select {
+	case msg := <-client.outbox:
+		if msg.Method != "notifications/tools/list_changed" {
+			t.Fatalf("expected tools/list_changed notification, got %q", msg.Method)
+		}
+	default:
+		t.Fatal("expected a tools/list_changed notification")
+	}
+}
@@ -0,0 +1,149 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+)
+
+func gameMetricsURI(gameID string) string {
+	return fmt.Sprintf("gab://%s/metrics", gameID)
+}
+
+// gameProcessPIDs returns the live processes of a game: the process GABS
+// started, the one recorded in the shared runtime state, and every process
+// named stopProcessName. Launcher games are only found by the latter.
+func (s *Server) gameProcessPIDs(game config.GameConfig) []int {
+	var pids []int
+	add := func(pid int) {
+		if pid > 0 && !containsPID(pids, pid) && process.IsProcessAlive(pid) {
+			pids = append(pids, pid)
+		}
+	}
+
+	s.mu.RLock()
+	controller, _ := s.sessions[game.ID].process()
+	s.mu.RUnlock()
+	if controller != nil {
+		add(controller.GetPID())
+	}
+	if state, err := process.LoadRuntimeState(game.ID, s.configDir); err == nil && state != nil {
+		add(state.GamePID)
+	}
+	if game.StopProcessName != "" {
+		if found, err := process.FindProcessesByName(game.StopProcessName); err == nil {
+			for _, pid := range found {
+				add(pid)
+			}
+		}
+	}
+	sort.Ints(pids)
+	return pids
+}
+
+// gameResourceUsage samples CPU, resident memory and uptime of a game's
+// processes. It returns nil when no process of the game is running.
+func (s *Server) gameResourceUsage(game config.GameConfig) map[string]interface{} {
+	var processes []map[string]interface{}
+	var cpuPercent float64
+	var rssBytes uint64
+	var uptime time.Duration
+	var sampledAt time.Time
+
+	for _, pid := range s.gameProcessPIDs(game) {
+		sample, err := s.usage.Sample(pid)
+		if err != nil {
+			s.log.Debugw("failed to sample game process", "gameId", game.ID, "pid", pid, "error", err)
+			continue
+		}
+		processes = append(processes, map[string]interface{}{
+			"pid":           pid,
+			"cpuPercent":    roundPercent(sample.CPUPercent),
+			"rssBytes":      sample.RSSBytes,
+			"uptimeSeconds": int64(sample.Uptime / time.Second),
+		})
+		cpuPercent += sample.CPUPercent
+		rssBytes += sample.RSSBytes
+		if sample.Uptime > uptime {
+			uptime = sample.Uptime
+		}
+		sampledAt = sample.At
+	}
+	if len(processes) == 0 {
+		return nil
+	}
+	return map[string]interface{}{
+		"cpuPercent":    roundPercent(cpuPercent),
+		"rssBytes":      rssBytes,
+		"uptimeSeconds": int64(uptime / time.Second),
+		"processes":     processes,
+		"sampledAt":     sampledAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func roundPercent(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+// resourceUsageSummaryText renders the totals of gameResourceUsage as one
+// line for text output.
+func resourceUsageSummaryText(resources map[string]interface{}) string {
+	if resources == nil {
+		return ""
+	}
+	cpu, _ := resources["cpuPercent"].(float64)
+	rss, _ := resources["rssBytes"].(uint64)
+	uptime, _ := resources["uptimeSeconds"].(int64)
+	summary := fmt.Sprintf("Resources: CPU %.1f%%, memory %.1f MiB", cpu, float64(rss)/(1024*1024))
+	if uptime > 0 {
+		summary += fmt.Sprintf(", up %s", (time.Duration(uptime) * time.Second).String())
+	}
+	if processes, _ := resources["processes"].([]map[string]interface{}); len(processes) > 1 {
+		summary += fmt.Sprintf(" across %d processes", len(processes))
+	}
+	return summary
+}
+
+// registerGameMetricsResource exposes gab://<gameId>/metrics once a game has
+// been started.
+func (s *Server) registerGameMetricsResource(game config.GameConfig) {
+	uri := gameMetricsURI(game.ID)
+	s.mu.RLock()
+	_, exists := s.resources[uri]
+	s.mu.RUnlock()
+	if exists {
+		return
+	}
+
+	gameID := game.ID
+	s.RegisterResource(Resource{
+		URI:         uri,
+		Name:        fmt.Sprintf("%s Resource Usage", gameID),
+		Description: fmt.Sprintf("CPU, memory and uptime of the processes of game %s", gameID),
+		MimeType:    "application/json",
+	}, func() ([]Content, error) {
+		current := game
+		if s.gamesConfig != nil {
+			if configured, ok := s.gamesConfig.GetGame(gameID); ok {
+				current = *configured
+			}
+		}
+		report := map[string]interface{}{"gameId": gameID, "running": false}
+		if resources := s.gameResourceUsage(current); resources != nil {
+			report["running"] = true
+			for key, value := range resources {
+				report[key] = value
+			}
+		}
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return []Content{{Type: "text", Text: string(data)}}, nil
+	})
+}
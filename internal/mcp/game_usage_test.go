@@ -0,0 +1,76 @@
+package mcp
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+)
+
+func TestGamesStatusAndMetricsResourceReportResourceUsage(t *testing.T) {
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"adventure": {ID: "adventure", Name: "Adventure", LaunchMode: "DirectPath", Target: "/opt/adventure/run", StopProcessName: "AdventureGame"},
+		},
+	}
+	server, _ := newGamesTestServer(t, gamesConfig)
+	gamePID := os.Getpid()
+	t.Cleanup(process.SetFindProcessesByNameForTesting(func(name string) ([]int, error) {
+		if name == "AdventureGame" {
+			return []int{gamePID}, nil
+		}
+		return nil, nil
+	}))
+	t.Cleanup(process.SetReadResourceUsageForTesting(func(pid int) (process.ResourceUsage, error) {
+		return process.ResourceUsage{
+			PID:       pid,
+			CPUTime:   30 * time.Second,
+			RSSBytes:  512 << 20,
+			StartedAt: time.Now().Add(-2 * time.Minute),
+		}, nil
+	}))
+
+	controller := process.NewControllerWithRunner(process.NewFakeProcessRunner(gamePID), nil)
+	game, _ := gamesConfig.GetGame("adventure")
+	if err := controller.Configure(process.LaunchSpec{GameId: game.ID, Mode: game.LaunchMode, PathOrId: game.Target, StopProcessName: game.StopProcessName}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	server.mu.Lock()
+	server.trackGameLocked(game.ID, controller)
+	server.mu.Unlock()
+	server.registerGameMetricsResource(*game)
+
+	result := callToolForTest(t, server, "games_status", map[string]interface{}{"gameId": "adventure"})
+	resources, ok := result.StructuredContent["resources"].(map[string]interface{})
+	if result.IsError || !ok {
+		t.Fatalf("expected resource usage in games_status, got %#v", result.StructuredContent)
+	}
+	if resources["rssBytes"] != float64(512<<20) || resources["cpuPercent"] != float64(25) {
+		t.Fatalf("unexpected resource usage %#v", resources)
+	}
+	if processes, _ := resources["processes"].([]interface{}); len(processes) != 1 {
+		t.Fatalf("expected the controller and stopProcessName PIDs to be counted once, got %#v", resources["processes"])
+	}
+	if !strings.Contains(result.Content[0].Text, "Resources: CPU 25.0%, memory 512.0 MiB, up 2m0s") {
+		t.Fatalf("expected a resources line in the status text, got %q", result.Content[0].Text)
+	}
+
+	response := server.HandleMessage(&Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "resources/read", Params: map[string]interface{}{"uri": gameMetricsURI("adventure")}})
+	var read struct {
+		Contents []Content `json:"contents"`
+	}
+	if response.Error != nil || decodeResult(response.Result, &read) != nil || len(read.Contents) != 1 {
+		t.Fatalf("unexpected metrics resource read: %#v", response)
+	}
+	var report map[string]interface{}
+	if err := json.Unmarshal([]byte(read.Contents[0].Text), &report); err != nil || report["running"] != true || report["rssBytes"] != float64(512<<20) {
+		t.Fatalf("expected the metrics resource to report the running game, got %q (%v)", read.Contents[0].Text, err)
+	}
+}
@@ -0,0 +1,270 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/epic"
+	"github.com/pardeike/gabs/internal/process"
+	"github.com/pardeike/gabs/internal/steam"
+)
+
+// Outcomes of a games_validate check.
+const (
+	checkOK      = "ok"
+	checkWarning = "warning"
+	checkError   = "error"
+	checkSkipped = "skipped"
+)
+
+// validationCheck is one line of the games_validate report.
+type validationCheck struct {
+	Check   string // What was checked, such as "target"
+	Status  string // checkOK, checkWarning, checkError or checkSkipped
+	Message string
+}
+
+// validateGameSetup checks whether game could be launched on this machine
+// without launching it: the config itself, the target, the working directory
+// and stopProcessName.
+func validateGameSetup(game config.GameConfig) []validationCheck {
+	var checks []validationCheck
+	add := func(check, status, format string, args ...interface{}) {
+		checks = append(checks, validationCheck{Check: check, Status: status, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if err := game.Validate(); err != nil {
+		add("config", checkError, "%v", err)
+	} else {
+		add("config", checkOK, "configuration is valid")
+	}
+
+	// The executable the game runs as, when GABS can tell, for the
+	// stopProcessName check
+	var executable string
+	var stopCandidates []string
+
+	switch game.LaunchMode {
+	case "", "DirectPath":
+		if game.Target == "" {
+			add("target", checkError, "no target executable is configured")
+			break
+		}
+		info, err := os.Stat(game.Target)
+		switch {
+		case err != nil:
+			add("target", checkError, "target not found: %v", err)
+		case info.IsDir():
+			add("target", checkError, "target %s is a directory, not an executable", game.Target)
+		case !isExecutable(game.Target, info):
+			add("target", checkError, "target %s is not executable", game.Target)
+		default:
+			add("target", checkOK, "target %s is executable", game.Target)
+			executable = game.Target
+		}
+	case "CustomCommand":
+		if path, err := exec.LookPath(game.Target); err != nil {
+			add("target", checkError, "command %s not found: %v", game.Target, err)
+		} else {
+			add("target", checkOK, "command %s resolves to %s", game.Target, path)
+		}
+	case "SteamAppId", "SteamManaged":
+		if app, err := steam.ResolveApp(game.Target); err != nil {
+			add("target", checkError, "Steam app %s does not appear to be installed: %v", game.Target, err)
+		} else {
+			add("target", checkOK, "Steam app %s (%s) is installed at %s", app.AppID, app.Name, app.InstallPath)
+			executable = app.Executable
+		}
+	case "EpicAppId":
+		if app, err := epic.FindApp(game.Target); err != nil {
+			add("target", checkError, "Epic app %s does not appear to be installed: %v", game.Target, err)
+		} else {
+			add("target", checkOK, "Epic app %s (%s) is installed at %s", app.AppName, app.DisplayName, app.InstallLocation)
+			stopCandidates = app.StopProcessCandidates()
+		}
+	case "Remote":
+		add("target", checkSkipped, "the agent checks the target on its own machine; use 'gabs games doctor %s' to check that it answers", game.ID)
+	default:
+		// Launchers that cannot tell whether the game is installed still
+		// know whether they can run here at all
+		launcher, exists := process.LauncherFor(game.LaunchMode)
+		if !exists {
+			break
+		}
+		if _, err := launcher.BuildCommand(process.LaunchSpec{GameId: game.ID, Mode: game.LaunchMode, PathOrId: game.Target, Proton: game.Proton}); err != nil {
+			add("target", checkError, "%v", err)
+		} else {
+			add("target", checkSkipped, "%s can launch here, but GABS cannot check that %s is installed", game.LaunchMode, game.Target)
+		}
+	}
+
+	if game.WorkingDir != "" {
+		if info, err := os.Stat(game.WorkingDir); err != nil {
+			add("workingDir", checkError, "working directory not found: %v", err)
+		} else if !info.IsDir() {
+			add("workingDir", checkError, "working directory %s is not a directory", game.WorkingDir)
+		} else {
+			add("workingDir", checkOK, "working directory %s exists", game.WorkingDir)
+		}
+	}
+
+	if executable != "" {
+		stopCandidates = append(stopCandidates, filepath.Base(executable))
+	}
+	if check, ok := checkStopProcessName(game, stopCandidates); ok {
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// checkStopProcessName reports whether stopProcessName looks like a process
+// name GABS can find. candidates are the names the game is known to run as.
+func checkStopProcessName(game config.GameConfig, candidates []string) (validationCheck, bool) {
+	check := validationCheck{Check: "stopProcessName"}
+	name := game.StopProcessName
+	if name == "" {
+		// Store launch modes need one, which the config check reports
+		return check, false
+	}
+
+	switch {
+	case strings.ContainsAny(name, `/\`):
+		check.Status = checkWarning
+		check.Message = fmt.Sprintf("%q looks like a path; use the process name only, such as %q", name, name[strings.LastIndexAny(name, `/\`)+1:])
+	case runtime.GOOS == "windows" && !strings.HasSuffix(strings.ToLower(name), ".exe"):
+		check.Status = checkWarning
+		check.Message = fmt.Sprintf("%q has no .exe suffix; Windows process names include it", name)
+	case len(candidates) > 0 && !containsFold(candidates, name):
+		check.Status = checkWarning
+		check.Message = fmt.Sprintf("%q is not a name the game is known to run as (%s); games_infer_stop_process can find the right one after a start", name, strings.Join(candidates, ", "))
+	default:
+		check.Status = checkOK
+		check.Message = fmt.Sprintf("%q looks like a process name", name)
+	}
+	return check, true
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExecutable reports whether the file at path can be run: an executable
+// mode bit elsewhere, an extension from PATHEXT on Windows.
+func isExecutable(path string, info os.FileInfo) bool {
+	if runtime.GOOS != "windows" {
+		return info.Mode()&0111 != 0
+	}
+	pathExt := os.Getenv("PATHEXT")
+	if pathExt == "" {
+		pathExt = ".COM;.EXE;.BAT;.CMD"
+	}
+	ext := filepath.Ext(path)
+	for _, candidate := range strings.Split(pathExt, ";") {
+		if candidate != "" && strings.EqualFold(candidate, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) registerGameValidateTool(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.validate",
+		Description: "Check whether a configured game could be launched without starting it: the config, the target executable or installed store app, the working directory and stopProcessName. Pass patch to check games_update changes before saving them.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": map[string]interface{}{
+					"type":        "string",
+					"description": "Game ID or launch target",
+				},
+				"patch": map[string]interface{}{
+					"type":        "object",
+					"description": "Optional games_update patch to apply before checking; nothing is saved",
+				},
+			},
+			"required": []string{"gameId"},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		gameIdOrTarget, ok := args["gameId"].(string)
+		if !ok || strings.TrimSpace(gameIdOrTarget) == "" {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "gameId parameter is required"}},
+				IsError: true,
+			}, nil
+		}
+		game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
+		if !exists {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' not found. Use games_list to see available games.", gameIdOrTarget)}},
+				IsError: true,
+			}, nil
+		}
+
+		candidate := *game
+		if rawPatch, hasPatch := args["patch"]; hasPatch && rawPatch != nil {
+			patch, ok := rawPatch.(map[string]interface{})
+			if !ok {
+				return &ToolResult{
+					Content: []Content{{Type: "text", Text: "patch must be an object"}},
+					IsError: true,
+				}, nil
+			}
+			patched, _, err := applyGamePatch(candidate, patch)
+			if err != nil {
+				return &ToolResult{
+					Content: []Content{{Type: "text", Text: err.Error()}},
+					IsError: true,
+				}, nil
+			}
+			candidate = patched
+		}
+
+		checks := validateGameSetup(candidate)
+		valid := true
+		var problems []string
+		for _, check := range checks {
+			if check.Status == checkError {
+				valid = false
+			}
+			if check.Status == checkError || check.Status == checkWarning {
+				problems = append(problems, fmt.Sprintf("%s %s: %s", check.Status, check.Check, check.Message))
+			}
+		}
+
+		var text string
+		switch {
+		case !valid:
+			text = fmt.Sprintf("Game '%s' would not launch:\n%s", candidate.ID, strings.Join(problems, "\n"))
+		case len(problems) > 0:
+			text = fmt.Sprintf("Game '%s' looks launchable, with warnings:\n%s", candidate.ID, strings.Join(problems, "\n"))
+		default:
+			text = fmt.Sprintf("Game '%s' looks launchable; all %d checks passed.", candidate.ID, len(checks))
+		}
+
+		items := make([]interface{}, 0, len(checks))
+		for _, check := range checks {
+			items = append(items, map[string]interface{}{"check": check.Check, "status": check.Status, "message": check.Message})
+		}
+		structured := map[string]interface{}{
+			"gameId": candidate.ID,
+			"valid":  valid,
+			"checks": items,
+		}
+		addValidationWarnings(structured, gameValidationWarnings(candidate))
+		return &ToolResult{
+			Content:           []Content{{Type: "text", Text: text}},
+			StructuredContent: structured,
+		}, nil
+	}, normalizationConfig)
+}
@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func validationStatuses(t *testing.T, result ToolResult) map[string]string {
+	t.Helper()
+	if result.IsError {
+		t.Fatalf("games_validate failed: %#v", result)
+	}
+	statuses := map[string]string{}
+	checks, _ := result.StructuredContent["checks"].([]interface{})
+	for _, raw := range checks {
+		check, _ := raw.(map[string]interface{})
+		name, _ := check["check"].(string)
+		status, _ := check["status"].(string)
+		statuses[name] = status
+	}
+	return statuses
+}
+
+func TestGamesValidateChecksTargetWorkingDirAndStopProcessName(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses executable mode bits")
+	}
+	dir := t.TempDir()
+	target := filepath.Join(dir, "adventure.sh")
+	if err := os.WriteFile(target, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("failed to write target: %v", err)
+	}
+	workingDir := filepath.Join(dir, "work")
+	if err := os.Mkdir(workingDir, 0755); err != nil {
+		t.Fatalf("failed to create working dir: %v", err)
+	}
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"adventure": {ID: "adventure", Name: "Adventure", LaunchMode: "DirectPath", Target: target, WorkingDir: workingDir, StopProcessName: "adventure.sh"},
+		},
+	}
+	server, _ := newGamesTestServer(t, gamesConfig)
+
+	result := callToolForTest(t, server, "games_validate", map[string]interface{}{"gameId": "adventure"})
+	statuses := validationStatuses(t, result)
+	if statuses["config"] != checkOK || statuses["target"] != checkOK || statuses["workingDir"] != checkOK || statuses["stopProcessName"] != checkOK {
+		t.Fatalf("expected every check to pass, got %v", statuses)
+	}
+	if valid, _ := result.StructuredContent["valid"].(bool); !valid {
+		t.Fatalf("expected the game to be valid, got %#v", result.StructuredContent)
+	}
+
+	if err := os.Chmod(target, 0644); err != nil {
+		t.Fatalf("failed to make target non-executable: %v", err)
+	}
+	if err := os.Remove(workingDir); err != nil {
+		t.Fatalf("failed to remove working dir: %v", err)
+	}
+	result = callToolForTest(t, server, "games_validate", map[string]interface{}{
+		"gameId": "adventure",
+		"patch":  map[string]interface{}{"stopProcessName": "/opt/adventure/adventure.sh"},
+	})
+	statuses = validationStatuses(t, result)
+	if statuses["target"] != checkError || statuses["workingDir"] != checkError || statuses["stopProcessName"] != checkWarning {
+		t.Fatalf("expected target and working dir errors and a stopProcessName warning, got %v", statuses)
+	}
+	if valid, _ := result.StructuredContent["valid"].(bool); valid {
+		t.Fatal("expected the patched game to be invalid")
+	}
+	if text := result.Content[0].Text; !strings.Contains(text, "would not launch") {
+		t.Fatalf("expected the summary to say the game would not launch, got %q", text)
+	}
+
+	// The patch is only checked, never saved
+	if game, _ := gamesConfig.GetGame("adventure"); game.StopProcessName != "adventure.sh" {
+		t.Fatalf("games_validate must not change the config, got stopProcessName %q", game.StopProcessName)
+	}
+}
+
+func TestGamesValidateReportsInvalidConfig(t *testing.T) {
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"factory": {ID: "factory", Name: "Factory", LaunchMode: "CustomCommand", Target: "factory-command-that-does-not-exist"},
+			"store":   {ID: "store", Name: "Store", LaunchMode: "EpicAppId", Target: "Store", StopProcessName: "Store.exe"},
+		},
+	}
+	server, _ := newGamesTestServer(t, gamesConfig)
+
+	result := callToolForTest(t, server, "games_validate", map[string]interface{}{
+		"gameId": "store",
+		"patch":  map[string]interface{}{"stopProcessName": nil},
+	})
+	statuses := validationStatuses(t, result)
+	if statuses["config"] != checkError {
+		t.Fatalf("expected the missing stopProcessName to fail the config check, got %v", statuses)
+	}
+
+	result = callToolForTest(t, server, "games_validate", map[string]interface{}{"gameId": "factory"})
+	if statuses := validationStatuses(t, result); statuses["target"] != checkError {
+		t.Fatalf("expected an unknown command to fail the target check, got %v", statuses)
+	}
+}
@@ -57,6 +57,9 @@ func TestGamesCallToolHonorsOuterTimeout(t *testing.T) {
 	if !strings.Contains(callText, "request timeout after 1s") {
 		t.Fatalf("expected timeout details in response, got: %s", callText)
 	}
+	if !strings.Contains(callText, `"kind":"game-busy"`) {
+		t.Fatalf("expected the timeout to be reported as game-busy, got: %s", callText)
+	}
 
 	if err := <-serverDone; err != nil {
 		t.Fatalf("test GABP server failed: %v", err)
@@ -115,6 +118,52 @@ func TestGamesCallToolUsesToolTimeoutHintsFromArguments(t *testing.T) {
 	}
 }
 
+func TestGamesCallToolStopsWhenTheClientCancels(t *testing.T) {
+	server, _ := newGamesCallToolTimeoutTestServer(t, 5*time.Second)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_connect failed: %#v", result)
+	}
+
+	session := &mcpSession{}
+	done := make(chan interface{}, 1)
+	callStart := time.Now()
+	go func() {
+		call := `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"games_call_tool","arguments":{"tool":"adventure.adventure.load_game_ready","timeout":10}}}`
+		response, _ := server.handleRawMessage([]byte(call), session, config.AccessRoleLocal)
+		done <- response
+	}()
+
+	// A cancellation from another session must not reach the call.
+	cancel := `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7,"reason":"user aborted"}}`
+	time.Sleep(200 * time.Millisecond)
+	if _, err := server.handleRawMessage([]byte(cancel), &mcpSession{}, config.AccessRoleLocal); err != nil {
+		t.Fatalf("cancellation failed: %v", err)
+	}
+	select {
+	case response := <-done:
+		t.Fatalf("expected the call to keep running, got %#v", response)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if _, err := server.handleRawMessage([]byte(cancel), session, config.AccessRoleLocal); err != nil {
+		t.Fatalf("cancellation failed: %v", err)
+	}
+	select {
+	case response := <-done:
+		if response != nil {
+			t.Fatalf("expected no response to a cancelled request, got %#v", response)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the cancelled call to return")
+	}
+	if elapsed := time.Since(callStart); elapsed > 3*time.Second {
+		t.Fatalf("expected the call to end on cancellation, took %v", elapsed)
+	}
+	if len(session.calls) != 0 {
+		t.Fatalf("expected no tracked calls after the call ended, got %d", len(session.calls))
+	}
+}
+
 func newGamesCallToolTimeoutTestServer(t *testing.T, toolDelay time.Duration) (*Server, <-chan error) {
 	t.Helper()
 
@@ -282,3 +331,46 @@ func serveTestGabpSessionWithDelayedToolResponse(listener net.Listener, expected
 		}
 	}
 }
+
+func TestMirroredToolCallStopsWhenTheClientCancels(t *testing.T) {
+	server, _ := newGamesCallToolTimeoutTestServer(t, 5*time.Second)
+	server.SetToolCallLimits(1, 0)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_connect failed: %#v", result)
+	}
+
+	session := &mcpSession{}
+	done := make(chan interface{}, 1)
+	go func() {
+		call := `{"jsonrpc":"2.0","id":9,"method":"tools/call","params":{"name":"adventure.adventure.load_game_ready","arguments":{}}}`
+		response, _ := server.handleRawMessage([]byte(call), session, config.AccessRoleLocal)
+		done <- response
+	}()
+	time.Sleep(200 * time.Millisecond)
+	if len(server.callSlots) != 1 {
+		t.Fatalf("expected the mirrored call to hold the only slot, got %d", len(server.callSlots))
+	}
+
+	cancel := `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":9}}`
+	if _, err := server.handleRawMessage([]byte(cancel), session, config.AccessRoleLocal); err != nil {
+		t.Fatalf("cancellation failed: %v", err)
+	}
+	select {
+	case response := <-done:
+		if response != nil {
+			t.Fatalf("expected no response to a cancelled request, got %#v", response)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the cancelled call to return")
+	}
+
+	// The handler gives up on the bridge too, so its slot is freed long
+	// before the bridge would have answered.
+	deadline := time.Now().Add(2 * time.Second)
+	for len(server.callSlots) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the cancelled mirrored call to free its slot")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,202 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// healthURI is the resource that reports the same summary as games_health.
+const healthURI = "gabs://health"
+
+// inspectBridgeFile reports whether a game's bridge.json exists and what
+// endpoint it points at.
+func (s *Server) inspectBridgeFile(gameID string) bridgeFileDiagnostic {
+	paths, err := config.NewConfigPaths(s.configDir)
+	if err != nil {
+		return bridgeFileDiagnostic{Error: err.Error()}
+	}
+	diagnostic := bridgeFileDiagnostic{Path: paths.GetBridgeConfigPath(gameID)}
+	bridge, err := config.ReadBridgeEndpoint(gameID, s.configDir)
+	if err != nil {
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) {
+			if !os.IsNotExist(err) {
+				diagnostic.Error = err.Error()
+			}
+		} else {
+			diagnostic.Error = fmt.Sprintf("failed to parse bridge.json: %v", err)
+		}
+		return diagnostic
+	}
+	diagnostic.Present = true
+	diagnostic.Port = bridge.Port
+	diagnostic.Token = bridge.Token
+	diagnostic.GameID = bridge.GameId
+	return diagnostic
+}
+
+// transportHealth describes the MCP transport GABS is serving and the clients
+// attached to it.
+func (s *Server) transportHealth() map[string]interface{} {
+	s.mu.RLock()
+	transport := s.serveTransport
+	serving := s.serveCtx != nil && s.serveCtx.Err() == nil
+	sessions := len(s.httpSessions)
+	s.mu.RUnlock()
+
+	clients := map[string]int{clientTransportStdio: 0, clientTransportSSE: 0}
+	s.clientsMu.RLock()
+	for _, client := range s.clients {
+		clients[client.transport]++
+	}
+	s.clientsMu.RUnlock()
+
+	if transport == "" {
+		transport = "none"
+	}
+	item := map[string]interface{}{
+		"transport":           transport,
+		"serving":             serving,
+		"notificationClients": clients,
+	}
+	if transport == "http" {
+		item["httpSessions"] = sessions
+	}
+	return item
+}
+
+// gameHealth summarizes one game for games_health. It reports whether the
+// game needs attention, and why.
+func (s *Server) gameHealth(game config.GameConfig) (map[string]interface{}, []string) {
+	status := s.checkGameStatus(game.ID)
+
+	s.mu.RLock()
+	client, _ := s.sessions[game.ID].gabpClient()
+	record, hasDisconnect := s.gabpDisconnects[game.ID]
+	s.mu.RUnlock()
+	gabpConnected := client != nil && client.IsConnected()
+
+	bridgeFile := s.inspectBridgeFile(game.ID)
+	item := map[string]interface{}{
+		"gameId":        game.ID,
+		"status":        status,
+		"gabpConnected": gabpConnected,
+		"toolCount":     len(s.getGameSpecificTools(game.ID)),
+		"bridgeFile":    bridgeFile.structured(),
+	}
+
+	var problems []string
+	if hasDisconnect {
+		item["lastError"] = map[string]interface{}{
+			"at":      record.At.Format(time.RFC3339),
+			"message": record.Message,
+		}
+		if !gabpConnected {
+			problems = append(problems, fmt.Sprintf("%s lost its GABP connection: %s", game.ID, record.Message))
+		}
+	}
+	if bridgeFile.Error != "" {
+		problems = append(problems, fmt.Sprintf("%s has an unreadable bridge file: %s", game.ID, bridgeFile.Error))
+	}
+	if runningStatusNeedsBridgeEnvironment(status) && !gabpConnected && !hasDisconnect {
+		problems = append(problems, fmt.Sprintf("%s is %s but GABP is not connected.", game.ID, status))
+	}
+	if len(problems) > 0 {
+		item["problems"] = problems
+	}
+	return item, problems
+}
+
+// healthReport aggregates transport, GABP and per-game state into one
+// summary, for operators and agents diagnosing a wedged setup.
+func (s *Server) healthReport(gamesConfig *config.GamesConfig) map[string]interface{} {
+	games := make([]map[string]interface{}, 0)
+	var problems []string
+	if gamesConfig != nil {
+		for _, game := range gamesConfig.ListGames() {
+			item, gameProblems := s.gameHealth(game)
+			games = append(games, item)
+			problems = append(problems, gameProblems...)
+		}
+	}
+
+	s.mu.RLock()
+	gabpClients := 0
+	for _, session := range s.sessions {
+		if client, exists := session.gabpClient(); exists && client.IsConnected() {
+			gabpClients++
+		}
+	}
+	s.mu.RUnlock()
+
+	status := "ok"
+	if len(problems) > 0 {
+		status = "degraded"
+	}
+	report := map[string]interface{}{
+		"status":      status,
+		"mcp":         s.transportHealth(),
+		"gabpClients": gabpClients,
+		"games":       games,
+		"problems":    problems,
+	}
+	// Collisions do not degrade GABS, but agents calling a tool by the
+	// name it lost should learn which name it got instead.
+	if collisions := s.toolNameCollisions(); len(collisions) > 0 {
+		report["toolNameCollisions"] = collisions
+	}
+	return report
+}
+
+func healthSummaryText(report map[string]interface{}) string {
+	games, _ := report["games"].([]map[string]interface{})
+	problems, _ := report["problems"].([]string)
+	text := fmt.Sprintf("GABS health: %s. %d game(s) configured, %d GABP connection(s).", report["status"], len(games), report["gabpClients"])
+	if len(problems) > 0 {
+		text += " " + strings.Join(problems, " ")
+	}
+	if collisions, _ := report["toolNameCollisions"].([]toolNameCollision); len(collisions) > 0 {
+		renamed := make([]string, 0, len(collisions))
+		for _, collision := range collisions {
+			renamed = append(renamed, fmt.Sprintf("%s is registered as %s because %s holds %s.", collision.Tool, collision.RegisteredAs, collision.Holder, collision.Name))
+		}
+		text += " Tool name collisions: " + strings.Join(renamed, " ")
+	}
+	return text
+}
+
+func (s *Server) registerHealthTool(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	s.RegisterResource(Resource{
+		URI:         healthURI,
+		Name:        "GABS Health",
+		Description: "MCP transport status, GABP connections, and process state, bridge file and last error per game",
+		MimeType:    "application/json",
+	}, func() ([]Content, error) {
+		data, err := json.Marshal(s.healthReport(gamesConfig))
+		if err != nil {
+			return nil, err
+		}
+		return []Content{{Type: "text", Text: string(data)}}, nil
+	})
+
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.health",
+		Description: "Summarize GABS health: MCP transport status, connected GABP clients, and process state, bridge file presence and last error per game. Use it to diagnose a setup that stopped responding.",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		report := s.healthReport(gamesConfig)
+		return &ToolResult{
+			Content:           []Content{{Type: "text", Text: healthSummaryText(report)}},
+			StructuredContent: report,
+		}, nil
+	}, normalizationConfig)
+}
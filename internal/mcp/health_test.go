@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func TestGamesHealthReportsBridgeFilesAndLastError(t *testing.T) {
+	gamesConfig := roamingGamesConfig()
+	gamesConfig.Games["factory"] = config.GameConfig{
+		ID:         "factory",
+		Name:       "Factory",
+		LaunchMode: "DirectPath",
+		Target:     "/opt/factory/start.sh",
+	}
+	server, configDir := newGamesTestServer(t, gamesConfig)
+
+	result := callToolForTest(t, server, "games.health", map[string]interface{}{})
+	if result.IsError || result.StructuredContent["status"] != "ok" {
+		t.Fatalf("expected a healthy report, got %#v", result)
+	}
+
+	writeBridgeJSONForTest(t, configDir, "adventure", 49152, "secret-token")
+	server.mu.Lock()
+	server.recordGABPDisconnectLocked("adventure", errors.New("connection reset by peer"))
+	server.mu.Unlock()
+
+	result = callToolForTest(t, server, "games.health", map[string]interface{}{})
+	var report struct {
+		Status      string `json:"status"`
+		GABPClients int    `json:"gabpClients"`
+		MCP         struct {
+			Transport string `json:"transport"`
+		} `json:"mcp"`
+		Games []struct {
+			GameID     string `json:"gameId"`
+			Status     string `json:"status"`
+			BridgeFile struct {
+				Present          bool   `json:"present"`
+				Port             int    `json:"port"`
+				TokenFingerprint string `json:"tokenFingerprint"`
+				Token            string `json:"token"`
+			} `json:"bridgeFile"`
+			LastError *struct {
+				Message string `json:"message"`
+			} `json:"lastError"`
+		} `json:"games"`
+		Problems []string `json:"problems"`
+	}
+	if err := decodeResult(result.StructuredContent, &report); err != nil {
+		t.Fatalf("failed to decode health report: %v", err)
+	}
+	if report.Status != "degraded" || len(report.Problems) != 1 || !strings.Contains(report.Problems[0], "connection reset by peer") {
+		t.Fatalf("expected the disconnect to degrade health, got %#v", report)
+	}
+	if report.MCP.Transport != "none" || report.GABPClients != 0 || len(report.Games) != 2 {
+		t.Fatalf("unexpected transport or game summary: %#v", report)
+	}
+	for _, game := range report.Games {
+		switch game.GameID {
+		case "adventure":
+			if !game.BridgeFile.Present || game.BridgeFile.Port != 49152 || game.BridgeFile.TokenFingerprint == "" || game.BridgeFile.Token != "" {
+				t.Fatalf("expected the bridge file without its token, got %#v", game.BridgeFile)
+			}
+			if game.LastError == nil || game.LastError.Message != "connection reset by peer" {
+				t.Fatalf("expected the last GABP error, got %#v", game.LastError)
+			}
+		case "factory":
+			if game.Status != "stopped" || game.BridgeFile.Present || game.LastError != nil {
+				t.Fatalf("expected a stopped game without bridge file, got %#v", game)
+			}
+		}
+	}
+	if !strings.Contains(result.Content[0].Text, "degraded") {
+		t.Fatalf("expected the summary to mention the degraded state, got %q", result.Content[0].Text)
+	}
+
+	response := server.HandleMessage(&Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "resources/read", Params: map[string]interface{}{"uri": healthURI}})
+	var read struct {
+		Contents []Content `json:"contents"`
+	}
+	if response.Error != nil || decodeResult(response.Result, &read) != nil || len(read.Contents) != 1 {
+		t.Fatalf("unexpected %s read: %#v", healthURI, response)
+	}
+	var resourceReport map[string]interface{}
+	if err := json.Unmarshal([]byte(read.Contents[0].Text), &resourceReport); err != nil || resourceReport["status"] != "degraded" {
+		t.Fatalf("expected the resource to serve the same report, got %q (%v)", read.Contents[0].Text, err)
+	}
+}
@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// JSON-RPC error codes for HTTP requests turned away before they are read.
+const (
+	jsonRPCUnauthorized = -32001
+	jsonRPCRateLimited  = -32029
+)
+
+// apiKeyHeader carries the API key for clients that cannot set an
+// Authorization header.
+const apiKeyHeader = "X-API-Key"
+
+// rateLimitWindow is the sliding window an API key's rateLimit counts
+// requests in.
+const rateLimitWindow = time.Minute
+
+// authenticateHTTPRequest resolves the API key an HTTP request was sent with.
+// When no API keys are configured every request is accepted as anonymous.
+func (s *Server) authenticateHTTPRequest(r *http.Request) (config.APIKeyConfig, bool) {
+	s.mu.RLock()
+	keys := append([]config.APIKeyConfig(nil), s.fileAPIKeys...)
+	s.mu.RUnlock()
+	if s.gamesConfig != nil {
+		keys = append(keys, s.gamesConfig.APIKeys...)
+	}
+	if s.apiKey != "" {
+		keys = append([]config.APIKeyConfig{{Key: s.apiKey, Role: config.AccessRoleAdmin, Name: "apiKey"}}, keys...)
+	}
+	if len(keys) == 0 {
+		return config.APIKeyConfig{Role: config.AccessRoleAnonymous}, true
+	}
+	return config.MatchAPIKey(keys, requestAPIKey(r))
+}
+
+// requestAPIKey returns the key from an "Authorization: Bearer" header, or
+// from X-API-Key when there is none.
+func requestAPIKey(r *http.Request) string {
+	if key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return key
+	}
+	return r.Header.Get(apiKeyHeader)
+}
+
+// admitHTTPRequest authenticates an HTTP request and counts it against its
+// key's rate limit. It returns the key whose role serves the request, or
+// answers the request with a 401 or 429 JSON-RPC error and returns false.
+func (s *Server) admitHTTPRequest(w http.ResponseWriter, r *http.Request) (config.APIKeyConfig, bool) {
+	apiKey, authorized := s.authenticateHTTPRequest(r)
+	if !authorized {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="gabs"`)
+		writeHTTPError(w, http.StatusUnauthorized, NewError(nullID, jsonRPCUnauthorized, "Unauthorized",
+			"Invalid or missing API key. Include 'Authorization: Bearer <your-api-key>' header."))
+		s.log.Warnw("unauthorized HTTP request", "path", r.URL.Path, "clientIP", r.RemoteAddr, "authHeader", r.Header.Get("Authorization") != "")
+		return config.APIKeyConfig{}, false
+	}
+
+	if retryAfter, limited := s.rateLimitAPIKey(apiKey); limited {
+		seconds := int(math.Ceil(retryAfter.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		writeHTTPError(w, http.StatusTooManyRequests, NewError(nullID, jsonRPCRateLimited, "Rate limit exceeded", map[string]interface{}{
+			"key":               apiKey.Label(),
+			"rateLimit":         apiKey.RateLimit,
+			"retryAfterSeconds": seconds,
+		}))
+		s.log.Warnw("HTTP request rate limited", "path", r.URL.Path, "key", apiKey.Label(), "clientIP", r.RemoteAddr)
+		return config.APIKeyConfig{}, false
+	}
+	return apiKey, true
+}
+
+// rateLimitAPIKey counts a request against apiKey's rateLimit. When the key
+// is used up it counts nothing and returns how long until the next request is
+// allowed.
+func (s *Server) rateLimitAPIKey(apiKey config.APIKeyConfig) (time.Duration, bool) {
+	if apiKey.RateLimit <= 0 {
+		return 0, false
+	}
+
+	s.mu.RLock()
+	now := s.clock.Now()
+	s.mu.RUnlock()
+
+	s.rateLimitMu.Lock()
+	defer s.rateLimitMu.Unlock()
+	if s.rateLimitUsage == nil {
+		s.rateLimitUsage = make(map[string][]time.Time)
+	}
+	requests := pruneBudgetCalls(s.rateLimitUsage[apiKey.Key], now, rateLimitWindow)
+	if len(requests) >= apiKey.RateLimit {
+		s.rateLimitUsage[apiKey.Key] = requests
+		return requests[0].Add(rateLimitWindow).Sub(now), true
+	}
+	s.rateLimitUsage[apiKey.Key] = append(requests, now)
+	return 0, false
+}
+
+func writeHTTPError(w http.ResponseWriter, status int, response *Message) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestHTTPRateLimitsEachAPIKey(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	server.SetClock(clock)
+	server.SetAPIKey("main-key")
+	server.AddAPIKeys([]config.APIKeyConfig{{Key: "agent-key", Role: "readonly", Name: "agent", RateLimit: 2}})
+
+	list := Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "tools/list"}
+	for i := 0; i < 2; i++ {
+		if response, _ := postMCPForTest(t, server, "agent-key", list); response.StatusCode != http.StatusOK {
+			t.Fatalf("request %d: expected 200 within the rate limit, got %d", i+1, response.StatusCode)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`))
+	request.Header.Set(apiKeyHeader, "agent-key")
+	server.handleMCPHTTPRequest(recorder, request)
+	if recorder.Code != http.StatusTooManyRequests || recorder.Header().Get("Retry-After") != "60" {
+		t.Fatalf("expected 429 with Retry-After for the X-API-Key request, got %d %q", recorder.Code, recorder.Header().Get("Retry-After"))
+	}
+	var limited Message
+	if err := json.Unmarshal(recorder.Body.Bytes(), &limited); err != nil || limited.Error == nil || limited.Error.Code != jsonRPCRateLimited {
+		t.Fatalf("expected a JSON-RPC rate limit error, got %s", recorder.Body.String())
+	}
+
+	// Other keys have their own limits, and the main key has none.
+	for i := 0; i < 3; i++ {
+		if response, _ := postMCPForTest(t, server, "main-key", list); response.StatusCode != http.StatusOK {
+			t.Fatalf("expected the main key to be unaffected, got %d", response.StatusCode)
+		}
+	}
+
+	clock.Advance(time.Minute)
+	if response, _ := postMCPForTest(t, server, "agent-key", list); response.StatusCode != http.StatusOK {
+		t.Fatalf("expected the limit to reset after a minute, got %d", response.StatusCode)
+	}
+}
+
+func TestHTTPUnauthorizedIsJSONRPCError(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.AddAPIKeys([]config.APIKeyConfig{{Key: "agent-key", Role: "readonly"}})
+
+	recorder := httptest.NewRecorder()
+	server.handleMCPHTTPRequest(recorder, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)))
+	if recorder.Code != http.StatusUnauthorized || recorder.Header().Get("WWW-Authenticate") == "" {
+		t.Fatalf("expected 401 with WWW-Authenticate, got %d %v", recorder.Code, recorder.Header())
+	}
+	var response Message
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil || response.Error == nil || response.Error.Code != jsonRPCUnauthorized {
+		t.Fatalf("expected a JSON-RPC unauthorized error, got %s", recorder.Body.String())
+	}
+}
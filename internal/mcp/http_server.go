@@ -3,29 +3,49 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
-	"sync"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/pardeike/gabs/internal/version"
 )
 
-// HTTPClient represents an HTTP client connection for SSE
-type HTTPClient struct {
-	ID      string
-	Writer  http.ResponseWriter
-	Flusher http.Flusher
-	Done    chan struct{}
-	Request *http.Request
-}
+// mcpSessionHeader carries the session ID GABS issues when an HTTP client
+// initializes, so each client keeps its own protocol state.
+const mcpSessionHeader = "Mcp-Session-Id"
+
+// httpSessionIdleTimeout is how long an HTTP session may go without a request
+// or an open notification stream before GABS ends it, for clients that go
+// away without sending DELETE.
+var httpSessionIdleTimeout = 30 * time.Minute
+
+// httpSessionSweepInterval is how often ServeHTTP looks for idle sessions.
+var httpSessionSweepInterval = time.Minute
+
+// sseWriteTimeout bounds a single write to an SSE stream, so a client that
+// stopped reading is dropped instead of holding its stream open.
+const sseWriteTimeout = 10 * time.Second
+
+// sseKeepAlive is how often an idle SSE stream gets a ping event, so proxies
+// and clients do not mistake it for a dead connection.
+var sseKeepAlive = 15 * time.Second
+
+// sseRetry is the reconnection delay SSE streams suggest to clients.
+const sseRetry = 2 * time.Second
+
+// defaultHTTPBodyLimit is the largest request body /mcp accepts unless
+// maxMessageBytes is configured.
+const defaultHTTPBodyLimit = 1 << 20 // 1MB
 
 // ServeHTTP starts the MCP server on HTTP (Streamable HTTP transport)
 func (s *Server) ServeHTTP(ctx context.Context, addr string) error {
-	// HTTP clients for Server-Sent Events
-	httpClients := make(map[string]*HTTPClient)
-	httpClientsMu := sync.RWMutex{}
+	s.setServeContext(ctx, "http")
 
 	mux := http.NewServeMux()
 
@@ -33,9 +53,15 @@ func (s *Server) ServeHTTP(ctx context.Context, addr string) error {
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		fmt.Fprintf(w, `{"status":"ok","server":"gabs","version":"%s"}`, version.Get())
+		fmt.Fprintf(w, `{"status":"ok","server":"gabs","version":"%s","platform":"%s"}`, version.Get(), version.Platform())
 	})
 
+	// Prometheus metrics, behind the same API keys as /mcp
+	mux.HandleFunc("/metrics", s.handleMetrics)
+
+	// Runtime log level, for the main apiKey only
+	mux.HandleFunc("/admin/log-level", s.handleLogLevel)
+
 	// MCP JSON-RPC endpoint - handles all MCP method calls
 	mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
 		s.handleMCPHTTPRequest(w, r)
@@ -43,16 +69,18 @@ func (s *Server) ServeHTTP(ctx context.Context, addr string) error {
 
 	// Server-Sent Events endpoint for notifications
 	mux.HandleFunc("/mcp/events", func(w http.ResponseWriter, r *http.Request) {
-		s.handleSSEConnection(w, r, httpClients, &httpClientsMu)
+		s.handleSSEConnection(w, r)
 	})
 
 	server := &http.Server{
 		Addr:    addr,
-		Handler: mux,
+		Handler: withServerHeader(mux),
 	}
 
 	s.log.Infow("starting HTTP server with full MCP support", "addr", addr)
 
+	go s.expireHTTPSessionsUntil(ctx)
+
 	// Start server in goroutine
 	errCh := make(chan error, 1)
 	go func() {
@@ -75,11 +103,7 @@ func (s *Server) ServeHTTP(ctx context.Context, addr string) error {
 	}
 
 	// Close all SSE connections
-	httpClientsMu.Lock()
-	for _, client := range httpClients {
-		close(client.Done)
-	}
-	httpClientsMu.Unlock()
+	s.closeClients(clientTransportSSE)
 
 	// Graceful shutdown
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -88,8 +112,41 @@ func (s *Server) ServeHTTP(ctx context.Context, addr string) error {
 	return server.Shutdown(shutdownCtx)
 }
 
+// handleMetrics serves the server metrics in the Prometheus text format.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if _, admitted := s.admitHTTPRequest(w, r); !admitted {
+		return
+	}
+	s.metrics.registry.Handler().ServeHTTP(w, r)
+}
+
+// httpBodyLimit returns the largest request body /mcp accepts.
+func (s *Server) httpBodyLimit() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.maxMessageSize > 0 {
+		return s.maxMessageSize
+	}
+	return defaultHTTPBodyLimit
+}
+
+// isJSONContentType reports whether a request's Content-Type allows a JSON
+// body. Requests without one are accepted, as simple clients such as curl
+// scripts often leave it out.
+func isJSONContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
 // handleMCPHTTPRequest handles JSON-RPC requests over HTTP
 func (s *Server) handleMCPHTTPRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodDelete {
+		s.handleMCPHTTPDelete(w, r)
+		return
+	}
 	if r.Method != http.MethodPost {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusMethodNotAllowed)
@@ -97,55 +154,81 @@ func (s *Server) handleMCPHTTPRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check API key authentication if configured
-	if s.apiKey != "" {
-		authHeader := r.Header.Get("Authorization")
-		expectedAuth := "Bearer " + s.apiKey
-		if authHeader != expectedAuth {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			fmt.Fprintf(w, `{"error":"Invalid or missing API key. Include 'Authorization: Bearer <your-api-key>' header."}`)
-			s.log.Warnw("unauthorized HTTP request", "clientIP", r.RemoteAddr, "authHeader", authHeader != "")
-			return
-		}
+	// Check API key authentication and rate limits if configured
+	apiKey, admitted := s.admitHTTPRequest(w, r)
+	if !admitted {
+		return
+	}
+	role := apiKey.Role
+
+	if !isJSONContentType(r.Header.Get("Content-Type")) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		fmt.Fprintf(w, `{"error":"Unsupported Content-Type. Send MCP requests as application/json."}`)
+		return
 	}
 
 	// Limit request body size to prevent memory exhaustion
-	const maxBodySize = 1 << 20 // 1MB
-	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	maxBodySize := s.httpBodyLimit()
+	r.Body = http.MaxBytesReader(w, r.Body, int64(maxBodySize))
 
 	// Read request body
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		if err.Error() == "http: request body too large" {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
 			fmt.Fprintf(w, `{"error":"Request body too large (max %d bytes)"}`, maxBodySize)
 		} else {
+			w.WriteHeader(http.StatusBadRequest)
 			fmt.Fprintf(w, `{"error":"Failed to read request body"}`)
 		}
 		return
 	}
 	defer r.Body.Close()
 
-	// Parse JSON-RPC message
-	var msg Message
-	if err := json.Unmarshal(body, &msg); err != nil {
+	// Requests that carry a session ID continue that client's session. Without
+	// one the request starts a fresh session, which is only kept if it
+	// initializes successfully.
+	sessionID := r.Header.Get(mcpSessionHeader)
+	session, known := s.lookupHTTPSession(sessionID)
+	if sessionID != "" && !known && s.strictMCPEnabled() {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"error":"Unknown MCP session. Send initialize without an %s header to start a new one."}`, mcpSessionHeader)
+		return
+	}
+	if checkProtocolVersionHeader(w, r) {
+		return
+	}
+	if !known {
+		session = &mcpSession{transport: sessionTransportHTTP}
+		session.info.ToolProfile = apiKey.ToolProfile
+	}
+
+	// Parse and handle the JSON-RPC message
+	response, err := s.handleRawMessage(body, session, role)
+	if err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		fmt.Fprintf(w, `{"error":"Invalid JSON-RPC message"}`)
 		return
 	}
-
-	s.log.Debugw("received HTTP MCP request", "method", msg.Method, "id", msg.ID)
-
-	// Handle the message using existing handler
-	response := s.handleMessage(&msg)
+	if !known && session.initialized.Load() {
+		w.Header().Set(mcpSessionHeader, s.registerHTTPSession(session))
+	}
 
 	// Send response
 	w.Header().Set("Content-Type", "application/json")
 	if response != nil {
-		w.WriteHeader(http.StatusOK)
+		// Malformed JSON is a 400 in both modes; strict mode also puts the
+		// JSON-RPC parse error in the body.
+		status := http.StatusOK
+		if !json.Valid(body) {
+			status = http.StatusBadRequest
+		}
+		w.WriteHeader(status)
 		if err := json.NewEncoder(w).Encode(response); err != nil {
 			s.log.Errorw("failed to encode HTTP response", "error", err)
 		}
@@ -155,95 +238,265 @@ func (s *Server) handleMCPHTTPRequest(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// handleSSEConnection handles Server-Sent Events connections for notifications
-func (s *Server) handleSSEConnection(w http.ResponseWriter, r *http.Request, clients map[string]*HTTPClient, clientsMu *sync.RWMutex) {
-	// Check if client supports SSE
-	flusher, ok := w.(http.Flusher)
+// handleMCPHTTPDelete ends the session named by the Mcp-Session-Id header,
+// which clients send when they shut down.
+func (s *Server) handleMCPHTTPDelete(w http.ResponseWriter, r *http.Request) {
+	if _, admitted := s.admitHTTPRequest(w, r); !admitted {
+		return
+	}
+	sessionID := r.Header.Get(mcpSessionHeader)
+	if sessionID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"error":"DELETE needs an %s header naming the session to end."}`, mcpSessionHeader)
+		return
+	}
+	session, ok := s.removeHTTPSession(sessionID)
 	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"error":"Unknown MCP session."}`)
+		return
+	}
+	s.closeSession(session)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lookupHTTPSession returns the session registered under id, if any, and
+// marks it active. A session that was idle for longer than
+// httpSessionIdleTimeout is ended instead of being returned.
+func (s *Server) lookupHTTPSession(id string) (*mcpSession, bool) {
+	if id == "" {
+		return nil, false
+	}
+	s.mu.Lock()
+	session, ok := s.httpSessions[id]
+	now := s.clock.Now()
+	expired := ok && now.Sub(session.lastRequest) > httpSessionIdleTimeout
+	if expired {
+		delete(s.httpSessions, id)
+	} else if ok {
+		session.lastRequest = now
+	}
+	s.mu.Unlock()
+
+	if expired {
+		s.log.Infow("MCP HTTP session expired", sessionLogFields(session)...)
+		s.closeSession(session)
+		return nil, false
+	}
+	return session, ok
+}
+
+// touchHTTPSession marks session active, so an open notification stream keeps
+// it from expiring.
+func (s *Server) touchHTTPSession(session *mcpSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session.lastRequest = s.clock.Now()
+}
+
+// removeHTTPSession unregisters the session with the given ID.
+func (s *Server) removeHTTPSession(id string) (*mcpSession, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.httpSessions[id]
+	delete(s.httpSessions, id)
+	return session, ok
+}
+
+// expireHTTPSessions ends every HTTP session that was idle for longer than
+// httpSessionIdleTimeout.
+func (s *Server) expireHTTPSessions() {
+	s.mu.Lock()
+	now := s.clock.Now()
+	var expired []*mcpSession
+	for id, session := range s.httpSessions {
+		if now.Sub(session.lastRequest) > httpSessionIdleTimeout {
+			delete(s.httpSessions, id)
+			expired = append(expired, session)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, session := range expired {
+		s.log.Infow("MCP HTTP session expired", sessionLogFields(session)...)
+		s.closeSession(session)
+	}
+}
+
+// expireHTTPSessionsUntil runs expireHTTPSessions every
+// httpSessionSweepInterval until ctx is done.
+func (s *Server) expireHTTPSessionsUntil(ctx context.Context) {
+	s.mu.RLock()
+	ticker := s.clock.NewTicker(httpSessionSweepInterval)
+	s.mu.RUnlock()
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			s.expireHTTPSessions()
+		}
+	}
+}
+
+// registerHTTPSession stores an initialized session and returns its ID,
+// which initialize assigned.
+func (s *Server) registerHTTPSession(session *mcpSession) string {
+	id := session.sessionID()
+	if id == "" {
+		id = uuid.New().String()
+	}
+	s.makeResumable(session)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.httpSessions == nil {
+		s.httpSessions = make(map[string]*mcpSession)
+	}
+	session.lastRequest = s.clock.Now()
+	s.httpSessions[id] = session
+	return id
+}
+
+// handleSSEConnection handles Server-Sent Events connections for notifications.
+// A stream opened with an Mcp-Session-Id header belongs to that session and
+// also receives the resource updates the session subscribed to. Its
+// notifications carry event IDs, and a stream reopened with Last-Event-ID
+// first gets the notifications of the session it missed.
+func (s *Server) handleSSEConnection(w http.ResponseWriter, r *http.Request) {
+	// Check if client supports SSE
+	if _, ok := w.(http.Flusher); !ok {
 		http.Error(w, "Server-Sent Events not supported", http.StatusNotImplemented)
 		return
 	}
 
+	if _, admitted := s.admitHTTPRequest(w, r); !admitted {
+		return
+	}
+	var session *mcpSession
+	if sessionID := r.Header.Get(mcpSessionHeader); sessionID != "" {
+		var known bool
+		if session, known = s.lookupHTTPSession(sessionID); !known {
+			http.Error(w, "Unknown MCP session", http.StatusNotFound)
+			return
+		}
+	}
+
 	// Set SSE headers
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET")
-	w.Header().Set("Access-Control-Allow-Headers", "Cache-Control")
-
-	// Generate client ID
-	clientID := fmt.Sprintf("client-%d", time.Now().UnixNano())
-
-	// Create client
-	client := &HTTPClient{
-		ID:      clientID,
-		Writer:  w,
-		Flusher: flusher,
-		Done:    make(chan struct{}),
-		Request: r,
-	}
-
-	// Register client
-	clientsMu.Lock()
-	clients[clientID] = client
-	clientsMu.Unlock()
+	w.Header().Set("Access-Control-Allow-Headers", "Cache-Control, Last-Event-ID")
 
-	// Clean up on disconnect
+	client := s.addClient(clientTransportSSE, session)
 	defer func() {
-		clientsMu.Lock()
-		delete(clients, clientID)
-		clientsMu.Unlock()
-		s.log.Debugw("SSE client disconnected", "clientId", clientID)
+		s.removeClient(client)
+		s.log.Debugw("SSE client disconnected", "clientId", client.id)
 	}()
+	s.log.Debugw("SSE client connected", "clientId", client.id, "session", session != nil)
+
+	controller := http.NewResponseController(w)
+	// fields are further "name: value" lines, such as the event ID.
+	writeEvent := func(event string, data []byte, fields ...string) error {
+		// Not every ResponseWriter supports deadlines; writes then block as before.
+		_ = controller.SetWriteDeadline(time.Now().Add(sseWriteTimeout))
+		var frame strings.Builder
+		fmt.Fprintf(&frame, "event: %s\n", event)
+		for _, field := range fields {
+			frame.WriteString(field + "\n")
+		}
+		fmt.Fprintf(&frame, "data: %s\n\n", data)
+		if _, err := io.WriteString(w, frame.String()); err != nil {
+			return err
+		}
+		return controller.Flush()
+	}
+	// written is the highest session event ID sent on this stream, so events
+	// both replayed and queued are sent once.
+	var written uint64
+	writeNotification := func(msg *Message, id uint64) error {
+		if id != 0 && id <= written {
+			return nil
+		}
+		data, err := json.Marshal(msg)
+		if err != nil {
+			s.log.Errorw("failed to marshal notification for HTTP", "error", err)
+			return nil
+		}
+		if id == 0 {
+			return writeEvent("notification", data)
+		}
+		written = id
+		return writeEvent("notification", data, "id: "+strconv.FormatUint(id, 10))
+	}
 
-	s.log.Debugw("SSE client connected", "clientId", clientID)
+	// Send initial connection event, telling the client how soon to reconnect
+	connected, _ := json.Marshal(map[string]string{"clientId": client.id, "server": "gabs", "version": version.Get()})
+	if err := writeEvent("connected", connected, fmt.Sprintf("retry: %d", sseRetry.Milliseconds())); err != nil {
+		return
+	}
 
-	// Send initial connection event
-	fmt.Fprintf(w, "event: connected\n")
-	fmt.Fprintf(w, "data: {\"clientId\":\"%s\",\"server\":\"gabs\",\"version\":\"%s\"}\n\n", clientID, version.Get())
-	flusher.Flush()
+	// A stream resuming a session catches up on what it missed. When the
+	// session's log no longer reaches back far enough, list_changed tells the
+	// client to fetch the tools and resources again.
+	if lastID, resuming := parseLastEventID(r.Header.Get("Last-Event-ID")); resuming && session != nil && session.events != nil {
+		missed, complete := session.events.since(lastID)
+		if !complete {
+			s.log.Debugw("SSE client resumed beyond the replay buffer", "clientId", client.id, "lastEventId", lastID)
+			for _, method := range []string{"notifications/tools/list_changed", "notifications/resources/list_changed"} {
+				if err := writeNotification(NewNotification(method, map[string]interface{}{}), 0); err != nil {
+					return
+				}
+			}
+		}
+		for _, event := range missed {
+			if err := writeNotification(event.msg, event.id); err != nil {
+				return
+			}
+		}
+		s.log.Debugw("SSE client resumed", "clientId", client.id, "lastEventId", lastID, "replayed", len(missed))
+	}
 
 	// Keep connection alive and wait for disconnect
-	ticker := time.NewTicker(30 * time.Second)
+	ticker := time.NewTicker(sseKeepAlive)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-client.Done:
+		case <-client.done:
 			return
 		case <-r.Context().Done():
 			return
+		case msg := <-client.outbox:
+			var id uint64
+			if session != nil && session.events != nil {
+				id = session.events.idOf(msg)
+			}
+			if err := writeNotification(msg, id); err != nil {
+				s.log.Warnw("dropping SSE client after failed write", "clientId", client.id, "method", msg.Method, "error", err)
+				return
+			}
 		case <-ticker.C:
+			if session != nil {
+				s.touchHTTPSession(session)
+			}
 			// Send keepalive ping
-			fmt.Fprintf(w, "event: ping\n")
-			fmt.Fprintf(w, "data: {\"timestamp\":%d}\n\n", time.Now().Unix())
-			flusher.Flush()
+			if err := writeEvent("ping", []byte(fmt.Sprintf(`{"timestamp":%d}`, time.Now().Unix()))); err != nil {
+				return
+			}
 		}
 	}
 }
 
-// SendHTTPNotification sends notifications to all connected HTTP SSE clients
-func (s *Server) SendHTTPNotification(method string, params interface{}, clients map[string]*HTTPClient, clientsMu *sync.RWMutex) {
-	clientsMu.RLock()
-	defer clientsMu.RUnlock()
-
-	notification := NewNotification(method, params)
-	data, err := json.Marshal(notification)
-	if err != nil {
-		s.log.Errorw("failed to marshal notification for HTTP", "error", err)
-		return
-	}
-
-	for clientID, client := range clients {
-		select {
-		case <-client.Done:
-			continue // Client already disconnected
-		default:
-			fmt.Fprintf(client.Writer, "event: notification\n")
-			fmt.Fprintf(client.Writer, "data: %s\n\n", string(data))
-			client.Flusher.Flush()
-			s.log.Debugw("sent HTTP notification", "clientId", clientID, "method", method)
-		}
-	}
+// withServerHeader names the GABS version and platform in the Server header
+// of every HTTP response.
+func withServerHeader(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", version.ServerHeader())
+		next.ServeHTTP(w, r)
+	})
 }
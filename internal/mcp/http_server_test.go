@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/pardeike/gabs/internal/util"
+	"github.com/pardeike/gabs/internal/version"
 )
 
 func TestHTTPServerBasicFunctionality(t *testing.T) {
@@ -182,7 +184,7 @@ func TestHTTPMethodValidation(t *testing.T) {
 		{"GET", http.StatusMethodNotAllowed},
 		{"POST", http.StatusBadRequest}, // Will fail due to no body, but method is allowed
 		{"PUT", http.StatusMethodNotAllowed},
-		{"DELETE", http.StatusMethodNotAllowed},
+		{"DELETE", http.StatusBadRequest}, // Ends a session; this request names none
 	}
 
 	for _, tt := range tests {
@@ -215,6 +217,34 @@ func TestHTTPMethodValidation(t *testing.T) {
 	}
 }
 
+func TestMCPHTTPValidatesContentTypeAndSize(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.maxMessageSize = 64
+
+	post := func(contentType, body string) int {
+		request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+		if contentType != "" {
+			request.Header.Set("Content-Type", contentType)
+		}
+		recorder := httptest.NewRecorder()
+		server.handleMCPHTTPRequest(recorder, request)
+		return recorder.Code
+	}
+
+	listTools := `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`
+	if code := post("text/plain", listTools); code != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected 415 for text/plain, got %d", code)
+	}
+	for _, contentType := range []string{"", "application/json; charset=utf-8"} {
+		if code := post(contentType, listTools); code != http.StatusOK {
+			t.Fatalf("expected %q to be accepted, got %d", contentType, code)
+		}
+	}
+	if code := post("application/json", `{"jsonrpc":"2.0","id":2,"method":"tools/list","params":{"cursor":"`+strings.Repeat("x", 64)+`"}}`); code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 for a body over maxMessageBytes, got %d", code)
+	}
+}
+
 // MockResponseWriter for testing HTTP handlers
 type MockResponseWriter struct {
 	headers    http.Header
@@ -288,3 +318,89 @@ func TestValidJSONRPCOverHTTP(t *testing.T) {
 		t.Logf("Initialize response: ID=%v, Result present=%v", response.ID, response.Result != nil)
 	}
 }
+
+func TestHTTPResponsesAndInitializeReportBuild(t *testing.T) {
+	handler := withServerHeader(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if got := recorder.Header().Get("Server"); got != version.ServerHeader() || !strings.Contains(got, version.Platform()) {
+		t.Fatalf("expected Server header %q, got %q", version.ServerHeader(), got)
+	}
+
+	server := NewServerForTesting(util.NewLogger("error"))
+	response := server.HandleMessage(&Message{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "initialize",
+		Params:  map[string]interface{}{"protocolVersion": "2024-11-05"},
+	})
+	result, ok := response.Result.(InitializeResult)
+	if !ok {
+		t.Fatalf("expected initialize result, got %#v", response.Result)
+	}
+	if result.ServerInfo.Version != version.Get() || result.Meta["platform"] != version.Platform() || result.Meta["commit"] != version.GetCommit() {
+		t.Fatalf("initialize does not report the GABS build: %+v %v", result.ServerInfo, result.Meta)
+	}
+}
+
+func TestMCPHTTPDeleteEndsSession(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.SetStrictMCP(true)
+
+	sessionID, _ := postSessionMCPForTest(t, server, "", strictInitializeLine)
+	if sessionID == "" {
+		t.Fatal("expected initialize to issue a session ID")
+	}
+
+	deleteSession := func(id string) int {
+		request := httptest.NewRequest(http.MethodDelete, "/mcp", nil)
+		request.Header.Set(mcpSessionHeader, id)
+		recorder := httptest.NewRecorder()
+		server.handleMCPHTTPRequest(recorder, request)
+		return recorder.Code
+	}
+	if code := deleteSession(sessionID); code != http.StatusNoContent {
+		t.Fatalf("expected DELETE to end the session with 204, got %d", code)
+	}
+	if code := deleteSession(sessionID); code != http.StatusNotFound {
+		t.Fatalf("expected a second DELETE to find no session, got %d", code)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`))
+	request.Header.Set(mcpSessionHeader, sessionID)
+	recorder := httptest.NewRecorder()
+	server.handleMCPHTTPRequest(recorder, request)
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected the ended session to be unknown, got %d", recorder.Code)
+	}
+}
+
+func TestMCPHTTPSessionsExpireWhenIdle(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	server.SetClock(clock)
+
+	idle, _ := postSessionMCPForTest(t, server, "", strictInitializeLine)
+	active, _ := postSessionMCPForTest(t, server, "", strictInitializeLine)
+
+	clock.Advance(httpSessionIdleTimeout / 2)
+	if _, ok := server.lookupHTTPSession(active); !ok {
+		t.Fatal("expected the active session to be known")
+	}
+	clock.Advance(httpSessionIdleTimeout/2 + time.Second)
+	server.expireHTTPSessions()
+
+	if _, ok := server.lookupHTTPSession(idle); ok {
+		t.Fatal("expected the idle session to expire")
+	}
+	if _, ok := server.lookupHTTPSession(active); !ok {
+		t.Fatal("expected the recently used session to stay")
+	}
+	server.clientsMu.RLock()
+	defer server.clientsMu.RUnlock()
+	if _, listed := server.clientSessions[idle]; listed || len(server.resumable) != 1 || len(server.clientSessions) != 1 {
+		t.Fatalf("expected only the active session to stay listed and resumable, got %d listed and %d resumable", len(server.clientSessions), len(server.resumable))
+	}
+}
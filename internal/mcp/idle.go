@@ -0,0 +1,97 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// idleCheckInterval is how often RunIdleStops looks for idle games.
+const idleCheckInterval = 30 * time.Second
+
+// RunIdleStops gracefully stops running games whose idleTimeout passed
+// without activity, until ctx is cancelled. Activity is an MCP tool call that
+// touches the game or a GABP event from it; starting or connecting the game
+// counts too.
+func (s *Server) RunIdleStops(ctx context.Context) {
+	s.mu.RLock()
+	clock := s.clock
+	s.mu.RUnlock()
+
+	ticker := clock.NewTicker(idleCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C():
+			s.stopIdleGames(now)
+		}
+	}
+}
+
+// touchGame records activity for gameID. Games GABS does not know about are
+// ignored.
+func (s *Server) touchGame(gameID string) {
+	if gameID == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if session, exists := s.sessions[gameID]; exists {
+		session.lastActivity = s.clock.Now()
+	}
+}
+
+// stopIdleGames stops every game that is idle at now. Each stop is announced
+// with a notifications/message first, so agents know why the game went away.
+func (s *Server) stopIdleGames(now time.Time) {
+	s.mu.RLock()
+	gamesConfig := s.gamesConfig
+	candidates := make(map[string]time.Time)
+	for gameID, session := range s.sessions {
+		_, tracked := session.process()
+		_, connected := session.gabpClient()
+		if (tracked || connected) && !session.isStopping() {
+			candidates[gameID] = session.lastActive()
+		}
+	}
+	s.mu.RUnlock()
+	if gamesConfig == nil {
+		return
+	}
+
+	for gameID, lastActive := range candidates {
+		game, exists := s.lookupGame(gamesConfig, gameID)
+		if !exists {
+			continue
+		}
+		timeout, err := game.IdleTimeoutDuration()
+		if err != nil || timeout == 0 || now.Sub(lastActive) < timeout {
+			continue
+		}
+		go s.stopIdleGame(*game, timeout, now.Sub(lastActive))
+	}
+}
+
+func (s *Server) stopIdleGame(game config.GameConfig, timeout, idle time.Duration) {
+	message := fmt.Sprintf("Stopping game '%s': no tool calls or game events for %s (idleTimeout %s).", game.ID, idle.Round(time.Second), timeout)
+	s.SendNotification("notifications/message", map[string]interface{}{
+		"level":  "warning",
+		"logger": "gabs",
+		"data": map[string]interface{}{
+			"message":     message,
+			"gameId":      game.ID,
+			"reason":      "idleTimeout",
+			"idleTimeout": timeout.String(),
+		},
+	})
+
+	if err := s.stopGame(game, false); err != nil {
+		s.log.Warnw("failed to stop idle game", "gameId", game.ID, "idleTimeout", timeout, "error", err)
+		return
+	}
+	s.log.Infow("stopped idle game", "gameId", game.ID, "idleTimeout", timeout, "idle", idle)
+}
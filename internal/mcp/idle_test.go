@@ -0,0 +1,44 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestIdleGameIsStoppedAfterNotification(t *testing.T) {
+	requireSleepForTest(t)
+	game := sleepingGameForTest("factory", "Factory")
+	game.IdleTimeout = "10m"
+	server, _ := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{"factory": game}})
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	server.SetClock(clock)
+	trackSleepingGameForTest(t, server, "factory")
+	client := server.addClient(clientTransportStdio, nil)
+	defer server.removeClient(client)
+
+	clock.Advance(8 * time.Minute)
+	callToolForTest(t, server, "games_status", map[string]interface{}{"gameId": "factory"})
+	clock.Advance(8 * time.Minute)
+	server.stopIdleGames(clock.Now())
+	if status := server.checkGameStatus("factory"); status != "running" {
+		t.Fatalf("expected the tool call to keep the game running, got %q", status)
+	}
+	if len(client.outbox) != 0 {
+		t.Fatalf("expected no notification yet, got %d", len(client.outbox))
+	}
+
+	clock.Advance(3 * time.Minute)
+	server.stopIdleGames(clock.Now())
+	select {
+	case msg := <-client.outbox:
+		if msg.Method != "notifications/message" {
+			t.Fatalf("expected notifications/message before the stop, got %q", msg.Method)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a notification before the idle stop")
+	}
+	waitForGameStatus(t, server, "factory", "stopped")
+}
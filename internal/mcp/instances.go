@@ -0,0 +1,266 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// Extra copies of a game run under their own instance ID, <gameId>-<n>.
+// Everything GABS tracks per game, such as the controller, bridge.json and
+// its port, the runtime state, logs and mirrored tools, is keyed by that ID,
+// so an instance is the game's configuration under another ID. The first
+// instance keeps the game ID, so a game started once behaves as before.
+
+// multiInstanceLaunchModes lists the launch modes that can run several copies
+// of a game. Steam and Epic start one copy per app, and GABS cannot tell the
+// processes of two copies apart by stopProcessName.
+var multiInstanceLaunchModes = map[string]bool{"DirectPath": true, "CustomCommand": true}
+
+// instanceGameConfig returns the configuration instanceID of game runs with.
+func instanceGameConfig(game config.GameConfig, instanceID string) config.GameConfig {
+	if instanceID == game.ID {
+		return game
+	}
+	instance := game
+	instance.ID = instanceID
+	instance.Name = fmt.Sprintf("%s (%s)", game.Name, instanceID)
+	// A process name would match the processes of every instance.
+	instance.StopProcessName = ""
+	// Schedules act on the game, not on each of its instances.
+	instance.Schedules = nil
+	return instance
+}
+
+// reserveInstanceID returns the lowest free instance ID of game. IDs that a
+// game or cluster uses are skipped. An ID keeps belonging to its game after
+// the instance stops, so the next instance reuses its bridge port.
+func (s *Server) reserveInstanceID(gamesConfig *config.GamesConfig, gameID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.instances == nil {
+		s.instances = make(map[string]string)
+	}
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s-%d", gameID, n)
+		if owner, known := s.instances[candidate]; known && owner != gameID {
+			continue
+		}
+		if _, running := s.sessions[candidate].process(); running {
+			continue
+		}
+		if _, exists := gamesConfig.GetGame(candidate); exists {
+			continue
+		}
+		if _, exists := gamesConfig.GetCluster(candidate); exists {
+			continue
+		}
+		s.instances[candidate] = gameID
+		return candidate
+	}
+}
+
+// lookupGame returns the configuration of a game or of an extra instance.
+func (s *Server) lookupGame(gamesConfig *config.GamesConfig, id string) (*config.GameConfig, bool) {
+	if game, exists := gamesConfig.GetGame(id); exists {
+		return game, true
+	}
+	s.mu.RLock()
+	gameID, isInstance := s.instances[id]
+	s.mu.RUnlock()
+	if !isInstance {
+		return nil, false
+	}
+	game, exists := gamesConfig.GetGame(gameID)
+	if !exists {
+		return nil, false
+	}
+	instance := instanceGameConfig(*game, id)
+	return &instance, true
+}
+
+// instanceOf returns the game an instance ID belongs to, or "" if id is not
+// an extra instance.
+func (s *Server) instanceOf(id string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.instances[id]
+}
+
+// extraInstanceIDs returns the tracked extra instances of gameID in start
+// order.
+func (s *Server) extraInstanceIDs(gameID string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var ids []string
+	for id, owner := range s.instances {
+		if _, tracked := s.sessions[id].process(); tracked && owner == gameID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if len(ids[i]) != len(ids[j]) {
+			return len(ids[i]) < len(ids[j])
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// multiInstanceRefusal explains why game cannot run a second instance, or
+// returns nil if it can.
+func multiInstanceRefusal(game config.GameConfig) *ToolResult {
+	if multiInstanceLaunchModes[game.LaunchMode] && game.SSHTunnel == nil {
+		return nil
+	}
+	return &ToolResult{
+		Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' cannot run more than one instance: only local DirectPath and CustomCommand games can.", game.ID)}},
+		IsError: true,
+	}
+}
+
+// instanceTargets returns the instances of game that stop, kill and status
+// act on: the one named by instanceID, or every running instance.
+func (s *Server) instanceTargets(game config.GameConfig, instanceID string) ([]config.GameConfig, *ToolResult) {
+	if instanceID != "" && instanceID != game.ID {
+		if s.instanceOf(instanceID) != game.ID {
+			return nil, &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("'%s' is not an instance of game '%s'. Use games_status to list its instances.", instanceID, game.ID)}},
+				IsError: true,
+			}
+		}
+		return []config.GameConfig{instanceGameConfig(game, instanceID)}, nil
+	}
+	if instanceID == game.ID {
+		return []config.GameConfig{game}, nil
+	}
+
+	var extras []config.GameConfig
+	for _, id := range s.extraInstanceIDs(game.ID) {
+		if s.checkGameStatus(id) != "stopped" {
+			extras = append(extras, instanceGameConfig(game, id))
+		}
+	}
+	if len(extras) > 0 && s.checkGameStatus(game.ID) == "stopped" {
+		return extras, nil
+	}
+	return append([]config.GameConfig{game}, extras...), nil
+}
+
+// stopGameInstances stops or kills targets, the instances of game, and
+// reports the outcome the way games_stop and games_kill do. escalate kills
+// targets a graceful stop leaves running.
+func (s *Server) stopGameInstances(game config.GameConfig, targets []config.GameConfig, force, escalate bool) *ToolResult {
+	failVerb, doneVerb := "stop", "stopped"
+	if force {
+		failVerb, doneVerb = "kill", "terminated"
+	}
+
+	var stopped, escalated []string
+	for _, target := range targets {
+		killed, err := s.stopGameVerified(target, force, escalate)
+		if killed {
+			escalated = append(escalated, target.ID)
+		}
+		if err == nil {
+			stopped = append(stopped, target.ID)
+			continue
+		}
+		// Check if this is a launcher-specific configuration issue
+		if strings.Contains(err.Error(), "Configure 'stopProcessName'") {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("⚠️ %s\n\nTo fix this, update your game configuration to include a 'stopProcessName'. Use: gabs games show %s", err.Error(), game.ID)}},
+				IsError: true,
+			}
+		}
+		var inProgress *gameStopInProgressError
+		if errors.As(err, &inProgress) {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' is already stopping. Use games_status to see when it has exited.", target.ID)}},
+				StructuredContent: map[string]interface{}{
+					"gameId":      target.ID,
+					"status":      "stopping",
+					"stopped":     stopped,
+					"nextActions": s.nextActionsForGameStatus(target, "stopping", 0),
+				},
+			}
+		}
+
+		message := fmt.Sprintf("Failed to %s %s: %v", failVerb, target.ID, err)
+		if len(stopped) > 0 {
+			message += fmt.Sprintf(" (%s: %s)", doneVerb, strings.Join(stopped, ", "))
+		}
+		var stillRunning *gameStillRunningError
+		if !errors.As(err, &stillRunning) {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: message}},
+				IsError: true,
+			}
+		}
+		nextActions := []map[string]interface{}{
+			mcpNextAction("games_status", map[string]interface{}{"gameId": target.ID}, "Check whether the game has exited since."),
+		}
+		if !stillRunning.killed {
+			nextActions = append(nextActions, mcpNextAction("games_kill", map[string]interface{}{"gameId": game.ID, "instanceId": target.ID}, "Force terminate the game."))
+		}
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: message}},
+			StructuredContent: map[string]interface{}{
+				"gameId":      target.ID,
+				"status":      "failed to " + failVerb,
+				"killed":      stillRunning.killed,
+				"stopped":     stopped,
+				"nextActions": nextActions,
+			},
+			IsError: true,
+		}
+	}
+
+	if len(targets) == 1 {
+		message := fmt.Sprintf("Game '%s' (%s) %s successfully", targets[0].ID, targets[0].Name, doneVerb)
+		if len(escalated) > 0 {
+			message = fmt.Sprintf("Game '%s' (%s) did not exit within %s and was killed", targets[0].ID, targets[0].Name, stopGracePeriod)
+		}
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: message}},
+			StructuredContent: map[string]interface{}{
+				"gameId":    targets[0].ID,
+				"status":    "stopped",
+				"escalated": len(escalated) > 0,
+			},
+		}
+	}
+	message := fmt.Sprintf("Game '%s' (%s) %s successfully, instances: %s", game.ID, game.Name, doneVerb, strings.Join(stopped, ", "))
+	if len(escalated) > 0 {
+		message += fmt.Sprintf(" (killed after %s: %s)", stopGracePeriod, strings.Join(escalated, ", "))
+	}
+	return &ToolResult{
+		Content: []Content{{Type: "text", Text: message}},
+		StructuredContent: map[string]interface{}{
+			"gameId":    game.ID,
+			"status":    "stopped",
+			"instances": stopped,
+			"escalated": escalated,
+		},
+	}
+}
+
+// instanceStatusItems returns the structured status of every running extra
+// instance of game.
+func (s *Server) instanceStatusItems(game config.GameConfig) []map[string]interface{} {
+	var items []map[string]interface{}
+	for _, id := range s.extraInstanceIDs(game.ID) {
+		status := s.checkGameStatus(id)
+		if status == "stopped" {
+			continue
+		}
+		item := s.gameStatusStructured(instanceGameConfig(game, id), status)
+		item["instanceId"] = id
+		item["instanceOf"] = game.ID
+		items = append(items, item)
+	}
+	return items
+}
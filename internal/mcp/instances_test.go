@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func TestGamesStartNewInstanceRunsSecondCopyWithOwnBridge(t *testing.T) {
+	requireSleepForTest(t)
+
+	server, configDir := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"factory":   sleepingGameForTest("factory", "Factory"),
+			"factory-2": sleepingGameForTest("factory-2", "Other factory"),
+		},
+	})
+	primary := callToolForTest(t, server, "games_start", map[string]interface{}{"gameId": "factory", "newInstance": true, "timeout": 1})
+	t.Cleanup(func() { server.stopGame(sleepingGameForTest("factory", "Factory"), true) })
+	if primary.IsError || primary.StructuredContent["instanceId"] != "factory" {
+		t.Fatalf("expected a stopped game to start under its own ID, got %#v", primary)
+	}
+	waitForGameStatus(t, server, "factory", "running")
+
+	started := callToolForTest(t, server, "games_start", map[string]interface{}{"gameId": "factory", "newInstance": true, "timeout": 1})
+	t.Cleanup(func() {
+		server.stopGame(instanceGameConfig(sleepingGameForTest("factory", "Factory"), "factory-3"), true)
+	})
+	if started.IsError || started.StructuredContent["instanceId"] != "factory-3" || started.StructuredContent["gameId"] != "factory" {
+		t.Fatalf("expected a second instance that skips the configured factory-2, got %#v", started)
+	}
+	waitForGameStatus(t, server, "factory-3", "running")
+
+	_, primaryPort, _, err := config.ReadBridgeJSON("factory", configDir)
+	if err != nil {
+		t.Fatalf("read primary bridge config: %v", err)
+	}
+	_, instancePort, _, err := config.ReadBridgeJSON("factory-3", configDir)
+	if err != nil {
+		t.Fatalf("read instance bridge config: %v", err)
+	}
+	if primaryPort == instancePort {
+		t.Fatalf("expected the instance to get its own port, both use %d", primaryPort)
+	}
+
+	status := callToolForTest(t, server, "games_status", map[string]interface{}{"gameId": "factory"})
+	instances, _ := status.StructuredContent["instances"].([]interface{})
+	if len(instances) != 1 || instances[0].(map[string]interface{})["instanceId"] != "factory-3" {
+		t.Fatalf("expected the instance in the game status, got %#v", status.StructuredContent)
+	}
+
+	wrong := callToolForTest(t, server, "games_stop", map[string]interface{}{"gameId": "factory", "instanceId": "factory-2"})
+	if !wrong.IsError || !strings.Contains(wrong.Content[0].Text, "not an instance of game 'factory'") {
+		t.Fatalf("expected a configured game not to count as an instance, got %#v", wrong)
+	}
+
+	stopped := callToolForTest(t, server, "games_stop", map[string]interface{}{"gameId": "factory", "instanceId": "factory-3"})
+	if stopped.IsError {
+		t.Fatalf("instance stop failed: %#v", stopped)
+	}
+	if status := server.checkGameStatus("factory"); status != "running" {
+		t.Fatalf("expected stopping the instance to leave the game running, got %q", status)
+	}
+
+	started = callToolForTest(t, server, "games_start", map[string]interface{}{"gameId": "factory", "newInstance": true, "timeout": 1})
+	if started.IsError || started.StructuredContent["instanceId"] != "factory-3" {
+		t.Fatalf("expected the stopped instance ID to be reused, got %#v", started)
+	}
+	killed := callToolForTest(t, server, "games_kill", map[string]interface{}{"gameId": "factory"})
+	if killed.IsError || !strings.Contains(killed.Content[0].Text, "instances: factory, factory-3") {
+		t.Fatalf("expected both instances to be killed, got %#v", killed)
+	}
+	for _, id := range []string{"factory", "factory-3"} {
+		if status := server.checkGameStatus(id); status != "stopped" {
+			t.Fatalf("expected %s to be stopped, got %q", id, status)
+		}
+	}
+}
+
+func TestGamesStartNewInstanceRefusesLauncherGames(t *testing.T) {
+	refusal := multiInstanceRefusal(config.GameConfig{ID: "adventure", LaunchMode: "SteamAppId", Target: "123456"})
+	if refusal == nil || !strings.Contains(refusal.Content[0].Text, "cannot run more than one instance") {
+		t.Fatalf("expected launcher games to be refused, got %#v", refusal)
+	}
+	if refusal := multiInstanceRefusal(sleepingGameForTest("factory", "Factory")); refusal != nil {
+		t.Fatalf("expected DirectPath games to be allowed, got %#v", refusal)
+	}
+}
@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"time"
+
+	"github.com/pardeike/gabs/internal/process"
+)
+
+// gameExitPollInterval is how often the exit monitor of a started game checks
+// whether its process is still running.
+const gameExitPollInterval = 2 * time.Second
+
+// Cleaning up after a game, which closes its GABP connection, unregisters its
+// tools and resources and removes its runtime state, never happens inside a
+// status query. Status queries, the exit monitor and stops report exits as
+// events, and a lifecycle worker per game handles them one at a time.
+//
+// Every time a controller is tracked for a game, and every time a game is
+// cleaned up, the game's generation goes up. An exit event carries the
+// generation it was seen in, so an exit reported for a process that was
+// replaced by a restart in the meantime is dropped instead of tearing down the
+// new one.
+
+// gameExit is an exit event waiting for a game's lifecycle worker.
+type gameExit struct {
+	generation uint64
+	done       chan struct{} // Closed once the event was handled
+}
+
+// trackGameLocked tracks controller as gameID's running process and returns
+// its generation. Callers hold s.mu.
+func (s *Server) trackGameLocked(gameID string, controller process.ControllerInterface) uint64 {
+	return s.sessionLocked(gameID).track(controller, s.clock.Now())
+}
+
+// gameGeneration returns gameID's current generation.
+func (s *Server) gameGeneration(gameID string) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sessions[gameID].currentGeneration()
+}
+
+// queueGameExit reports that the process of generation exited and returns a
+// channel that is closed once gameID's lifecycle worker handled it. It only
+// takes lifecycleMu, so callers may hold s.mu.
+func (s *Server) queueGameExit(gameID string, generation uint64) <-chan struct{} {
+	event := gameExit{generation: generation, done: make(chan struct{})}
+
+	s.lifecycleMu.Lock()
+	defer s.lifecycleMu.Unlock()
+	if s.pendingExits == nil {
+		s.pendingExits = make(map[string][]gameExit)
+		s.lifecycleBusy = make(map[string]bool)
+	}
+	s.pendingExits[gameID] = append(s.pendingExits[gameID], event)
+	if !s.lifecycleBusy[gameID] {
+		s.lifecycleBusy[gameID] = true
+		go s.runGameLifecycle(gameID)
+	}
+	return event.done
+}
+
+// cleanupStoppedGame cleans up after gameID's process of generation exited
+// and returns once that is done.
+func (s *Server) cleanupStoppedGame(gameID string, generation uint64) {
+	<-s.queueGameExit(gameID, generation)
+}
+
+// runGameLifecycle handles gameID's exit events in order until none are left.
+func (s *Server) runGameLifecycle(gameID string) {
+	for {
+		s.lifecycleMu.Lock()
+		events := s.pendingExits[gameID]
+		if len(events) == 0 {
+			delete(s.pendingExits, gameID)
+			delete(s.lifecycleBusy, gameID)
+			s.lifecycleMu.Unlock()
+			return
+		}
+		event := events[0]
+		s.pendingExits[gameID] = events[1:]
+		s.lifecycleMu.Unlock()
+
+		s.handleGameExit(gameID, event.generation)
+		close(event.done)
+	}
+}
+
+// handleGameExit cleans up after gameID unless the exit is stale: the game was
+// started again or already cleaned up since, or its process is running after
+// all.
+func (s *Server) handleGameExit(gameID string, generation uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current := s.sessions[gameID].currentGeneration(); current != generation {
+		s.processLog.Debugw("ignoring exit of a replaced game process", "gameId", gameID, "generation", generation, "current", current)
+		return
+	}
+	if controller, tracked := s.sessions[gameID].process(); tracked && controller.IsRunning() {
+		s.processLog.Debugw("ignoring exit of a game that is running again", "gameId", gameID, "generation", generation)
+		return
+	}
+	s.cleanupStoppedGameLocked(gameID)
+}
+
+// monitorGameExit watches the game started as generation and reports its
+// exit the way a status query does, so a game that crashes is cleaned up even
+// when nobody asks for its status. It returns once the game is replaced or
+// stopped, or GABS shuts down.
+func (s *Server) monitorGameExit(gameID string, generation uint64) {
+	s.mu.RLock()
+	clock := s.clock
+	s.mu.RUnlock()
+	ctx := s.serveContext()
+
+	ticker := clock.NewTicker(gameExitPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+		if s.gameGeneration(gameID) != generation || s.checkGameStatus(gameID) == "stopped" {
+			return
+		}
+	}
+}
@@ -6,266 +6,129 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
 	"github.com/pardeike/gabs/internal/util"
 )
 
-// TestApplicationLifecycleManagement tests the improved application lifecycle management
-func TestApplicationLifecycleManagement(t *testing.T) {
-	// Create a temporary config for testing
-	tempDir, err := os.MkdirTemp("", "gabs_lifecycle_test")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer os.RemoveAll(tempDir)
-
-	configPath := filepath.Join(tempDir, "config.json")
+func registerLifecycleToolForTest(server *Server) {
+	server.RegisterGameTool("factory", Tool{
+		Name:        "factory.world.status",
+		InputSchema: map[string]interface{}{"type": "object"},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		return &ToolResult{Content: []Content{{Type: "text", Text: "live"}}}, nil
+	}, nil)
+}
 
-	// Create config with multiple launch modes to test different scenarios
-	gamesConfig := &config.GamesConfig{
-		Version: "1.0",
-		Games: map[string]config.GameConfig{
-			"test-direct": {
-				ID:         "test-direct",
-				Name:       "Test Direct Launch",
-				LaunchMode: "DirectPath",
-				Target:     "/bin/sleep",
-				Args:       []string{"5"},
-			},
-			"test-steam": {
-				ID:         "test-steam",
-				Name:       "Test Steam Game",
-				LaunchMode: "SteamAppId",
-				Target:     "123456",
-			},
-		},
-	}
+func TestStatusQueryLeavesCleanupToLifecycleWorker(t *testing.T) {
+	game := sleepingGameForTest("factory", "Factory")
+	server, _ := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{"factory": game}})
+	clock := util.NewFakeClock(time.Unix(0, 0))
 
-	err = config.SaveGamesConfigToPath(gamesConfig, configPath)
-	if err != nil {
-		t.Fatal(err)
-	}
+	proc := trackFakeGameForTest(t, server, game, clock)
+	registerLifecycleToolForTest(server)
+	exitedGeneration := server.gameGeneration("factory")
 
-	// Load config and create server
-	loadedConfig, err := config.LoadGamesConfigFromPath(configPath)
-	if err != nil {
-		t.Fatal(err)
+	proc.Exit(nil)
+	if status := server.checkGameStatus("factory"); status != "stopped" {
+		t.Fatalf("expected the exited game to be reported stopped, got %q", status)
 	}
 
-	logger := util.NewLogger("info")
-	server := NewServerForTesting(logger)
-	server.RegisterGameManagementTools(loadedConfig, 0, 0)
-
-	t.Run("DirectPathApplication", func(t *testing.T) {
-		// Test starting a direct path application
-		startMsg := &Message{
-			JSONRPC: "2.0",
-			Method:  "tools/call",
-			ID:      json.RawMessage(`"start-direct"`),
-			Params: map[string]interface{}{
-				"name": "games.start",
-				"arguments": map[string]interface{}{
-					"gameId": "test-direct",
-				},
-			},
-		}
-
-		response := server.HandleMessage(startMsg)
-		if response == nil {
-			t.Fatal("Expected response from games.start")
-		}
-
-		respBytes, _ := json.Marshal(response)
-		responseStr := string(respBytes)
-		t.Logf("Direct path start result: %s", responseStr)
+	// Events for a game are handled in order, so once this one is done the
+	// status query's event was handled too.
+	server.cleanupStoppedGame("factory", exitedGeneration)
 
-		if strings.Contains(responseStr, "isError") && strings.Contains(responseStr, "true") {
-			t.Error("Direct path game should start successfully")
-		}
+	server.mu.RLock()
+	_, tracked := server.sessions["factory"].process()
+	toolCount := len(server.sessions["factory"].toolNames())
+	server.mu.RUnlock()
+	if tracked || toolCount != 0 {
+		t.Fatalf("expected the lifecycle worker to untrack the game and remove its tools, tracked=%v tools=%d", tracked, toolCount)
+	}
+	if generation := server.gameGeneration("factory"); generation != exitedGeneration+1 {
+		t.Fatalf("expected one cleanup and the repeated exit to be dropped, generation %d after %d", generation, exitedGeneration)
+	}
+}
 
-		// Check status immediately after start
-		statusMsg := &Message{
-			JSONRPC: "2.0",
-			Method:  "tools/call",
-			ID:      json.RawMessage(`"status-direct"`),
-			Params: map[string]interface{}{
-				"name": "games.status",
-				"arguments": map[string]interface{}{
-					"gameId": "test-direct",
-				},
-			},
-		}
+func TestStaleExitDoesNotCleanUpRestartedGame(t *testing.T) {
+	game := sleepingGameForTest("factory", "Factory")
+	server, _ := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{"factory": game}})
+	clock := util.NewFakeClock(time.Unix(0, 0))
 
-		statusResponse := server.HandleMessage(statusMsg)
-		statusBytes, _ := json.Marshal(statusResponse)
-		statusStr := string(statusBytes)
-		t.Logf("Direct path status: %s", statusStr)
+	trackFakeGameForTest(t, server, game, clock)
+	oldGeneration := server.gameGeneration("factory")
 
-		// Should show running initially (this validates the process controller is working)
-		if !strings.Contains(statusStr, "running") && !strings.Contains(statusStr, "launched") {
-			t.Error("Direct path game should show as running or launched after start")
-		}
+	// The game is restarted before the exit of its old process is handled.
+	trackFakeGameForTest(t, server, game, clock)
+	registerLifecycleToolForTest(server)
+	server.cleanupStoppedGame("factory", oldGeneration)
 
-		// Test that we can start the same game again (this was the main issue)
-		// Previously this would fail with "already running"
-		response2 := server.HandleMessage(startMsg)
-		respBytes2, _ := json.Marshal(response2)
-		responseStr2 := string(respBytes2)
-		t.Logf("Second start attempt result: %s", responseStr2)
+	if status := server.checkGameStatus("factory"); status != "running" {
+		t.Fatalf("expected the restarted game to keep running, got %q", status)
+	}
+	server.mu.RLock()
+	toolCount := len(server.sessions["factory"].toolNames())
+	server.mu.RUnlock()
+	if toolCount != 1 {
+		t.Fatalf("expected the restarted game to keep its tools, got %d", toolCount)
+	}
+}
 
-		// The second start should either succeed (for Steam-like games) or
-		// give a reasonable error (for direct processes that are still running)
-		// The key improvement is that it doesn't fail with "not found"
-		if strings.Contains(responseStr2, "not found") {
-			t.Error("Second start attempt should not fail with 'not found'")
-		}
+func TestGamesStartWritesBridgeConfigAndResolvesLaunchTargets(t *testing.T) {
+	runner := process.NewFakeProcessRunner(4400)
+	t.Cleanup(process.SetProcessRunnerForTesting(runner))
+	server, configDir := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"test-direct": {ID: "test-direct", Name: "Test Direct Launch", LaunchMode: "DirectPath", Target: "/opt/direct/run", Args: []string{"5"}},
+			"test-steam":  {ID: "test-steam", Name: "Test Steam Game", LaunchMode: "SteamAppId", Target: "123456", StopProcessName: "SteamGame.exe"},
+		},
 	})
-
-	t.Run("SteamAppIdResolution", func(t *testing.T) {
-		// Test starting with Steam App ID (using direct ID)
-		startCorrectMsg := &Message{
-			JSONRPC: "2.0",
-			Method:  "tools/call",
-			ID:      json.RawMessage(`"start-steam-correct"`),
-			Params: map[string]interface{}{
-				"name": "games.start",
-				"arguments": map[string]interface{}{
-					"gameId": "test-steam",
-				},
-			},
-		}
-
-		response := server.HandleMessage(startCorrectMsg)
-		respBytes, _ := json.Marshal(response)
-		responseStr := string(respBytes)
-		t.Logf("Steam game start (game ID): %s", responseStr)
-
-		// Test starting with Steam App ID (using target)
-		startTargetMsg := &Message{
-			JSONRPC: "2.0",
-			Method:  "tools/call",
-			ID:      json.RawMessage(`"start-steam-target"`),
-			Params: map[string]interface{}{
-				"name": "games.start",
-				"arguments": map[string]interface{}{
-					"gameId": "123456", // Using Steam App ID directly
-				},
-			},
-		}
-
-		response2 := server.HandleMessage(startTargetMsg)
-		respBytes2, _ := json.Marshal(response2)
-		responseStr2 := string(respBytes2)
-		t.Logf("Steam game start (Steam App ID): %s", responseStr2)
-
-		// Both should resolve to the same game - either both succeed or both recognize the game configuration
-		if strings.Contains(responseStr, "game not found") || strings.Contains(responseStr2, "game not found") ||
-			strings.Contains(responseStr, "configuration not found") || strings.Contains(responseStr2, "configuration not found") {
-			t.Error("Steam App ID should resolve to configured game")
-		}
-
-		// Check status
-		statusMsg := &Message{
-			JSONRPC: "2.0",
-			Method:  "tools/call",
-			ID:      json.RawMessage(`"status-steam"`),
-			Params: map[string]interface{}{
-				"name": "games.status",
-				"arguments": map[string]interface{}{
-					"gameId": "123456", // Should resolve via target
-				},
-			},
-		}
-
-		statusResponse := server.HandleMessage(statusMsg)
-		statusBytes, _ := json.Marshal(statusResponse)
-		statusStr := string(statusBytes)
-		t.Logf("Steam game status via App ID: %s", statusStr)
-
-		// Should resolve correctly
-		if strings.Contains(statusStr, "not found") {
-			t.Error("Steam App ID should resolve for status check")
+	t.Cleanup(func() {
+		for _, proc := range runner.Processes() {
+			proc.Exit(nil)
 		}
 	})
 
-	t.Run("GABPBridgeConfiguration", func(t *testing.T) {
-		// Verify that GABP bridge configuration is created when starting games
-		// Start a game and check if bridge config exists
-		startMsg := &Message{
-			JSONRPC: "2.0",
-			Method:  "tools/call",
-			ID:      json.RawMessage(`"start-for-bridge"`),
-			Params: map[string]interface{}{
-				"name": "games.start",
-				"arguments": map[string]interface{}{
-					"gameId": "test-direct",
-				},
-			},
-		}
-
-		response := server.HandleMessage(startMsg)
-		respBytes, _ := json.Marshal(response)
-		t.Logf("Game start for bridge test: %s", string(respBytes))
-
-		// Check if bridge config was created in the default per-game config directory.
-		homeDir, _ := os.UserHomeDir()
-		bridgePath := filepath.Join(homeDir, ".gabs", "test-direct", "bridge.json")
-
-		if _, err := os.Stat(bridgePath); err == nil {
-			t.Logf("Bridge config created at: %s", bridgePath)
+	result := callToolForTest(t, server, "games_start", map[string]interface{}{"gameId": "test-direct"})
+	if result.IsError {
+		t.Fatalf("expected the direct game to start, got %#v", result)
+	}
+	if started := runner.Started(); len(started) != 1 || started[0].Path != "/opt/direct/run" || strings.Join(started[0].Args[1:], " ") != "5" {
+		t.Fatalf("expected one launch of the target with its args, got %#v", started)
+	}
+	if status := server.checkGameStatus("test-direct"); status != "running" {
+		t.Fatalf("expected the started game to be running, got %q", status)
+	}
+	result = callToolForTest(t, server, "games_start", map[string]interface{}{"gameId": "test-direct"})
+	if text := result.Content[0].Text; strings.Contains(text, "not found") {
+		t.Fatalf("expected a second start to find the game, got %q", text)
+	}
+	if len(runner.Started()) != 1 {
+		t.Fatalf("expected a running game not to be launched twice, got %d launches", len(runner.Started()))
+	}
 
-			// Read and validate bridge config structure
-			bridgeData, err := os.ReadFile(bridgePath)
-			if err != nil {
-				t.Error("Failed to read bridge config")
-			} else {
-				var bridgeConfig map[string]interface{}
-				if err := json.Unmarshal(bridgeData, &bridgeConfig); err != nil {
-					t.Error("Failed to parse bridge config JSON")
-				} else {
-					// Verify required fields
-					requiredFields := []string{"port", "token", "gameId"}
-					for _, field := range requiredFields {
-						if _, exists := bridgeConfig[field]; !exists {
-							t.Errorf("Bridge config missing required field: %s", field)
-						}
-					}
-					t.Logf("Bridge config valid with fields: %v", keys(bridgeConfig))
-				}
-			}
-		} else {
-			// Bridge config might be in a different location or test might not create files
-			t.Logf("Bridge config not found at expected location (may be normal in test environment)")
+	data, err := os.ReadFile(filepath.Join(configDir, "test-direct", "bridge.json"))
+	if err != nil {
+		t.Fatalf("expected games_start to write bridge.json: %v", err)
+	}
+	var bridge map[string]interface{}
+	if err := json.Unmarshal(data, &bridge); err != nil {
+		t.Fatalf("invalid bridge.json: %v", err)
+	}
+	for _, field := range []string{"port", "token", "gameId"} {
+		if _, ok := bridge[field]; !ok {
+			t.Errorf("bridge.json is missing %s: %s", field, data)
 		}
-	})
-}
-
-// TestProcessStateManagement tests the stateless process state detection
-func TestProcessStateManagement(t *testing.T) {
-	// Test the stateless process controller
-	t.Run("ProcessStatusDetection", func(t *testing.T) {
-		// This tests the IsRunning() method of process controller
-		// The stateless approach queries actual system state directly
-		t.Log("Process status detection tested indirectly through lifecycle tests")
-	})
-
-	t.Run("DeadProcessCleanup", func(t *testing.T) {
-		// Test that dead processes are cleaned up from the games map
-		t.Log("Dead process cleanup tested in DirectPathApplication test")
-	})
-
-	t.Run("SteamLauncherHandling", func(t *testing.T) {
-		// Test that Steam/Epic launchers are handled differently from direct processes
-		t.Log("Steam launcher handling tested in SteamAppIdResolution test")
-	})
-}
+	}
 
-// Helper function to get map keys
-func keys(m map[string]interface{}) []string {
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
+	game, ok := server.resolveGameId(server.gamesConfig, "123456")
+	if !ok || game.ID != "test-steam" {
+		t.Fatalf("expected the Steam App ID to resolve to test-steam, got %#v", game)
+	}
+	status := callToolForTest(t, server, "games_status", map[string]interface{}{"gameId": "123456"})
+	if status.IsError || !strings.Contains(status.Content[0].Text, "test-steam") {
+		t.Fatalf("expected games_status to resolve the Steam App ID, got %#v", status)
 	}
-	return keys
 }
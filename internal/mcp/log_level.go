@@ -0,0 +1,170 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// logSubsystems are the parts of GABS whose log level can be set on its own:
+// MCP handling, GABP connections and game processes.
+var logSubsystems = []string{"mcp", "gabp", "process"}
+
+// logLevelRequest is the body of a POST to /admin/log-level.
+type logLevelRequest struct {
+	Level     string `json:"level"`
+	Subsystem string `json:"subsystem,omitempty"`
+}
+
+// canChangeServerSettings reports whether role may change how the server
+// itself runs: the local user, the main apiKey, or anyone when HTTP has no
+// API keys.
+func canChangeServerSettings(role string) bool {
+	switch role {
+	case config.AccessRoleLocal, config.AccessRoleAdmin, config.AccessRoleAnonymous:
+		return true
+	}
+	return false
+}
+
+// setLogLevel changes the log level of GABS, or of one subsystem when
+// subsystem is not empty, while it runs.
+func (s *Server) setLogLevel(subsystem, level string) error {
+	if subsystem != "" && !containsString(logSubsystems, subsystem) {
+		return fmt.Errorf("unknown subsystem %q (use %s)", subsystem, strings.Join(logSubsystems, ", "))
+	}
+	if err := util.SetLogLevel(s.log, subsystem, level); err != nil {
+		return err
+	}
+	scope := subsystem
+	if scope == "" {
+		scope = "all"
+	}
+	// s.log entries already carry subsystem "mcp", so the changed one is the scope
+	s.log.Infow("log level changed", "level", level, "scope", scope)
+	return nil
+}
+
+// logLevels reports the level of GABS and the level each subsystem logs at.
+func (s *Server) logLevels() map[string]interface{} {
+	levels := util.LogLevelsOf(s.log)
+	subsystems := make(map[string]interface{}, len(logSubsystems))
+	for _, name := range logSubsystems {
+		if level, own := levels[name]; own {
+			subsystems[name] = level
+		} else {
+			subsystems[name] = levels[""]
+		}
+	}
+	return map[string]interface{}{
+		"level":      levels[""],
+		"subsystems": subsystems,
+	}
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// handleLogLevel serves /admin/log-level: GET reports the levels, POST sets
+// one from a logLevelRequest. Only the main apiKey may use it.
+func (s *Server) handleLogLevel(w http.ResponseWriter, r *http.Request) {
+	apiKey, admitted := s.admitHTTPRequest(w, r)
+	if !admitted {
+		return
+	}
+	if role := apiKey.Role; !canChangeServerSettings(role) {
+		writeHTTPJSON(w, http.StatusForbidden, map[string]interface{}{"error": fmt.Sprintf("role '%s' may not change the log level", role)})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var request logLevelRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+			writeHTTPJSON(w, http.StatusBadRequest, map[string]interface{}{"error": fmt.Sprintf("invalid request body: %v", err)})
+			return
+		}
+		if err := s.setLogLevel(request.Subsystem, request.Level); err != nil {
+			writeHTTPJSON(w, http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeHTTPJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{"error": "Method not allowed. Use GET or POST."})
+		return
+	}
+	writeHTTPJSON(w, http.StatusOK, s.logLevels())
+}
+
+func writeHTTPJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (s *Server) registerSetLogLevelTool(normalizationConfig *config.ToolNormalizationConfig) {
+	s.registerCallTool(Tool{
+		Name:        "server.set_log_level",
+		Description: fmt.Sprintf("Change the GABS log level while it runs, for example to debug a flaky GABP connection without a restart. Pass subsystem (%s) to change only that part.", strings.Join(logSubsystems, ", ")),
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"level": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"trace", "debug", "info", "warn", "error"},
+					"description": "New log level",
+				},
+				"subsystem": map[string]interface{}{
+					"type":        "string",
+					"enum":        logSubsystems,
+					"description": "Only change this subsystem (optional, defaults to all subsystems without a level of their own)",
+				},
+			},
+			"required": []string{"level"},
+		},
+	}, func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
+		if role := call.accessRole(); !canChangeServerSettings(role) {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Role '%s' may not change the log level.", role)}},
+				IsError: true,
+			}, nil
+		}
+		level, ok := args["level"].(string)
+		if !ok || level == "" {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "level parameter is required"}},
+				IsError: true,
+			}, nil
+		}
+		subsystem, _, invalidArg := parseOptionalStringArg(args, "subsystem")
+		if invalidArg != nil {
+			return invalidArg, nil
+		}
+		if err := s.setLogLevel(subsystem, level); err != nil {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: err.Error()}},
+				IsError: true,
+			}, nil
+		}
+
+		text := fmt.Sprintf("GABS now logs at %s.", level)
+		if subsystem != "" {
+			text = fmt.Sprintf("The %s subsystem now logs at %s.", subsystem, level)
+		}
+		return &ToolResult{
+			Content:           []Content{{Type: "text", Text: text}},
+			StructuredContent: s.logLevels(),
+		}, nil
+	}, normalizationConfig)
+}
@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func TestSetLogLevelToolChangesOneSubsystem(t *testing.T) {
+	server, _ := newGamesTestServer(t, &config.GamesConfig{})
+
+	result := callToolForTest(t, server, "server_set_log_level", map[string]interface{}{"level": "debug", "subsystem": "gabp"})
+	if result.IsError {
+		t.Fatalf("server_set_log_level failed: %#v", result)
+	}
+	subsystems, _ := result.StructuredContent["subsystems"].(map[string]interface{})
+	if result.StructuredContent["level"] != "error" || subsystems["gabp"] != "debug" || subsystems["process"] != "error" {
+		t.Fatalf("expected only gabp to log at debug, got %#v", result.StructuredContent)
+	}
+
+	if result := callToolForTest(t, server, "server_set_log_level", map[string]interface{}{"level": "debug", "subsystem": "steam"}); !result.IsError {
+		t.Fatalf("expected an unknown subsystem to be rejected, got %#v", result)
+	}
+	if result := callToolForTest(t, server, "server_set_log_level", map[string]interface{}{"level": "loud"}); !result.IsError {
+		t.Fatalf("expected an unknown level to be rejected, got %#v", result)
+	}
+}
+
+func TestSetLogLevelIsLimitedToTheMainAPIKey(t *testing.T) {
+	server := newResourceAccessTestServer(t)
+	call := Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "tools/call", Params: map[string]interface{}{
+		"name":      "server_set_log_level",
+		"arguments": map[string]interface{}{"level": "debug"},
+	}}
+
+	_, denied := postMCPForTest(t, server, "viewer-key", call)
+	var result ToolResult
+	if err := decodeResult(denied.Result, &result); err != nil || !result.IsError {
+		t.Fatalf("expected the readonly key to be refused, got %#v (%v)", denied, err)
+	}
+
+	post := func(key, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/admin/log-level", strings.NewReader(body))
+		request.Header.Set("Authorization", "Bearer "+key)
+		recorder := httptest.NewRecorder()
+		server.handleLogLevel(recorder, request)
+		return recorder
+	}
+	if recorder := post("viewer-key", `{"level":"debug"}`); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for the readonly key, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := post("main-key", `{"level":"warn"}`); recorder.Code != http.StatusOK {
+		t.Fatalf("expected the main key to set the level, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := post("main-key", `{"level":"debug","subsystem":"steam"}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown subsystem, got %d", recorder.Code)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/admin/log-level", nil)
+	request.Header.Set("Authorization", "Bearer main-key")
+	recorder := httptest.NewRecorder()
+	server.handleLogLevel(recorder, request)
+	var levels map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &levels); err != nil || levels["level"] != "warn" {
+		t.Fatalf("expected GET to report the level set before, got %s (%v)", recorder.Body.String(), err)
+	}
+}
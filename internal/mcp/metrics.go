@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"time"
+
+	"github.com/pardeike/gabs/internal/metrics"
+)
+
+// serverMetrics are the counters and gauges served on /metrics in HTTP mode.
+type serverMetrics struct {
+	registry            *metrics.Registry
+	toolCalls           *metrics.CounterVec
+	toolCallDuration    *metrics.HistogramVec
+	gabpConnectAttempts *metrics.CounterVec
+	gabpConnectFailures *metrics.CounterVec
+	gameStarts          *metrics.CounterVec
+	gameExits           *metrics.CounterVec
+	notificationErrors  *metrics.CounterVec
+	gabpEventsDropped   *metrics.CounterVec
+}
+
+func newServerMetrics(s *Server) *serverMetrics {
+	registry := metrics.NewRegistry()
+	m := &serverMetrics{
+		registry:            registry,
+		toolCalls:           registry.NewCounterVec("gabs_tool_calls_total", "MCP tool calls by tool and result (ok, error or failed).", "tool", "result"),
+		toolCallDuration:    registry.NewHistogramVec("gabs_tool_call_duration_seconds", "MCP tool call latency in seconds.", metrics.DefaultBuckets, "tool"),
+		gabpConnectAttempts: registry.NewCounterVec("gabs_gabp_connect_attempts_total", "GABP dial attempts per game, including retries.", "game"),
+		gabpConnectFailures: registry.NewCounterVec("gabs_gabp_connect_failures_total", "Failed GABP dial attempts per game.", "game"),
+		gameStarts:          registry.NewCounterVec("gabs_game_starts_total", "Game processes started by GABS.", "game"),
+		gameExits:           registry.NewCounterVec("gabs_game_exits_total", "Tracked game processes that stopped or exited.", "game"),
+		notificationErrors:  registry.NewCounterVec("gabs_notification_send_errors_total", "Notifications that could not be delivered, by client transport.", "transport"),
+		gabpEventsDropped:   registry.NewCounterVec("gabs_gabp_events_dropped_total", "GABP events dropped or coalesced because GABS fell behind handling them, per game and channel.", "game", "channel"),
+	}
+	registry.NewGaugeFunc("gabs_running_games", "Game processes currently tracked by GABS.", func() float64 {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		running := 0
+		for _, session := range s.sessions {
+			if _, tracked := session.process(); tracked {
+				running++
+			}
+		}
+		return float64(running)
+	})
+	registry.NewGaugeFunc("gabs_gabp_connected_clients", "Live GABP connections.", func() float64 {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		connected := 0
+		for _, session := range s.sessions {
+			if client, exists := session.gabpClient(); exists && client.IsConnected() {
+				connected++
+			}
+		}
+		return float64(connected)
+	})
+	return m
+}
+
+// observeToolCall records one tool call. Results with IsError count as
+// "error"; handler failures count as "failed".
+func (m *serverMetrics) observeToolCall(tool string, started time.Time, result *ToolResult, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "failed"
+	} else if result != nil && result.IsError {
+		outcome = "error"
+	}
+	m.toolCalls.Inc(tool, outcome)
+	m.toolCallDuration.Observe(time.Since(started).Seconds(), tool)
+}
+
+// observeGABPDial is the gabp.Client dial observer for gameID.
+func (m *serverMetrics) observeGABPDial(gameID string) func(error) {
+	return func(err error) {
+		m.gabpConnectAttempts.Inc(gameID)
+		if err != nil {
+			m.gabpConnectFailures.Inc(gameID)
+		}
+	}
+}
+
+// observeGABPEventDrop is the gabp.Client event drop observer for gameID.
+func (m *serverMetrics) observeGABPEventDrop(gameID string) func(channel string) {
+	return func(channel string) {
+		m.gabpEventsDropped.Inc(gameID, channel)
+	}
+}
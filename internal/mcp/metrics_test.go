@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func scrapeMetricsForTest(t *testing.T, server *Server, key string) (int, string) {
+	t.Helper()
+	request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	if key != "" {
+		request.Header.Set("Authorization", "Bearer "+key)
+	}
+	recorder := httptest.NewRecorder()
+	server.handleMetrics(recorder, request)
+	return recorder.Code, recorder.Body.String()
+}
+
+func TestMetricsEndpointCountsToolCallsAndGABPDials(t *testing.T) {
+	server, _ := newGamesTestServer(t, &config.GamesConfig{APIKey: "main-key"})
+	server.SetAPIKey("main-key")
+
+	if code, _ := scrapeMetricsForTest(t, server, ""); code != http.StatusUnauthorized {
+		t.Fatalf("expected /metrics to require the API key, got %d", code)
+	}
+
+	postMCPForTest(t, server, "main-key", Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "tools/call", Params: map[string]interface{}{"name": "games.list", "arguments": map[string]interface{}{}}})
+	postMCPForTest(t, server, "main-key", Message{JSONRPC: "2.0", ID: json.RawMessage(`2`), Method: "tools/call", Params: map[string]interface{}{"name": "games.show", "arguments": map[string]interface{}{"gameId": "missing"}}})
+
+	// Dial a port nobody listens on until the deadline runs out.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+	client := server.newGABPClient("factory")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := client.Connect(ctx, addr, "token", time.Millisecond, time.Millisecond); err == nil {
+		t.Fatal("expected connect to a closed port to fail")
+	}
+
+	code, text := scrapeMetricsForTest(t, server, "main-key")
+	if code != http.StatusOK {
+		t.Fatalf("expected metrics, got %d: %s", code, text)
+	}
+	for _, want := range []string{
+		`gabs_tool_calls_total{tool="games_list",result="ok"} 1`,
+		`gabs_tool_calls_total{tool="games_show",result="error"} 1`,
+		`gabs_tool_call_duration_seconds_count{tool="games_list"} 1`,
+		"gabs_running_games 0",
+		"gabs_gabp_connected_clients 0",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in metrics:\n%s", want, text)
+		}
+	}
+	attempts := server.metrics.gabpConnectAttempts.Value("factory")
+	if attempts < 1 || server.metrics.gabpConnectFailures.Value("factory") != attempts {
+		t.Fatalf("expected every failed dial to be counted, got %v attempts and %v failures", attempts, server.metrics.gabpConnectFailures.Value("factory"))
+	}
+}
@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+// mirroredOutputSchema turns the outputSchema a bridge declares for a tool
+// into one MCP accepts, which needs an object at the root. Object schemas
+// and schemas without a type are kept as objects. Any other schema is
+// wrapped in a required "value" property, the way the GABP client wraps
+// tool results that are not objects, so the results still match it.
+func mirroredOutputSchema(schema map[string]interface{}) map[string]interface{} {
+	if len(schema) == 0 {
+		return nil
+	}
+	types := schemaStrings(schema["type"])
+	if len(types) == 0 || containsString(types, "object") {
+		object := make(map[string]interface{}, len(schema)+1)
+		for key, value := range schema {
+			object[key] = value
+		}
+		object["type"] = "object"
+		return object
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"value": schema},
+		"required":   []interface{}{"value"},
+	}
+}
+
+// conformStructuredContent checks a mirrored tool's result against the
+// tool's outputSchema, so schema-aware clients can rely on structuredContent.
+// A result that does not match is logged and answered with an error result
+// that names the mismatch and still carries the result as text.
+func (s *Server) conformStructuredContent(gameID, toolName string, schema map[string]interface{}, result *ToolResult) *ToolResult {
+	if len(schema) == 0 || result.StructuredContent == nil {
+		return result
+	}
+	var errs []argumentError
+	validateValue(schema, result.StructuredContent, "", &errs)
+	if len(errs) == 0 {
+		return result
+	}
+	s.gabpLog.Warnw("GABP tool result does not match its outputSchema", "gameId", gameID, "tool", toolName, "errors", errs)
+	message := fmt.Sprintf("The result of tool '%s' from game '%s' does not match its outputSchema: %s. The bridge needs fixing; the result follows as text.", toolName, gameID, describeArgumentErrors(errs))
+	return &ToolResult{
+		Content: append([]Content{{Type: "text", Text: message}}, result.Content...),
+		StructuredContent: map[string]interface{}{
+			"tool": toolName,
+			"error": map[string]interface{}{
+				"kind":    gabp.ErrorKindInternal,
+				"message": message,
+				"fields":  errs,
+			},
+		},
+		IsError: true,
+	}
+}
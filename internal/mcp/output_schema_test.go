@@ -0,0 +1,160 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestMirroredOutputSchema(t *testing.T) {
+	stringSchema := map[string]interface{}{"type": "string"}
+	tests := []struct {
+		name   string
+		schema map[string]interface{}
+		want   map[string]interface{}
+	}{
+		{"none", nil, nil},
+		{"object", map[string]interface{}{"type": "object", "required": []interface{}{"count"}}, map[string]interface{}{"type": "object", "required": []interface{}{"count"}}},
+		{"untyped", map[string]interface{}{"description": "stats"}, map[string]interface{}{"type": "object", "description": "stats"}},
+		{"string", stringSchema, map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"value": stringSchema},
+			"required":   []interface{}{"value"},
+		}},
+	}
+	for _, tt := range tests {
+		if got := mirroredOutputSchema(tt.schema); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: mirroredOutputSchema() = %#v, want %#v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// serveTestGabpOutputSchemaSession advertises tools with output schemas and
+// answers calls to them, one with a result that breaks its schema.
+func serveTestGabpOutputSchemaSession(listener net.Listener, expectedToken string, done chan<- error) {
+	conn, err := listener.Accept()
+	if err != nil {
+		done <- err
+		return
+	}
+	defer conn.Close()
+
+	reader := util.NewLSPFrameReader(conn)
+	writer := util.NewLSPFrameWriter(conn)
+	countSchema := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"count": map[string]interface{}{"type": "integer"}},
+		"required":   []interface{}{"count"},
+	}
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+			done <- err
+			return
+		}
+		data, err := reader.ReadMessage()
+		if err != nil {
+			var netErr net.Error
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || (errors.As(err, &netErr) && netErr.Timeout()) {
+				done <- nil
+				return
+			}
+			done <- err
+			return
+		}
+
+		var request util.GABPMessage
+		if err := json.Unmarshal(data, &request); err != nil {
+			done <- err
+			return
+		}
+
+		switch request.Method {
+		case "session/hello":
+			params, _ := request.Params.(map[string]interface{})
+			if token, _ := params["token"].(string); token != expectedToken {
+				done <- fmt.Errorf("unexpected handshake token: %q", token)
+				return
+			}
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, gabp.SessionWelcomeResult{
+				AgentID:       "adventure",
+				App:           gabp.AppInfo{Name: "ExampleGameBridge", Version: "0.1.0"},
+				Capabilities:  gabp.Capabilities{Methods: []string{"tools/list", "tools/call"}},
+				SchemaVersion: "1.0",
+			}))
+		case "tools/list":
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"tools": []map[string]interface{}{
+				{"name": "stats/get", "inputSchema": map[string]interface{}{"type": "object"}, "outputSchema": countSchema},
+				{"name": "stats/broken", "inputSchema": map[string]interface{}{"type": "object"}, "outputSchema": countSchema},
+				{"name": "player/name", "inputSchema": map[string]interface{}{"type": "object"}, "outputSchema": map[string]interface{}{"type": "string"}},
+			}}))
+		case "tools/call":
+			params, _ := request.Params.(map[string]interface{})
+			var result interface{}
+			switch params["name"] {
+			case "stats/get":
+				result = map[string]interface{}{"count": 3}
+			case "stats/broken":
+				result = map[string]interface{}{"count": "three"}
+			default:
+				result = "ada"
+			}
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, result))
+		default:
+			err = fmt.Errorf("unexpected method: %s", request.Method)
+		}
+		if err != nil {
+			done <- err
+			return
+		}
+	}
+}
+
+func TestMirroredToolsKeepStructuredContentMatchingOutputSchema(t *testing.T) {
+	server, configDir := newGamesTestServer(t, roamingGamesConfig())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	writeBridgeJSONForTest(t, configDir, "adventure", listener.Addr().(*net.TCPAddr).Port, "schema-token")
+
+	serverDone := make(chan error, 1)
+	go serveTestGabpOutputSchemaSession(listener, "schema-token", serverDone)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_connect failed: %#v", result)
+	}
+
+	nameTool := server.safeMCPToolNameForGABPTool("adventure", "player/name")
+	server.mu.RLock()
+	handler := server.tools[nameTool]
+	server.mu.RUnlock()
+	if handler == nil || handler.Tool.OutputSchema["type"] != "object" {
+		t.Fatalf("expected %s to get an object outputSchema, got %#v", nameTool, handler)
+	}
+
+	result := callToolForTest(t, server, server.safeMCPToolNameForGABPTool("adventure", "stats/get"), nil)
+	if result.StructuredContent["count"] != float64(3) {
+		t.Fatalf("expected structured content matching the schema, got %#v", result)
+	}
+	result = callToolForTest(t, server, nameTool, nil)
+	if result.StructuredContent["value"] != "ada" {
+		t.Fatalf("expected the string result wrapped in value, got %#v", result)
+	}
+	result = callToolForTest(t, server, server.safeMCPToolNameForGABPTool("adventure", "stats/broken"), nil)
+	if !result.IsError || len(result.Content) != 2 || !strings.Contains(result.Content[0].Text, "count must be integer") || result.Content[1].Text != `{"count":"three"}` {
+		t.Fatalf("expected a result breaking its schema to be an error naming the mismatch, got %#v", result)
+	}
+	if _, hasCount := result.StructuredContent["count"]; hasCount {
+		t.Fatalf("expected no structuredContent claiming to match the schema, got %#v", result.StructuredContent)
+	}
+}
@@ -0,0 +1,76 @@
+package mcp
+
+import "fmt"
+
+// toolPolicyNameLocked returns how tool policies see a registered tool: the
+// game and GABP name of a mirrored tool, or an empty game and the dotted name
+// of one of GABS's own tools. Callers hold s.mu.
+func (s *Server) toolPolicyNameLocked(handler *ToolHandler) (string, string) {
+	for gameID, session := range s.sessions {
+		for _, toolName := range session.toolNames() {
+			if s.tools[toolName] == handler {
+				return gameID, gabpToolNameFromTool(gameID, handler.Tool)
+			}
+		}
+	}
+	return "", toolCanonicalName(handler.Tool)
+}
+
+// toolAllowed reports whether role may call the tool gameID and name describe,
+// as returned by toolPolicyNameLocked, from session, which may be nil. Both
+// the tool policy and the session's tool profile must allow it.
+func (s *Server) toolAllowed(role string, session *mcpSession, gameID, name string) bool {
+	return s.gamesConfig.ToolAllowed(role, gameID, name) && s.gamesConfig.ToolInProfile(session.toolProfile(), gameID, name)
+}
+
+// enforceToolPolicy returns an error result when the tool policy or the tool
+// profile of session keeps role from calling the tool, and nil otherwise.
+func (s *Server) enforceToolPolicy(role string, session *mcpSession, gameID, name string) *ToolResult {
+	if !s.gamesConfig.ToolAllowed(role, gameID, name) {
+		return s.toolPolicyDenied(role, gameID, name)
+	}
+	if profile := session.toolProfile(); !s.gamesConfig.ToolInProfile(profile, gameID, name) {
+		return s.toolProfileDenied(session, profile, gameID, name)
+	}
+	return nil
+}
+
+func (s *Server) toolPolicyDenied(role, gameID, name string) *ToolResult {
+	s.log.Warnw("tool call denied by policy", "gameId", gameID, "tool", name, "role", role)
+
+	structured := map[string]interface{}{
+		"tool":   name,
+		"role":   role,
+		"denied": "toolPolicy",
+	}
+	text := fmt.Sprintf("Tool '%s' is not allowed for role '%s' by the GABS tool policy. Do not retry; ask the operator if you need it.", name, role)
+	if gameID != "" {
+		structured["gameId"] = gameID
+		text = fmt.Sprintf("Tool '%s' of game '%s' is not allowed for role '%s' by the GABS tool policy. Do not retry; ask the operator if you need it.", name, gameID, role)
+	}
+	return &ToolResult{
+		Content:           []Content{{Type: "text", Text: text}},
+		StructuredContent: structured,
+		IsError:           true,
+	}
+}
+
+func (s *Server) toolProfileDenied(session *mcpSession, profile, gameID, name string) *ToolResult {
+	s.log.Warnw("tool call denied by tool profile", append([]interface{}{"gameId", gameID, "tool", name, "toolProfile", profile}, sessionLogFields(session)...)...)
+
+	structured := map[string]interface{}{
+		"tool":        name,
+		"toolProfile": profile,
+		"denied":      "toolProfile",
+	}
+	text := fmt.Sprintf("Tool '%s' is not in tool profile '%s' of this session. Do not retry; ask the operator if you need it.", name, profile)
+	if gameID != "" {
+		structured["gameId"] = gameID
+		text = fmt.Sprintf("Tool '%s' of game '%s' is not in tool profile '%s' of this session. Do not retry; ask the operator if you need it.", name, gameID, profile)
+	}
+	return &ToolResult{
+		Content:           []Content{{Type: "text", Text: text}},
+		StructuredContent: structured,
+		IsError:           true,
+	}
+}
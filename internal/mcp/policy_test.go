@@ -0,0 +1,189 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func newToolPolicyTestServer(t *testing.T) *Server {
+	t.Helper()
+	factory := sleepingGameForTest("factory", "Factory")
+	factory.ToolPolicy = []config.ToolPolicyConfig{{Deny: []string{"world.*"}}}
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{"factory": factory},
+		APIKeys: []config.APIKeyConfig{
+			{Key: "admin-key", Role: config.AccessRoleAdmin},
+			{Key: "viewer-key", Role: "readonly"},
+		},
+		ToolPolicy: []config.ToolPolicyConfig{
+			{Roles: []string{"readonly"}, Deny: []string{"games.kill", "games.stop"}},
+		},
+	})
+
+	handler := func(args map[string]interface{}) (*ToolResult, error) {
+		return &ToolResult{Content: []Content{{Type: "text", Text: "done"}}}, nil
+	}
+	for _, name := range []string{"factory.inventory.get", "factory.world.set_block"} {
+		server.RegisterGameTool("factory", Tool{Name: name, InputSchema: map[string]interface{}{"type": "object"}}, handler, nil)
+	}
+	return server
+}
+
+func TestToolPolicyFiltersToolsListPerRole(t *testing.T) {
+	server := newToolPolicyTestServer(t)
+
+	listTools := func(key string) map[string]bool {
+		_, response := postMCPForTest(t, server, key, Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "tools/list"})
+		var result ToolsListResult
+		if err := decodeResult(response.Result, &result); err != nil {
+			t.Fatalf("decode tools/list: %v", err)
+		}
+		names := make(map[string]bool)
+		for _, tool := range result.Tools {
+			names[toolCanonicalName(tool)] = true
+		}
+		return names
+	}
+
+	if viewer := listTools("viewer-key"); viewer["games.kill"] || viewer["games.stop"] || !viewer["games.status"] {
+		t.Fatalf("expected readonly tools/list without kill and stop, got %v", viewer)
+	}
+	if admin := listTools("admin-key"); !admin["games.kill"] {
+		t.Fatalf("expected admin tools/list to keep games.kill, got %v", admin)
+	}
+
+	_, response := postMCPForTest(t, server, "viewer-key", Message{JSONRPC: "2.0", ID: json.RawMessage(`2`), Method: "tools/call", Params: map[string]interface{}{
+		"name":      "games_kill",
+		"arguments": map[string]interface{}{"gameId": "factory"},
+	}})
+	var result ToolResult
+	if err := decodeResult(response.Result, &result); err != nil || !result.IsError || result.StructuredContent["denied"] != "toolPolicy" {
+		t.Fatalf("expected games_kill to be denied for readonly, got %#v", response)
+	}
+}
+
+func TestGameToolPolicyAppliesToEveryCallPath(t *testing.T) {
+	server := newToolPolicyTestServer(t)
+
+	if result := callToolForTest(t, server, "factory.world.set_block", nil); !result.IsError || result.StructuredContent["denied"] != "toolPolicy" {
+		t.Fatalf("expected the world tool to be denied, got %#v", result)
+	}
+	viaCallTool := callToolForTest(t, server, "games_call_tool", map[string]interface{}{"gameId": "factory", "tool": "world.set_block"})
+	if !viaCallTool.IsError || viaCallTool.StructuredContent["denied"] != "toolPolicy" {
+		t.Fatalf("expected games_call_tool to apply the game's policy, got %#v", viaCallTool)
+	}
+	if result := callToolForTest(t, server, "factory.inventory.get", nil); result.IsError {
+		t.Fatalf("expected the inventory tool to be allowed, got %#v", result)
+	}
+
+	names := callToolForTest(t, server, "games_tool_names", map[string]interface{}{"gameId": "factory"})
+	if names.IsError {
+		t.Fatalf("games_tool_names failed: %#v", names)
+	}
+	listed, _ := json.Marshal(names.StructuredContent)
+	var structured struct {
+		Tools []interface{} `json:"tools"`
+		Total int           `json:"total"`
+	}
+	if err := json.Unmarshal(listed, &structured); err != nil || structured.Total != 1 {
+		t.Fatalf("expected games_tool_names to list only the allowed tool, got %s", listed)
+	}
+}
+
+func newToolProfileTestServer(t *testing.T) *Server {
+	t.Helper()
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{"factory": sleepingGameForTest("factory", "Factory")},
+		APIKeys: []config.APIKeyConfig{
+			{Key: "admin-key", Role: config.AccessRoleAdmin},
+			{Key: "bot-key", Role: config.AccessRoleAdmin, ToolProfile: "readonly"},
+		},
+		ToolProfiles: map[string]config.ToolProfile{
+			"readonly": {Allow: []string{"games.list", "games.status"}},
+		},
+	})
+	return server
+}
+
+func TestToolProfileOfAPIKeyLimitsItsSessions(t *testing.T) {
+	server := newToolProfileTestServer(t)
+
+	_, response := postMCPForTest(t, server, "bot-key", Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "tools/list"})
+	var list ToolsListResult
+	if err := decodeResult(response.Result, &list); err != nil {
+		t.Fatalf("decode tools/list: %v", err)
+	}
+	if len(list.Tools) != 2 {
+		t.Fatalf("expected only the two readonly tools, got %d tools", len(list.Tools))
+	}
+
+	_, response = postMCPForTest(t, server, "bot-key", Message{JSONRPC: "2.0", ID: json.RawMessage(`2`), Method: "tools/call", Params: map[string]interface{}{
+		"name":      "games_kill",
+		"arguments": map[string]interface{}{"gameId": "factory"},
+	}})
+	var result ToolResult
+	if err := decodeResult(response.Result, &result); err != nil || !result.IsError || result.StructuredContent["denied"] != "toolProfile" {
+		t.Fatalf("expected games_kill to be denied by the tool profile, got %#v", response)
+	}
+}
+
+func TestSetSessionProfileLimitsOneSession(t *testing.T) {
+	server := newToolProfileTestServer(t)
+
+	post := func(sessionID, body string) (string, map[string]interface{}) {
+		request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+		request.Header.Set("Authorization", "Bearer admin-key")
+		if sessionID != "" {
+			request.Header.Set(mcpSessionHeader, sessionID)
+		}
+		recorder := httptest.NewRecorder()
+		server.handleMCPHTTPRequest(recorder, request)
+		var response map[string]interface{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("invalid response %q: %v", recorder.Body.String(), err)
+		}
+		return recorder.Header().Get(mcpSessionHeader), response
+	}
+
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","clientInfo":{"name":"agent"}}}`
+	limited, _ := post("", initialize)
+	other, _ := post("", initialize)
+	for _, sessionID := range []string{limited, other} {
+		request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0","method":"notifications/initialized"}`))
+		request.Header.Set("Authorization", "Bearer admin-key")
+		request.Header.Set(mcpSessionHeader, sessionID)
+		server.handleMCPHTTPRequest(httptest.NewRecorder(), request)
+	}
+
+	result := callToolForTest(t, server, "server.set_session_profile", map[string]interface{}{"sessionId": limited, "profile": "readonly"})
+	if result.IsError || result.StructuredContent["toolProfile"] != "readonly" {
+		t.Fatalf("expected the profile to be set, got %#v", result)
+	}
+	if result := callToolForTest(t, server, "server.set_session_profile", map[string]interface{}{"sessionId": limited, "profile": "unknown"}); !result.IsError {
+		t.Fatalf("expected an unknown profile to be refused, got %#v", result)
+	}
+
+	killCall := `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"games.kill","arguments":{"gameId":"factory"}}}`
+	_, response := post(limited, killCall)
+	structured, _ := response["result"].(map[string]interface{})["structuredContent"].(map[string]interface{})
+	if structured["denied"] != "toolProfile" {
+		t.Fatalf("expected the limited session to be denied games.kill, got %#v", response)
+	}
+	_, response = post(other, killCall)
+	structured, _ = response["result"].(map[string]interface{})["structuredContent"].(map[string]interface{})
+	if structured["denied"] != nil {
+		t.Fatalf("expected the other session to keep games.kill, got %#v", response)
+	}
+
+	if result := callToolForTest(t, server, "server.set_session_profile", map[string]interface{}{"sessionId": limited}); result.IsError {
+		t.Fatalf("expected clearing the profile to work, got %#v", result)
+	}
+	if profile := server.clientSessions[limited].toolProfile(); profile != "" {
+		t.Fatalf("expected the profile to be cleared, got %q", profile)
+	}
+}
@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+)
+
+// bridgePortTakenError is returned by startGame when another process started
+// listening on the bridge port between choosing it and launching the game, so
+// the game's bridge could not bind it.
+type bridgePortTakenError struct {
+	gameID    string
+	port      int
+	fixedPort bool
+	owner     process.PortListener
+	ownerSeen bool
+}
+
+func (e *bridgePortTakenError) Error() string {
+	return fmt.Sprintf("GABP port %d for game %q is already used by %s", e.port, e.gameID, e.ownerDescription())
+}
+
+func (e *bridgePortTakenError) ownerDescription() string {
+	if !e.ownerSeen {
+		return "another process"
+	}
+	if e.owner.PID == os.Getpid() {
+		return "this GABS server"
+	}
+	return e.owner.String()
+}
+
+// checkBridgePortFree re-verifies the bridge port right before launch and
+// names the process listening on it where the platform allows.
+func checkBridgePortFree(game config.GameConfig, port int) error {
+	if config.BridgePortAvailable(port) {
+		return nil
+	}
+	owner, seen := process.FindPortListener(port)
+	return &bridgePortTakenError{
+		gameID:    game.ID,
+		port:      port,
+		fixedPort: game.FixedPort != 0 && game.FixedPort == port,
+		owner:     owner,
+		ownerSeen: seen,
+	}
+}
+
+func portListenerStructured(owner process.PortListener) map[string]interface{} {
+	item := map[string]interface{}{"pid": owner.PID}
+	if owner.Name != "" {
+		item["name"] = owner.Name
+	}
+	return item
+}
+
+func bridgePortTakenResult(game config.GameConfig, portErr *bridgePortTakenError) *ToolResult {
+	gameArg := map[string]interface{}{"gameId": game.ID}
+	text := fmt.Sprintf("Could not start %s: GABP port %d is already used by %s, so the game's bridge could not listen on it. The game was not launched.", game.ID, portErr.port, portErr.ownerDescription())
+	nextActions := []map[string]interface{}{
+		mcpNextAction("games_status", gameArg, "Check whether another copy of the game already owns the port."),
+	}
+	if portErr.fixedPort {
+		text += fmt.Sprintf(" Port %d is the fixedPort of %s; stop the other process or change fixedPort.", portErr.port, game.ID)
+	} else {
+		text += " Stop the other process, or start again with resetEndpoint to pick another port."
+		nextActions = append(nextActions, mcpNextAction("games_start", map[string]interface{}{"gameId": game.ID, "resetEndpoint": true}, "Pick another GABP port and start again."))
+	}
+	structured := map[string]interface{}{
+		"gameId":      game.ID,
+		"status":      "bridge_port_in_use",
+		"port":        portErr.port,
+		"fixedPort":   portErr.fixedPort,
+		"nextActions": nextActions,
+	}
+	if portErr.ownerSeen {
+		structured["listener"] = portListenerStructured(portErr.owner)
+	}
+	return &ToolResult{
+		Content:           []Content{{Type: "text", Text: text}},
+		StructuredContent: structured,
+		IsError:           true,
+	}
+}
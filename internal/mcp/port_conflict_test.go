@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+)
+
+func TestCheckBridgePortFreeNamesListener(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve port: %v", err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	restore := process.SetFindPortListenerForTesting(func(p int) (process.PortListener, bool) {
+		return process.PortListener{PID: 4242, Name: "OtherGame.exe"}, p == port
+	})
+	defer restore()
+
+	game := config.GameConfig{ID: "taken", FixedPort: port}
+	err = checkBridgePortFree(game, port)
+	var portErr *bridgePortTakenError
+	if !errors.As(err, &portErr) {
+		t.Fatalf("expected bridgePortTakenError, got %T %v", err, err)
+	}
+	if !portErr.fixedPort || !strings.Contains(err.Error(), "OtherGame.exe (pid 4242)") {
+		t.Fatalf("unexpected error details: %#v (%v)", portErr, err)
+	}
+
+	result := bridgePortTakenResult(game, portErr)
+	structured := result.StructuredContent
+	if !result.IsError || structured["status"] != "bridge_port_in_use" || structured["listener"] == nil {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+	if !strings.Contains(result.Content[0].Text, "fixedPort") {
+		t.Fatalf("expected fixedPort hint, got %q", result.Content[0].Text)
+	}
+}
+
+func TestCheckBridgePortFreeAcceptsFreePort(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve port: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	if err := checkBridgePortFree(config.GameConfig{ID: "free"}, port); err != nil {
+		t.Fatalf("expected free port, got %v", err)
+	}
+}
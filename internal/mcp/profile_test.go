@@ -0,0 +1,51 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func TestGamesStartUsesLaunchProfileAndReportsIt(t *testing.T) {
+	requireSleepForTest(t)
+
+	game := config.GameConfig{
+		ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/bin/sh",
+		Args: []string{"-c", "echo world vanilla; exec sleep 30"},
+		Env:  map[string]string{"WORLD": "main"},
+		Profiles: map[string]config.LaunchProfile{
+			"hardcore": {Args: []string{"-c", "echo world $WORLD; exec sleep 30"}, Env: map[string]string{"WORLD": "hardcore"}},
+		},
+	}
+	server, _ := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{"factory": game}})
+
+	unknown := callToolForTest(t, server, "games_start", map[string]interface{}{"gameId": "factory", "profile": "creative"})
+	if !unknown.IsError || !strings.Contains(unknown.Content[0].Text, "must be one of: hardcore") {
+		t.Fatalf("expected an unknown profile to be refused, got %#v", unknown)
+	}
+
+	started := callToolForTest(t, server, "games_start", map[string]interface{}{"gameId": "factory", "profile": "hardcore", "timeout": 1})
+	t.Cleanup(func() { server.stopGame(game, true) })
+	if started.IsError || started.StructuredContent["profile"] != "hardcore" {
+		t.Fatalf("expected the game to start with the hardcore profile, got %#v", started)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		logs := callToolForTest(t, server, "games_logs", map[string]interface{}{"gameId": "factory"})
+		if strings.Contains(logs.Content[0].Text, "world hardcore") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the profile's args and env to be used, got %q", logs.Content[0].Text)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	status := callToolForTest(t, server, "games_status", map[string]interface{}{"gameId": "factory"})
+	if status.StructuredContent["profile"] != "hardcore" || !strings.Contains(status.Content[0].Text, "Launch profile: hardcore") {
+		t.Fatalf("expected the active profile in the status, got %#v", status)
+	}
+}
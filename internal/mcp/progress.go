@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"sync"
+)
+
+// gabpProgressChannel is the GABP event channel bridges publish progress of
+// long-running tool calls on. Each event is forwarded to the tools/call
+// requests on that game that asked for progress.
+const gabpProgressChannel = "tools/progress"
+
+// mcpProgress sends notifications/progress for one tools/call request that
+// carried a progressToken. A nil *mcpProgress ignores every report, so code
+// shared with calls that did not ask for progress needs no checks.
+type mcpProgress struct {
+	server  *Server
+	token   interface{}
+	session *mcpSession
+	mu      sync.Mutex
+	last    float64
+}
+
+// newToolProgress returns the progress reporter of a tools/call request, or
+// nil when the request has no progress token or no session to send it to.
+func (s *Server) newToolProgress(meta *RequestMeta, session *mcpSession) *mcpProgress {
+	if meta == nil || meta.ProgressToken == nil || session == nil {
+		return nil
+	}
+	return &mcpProgress{server: s, token: meta.ProgressToken, session: session}
+}
+
+// step reports that the call moved on to the phase described by message.
+func (p *mcpProgress) step(message string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sendLocked(p.last+1, 0, message)
+}
+
+// forward reports progress a GABP bridge published. MCP requires progress to
+// increase, so a value at or below the last one sent is dropped.
+func (p *mcpProgress) forward(progress, total float64, message string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if progress <= p.last {
+		return
+	}
+	p.sendLocked(progress, total, message)
+}
+
+func (p *mcpProgress) sendLocked(progress, total float64, message string) {
+	p.last = progress
+	params := map[string]interface{}{
+		"progressToken": p.token,
+		"progress":      progress,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	p.server.notifyClients("notifications/progress", params, func(client *clientConn) bool {
+		return client.session == p.session
+	})
+}
+
+// watchGameProgress forwards the game's GABP progress events to progress
+// until the returned function is called.
+func (s *Server) watchGameProgress(gameID string, progress *mcpProgress) func() {
+	if progress == nil {
+		return func() {}
+	}
+	s.mu.Lock()
+	if s.gameProgress == nil {
+		s.gameProgress = make(map[string][]*mcpProgress)
+	}
+	s.gameProgress[gameID] = append(s.gameProgress[gameID], progress)
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		watchers := s.gameProgress[gameID]
+		for i, watcher := range watchers {
+			if watcher == progress {
+				watchers = append(watchers[:i:i], watchers[i+1:]...)
+				break
+			}
+		}
+		if len(watchers) == 0 {
+			delete(s.gameProgress, gameID)
+		} else {
+			s.gameProgress[gameID] = watchers
+		}
+	}
+}
+
+// forwardGameProgress passes a tools/progress event payload of the form
+// {"progress": 40, "total": 100, "message": "..."} to every call on the game
+// that is watching for progress.
+func (s *Server) forwardGameProgress(gameID string, payload interface{}) {
+	event, _ := payload.(map[string]interface{})
+	progress, ok := event["progress"].(float64)
+	if !ok {
+		return
+	}
+	total, _ := event["total"].(float64)
+	message, _ := event["message"].(string)
+
+	s.mu.RLock()
+	watchers := append([]*mcpProgress(nil), s.gameProgress[gameID]...)
+	s.mu.RUnlock()
+	for _, watcher := range watchers {
+		watcher.forward(progress, total, message)
+	}
+}
@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func drainProgressForTest(client *clientConn) []map[string]interface{} {
+	var updates []map[string]interface{}
+	for len(client.outbox) > 0 {
+		msg := <-client.outbox
+		if msg.Method == "notifications/progress" {
+			updates = append(updates, msg.Params.(map[string]interface{}))
+		}
+	}
+	return updates
+}
+
+func TestGamesStartReportsProgressToTheCallingSession(t *testing.T) {
+	requireSleepForTest(t)
+
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{"factory": sleepingGameForTest("factory", "Factory")},
+	})
+	session := &mcpSession{}
+	client := server.addClient(clientTransportStdio, session)
+	other := server.addClient(clientTransportStdio, &mcpSession{})
+
+	raw := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"games_start","arguments":{"gameId":"factory","timeout":1},"_meta":{"progressToken":"start-1"}}}`
+	response, err := server.handleRawMessage([]byte(raw), session, config.AccessRoleLocal)
+	t.Cleanup(func() { server.stopGame(sleepingGameForTest("factory", "Factory"), true) })
+	if err != nil || response.(*Message).Error != nil {
+		t.Fatalf("games_start failed: %v %#v", err, response)
+	}
+
+	updates := drainProgressForTest(client)
+	if len(updates) < 2 {
+		t.Fatalf("expected launch and bridge phases, got %#v", updates)
+	}
+	if updates[0]["progressToken"] != "start-1" || updates[0]["message"] != "Launching factory" {
+		t.Fatalf("expected the first update to report the launch, got %#v", updates[0])
+	}
+	for i := 1; i < len(updates); i++ {
+		if updates[i]["progress"].(float64) <= updates[i-1]["progress"].(float64) {
+			t.Fatalf("expected progress to increase, got %#v", updates)
+		}
+	}
+	if len(other.outbox) != 0 {
+		t.Fatalf("expected progress only on the calling session, got %d notifications elsewhere", len(other.outbox))
+	}
+
+	// Without a token the same call stays silent.
+	server.stopGame(sleepingGameForTest("factory", "Factory"), true)
+	raw = `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"games_start","arguments":{"gameId":"factory","timeout":1}}}`
+	if _, err := server.handleRawMessage([]byte(raw), session, config.AccessRoleLocal); err != nil {
+		t.Fatalf("games_start failed: %v", err)
+	}
+	if updates := drainProgressForTest(client); len(updates) != 0 {
+		t.Fatalf("expected no progress without a token, got %#v", updates)
+	}
+}
+
+func TestGABPProgressEventsAreForwardedWhileWatching(t *testing.T) {
+	server, _ := newGamesTestServer(t, &config.GamesConfig{})
+	session := &mcpSession{}
+	client := server.addClient(clientTransportStdio, session)
+	progress := server.newToolProgress(&RequestMeta{ProgressToken: 7.0}, session)
+
+	stopWatching := server.watchGameProgress("factory", progress)
+	server.forwardGameProgress("factory", map[string]interface{}{"progress": 40.0, "total": 100.0, "message": "Loading save"})
+	server.forwardGameProgress("factory", map[string]interface{}{"progress": 30.0})
+	server.forwardGameProgress("adventure", map[string]interface{}{"progress": 90.0})
+	server.forwardGameProgress("factory", map[string]interface{}{"progress": 60.0, "total": 100.0})
+	stopWatching()
+	server.forwardGameProgress("factory", map[string]interface{}{"progress": 80.0})
+
+	updates := drainProgressForTest(client)
+	if len(updates) != 2 {
+		t.Fatalf("expected the increasing updates of the watched game only, got %#v", updates)
+	}
+	if updates[0]["progress"] != 40.0 || updates[0]["total"] != 100.0 || updates[0]["message"] != "Loading save" || updates[0]["progressToken"] != 7.0 {
+		t.Fatalf("unexpected first update: %#v", updates[0])
+	}
+	if updates[1]["progress"] != 60.0 {
+		t.Fatalf("unexpected second update: %#v", updates[1])
+	}
+	if len(server.gameProgress) != 0 {
+		t.Fatalf("expected no watchers left, got %#v", server.gameProgress)
+	}
+}
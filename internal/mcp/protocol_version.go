@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// supportedProtocolVersions lists the MCP revisions GABS speaks, oldest
+// first. Revisions are dates, so they order as strings.
+var supportedProtocolVersions = []string{"2024-11-05", "2025-03-26", "2025-06-18"}
+
+// outputSchemaProtocolVersion is the first revision with outputSchema on
+// tools.
+const outputSchemaProtocolVersion = "2025-06-18"
+
+// mcpProtocolVersionHeader carries the negotiated revision on HTTP requests
+// after initialize.
+const mcpProtocolVersionHeader = "Mcp-Protocol-Version"
+
+// latestProtocolVersion returns the newest revision GABS speaks.
+func latestProtocolVersion() string {
+	return supportedProtocolVersions[len(supportedProtocolVersions)-1]
+}
+
+// negotiateProtocolVersion picks the revision for a client that asked for
+// requested: the newest supported one that is not newer than requested, or
+// the newest of all when the client asked for something older than GABS
+// speaks, as MCP asks servers to do.
+func negotiateProtocolVersion(requested string) string {
+	for i := len(supportedProtocolVersions) - 1; i >= 0; i-- {
+		if supportedProtocolVersions[i] <= requested {
+			return supportedProtocolVersions[i]
+		}
+	}
+	return latestProtocolVersion()
+}
+
+// isSupportedProtocolVersion reports whether GABS speaks version.
+func isSupportedProtocolVersion(version string) bool {
+	for _, supported := range supportedProtocolVersions {
+		if supported == version {
+			return true
+		}
+	}
+	return false
+}
+
+// protocolVersion returns the revision negotiated at initialize, or "" for
+// sessions that never initialized.
+func (m *mcpSession) protocolVersion() string {
+	if m == nil {
+		return ""
+	}
+	m.infoMu.Lock()
+	defer m.infoMu.Unlock()
+	return m.info.ProtocolVersion
+}
+
+// supportsOutputSchema reports whether the session may receive outputSchema
+// on tools. Sessions that never negotiated a revision get it, as they always
+// did.
+func (m *mcpSession) supportsOutputSchema() bool {
+	version := m.protocolVersion()
+	return version == "" || version >= outputSchemaProtocolVersion
+}
+
+// adaptToolCallResponse fits a tools/call response to the session's revision.
+// Results keep their structuredContent, which clients of older revisions
+// ignore, so it is also added as a text content block unless the result
+// already carries text.
+func adaptToolCallResponse(response *Message, session *mcpSession) *Message {
+	if response == nil || session.supportsOutputSchema() {
+		return response
+	}
+	var result ToolResult
+	switch value := response.Result.(type) {
+	case *ToolResult:
+		if value == nil {
+			return response
+		}
+		result = *value
+	case ToolResult:
+		result = value
+	default:
+		return response
+	}
+	if result.StructuredContent == nil {
+		return response
+	}
+
+	hasText := false
+	for _, content := range result.Content {
+		if content.Type == "text" {
+			hasText = true
+			break
+		}
+	}
+	if hasText {
+		return response
+	}
+	data, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		return response
+	}
+	result.Content = append(append([]Content(nil), result.Content...), Content{Type: "text", Text: string(data)})
+	adapted := *response
+	adapted.Result = &result
+	return &adapted
+}
+
+// checkProtocolVersionHeader answers HTTP requests whose
+// Mcp-Protocol-Version header names a revision GABS does not speak with 400
+// and reports whether it did. Requests without the header are accepted, as
+// clients of 2024-11-05 do not send it.
+func checkProtocolVersionHeader(w http.ResponseWriter, r *http.Request) bool {
+	version := r.Header.Get(mcpProtocolVersionHeader)
+	if version == "" || isSupportedProtocolVersion(version) {
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	data, _ := json.Marshal(map[string]interface{}{
+		"error":             "Unsupported " + mcpProtocolVersionHeader + ": " + version,
+		"supportedVersions": supportedProtocolVersions,
+	})
+	w.Write(data)
+	return true
+}
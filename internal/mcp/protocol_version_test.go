@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestNegotiateProtocolVersion(t *testing.T) {
+	tests := []struct {
+		requested string
+		want      string
+	}{
+		{"2024-11-05", "2024-11-05"},
+		{"2025-03-26", "2025-03-26"},
+		{"2025-06-18", "2025-06-18"},
+		{"2025-05-01", "2025-03-26"},
+		{"2099-01-01", "2025-06-18"},
+		{"2024-01-01", "2025-06-18"},
+		{"", "2025-06-18"},
+	}
+	for _, tt := range tests {
+		if got := negotiateProtocolVersion(tt.requested); got != tt.want {
+			t.Errorf("negotiateProtocolVersion(%q) = %q, want %q", tt.requested, got, tt.want)
+		}
+	}
+}
+
+func TestOutputSchemaFollowsNegotiatedVersion(t *testing.T) {
+	for _, tt := range []struct {
+		version      string
+		outputSchema bool
+	}{
+		{"2024-11-05", false},
+		{"2025-06-18", true},
+	} {
+		t.Run(tt.version, func(t *testing.T) {
+			server := NewServerForTesting(util.NewLogger("error"))
+			server.RegisterTool(Tool{
+				Name:         "test.count",
+				InputSchema:  map[string]interface{}{"type": "object"},
+				OutputSchema: map[string]interface{}{"type": "object", "properties": map[string]interface{}{"count": map[string]interface{}{"type": "integer"}}},
+			}, func(args map[string]interface{}) (*ToolResult, error) {
+				return &ToolResult{StructuredContent: map[string]interface{}{"count": 3}}, nil
+			})
+
+			responses := serveLinesForTest(t, server,
+				strings.Replace(strictInitializeLine, "2025-06-18", tt.version, 1),
+				`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+				`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+				`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"test.count"}}`,
+			)
+			if len(responses) != 3 {
+				t.Fatalf("expected three responses, got %#v", responses)
+			}
+			byID := map[float64]map[string]interface{}{}
+			for _, response := range responses {
+				message := response.(map[string]interface{})
+				byID[message["id"].(float64)] = message["result"].(map[string]interface{})
+			}
+
+			if got := byID[1]["protocolVersion"]; got != tt.version {
+				t.Fatalf("expected protocol version %s, got %v", tt.version, got)
+			}
+			tool := byID[2]["tools"].([]interface{})[0].(map[string]interface{})
+			if _, has := tool["outputSchema"]; has != tt.outputSchema {
+				t.Errorf("expected outputSchema present=%v, got %#v", tt.outputSchema, tool)
+			}
+			result := byID[3]
+			if _, has := result["structuredContent"]; !has {
+				t.Errorf("expected structuredContent for every revision, got %#v", result)
+			}
+			if !tt.outputSchema {
+				content := result["content"].([]interface{})
+				if len(content) != 1 || content[0].(map[string]interface{})["text"] != `{"count":3}` {
+					t.Errorf("expected the structured content also as text, got %#v", content)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPRejectsUnsupportedProtocolVersionHeader(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+
+	post := func(version string) int {
+		request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+		request.Header.Set(mcpProtocolVersionHeader, version)
+		recorder := httptest.NewRecorder()
+		server.handleMCPHTTPRequest(recorder, request)
+		return recorder.Code
+	}
+	if code := post("1999-01-01"); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unsupported protocol version, got %d", code)
+	}
+	if code := post("2025-03-26"); code != http.StatusOK {
+		t.Fatalf("expected a supported protocol version to be accepted, got %d", code)
+	}
+}
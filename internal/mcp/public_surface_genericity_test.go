@@ -18,6 +18,7 @@ func TestPublicSurfacesStayGeneric(t *testing.T) {
 
 	forbiddenTerms := []string{
 		`\b` + "m" + `ods?\b`,
+		`[._]` + "m" + `ods?[._]`,
 		`\b` + "m" + `odifications?\b`,
 		"rim" + "world",
 		"rim" + "bridge",
@@ -113,6 +113,9 @@ func TestGamesConnectCanReattachUsingBridgeConfigWithoutTrackedProcess(t *testin
 	if !strings.Contains(statusText, "connected via GABP") {
 		t.Fatalf("expected connected status after reattach, got: %s", statusText)
 	}
+	if !strings.Contains(statusText, `"bridge":{`) || !strings.Contains(statusText, `"schemaVersion":"1.0"`) || !strings.Contains(statusText, "Bridge: ExampleGameBridge 0.1.0 via ExampleBridgeServer 0.1.0, schema 1.0") {
+		t.Fatalf("expected handshake details in status, got: %s", statusText)
+	}
 
 	toolsMsg := &Message{
 		JSONRPC: "2.0",
@@ -1045,6 +1048,11 @@ func serveTestGabpSession(listener net.Listener, expectedToken string, done chan
 					Resources: []string{},
 				},
 				SchemaVersion: "1.0",
+				ServerInfo: &gabp.ServerInfo{
+					Name:    "ExampleBridgeServer",
+					Version: "0.1.0",
+					Author:  "ExampleStudio",
+				},
 			})
 			if err := writer.WriteJSON(response); err != nil {
 				done <- err
@@ -1543,3 +1551,105 @@ func isExpectedTestConnectionClose(err error) bool {
 		strings.Contains(text, "connection reset by peer") ||
 		strings.Contains(text, "use of closed network connection")
 }
+
+func TestGamesShowIncludesConnectedBridgeInfo(t *testing.T) {
+	server, configDir := newGamesTestServer(t, roamingGamesConfig())
+
+	result := callToolForTest(t, server, "games_show", map[string]interface{}{"gameId": "adventure"})
+	if result.IsError {
+		t.Fatalf("games_show failed: %#v", result)
+	}
+	if _, exists := result.StructuredContent["bridge"]; exists {
+		t.Fatalf("expected no bridge details before connecting, got %#v", result.StructuredContent["bridge"])
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	writeBridgeJSONForTest(t, configDir, "adventure", listener.Addr().(*net.TCPAddr).Port, "show-token")
+
+	serverDone := make(chan error, 1)
+	go serveTestGabpSession(listener, "show-token", serverDone)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_connect failed: %#v", result)
+	}
+
+	result = callToolForTest(t, server, "games_show", map[string]interface{}{"gameId": "adventure"})
+	if result.IsError {
+		t.Fatalf("games_show failed: %#v", result)
+	}
+	bridge, ok := result.StructuredContent["bridge"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected bridge details after connecting, got %#v", result.StructuredContent)
+	}
+	app, _ := bridge["app"].(map[string]interface{})
+	bridgeServer, _ := bridge["server"].(map[string]interface{})
+	capabilities, _ := bridge["capabilities"].(map[string]interface{})
+	if bridge["agentId"] != "adventure" || bridge["schemaVersion"] != "1.0" {
+		t.Fatalf("unexpected bridge identity: %#v", bridge)
+	}
+	if app["name"] != "ExampleGameBridge" || app["version"] != "0.1.0" {
+		t.Fatalf("unexpected bridge app: %#v", app)
+	}
+	if bridgeServer["name"] != "ExampleBridgeServer" || bridgeServer["author"] != "ExampleStudio" {
+		t.Fatalf("unexpected bridge server: %#v", bridgeServer)
+	}
+	if capabilities["methodCount"] != float64(2) || capabilities["attention"] != false {
+		t.Fatalf("unexpected bridge capabilities: %#v", capabilities)
+	}
+	if text := result.Content[0].Text; !strings.Contains(text, "Bridge: ExampleGameBridge 0.1.0 via ExampleBridgeServer 0.1.0, schema 1.0, 2 methods") {
+		t.Fatalf("expected bridge summary in games_show text, got: %s", text)
+	}
+
+	server.CleanupGameResources("adventure")
+	if err := <-serverDone; err != nil {
+		t.Fatalf("GABP session failed: %v", err)
+	}
+}
+
+func TestStoppingGameAbortsPendingGABPConnection(t *testing.T) {
+	server, _ := newGamesTestServer(t, roamingGamesConfig())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	connector := NewAsyncServerGABPConnector(server, 10*time.Millisecond, 50*time.Millisecond)
+	done := make(chan error, 1)
+	go func() {
+		done <- connector.AttemptConnection(server.gameContext("adventure"), "adventure", port, "stop-token")
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		server.mu.RLock()
+		_, pending := server.sessions["adventure"].gabpClient()
+		server.mu.RUnlock()
+		if pending {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("connection attempt never registered a GABP client")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	server.cleanupStoppedGame("adventure", server.gameGeneration("adventure"))
+	select {
+	case err := <-done:
+		if !errors.Is(err, errGameStopped) {
+			t.Fatalf("expected the connection attempt to stop with the game, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("connection attempt kept retrying after the game stopped")
+	}
+
+	if server.gameContext("adventure").Err() != nil {
+		t.Fatal("expected a fresh game context after the stop")
+	}
+}
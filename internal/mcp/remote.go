@@ -0,0 +1,47 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+	"github.com/pardeike/gabs/internal/remote"
+)
+
+// newGameController returns the controller that starts and stops game: one
+// that talks to a 'gabs agent' for Remote games, and a local one otherwise.
+func newGameController(game config.GameConfig) process.ControllerInterface {
+	if game.LaunchMode == "Remote" && game.Remote != nil {
+		return remote.NewController(*game.Remote, nil)
+	}
+	return process.NewController()
+}
+
+// stopUntrackedRemoteGame stops a Remote game this server did not start, for
+// example one started before GABS restarted, through its agent.
+func (s *Server) stopUntrackedRemoteGame(game config.GameConfig, force bool) error {
+	controller := newGameController(game)
+	if err := controller.Configure(launchSpecFromGame(game)); err != nil {
+		return fmt.Errorf("failed to configure remote controller for %s: %w", game.ID, err)
+	}
+	if controller.WaitForExit(0) {
+		return fmt.Errorf("game %s is not running (its agent at %s reports it stopped)", game.ID, game.Remote.URL)
+	}
+
+	var err error
+	if force {
+		err = controller.Kill()
+	} else {
+		err = controller.Stop(stopGracePeriod)
+	}
+	if err != nil {
+		return err
+	}
+	if !controller.WaitForExit(stopGracePeriod) {
+		return &gameStillRunningError{gameID: game.ID, killed: true}
+	}
+
+	s.log.Infow("untracked remote game stopped through its agent", "gameId", game.ID, "agent", game.Remote.URL, "force", force)
+	s.cleanupStoppedGame(game.ID, s.gameGeneration(game.ID))
+	return nil
+}
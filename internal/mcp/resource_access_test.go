@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func newResourceAccessTestServer(t *testing.T) *Server {
+	t.Helper()
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		APIKey: "main-key",
+		APIKeys: []config.APIKeyConfig{
+			{Key: "viewer-key", Role: "readonly"},
+		},
+		ResourceAccess: []config.ResourceAccessRule{
+			{URI: "gab://*/files/*", Deny: []string{"readonly"}},
+		},
+	})
+	server.SetAPIKey("main-key")
+
+	for _, uri := range []string{"gab://factory/state", "gab://factory/files/world.dat"} {
+		uri := uri
+		server.RegisterResource(Resource{URI: uri, Name: uri}, func() ([]Content, error) {
+			return []Content{{Type: "text", Text: "contents of " + uri}}, nil
+		})
+	}
+	return server
+}
+
+func TestResourceAccessRulesFilterListAndRead(t *testing.T) {
+	server := newResourceAccessTestServer(t)
+
+	listURIs := func(key string) map[string]bool {
+		_, response := postMCPForTest(t, server, key, Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "resources/list"})
+		var result ResourcesListResult
+		if err := decodeResult(response.Result, &result); err != nil {
+			t.Fatalf("decode resources/list: %v", err)
+		}
+		uris := make(map[string]bool)
+		for _, resource := range result.Resources {
+			uris[resource.URI] = true
+		}
+		return uris
+	}
+
+	viewer := listURIs("viewer-key")
+	if viewer["gab://factory/files/world.dat"] || !viewer["gab://factory/state"] {
+		t.Fatalf("unexpected readonly resource list: %v", viewer)
+	}
+	admin := listURIs("main-key")
+	if !admin["gab://factory/files/world.dat"] || !admin["gab://factory/state"] {
+		t.Fatalf("unexpected admin resource list: %v", admin)
+	}
+
+	readParams := map[string]interface{}{"uri": "gab://factory/files/world.dat"}
+	_, denied := postMCPForTest(t, server, "viewer-key", Message{JSONRPC: "2.0", ID: json.RawMessage(`2`), Method: "resources/read", Params: readParams})
+	if denied.Error == nil {
+		t.Fatalf("expected readonly key to be denied, got %#v", denied.Result)
+	}
+	_, allowed := postMCPForTest(t, server, "main-key", Message{JSONRPC: "2.0", ID: json.RawMessage(`3`), Method: "resources/read", Params: readParams})
+	if allowed.Error != nil {
+		t.Fatalf("expected admin key to read resource, got %#v", allowed.Error)
+	}
+
+	// stdio clients act as the local user and are not affected by the rule.
+	local := server.HandleMessage(&Message{JSONRPC: "2.0", ID: json.RawMessage(`4`), Method: "resources/read", Params: readParams})
+	if local.Error != nil {
+		t.Fatalf("expected local read to succeed, got %#v", local.Error)
+	}
+}
+
+func TestHTTPRejectsUnknownAPIKey(t *testing.T) {
+	server := newResourceAccessTestServer(t)
+
+	response, _ := postMCPForTest(t, server, "wrong-key", Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "resources/list"})
+	if response.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for unknown key, got %d", response.StatusCode)
+	}
+}
@@ -0,0 +1,196 @@
+package mcp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// preservedGameSurface is the mirrored tool and resource list of a game,
+// kept across a restart so agents do not lose track of names while the GABP
+// bridge comes back.
+type preservedGameSurface struct {
+	tools     []Tool
+	resources []Resource
+}
+
+func (s *Server) preserveGameSurface(gameID string) preservedGameSurface {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var surface preservedGameSurface
+	for _, toolName := range s.sessions[gameID].toolNames() {
+		if handler, exists := s.tools[toolName]; exists {
+			surface.tools = append(surface.tools, handler.Tool)
+		}
+	}
+	for _, uri := range s.sessions[gameID].resourceURIs() {
+		if handler, exists := s.resources[uri]; exists {
+			surface.resources = append(surface.resources, handler.Resource)
+		}
+	}
+	return surface
+}
+
+// restoreGameSurface registers placeholders for preserved tools and resources
+// that the restarted game has not mirrored again yet. The next GABP sync
+// replaces them with live handlers. It returns how many were restored.
+func (s *Server) restoreGameSurface(gameID string, surface preservedGameSurface) int {
+	notReady := fmt.Sprintf("Game '%s' is restarting and its GABP bridge has not reconnected yet.", gameID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	restored := 0
+	for _, tool := range surface.tools {
+		if _, exists := s.tools[tool.Name]; exists {
+			continue
+		}
+		s.tools[tool.Name] = &ToolHandler{
+			Tool: tool,
+			Handler: func(args map[string]interface{}) (*ToolResult, error) {
+				return &ToolResult{
+					Content: []Content{{Type: "text", Text: notReady + " Try again once games_status reports it connected."}},
+					StructuredContent: map[string]interface{}{
+						"gameId": gameID,
+						"nextActions": []map[string]interface{}{
+							mcpNextAction("games_status", map[string]interface{}{"gameId": gameID}, "Check whether GABP has reconnected."),
+							mcpNextAction("games_connect", map[string]interface{}{"gameId": gameID}, "Reconnect once the bridge has loaded."),
+						},
+					},
+					IsError: true,
+				}, nil
+			},
+		}
+		s.sessionLocked(gameID).addTool(tool.Name)
+		if gabpName := toolMetaString(tool, toolMetaGABPName); gabpName != "" {
+			s.registerGameToolAliasesLocked(gameID, gabpName, tool.Name, tool.InputSchema)
+		}
+		restored++
+	}
+	for _, resource := range surface.resources {
+		if _, exists := s.resources[resource.URI]; exists {
+			continue
+		}
+		s.resources[resource.URI] = &ResourceHandler{
+			Resource: resource,
+			Handler: func() ([]Content, error) {
+				return nil, fmt.Errorf("%s", notReady)
+			},
+		}
+		s.sessionLocked(gameID).addResource(resource.URI)
+		restored++
+	}
+	return restored
+}
+
+// restartGame stops a running game, waits out the stop grace period so the
+// launcher can release the game, and starts it again on the same bridge
+// endpoint. Each phase is reported to call, which may be nil, and cancelling
+// call during the grace period leaves the game stopped.
+func (s *Server) restartGame(game config.GameConfig, gamesConfig *config.GamesConfig, backoffMin, backoffMax time.Duration, startupGABPTimeout time.Duration, call *toolCall) *ToolResult {
+	surface := s.preserveGameSurface(game.ID)
+	// Come back with the launch profile the game runs with now
+	profile := s.activeProfile(game.ID)
+
+	wasRunning := s.checkGameStatus(game.ID) != "stopped"
+	if wasRunning {
+		call.reporter().step(fmt.Sprintf("Stopping %s", game.ID))
+		if err := s.stopGame(game, false); err != nil {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Failed to stop %s for a restart, so it was not started again: %v", game.ID, err)}},
+				StructuredContent: map[string]interface{}{
+					"gameId":    game.ID,
+					"restarted": false,
+					"nextActions": []map[string]interface{}{
+						mcpNextAction("games_status", map[string]interface{}{"gameId": game.ID}, "Check whether the game is still running."),
+					},
+				},
+				IsError: true,
+			}
+		}
+
+		s.mu.RLock()
+		clock := s.clock
+		s.mu.RUnlock()
+		select {
+		case <-s.serveContext().Done():
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' was stopped, but GABS shut down before it could be started again.", game.ID)}},
+				IsError: true,
+			}
+		case <-call.context().Done():
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' was stopped, but the restart was cancelled before it could be started again.", game.ID)}},
+				IsError: true,
+			}
+		case <-clock.After(stopGracePeriod):
+		}
+	}
+
+	startResult, err := s.startGame(game, profile, gamesConfig, backoffMin, backoffMax, startupGABPTimeout, false, false, call)
+	result := s.gameStartResult(game, startResult, err)
+	if err != nil {
+		return result
+	}
+
+	restored := 0
+	if startResult != nil && !startResult.GABPConnected {
+		restored = s.restoreGameSurface(game.ID, surface)
+	}
+	if result.StructuredContent == nil {
+		result.StructuredContent = map[string]interface{}{}
+	}
+	result.StructuredContent["restarted"] = wasRunning
+	result.StructuredContent["preservedTools"] = len(surface.tools)
+	result.StructuredContent["placeholders"] = restored
+	if wasRunning && len(result.Content) > 0 {
+		result.Content[0].Text = fmt.Sprintf("Stopped '%s' and started it again. %s", game.ID, result.Content[0].Text)
+	}
+	return result
+}
+
+func (s *Server) registerGameRestartTool(gamesConfig *config.GamesConfig, backoffMin, backoffMax time.Duration, normalizationConfig *config.ToolNormalizationConfig) {
+	s.registerCallTool(Tool{
+		Name:        "games.restart",
+		Description: "Gracefully stop a game and start it again on the same GABP bridge endpoint, keeping its mirrored tool names while GABP reconnects",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": map[string]interface{}{
+					"type":        "string",
+					"description": "Game ID or launch target to restart. A stopped game is simply started.",
+				},
+				"timeout": map[string]interface{}{
+					"type":        "integer",
+					"description": "Optional total GABP startup connection budget in seconds, as for games_start.",
+				},
+			},
+			"required": []string{"gameId"},
+		},
+	}, func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
+		gameIdOrTarget, ok := args["gameId"].(string)
+		if !ok {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "gameId parameter is required"}},
+				IsError: true,
+			}, nil
+		}
+
+		startupGABPTimeout, invalidTimeout := parseOptionalTimeoutSecondsArg(args, "timeout", 0)
+		if invalidTimeout != nil {
+			return invalidTimeout, nil
+		}
+
+		game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
+		if !exists {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' not found. Use games_list to see available games.", gameIdOrTarget)}},
+				IsError: true,
+			}, nil
+		}
+
+		return s.restartGame(*game, gamesConfig, backoffMin, backoffMax, startupGABPTimeout, call), nil
+	}, normalizationConfig)
+}
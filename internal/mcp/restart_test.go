@@ -0,0 +1,85 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestRestartGameStartsNewProcessAndKeepsMirroredToolNames(t *testing.T) {
+	requireSleepForTest(t)
+
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"factory": sleepingGameForTest("factory", "Factory"),
+		},
+	}
+	server, _ := newGamesTestServer(t, gamesConfig)
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	server.SetClock(clock)
+
+	trackSleepingGameForTest(t, server, "factory")
+	server.mu.RLock()
+	oldPID := server.sessions["factory"].controller.GetPID()
+	server.mu.RUnlock()
+	server.RegisterGameTool("factory", Tool{
+		Name: "factory_world_status",
+		Meta: map[string]interface{}{toolMetaGABPName: "world/status"},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		return &ToolResult{Content: []Content{{Type: "text", Text: "live"}}}, nil
+	}, nil)
+
+	game, _ := gamesConfig.GetGame("factory")
+	done := make(chan *ToolResult, 1)
+	go func() {
+		done <- server.restartGame(*game, gamesConfig, 10*time.Millisecond, 100*time.Millisecond, time.Second, nil)
+	}()
+
+	// The start waits out the stop grace period first.
+	clock.BlockUntil(1)
+	if status := server.checkGameStatus("factory"); status != "stopped" {
+		t.Fatalf("expected factory to be stopped during the grace period, got %q", status)
+	}
+	clock.Advance(stopGracePeriod)
+	result := <-done
+	t.Cleanup(func() { server.stopGame(*game, true) })
+
+	if result.IsError || result.StructuredContent["restarted"] != true {
+		t.Fatalf("expected restart to succeed, got %#v", result)
+	}
+	if !strings.HasPrefix(result.Content[0].Text, "Stopped 'factory' and started it again.") {
+		t.Fatalf("unexpected restart message %q", result.Content[0].Text)
+	}
+	server.mu.RLock()
+	newPID := server.sessions["factory"].controller.GetPID()
+	server.mu.RUnlock()
+	if newPID == 0 || newPID == oldPID {
+		t.Fatalf("expected a new process, old pid %d new pid %d", oldPID, newPID)
+	}
+
+	if result.StructuredContent["placeholders"] != 1 {
+		t.Fatalf("expected the mirrored tool to be kept, got %#v", result.StructuredContent)
+	}
+	called := callToolForTest(t, server, "factory_world_status", map[string]interface{}{})
+	if !called.IsError || !strings.Contains(called.Content[0].Text, "restarting") {
+		t.Fatalf("expected the kept tool to report the pending reconnect, got %#v", called)
+	}
+}
+
+func TestRestartGameStartsStoppedGameWithoutWaiting(t *testing.T) {
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"factory": {ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/nonexistent/factory"},
+		},
+	}
+	server, _ := newGamesTestServer(t, gamesConfig)
+	server.SetClock(util.NewFakeClock(time.Unix(0, 0)))
+
+	result := callToolForTest(t, server, "games_restart", map[string]interface{}{"gameId": "factory"})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "Failed to start factory") {
+		t.Fatalf("expected the start error of a stopped game, got %#v", result)
+	}
+}
@@ -0,0 +1,358 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/scheduler"
+)
+
+// Outcomes recorded for a scheduled action.
+const (
+	scheduleOutcomeDone    = "done"
+	scheduleOutcomeSkipped = "skipped"
+	scheduleOutcomeFailed  = "failed"
+)
+
+// scheduleRun is the last time a schedule fired in this GABS process.
+type scheduleRun struct {
+	At      time.Time
+	Outcome string
+	Message string
+}
+
+func scheduleRunKey(gameID, scheduleID string) string {
+	return gameID + "/" + scheduleID
+}
+
+// RunSchedules fires the schedules of every configured game until ctx is
+// cancelled. Schedules added, changed or removed in the config, by
+// games_schedule or by a reload, are picked up within
+// scheduler.DefaultRescanInterval.
+func (s *Server) RunSchedules(ctx context.Context, backoffMin, backoffMax time.Duration) {
+	s.mu.RLock()
+	clock := s.clock
+	s.mu.RUnlock()
+
+	runner := &scheduler.Runner{
+		Clock: clock,
+		Jobs: func() []scheduler.Job {
+			return s.scheduleJobs(backoffMin, backoffMax)
+		},
+	}
+	runner.Run(ctx)
+}
+
+func (s *Server) scheduleJobs(backoffMin, backoffMax time.Duration) []scheduler.Job {
+	s.mu.RLock()
+	gamesConfig := s.gamesConfig
+	s.mu.RUnlock()
+	if gamesConfig == nil {
+		return nil
+	}
+
+	var jobs []scheduler.Job
+	for _, game := range gamesConfig.ListGames() {
+		gameID := game.ID
+		for _, schedule := range game.Schedules {
+			schedule := schedule
+			spec, err := schedule.Spec()
+			if err != nil {
+				continue
+			}
+			jobs = append(jobs, scheduler.Job{
+				Key:  strings.Join([]string{gameID, schedule.ID, schedule.Action, schedule.Cron, schedule.Every}, "|"),
+				Spec: spec,
+				Run: func(at time.Time) {
+					go s.runSchedule(gamesConfig, gameID, schedule, at, backoffMin, backoffMax)
+				},
+			})
+		}
+	}
+	return jobs
+}
+
+// runSchedule performs one firing of a schedule. Start is skipped while the
+// game is running and stop while it is stopped, so a schedule never fights a
+// game someone started or stopped by hand.
+func (s *Server) runSchedule(gamesConfig *config.GamesConfig, gameID string, schedule config.ScheduleConfig, at time.Time, backoffMin, backoffMax time.Duration) {
+	if !s.claimScheduleRun(gameID, schedule.ID, at) {
+		s.log.Debugw("scheduled action already claimed by another GABS process", "gameId", gameID, "schedule", schedule.ID, "at", at)
+		return
+	}
+
+	run := scheduleRun{At: at, Outcome: scheduleOutcomeDone}
+	game, exists := gamesConfig.GetGame(gameID)
+	if !exists {
+		return
+	}
+
+	switch status := s.checkGameStatus(gameID); schedule.Action {
+	case config.ScheduleActionStart:
+		if status != "stopped" {
+			run.Outcome = scheduleOutcomeSkipped
+			run.Message = fmt.Sprintf("game was already %s", status)
+		} else if _, err := s.startGame(*game, "", gamesConfig, backoffMin, backoffMax, 0, false, false, nil); err != nil {
+			run.Outcome = scheduleOutcomeFailed
+			run.Message = err.Error()
+		}
+	case config.ScheduleActionStop:
+		if status == "stopped" {
+			run.Outcome = scheduleOutcomeSkipped
+			run.Message = "game was already stopped"
+		} else if err := s.stopGame(*game, false); err != nil {
+			run.Outcome = scheduleOutcomeFailed
+			run.Message = err.Error()
+		}
+	case config.ScheduleActionRestart:
+		if result := s.restartGame(*game, gamesConfig, backoffMin, backoffMax, 0, nil); result.IsError {
+			run.Outcome = scheduleOutcomeFailed
+			if len(result.Content) > 0 {
+				run.Message = result.Content[0].Text
+			}
+		}
+	}
+
+	s.mu.Lock()
+	if s.scheduleRuns == nil {
+		s.scheduleRuns = make(map[string]scheduleRun)
+	}
+	s.scheduleRuns[scheduleRunKey(gameID, schedule.ID)] = run
+	s.mu.Unlock()
+
+	if run.Outcome == scheduleOutcomeFailed {
+		s.log.Warnw("scheduled action failed", "gameId", gameID, "schedule", schedule.ID, "action", schedule.Action, "error", run.Message)
+	} else {
+		s.log.Infow("scheduled action ran", "gameId", gameID, "schedule", schedule.ID, "action", schedule.Action, "outcome", run.Outcome)
+	}
+}
+
+// claimScheduleRun makes sure only one GABS process acts on a firing when
+// several share the config directory. The first to create the claim file for
+// the firing time wins; older claims of the schedule are removed.
+func (s *Server) claimScheduleRun(gameID, scheduleID string, at time.Time) bool {
+	paths, err := config.NewConfigPaths(s.configDir)
+	if err != nil {
+		return true
+	}
+	if err := paths.EnsureGameDir(gameID); err != nil {
+		return true
+	}
+	dir := paths.GetGameDir(gameID)
+	prefix := "schedule-" + scheduleID + "-"
+	name := fmt.Sprintf("%s%d.claim", prefix, at.Unix())
+
+	file, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return !errors.Is(err, os.ErrExist)
+	}
+	fmt.Fprintf(file, "%d\n", os.Getpid())
+	file.Close()
+
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		other := entry.Name()
+		if other == name || !strings.HasPrefix(other, prefix) || !strings.HasSuffix(other, ".claim") {
+			continue
+		}
+		// Only remove claims of this schedule, not of one whose ID merely
+		// starts with the same text.
+		if _, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(other, prefix), ".claim"), 10, 64); err == nil {
+			os.Remove(filepath.Join(dir, other))
+		}
+	}
+	return true
+}
+
+// scheduleDetails describes a schedule with its next and last run.
+func (s *Server) scheduleDetails(gameID string, schedule config.ScheduleConfig, now time.Time) (map[string]interface{}, string) {
+	item := map[string]interface{}{
+		"id":     schedule.ID,
+		"action": schedule.Action,
+	}
+	timing := ""
+	if schedule.Cron != "" {
+		item["cron"] = schedule.Cron
+		timing = fmt.Sprintf("cron '%s'", schedule.Cron)
+	} else {
+		item["every"] = schedule.Every
+		timing = "every " + schedule.Every
+	}
+	line := fmt.Sprintf("- %s: %s, %s", schedule.ID, schedule.Action, timing)
+
+	if spec, err := schedule.Spec(); err == nil {
+		if next := spec.Next(now); !next.IsZero() {
+			item["nextRun"] = next
+			line += ", next " + next.Format("2006-01-02 15:04 MST")
+		}
+	}
+
+	s.mu.RLock()
+	run, ran := s.scheduleRuns[scheduleRunKey(gameID, schedule.ID)]
+	s.mu.RUnlock()
+	if ran {
+		last := map[string]interface{}{"at": run.At, "outcome": run.Outcome}
+		line += fmt.Sprintf(", last %s %s", run.At.Format("2006-01-02 15:04 MST"), run.Outcome)
+		if run.Message != "" {
+			last["message"] = run.Message
+			line += " (" + run.Message + ")"
+		}
+		item["lastRun"] = last
+	}
+	return item, line
+}
+
+func (s *Server) registerGameScheduleTool(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.schedule",
+		Description: "List, add or remove schedules that start, stop or restart a game at fixed times, such as a nightly restart, and save them to the GABS config",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": map[string]interface{}{
+					"type":        "string",
+					"description": "Game ID or launch target",
+				},
+				"operation": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"list", "add", "remove"},
+					"description": "list (default) shows the schedules with their next and last run, add creates or replaces one, remove deletes one",
+				},
+				"id": map[string]interface{}{
+					"type":        "string",
+					"description": "Schedule ID. Required for remove; for add, an existing ID is replaced and an omitted one defaults to the action name.",
+				},
+				"action": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{config.ScheduleActionStart, config.ScheduleActionStop, config.ScheduleActionRestart},
+					"description": "What the schedule does to the game",
+				},
+				"cron": map[string]interface{}{
+					"type":        "string",
+					"description": "Five-field cron expression in the local time of the GABS machine, such as \"0 4 * * *\" for 04:00 every day",
+				},
+				"every": map[string]interface{}{
+					"type":        "string",
+					"description": "Interval such as \"6h\", aligned to midnight UTC; use instead of cron",
+				},
+			},
+			"required": []string{"gameId"},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		gameIdOrTarget, ok := args["gameId"].(string)
+		if !ok {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "gameId parameter is required"}},
+				IsError: true,
+			}, nil
+		}
+		game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
+		if !exists {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' not found. Use games_list to see available games.", gameIdOrTarget)}},
+				IsError: true,
+			}, nil
+		}
+
+		operation, _ := args["operation"].(string)
+		id, _ := args["id"].(string)
+		id = strings.TrimSpace(id)
+		var summary string
+		switch operation {
+		case "", "list":
+		case "add":
+			action, _ := args["action"].(string)
+			cron, _ := args["cron"].(string)
+			every, _ := args["every"].(string)
+			schedule := config.ScheduleConfig{
+				ID:     id,
+				Action: strings.TrimSpace(action),
+				Cron:   strings.TrimSpace(cron),
+				Every:  strings.TrimSpace(every),
+			}
+			if schedule.ID == "" {
+				schedule.ID = game.NextScheduleID(schedule.Action)
+			}
+			updated, replaced := game.WithSchedule(schedule)
+			if err := s.persistGameConfig(gamesConfig, updated); err != nil {
+				return &ToolResult{
+					Content: []Content{{Type: "text", Text: fmt.Sprintf("Schedule was not saved: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+			game = &updated
+			verb := "Added"
+			if replaced {
+				verb = "Replaced"
+			}
+			summary = fmt.Sprintf("%s schedule '%s' for %s.", verb, schedule.ID, game.ID)
+			s.log.Infow("saved game schedule", "gameId", game.ID, "schedule", schedule.ID, "action", schedule.Action)
+		case "remove":
+			if id == "" {
+				return &ToolResult{
+					Content: []Content{{Type: "text", Text: "id parameter is required to remove a schedule"}},
+					IsError: true,
+				}, nil
+			}
+			updated, removed := game.WithoutSchedule(id)
+			if !removed {
+				return &ToolResult{
+					Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' has no schedule '%s'.", game.ID, id)}},
+					IsError: true,
+				}, nil
+			}
+			if err := s.persistGameConfig(gamesConfig, updated); err != nil {
+				return &ToolResult{
+					Content: []Content{{Type: "text", Text: fmt.Sprintf("Schedule was not removed: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+			game = &updated
+			summary = fmt.Sprintf("Removed schedule '%s' from %s.", id, game.ID)
+			s.log.Infow("removed game schedule", "gameId", game.ID, "schedule", id)
+		default:
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("invalid operation '%s', must be one of: list, add, remove", operation)}},
+				IsError: true,
+			}, nil
+		}
+
+		s.mu.RLock()
+		now := s.clock.Now()
+		s.mu.RUnlock()
+
+		schedules := append([]config.ScheduleConfig(nil), game.Schedules...)
+		sort.SliceStable(schedules, func(i, j int) bool { return schedules[i].ID < schedules[j].ID })
+		items := make([]map[string]interface{}, 0, len(schedules))
+		var text strings.Builder
+		if summary != "" {
+			text.WriteString(summary + "\n")
+		}
+		if len(schedules) == 0 {
+			text.WriteString(fmt.Sprintf("Game '%s' has no schedules.", game.ID))
+		} else {
+			text.WriteString(fmt.Sprintf("Schedules for %s:", game.ID))
+		}
+		for _, schedule := range schedules {
+			item, line := s.scheduleDetails(game.ID, schedule, now)
+			items = append(items, item)
+			text.WriteString("\n" + line)
+		}
+
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: text.String()}},
+			StructuredContent: map[string]interface{}{
+				"gameId":    game.ID,
+				"schedules": items,
+			},
+		}, nil
+	}, normalizationConfig)
+}
@@ -0,0 +1,97 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func TestGamesScheduleAddsListsAndRemovesSchedules(t *testing.T) {
+	server, configDir := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"factory": {ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/opt/factory/start.sh"},
+		},
+	})
+
+	added := callToolForTest(t, server, "games_schedule", map[string]interface{}{
+		"gameId":    "factory",
+		"operation": "add",
+		"action":    "restart",
+		"cron":      "0 4 * * *",
+	})
+	if added.IsError || !strings.Contains(added.Content[0].Text, "Added schedule 'restart' for factory.") {
+		t.Fatalf("unexpected add result: %#v", added)
+	}
+	saved, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		t.Fatalf("load saved config: %v", err)
+	}
+	game, _ := saved.GetGame("factory")
+	if len(game.Schedules) != 1 || game.Schedules[0].Cron != "0 4 * * *" {
+		t.Fatalf("expected the schedule to be saved, got %#v", game.Schedules)
+	}
+
+	invalid := callToolForTest(t, server, "games_schedule", map[string]interface{}{
+		"gameId":    "factory",
+		"operation": "add",
+		"action":    "start",
+		"cron":      "0 4 * * *",
+		"every":     "6h",
+	})
+	if !invalid.IsError || !strings.Contains(invalid.Content[0].Text, "exactly one of cron or every") {
+		t.Fatalf("expected a validation error, got %#v", invalid)
+	}
+
+	server.runSchedule(server.gamesConfig, "factory", config.ScheduleConfig{ID: "restart", Action: config.ScheduleActionStop, Cron: "0 4 * * *"}, time.Unix(1773460800, 0), 0, 0)
+	listed := callToolForTest(t, server, "games_schedule", map[string]interface{}{"gameId": "factory"})
+	schedules, _ := listed.StructuredContent["schedules"].([]interface{})
+	if listed.IsError || len(schedules) != 1 {
+		t.Fatalf("unexpected list result: %#v", listed)
+	}
+	item := schedules[0].(map[string]interface{})
+	if item["nextRun"] == nil {
+		t.Fatalf("expected the next run in %#v", item)
+	}
+	if lastRun, _ := item["lastRun"].(map[string]interface{}); lastRun["outcome"] != scheduleOutcomeSkipped {
+		t.Fatalf("expected the skipped stop as last run, got %#v", item["lastRun"])
+	}
+
+	removed := callToolForTest(t, server, "games_schedule", map[string]interface{}{"gameId": "factory", "operation": "remove", "id": "restart"})
+	if removed.IsError || !strings.Contains(removed.Content[0].Text, "has no schedules") {
+		t.Fatalf("unexpected remove result: %#v", removed)
+	}
+}
+
+func TestClaimScheduleRunLetsOneServerActPerFiring(t *testing.T) {
+	server, configDir := newGamesTestServer(t, &config.GamesConfig{})
+	first := time.Date(2026, time.March, 14, 4, 0, 0, 0, time.UTC)
+
+	if !server.claimScheduleRun("factory", "nightly", first) {
+		t.Fatal("expected the first claim to succeed")
+	}
+	if server.claimScheduleRun("factory", "nightly", first) {
+		t.Fatal("expected a second claim of the same firing to fail")
+	}
+	if !server.claimScheduleRun("factory", "nightly-2", first) {
+		t.Fatal("expected another schedule to claim its own firing")
+	}
+	if !server.claimScheduleRun("factory", "nightly", first.Add(24*time.Hour)) {
+		t.Fatal("expected the next firing to be claimable")
+	}
+
+	paths, err := config.NewConfigPaths(configDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, _ := filepath.Glob(filepath.Join(paths.GetGameDir("factory"), "schedule-*.claim"))
+	if len(claims) != 2 {
+		t.Fatalf("expected the older claim to be removed and the other schedule's kept, got %v", claims)
+	}
+	if _, err := os.Stat(filepath.Join(paths.GetGameDir("factory"), "schedule-nightly-2-1773460800.claim")); err != nil {
+		t.Fatalf("expected the claim of nightly-2 to survive: %v", err)
+	}
+}
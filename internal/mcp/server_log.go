@@ -0,0 +1,30 @@
+package mcp
+
+import (
+	"strings"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// serverLogsURI is the resource that serves recent GABS log entries.
+const serverLogsURI = "gabs://logs/server"
+
+// gameLogger returns a child logger whose entries carry gameId, so the
+// entries about one game can be filtered out of the server log.
+func (s *Server) gameLogger(gameID string) util.Logger {
+	return util.WithFields(s.log, "gameId", gameID)
+}
+
+// SetServerLogBuffer serves the entries kept in buffer, which the logger
+// passed to NewServer should also write to, as the gabs://logs/server
+// resource.
+func (s *Server) SetServerLogBuffer(buffer *util.LogBuffer) {
+	s.RegisterResource(Resource{
+		URI:         serverLogsURI,
+		Name:        "GABS Server Log",
+		Description: "Recent log entries of this GABS server, oldest first",
+		MimeType:    "text/plain",
+	}, func() ([]Content, error) {
+		return []Content{{Type: "text", Text: strings.Join(buffer.Lines(0), "\n")}}, nil
+	})
+}
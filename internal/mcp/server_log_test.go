@@ -0,0 +1,39 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestServerLogResourceServesRecentEntriesWithGameID(t *testing.T) {
+	recent := util.NewLogBuffer(10)
+	log, err := util.NewLoggerWithOptions(util.LogOptions{Level: "info", Format: util.LogFormatJSON, Recent: recent})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	server := NewServerForTesting(log)
+	server.SetServerLogBuffer(recent)
+
+	server.gameLogger("factory").Infow("bridge connected")
+
+	response := server.HandleMessage(&Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "resources/read", Params: map[string]interface{}{"uri": serverLogsURI}})
+	var read struct {
+		Contents []Content `json:"contents"`
+	}
+	if response.Error != nil || decodeResult(response.Result, &read) != nil || len(read.Contents) != 1 {
+		t.Fatalf("unexpected %s read: %#v", serverLogsURI, response)
+	}
+	var found bool
+	for _, line := range strings.Split(read.Contents[0].Text, "\n") {
+		var entry map[string]interface{}
+		if json.Unmarshal([]byte(line), &entry) == nil && entry["msg"] == "bridge connected" {
+			found = entry["gameId"] == "factory"
+		}
+	}
+	if !found {
+		t.Fatalf("expected the game entry tagged with gameId, got %q", read.Contents[0].Text)
+	}
+}
@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"strconv"
+	"sync"
+)
+
+// sessionEventLogSize is how many notifications an HTTP session keeps for
+// SSE streams that reconnect with Last-Event-ID.
+const sessionEventLogSize = 256
+
+// sessionEvent is a notification with the SSE event ID it was sent under.
+type sessionEvent struct {
+	id  uint64
+	msg *Message
+}
+
+// sessionEventLog numbers the notifications addressed to an HTTP session and
+// keeps the latest ones, including those sent while no stream was open, so a
+// reconnecting stream can catch up.
+type sessionEventLog struct {
+	mu     sync.Mutex
+	nextID uint64
+	events []sessionEvent
+}
+
+// record assigns msg the next event ID and keeps it.
+func (l *sessionEventLog) record(msg *Message) uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.nextID++
+	l.events = append(l.events, sessionEvent{id: l.nextID, msg: msg})
+	if len(l.events) > sessionEventLogSize {
+		l.events = append([]sessionEvent(nil), l.events[len(l.events)-sessionEventLogSize:]...)
+	}
+	return l.nextID
+}
+
+// idOf returns the event ID msg was recorded under, or 0 when it is not in
+// the log.
+func (l *sessionEventLog) idOf(msg *Message) uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i := len(l.events) - 1; i >= 0; i-- {
+		if l.events[i].msg == msg {
+			return l.events[i].id
+		}
+	}
+	return 0
+}
+
+// since returns the events after lastID. complete is false when some of them
+// already fell out of the log.
+func (l *sessionEventLog) since(lastID uint64) (events []sessionEvent, complete bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	complete = len(l.events) == 0 || l.events[0].id <= lastID+1
+	for _, event := range l.events {
+		if event.id > lastID {
+			events = append(events, event)
+		}
+	}
+	return events, complete
+}
+
+// recordSessionEvents adds notification to the event log of every resumable
+// HTTP session whose streams match would accept. The caller holds clientsMu.
+func (s *Server) recordSessionEvents(notification *Message, match func(*clientConn) bool) {
+	for _, session := range s.resumable {
+		if match != nil && !match(&clientConn{transport: clientTransportSSE, session: session}) {
+			continue
+		}
+		session.events.record(notification)
+	}
+}
+
+// makeResumable gives session an event log, so its SSE streams can resume.
+func (s *Server) makeResumable(session *mcpSession) {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	session.events = &sessionEventLog{}
+	s.resumable = append(s.resumable, session)
+}
+
+// parseLastEventID reads the Last-Event-ID a reconnecting SSE stream sends.
+func parseLastEventID(value string) (uint64, bool) {
+	if value == "" {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	return id, err == nil
+}
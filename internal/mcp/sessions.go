@@ -0,0 +1,361 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// sessionTransportHTTP marks sessions of clients that use the HTTP transport.
+// Stdio sessions use clientTransportStdio.
+const sessionTransportHTTP = "http"
+
+// sessionInfo identifies the client behind an MCP session and what it did.
+type sessionInfo struct {
+	ID          string
+	Client      ClientInfo
+	Role        string
+	ConnectedAt time.Time
+	LastActive  time.Time
+	LastMethod  string
+	LastTool    string
+	ToolCalls   int
+	ToolProfile string // Name of the toolProfiles entry limiting the session, or ""
+
+	ProtocolVersion string // MCP revision negotiated at initialize
+}
+
+// openSession gives session an ID, unless an earlier initialize already did,
+// and records the clientInfo of the initialize request msg.
+func (s *Server) openSession(session *mcpSession, msg *Message, role string) {
+	var params InitializeParams
+	if raw, err := json.Marshal(msg.Params); err == nil {
+		_ = json.Unmarshal(raw, &params)
+	}
+
+	session.infoMu.Lock()
+	if session.info.ID == "" {
+		session.info.ID = uuid.New().String()
+		session.info.ConnectedAt = time.Now()
+	}
+	session.info.Client = params.ClientInfo
+	session.info.ProtocolVersion = negotiateProtocolVersion(params.ProtocolVersion)
+	session.info.Role = role
+	id := session.info.ID
+	negotiated := session.info.ProtocolVersion
+	session.infoMu.Unlock()
+
+	s.clientsMu.Lock()
+	if s.clientSessions == nil {
+		s.clientSessions = make(map[string]*mcpSession)
+	}
+	s.clientSessions[id] = session
+	s.clientsMu.Unlock()
+
+	s.log.Infow("MCP client session started", append([]interface{}{"transport", session.transport, "protocolVersion", negotiated, "requestedProtocolVersion", params.ProtocolVersion}, sessionLogFields(session)...)...)
+}
+
+// closeSession forgets a session whose stdio connection ended or whose HTTP
+// client sent DELETE or went idle. It stops keeping notifications for the
+// session, closes its notification streams and drops its session budget
+// counts.
+func (s *Server) closeSession(session *mcpSession) {
+	id := session.sessionID()
+	if id == "" {
+		return
+	}
+	s.clientsMu.Lock()
+	delete(s.clientSessions, id)
+	for i, resumable := range s.resumable {
+		if resumable == session {
+			s.resumable = append(s.resumable[:i], s.resumable[i+1:]...)
+			break
+		}
+	}
+	var streams []*clientConn
+	for _, client := range s.clients {
+		if client.session == session {
+			streams = append(streams, client)
+		}
+	}
+	s.clientsMu.Unlock()
+
+	for _, client := range streams {
+		s.removeClient(client)
+	}
+	s.forgetBudgetUsage(id)
+	s.log.Infow("MCP client session ended", sessionLogFields(session)...)
+}
+
+// sessionID returns the ID initialize assigned, or "" before that.
+func (m *mcpSession) sessionID() string {
+	if m == nil {
+		return ""
+	}
+	m.infoMu.Lock()
+	defer m.infoMu.Unlock()
+	return m.info.ID
+}
+
+// snapshot returns a copy of the session's identity and activity.
+func (m *mcpSession) snapshot() sessionInfo {
+	m.infoMu.Lock()
+	defer m.infoMu.Unlock()
+	return m.info
+}
+
+// noteActivity records that the client sent method.
+func (m *mcpSession) noteActivity(method string) {
+	if m == nil {
+		return
+	}
+	m.infoMu.Lock()
+	defer m.infoMu.Unlock()
+	if m.info.ID == "" {
+		return
+	}
+	m.info.LastActive = time.Now()
+	m.info.LastMethod = method
+}
+
+// noteToolCall records that the client called tool.
+func (m *mcpSession) noteToolCall(tool string) {
+	if m == nil {
+		return
+	}
+	m.infoMu.Lock()
+	defer m.infoMu.Unlock()
+	m.info.ToolCalls++
+	m.info.LastTool = tool
+}
+
+// toolProfile returns the tool profile the session is limited to, or "".
+func (m *mcpSession) toolProfile() string {
+	if m == nil {
+		return ""
+	}
+	m.infoMu.Lock()
+	defer m.infoMu.Unlock()
+	return m.info.ToolProfile
+}
+
+// setToolProfile limits the session to profile, or lifts the limit when
+// profile is empty.
+func (m *mcpSession) setToolProfile(profile string) {
+	m.infoMu.Lock()
+	defer m.infoMu.Unlock()
+	m.info.ToolProfile = profile
+}
+
+// sessionLogFields returns the log fields that tell which client a log entry
+// is about. It is empty for calls without an initialized session.
+func sessionLogFields(session *mcpSession) []interface{} {
+	if session == nil {
+		return nil
+	}
+	info := session.snapshot()
+	if info.ID == "" {
+		return nil
+	}
+	fields := []interface{}{"sessionId", info.ID}
+	if info.Client.Name != "" {
+		fields = append(fields, "client", info.Client.Name, "clientVersion", info.Client.Version)
+	}
+	return fields
+}
+
+// SendToolsListChangedNotificationTo notifies only the given sessions that
+// the tool list they see has changed, for changes that do not concern other
+// clients.
+func (s *Server) SendToolsListChangedNotificationTo(sessionIDs ...string) {
+	wanted := make(map[string]bool, len(sessionIDs))
+	for _, id := range sessionIDs {
+		wanted[id] = true
+	}
+	s.notifyClients("notifications/tools/list_changed", map[string]interface{}{}, func(client *clientConn) bool {
+		return wanted[client.session.sessionID()]
+	})
+	s.log.Debugw("sent tools/list_changed notification", "sessions", sessionIDs)
+}
+
+// sessionsStructured lists the initialized sessions, oldest first, marking
+// current, which may be nil.
+func (s *Server) sessionsStructured(current *mcpSession) []map[string]interface{} {
+	s.clientsMu.RLock()
+	sessions := make([]*mcpSession, 0, len(s.clientSessions))
+	for _, session := range s.clientSessions {
+		sessions = append(sessions, session)
+	}
+	streams := make(map[*mcpSession]int)
+	for _, client := range s.clients {
+		if client.session != nil {
+			streams[client.session]++
+		}
+	}
+	s.clientsMu.RUnlock()
+
+	infos := make(map[*mcpSession]sessionInfo, len(sessions))
+	for _, session := range sessions {
+		infos[session] = session.snapshot()
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return infos[sessions[i]].ConnectedAt.Before(infos[sessions[j]].ConnectedAt)
+	})
+
+	items := make([]map[string]interface{}, 0, len(sessions))
+	for _, session := range sessions {
+		info := infos[session]
+		session.subsMu.Lock()
+		subscriptions := len(session.subscriptions)
+		session.subsMu.Unlock()
+		item := map[string]interface{}{
+			"sessionId":           info.ID,
+			"transport":           session.transport,
+			"role":                info.Role,
+			"client":              info.Client,
+			"connectedAt":         info.ConnectedAt.UTC().Format(time.RFC3339),
+			"toolCalls":           info.ToolCalls,
+			"subscriptions":       subscriptions,
+			"notificationStreams": streams[session],
+			"current":             session == current,
+		}
+		if !info.LastActive.IsZero() {
+			item["lastActive"] = info.LastActive.UTC().Format(time.RFC3339)
+			item["lastMethod"] = info.LastMethod
+		}
+		if info.LastTool != "" {
+			item["lastTool"] = info.LastTool
+		}
+		if info.ToolProfile != "" {
+			item["toolProfile"] = info.ToolProfile
+		}
+		if info.ProtocolVersion != "" {
+			item["protocolVersion"] = info.ProtocolVersion
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (s *Server) registerSessionsTool(normalizationConfig *config.ToolNormalizationConfig) {
+	s.registerCallTool(Tool{
+		Name:        "server.sessions",
+		Description: "List the MCP clients connected to this GABS server: session ID, client name and version, transport, role and recent activity. Only the local user and the main apiKey may use it.",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+	}, func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
+		if role := call.accessRole(); !canChangeServerSettings(role) {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Role '%s' may not list client sessions.", role)}},
+				IsError: true,
+			}, nil
+		}
+		var current *mcpSession
+		if call != nil {
+			current = call.session
+		}
+		sessions := s.sessionsStructured(current)
+
+		var text strings.Builder
+		text.WriteString(fmt.Sprintf("%d MCP client session(s):", len(sessions)))
+		for _, item := range sessions {
+			client := item["client"].(ClientInfo)
+			name := client.Name
+			if name == "" {
+				name = "unnamed client"
+			} else if client.Version != "" {
+				name += " " + client.Version
+			}
+			line := fmt.Sprintf("\n- %s: %s over %s, role %s, %d tool call(s)", item["sessionId"], name, item["transport"], item["role"], item["toolCalls"])
+			if profile, ok := item["toolProfile"].(string); ok {
+				line += fmt.Sprintf(", tool profile %s", profile)
+			}
+			if item["current"] == true {
+				line += " (this session)"
+			}
+			text.WriteString(line)
+		}
+		return &ToolResult{
+			Content:           []Content{{Type: "text", Text: text.String()}},
+			StructuredContent: map[string]interface{}{"sessions": sessions},
+		}, nil
+	}, normalizationConfig)
+}
+
+// lookupClientSession returns the initialized session with the given ID.
+func (s *Server) lookupClientSession(id string) (*mcpSession, bool) {
+	s.clientsMu.RLock()
+	defer s.clientsMu.RUnlock()
+	session, exists := s.clientSessions[id]
+	return session, exists
+}
+
+func (s *Server) registerSessionProfileTool(normalizationConfig *config.ToolNormalizationConfig) {
+	s.registerCallTool(Tool{
+		Name:        "server.set_session_profile",
+		Description: "Limit one connected MCP client to the tools of a toolProfiles entry, such as a read-only profile, or lift the limit with an empty profile. The client is told its tool list changed. Only the local user and the main apiKey may use it.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"sessionId": map[string]interface{}{
+					"type":        "string",
+					"description": "Session ID as listed by server_sessions",
+				},
+				"profile": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of a toolProfiles entry, or empty to give the session every tool its role allows",
+				},
+			},
+			"required": []string{"sessionId"},
+		},
+	}, func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
+		if role := call.accessRole(); !canChangeServerSettings(role) {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Role '%s' may not change session tool profiles.", role)}},
+				IsError: true,
+			}, nil
+		}
+		sessionID, _ := args["sessionId"].(string)
+		profile, _ := args["profile"].(string)
+		session, exists := s.lookupClientSession(sessionID)
+		if !exists {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Session '%s' not found. Use server_sessions to see connected clients.", sessionID)}},
+				IsError: true,
+			}, nil
+		}
+		if profile != "" && !s.gamesConfig.HasToolProfile(profile) {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Tool profile '%s' is not configured. Add it to toolProfiles in the GABS config.", profile)}},
+				IsError: true,
+			}, nil
+		}
+
+		previous := session.toolProfile()
+		session.setToolProfile(profile)
+		s.log.Infow("session tool profile changed", append([]interface{}{"from", previous, "to", profile}, sessionLogFields(session)...)...)
+		if previous != profile {
+			s.SendToolsListChangedNotificationTo(sessionID)
+		}
+
+		text := fmt.Sprintf("Session %s is now limited to tool profile '%s'.", sessionID, profile)
+		if profile == "" {
+			text = fmt.Sprintf("Session %s may now use every tool its role allows.", sessionID)
+		}
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: text}},
+			StructuredContent: map[string]interface{}{
+				"sessionId":       sessionID,
+				"toolProfile":     profile,
+				"previousProfile": previous,
+			},
+		}, nil
+	}, normalizationConfig)
+}
@@ -0,0 +1,127 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// postSessionMCPForTest posts body to the MCP HTTP endpoint, continuing sessionID
+// when it is not empty, and returns the session ID header and the response.
+func postSessionMCPForTest(t *testing.T, server *Server, sessionID, body string) (string, map[string]interface{}) {
+	t.Helper()
+	request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	if sessionID != "" {
+		request.Header.Set(mcpSessionHeader, sessionID)
+	}
+	recorder := httptest.NewRecorder()
+	server.handleMCPHTTPRequest(recorder, request)
+	var response map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response %q: %v", recorder.Body.String(), err)
+	}
+	return recorder.Header().Get(mcpSessionHeader), response
+}
+
+func TestServerSessionsListsHTTPClients(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.registerSessionsTool(nil)
+
+	first, _ := postSessionMCPForTest(t, server, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","clientInfo":{"name":"planner","version":"1.2"}}}`)
+	second, _ := postSessionMCPForTest(t, server, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","clientInfo":{"name":"watcher","version":"0.9"}}}`)
+	if first == "" || second == "" || first == second {
+		t.Fatalf("expected two distinct session IDs, got %q and %q", first, second)
+	}
+	request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0","method":"notifications/initialized"}`))
+	request.Header.Set(mcpSessionHeader, first)
+	server.handleMCPHTTPRequest(httptest.NewRecorder(), request)
+
+	_, response := postSessionMCPForTest(t, server, first, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"server.sessions","arguments":{}}}`)
+	result, ok := response["result"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("server_sessions failed: %#v", response)
+	}
+	sessions := result["structuredContent"].(map[string]interface{})["sessions"].([]interface{})
+	if len(sessions) != 2 {
+		t.Fatalf("expected two sessions, got %#v", sessions)
+	}
+	byID := map[string]map[string]interface{}{}
+	for _, raw := range sessions {
+		item := raw.(map[string]interface{})
+		byID[item["sessionId"].(string)] = item
+	}
+	planner := byID[first]
+	if planner == nil || planner["client"].(map[string]interface{})["name"] != "planner" || planner["current"] != true || planner["transport"] != sessionTransportHTTP {
+		t.Fatalf("unexpected planner session: %#v", planner)
+	}
+	if planner["toolCalls"] != float64(1) || planner["lastTool"] != "server.sessions" {
+		t.Fatalf("expected the call to be counted, got %#v", planner)
+	}
+	if watcher := byID[second]; watcher == nil || watcher["current"] != false || watcher["toolCalls"] != float64(0) {
+		t.Fatalf("unexpected watcher session: %#v", watcher)
+	}
+}
+
+func TestToolsListChangedCanBeScopedToSessions(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	planner := &mcpSession{transport: clientTransportStdio}
+	watcher := &mcpSession{transport: clientTransportStdio}
+	for _, session := range []*mcpSession{planner, watcher} {
+		server.openSession(session, &Message{Method: "initialize"}, "local")
+	}
+	plannerClient := server.addClient(clientTransportStdio, planner)
+	watcherClient := server.addClient(clientTransportStdio, watcher)
+
+	server.SendToolsListChangedNotificationTo(planner.sessionID())
+	if len(plannerClient.outbox) != 1 || len(watcherClient.outbox) != 0 {
+		t.Fatalf("expected only the planner to be notified, got %d and %d", len(plannerClient.outbox), len(watcherClient.outbox))
+	}
+
+	server.closeSession(watcher)
+	if items := server.sessionsStructured(nil); len(items) != 1 || items[0]["sessionId"] != planner.sessionID() {
+		t.Fatalf("expected only the planner session to stay listed, got %#v", items)
+	}
+}
+
+func TestEndedHTTPSessionsAreForgotten(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	server.SetClock(clock)
+
+	deleted, _ := postSessionMCPForTest(t, server, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","clientInfo":{"name":"planner"}}}`)
+	idle, _ := postSessionMCPForTest(t, server, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","clientInfo":{"name":"watcher"}}}`)
+	deletedSession, _ := server.lookupHTTPSession(deleted)
+	idleSession, _ := server.lookupHTTPSession(idle)
+	deletedStream := server.addClient(clientTransportSSE, deletedSession)
+	idleStream := server.addClient(clientTransportSSE, idleSession)
+	if items := server.sessionsStructured(nil); len(items) != 2 {
+		t.Fatalf("expected two sessions, got %#v", items)
+	}
+
+	request := httptest.NewRequest(http.MethodDelete, "/mcp", nil)
+	request.Header.Set(mcpSessionHeader, deleted)
+	server.handleMCPHTTPRequest(httptest.NewRecorder(), request)
+	clock.Advance(httpSessionIdleTimeout + time.Second)
+	server.expireHTTPSessions()
+
+	if items := server.sessionsStructured(nil); len(items) != 0 {
+		t.Fatalf("expected ended sessions to be unlisted, got %#v", items)
+	}
+	for name, stream := range map[string]*clientConn{"deleted": deletedStream, "idle": idleStream} {
+		select {
+		case <-stream.done:
+		default:
+			t.Fatalf("expected the %s session's notification stream to be closed", name)
+		}
+	}
+	server.clientsMu.RLock()
+	defer server.clientsMu.RUnlock()
+	if len(server.resumable) != 0 {
+		t.Fatalf("expected no resumable sessions, got %d", len(server.resumable))
+	}
+}
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+)
+
+// shutdownPollInterval is how often Shutdown checks for running tool calls
+// and queued notifications.
+const shutdownPollInterval = 10 * time.Millisecond
+
+// SetShutdownPolicy sets the shutdownPolicy of games that do not configure
+// one. Empty means config.ShutdownPolicyLeaveRunning.
+func (s *Server) SetShutdownPolicy(policy string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.shutdownPolicy = policy
+}
+
+// Shutdown ends the server's work on its games before the process exits. It
+// refuses new tool calls, waits for running ones so no GABP request is cut
+// off, then stops, kills or detaches from each game as its shutdownPolicy
+// says, and waits until queued notifications are written. It returns early
+// when ctx ends. The transports keep serving until the caller stops them.
+func (s *Server) Shutdown(ctx context.Context) {
+	s.shuttingDown.Store(true)
+	if !s.waitForToolCalls(ctx) {
+		s.log.Warnw("shutting down while tool calls are still running")
+	}
+
+	s.mu.RLock()
+	var gameIDs []string
+	for gameID, session := range s.sessions {
+		_, tracked := session.process()
+		_, connected := session.gabpClient()
+		if tracked || connected {
+			gameIDs = append(gameIDs, gameID)
+		}
+	}
+	s.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, gameID := range gameIDs {
+		wg.Add(1)
+		go func(gameID string) {
+			defer wg.Done()
+			s.shutdownGame(gameID)
+		}(gameID)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.log.Warnw("shutdown timed out before every game was handled")
+	}
+
+	s.flushNotifications(ctx)
+}
+
+// shutdownGame applies gameID's shutdownPolicy.
+func (s *Server) shutdownGame(gameID string) {
+	game := s.gameConfigForRuntimeOwnership(gameID)
+	policy := game.ShutdownPolicy
+	if policy == "" {
+		s.mu.RLock()
+		policy = s.shutdownPolicy
+		s.mu.RUnlock()
+	}
+
+	switch policy {
+	case config.ShutdownPolicyGracefulStop, config.ShutdownPolicyKill:
+		force := policy == config.ShutdownPolicyKill
+		if _, err := s.stopGameVerified(game, force, true); err != nil {
+			// The game may still use its endpoint, so bridge.json stays
+			s.processLog.Warnw("failed to stop game on shutdown", "gameId", gameID, "policy", policy, "error", err)
+			return
+		}
+		s.CleanupBridgeConfig(gameID)
+		s.processLog.Infow("stopped game on shutdown", "gameId", gameID, "policy", policy)
+	default:
+		s.detachGame(gameID)
+		s.processLog.Infow("left game running on shutdown", "gameId", gameID)
+	}
+}
+
+// detachGame closes gameID's GABP connection and gives up ownership of its
+// runtime state, so another GABS session can games_connect at once. The
+// game keeps running and bridge.json stays, since the bridge still listens
+// on that endpoint.
+func (s *Server) detachGame(gameID string) {
+	s.mu.Lock()
+	s.cancelGameContextLocked(gameID)
+	s.cleanupGABPConnectionInternal(gameID)
+	s.mu.Unlock()
+
+	state, err := process.LoadRuntimeState(gameID, s.configDir)
+	if err != nil || state == nil {
+		return
+	}
+	if state.OwnerPID != os.Getpid() || state.OwnerInstanceID != s.instanceID {
+		return
+	}
+	state.OwnerPID = 0
+	state.OwnerInstanceID = ""
+	state.OwnerLeaseUntil = time.Time{}
+	if err := process.SaveRuntimeState(gameID, s.configDir, *state); err != nil {
+		s.log.Warnw("failed to release runtime ownership on shutdown", "gameId", gameID, "error", err)
+	}
+}
+
+// waitForToolCalls waits until no tools/call handler runs. It reports false
+// when ctx ended first.
+func (s *Server) waitForToolCalls(ctx context.Context) bool {
+	s.mu.RLock()
+	slots := s.callSlots
+	s.mu.RUnlock()
+	return waitUntil(ctx, func() bool { return len(slots) == 0 })
+}
+
+// flushNotifications waits until every client wrote its queued
+// notifications.
+func (s *Server) flushNotifications(ctx context.Context) {
+	waitUntil(ctx, func() bool {
+		s.clientsMu.RLock()
+		defer s.clientsMu.RUnlock()
+		for _, client := range s.clients {
+			if len(client.outbox) > 0 {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// waitUntil polls done until it is true or ctx ends, and reports which.
+func waitUntil(ctx context.Context, done func() bool) bool {
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+	for !done() {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+	return true
+}
+
+// shuttingDownResult is what tool calls made during Shutdown get.
+func shuttingDownResult(toolName string) *ToolResult {
+	return &ToolResult{
+		Content: []Content{{Type: "text", Text: fmt.Sprintf("GABS is shutting down; tool '%s' was not run.", toolName)}},
+		IsError: true,
+	}
+}
@@ -0,0 +1,119 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestShutdownWaitsForRunningToolCallsAndRefusesNewOnes(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	release := make(chan struct{})
+	registerBlockingToolForTest(server, release)
+
+	running := make(chan *Message, 1)
+	go func() {
+		running <- server.HandleMessage(&Message{JSONRPC: "2.0", ID: 1, Method: "tools/call", Params: map[string]interface{}{"name": "test.block"}})
+	}()
+	waitUntil(context.Background(), func() bool { return len(server.callSlots) == 1 })
+
+	shutDown := make(chan struct{})
+	go func() {
+		server.Shutdown(context.Background())
+		close(shutDown)
+	}()
+	waitUntil(context.Background(), server.shuttingDown.Load)
+
+	refused := server.HandleMessage(&Message{JSONRPC: "2.0", ID: 2, Method: "tools/call", Params: map[string]interface{}{"name": "test.block"}})
+	result, _ := refused.Result.(*ToolResult)
+	if result == nil || !result.IsError || !strings.Contains(result.Content[0].Text, "shutting down") {
+		t.Fatalf("expected a new call to be refused during shutdown, got %#v", refused)
+	}
+	select {
+	case <-shutDown:
+		t.Fatal("expected Shutdown to wait for the running tool call")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if response := <-running; response.Error != nil {
+		t.Fatalf("expected the running call to finish, got %#v", response.Error)
+	}
+	select {
+	case <-shutDown:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Shutdown to return once the call finished")
+	}
+}
+
+// writeBridgeFileForTest writes a placeholder bridge.json for gameID and
+// returns its path.
+func writeBridgeFileForTest(t *testing.T, configDir, gameID string) string {
+	t.Helper()
+	cp, err := config.NewConfigPaths(configDir)
+	if err != nil {
+		t.Fatalf("config paths: %v", err)
+	}
+	if err := cp.EnsureGameDir(gameID); err != nil {
+		t.Fatalf("game dir: %v", err)
+	}
+	path := cp.GetBridgeConfigPath(gameID)
+	if err := os.WriteFile(path, []byte(`{"port":49234,"token":"t","gameId":"`+gameID+`"}`), 0600); err != nil {
+		t.Fatalf("write bridge.json: %v", err)
+	}
+	return path
+}
+
+func TestShutdownLeavesGameRunningAndReleasesOwnership(t *testing.T) {
+	requireSleepForTest(t)
+	game := sleepingGameForTest("factory", "Factory")
+	server, configDir := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{"factory": game}})
+	trackSleepingGameForTest(t, server, "factory")
+	if _, err := server.saveRuntimeOwnerLease(game, nil, 0); err != nil {
+		t.Fatalf("save runtime state: %v", err)
+	}
+	bridgePath := writeBridgeFileForTest(t, configDir, "factory")
+
+	server.Shutdown(context.Background())
+
+	server.mu.RLock()
+	controller, _ := server.sessions["factory"].process()
+	server.mu.RUnlock()
+	if !controller.IsRunning() {
+		t.Fatal("expected the game to keep running")
+	}
+	if _, err := os.Stat(bridgePath); err != nil {
+		t.Fatalf("expected bridge.json to stay for the running game: %v", err)
+	}
+	state, err := process.LoadRuntimeState("factory", configDir)
+	if err != nil || state == nil {
+		t.Fatalf("expected runtime state to stay, got %v, %v", state, err)
+	}
+	if state.OwnerPID != 0 || state.OwnerInstanceID != "" {
+		t.Fatalf("expected runtime ownership to be released, got pid %d instance %q", state.OwnerPID, state.OwnerInstanceID)
+	}
+}
+
+func TestShutdownKillsGamesWithKillPolicy(t *testing.T) {
+	requireSleepForTest(t)
+	game := sleepingGameForTest("factory", "Factory")
+	game.ShutdownPolicy = config.ShutdownPolicyKill
+	server, configDir := newGamesTestServer(t, &config.GamesConfig{Games: map[string]config.GameConfig{"factory": game}})
+	trackSleepingGameForTest(t, server, "factory")
+	bridgePath := writeBridgeFileForTest(t, configDir, "factory")
+
+	server.Shutdown(context.Background())
+
+	if status := server.checkGameStatus("factory"); status != "stopped" {
+		t.Fatalf("expected the game to be stopped, got %q", status)
+	}
+	if _, err := os.Stat(bridgePath); !os.IsNotExist(err) {
+		t.Fatalf("expected bridge.json of the stopped game to be removed, got %v", err)
+	}
+}
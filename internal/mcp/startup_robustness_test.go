@@ -244,3 +244,58 @@ func withMaxSynchronousStartupGABPWait(t *testing.T, value time.Duration) func()
 func shellQuote(value string) string {
 	return "'" + strings.ReplaceAll(value, "'", "'\\''") + "'"
 }
+
+func TestGamesStartWaitForGabpBlocksUntilToolsAreMirrored(t *testing.T) {
+	requireSleepForTest(t)
+	restoreWait := withMaxSynchronousStartupGABPWait(t, 75*time.Millisecond)
+	defer restoreWait()
+
+	server, configDir := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{"adventure": sleepingGameForTest("adventure", "AdventureGame")},
+	})
+	reserved, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve bridge port: %v", err)
+	}
+	port := reserved.Addr().(*net.TCPAddr).Port
+	reserved.Close()
+	writeBridgeJSONForTest(t, configDir, "adventure", port, "wait-token")
+
+	// The bridge comes up well after the bounded synchronous slice.
+	sessionDone := make(chan error, 1)
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+			if err == nil {
+				defer listener.Close()
+				serveTestGabpToolsSession(listener, "wait-token", []string{"world/status", "world/reload"}, true, sessionDone)
+				return
+			}
+			if time.Now().After(deadline) {
+				sessionDone <- err
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	result := callToolForTest(t, server, "games_start", map[string]interface{}{"gameId": "adventure", "timeout": 5, "waitForGabp": true})
+	t.Cleanup(func() {
+		if game, ok := server.gamesConfig.GetGame("adventure"); ok {
+			server.stopGame(*game, true)
+		}
+	})
+	if result.IsError || result.StructuredContent["gabpConnected"] != true {
+		t.Fatalf("expected games_start to wait for GABP, got %#v", result)
+	}
+	if result.StructuredContent["toolCount"] != float64(2) || !strings.Contains(result.Content[0].Text, "with 2 game tools") {
+		t.Fatalf("expected the mirrored tool count in the result, got %#v", result)
+	}
+
+	server.CleanupGABPConnection("adventure")
+	if err := <-sessionDone; err != nil {
+		t.Fatalf("GABP session failed: %v", err)
+	}
+}
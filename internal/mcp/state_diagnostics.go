@@ -150,12 +150,17 @@ func readableProcessEnvLacksAttachableBridgeEndpoint(game config.GameConfig, pro
 }
 
 func platformManagedLaunchModeNeedsVisibleBridgeEnvironment(game config.GameConfig) bool {
-	switch game.LaunchMode {
-	case "SteamManaged", "SteamAppId", "EpicAppId":
-		return true
-	default:
-		return false
+	return launchStoreName(game.LaunchMode) != ""
+}
+
+// launchStoreName returns the store client games in mode are launched
+// through, or "" when GABS starts them itself.
+func launchStoreName(mode string) string {
+	launcher, exists := process.LauncherFor(mode)
+	if !exists {
+		return ""
 	}
+	return launcher.StopHints().Store
 }
 
 func processBridgeEnvironmentMissingMessage(game config.GameConfig, processEnv processEnvDiagnostic) string {
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"sort"
 	"strconv"
@@ -14,9 +15,11 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/pardeike/gabs/internal/chaos"
 	"github.com/pardeike/gabs/internal/config"
 	"github.com/pardeike/gabs/internal/gabp"
 	"github.com/pardeike/gabs/internal/process"
+	"github.com/pardeike/gabs/internal/tunnel"
 	"github.com/pardeike/gabs/internal/util"
 	"github.com/pardeike/gabs/internal/version"
 )
@@ -24,25 +27,73 @@ import (
 // Server runs MCP over stdio.
 type Server struct {
 	log               util.Logger
+	gabpLog           util.Logger // GABP connections, as the "gabp" log subsystem
+	processLog        util.Logger // Game processes, as the "process" log subsystem
 	tools             map[string]*ToolHandler
 	resources         map[string]*ResourceHandler
-	games             map[string]process.ControllerInterface // Track running games
-	configDir         string                                 // Config directory for bridge files
-	apiKey            string                                 // API key for HTTP authentication
+	sessions          map[string]*gameSession // Process, GABP client, tools and resources per game
+	configDir         string                  // Config directory for bridge files
+	apiKey            string                  // API key for HTTP authentication
 	mu                sync.RWMutex
-	writers           []util.FrameWriter       // Track client connections for notifications
-	writersMu         sync.RWMutex             // Protect writers slice
-	gameTools         map[string][]string      // Track which tools belong to which games
-	gameToolAliases   map[string]gameToolAlias // Resolve strict-safe and legacy names back to GABP names
-	gameResources     map[string][]string      // Track which resources belong to which games
-	gabpClients       map[string]*gabp.Client  // Track GABP connections per game
+	clients           map[string]*clientConn       // Connected clients that receive notifications
+	resumable         []*mcpSession                // HTTP sessions that keep notifications for reconnecting SSE streams
+	clientSessions    map[string]*mcpSession       // Initialized MCP sessions by session ID, for server_sessions
+	clientsMu         sync.RWMutex                 // Protects clients, resumable and clientSessions
+	gameToolAliases   map[string]gameToolAlias     // Resolve strict-safe and legacy names back to GABP names
+	toolCollisions    map[string]toolNameCollision // Tools registered under a disambiguated name, by that name
 	gabpAttention     map[string]*gameAttentionState
+	gabpEvents        map[string]*gameEventBridge
+	gameAlerts        map[string]*gameAlertLog // Alerts raised by GABP events, per game
+	gameChats         map[string][]chatEntry   // Recent chat messages, per game
+	toolWatches       map[string]*gabp.Client  // Bridge whose tool changes are followed, per game
+	toolResyncMu      sync.Mutex               // Serializes resyncGABPTools
+	gameLifetimes     map[string]gameLifetime
+	gabpTargets       map[string]gabpReconnectTarget
 	gabpDisconnects   map[string]gabpDisconnectRecord
 	starter           *process.SerializedStarter // Serialized process starter
 	gamesConfig       *config.GamesConfig
+	gameLogs          map[string]*process.GameLog // Captured stdout and stderr per game
 	instanceID        string
 	ownerLease        time.Duration
-	stripOutputSchema bool // Strip outputSchema from tools/list responses
+	bridgeTimeout     time.Duration               // Overrides the requestTimeout limit of GABP bridges, 0 to keep it
+	stripOutputSchema bool                        // Strip outputSchema from tools/list responses
+	toolsListPageSize int                         // Tools per tools/list page, 0 for a single page
+	advertisedTools   map[string]bool             // Games whose mirrored tools tools/list includes
+	lenientArguments  bool                        // Forward tool arguments that break the input schema instead of rejecting them
+	maxMessageSize    int                         // Largest MCP or GABP message accepted, 0 for each transport's default
+	chaos             *chaos.Injector             // Developer-only failure injection, nil unless --chaos is set
+	gabpRecorder      *gabp.Recorder              // Records every GABP session, nil unless --gabp-record is set
+	tunnels           map[string]*tunnel.Tunnel   // SSH port-forwards for games with sshTunnel
+	tunnelSetup       map[string]*sync.Mutex      // Serializes opening a game's SSH tunnel
+	strictMCP         bool                        // Enforce strict MCP/JSON-RPC protocol checks
+	httpSessions      map[string]*mcpSession      // Protocol state per HTTP client, keyed by Mcp-Session-Id
+	clock             util.Clock                  // Time source for cluster start delays and process polling
+	stopCandidates    map[string]stopSuggestion   // Process names that appeared after a launcher start
+	budgetMu          sync.Mutex                  // Protects budgetUsage
+	budgetUsage       map[string][]time.Time      // Call times counted against tool budgets
+	toolCacheMu       sync.Mutex                  // Protects toolCache
+	toolCache         map[string]cachedToolAnswer // Reusable answers of cacheable game tools, see toolCacheKey
+	gameProgress      map[string][]*mcpProgress   // Calls forwarding each game's GABP progress events
+	callSlots         chan struct{}               // One entry per tools/call handler running
+	toolTimeout       time.Duration               // Limit for each tools/call, 0 for none
+	serveCtx          context.Context             // Cancelled when the serving transport shuts down
+	serveTransport    string                      // "stdio" or "http" once a transport is serving
+	metrics           *serverMetrics              // Served on /metrics in HTTP mode
+	usage             *process.UsageMonitor       // Turns game CPU times into CPU percentages
+	scheduleRuns      map[string]scheduleRun      // Last scheduled action per gameId/scheduleId
+	instances         map[string]string           // Extra instance IDs and the game each belongs to
+	fileAPIKeys       []config.APIKeyConfig       // HTTP API keys loaded from --api-key-file
+	rateLimitMu       sync.Mutex                  // Protects rateLimitUsage
+	rateLimitUsage    map[string][]time.Time      // HTTP request times per rate-limited API key
+	confirmMu         sync.Mutex                  // Protects confirmations
+	confirmations     map[string]pendingConfirm   // Tool calls waiting for games.confirm, by token
+	lifecycleMu       sync.Mutex                  // Protects pendingExits and lifecycleBusy
+	pendingExits      map[string][]gameExit       // Exit events waiting for each game's lifecycle worker
+	lifecycleBusy     map[string]bool             // Games whose lifecycle worker is running
+	tokenRotationMu   sync.Mutex                  // Serializes bridge token rotations
+	savesMu           sync.Mutex                  // Serializes save backups and restores
+	shutdownPolicy    string                      // Default shutdownPolicy for games without one
+	shuttingDown      atomic.Bool                 // Set once Shutdown began; new tool calls are refused
 }
 
 type gabpDisconnectRecord struct {
@@ -82,53 +133,70 @@ func (e *gameAlreadyActiveError) ToolMessage(game config.GameConfig) string {
 type ToolHandler struct {
 	Tool    Tool
 	Handler func(args map[string]interface{}) (*ToolResult, error)
+	// CallHandler, when set, is used instead of Handler for tools/call
+	// requests, so the tool can report progress and notice cancellation.
+	CallHandler func(args map[string]interface{}, call *toolCall) (*ToolResult, error)
+
+	sourceName string // Name the tool was registered with, before normalization
 }
 
 // ResourceHandler represents a resource handler function
 type ResourceHandler struct {
 	Resource Resource
 	Handler  func() ([]Content, error)
+	// Query, when set, answers reads of the resource URI with a ?query suffix.
+	Query func(query url.Values) ([]Content, error)
 }
 
 func NewServer(log util.Logger) *Server {
-	return &Server{
-		log:             log,
+	s := &Server{
+		log:             util.Subsystem(log, "mcp"),
+		gabpLog:         util.Subsystem(log, "gabp"),
+		processLog:      util.Subsystem(log, "process"),
 		tools:           make(map[string]*ToolHandler),
 		resources:       make(map[string]*ResourceHandler),
-		games:           make(map[string]process.ControllerInterface),
+		sessions:        make(map[string]*gameSession),
 		configDir:       "", // Will be set by SetConfigDir
-		writers:         make([]util.FrameWriter, 0),
-		gameTools:       make(map[string][]string),
+		clients:         make(map[string]*clientConn),
 		gameToolAliases: make(map[string]gameToolAlias),
-		gameResources:   make(map[string][]string),
-		gabpClients:     make(map[string]*gabp.Client),
 		gabpAttention:   make(map[string]*gameAttentionState),
 		gabpDisconnects: make(map[string]gabpDisconnectRecord),
+		tunnels:         make(map[string]*tunnel.Tunnel),
 		starter:         process.NewSerializedStarter(), // Initialize serialized starter
+		clock:           util.NewRealClock(),
 		instanceID:      newServerInstanceID(),
 		ownerLease:      (&config.GamesConfig{}).GetSessionOwnerLease(),
+		usage:           process.NewUsageMonitor(nil),
+		callSlots:       make(chan struct{}, DefaultMaxConcurrentCalls),
 	}
+	s.metrics = newServerMetrics(s)
+	return s
 }
 
 // NewServerForTesting creates a server with shorter timeouts for testing
 func NewServerForTesting(log util.Logger) *Server {
-	return &Server{
-		log:             log,
+	s := &Server{
+		log:             util.Subsystem(log, "mcp"),
+		gabpLog:         util.Subsystem(log, "gabp"),
+		processLog:      util.Subsystem(log, "process"),
 		tools:           make(map[string]*ToolHandler),
 		resources:       make(map[string]*ResourceHandler),
-		games:           make(map[string]process.ControllerInterface),
+		sessions:        make(map[string]*gameSession),
 		configDir:       "", // Will be set by SetConfigDir
-		writers:         make([]util.FrameWriter, 0),
-		gameTools:       make(map[string][]string),
+		clients:         make(map[string]*clientConn),
 		gameToolAliases: make(map[string]gameToolAlias),
-		gameResources:   make(map[string][]string),
-		gabpClients:     make(map[string]*gabp.Client),
 		gabpAttention:   make(map[string]*gameAttentionState),
 		gabpDisconnects: make(map[string]gabpDisconnectRecord),
+		tunnels:         make(map[string]*tunnel.Tunnel),
 		starter:         process.NewSerializedStarterForTesting(), // Use testing timeouts
+		clock:           util.NewRealClock(),
 		instanceID:      newServerInstanceID(),
 		ownerLease:      (&config.GamesConfig{}).GetSessionOwnerLease(),
+		usage:           process.NewUsageMonitor(nil),
+		callSlots:       make(chan struct{}, DefaultMaxConcurrentCalls),
 	}
+	s.metrics = newServerMetrics(s)
+	return s
 }
 
 func newServerInstanceID() string {
@@ -157,7 +225,7 @@ func (s *Server) runtimeOwnerLeaseForOperation(operationTimeout time.Duration) t
 
 func (s *Server) gameConfigForRuntimeOwnership(gameID string) config.GameConfig {
 	if s.gamesConfig != nil {
-		if game, exists := s.gamesConfig.GetGame(gameID); exists {
+		if game, exists := s.lookupGame(s.gamesConfig, gameID); exists {
 			return *game
 		}
 	}
@@ -339,6 +407,30 @@ func parseOptionalBoolArg(args map[string]interface{}, key string) (bool, bool,
 	}
 }
 
+// parseOptionalStringArg returns a trimmed string argument. Missing, null and
+// blank values are reported as absent.
+func parseOptionalStringArg(args map[string]interface{}, key string) (string, bool, *ToolResult) {
+	raw, exists := args[key]
+	if !exists || raw == nil {
+		return "", false, nil
+	}
+
+	value, ok := raw.(string)
+	if !ok {
+		return "", false, &ToolResult{
+			Content: []Content{{Type: "text", Text: fmt.Sprintf("Argument '%s' must be a string", key)}},
+			IsError: true,
+		}
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", false, nil
+	}
+
+	return value, true, nil
+}
+
 func parseOptionalTimeoutSecondsArg(args map[string]interface{}, key string, defaultValue time.Duration) (time.Duration, *ToolResult) {
 	raw, exists := args[key]
 	if !exists || raw == nil {
@@ -380,6 +472,15 @@ func deriveMirroredToolCallTimeout(args map[string]interface{}, defaultValue tim
 	return timeout, nil
 }
 
+// gabpCallTimeout returns timeout, or the request timeout of client when the
+// caller did not ask for one.
+func gabpCallTimeout(client *gabp.Client, timeout time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+	return client.RequestTimeout()
+}
+
 var maxSynchronousStartupGABPWait = 20 * time.Second
 
 type bridgeEndpoint struct {
@@ -400,22 +501,28 @@ type startupConnectResult struct {
 func bridgeEndpointInUseResult(game config.GameConfig, endpointErr *config.BridgeEndpointInUseError) *ToolResult {
 	gameArg := map[string]interface{}{"gameId": game.ID}
 	resetArg := map[string]interface{}{"gameId": game.ID, "resetEndpoint": true}
+	listening := "that port is already listening"
+	structured := map[string]interface{}{
+		"gameId": game.ID,
+		"status": "endpoint_cache_in_use",
+		"port":   endpointErr.Port,
+		"nextActions": []map[string]interface{}{
+			mcpNextAction("games_status", gameArg, "Inspect runtime ownership and process status."),
+			mcpNextAction("games_connect", gameArg, "Attach if an already-running game-side bridge owns the cached endpoint."),
+			mcpNextAction("games_start", resetArg, "Rotate the endpoint cache and start a new process only after confirming the cached endpoint is not an existing game."),
+		},
+	}
+	if owner, seen := process.FindPortListener(endpointErr.Port); seen {
+		listening = fmt.Sprintf("%s is already listening on it", owner)
+		structured["listener"] = portListenerStructured(owner)
+	}
 	return &ToolResult{
 		Content: []Content{{
 			Type: "text",
-			Text: fmt.Sprintf("GABS endpoint cache for game '%s' uses port %d, but that port is already listening. This session did not start another process because the cached endpoint may belong to an already-running game-side bridge. Use games_connect to attach, or start again with resetEndpoint only after confirming that the cached endpoint should be rotated.", game.ID, endpointErr.Port),
+			Text: fmt.Sprintf("GABS endpoint cache for game '%s' uses port %d, but %s. This session did not start another process because the cached endpoint may belong to an already-running game-side bridge. Use games_connect to attach, or start again with resetEndpoint only after confirming that the cached endpoint should be rotated.", game.ID, endpointErr.Port, listening),
 		}},
-		StructuredContent: map[string]interface{}{
-			"gameId": game.ID,
-			"status": "endpoint_cache_in_use",
-			"port":   endpointErr.Port,
-			"nextActions": []map[string]interface{}{
-				mcpNextAction("games_status", gameArg, "Inspect runtime ownership and process status."),
-				mcpNextAction("games_connect", gameArg, "Attach if an already-running game-side bridge owns the cached endpoint."),
-				mcpNextAction("games_start", resetArg, "Rotate the endpoint cache and start a new process only after confirming the cached endpoint is not an existing game."),
-			},
-		},
-		IsError: true,
+		StructuredContent: structured,
+		IsError:           true,
 	}
 }
 
@@ -443,12 +550,21 @@ func (s *Server) RegisterTool(tool Tool, handler func(args map[string]interface{
 
 // RegisterToolWithConfig registers a tool with its handler, applying normalization based on config
 func (s *Server) RegisterToolWithConfig(tool Tool, handler func(args map[string]interface{}) (*ToolResult, error), normalizationConfig *config.ToolNormalizationConfig) {
+	s.registerTool(tool, handler, nil, normalizationConfig)
+}
+
+// registerTool registers tool and returns the name it was registered under,
+// which differs from tool.Name when normalization changed it or another tool
+// already held it.
+func (s *Server) registerTool(tool Tool, handler func(args map[string]interface{}) (*ToolResult, error), callHandler func(args map[string]interface{}, call *toolCall) (*ToolResult, error), normalizationConfig *config.ToolNormalizationConfig) string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// Apply normalization if configured
 	registeredTool := tool
+	maxLength := 0
 	if normalizationConfig != nil && normalizationConfig.EnableOpenAINormalization {
+		maxLength = normalizationConfig.MaxToolNameLength
 		normalizedResult := util.NormalizeToolNameForOpenAI(tool.Name, normalizationConfig.MaxToolNameLength)
 
 		if normalizedResult.WasNormalized {
@@ -472,10 +588,23 @@ func (s *Server) RegisterToolWithConfig(tool Tool, handler func(args map[string]
 		}
 	}
 
+	if name := s.disambiguateToolNameLocked(registeredTool, tool.Name, maxLength); name != registeredTool.Name {
+		meta := make(map[string]interface{}, len(registeredTool.Meta)+1)
+		for key, value := range registeredTool.Meta {
+			meta[key] = value
+		}
+		meta["originalName"] = tool.Name
+		registeredTool.Meta = meta
+		registeredTool.Name = name
+	}
+
 	s.tools[registeredTool.Name] = &ToolHandler{
-		Tool:    registeredTool,
-		Handler: handler,
+		Tool:        registeredTool,
+		Handler:     handler,
+		CallHandler: callHandler,
+		sourceName:  tool.Name,
 	}
+	return registeredTool.Name
 }
 
 // RegisterResource registers a resource with its handler
@@ -498,11 +627,24 @@ func (s *Server) SetAPIKey(apiKey string) {
 	s.apiKey = apiKey
 }
 
+// AddAPIKeys accepts further HTTP API keys next to those in config.json, such
+// as the ones read from --api-key-file.
+func (s *Server) AddAPIKeys(keys []config.APIKeyConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fileAPIKeys = append(s.fileAPIKeys, keys...)
+}
+
 // RegisterGameManagementTools registers the game management tools for the new architecture
 func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, backoffMin, backoffMax time.Duration) {
 	s.stripOutputSchema = gamesConfig.StripOutputSchema
+	s.toolsListPageSize = gamesConfig.ToolsListPageSize
+	s.advertiseConfiguredTools(gamesConfig.AdvertiseTools)
+	s.lenientArguments = gamesConfig.LenientArguments
+	s.maxMessageSize = gamesConfig.MaxMessageBytes
 	s.gamesConfig = gamesConfig
 	s.ownerLease = gamesConfig.GetSessionOwnerLease()
+	s.bridgeTimeout = gamesConfig.GetBridgeRequestTimeout()
 	normalizationConfig := gamesConfig.GetToolNormalization()
 	if gamesConfig.Timeouts != nil && gamesConfig.Timeouts.Startup != nil {
 		processStartTimeout, gabpConnectTimeout := gamesConfig.GetStartupTimeouts()
@@ -535,12 +677,19 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 		gameItems := make([]map[string]interface{}, 0, len(games))
 		for _, game := range games {
 			item := map[string]interface{}{
-				"gameId": game.ID,
-				"name":   game.Name,
+				"gameId":    game.ID,
+				"name":      game.Name,
+				"toolCount": len(s.getGameSpecificTools(game.ID)),
 			}
 			if game.Description != "" {
 				item["description"] = game.Description
 			}
+			if len(game.Profiles) > 0 {
+				item["profiles"] = game.ProfileNames()
+			}
+			for key, value := range s.gameStateStructured(game.ID, s.checkGameStatus(game.ID)) {
+				item[key] = value
+			}
 			gameItems = append(gameItems, item)
 		}
 
@@ -548,6 +697,19 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			"count": len(games),
 			"games": gameItems,
 		}
+		if clusters := gamesConfig.ListClusters(); len(clusters) > 0 {
+			content.WriteString("\n\nClusters:")
+			clusterItems := make([]map[string]interface{}, 0, len(clusters))
+			for _, cluster := range clusters {
+				content.WriteString(fmt.Sprintf("\n%s (%s)", cluster.ID, strings.Join(cluster.Members, ", ")))
+				clusterItems = append(clusterItems, map[string]interface{}{
+					"clusterId": cluster.ID,
+					"name":      cluster.Name,
+					"members":   cluster.Members,
+				})
+			}
+			structured["clusters"] = clusterItems
+		}
 		if len(games) == 0 {
 			structured["nextActions"] = []map[string]interface{}{
 				{
@@ -610,9 +772,17 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 		if len(game.Args) > 0 {
 			content.WriteString(fmt.Sprintf("  Arguments: %s\n", strings.Join(game.Args, " ")))
 		}
+		for _, name := range game.ProfileNames() {
+			profile := game.Profiles[name]
+			marker := ""
+			if name == game.DefaultProfile {
+				marker = " (default)"
+			}
+			content.WriteString(fmt.Sprintf("  Profile %s%s: %s\n", name, marker, strings.Join(profile.Args, " ")))
+		}
 
-		// Validation status for launcher-based games
-		if game.LaunchMode == "SteamAppId" || game.LaunchMode == "EpicAppId" {
+		// Validation status for store launcher games
+		if config.IsStoreLaunchMode(game.LaunchMode) {
 			content.WriteString("\nGame Termination Configuration:\n")
 			if game.StopProcessName != "" {
 				content.WriteString(fmt.Sprintf("  ✓ Configured for proper game termination (process: %s)\n", game.StopProcessName))
@@ -624,6 +794,18 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			content.WriteString(fmt.Sprintf("  Stop Process Name: %s\n", game.StopProcessName))
 		}
 
+		if game.Proton != nil {
+			content.WriteString(fmt.Sprintf("  Proton: %s\n", game.Proton.Path))
+		}
+		if game.Remote != nil {
+			content.WriteString(fmt.Sprintf("  Remote agent: %s\n", game.Remote.URL))
+		}
+		if game.SSHTunnel != nil {
+			content.WriteString(fmt.Sprintf("  GABP via SSH tunnel: %s\n", game.SSHTunnel.Host))
+		}
+		if game.ListensForBridge() {
+			content.WriteString("  GABP: GABS listens, the bridge connects in\n")
+		}
 		if game.Description != "" {
 			content.WriteString(fmt.Sprintf("\nDescription: %s\n", game.Description))
 		}
@@ -643,6 +825,10 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			"validationWarnings": validationWarnings,
 			"nextActions":        s.nextActionsForGameStatus(*game, status, len(s.getGameSpecificTools(game.ID))),
 		}
+		if bridge := s.connectedBridgeStructured(game.ID); bridge != nil {
+			structured["bridge"] = bridge
+			content.WriteString(fmt.Sprintf("\n%s\n", bridgeSummaryText(bridge)))
+		}
 
 		return &ToolResult{
 			Content:           []Content{{Type: "text", Text: content.String()}},
@@ -659,7 +845,11 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			"properties": map[string]interface{}{
 				"gameId": map[string]interface{}{
 					"type":        "string",
-					"description": "Game ID or launch target to check (optional, checks all if not provided)",
+					"description": "Game ID, cluster ID or launch target to check (optional, checks all if not provided)",
+				},
+				"instanceId": map[string]interface{}{
+					"type":        "string",
+					"description": "Only check this instance of the game, as returned by games_start",
 				},
 			},
 		},
@@ -668,6 +858,10 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 
 		var content strings.Builder
 		if hasGameID {
+			if cluster, ok := gamesConfig.GetCluster(gameIdOrTarget); ok {
+				return s.clusterStatusResult(gamesConfig, *cluster), nil
+			}
+
 			// Check specific game
 			game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
 			if !exists {
@@ -677,11 +871,30 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 				}, nil
 			}
 
+			instanceID, _ := args["instanceId"].(string)
+			instanceID = strings.TrimSpace(instanceID)
+			if instanceID != "" {
+				targets, invalid := s.instanceTargets(*game, instanceID)
+				if invalid != nil {
+					return invalid, nil
+				}
+				game = &targets[0]
+			}
+
 			// Get status once to avoid double mutex lock
 			status := s.checkGameStatus(game.ID)
 			statusDesc := s.getStatusDescriptionFromStatus(status, game)
 			statusItem := s.gameStatusStructured(*game, status)
 			content.WriteString(fmt.Sprintf("**%s** (%s): %s\n", game.ID, game.Name, statusDesc))
+			if profile, ok := statusItem["profile"].(string); ok {
+				content.WriteString(fmt.Sprintf("Launch profile: %s\n", profile))
+			}
+			if bridge, ok := statusItem["bridge"].(map[string]interface{}); ok {
+				content.WriteString(bridgeSummaryText(bridge) + "\n")
+			}
+			if resources, ok := statusItem["resources"].(map[string]interface{}); ok {
+				content.WriteString(resourceUsageSummaryText(resources) + "\n")
+			}
 			if diagnosticMessage := gameStateDiagnosticMessage(statusItem); diagnosticMessage != "" {
 				content.WriteString(fmt.Sprintf("\nDiagnosis: %s\n", diagnosticMessage))
 			}
@@ -690,14 +903,23 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			}
 
 			// Add helpful info for launcher games ONLY when we cannot track them
-			if game.LaunchMode == "SteamAppId" || game.LaunchMode == "EpicAppId" {
+			if config.IsStoreLaunchMode(game.LaunchMode) {
 				if status == "launcher-triggered" {
 					// Only show the warning if we don't have stopProcessName configured
 					if game.StopProcessName == "" {
-						content.WriteString(fmt.Sprintf("\nNote: %s game was launched, but GABS cannot track whether it's still running because no 'stopProcessName' is configured.\nCheck Steam/Epic or your system processes to verify the actual game status.\n", game.LaunchMode))
+						content.WriteString(fmt.Sprintf("\nNote: %s game was launched, but GABS cannot track whether it's still running because no 'stopProcessName' is configured.\nCheck %s or your system processes to verify the actual game status.\n", game.LaunchMode, launchStoreName(game.LaunchMode)))
 					}
 				}
 			}
+			if instanceID == "" {
+				if instances := s.instanceStatusItems(*game); len(instances) > 0 {
+					content.WriteString("\nOther instances:\n")
+					for _, item := range instances {
+						content.WriteString(fmt.Sprintf("• **%s**: %s\n", item["instanceId"], item["status"]))
+					}
+					statusItem["instances"] = instances
+				}
+			}
 			return &ToolResult{
 				Content:           []Content{{Type: "text", Text: content.String()}},
 				StructuredContent: statusItem,
@@ -711,12 +933,19 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 				status := s.checkGameStatus(game.ID)
 				statusDesc := s.getStatusDescriptionFromStatus(status, &game)
 				statusItem := s.gameStatusStructured(game, status)
+				if profile, ok := statusItem["profile"].(string); ok {
+					statusDesc = fmt.Sprintf("%s (profile %s)", statusDesc, profile)
+				}
 				if diagnosticMessage := gameStateDiagnosticMessage(statusItem); diagnosticMessage != "" {
 					content.WriteString(fmt.Sprintf("• **%s**: %s — %s\n", game.ID, statusDesc, diagnosticMessage))
 				} else {
 					content.WriteString(fmt.Sprintf("• **%s**: %s\n", game.ID, statusDesc))
 				}
 				statusItems = append(statusItems, statusItem)
+				for _, item := range s.instanceStatusItems(game) {
+					content.WriteString(fmt.Sprintf("• **%s** (instance of %s): %s\n", item["instanceId"], game.ID, item["status"]))
+					statusItems = append(statusItems, item)
+				}
 			}
 
 			return &ToolResult{
@@ -730,7 +959,7 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 	}, normalizationConfig)
 
 	// games_start tool
-	s.RegisterToolWithConfig(Tool{
+	s.registerCallTool(Tool{
 		Name:        "games.start",
 		Description: "Start a configured game using game ID or launch target (e.g., Steam App ID)",
 		InputSchema: map[string]interface{}{
@@ -738,20 +967,32 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			"properties": map[string]interface{}{
 				"gameId": map[string]interface{}{
 					"type":        "string",
-					"description": "Game ID or launch target (Steam App ID, path, etc.)",
+					"description": "Game ID, cluster ID or launch target (Steam App ID, path, etc.). A cluster starts its members in order.",
 				},
 				"timeout": map[string]interface{}{
 					"type":        "integer",
 					"description": "Optional total GABP startup connection budget in seconds. The MCP call waits only for a bounded initial slice, then GABS continues connecting in the background.",
 				},
+				"waitForGabp": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Block for the whole timeout until the GABP handshake completes and the game's tools are mirrored, then report how many tools are available. Defaults to false.",
+				},
 				"resetEndpoint": map[string]interface{}{
 					"type":        "boolean",
 					"description": "Rotate the GABS endpoint cache before launch. Use only after confirming the cached endpoint is not an already-running game-side bridge.",
 				},
+				"newInstance": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Start another instance when the game is already running, with its own bridge port. The result's instanceId names it for games_stop, games_kill, games_status and as gameId in other tools. DirectPath and CustomCommand games only.",
+				},
+				"profile": map[string]interface{}{
+					"type":        "string",
+					"description": "Launch profile to start with, as listed in the profiles of games_list. Defaults to the game's defaultProfile.",
+				},
 			},
 			"required": []string{"gameId"},
 		},
-	}, func(args map[string]interface{}) (*ToolResult, error) {
+	}, func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
 		gameIdOrTarget, ok := args["gameId"].(string)
 		if !ok {
 			return &ToolResult{
@@ -760,6 +1001,14 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			}, nil
 		}
 
+		startupGABPTimeout, invalidTimeout := parseOptionalTimeoutSecondsArg(args, "timeout", 0)
+		if invalidTimeout != nil {
+			return invalidTimeout, nil
+		}
+		if cluster, ok := gamesConfig.GetCluster(gameIdOrTarget); ok {
+			return s.startCluster(call.context(), gamesConfig, *cluster, backoffMin, backoffMax, startupGABPTimeout), nil
+		}
+
 		game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
 		if !exists {
 			return &ToolResult{
@@ -768,101 +1017,49 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			}, nil
 		}
 
-		startupGABPTimeout, invalidTimeout := parseOptionalTimeoutSecondsArg(args, "timeout", 0)
-		if invalidTimeout != nil {
-			return invalidTimeout, nil
-		}
 		resetEndpoint, _, resetEndpointErr := parseOptionalBoolArg(args, "resetEndpoint")
 		if resetEndpointErr != nil {
 			return resetEndpointErr, nil
 		}
+		waitForGABP, _, waitErr := parseOptionalBoolArg(args, "waitForGabp")
+		if waitErr != nil {
+			return waitErr, nil
+		}
 
-		validationWarnings := gameValidationWarnings(*game)
-		startResult, err := s.startGame(*game, gamesConfig, backoffMin, backoffMax, startupGABPTimeout, resetEndpoint)
-		if err != nil {
-			var activeErr *gameAlreadyActiveError
-			if errors.As(err, &activeErr) {
-				status := activeErr.status
-				if status == "" {
-					status = s.checkGameStatus(game.ID)
-				}
-				toolCount := len(s.getGameSpecificTools(game.ID))
-				structured := map[string]interface{}{
-					"gameId":      game.ID,
-					"status":      status,
-					"toolCount":   toolCount,
-					"nextActions": s.nextActionsForGameStatus(*game, status, toolCount),
-				}
-				addValidationWarnings(structured, validationWarnings)
-				return &ToolResult{
-					Content:           []Content{{Type: "text", Text: activeErr.ToolMessage(*game)}},
-					StructuredContent: structured,
-				}, nil
-			}
-			var endpointErr *config.BridgeEndpointInUseError
-			if errors.As(err, &endpointErr) {
-				return bridgeEndpointInUseResult(*game, endpointErr), nil
-			}
+		newInstance, _, newInstanceErr := parseOptionalBoolArg(args, "newInstance")
+		if newInstanceErr != nil {
+			return newInstanceErr, nil
+		}
 
+		profile, _ := args["profile"].(string)
+		profile = strings.TrimSpace(profile)
+		if _, _, err := game.WithProfile(profile); err != nil {
 			return &ToolResult{
-				Content: []Content{{Type: "text", Text: fmt.Sprintf("Failed to start %s: %v", game.ID, err)}},
+				Content: []Content{{Type: "text", Text: err.Error() + ". Use games_list to see the launch profiles of each game."}},
 				IsError: true,
 			}, nil
 		}
 
-		if startResult != nil && !startResult.GABPConnected {
-			message := fmt.Sprintf("Game '%s' (%s) started, but GABP was not ready after %s", game.ID, game.Name, startResult.GABPConnectWait.Round(time.Millisecond))
-			if startResult.GABPConnectError != nil {
-				message = fmt.Sprintf("%s: %v", message, startResult.GABPConnectError)
-			}
-			if startResult.BackgroundGABPConnect {
-				message = fmt.Sprintf("%s. GABS will keep trying in the background for up to %s. The game may still be loading or the GABP bridge may be missing. Use games_status, then games_connect once the bridge is ready.", message, startResult.BackgroundGABPWait.Round(time.Second))
-			} else {
-				message = fmt.Sprintf("%s. The game may still be loading or the GABP bridge may be missing. Use games_status, then games_connect once the bridge is ready.", message)
-			}
-			message = appendValidationWarningText(message, validationWarnings)
-			structured := map[string]interface{}{
-				"gameId":            game.ID,
-				"processStarted":    startResult.ProcessStarted,
-				"gabpConnected":     startResult.GABPConnected,
-				"gameStillRunning":  startResult.GameStillRunning,
-				"gabpWaitMs":        startResult.GABPConnectWait.Milliseconds(),
-				"backgroundConnect": startResult.BackgroundGABPConnect,
-				"backgroundWaitMs":  startResult.BackgroundGABPWait.Milliseconds(),
-				"gabpError": func() interface{} {
-					if startResult.GABPConnectError == nil {
-						return nil
-					}
-					return startResult.GABPConnectError.Error()
-				}(),
-				"nextActions": []map[string]interface{}{
-					mcpNextAction("games_status", map[string]interface{}{"gameId": game.ID}, "Verify whether the game is still running."),
-					mcpNextAction("games_connect", map[string]interface{}{"gameId": game.ID}, "Connect after the GABP bridge finishes loading."),
-				},
+		started := *game
+		if newInstance && s.checkGameStatus(game.ID) != "stopped" {
+			if refusal := multiInstanceRefusal(*game); refusal != nil {
+				return refusal, nil
 			}
-			addValidationWarnings(structured, validationWarnings)
-			return &ToolResult{
-				Content:           []Content{{Type: "text", Text: message}},
-				StructuredContent: structured,
-			}, nil
+			started = instanceGameConfig(*game, s.reserveInstanceID(gamesConfig, game.ID))
 		}
 
-		message := fmt.Sprintf("Game '%s' (%s) started successfully and connected via GABP.", game.ID, game.Name)
-		message = appendValidationWarningText(message, validationWarnings)
-		structured := map[string]interface{}{
-			"gameId":           game.ID,
-			"processStarted":   true,
-			"gabpConnected":    true,
-			"gameStillRunning": true,
-			"nextActions": []map[string]interface{}{
-				mcpNextAction("games_tool_names", map[string]interface{}{"gameId": game.ID, "brief": true}, "Discover connected game-specific tools."),
-			},
+		startResult, err := s.startGame(started, profile, gamesConfig, backoffMin, backoffMax, startupGABPTimeout, resetEndpoint, waitForGABP, call)
+		result := s.gameStartResult(started, startResult, err)
+		if !result.IsError && result.StructuredContent != nil {
+			result.StructuredContent["instanceId"] = started.ID
+			if active := s.activeProfile(started.ID); active != "" {
+				result.StructuredContent["profile"] = active
+			}
+			if instanceOf := s.instanceOf(started.ID); instanceOf != "" {
+				result.StructuredContent["gameId"] = instanceOf
+			}
 		}
-		addValidationWarnings(structured, validationWarnings)
-		return &ToolResult{
-			Content:           []Content{{Type: "text", Text: message}},
-			StructuredContent: structured,
-		}, nil
+		return result, nil
 	}, normalizationConfig)
 
 	// games.stop tool
@@ -874,7 +1071,15 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			"properties": map[string]interface{}{
 				"gameId": map[string]interface{}{
 					"type":        "string",
-					"description": "Game ID or launch target to stop",
+					"description": "Game ID, cluster ID or launch target to stop. A cluster stops its members in reverse order.",
+				},
+				"instanceId": map[string]interface{}{
+					"type":        "string",
+					"description": "Only stop this instance, as returned by games_start. Without it every running instance of the game is stopped.",
+				},
+				"escalate": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Kill the game if it is still running after the grace period. Defaults to true; with false the game is left running and the result reports it.",
 				},
 			},
 			"required": []string{"gameId"},
@@ -888,6 +1093,10 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			}, nil
 		}
 
+		if cluster, ok := gamesConfig.GetCluster(gameIdOrTarget); ok {
+			return s.stopCluster(gamesConfig, *cluster, false), nil
+		}
+
 		game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
 		if !exists {
 			return &ToolResult{
@@ -896,25 +1105,16 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			}, nil
 		}
 
-		err := s.stopGame(*game, false)
-		if err != nil {
-			// Check if this is a launcher-specific configuration issue
-			if strings.Contains(err.Error(), "Configure 'stopProcessName'") {
-				return &ToolResult{
-					Content: []Content{{Type: "text", Text: fmt.Sprintf("⚠️ %s\n\nTo fix this, update your game configuration to include a 'stopProcessName'. Use: gabs games show %s", err.Error(), game.ID)}},
-					IsError: true,
-				}, nil
-			}
-
-			return &ToolResult{
-				Content: []Content{{Type: "text", Text: fmt.Sprintf("Failed to stop %s: %v", game.ID, err)}},
-				IsError: true,
-			}, nil
+		instanceID, _ := args["instanceId"].(string)
+		targets, invalid := s.instanceTargets(*game, strings.TrimSpace(instanceID))
+		if invalid != nil {
+			return invalid, nil
 		}
-
-		return &ToolResult{
-			Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' (%s) stopped successfully", game.ID, game.Name)}},
-		}, nil
+		escalate := true
+		if value, ok := args["escalate"].(bool); ok {
+			escalate = value
+		}
+		return s.stopGameInstances(*game, targets, false, escalate), nil
 	}, normalizationConfig)
 
 	// games.kill tool
@@ -926,7 +1126,11 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			"properties": map[string]interface{}{
 				"gameId": map[string]interface{}{
 					"type":        "string",
-					"description": "Game ID or launch target to force terminate",
+					"description": "Game ID, cluster ID or launch target to force terminate",
+				},
+				"instanceId": map[string]interface{}{
+					"type":        "string",
+					"description": "Only kill this instance, as returned by games_start. Without it every running instance of the game is killed.",
 				},
 			},
 			"required": []string{"gameId"},
@@ -940,6 +1144,10 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			}, nil
 		}
 
+		if cluster, ok := gamesConfig.GetCluster(gameIdOrTarget); ok {
+			return s.stopCluster(gamesConfig, *cluster, true), nil
+		}
+
 		game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
 		if !exists {
 			return &ToolResult{
@@ -948,25 +1156,12 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			}, nil
 		}
 
-		err := s.stopGame(*game, true)
-		if err != nil {
-			// Check if this is a launcher-specific configuration issue
-			if strings.Contains(err.Error(), "Configure 'stopProcessName'") {
-				return &ToolResult{
-					Content: []Content{{Type: "text", Text: fmt.Sprintf("⚠️ %s\n\nTo fix this, update your game configuration to include a 'stopProcessName'. Use: gabs games show %s", err.Error(), game.ID)}},
-					IsError: true,
-				}, nil
-			}
-
-			return &ToolResult{
-				Content: []Content{{Type: "text", Text: fmt.Sprintf("Failed to kill %s: %v", game.ID, err)}},
-				IsError: true,
-			}, nil
+		instanceID, _ := args["instanceId"].(string)
+		targets, invalid := s.instanceTargets(*game, strings.TrimSpace(instanceID))
+		if invalid != nil {
+			return invalid, nil
 		}
-
-		return &ToolResult{
-			Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' (%s) terminated successfully", game.ID, game.Name)}},
-		}, nil
+		return s.stopGameInstances(*game, targets, true, false), nil
 	}, normalizationConfig)
 
 	type listedGameTool struct {
@@ -976,27 +1171,7 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 		LocalName     string
 	}
 
-	getOptionalStringArg := func(args map[string]interface{}, key string) (string, bool, *ToolResult) {
-		raw, exists := args[key]
-		if !exists || raw == nil {
-			return "", false, nil
-		}
-
-		value, ok := raw.(string)
-		if !ok {
-			return "", false, &ToolResult{
-				Content: []Content{{Type: "text", Text: fmt.Sprintf("Argument '%s' must be a string", key)}},
-				IsError: true,
-			}
-		}
-
-		value = strings.TrimSpace(value)
-		if value == "" {
-			return "", false, nil
-		}
-
-		return value, true, nil
-	}
+	getOptionalStringArg := parseOptionalStringArg
 
 	getOptionalBoolArg := parseOptionalBoolArg
 
@@ -1116,6 +1291,28 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 	listToolsForDiscovery := func(gameID string, hasGameID bool, forceInitialSync bool) ([]listedGameTool, *config.GameConfig, *ToolResult) {
 		entries := make([]listedGameTool, 0)
 
+		if cluster, ok := gamesConfig.GetCluster(gameID); hasGameID && ok {
+			for _, memberID := range cluster.Members {
+				if forceInitialSync {
+					if err := s.ensureGameToolsMirrored(memberID, 10*time.Second); err != nil {
+						s.log.Debugw("failed to sync GABP tools during cluster discovery", "clusterId", cluster.ID, "gameId", memberID, "error", err)
+					}
+				}
+				for _, tool := range s.getGameSpecificTools(memberID) {
+					localName := toolLocalName(memberID, tool)
+					canonicalName := toolCanonicalName(tool)
+					tool.Name = clusterToolName(cluster.ID, memberID, localName)
+					entries = append(entries, listedGameTool{
+						GameID:        memberID,
+						Tool:          tool,
+						CanonicalName: canonicalName,
+						LocalName:     localName,
+					})
+				}
+			}
+			return entries, nil, nil
+		}
+
 		if hasGameID {
 			game, exists := s.resolveGameId(gamesConfig, gameID)
 			if !exists {
@@ -1169,6 +1366,19 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 		return entries, nil, nil
 	}
 
+	// filterToolsByPolicy drops the game tools the tool policy or the
+	// session's tool profile keeps the caller from calling, so agents do not
+	// discover tools they cannot use.
+	filterToolsByPolicy := func(entries []listedGameTool, call *toolCall) []listedGameTool {
+		allowed := make([]listedGameTool, 0, len(entries))
+		for _, entry := range entries {
+			if s.toolAllowed(call.accessRole(), call.clientSession(), entry.GameID, gabpToolNameFromTool(entry.GameID, entry.Tool)) {
+				allowed = append(allowed, entry)
+			}
+		}
+		return allowed
+	}
+
 	filterListedTools := func(entries []listedGameTool, query, prefix string) []listedGameTool {
 		if query == "" && prefix == "" {
 			return entries
@@ -1258,6 +1468,12 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			if tags := toolMetaStringSlice(entry.Tool, toolMetaTags); len(tags) > 0 {
 				item["tags"] = tags
 			}
+			if category := toolMetaString(entry.Tool, toolMetaCategory); category != "" {
+				item["category"] = category
+			}
+			if title := toolMetaString(entry.Tool, toolMetaTitle); title != "" {
+				item["title"] = title
+			}
 			if brief {
 				if summary := toolBriefDescription(entry.Tool.Description); summary != "" {
 					item["summary"] = summary
@@ -1268,6 +1484,29 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 		return items
 	}
 
+	// buildToolGroups groups the names of entries by game and category, in
+	// the order they are listed, for clients that present tools grouped.
+	buildToolGroups := func(entries []listedGameTool) []map[string]interface{} {
+		groups := make([]map[string]interface{}, 0)
+		index := make(map[[2]string]int)
+		for _, entry := range entries {
+			category := toolMetaString(entry.Tool, toolMetaCategory)
+			key := [2]string{entry.GameID, category}
+			i, exists := index[key]
+			if !exists {
+				group := map[string]interface{}{"gameId": entry.GameID, "tools": []string{}}
+				if category != "" {
+					group["category"] = category
+				}
+				i = len(groups)
+				index[key] = i
+				groups = append(groups, group)
+			}
+			groups[i]["tools"] = append(groups[i]["tools"].([]string), entry.Tool.Name)
+		}
+		return groups
+	}
+
 	buildDetailedToolItems := func(entries []listedGameTool) []map[string]interface{} {
 		items := make([]map[string]interface{}, 0, len(entries))
 		for _, entry := range entries {
@@ -1288,6 +1527,13 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			if tags := toolMetaStringSlice(entry.Tool, toolMetaTags); len(tags) > 0 {
 				item["tags"] = tags
 			}
+			if category := toolMetaString(entry.Tool, toolMetaCategory); category != "" {
+				item["category"] = category
+			}
+			if title := toolMetaString(entry.Tool, toolMetaTitle); title != "" {
+				item["title"] = title
+			}
+			item["advertised"] = s.gameToolsAdvertised(entry.GameID)
 			items = append(items, item)
 		}
 		return items
@@ -1449,7 +1695,7 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 	}
 
 	// games_tool_names tool - Compact game tool discovery for AI clients
-	s.RegisterToolWithConfig(Tool{
+	s.registerCallTool(Tool{
 		Name:        "games.tool_names",
 		Description: "List compact game-specific tool names. Use this first for low-token discovery, then call games_tool_detail for one tool.",
 		InputSchema: map[string]interface{}{
@@ -1457,7 +1703,7 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			"properties": map[string]interface{}{
 				"gameId": map[string]interface{}{
 					"type":        "string",
-					"description": "Game ID to list tools for (optional, lists all configured games if not provided)",
+					"description": "Game ID or cluster ID to list tools for (optional, lists all configured games if not provided). Cluster tools are named cluster.member.tool.",
 				},
 				"query": map[string]interface{}{
 					"type":        "string",
@@ -1481,7 +1727,7 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 				},
 			},
 		},
-	}, func(args map[string]interface{}) (*ToolResult, error) {
+	}, func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
 		gameID, hasGameID, invalidArg := getOptionalStringArg(args, "gameId")
 		if invalidArg != nil {
 			return invalidArg, nil
@@ -1503,6 +1749,7 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 		if listErr != nil {
 			return listErr, nil
 		}
+		entries = filterToolsByPolicy(entries, call)
 
 		availableTotal := len(entries)
 		entries = filterListedTools(entries, query, prefix)
@@ -1563,6 +1810,7 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			"total":          total,
 			"returned":       len(page),
 			"tools":          buildToolNameItemsWithOptions(page, brief),
+			"groups":         buildToolGroups(page),
 			"nextCursor":     nextCursor,
 		}
 		if game != nil {
@@ -1648,6 +1896,12 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 		if tags := toolMetaStringSlice(entry.Tool, toolMetaTags); len(tags) > 0 {
 			structured["tags"] = tags
 		}
+		if category := toolMetaString(entry.Tool, toolMetaCategory); category != "" {
+			structured["category"] = category
+		}
+		if title := toolMetaString(entry.Tool, toolMetaTitle); title != "" {
+			structured["title"] = title
+		}
 
 		return &ToolResult{
 			Content:           []Content{{Type: "text", Text: strings.TrimSpace(content.String())}},
@@ -1656,7 +1910,7 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 	}, normalizationConfig)
 
 	// games_tools tool - Detailed tool listing, kept for compatibility
-	s.RegisterToolWithConfig(Tool{
+	s.registerCallTool(Tool{
 		Name:        "games.tools",
 		Description: "List game-specific tools in detailed form for compatibility and human-readable inspection. Prefer games_tool_names for compact discovery and games_tool_detail for one tool.",
 		InputSchema: map[string]interface{}{
@@ -1684,7 +1938,7 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 				},
 			},
 		},
-	}, func(args map[string]interface{}) (*ToolResult, error) {
+	}, func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
 		gameID, hasGameID, invalidArg := getOptionalStringArg(args, "gameId")
 		if invalidArg != nil {
 			return invalidArg, nil
@@ -1702,6 +1956,7 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 		if listErr != nil {
 			return listErr, nil
 		}
+		entries = filterToolsByPolicy(entries, call)
 
 		availableTotal := len(entries)
 		entries = filterListedTools(entries, query, prefix)
@@ -1775,10 +2030,12 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			"total":          total,
 			"returned":       len(page),
 			"tools":          buildDetailedToolItems(page),
+			"groups":         buildToolGroups(page),
 			"nextCursor":     nextCursor,
 		}
 		if game != nil {
 			structured["gameId"] = game.ID
+			structured["advertised"] = s.gameToolsAdvertised(game.ID)
 		}
 		if query != "" {
 			structured["query"] = query
@@ -1843,7 +2100,7 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 
 		// Check if already connected - re-sync tools.
 		s.mu.RLock()
-		existingClient, alreadyConnected := s.gabpClients[game.ID]
+		existingClient, alreadyConnected := s.sessions[game.ID].gabpClient()
 		s.mu.RUnlock()
 
 		if alreadyConnected && existingClient.IsConnected() {
@@ -1931,7 +2188,7 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 		// environment; fall back to the internal bridge file only when no live
 		// environment is readable.
 		connector := NewServerGABPConnector(s, backoffMin, backoffMax)
-		connectCtx, connectCancel := context.WithTimeout(context.Background(), connectTimeout)
+		connectCtx, connectCancel := context.WithTimeout(s.gameContext(game.ID), connectTimeout)
 		defer connectCancel()
 
 		err = connector.AttemptConnection(connectCtx, game.ID, port, token)
@@ -2097,6 +2354,63 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 		}, nil
 	}, normalizationConfig)
 
+	// games_events - Recent GABP events buffered for a connected game
+	s.registerGameEventsTool(gamesConfig, normalizationConfig)
+
+	// games_infer_stop_process - Suggest and save stopProcessName for launcher games
+	s.registerStopProcessInferenceTool(gamesConfig, normalizationConfig)
+
+	// games_force_cleanup - Kill lingering game processes and reset a confused game
+	s.registerForceCleanupTool(gamesConfig, normalizationConfig)
+
+	// games_restart - Stop and start a game on the same bridge endpoint
+	s.registerGameRestartTool(gamesConfig, backoffMin, backoffMax, normalizationConfig)
+
+	// games_logs - Captured stdout and stderr of a game
+	s.registerGameLogsTool(gamesConfig, normalizationConfig)
+
+	// games_update - Change and save a game's configuration
+	s.registerGameUpdateTool(gamesConfig, normalizationConfig)
+
+	// games_validate - Check a game's config without launching it
+	s.registerGameValidateTool(gamesConfig, normalizationConfig)
+
+	// games_rotate_token - Give a game's bridge a new token
+	s.registerRotateTokenTool(gamesConfig, normalizationConfig)
+
+	// games_enable_tools / games_disable_tools - List a game's mirrored tools in tools/list or stop listing them
+	s.registerToolAdvertisingTools(gamesConfig, normalizationConfig)
+
+	// games_export / games_import - Move game definitions between machines
+	s.registerGameTransferTools(gamesConfig, normalizationConfig)
+
+	// games_schedule - Timed start, stop and restart of a game
+	s.registerGameScheduleTool(gamesConfig, normalizationConfig)
+
+	// games_saves_list / games_saves_backup / games_saves_restore - Snapshot and roll back a game's saves
+	s.registerGameSavesTools(gamesConfig, normalizationConfig)
+
+	// games_bridges_list / games_bridges_install / games_bridges_enable - Install and switch a game's GABP bridge packages
+	s.registerBridgePackageTools(gamesConfig, backoffMin, backoffMax, normalizationConfig)
+
+	// system_budgets - Remaining mirrored tool call budgets per game
+	s.registerBudgetTool(gamesConfig, normalizationConfig)
+
+	// server_set_log_level - Change the log level while GABS runs
+	s.registerSetLogLevelTool(normalizationConfig)
+
+	// server_sessions - Connected MCP clients and their activity
+	s.registerSessionsTool(normalizationConfig)
+
+	// server_set_session_profile - Limit one client to a tool profile
+	s.registerSessionProfileTool(normalizationConfig)
+
+	// games_health - Transport, GABP and per-game health summary, also served as gabs://health
+	s.registerHealthTool(gamesConfig, normalizationConfig)
+
+	// gabs://dashboard - One-shot overview of every game
+	s.registerDashboardResource(gamesConfig)
+
 	// games_ack_attention - Acknowledge the current blocking attention item for a connected game
 	s.RegisterToolWithConfig(Tool{
 		Name:        "games.ack_attention",
@@ -2192,7 +2506,7 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 	}, normalizationConfig)
 
 	// games_call_tool - Proxy tool calls to a game's GABP server
-	s.RegisterToolWithConfig(Tool{
+	s.registerCallTool(Tool{
 		Name:        "games.call_tool",
 		Description: "Call a game-specific tool on a running game via its GABP connection. Prefer games_tool_names for discovery and games_tool_detail for schema inspection before calling.",
 		InputSchema: map[string]interface{}{
@@ -2212,12 +2526,12 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 				},
 				"timeout": map[string]interface{}{
 					"type":        "integer",
-					"description": "Request timeout in seconds (optional, default 30). Increase for long-running tools such as composite load-ready flows or screen-wait operations.",
+					"description": "Request timeout in seconds (optional). Defaults to the requestTimeout limit of the game's bridge, or 30. Increase for long-running tools such as composite load-ready flows or screen-wait operations.",
 				},
 			},
 			"required": []string{"tool"},
 		},
-	}, func(args map[string]interface{}) (*ToolResult, error) {
+	}, func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
 		gameIdArg, hasGameID, invalidArg := getOptionalStringArg(args, "gameId")
 		if invalidArg != nil {
 			return invalidArg, nil
@@ -2235,27 +2549,40 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			toolArgs = map[string]interface{}{}
 		}
 
-		timeout, invalidTimeout := parseOptionalTimeoutSecondsArg(args, "timeout", 30*time.Second)
+		// 0 until the game's client is known, see gabpCallTimeout
+		timeout, invalidTimeout := parseOptionalTimeoutSecondsArg(args, "timeout", 0)
 		if invalidTimeout != nil {
 			return invalidTimeout, nil
 		}
 
-		proxyTimeout, invalidProxyTimeout := deriveMirroredToolCallTimeout(toolArgs, timeout)
-		if invalidProxyTimeout != nil {
+		if _, invalidProxyTimeout := deriveMirroredToolCallTimeout(toolArgs, timeout); invalidProxyTimeout != nil {
 			return invalidProxyTimeout, nil
 		}
 
+		if memberID, memberTool, handled, clusterErr := resolveClusterTool(gamesConfig, gameIdArg, hasGameID, toolName); handled {
+			if clusterErr != nil {
+				return clusterErr, nil
+			}
+			gameIdArg, hasGameID, toolName = memberID, true, memberTool
+		}
+
 		entry, resolveErr := resolveListedTool(gameIdArg, hasGameID, toolName, false)
 		if resolveErr != nil {
-			if directResult, handled := s.callDirectGABPTool(gamesConfig, gameIdArg, hasGameID, toolName, toolArgs, proxyTimeout); handled {
+			if directResult, handled := s.callDirectGABPTool(call, gamesConfig, gameIdArg, hasGameID, toolName, toolArgs, timeout); handled {
 				return directResult, nil
 			}
 			return resolveErr, nil
 		}
+		if denied := s.enforceToolPolicy(call.accessRole(), call.clientSession(), entry.GameID, gabpToolNameFromTool(entry.GameID, entry.Tool)); denied != nil {
+			return denied, nil
+		}
+		if pending := s.holdForConfirmation(call, entry.GameID, gabpToolNameFromTool(entry.GameID, entry.Tool), toolMetaStringSlice(entry.Tool, toolMetaTags)); pending != nil {
+			return pending, nil
+		}
 
 		// Get the GABP client for this game
 		s.mu.RLock()
-		client, connected := s.gabpClients[entry.GameID]
+		client, connected := s.sessions[entry.GameID].gabpClient()
 		s.mu.RUnlock()
 
 		if !connected || !client.IsConnected() {
@@ -2269,6 +2596,11 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			}, nil
 		}
 
+		if invalid := s.checkToolArguments(entry.Tool.Name, entry.Tool.InputSchema, toolArgs); invalid != nil {
+			return invalid, nil
+		}
+
+		proxyTimeout, _ := deriveMirroredToolCallTimeout(toolArgs, gabpCallTimeout(client, timeout))
 		if blocked := s.ensureRuntimeOwnershipForGameCall(entry.GameID, fmt.Sprintf("tool '%s'", toolName), proxyTimeout); blocked != nil {
 			return blocked, nil
 		}
@@ -2284,17 +2616,20 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 				return blocked, nil
 			}
 		}
+		tags := toolMetaStringSlice(entry.Tool, toolMetaTags)
+		cacheTTL := s.toolCacheTTL(entry.GameID, gabpToolName, tags)
+		if cached := s.cachedToolResult(client, entry.GameID, gabpToolName, toolArgs, cacheTTL); cached != nil {
+			return cached, nil
+		}
+		if exceeded := s.enforceToolBudget(call.clientSession(), entry.GameID, gabpToolName, tags); exceeded != nil {
+			return exceeded, nil
+		}
 
-		result, isError, err := client.CallToolWithTimeout(gabpToolName, toolArgs, proxyTimeout)
+		stopWatching := s.watchGameProgress(entry.GameID, call.reporter())
+		result, isError, err := client.CallToolContext(call.context(), gabpToolName, toolArgs, proxyTimeout)
+		stopWatching()
 		if err != nil {
-			disconnectNote := s.describeLastGABPDisconnect(entry.GameID)
-			if disconnectNote != "" {
-				disconnectNote = " " + disconnectNote
-			}
-			return &ToolResult{
-				Content: []Content{{Type: "text", Text: fmt.Sprintf("GABP tool call failed: %v.%s", err, disconnectNote)}},
-				IsError: true,
-			}, nil
+			return s.gabpCallErrorResult(entry.GameID, err), nil
 		}
 
 		if isError {
@@ -2305,24 +2640,20 @@ func (s *Server) RegisterGameManagementTools(gamesConfig *config.GamesConfig, ba
 			}, nil
 		}
 
-		// Convert result to text content
-		content := []Content{}
-		if resultText, ok := result["text"].(string); ok {
-			content = append(content, Content{Type: "text", Text: resultText})
-		} else {
-			if jsonData, err := json.Marshal(result); err != nil {
-				content = append(content, Content{Type: "text", Text: fmt.Sprintf("%v", result)})
-			} else {
-				content = append(content, Content{Type: "text", Text: string(jsonData)})
-			}
-		}
-
-		return &ToolResult{
-			Content:           content,
-			StructuredContent: result,
-			IsError:           false,
-		}, nil
+		success := gabpCallSuccessResult(result)
+		s.storeToolResult(client, entry.GameID, gabpToolName, toolArgs, cacheTTL, success)
+		return success, nil
 	}, normalizationConfig)
+
+	// games.exec is opt-in because it runs local helper commands.
+	if gamesConfig.EnableExec {
+		s.registerGameExecTool(gamesConfig, normalizationConfig)
+	}
+
+	// games.confirm only exists when some tools need confirmation.
+	if gamesConfig.Confirmation != nil {
+		s.registerConfirmTool(normalizationConfig)
+	}
 }
 
 // RegisterBridgeTools registers the legacy bridge management tools (for compatibility)
@@ -2349,8 +2680,8 @@ func (s *Server) getGameFromController(controller process.ControllerInterface) *
 // resolveGameId tries to find a game by ID or by target (for better UX)
 // Returns the actual game config and whether it was found
 func (s *Server) resolveGameId(gamesConfig *config.GamesConfig, gameIdOrTarget string) (*config.GameConfig, bool) {
-	// First try direct lookup by game ID
-	if game, exists := gamesConfig.GetGame(gameIdOrTarget); exists {
+	// First try direct lookup by game or instance ID
+	if game, exists := s.lookupGame(gamesConfig, gameIdOrTarget); exists {
 		return game, true
 	}
 
@@ -2398,13 +2729,56 @@ func gameConfigStructured(game config.GameConfig) map[string]interface{} {
 	if game.GABPMode != "" {
 		item["gabpMode"] = game.GABPMode
 	}
+	if game.BridgeMode != "" {
+		item["bridgeMode"] = game.BridgeMode
+	}
+	if len(game.AllowedCommands) > 0 {
+		item["allowedCommands"] = allowedCommandNames(game)
+	}
+	if len(game.Env) > 0 {
+		// Names only: values can be secrets.
+		names := make([]string, 0, len(game.Env))
+		for name := range game.Env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		item["envNames"] = names
+	}
+	if game.EnvFile != "" {
+		item["envFile"] = game.EnvFile
+	}
+	if len(game.Profiles) > 0 {
+		item["profiles"] = game.ProfileNames()
+		if game.DefaultProfile != "" {
+			item["defaultProfile"] = game.DefaultProfile
+		}
+	}
+	if game.SSHTunnel != nil {
+		item["sshTunnel"] = map[string]interface{}{
+			"host": game.SSHTunnel.Host,
+			"user": game.SSHTunnel.User,
+		}
+	}
+	if game.Proton != nil {
+		item["proton"] = map[string]interface{}{
+			"path":           game.Proton.Path,
+			"compatDataPath": game.Proton.CompatDataPath,
+		}
+	}
+	if game.Remote != nil {
+		// The URL only: the token is a secret.
+		item["remote"] = map[string]interface{}{"url": game.Remote.URL}
+	}
 	return item
 }
 
 func gameValidationWarnings(game config.GameConfig) []string {
 	warnings := make([]string, 0, 2)
-	if (game.LaunchMode == "SteamAppId" || game.LaunchMode == "EpicAppId") && game.StopProcessName == "" {
-		warnings = append(warnings, fmt.Sprintf("%s games need stopProcessName for reliable games_stop and games_kill.", game.LaunchMode))
+	if config.IsStoreLaunchMode(game.LaunchMode) && game.StopProcessName == "" {
+		warnings = append(warnings, fmt.Sprintf("%s games need stopProcessName for reliable games_stop and games_kill. After games_start, games_infer_stop_process can suggest and save it.", game.LaunchMode))
+	}
+	if game.LaunchMode == "Remote" && game.SSHTunnel == nil {
+		warnings = append(warnings, "Remote games need sshTunnel to reach the GABP bridge on the agent's machine; without it GABS can start and stop the game but not connect to it.")
 	}
 	if launcherModeIgnoresConfiguredArgs(game) {
 		if game.LaunchMode == "SteamAppId" {
@@ -2417,7 +2791,7 @@ func gameValidationWarnings(game config.GameConfig) []string {
 }
 
 func launcherModeIgnoresConfiguredArgs(game config.GameConfig) bool {
-	return (game.LaunchMode == "SteamAppId" || game.LaunchMode == "EpicAppId") && len(game.Args) > 0
+	return config.IsStoreLaunchMode(game.LaunchMode) && len(game.Args) > 0
 }
 
 func addValidationWarnings(structured map[string]interface{}, warnings []string) {
@@ -2455,8 +2829,87 @@ func (s *Server) gameStatusStructured(game config.GameConfig, status string) map
 	if warnings := gameValidationWarnings(game); len(warnings) > 0 {
 		item["validationWarnings"] = warnings
 	}
-	return item
-}
+	if bridge := s.connectedBridgeStructured(game.ID); bridge != nil {
+		item["bridge"] = bridge
+	}
+	for key, value := range s.gameSessionStructured(game.ID) {
+		item[key] = value
+	}
+	for key, value := range s.gameStateStructured(game.ID, status) {
+		item[key] = value
+	}
+	if status != "stopped" {
+		if resources := s.gameResourceUsage(game); resources != nil {
+			item["resources"] = resources
+		}
+	}
+	return item
+}
+
+// connectedBridgeStructured reports what a live GABP bridge said about itself
+// during the handshake, so agents know which bridge and version they talk to.
+func (s *Server) connectedBridgeStructured(gameID string) map[string]interface{} {
+	s.mu.RLock()
+	client, _ := s.sessions[gameID].gabpClient()
+	s.mu.RUnlock()
+	if client == nil || !client.IsConnected() {
+		return nil
+	}
+
+	info := client.GetSessionInfo()
+	bridge := map[string]interface{}{
+		"agentId":       info.AgentID,
+		"schemaVersion": info.SchemaVersion,
+		"compatibility": info.Compatibility,
+		"app": map[string]interface{}{
+			"name":    info.App.Name,
+			"version": info.App.Version,
+		},
+		"capabilities": map[string]interface{}{
+			"methodCount":   len(info.Capabilities.Methods),
+			"methods":       info.Capabilities.Methods,
+			"events":        info.Capabilities.Events,
+			"resources":     info.Capabilities.Resources,
+			"attention":     gabp.SupportsAttention(info.Capabilities),
+			"hasExtensions": len(info.Capabilities.Extensions) > 0,
+		},
+	}
+	if info.ServerInfo != nil {
+		server := map[string]interface{}{
+			"name":    info.ServerInfo.Name,
+			"version": info.ServerInfo.Version,
+		}
+		if info.ServerInfo.Author != "" {
+			server["author"] = info.ServerInfo.Author
+		}
+		bridge["server"] = server
+	}
+	bridge["limits"] = map[string]interface{}{
+		"maxConcurrentRequests": client.MaxConcurrentRequests(),
+		"requestTimeoutSeconds": int(client.RequestTimeout() / time.Second),
+		"maxMessageSize":        client.MaxMessageSize(),
+	}
+	return bridge
+}
+
+// bridgeSummaryText renders the handshake details as one line for text output.
+func bridgeSummaryText(bridge map[string]interface{}) string {
+	if bridge == nil {
+		return ""
+	}
+	app, _ := bridge["app"].(map[string]interface{})
+	summary := fmt.Sprintf("Bridge: %v %v", app["name"], app["version"])
+	if server, ok := bridge["server"].(map[string]interface{}); ok {
+		summary += fmt.Sprintf(" via %v %v", server["name"], server["version"])
+	}
+	if schemaVersion, _ := bridge["schemaVersion"].(string); schemaVersion != "" {
+		summary += fmt.Sprintf(", schema %s", schemaVersion)
+	}
+	if capabilities, ok := bridge["capabilities"].(map[string]interface{}); ok {
+		summary += fmt.Sprintf(", %v methods", capabilities["methodCount"])
+	}
+	return summary
+}
 
 func (s *Server) nextActionsForGameStatus(game config.GameConfig, status string, toolCount int) []map[string]interface{} {
 	gameArg := map[string]interface{}{"gameId": game.ID}
@@ -2492,6 +2945,10 @@ func (s *Server) nextActionsForGameStatus(game config.GameConfig, status string,
 		return []map[string]interface{}{
 			mcpNextAction("games_connect", gameArg, "Reconnect after the GABP bridge disconnected or finished loading."),
 		}
+	case "stopping":
+		return []map[string]interface{}{
+			mcpNextAction("games_status", gameArg, "Poll until the game has exited."),
+		}
 	case "launcher-running", "launcher-triggered":
 		return []map[string]interface{}{
 			mcpNextAction("games_status", gameArg, "Poll until the real game process or GABP bridge becomes visible."),
@@ -2594,15 +3051,16 @@ func gabpToolNameFromTool(gameID string, tool Tool) string {
 	return strings.ReplaceAll(mirroredName, ".", "/")
 }
 
-func (s *Server) registerGameToolAliasesLocked(gameID, gabpName, exposedName string) {
+func (s *Server) registerGameToolAliasesLocked(gameID, gabpName, exposedName string, inputSchema map[string]interface{}) {
 	if s.gameToolAliases == nil {
 		s.gameToolAliases = make(map[string]gameToolAlias)
 	}
 
 	alias := gameToolAlias{
-		GameID:  gameID,
-		GABP:    gabpName,
-		Exposed: exposedName,
+		GameID:      gameID,
+		GABP:        gabpName,
+		Exposed:     exposedName,
+		InputSchema: inputSchema,
 	}
 	for _, name := range []string{
 		exposedName,
@@ -2638,7 +3096,7 @@ func (s *Server) resolveKnownGABPToolAlias(gameID, requested string) (string, bo
 		return alias.GABP, true
 	}
 
-	for _, toolName := range s.gameTools[gameID] {
+	for _, toolName := range s.sessions[gameID].toolNames() {
 		handler, exists := s.tools[toolName]
 		if !exists {
 			continue
@@ -2682,7 +3140,7 @@ func (s *Server) cacheGABPToolAliases(gameID string, tools []gabp.ToolDescriptor
 		}
 		exposedName := s.safeMCPToolNameForGABPTool(gameID, gabpName)
 		s.mu.Lock()
-		s.registerGameToolAliasesLocked(gameID, gabpName, exposedName)
+		s.registerGameToolAliasesLocked(gameID, gabpName, exposedName, tool.InputSchema)
 		s.mu.Unlock()
 	}
 }
@@ -2696,7 +3154,33 @@ func (s *Server) refreshGABPToolAliases(client *gabp.Client, gameID string, time
 	return nil
 }
 
-func (s *Server) callDirectGABPTool(gamesConfig *config.GamesConfig, gameIDArg string, hasGameID bool, requested string, args map[string]interface{}, timeout time.Duration) (*ToolResult, bool) {
+// gabpToolInputSchema returns the input schema the bridge of gameID lists
+// for the first of candidates it knows. Unknown names refresh the cached
+// aliases once; nil means there is no schema to check against.
+func (s *Server) gabpToolInputSchema(client *gabp.Client, gameID string, candidates []string, timeout time.Duration) map[string]interface{} {
+	lookup := func() (map[string]interface{}, bool) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		for _, candidate := range candidates {
+			if alias, ok := s.gameToolAliases[candidate]; ok && alias.GameID == gameID && alias.GABP == candidate {
+				return alias.InputSchema, true
+			}
+		}
+		return nil, false
+	}
+
+	if schema, ok := lookup(); ok {
+		return schema
+	}
+	if err := s.refreshGABPToolAliases(client, gameID, timeout); err != nil {
+		s.log.Debugw("failed to refresh GABP tool aliases for argument validation", "gameId", gameID, "tools", candidates, "error", err)
+		return nil
+	}
+	schema, _ := lookup()
+	return schema
+}
+
+func (s *Server) callDirectGABPTool(call *toolCall, gamesConfig *config.GamesConfig, gameIDArg string, hasGameID bool, requested string, args map[string]interface{}, timeout time.Duration) (*ToolResult, bool) {
 	gameID, result, handled := s.resolveDirectGABPToolGame(gamesConfig, gameIDArg, hasGameID, requested)
 	if handled {
 		return result, true
@@ -2704,15 +3188,17 @@ func (s *Server) callDirectGABPTool(gamesConfig *config.GamesConfig, gameIDArg s
 	if gameID == "" {
 		return nil, false
 	}
+	s.touchGame(gameID)
 
 	s.mu.RLock()
-	client, connected := s.gabpClients[gameID]
+	client, connected := s.sessions[gameID].gabpClient()
 	s.mu.RUnlock()
 
 	if !connected || !client.IsConnected() {
 		return nil, false
 	}
 
+	timeout, _ = deriveMirroredToolCallTimeout(args, gabpCallTimeout(client, timeout))
 	if blocked := s.ensureRuntimeOwnershipForGameCall(gameID, fmt.Sprintf("direct tool '%s'", requested), timeout); blocked != nil {
 		return blocked, true
 	}
@@ -2754,11 +3240,28 @@ func (s *Server) callDirectGABPTool(gamesConfig *config.GamesConfig, gameIDArg s
 			return blocked, true
 		}
 	}
+	if denied := s.enforceToolPolicy(call.accessRole(), call.clientSession(), gameID, candidates[0]); denied != nil {
+		return denied, true
+	}
+	if invalid := s.checkToolArguments(requested, s.gabpToolInputSchema(client, gameID, candidates, timeout), args); invalid != nil {
+		return invalid, true
+	}
+	tags := s.gabpToolTags(gameID, candidates[0])
+	if pending := s.holdForConfirmation(call, gameID, candidates[0], tags); pending != nil {
+		return pending, true
+	}
+	cacheTTL := s.toolCacheTTL(gameID, candidates[0], tags)
+	if cached := s.cachedToolResult(client, gameID, candidates[0], args, cacheTTL); cached != nil {
+		return cached, true
+	}
+	if exceeded := s.enforceToolBudget(call.clientSession(), gameID, candidates[0], tags); exceeded != nil {
+		return exceeded, true
+	}
 
 	var firstErr error
 	var lastErr error
 	for _, candidate := range candidates {
-		callResult, isError, err := client.CallToolWithTimeout(candidate, args, timeout)
+		callResult, isError, err := client.CallToolContext(call.context(), candidate, args, timeout)
 		if err != nil {
 			if firstErr == nil {
 				firstErr = err
@@ -2778,7 +3281,9 @@ func (s *Server) callDirectGABPTool(gamesConfig *config.GamesConfig, gameIDArg s
 			}, true
 		}
 
-		return gabpCallSuccessResult(callResult), true
+		success := gabpCallSuccessResult(callResult)
+		s.storeToolResult(client, gameID, candidates[0], args, cacheTTL, success)
+		return success, true
 	}
 
 	if firstErr == nil {
@@ -2812,7 +3317,7 @@ func (s *Server) resolveDirectGABPToolGame(gamesConfig *config.GamesConfig, game
 	connectedGameIDs := make([]string, 0)
 	for _, game := range gamesConfig.ListGames() {
 		s.mu.RLock()
-		client, connected := s.gabpClients[game.ID]
+		client, connected := s.sessions[game.ID].gabpClient()
 		s.mu.RUnlock()
 		if connected && client.IsConnected() {
 			connectedGameIDs = append(connectedGameIDs, game.ID)
@@ -2850,8 +3355,9 @@ func (s *Server) gabpCallErrorResult(gameID string, err error) *ToolResult {
 		disconnectNote = " " + disconnectNote
 	}
 	return &ToolResult{
-		Content: []Content{{Type: "text", Text: fmt.Sprintf("GABP tool call failed: %v.%s", err, disconnectNote)}},
-		IsError: true,
+		Content:           []Content{{Type: "text", Text: fmt.Sprintf("GABP tool call failed: %v.%s", err, disconnectNote)}},
+		StructuredContent: gabpErrorStructured(gameID, err),
+		IsError:           true,
 	}
 }
 
@@ -2875,11 +3381,7 @@ func gabpCallSuccessResult(result map[string]interface{}) *ToolResult {
 }
 
 func isGABPToolNotFoundError(err error) bool {
-	if err == nil {
-		return false
-	}
-	message := strings.ToLower(err.Error())
-	return strings.Contains(message, "tool") && strings.Contains(message, "not found")
+	return err != nil && gabp.ErrorKind(err) == gabp.ErrorKindNotFound
 }
 
 func toolBriefDescription(description string) string {
@@ -3083,11 +3585,9 @@ func (s *Server) getGameSpecificTools(gameID string) []Tool {
 		gameTools = append(gameTools, tool)
 	}
 
-	if trackedToolNames, exists := s.gameTools[gameID]; exists {
-		for _, toolName := range trackedToolNames {
-			if handler, exists := s.tools[toolName]; exists {
-				addTool(handler.Tool)
-			}
+	for _, toolName := range s.sessions[gameID].toolNames() {
+		if handler, exists := s.tools[toolName]; exists {
+			addTool(handler.Tool)
 		}
 	}
 
@@ -3113,7 +3613,7 @@ func (s *Server) ensureGameToolsMirrored(gameID string, timeout time.Duration) e
 	}
 
 	s.mu.RLock()
-	client := s.gabpClients[gameID]
+	client, _ := s.sessions[gameID].gabpClient()
 	s.mu.RUnlock()
 	if client == nil || !client.IsConnected() {
 		return nil
@@ -3151,7 +3651,7 @@ func (s *Server) getStatusDescriptionFromStatus(status string, gameConfig *confi
 		return "running, but the GABP bridge disconnected"
 	case "running":
 		// Check if this is a launcher-based game with process tracking
-		if gameConfig.LaunchMode == "SteamAppId" || gameConfig.LaunchMode == "EpicAppId" {
+		if config.IsStoreLaunchMode(gameConfig.LaunchMode) {
 			if gameConfig.StopProcessName != "" {
 				return "running (GABS is tracking the game process)"
 			}
@@ -3163,10 +3663,12 @@ func (s *Server) getStatusDescriptionFromStatus(status string, gameConfig *confi
 		return "GABP disconnected (the game may have crashed or closed the bridge)"
 	case "stale-runtime-cleaned":
 		return "stopped (stale runtime state was removed)"
+	case "stopping":
+		return "stopping (GABS asked the game to exit and is waiting for it)"
 	case "stopped":
 		return "stopped"
 	case "launcher-running":
-		return fmt.Sprintf("launcher active (game may be starting via %s)", gameConfig.LaunchMode)
+		return fmt.Sprintf("launcher active (game may be starting via %s)", launchStoreName(gameConfig.LaunchMode))
 	case "launcher-triggered":
 		return fmt.Sprintf("launched via %s (GABS cannot track the game process - no stopProcessName configured)", gameConfig.LaunchMode)
 	default:
@@ -3205,26 +3707,38 @@ func (s *Server) clearGABPDisconnectLocked(gameID string) {
 	delete(s.gabpDisconnects, gameID)
 }
 
-// HandleUnexpectedGABPDisconnect records bridge loss and removes mirrored tools immediately.
+// HandleUnexpectedGABPDisconnect records bridge loss, removes mirrored tools
+// immediately and starts reconnecting in the background.
 func (s *Server) HandleUnexpectedGABPDisconnect(gameID string, client *gabp.Client, err error) {
+	s.handleGABPDisconnect(gameID, client, err, true)
+}
+
+func (s *Server) handleGABPDisconnect(gameID string, client *gabp.Client, err error, reconnect bool) {
 	s.mu.Lock()
-	current, exists := s.gabpClients[gameID]
+	current, exists := s.sessions[gameID].gabpClient()
 	if !exists || current != client {
 		s.mu.Unlock()
 		return
 	}
 
 	s.recordGABPDisconnectLocked(gameID, err)
-	resourcesChanged := len(s.gameResources[gameID]) > 0
+	previousTools := append([]string(nil), s.sessions[gameID].toolNames()...)
+	resourcesChanged := len(s.sessions[gameID].resourceURIs()) > 0
 	s.clearGameAttentionStateLocked(gameID)
 	s.cleanupGameResourcesInternal(gameID)
+	target, hasTarget := s.gabpTargets[gameID]
 	s.mu.Unlock()
 
 	if resourcesChanged {
 		s.SendResourcesListChangedNotification()
 	}
+	s.notifyGameResourcesUpdated(gameID)
 
 	s.log.Warnw("unexpected GABP disconnect", "gameId", gameID, "error", err)
+
+	if reconnect && hasTarget {
+		go s.reconnectGABP(gameID, client, target, previousTools)
+	}
 }
 
 func (s *Server) resolveSharedRuntimeStatus(gameID string) string {
@@ -3348,6 +3862,7 @@ func (s *Server) resolveConnectBridgeEndpoint(game config.GameConfig, runtimeSta
 }
 
 func (s *Server) attemptStartupGABPConnection(
+	callCtx context.Context,
 	controller process.ControllerInterface,
 	connector process.GABPConnector,
 	gameID string,
@@ -3362,8 +3877,10 @@ func (s *Server) attemptStartupGABPConnection(
 		}
 	}
 
-	ctx, cancel := context.WithCancelCause(context.Background())
+	ctx, cancel := context.WithCancelCause(s.gameContext(gameID))
 	defer cancel(nil)
+	stopAfterCall := context.AfterFunc(callCtx, func() { cancel(context.Cause(callCtx)) })
+	defer stopAfterCall()
 
 	timeoutCtx, timeoutCancel := context.WithTimeoutCause(ctx, timeout,
 		fmt.Errorf("no GABP server became available within %s", timeout))
@@ -3419,14 +3936,14 @@ func (s *Server) continueStartupGABPConnection(
 
 	go func() {
 		s.mu.RLock()
-		existingClient, alreadyConnected := s.gabpClients[game.ID]
+		existingClient, alreadyConnected := s.sessions[game.ID].gabpClient()
 		s.mu.RUnlock()
 		if alreadyConnected && existingClient.IsConnected() {
 			return
 		}
 
 		connector := NewAsyncServerGABPConnector(s, backoffMin, backoffMax)
-		result := s.attemptStartupGABPConnection(controller, connector, game.ID, endpoint, timeout)
+		result := s.attemptStartupGABPConnection(context.Background(), controller, connector, game.ID, endpoint, timeout)
 		if result.Connected {
 			s.log.Infow("background GABP connection established",
 				"gameId", game.ID,
@@ -3456,19 +3973,17 @@ func controllerLooksAliveForMCP(controller process.ControllerInterface) bool {
 	if controller.IsRunning() {
 		return true
 	}
-	switch controller.GetLaunchMode() {
-	case "SteamAppId", "EpicAppId":
+	if config.IsStoreLaunchMode(controller.GetLaunchMode()) {
 		return controller.IsLauncherProcessRunning()
-	default:
-		return false
 	}
+	return false
 }
 
 func resolveRuntimeGamePID(game config.GameConfig, controller process.ControllerInterface) int {
 	if controller == nil {
 		return 0
 	}
-	if game.LaunchMode == "SteamAppId" || game.LaunchMode == "EpicAppId" {
+	if config.IsStoreLaunchMode(game.LaunchMode) {
 		if game.StopProcessName != "" {
 			pids, err := process.FindProcessesByName(game.StopProcessName)
 			if err == nil && len(pids) > 0 {
@@ -3484,8 +3999,8 @@ func (s *Server) checkGameStatus(gameID string) string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	controller, exists := s.games[gameID]
-	client, clientConnected := s.gabpClients[gameID]
+	controller, exists := s.sessions[gameID].process()
+	client, clientConnected := s.sessions[gameID].gabpClient()
 	if !exists {
 		if clientConnected {
 			if client.IsConnected() {
@@ -3502,11 +4017,15 @@ func (s *Server) checkGameStatus(gameID string) string {
 		return "stopped"
 	}
 
+	if s.sessions[gameID].isStopping() && controller.IsRunning() {
+		return "stopping"
+	}
+
 	// Simple stateless approach: directly query the system state
 	launchMode := controller.GetLaunchMode()
 
-	// For Steam/Epic launcher games, check the actual game process
-	if launchMode == "SteamAppId" || launchMode == "EpicAppId" {
+	// For store launcher games, check the actual game process
+	if config.IsStoreLaunchMode(launchMode) {
 		if controller.IsRunning() {
 			if clientConnected && !client.IsConnected() {
 				return "running-disconnected"
@@ -3522,7 +4041,7 @@ func (s *Server) checkGameStatus(gameID string) string {
 			game := s.getGameFromController(controller)
 			if game != nil && game.StopProcessName != "" {
 				// We have tracking capability but game is not running
-				s.cleanupStoppedGameLocked(gameID)
+				s.queueGameExit(gameID, s.sessions[gameID].currentGeneration())
 				return "stopped"
 			} else {
 				// We don't have tracking capability, so we can't know the real status
@@ -3539,36 +4058,50 @@ func (s *Server) checkGameStatus(gameID string) string {
 		return "running"
 	}
 
-	// Process is dead, clean up
-	s.cleanupStoppedGameLocked(gameID)
+	// Process is dead; its lifecycle worker cleans up
+	s.queueGameExit(gameID, s.sessions[gameID].currentGeneration())
 	return "stopped"
 }
 
-// cleanupStoppedGameLocked centralizes cleanup when s.mu is already held.
+// cleanupStoppedGameLocked cleans up after a game whose process exited. Only
+// the game's lifecycle worker calls it, with s.mu held.
 func (s *Server) cleanupStoppedGameLocked(gameID string) {
-	// Remove from games map - no need for complex cleanup in stateless approach
-	delete(s.games, gameID)
+	session := s.sessionLocked(gameID)
+	stopped := session.isStopping()
+	if session.untrack(s.clock.Now()) {
+		s.metrics.gameExits.Inc(gameID)
+		event := config.WebhookEventGameCrashed
+		if stopped {
+			event = config.WebhookEventGameStopped
+		}
+		s.emitWebhookEvent(event, gameID, "", map[string]interface{}{})
+	}
+	s.cancelGameContextLocked(gameID)
 
-	// Note: The mutex is already held when this is called from checkGameStatus
-	// So we call internal cleanup methods that don't acquire locks
+	// s.mu is held, so use the internal cleanup methods that don't lock
 	s.cleanupGABPConnectionInternal(gameID)
 	s.cleanupGameResourcesInternal(gameID)
 	s.cleanupRuntimeStateInternal(gameID)
 	s.log.Debugw("cleaned up dead game process and resources", "gameId", gameID)
-}
-
-func (s *Server) cleanupStoppedGame(gameID string) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.cleanupStoppedGameLocked(gameID)
+	s.notifyGameResourcesUpdated(gameID)
 }
 
 // startGame starts a game process using the serialized starter approach
-// This implements @pardeike's requirements for serialized, verified process starting
-func (s *Server) startGame(game config.GameConfig, gamesConfig *config.GamesConfig, backoffMin, backoffMax time.Duration, startupGABPTimeout time.Duration, resetEndpoint bool) (*process.ProcessStartResult, error) {
+// This implements @pardeike's requirements for serialized, verified process starting.
+//
+// With waitForGABP set it waits the whole GABP budget instead of a bounded
+// slice and mirrors the game's tools before returning. Each phase is
+// reported to call, which may be nil; cancelling call ends the wait for
+// GABP early and leaves the connection to the background.
+func (s *Server) startGame(game config.GameConfig, profile string, gamesConfig *config.GamesConfig, backoffMin, backoffMax time.Duration, startupGABPTimeout time.Duration, resetEndpoint, waitForGABP bool, call *toolCall) (*process.ProcessStartResult, error) {
+	progress := call.reporter()
+	game, profile, err := game.WithProfile(profile)
+	if err != nil {
+		return nil, err
+	}
 	launchSpec := launchSpecFromGame(game)
 
-	controller := process.NewController()
+	controller := newGameController(game)
 	if err := controller.Configure(launchSpec); err != nil {
 		return nil, fmt.Errorf("failed to configure game launcher for '%s' (mode: %s, target: %s): %w",
 			game.ID, game.LaunchMode, game.Target, err)
@@ -3587,19 +4120,27 @@ func (s *Server) startGame(game config.GameConfig, gamesConfig *config.GamesConf
 	}()
 
 	s.mu.Lock()
-	if trackedController, exists := s.games[game.ID]; exists && trackedController != nil && trackedController.IsRunning() {
+	trackedController, stale := s.sessions[game.ID].process()
+	if stale && trackedController != nil && trackedController.IsRunning() {
 		s.mu.Unlock()
 		return nil, &gameAlreadyActiveError{status: "running"}
 	}
-
-	// Clean up any stale controller reference
-	delete(s.games, game.ID)
+	staleGeneration := s.sessions[game.ID].currentGeneration()
 	s.mu.Unlock()
 
+	// Finish cleaning up after the previous process before starting a new one
+	if stale {
+		s.cleanupStoppedGame(game.ID, staleGeneration)
+	}
+
+	preferredPort := config.PreferredBridgePort(game.ID, s.configDir, gamesConfig)
 	port, token, bridgePath, reusedBridge, err := config.PrepareBridgeEndpointForStart(game.ID, s.configDir, gamesConfig, resetEndpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare GABS endpoint cache for game '%s': %w", game.ID, err)
 	}
+	if !reusedBridge && preferredPort != 0 && port != preferredPort {
+		s.log.Warnw("preferred GABP port is taken, using another one", "gameId", game.ID, "preferredPort", preferredPort, "port", port, "fixedPort", game.FixedPort != 0)
+	}
 
 	if reusedBridge {
 		s.log.Infow("reusing GABS endpoint cache", "gameId", game.ID, "port", port, "host", "127.0.0.1", "configPath", bridgePath)
@@ -3608,7 +4149,23 @@ func (s *Server) startGame(game config.GameConfig, gamesConfig *config.GamesConf
 	}
 
 	controller.SetBridgeInfo(port, token)
+	socket, err := config.BridgeSocket(game.ID, s.configDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare GABS endpoint cache for game '%s': %w", game.ID, err)
+	} else if socket != "" {
+		controller.SetBridgeSocket(socket)
+	}
+	controller.SetOutputLog(s.gameLogFor(game.ID))
+
+	if socket == "" {
+		// Another process may have bound the port since it was chosen
+		if err := checkBridgePortFree(game, port); err != nil {
+			return nil, err
+		}
+	}
 
+	processesBeforeStart := s.snapshotForStopProcessInference(game)
+	progress.step(fmt.Sprintf("Launching %s", game.ID))
 	result := s.starter.StartWithVerificationWithTimeouts(controller, nil, game.ID, port, token, 0, 0)
 	if result.Error != nil {
 		return result, fmt.Errorf("failed to start game '%s' (mode: %s, target: %s): %w",
@@ -3620,6 +4177,7 @@ func (s *Server) startGame(game config.GameConfig, gamesConfig *config.GamesConf
 
 	runtimeState.Status = process.RuntimeStateStatusRunning
 	runtimeState.GamePID = resolveRuntimeGamePID(game, controller)
+	runtimeState.Profile = profile
 	_, defaultGABPTimeout := s.starter.GetTimeouts()
 	totalGABPTimeout := startupGABPTimeout
 	if totalGABPTimeout <= 0 {
@@ -3632,18 +4190,41 @@ func (s *Server) startGame(game config.GameConfig, gamesConfig *config.GamesConf
 	cleanupRuntimeState = false
 
 	s.mu.Lock()
-	s.games[game.ID] = controller
+	generation := s.trackGameLocked(game.ID, controller)
+	s.sessionLocked(game.ID).profile = profile
 	s.mu.Unlock()
+	go s.monitorGameExit(game.ID, generation)
+	s.metrics.gameStarts.Inc(game.ID)
+	s.emitWebhookEvent(config.WebhookEventGameStarted, game.ID, "", map[string]interface{}{"pid": controller.GetPID(), "profile": profile})
+	s.registerGameMetricsResource(game)
+	if processesBeforeStart != nil {
+		go s.watchForStopProcess(game, processesBeforeStart)
+	}
 
 	endpoint := bridgeEndpoint{Port: port, Token: token, Source: "bridge.json"}
 	endpoint, adoptedProcessEnv := s.adoptProcessBridgeEndpoint(game, &runtimeState, endpoint)
 	port = endpoint.Port
 	token = endpoint.Token
+	s.mu.Lock()
+	if session := s.sessionLocked(game.ID); session.generation == generation {
+		session.bridgePort = port
+	}
+	s.mu.Unlock()
 
 	synchronousGABPTimeout := boundedStartupGABPWait(totalGABPTimeout)
 	connector := NewAsyncServerGABPConnector(s, backoffMin, backoffMax)
-	connectResult := s.attemptStartupGABPConnection(controller, connector, game.ID, endpoint, synchronousGABPTimeout)
+	if waitForGABP {
+		synchronousGABPTimeout = totalGABPTimeout
+		connector = NewServerGABPConnector(s, backoffMin, backoffMax)
+	}
+	progress.step(fmt.Sprintf("Waiting for the GABP bridge of %s", game.ID))
+	connectResult := s.attemptStartupGABPConnection(call.context(), controller, connector, game.ID, endpoint, synchronousGABPTimeout)
 	result.GABPConnected = connectResult.Connected
+	if waitForGABP && result.GABPConnected {
+		result.GABPToolsMirrored = true
+		result.GABPToolCount = len(s.getGameSpecificTools(game.ID))
+		progress.step(fmt.Sprintf("Synced %d tools from %s", result.GABPToolCount, game.ID))
+	}
 	result.GABPConnectError = connectResult.Error
 	result.GABPConnectWait = connectResult.Wait
 	result.GameStillRunning = connectResult.GameStillRunning
@@ -3675,7 +4256,7 @@ func (s *Server) startGame(game config.GameConfig, gamesConfig *config.GamesConf
 		logMsg += ", GABP not ready yet"
 	}
 
-	s.log.Infow(logMsg,
+	s.processLog.Infow(logMsg,
 		"gameId", game.ID,
 		"mode", game.LaunchMode,
 		"processStarted", result.ProcessStarted,
@@ -3686,6 +4267,7 @@ func (s *Server) startGame(game config.GameConfig, gamesConfig *config.GamesConf
 		"adoptedProcessEnvironment", adoptedProcessEnv,
 		"totalGABPTimeout", totalGABPTimeout,
 		"synchronousGABPTimeout", synchronousGABPTimeout)
+	s.notifyGameResourcesUpdated(game.ID)
 
 	return result, nil
 }
@@ -3702,15 +4284,15 @@ func (s *Server) establishGABPConnection(gameID string, port int, token string,
 	s.log.Debugw("attempting GABP connection for game", "gameId", gameID, "addr", addr)
 
 	// Create GABP client
-	client := gabp.NewClient(s.log)
+	client := s.newGABPClient(gameID)
 
 	// Store client reference for cleanup
 	s.mu.Lock()
-	s.gabpClients[gameID] = client
+	s.sessionLocked(gameID).attach(client, s.clock.Now())
 	s.mu.Unlock()
 
 	// Attempt connection with retry logic (handles game bridge startup delays)
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	ctx, cancel := context.WithTimeout(s.gameContext(gameID), 120*time.Second)
 	defer cancel()
 	err := client.Connect(ctx, addr, token, backoffMin, backoffMax)
 	if err != nil {
@@ -3719,7 +4301,7 @@ func (s *Server) establishGABPConnection(gameID string, port int, token string,
 
 		// Clean up client reference on failure
 		s.mu.Lock()
-		delete(s.gabpClients, gameID)
+		s.sessionLocked(gameID).detach()
 		s.mu.Unlock()
 		return
 	}
@@ -3749,13 +4331,21 @@ func (s *Server) syncGABPTools(client *gabp.Client, gameID string) error {
 }
 
 func (s *Server) syncGABPToolsWithTimeout(client *gabp.Client, gameID string, timeout time.Duration) error {
+	_, err := s.syncGABPToolNames(client, gameID, timeout)
+	return err
+}
+
+// syncGABPToolNames mirrors the bridge's tools like syncGABPToolsWithTimeout
+// and returns the names they were registered under.
+func (s *Server) syncGABPToolNames(client *gabp.Client, gameID string, timeout time.Duration) ([]string, error) {
 	// Get tools from GABP client
 	gabpTools, err := client.ListToolsWithTimeout(timeout)
 	if err != nil {
-		return fmt.Errorf("failed to list GABP tools: %w", err)
+		return nil, fmt.Errorf("failed to list GABP tools: %w", err)
 	}
 
 	// Register each GABP tool as an MCP tool with game-specific naming
+	var registered []string
 	for _, tool := range gabpTools {
 		rawGABPToolName := strings.TrimSpace(tool.Name)
 		gabpToolName := canonicalGABPToolName(rawGABPToolName)
@@ -3772,22 +4362,29 @@ func (s *Server) syncGABPToolsWithTimeout(client *gabp.Client, gameID string, ti
 			toolMetaLegacyName:        legacyToolName,
 			toolMetaAliases:           []string{legacyToolName, qualifiedToolName, localLegacyMCPToolName(gabpToolName), gabpToolName, rawGABPToolName},
 			"originalName":            legacyToolName,
+			toolMetaGameID:            gameID,
 		}
 		if len(tool.Tags) > 0 {
 			meta[toolMetaTags] = append([]string(nil), tool.Tags...)
 		}
+		if category := toolCategory(gabpToolName, tool.Tags); category != "" {
+			meta[toolMetaCategory] = category
+		}
+		if title := strings.TrimSpace(tool.Title); title != "" {
+			meta[toolMetaTitle] = title
+		}
 
 		mcpTool := Tool{
 			Name:         exposedToolName,
 			Description:  fmt.Sprintf("%s (Game: %s)", tool.Description, gameID),
 			InputSchema:  tool.InputSchema,
-			OutputSchema: tool.OutputSchema,
+			OutputSchema: mirroredOutputSchema(tool.OutputSchema),
 			Meta:         meta,
 		}
 
-		handler := func(toolName, exposedName string) func(args map[string]interface{}) (*ToolResult, error) {
-			return func(args map[string]interface{}) (*ToolResult, error) {
-				proxyTimeout, invalidTimeout := deriveMirroredToolCallTimeout(args, 30*time.Second)
+		handler := func(toolName, exposedName string) func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
+			return func(args map[string]interface{}, call *toolCall) (*ToolResult, error) {
+				proxyTimeout, invalidTimeout := deriveMirroredToolCallTimeout(args, client.RequestTimeout())
 				if invalidTimeout != nil {
 					return invalidTimeout, nil
 				}
@@ -3801,13 +4398,25 @@ func (s *Server) syncGABPToolsWithTimeout(client *gabp.Client, gameID string, ti
 						return blocked, nil
 					}
 				}
+				tags := toolMetaStringSlice(mcpTool, toolMetaTags)
+				cacheTTL := s.toolCacheTTL(gameID, toolName, tags)
+				if cached := s.cachedToolResult(client, gameID, toolName, args, cacheTTL); cached != nil {
+					return cached, nil
+				}
+				if exceeded := s.enforceToolBudget(call.clientSession(), gameID, toolName, tags); exceeded != nil {
+					return exceeded, nil
+				}
 
-				// Call GABP with original tool name (without game prefix)
-				result, isError, err := client.CallToolWithTimeout(toolName, args, proxyTimeout)
+				// Call GABP with original tool name (without game prefix). The call
+				// ends early when the client cancels it or it times out.
+				stopWatching := s.watchGameProgress(gameID, call.reporter())
+				result, isError, err := client.CallToolContext(call.context(), toolName, args, proxyTimeout)
+				stopWatching()
 				if err != nil {
 					return &ToolResult{
-						Content: []Content{{Type: "text", Text: err.Error()}},
-						IsError: true,
+						Content:           []Content{{Type: "text", Text: err.Error()}},
+						StructuredContent: gabpErrorStructured(gameID, err),
+						IsError:           true,
 					}, nil
 				}
 
@@ -3833,29 +4442,34 @@ func (s *Server) syncGABPToolsWithTimeout(client *gabp.Client, gameID string, ti
 					}
 				}
 
-				return &ToolResult{
+				success := s.conformStructuredContent(gameID, toolName, mcpTool.OutputSchema, &ToolResult{
 					Content:           content,
 					StructuredContent: result,
 					IsError:           false,
-				}, nil
+				})
+				s.storeToolResult(client, gameID, toolName, args, cacheTTL, success)
+				return success, nil
 			}
 		}(gabpToolName, exposedToolName)
 
 		normalizationConfig := &config.ToolNormalizationConfig{}
-		s.RegisterGameTool(gameID, mcpTool, handler, normalizationConfig)
+		s.registerGameCallTool(gameID, mcpTool, handler, normalizationConfig)
+		registered = append(registered, exposedToolName)
 		s.log.Debugw("registered GABP tool as game-specific MCP tool", "gameId", gameID, "gabpName", gabpToolName, "mcpName", exposedToolName, "legacyName", legacyToolName)
 	}
 
+	registered = append(registered, s.registerGABPChatTools(client, gameID, registered)...)
+
 	s.log.Infow("synced GABP tools to MCP with game namespacing", "gameId", gameID, "count", len(gabpTools))
 
-	return nil
+	return registered, nil
 }
 
 // exposeGABPResources creates MCP resources that expose GABP game information
 func (s *Server) exposeGABPResources(client *gabp.Client, gameID string) error {
 	// Game state resource for exposing current game information
 	stateResource := Resource{
-		URI:         fmt.Sprintf("gab://%s/state", gameID),
+		URI:         gameStateURI(gameID),
 		Name:        fmt.Sprintf("%s Game State", gameID),
 		Description: fmt.Sprintf("Current state and capabilities of game: %s", gameID),
 		MimeType:    "application/json",
@@ -3899,11 +4513,18 @@ func (s *Server) exposeGABPResources(client *gabp.Client, gameID string) error {
 
 	// Register the resource using the existing game resource registration method
 	s.RegisterGameResource(gameID, stateResource, stateHandler)
+	exposed := []string{"state"}
+	if s.exposeGABPEvents(client, gameID) {
+		exposed = append(exposed, "events/recent")
+	}
+	exposed = append(exposed, s.exposeBridgeResources(client, gameID)...)
+	s.watchGABPToolChanges(client, gameID)
 
-	s.log.Infow("exposed GABP resources as game-specific MCP resources", "gameId", gameID, "resources", []string{"state"})
+	s.log.Infow("exposed GABP resources as game-specific MCP resources", "gameId", gameID, "resources", exposed)
 
 	// Send resources/list_changed notification to alert AI agents
 	s.SendResourcesListChangedNotification()
+	s.notifyGameResourcesUpdated(gameID)
 
 	return nil
 }
@@ -3916,75 +4537,258 @@ func launchSpecFromGame(game config.GameConfig) process.LaunchSpec {
 		Args:            game.Args,
 		WorkingDir:      game.WorkingDir,
 		StopProcessName: game.StopProcessName,
+		Env:             game.Env,
+		EnvFile:         game.EnvFile,
+		Proton:          game.Proton,
+		BridgeMode:      game.BridgeMode,
+	}
+}
+
+// stopGracePeriod is how long a graceful stop waits for the game to exit
+// before it is killed.
+const stopGracePeriod = 3 * time.Second
+
+// gameStillRunningError reports a game that GABS tried to stop but that is
+// still running. The game stays tracked.
+type gameStillRunningError struct {
+	gameID string
+	pid    int
+	killed bool  // The game was killed and survived that too
+	err    error // Why signalling the game failed, if it did
+}
+
+func (e *gameStillRunningError) Error() string {
+	action := "asked to stop"
+	if e.killed {
+		action = "killed"
+	}
+	message := fmt.Sprintf("game %s is still running %s after it was %s", e.gameID, stopGracePeriod, action)
+	if e.pid > 0 {
+		message = fmt.Sprintf("game %s (pid %d) is still running %s after it was %s", e.gameID, e.pid, stopGracePeriod, action)
+	}
+	if e.err != nil {
+		message = fmt.Sprintf("%s: %v", message, e.err)
 	}
+	return message
 }
 
-// stopGame stops a game process gracefully or by force
+// gameStopInProgressError reports a stop of a game that another call is
+// already stopping.
+type gameStopInProgressError struct {
+	gameID string
+}
+
+func (e *gameStopInProgressError) Error() string {
+	return fmt.Sprintf("game %s is already stopping", e.gameID)
+}
+
+// stopGame stops a game process gracefully or by force, killing it when a
+// graceful stop leaves it running.
 func (s *Server) stopGame(game config.GameConfig, force bool) error {
+	_, err := s.stopGameVerified(game, force, true)
+	return err
+}
+
+// stopGameVerified stops a game process gracefully or by force and waits for
+// it to exit. With escalate set, a game still running after the grace period
+// of a graceful stop is killed, and the result reports that it was. While the
+// stop is under way the game's status is "stopping". A game that does not exit
+// stays tracked and the error is a *gameStillRunningError.
+func (s *Server) stopGameVerified(game config.GameConfig, force, escalate bool) (bool, error) {
 	s.mu.Lock()
-	controller, exists := s.games[game.ID]
+	controller, exists := s.sessions[game.ID].process()
 	if !exists {
 		s.mu.Unlock()
-		return s.stopUntrackedGame(game, force)
+		return false, s.stopUntrackedGame(game, force)
 	}
+	session := s.sessionLocked(game.ID)
+	if session.stopping {
+		s.mu.Unlock()
+		return false, &gameStopInProgressError{gameID: game.ID}
+	}
+	generation := session.generation
+	// Mark the game so a second stop does not signal it again
+	session.stopping = true
+	s.mu.Unlock()
 
-	launchMode := controller.GetLaunchMode()
+	defer func() {
+		s.mu.Lock()
+		session.stopping = false
+		s.mu.Unlock()
+	}()
 
-	// Remove from tracking immediately to prevent double-stops
-	delete(s.games, game.ID)
-	s.mu.Unlock()
+	launchMode := controller.GetLaunchMode()
 
-	defer s.cleanupStoppedGame(game.ID)
+	// watched tells whether the game itself is still running
+	watched := controller
+	if config.IsStoreLaunchMode(launchMode) {
+		if game.StopProcessName == "" {
+			// Without stopProcessName only the launcher can be stopped, and
+			// whether the game exited cannot be checked.
+			var err error
+			if force {
+				err = controller.Kill()
+			} else {
+				err = controller.Stop(stopGracePeriod)
+			}
+			if err != nil {
+				s.processLog.Infow("launcher process stop failed (may have already exited)", "gameId", game.ID, "mode", launchMode, "error", err)
+			} else {
+				s.processLog.Infow("launcher process stopped", "gameId", game.ID, "mode", launchMode, "pid", controller.GetPID())
+			}
+			s.cleanupStoppedGame(game.ID, generation)
+			return false, fmt.Errorf("launcher process stopped, but the actual %s game may still be running independently. Configure 'stopProcessName' in the game configuration to enable proper game termination", launchMode)
+		}
 
-	// Handle different launch modes differently
-	if launchMode == "SteamAppId" || launchMode == "EpicAppId" {
-		// For Steam/Epic games, try to use stopProcessName first if available
-		if game.StopProcessName != "" {
-			// Try to stop by process name first
-			if err := controller.Stop(3 * time.Second); err == nil {
-				s.log.Infow("game stopped via process name", "gameId", game.ID, "processName", game.StopProcessName)
-				return nil
+		// stopProcessName may have been saved after this game was started,
+		// in which case the tracked controller does not know it yet.
+		if controller.GetStopProcessName() != game.StopProcessName {
+			fresh := process.NewController()
+			if err := fresh.Configure(launchSpecFromGame(game)); err == nil {
+				watched = fresh
 			}
 		}
+	}
 
-		// Fall back to stopping the launcher process
-		var err error
-		if force {
-			err = controller.Kill()
-		} else {
-			err = controller.Stop(3 * time.Second)
+	var signalErr error
+	if force {
+		signalErr = watched.Kill()
+	} else {
+		signalErr = watched.Terminate()
+	}
+	if signalErr != nil && watched != controller {
+		// The game process could not be signalled by name; stop the launcher
+		// so at least nothing GABS started is left behind.
+		if err := controller.Stop(stopGracePeriod); err != nil {
+			s.processLog.Infow("launcher process stop failed (may have already exited)", "gameId", game.ID, "mode", launchMode, "error", err)
 		}
+	}
+	s.processLog.Infow("game signalled to stop", "gameId", game.ID, "pid", controller.GetPID(), "force", force, "error", signalErr)
 
-		if err != nil {
-			s.log.Infow("launcher process stop failed (may have already exited)", "gameId", game.ID, "mode", launchMode, "error", err)
-		} else {
-			s.log.Infow("launcher process stopped", "gameId", game.ID, "mode", launchMode, "pid", controller.GetPID())
+	exited := watched.WaitForExit(stopGracePeriod)
+	escalated := false
+	if !exited && !force && escalate {
+		s.processLog.Warnw("game still running after the stop grace period, killing it", "gameId", game.ID, "pid", controller.GetPID())
+		if err := watched.Kill(); err != nil {
+			signalErr = err
 		}
+		escalated = true
+		exited = watched.WaitForExit(stopGracePeriod)
+	}
+	if !exited {
+		s.processLog.Warnw("game did not exit", "gameId", game.ID, "pid", controller.GetPID(), "force", force, "escalated", escalated, "error", signalErr)
+		return escalated, &gameStillRunningError{gameID: game.ID, pid: controller.GetPID(), killed: force || escalated, err: signalErr}
+	}
 
-		// If we have stopProcessName configured, we should have been able to stop the game properly
-		if game.StopProcessName != "" {
-			return nil // Process was handled by stopProcessName logic above
+	s.processLog.Infow("game stopped", "gameId", game.ID, "pid", controller.GetPID(), "force", force, "escalated", escalated)
+	s.cleanupStoppedGame(game.ID, generation)
+	return escalated, nil
+}
+
+// gameStartResult turns the outcome of startGame into the games_start tool
+// result.
+func (s *Server) gameStartResult(game config.GameConfig, startResult *process.ProcessStartResult, err error) *ToolResult {
+	validationWarnings := gameValidationWarnings(game)
+	if err != nil {
+		var activeErr *gameAlreadyActiveError
+		if errors.As(err, &activeErr) {
+			status := activeErr.status
+			if status == "" {
+				status = s.checkGameStatus(game.ID)
+			}
+			toolCount := len(s.getGameSpecificTools(game.ID))
+			structured := map[string]interface{}{
+				"gameId":      game.ID,
+				"status":      status,
+				"toolCount":   toolCount,
+				"nextActions": s.nextActionsForGameStatus(game, status, toolCount),
+			}
+			addValidationWarnings(structured, validationWarnings)
+			return &ToolResult{
+				Content:           []Content{{Type: "text", Text: activeErr.ToolMessage(game)}},
+				StructuredContent: structured,
+			}
+		}
+		var endpointErr *config.BridgeEndpointInUseError
+		if errors.As(err, &endpointErr) {
+			return bridgeEndpointInUseResult(game, endpointErr)
+		}
+		var portErr *bridgePortTakenError
+		if errors.As(err, &portErr) {
+			return bridgePortTakenResult(game, portErr)
 		}
 
-		// Only show the confusing message if stopProcessName is not configured
-		return fmt.Errorf("launcher process stopped, but the actual %s game may still be running independently. Configure 'stopProcessName' in the game configuration to enable proper game termination", launchMode)
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: fmt.Sprintf("Failed to start %s: %v", game.ID, err)}},
+			IsError: true,
+		}
 	}
 
-	// For direct processes, stop normally
-	var err error
-	if force {
-		err = controller.Kill()
-		s.log.Infow("game killed", "gameId", game.ID, "pid", controller.GetPID())
-	} else {
-		// Use default grace period of 3 seconds
-		err = controller.Stop(3 * time.Second)
-		s.log.Infow("game stopped", "gameId", game.ID, "pid", controller.GetPID())
+	if startResult != nil && !startResult.GABPConnected {
+		message := fmt.Sprintf("Game '%s' (%s) started, but GABP was not ready after %s", game.ID, game.Name, startResult.GABPConnectWait.Round(time.Millisecond))
+		if startResult.GABPConnectError != nil {
+			message = fmt.Sprintf("%s: %v", message, startResult.GABPConnectError)
+		}
+		if startResult.BackgroundGABPConnect {
+			message = fmt.Sprintf("%s. GABS will keep trying in the background for up to %s. The game may still be loading or the GABP bridge may be missing. Use games_status, then games_connect once the bridge is ready.", message, startResult.BackgroundGABPWait.Round(time.Second))
+		} else {
+			message = fmt.Sprintf("%s. The game may still be loading or the GABP bridge may be missing. Use games_status, then games_connect once the bridge is ready.", message)
+		}
+		message = appendValidationWarningText(message, validationWarnings)
+		structured := map[string]interface{}{
+			"gameId":            game.ID,
+			"processStarted":    startResult.ProcessStarted,
+			"gabpConnected":     startResult.GABPConnected,
+			"gameStillRunning":  startResult.GameStillRunning,
+			"gabpWaitMs":        startResult.GABPConnectWait.Milliseconds(),
+			"backgroundConnect": startResult.BackgroundGABPConnect,
+			"backgroundWaitMs":  startResult.BackgroundGABPWait.Milliseconds(),
+			"gabpError": func() interface{} {
+				if startResult.GABPConnectError == nil {
+					return nil
+				}
+				return startResult.GABPConnectError.Error()
+			}(),
+			"nextActions": []map[string]interface{}{
+				mcpNextAction("games_status", map[string]interface{}{"gameId": game.ID}, "Verify whether the game is still running."),
+				mcpNextAction("games_connect", map[string]interface{}{"gameId": game.ID}, "Connect after the GABP bridge finishes loading."),
+			},
+		}
+		addValidationWarnings(structured, validationWarnings)
+		return &ToolResult{
+			Content:           []Content{{Type: "text", Text: message}},
+			StructuredContent: structured,
+		}
 	}
 
-	return err
+	message := fmt.Sprintf("Game '%s' (%s) started successfully and connected via GABP.", game.ID, game.Name)
+	if startResult != nil && startResult.GABPToolsMirrored {
+		message = fmt.Sprintf("Game '%s' (%s) started successfully and connected via GABP with %d game tools.", game.ID, game.Name, startResult.GABPToolCount)
+	}
+	message = appendValidationWarningText(message, validationWarnings)
+	structured := map[string]interface{}{
+		"gameId":           game.ID,
+		"processStarted":   true,
+		"gabpConnected":    true,
+		"gameStillRunning": true,
+		"nextActions": []map[string]interface{}{
+			mcpNextAction("games_tool_names", map[string]interface{}{"gameId": game.ID, "brief": true}, "Discover connected game-specific tools."),
+		},
+	}
+	if startResult != nil && startResult.GABPToolsMirrored {
+		structured["toolCount"] = startResult.GABPToolCount
+	}
+	addValidationWarnings(structured, validationWarnings)
+	return &ToolResult{
+		Content:           []Content{{Type: "text", Text: message}},
+		StructuredContent: structured,
+	}
 }
 
 func (s *Server) stopUntrackedGame(game config.GameConfig, force bool) error {
+	if game.LaunchMode == "Remote" {
+		return s.stopUntrackedRemoteGame(game, force)
+	}
 	if game.StopProcessName == "" {
 		return fmt.Errorf("game %s is not running (no process tracked)", game.ID)
 	}
@@ -4002,33 +4806,95 @@ func (s *Server) stopUntrackedGame(game config.GameConfig, force bool) error {
 	if force {
 		err = controller.Kill()
 	} else {
-		err = controller.Stop(3 * time.Second)
+		err = controller.Stop(stopGracePeriod)
 	}
 	if err != nil {
 		return err
 	}
+	if !controller.WaitForExit(stopGracePeriod) {
+		return &gameStillRunningError{gameID: game.ID, killed: true}
+	}
 
-	s.log.Infow("untracked game stopped via configured process name", "gameId", game.ID, "processName", game.StopProcessName, "force", force)
-	s.cleanupStoppedGame(game.ID)
+	s.processLog.Infow("untracked game stopped via configured process name", "gameId", game.ID, "processName", game.StopProcessName, "force", force)
+	s.cleanupStoppedGame(game.ID, s.gameGeneration(game.ID))
 	return nil
 }
 
 func (s *Server) ServeStdio(ctx context.Context) error {
+	s.setServeContext(ctx, "stdio")
 	return s.Serve(os.Stdin, os.Stdout)
 }
 
-// SendNotification sends a notification to all connected clients
-func (s *Server) SendNotification(method string, params interface{}) {
-	notification := NewNotification(method, params)
+// setServeContext records the context of the running transport so long tool
+// operations, such as staggered cluster starts, end when the server shuts down.
+func (s *Server) setServeContext(ctx context.Context, transport string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.serveCtx = ctx
+	s.serveTransport = transport
+}
 
-	s.writersMu.RLock()
-	defer s.writersMu.RUnlock()
+// serveContext returns the running transport's context, or a background
+// context when the server is driven directly, as in tests.
+func (s *Server) serveContext() context.Context {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.serveCtx == nil {
+		return context.Background()
+	}
+	return s.serveCtx
+}
 
-	for _, writer := range s.writers {
-		if err := writer.WriteJSON(notification); err != nil {
-			s.log.Warnw("failed to send notification", "method", method, "error", err)
-		}
+// gameLifetime is the context GABP work for one game runs under.
+type gameLifetime struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+}
+
+// errGameStopped cancels a game's context when it stops or exits.
+var errGameStopped = errors.New("game stopped")
+
+// gameContext returns the context for connecting to and talking with a game's
+// GABP bridge. It is cancelled when the game stops or exits and when GABS
+// shuts down, which aborts connection attempts and in-flight requests.
+func (s *Server) gameContext(gameID string) context.Context {
+	parent := s.serveContext()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lifetime, exists := s.gameLifetimes[gameID]; exists && lifetime.ctx.Err() == nil {
+		return lifetime.ctx
+	}
+	if s.gameLifetimes == nil {
+		s.gameLifetimes = make(map[string]gameLifetime)
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	s.gameLifetimes[gameID] = gameLifetime{ctx: ctx, cancel: cancel}
+	return ctx
+}
+
+// cancelGameContextLocked ends a game's context. The next gameContext call
+// starts a fresh one.
+func (s *Server) cancelGameContextLocked(gameID string) {
+	if lifetime, exists := s.gameLifetimes[gameID]; exists {
+		lifetime.cancel(errGameStopped)
+		delete(s.gameLifetimes, gameID)
+	}
+}
+
+// SetClock overrides the time source used for cluster start delays.
+func (s *Server) SetClock(clock util.Clock) {
+	if clock == nil {
+		return
 	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = clock
+}
+
+// SendNotification sends a notification to all connected clients
+func (s *Server) SendNotification(method string, params interface{}) {
+	s.notifyClients(method, params, nil)
 }
 
 // SendToolsListChangedNotification notifies clients that the tool list has changed
@@ -4043,32 +4909,34 @@ func (s *Server) SendResourcesListChangedNotification() {
 	s.log.Debugw("sent resources/list_changed notification")
 }
 
+// SendResourceUpdatedNotification notifies the clients that subscribed to a
+// resource that its content has changed
+func (s *Server) SendResourceUpdatedNotification(uri string) {
+	s.notifySubscribers(uri, "notifications/resources/updated", map[string]interface{}{"uri": uri})
+	s.log.Debugw("sent resources/updated notification", "uri", uri)
+}
+
 // RegisterGameTool registers a tool for a specific game and tracks it for cleanup
 func (s *Server) RegisterGameTool(gameId string, tool Tool, handler func(args map[string]interface{}) (*ToolResult, error), normalizationConfig *config.ToolNormalizationConfig) {
-	s.RegisterToolWithConfig(tool, handler, normalizationConfig)
+	s.trackGameTool(gameId, tool, s.registerTool(tool, handler, nil, normalizationConfig))
+}
 
-	// Track which game this tool belongs to
-	trackedToolName := tool.Name
-	if normalizationConfig != nil && normalizationConfig.EnableOpenAINormalization {
-		normalizedResult := util.NormalizeToolNameForOpenAI(tool.Name, normalizationConfig.MaxToolNameLength)
-		if normalizedResult.WasNormalized {
-			trackedToolName = normalizedResult.NormalizedName
-		}
-	}
+// registerGameCallTool registers a tool for a specific game whose handler sees
+// the call, like registerCallTool, and tracks it for cleanup.
+func (s *Server) registerGameCallTool(gameId string, tool Tool, handler func(args map[string]interface{}, call *toolCall) (*ToolResult, error), normalizationConfig *config.ToolNormalizationConfig) {
+	trackedToolName := s.registerTool(tool, func(args map[string]interface{}) (*ToolResult, error) {
+		return handler(args, nil)
+	}, handler, normalizationConfig)
+	s.trackGameTool(gameId, tool, trackedToolName)
+}
 
+// trackGameTool records that the tool registered as trackedToolName belongs
+// to gameId.
+func (s *Server) trackGameTool(gameId string, tool Tool, trackedToolName string) {
 	s.mu.Lock()
-	for _, existing := range s.gameTools[gameId] {
-		if existing == trackedToolName {
-			if gabpName := toolMetaString(tool, toolMetaGABPName); gabpName != "" {
-				s.registerGameToolAliasesLocked(gameId, gabpName, trackedToolName)
-			}
-			s.mu.Unlock()
-			return
-		}
-	}
-	s.gameTools[gameId] = append(s.gameTools[gameId], trackedToolName)
+	s.sessionLocked(gameId).addTool(trackedToolName)
 	if gabpName := toolMetaString(tool, toolMetaGABPName); gabpName != "" {
-		s.registerGameToolAliasesLocked(gameId, gabpName, trackedToolName)
+		s.registerGameToolAliasesLocked(gameId, gabpName, trackedToolName, tool.InputSchema)
 	}
 	s.mu.Unlock()
 }
@@ -4079,13 +4947,22 @@ func (s *Server) RegisterGameResource(gameId string, resource Resource, handler
 
 	// Track which game this resource belongs to
 	s.mu.Lock()
-	for _, existing := range s.gameResources[gameId] {
-		if existing == resource.URI {
-			s.mu.Unlock()
-			return
-		}
+	s.sessionLocked(gameId).addResource(resource.URI)
+	s.mu.Unlock()
+}
+
+// RegisterGameQueryResource registers a game resource whose reads may carry a
+// query string, such as gab://<gameId>/events/recent?channel=world/loaded.
+// A read without a query calls handler with empty values.
+func (s *Server) RegisterGameQueryResource(gameId string, resource Resource, handler func(query url.Values) ([]Content, error)) {
+	s.RegisterGameResource(gameId, resource, func() ([]Content, error) {
+		return handler(url.Values{})
+	})
+
+	s.mu.Lock()
+	if registered, exists := s.resources[resource.URI]; exists {
+		registered.Query = handler
 	}
-	s.gameResources[gameId] = append(s.gameResources[gameId], resource.URI)
 	s.mu.Unlock()
 }
 
@@ -4097,27 +4974,22 @@ func (s *Server) CleanupGameResources(gameId string) {
 	toolsRemoved := 0
 	resourcesRemoved := 0
 
+	session := s.sessionLocked(gameId)
+
 	// Remove game-specific tools
-	if toolNames, exists := s.gameTools[gameId]; exists {
-		for _, toolName := range toolNames {
-			if _, exists := s.tools[toolName]; exists {
-				delete(s.tools, toolName)
-				toolsRemoved++
-			}
+	for _, toolName := range session.takeTools() {
+		if _, exists := s.tools[toolName]; exists {
+			delete(s.tools, toolName)
+			toolsRemoved++
 		}
-		delete(s.gameTools, gameId)
-		s.deleteGameToolAliasesLocked(gameId)
 	}
 
 	// Remove game-specific resources
-	if resourceURIs, exists := s.gameResources[gameId]; exists {
-		for _, resourceURI := range resourceURIs {
-			if _, exists := s.resources[resourceURI]; exists {
-				delete(s.resources, resourceURI)
-				resourcesRemoved++
-			}
+	for _, resourceURI := range session.takeResources() {
+		if _, exists := s.resources[resourceURI]; exists {
+			delete(s.resources, resourceURI)
+			resourcesRemoved++
 		}
-		delete(s.gameResources, gameId)
 	}
 	s.deleteGameToolAliasesLocked(gameId)
 
@@ -4136,13 +5008,14 @@ func (s *Server) CleanupGABPConnection(gameId string) {
 	defer s.mu.Unlock()
 
 	// Clean up GABP client connection
-	if client, exists := s.gabpClients[gameId]; exists {
+	if client, exists := s.sessionLocked(gameId).detach(); exists {
 		if err := client.Close(); err != nil {
 			s.log.Warnw("error closing GABP client", "gameId", gameId, "error", err)
 		}
-		delete(s.gabpClients, gameId)
 		s.log.Debugw("cleaned up GABP client connection", "gameId", gameId)
 	}
+	delete(s.gabpTargets, gameId)
+	s.closeTunnelLocked(gameId)
 	s.clearGameAttentionStateLocked(gameId)
 	delete(s.gabpDisconnects, gameId)
 	s.deleteGameToolAliasesLocked(gameId)
@@ -4173,27 +5046,22 @@ func (s *Server) cleanupGameResourcesInternal(gameId string) {
 	toolsRemoved := 0
 	resourcesRemoved := 0
 
+	session := s.sessionLocked(gameId)
+
 	// Remove game-specific tools
-	if toolNames, exists := s.gameTools[gameId]; exists {
-		for _, toolName := range toolNames {
-			if _, exists := s.tools[toolName]; exists {
-				delete(s.tools, toolName)
-				toolsRemoved++
-			}
+	for _, toolName := range session.takeTools() {
+		if _, exists := s.tools[toolName]; exists {
+			delete(s.tools, toolName)
+			toolsRemoved++
 		}
-		delete(s.gameTools, gameId)
-		s.deleteGameToolAliasesLocked(gameId)
 	}
 
 	// Remove game-specific resources
-	if resourceURIs, exists := s.gameResources[gameId]; exists {
-		for _, resourceURI := range resourceURIs {
-			if _, exists := s.resources[resourceURI]; exists {
-				delete(s.resources, resourceURI)
-				resourcesRemoved++
-			}
+	for _, resourceURI := range session.takeResources() {
+		if _, exists := s.resources[resourceURI]; exists {
+			delete(s.resources, resourceURI)
+			resourcesRemoved++
 		}
-		delete(s.gameResources, gameId)
 	}
 	s.deleteGameToolAliasesLocked(gameId)
 
@@ -4208,13 +5076,14 @@ func (s *Server) cleanupGameResourcesInternal(gameId string) {
 // cleanupGABPConnectionInternal cleans up GABP connection without acquiring mutex
 func (s *Server) cleanupGABPConnectionInternal(gameId string) {
 	// Clean up GABP client connection
-	if client, exists := s.gabpClients[gameId]; exists {
+	if client, exists := s.sessionLocked(gameId).detach(); exists {
 		if err := client.Close(); err != nil {
 			s.log.Warnw("error closing GABP client", "gameId", gameId, "error", err)
 		}
-		delete(s.gabpClients, gameId)
 		s.log.Debugw("cleaned up GABP client connection", "gameId", gameId)
 	}
+	delete(s.gabpTargets, gameId)
+	s.closeTunnelLocked(gameId)
 	s.clearGameAttentionStateLocked(gameId)
 	delete(s.gabpDisconnects, gameId)
 }
@@ -4242,44 +5111,91 @@ func (s *Server) Serve(r io.Reader, w io.Writer) error {
 	// fallback so existing local clients keep working.
 	reader := util.NewAutoFrameReader(r)
 	writer := util.NewAutoFrameWriter(w)
-	writerRegistered := false
+	var client *clientConn
+	s.mu.RLock()
+	reader.SetMaxMessageSize(s.maxMessageSize)
+	s.mu.RUnlock()
 
-	// Clean up writer on exit
+	// Stop receiving notifications on exit
 	defer func() {
-		if writerRegistered {
-			s.writersMu.Lock()
-			// Find and remove writer from slice (safer than using index)
-			for i, w := range s.writers {
-				if w == writer {
-					s.writers = append(s.writers[:i], s.writers[i+1:]...)
-					break
-				}
-			}
-			s.writersMu.Unlock()
+		if client != nil {
+			s.removeClient(client)
 		}
 	}()
 
+	session := &mcpSession{transport: clientTransportStdio}
+	defer s.closeSession(session)
+
+	// Tool calls, and batches with tool calls in them, run on their own
+	// goroutines, so a slow one does not hold up tools/list or a
+	// cancellation. Their responses are still written after stdin closes,
+	// since a piped client may be waiting for them.
+	var inflight sync.WaitGroup
+	defer inflight.Wait()
+
 	for {
-		var msg Message
-		if err := reader.ReadJSON(&msg); err != nil {
-			if err == io.EOF {
-				break
+		var raw json.RawMessage
+		var response interface{}
+		readErr := reader.ReadJSON(&raw)
+		if readErr == io.EOF {
+			break
+		}
+		var tooLarge *util.MessageTooLargeError
+		if errors.As(readErr, &tooLarge) {
+			// Answer the request, when its ID can be found, so the client
+			// does not wait for a response that never comes.
+			id, found := util.LeadingMessageID(tooLarge.Head)
+			if !found {
+				id = nullID
 			}
-			s.log.Errorw("failed to read message", "error", err)
-			continue
+			s.log.Warnw("rejected MCP message larger than the message limit", "size", tooLarge.Size, "limit", tooLarge.Limit)
+			response = NewError(id, jsonRPCInvalidRequest, "Message too large", tooLarge.Error())
+		} else if readErr != nil {
+			var syntaxErr *json.SyntaxError
+			if !errors.As(readErr, &syntaxErr) || !s.strictMCPEnabled() {
+				s.log.Errorw("failed to read message", "error", readErr)
+				continue
+			}
+			response = NewError(nullID, jsonRPCParseError, "Parse error", readErr.Error())
 		}
 
-		if !writerRegistered {
+		if client == nil {
 			writer.SetMode(reader.Mode())
-			s.writersMu.Lock()
-			s.writers = append(s.writers, writer)
-			s.writersMu.Unlock()
-			writerRegistered = true
+			client = s.addClient(clientTransportStdio, session)
+			go s.pumpClient(client, func(msg *Message) error {
+				return writer.WriteJSON(msg)
+			})
 		}
 
-		s.log.Debugw("received message", "method", msg.Method, "id", msg.ID)
+		// A tool call sent before the client confirmed initialize is
+		// answered in order, so a later notifications/initialized cannot
+		// overtake its check.
+		if readErr == nil && runsConcurrently(raw) && !session.awaitingInitialized() {
+			inflight.Add(1)
+			go func(raw json.RawMessage) {
+				defer inflight.Done()
+				response, err := s.handleRawMessage(raw, session, config.AccessRoleLocal)
+				if err != nil {
+					s.log.Errorw("failed to read message", "error", err)
+					return
+				}
+				if response != nil {
+					if err := writer.WriteJSON(response); err != nil {
+						s.log.Errorw("failed to write response", "error", err)
+					}
+				}
+			}(raw)
+			continue
+		}
+		if readErr == nil {
+			var err error
+			response, err = s.handleRawMessage(raw, session, config.AccessRoleLocal)
+			if err != nil {
+				s.log.Errorw("failed to read message", "error", err)
+				continue
+			}
+		}
 
-		response := s.handleMessage(&msg)
 		if response != nil {
 			if err := writer.WriteJSON(response); err != nil {
 				s.log.Errorw("failed to write response", "error", err)
@@ -4297,30 +5213,52 @@ func (s *Server) HandleMessage(msg *Message) *Message {
 }
 
 func (s *Server) handleMessage(msg *Message) *Message {
+	return s.handleMessageAs(msg, nil, config.AccessRoleLocal)
+}
+
+// handleMessageAs dispatches msg on behalf of a client holding role. The role
+// decides which resources the client may list, read and subscribe to; the
+// session, which may be nil, holds the client's subscriptions.
+func (s *Server) handleMessageAs(msg *Message, session *mcpSession, role string) *Message {
 	if msg.ID == nil {
-		return s.handleNotification(msg)
+		return s.handleNotification(msg, session)
 	}
 
 	switch msg.Method {
 	case "initialize":
 		return s.handleInitialize(msg)
+	case "ping":
+		return NewResponse(msg.ID, map[string]interface{}{})
 	case "tools/list":
-		return s.handleToolsList(msg)
+		return s.handleToolsList(msg, session, role)
 	case "tools/call":
-		return s.handleToolsCall(msg)
+		return adaptToolCallResponse(s.handleToolsCall(msg, session, role), session)
 	case "resources/list":
-		return s.handleResourcesList(msg)
+		return s.handleResourcesList(msg, role)
 	case "resources/read":
-		return s.handleResourcesRead(msg)
+		return s.handleResourcesRead(msg, role)
+	case "resources/subscribe":
+		return s.handleResourcesSubscribe(msg, session, role, true)
+	case "resources/unsubscribe":
+		return s.handleResourcesSubscribe(msg, session, role, false)
 	default:
 		return NewError(msg.ID, -32601, "Method not found", nil)
 	}
 }
 
-func (s *Server) handleNotification(msg *Message) *Message {
+func (s *Server) handleNotification(msg *Message, session *mcpSession) *Message {
 	switch msg.Method {
 	case "notifications/initialized", "initialized":
+		if session != nil && !session.initialized.Load() {
+			s.log.Debugw("ignoring initialized notification before initialize")
+			break
+		}
+		if session != nil {
+			session.ready.Store(true)
+		}
 		s.log.Debugw("client initialized notification received")
+	case "notifications/cancelled", "$/cancelRequest":
+		s.handleCancelled(msg, session)
 	default:
 		// Notifications never receive responses. Ignore unsupported ones so
 		// spec-compliant clients can continue after initialize.
@@ -4331,14 +5269,19 @@ func (s *Server) handleNotification(msg *Message) *Message {
 }
 
 func (s *Server) handleInitialize(msg *Message) *Message {
+	var params InitializeParams
+	if raw, err := json.Marshal(msg.Params); err == nil {
+		_ = json.Unmarshal(raw, &params)
+	}
+
 	result := InitializeResult{
-		ProtocolVersion: "2024-11-05",
+		ProtocolVersion: negotiateProtocolVersion(params.ProtocolVersion),
 		Capabilities: ServerCapabilities{
 			Tools: &ToolsCapability{
 				ListChanged: false,
 			},
 			Resources: &ResourcesCapability{
-				Subscribe:   false,
+				Subscribe:   true,
 				ListChanged: true,
 			},
 		},
@@ -4347,41 +5290,133 @@ func (s *Server) handleInitialize(msg *Message) *Message {
 			Version: version.Get(),
 		},
 		Instructions: ServerInstructions,
+		Meta: map[string]interface{}{
+			"platform":  version.Platform(),
+			"commit":    version.GetCommit(),
+			"buildDate": version.GetBuildDate(),
+		},
 	}
 	return NewResponse(msg.ID, result)
 }
 
-func (s *Server) handleToolsList(msg *Message) *Message {
+func (s *Server) handleToolsList(msg *Message, session *mcpSession, role string) *Message {
+	var params ToolsListParams
+	if msg.Params != nil {
+		paramsBytes, err := json.Marshal(msg.Params)
+		if err != nil {
+			return NewError(msg.ID, -32602, "Invalid params", err.Error())
+		}
+		if err := json.Unmarshal(paramsBytes, &params); err != nil {
+			return NewError(msg.ID, -32602, "Invalid params", err.Error())
+		}
+	}
+
+	outputSchema := session.supportsOutputSchema()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	tools := make([]Tool, 0, len(s.tools))
-	gameToolNames := make(map[string]struct{})
-	for _, toolNames := range s.gameTools {
-		for _, toolName := range toolNames {
-			gameToolNames[toolName] = struct{}{}
+	gameToolNames := make(map[string]string)
+	for gameID, session := range s.sessions {
+		for _, toolName := range session.toolNames() {
+			gameToolNames[toolName] = gameID
 		}
 	}
 
 	for name, handler := range s.tools {
-		if _, isGameTool := gameToolNames[name]; isGameTool {
+		// Mirrored tools are only listed for games whose tools were
+		// advertised with games_enable_tools or advertiseTools
+		gameID, isGameTool := gameToolNames[name]
+		policyName := toolCanonicalName(handler.Tool)
+		var tags []string
+		if isGameTool {
+			if !s.gameToolsAdvertisedLocked(gameID) {
+				continue
+			}
+			policyName = gabpToolNameFromTool(gameID, handler.Tool)
+			tags = toolMetaStringSlice(handler.Tool, toolMetaTags)
+		}
+		if !s.toolAllowed(role, session, gameID, policyName) {
+			continue
+		}
+		if !toolMatchesListFilter(handler.Tool, params) {
 			continue
 		}
 
 		tool := handler.Tool
-		if s.stripOutputSchema {
+		if s.stripOutputSchema || !outputSchema {
 			tool.OutputSchema = nil
 		}
+		meta := make(map[string]interface{}, len(tool.Meta)+2)
+		for key, value := range tool.Meta {
+			meta[key] = value
+		}
+		if category := toolCategory(toolCanonicalName(handler.Tool), nil); category != "" && !isGameTool {
+			meta[toolMetaCategory] = category
+		}
+		if s.gamesConfig.RequiresConfirmation(gameID, policyName, tags) {
+			meta[toolMetaRequiresConfirmation] = true
+		}
+		if len(meta) > 0 {
+			tool.Meta = meta
+		}
 		tools = append(tools, tool)
 	}
 	sort.Slice(tools, func(i, j int) bool {
 		return tools[i].Name < tools[j].Name
 	})
 
-	result := ToolsListResult{Tools: tools}
+	page, nextCursor, err := paginateTools(tools, params.Cursor, s.toolsListPageSize)
+	if err != nil {
+		return NewError(msg.ID, -32602, "Invalid params", err.Error())
+	}
+	result := ToolsListResult{Tools: page, NextCursor: nextCursor}
+	if nextCursor != "" {
+		result.Meta = map[string]interface{}{
+			"hint": fmt.Sprintf("Showing %d of %d tools. Pass nextCursor as cursor for the rest, or narrow the list with prefix or tag. Mirrored game tools are listed by games_tool_names and games_tools.", len(page), len(tools)),
+		}
+	}
 	return NewResponse(msg.ID, result)
 }
 
+// toolMatchesListFilter reports whether tool passes the prefix and tag
+// filters of a tools/list request. The prefix matches the advertised name or
+// the dotted name, so "games_" and "games." both work.
+func toolMatchesListFilter(tool Tool, params ToolsListParams) bool {
+	if params.Prefix != "" && !strings.HasPrefix(tool.Name, params.Prefix) && !strings.HasPrefix(toolCanonicalName(tool), params.Prefix) {
+		return false
+	}
+	if params.Tag != "" {
+		for _, tag := range toolMetaStringSlice(tool, toolMetaTags) {
+			if strings.EqualFold(tag, params.Tag) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// paginateTools returns the page of tools that starts at cursor, an offset
+// from an earlier nextCursor, and the cursor of the following page. A
+// pageSize of 0 returns everything from cursor on.
+func paginateTools(tools []Tool, cursor string, pageSize int) ([]Tool, string, error) {
+	start := 0
+	if cursor != "" {
+		offset, err := strconv.Atoi(cursor)
+		if err != nil || offset < 0 || offset > len(tools) {
+			return nil, "", fmt.Errorf("invalid cursor %q", cursor)
+		}
+		start = offset
+	}
+	if pageSize <= 0 || start+pageSize >= len(tools) {
+		return tools[start:], "", nil
+	}
+	end := start + pageSize
+	return tools[start:end], strconv.Itoa(end), nil
+}
+
 func (s *Server) findToolHandlerLocked(name string) (*ToolHandler, bool) {
 	if handler, exists := s.tools[name]; exists {
 		return handler, true
@@ -4398,7 +5433,7 @@ func (s *Server) findToolHandlerLocked(name string) (*ToolHandler, bool) {
 	return nil, false
 }
 
-func (s *Server) handleToolsCall(msg *Message) *Message {
+func (s *Server) handleToolsCall(msg *Message, session *mcpSession, role string) *Message {
 	var params ToolCallParams
 	paramsBytes, err := json.Marshal(msg.Params)
 	if err != nil {
@@ -4411,24 +5446,66 @@ func (s *Server) handleToolsCall(msg *Message) *Message {
 
 	s.mu.RLock()
 	handler, exists := s.findToolHandlerLocked(params.Name)
+	var policyGameID, policyName string
+	if exists {
+		policyGameID, policyName = s.toolPolicyNameLocked(handler)
+	}
 	s.mu.RUnlock()
 
+	if exists {
+		if denied := s.enforceToolPolicy(role, session, policyGameID, policyName); denied != nil {
+			return NewResponse(msg.ID, denied)
+		}
+	}
+
+	call := s.beginToolCall(msg.ID, params, session, role)
+	defer call.end()
+
+	if policyGameID != "" {
+		s.touchGame(policyGameID)
+	} else if gameID, ok := params.Arguments["gameId"].(string); ok {
+		s.touchGame(gameID)
+	}
+
+	if exists {
+		if pending := s.holdForConfirmation(call, policyGameID, policyName, toolMetaStringSlice(handler.Tool, toolMetaTags)); pending != nil {
+			return NewResponse(msg.ID, pending)
+		}
+	}
+
 	if !exists {
-		if result, handled := s.callUnmirroredGABPTool(params.Name, params.Arguments); handled {
+		if result, handled := s.callUnmirroredGABPTool(call, params.Name, params.Arguments); handled {
+			if call.cancelled() {
+				return nil
+			}
 			return NewResponse(msg.ID, result)
 		}
 		return NewError(msg.ID, -32601, "Tool not found", params.Name)
 	}
 
-	result, err := handler.Handler(params.Arguments)
+	if policyGameID != "" {
+		if invalid := s.checkToolArguments(handler.Tool.Name, handler.Tool.InputSchema, params.Arguments); invalid != nil {
+			return NewResponse(msg.ID, invalid)
+		}
+	}
+
+	session.noteToolCall(params.Name)
+	started := time.Now()
+	result, err := s.runToolHandler(handler, params.Arguments, call)
+	s.metrics.observeToolCall(handler.Tool.Name, started, result, err)
+	s.log.Infow("tool call", append([]interface{}{"tool", params.Name, "role", role, "duration", time.Since(started), "isError", err != nil || (result != nil && result.IsError)}, sessionLogFields(session)...)...)
+	// A cancelled request gets no response, as MCP asks.
+	if call.cancelled() {
+		return nil
+	}
 	if err != nil {
-		return NewError(msg.ID, -32603, "Tool execution failed", err.Error())
+		return gabpErrorResponse(msg.ID, err, -32603, "Tool execution failed")
 	}
 
 	return NewResponse(msg.ID, result)
 }
 
-func (s *Server) callUnmirroredGABPTool(name string, args map[string]interface{}) (*ToolResult, bool) {
+func (s *Server) callUnmirroredGABPTool(call *toolCall, name string, args map[string]interface{}) (*ToolResult, bool) {
 	if args == nil {
 		args = map[string]interface{}{}
 	}
@@ -4438,15 +5515,18 @@ func (s *Server) callUnmirroredGABPTool(name string, args map[string]interface{}
 		return nil, false
 	}
 
-	return s.callDirectGABPTool(gamesConfig, "", false, name, args, 30*time.Second)
+	return s.callDirectGABPTool(call, gamesConfig, "", false, name, args, 0)
 }
 
-func (s *Server) handleResourcesList(msg *Message) *Message {
+func (s *Server) handleResourcesList(msg *Message, role string) *Message {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	resources := make([]Resource, 0, len(s.resources))
 	for _, handler := range s.resources {
+		if !s.gamesConfig.ResourceAllowed(role, handler.Resource.URI) {
+			continue
+		}
 		resources = append(resources, handler.Resource)
 	}
 
@@ -4454,7 +5534,7 @@ func (s *Server) handleResourcesList(msg *Message) *Message {
 	return NewResponse(msg.ID, result)
 }
 
-func (s *Server) handleResourcesRead(msg *Message) *Message {
+func (s *Server) handleResourcesRead(msg *Message, role string) *Message {
 	var params ResourcesReadParams
 	paramsBytes, err := json.Marshal(msg.Params)
 	if err != nil {
@@ -4465,17 +5545,40 @@ func (s *Server) handleResourcesRead(msg *Message) *Message {
 		return NewError(msg.ID, -32602, "Invalid params", err.Error())
 	}
 
+	uri, rawQuery, hasQuery := strings.Cut(params.URI, "?")
 	s.mu.RLock()
 	handler, exists := s.resources[params.URI]
+	if exists {
+		uri, hasQuery = params.URI, false
+	} else if hasQuery {
+		handler, exists = s.resources[uri]
+		exists = exists && handler.Query != nil
+	}
 	s.mu.RUnlock()
 
 	if !exists {
 		return NewError(msg.ID, -32601, "Resource not found", params.URI)
 	}
 
-	contents, err := handler.Handler()
+	// Denied resources look the same as missing ones so their existence does
+	// not leak to clients that may not read them.
+	if !s.gamesConfig.ResourceAllowed(role, uri) {
+		s.log.Warnw("denied resource read", "uri", params.URI, "role", role)
+		return NewError(msg.ID, -32601, "Resource not found", params.URI)
+	}
+
+	var contents []Content
+	if hasQuery {
+		query, parseErr := url.ParseQuery(rawQuery)
+		if parseErr != nil {
+			return NewError(msg.ID, -32602, "Invalid params", parseErr.Error())
+		}
+		contents, err = handler.Query(query)
+	} else {
+		contents, err = handler.Handler()
+	}
 	if err != nil {
-		return NewError(msg.ID, -32603, "Resource read failed", err.Error())
+		return gabpErrorResponse(msg.ID, err, -32603, "Resource read failed")
 	}
 
 	result := ResourcesReadResult{Contents: contents}
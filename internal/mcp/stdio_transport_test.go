@@ -226,3 +226,30 @@ func TestServeIgnoresInitializedNotification(t *testing.T) {
 		t.Fatalf("expected no response for initialized notification, got err=%v", err)
 	}
 }
+
+func TestServeAnswersOversizedMessagesAndKeepsReading(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.maxMessageSize = 100
+
+	oversized := `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"games.list","arguments":{"padding":"` + strings.Repeat("x", 200) + `"}}}`
+	input := oversized + "\n" + `{"jsonrpc":"2.0","id":8,"method":"tools/list"}` + "\n"
+	var stdout bytes.Buffer
+	if err := server.Serve(strings.NewReader(input), &stdout); err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected two responses, got %q", stdout.String())
+	}
+	var rejected, answered Message
+	if err := json.Unmarshal([]byte(lines[0]), &rejected); err != nil {
+		t.Fatalf("unmarshal rejection: %v", err)
+	}
+	if rejected.ID != float64(7) || rejected.Error == nil || rejected.Error.Code != jsonRPCInvalidRequest {
+		t.Fatalf("expected an invalid request error for id 7, got %s", lines[0])
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &answered); err != nil || answered.ID != float64(8) || answered.Error != nil {
+		t.Fatalf("expected the next message to be answered, got %s", lines[1])
+	}
+}
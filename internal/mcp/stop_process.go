@@ -0,0 +1,330 @@
+package mcp
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/epic"
+	"github.com/pardeike/gabs/internal/process"
+	"github.com/pardeike/gabs/internal/steam"
+)
+
+const (
+	// stopProcessInferencePoll is how often GABS looks for the game process
+	// after a launcher start.
+	stopProcessInferencePoll = 5 * time.Second
+	// stopProcessInferenceWindow bounds how long GABS keeps looking.
+	stopProcessInferenceWindow = 2 * time.Minute
+	// installDirScanDepth bounds how deep GABS looks for executables below a
+	// game's install directory.
+	installDirScanDepth = 4
+)
+
+// stopSuggestion holds the process names that appeared after a launcher start.
+// Names that match a file in the game's install directory come first and are
+// also listed in InstallDirMatches.
+type stopSuggestion struct {
+	Names             []string
+	InstallDir        string
+	InstallDirMatches []string
+}
+
+var gameInstallDirFunc = gameInstallDir
+
+// gameInstallDir asks the launcher where a game is installed. It returns ""
+// when the launcher manifests do not know the game.
+func gameInstallDir(game config.GameConfig) string {
+	switch game.LaunchMode {
+	case "SteamAppId":
+		if app, err := steam.ResolveApp(game.Target); err == nil {
+			return app.InstallPath
+		}
+	case "EpicAppId":
+		if app, err := epic.FindApp(game.Target); err == nil {
+			return app.InstallLocation
+		}
+	}
+	return ""
+}
+
+// processNameKey compares process names the way they show up on every
+// platform: without case and without a trailing .exe.
+func processNameKey(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".exe")
+}
+
+// installDirFileNames returns the keys of the file names below dir, up to
+// installDirScanDepth levels deep.
+func installDirFileNames(dir string) map[string]bool {
+	names := make(map[string]bool)
+	if dir == "" {
+		return names
+	}
+	root := filepath.Clean(dir)
+	_ = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			if rel, relErr := filepath.Rel(root, path); relErr == nil && rel != "." && strings.Count(rel, string(filepath.Separator)) >= installDirScanDepth-1 {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		names[processNameKey(entry.Name())] = true
+		return nil
+	})
+	return names
+}
+
+// rankStopProcessCandidates moves the names found in the game's install
+// directory to the front, keeping the order within both groups.
+func rankStopProcessCandidates(names []string, installDir string) stopSuggestion {
+	ranked := stopSuggestion{InstallDir: installDir, InstallDirMatches: []string{}}
+	files := installDirFileNames(installDir)
+	var others []string
+	for _, name := range names {
+		if files[processNameKey(name)] {
+			ranked.InstallDirMatches = append(ranked.InstallDirMatches, name)
+		} else {
+			others = append(others, name)
+		}
+	}
+	ranked.Names = append(append([]string{}, ranked.InstallDirMatches...), others...)
+	return ranked
+}
+
+// needsStopProcessInference reports whether GABS should watch a launcher
+// start to find the process that games_stop needs to end.
+func needsStopProcessInference(game config.GameConfig) bool {
+	return config.IsStoreLaunchMode(game.LaunchMode) && game.StopProcessName == ""
+}
+
+// snapshotForStopProcessInference records the process table before a launcher
+// start. It returns nil when the game needs no inference or the snapshot fails.
+func (s *Server) snapshotForStopProcessInference(game config.GameConfig) map[int]string {
+	if !needsStopProcessInference(game) {
+		return nil
+	}
+
+	s.mu.Lock()
+	delete(s.stopCandidates, game.ID)
+	s.mu.Unlock()
+
+	before, err := process.ListProcesses()
+	if err != nil {
+		s.log.Debugw("failed to snapshot processes for stopProcessName inference", "gameId", game.ID, "error", err)
+		return nil
+	}
+	return before
+}
+
+// watchForStopProcess compares the process table against the snapshot taken
+// before launch and keeps every process that showed up as a stopProcessName
+// candidate for games_infer_stop_process, ranked by whether it lives in the
+// game's install directory. Launchers often start helpers before the game, so
+// it watches until a process from the install directory appears or
+// stopProcessInferenceWindow has passed.
+func (s *Server) watchForStopProcess(game config.GameConfig, before map[int]string) {
+	gameID := game.ID
+	s.mu.RLock()
+	clock := s.clock
+	s.mu.RUnlock()
+	ctx := s.serveContext()
+	installDir := gameInstallDirFunc(game)
+
+	seen := make(map[string]bool)
+	var names []string
+	var candidates stopSuggestion
+	for waited := time.Duration(0); waited < stopProcessInferenceWindow; waited += stopProcessInferencePoll {
+		select {
+		case <-ctx.Done():
+			return
+		case <-clock.After(stopProcessInferencePoll):
+		}
+
+		after, err := process.ListProcesses()
+		if err != nil {
+			s.log.Debugw("failed to snapshot processes for stopProcessName inference", "gameId", gameID, "error", err)
+			continue
+		}
+		added := false
+		for _, name := range process.NewProcessNames(before, after) {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+				added = true
+			}
+		}
+		if !added {
+			continue
+		}
+		candidates = rankStopProcessCandidates(names, installDir)
+
+		s.mu.Lock()
+		if s.stopCandidates == nil {
+			s.stopCandidates = make(map[string]stopSuggestion)
+		}
+		s.stopCandidates[gameID] = candidates
+		s.mu.Unlock()
+		s.log.Debugw("new processes after launcher start", "gameId", gameID, "candidates", candidates.Names)
+		if len(candidates.InstallDirMatches) > 0 {
+			break
+		}
+	}
+	if len(names) == 0 {
+		s.log.Debugw("no new game process seen after launcher start", "gameId", gameID, "window", stopProcessInferenceWindow)
+		return
+	}
+	s.log.Infow("inferred stopProcessName candidates", "gameId", gameID, "candidates", candidates.Names, "installDirMatches", candidates.InstallDirMatches)
+}
+
+func (s *Server) stopProcessCandidatesFor(gameID string) stopSuggestion {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	candidates := s.stopCandidates[gameID]
+	return stopSuggestion{
+		Names:             append([]string(nil), candidates.Names...),
+		InstallDir:        candidates.InstallDir,
+		InstallDirMatches: append([]string{}, candidates.InstallDirMatches...),
+	}
+}
+
+func (s *Server) registerStopProcessInferenceTool(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.infer_stop_process",
+		Description: "Show the processes that appeared after a Steam or Epic game was started, and save one as the game's stopProcessName once confirmed",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": map[string]interface{}{
+					"type":        "string",
+					"description": "Game ID or launch target",
+				},
+				"processName": map[string]interface{}{
+					"type":        "string",
+					"description": "Process name to save as stopProcessName (optional; omit to list candidates)",
+				},
+				"confirm": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Must be true to write processName into the GABS config. Ask the user first.",
+				},
+			},
+			"required": []string{"gameId"},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		gameIdOrTarget, ok := args["gameId"].(string)
+		if !ok {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "gameId parameter is required"}},
+				IsError: true,
+			}, nil
+		}
+
+		game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
+		if !exists {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' not found. Use games_list to see available games.", gameIdOrTarget)}},
+				IsError: true,
+			}, nil
+		}
+
+		processName, hasProcessName, invalidArg := parseOptionalStringArg(args, "processName")
+		if invalidArg != nil {
+			return invalidArg, nil
+		}
+		confirm, _, invalidArg := parseOptionalBoolArg(args, "confirm")
+		if invalidArg != nil {
+			return invalidArg, nil
+		}
+
+		candidates := s.stopProcessCandidatesFor(game.ID)
+		if !hasProcessName {
+			return stopProcessCandidatesResult(*game, candidates), nil
+		}
+
+		processName = strings.TrimSpace(processName)
+		if processName == "" || strings.ContainsAny(processName, `/\`) {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "processName must be a bare process name such as GameName.exe, not a path"}},
+				IsError: true,
+			}, nil
+		}
+		if !confirm {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Saving stopProcessName '%s' for game '%s' changes the GABS config. Confirm with the user, then call again with confirm set to true.", processName, game.ID)}},
+				StructuredContent: map[string]interface{}{
+					"gameId":      game.ID,
+					"processName": processName,
+					"saved":       false,
+				},
+				IsError: true,
+			}, nil
+		}
+
+		updated := *game
+		updated.StopProcessName = processName
+		if err := s.persistGameConfig(gamesConfig, updated); err != nil {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Failed to update game '%s': %v", game.ID, err)}},
+				IsError: true,
+			}, nil
+		}
+
+		s.mu.Lock()
+		delete(s.stopCandidates, game.ID)
+		s.mu.Unlock()
+		s.log.Infow("saved stopProcessName", "gameId", game.ID, "processName", processName)
+
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: fmt.Sprintf("Saved stopProcessName '%s' for game '%s'. games_stop and games_kill now end that process.", processName, game.ID)}},
+			StructuredContent: map[string]interface{}{
+				"gameId":      game.ID,
+				"processName": processName,
+				"saved":       true,
+			},
+		}, nil
+	}, normalizationConfig)
+}
+
+func stopProcessCandidatesResult(game config.GameConfig, candidates stopSuggestion) *ToolResult {
+	var text string
+	nextActions := []map[string]interface{}{}
+	switch {
+	case len(candidates.Names) > 0:
+		text = fmt.Sprintf("Processes that appeared after '%s' was started: %s.", game.ID, strings.Join(candidates.Names, ", "))
+		if len(candidates.InstallDirMatches) > 0 {
+			text += fmt.Sprintf(" %s ships in the game's install directory and is the likely game process.", strings.Join(candidates.InstallDirMatches, ", "))
+		}
+		text += " Ask the user which one is the game, then save it with confirm set to true."
+		nextActions = append(nextActions, mcpNextAction("games_infer_stop_process", map[string]interface{}{"gameId": game.ID, "processName": candidates.Names[0], "confirm": true}, "Save the game process as stopProcessName after the user agrees."))
+	case game.StopProcessName != "":
+		text = fmt.Sprintf("Game '%s' already has stopProcessName '%s'.", game.ID, game.StopProcessName)
+	case needsStopProcessInference(game):
+		text = fmt.Sprintf("No new game process has been seen for '%s' yet. GABS watches for it for %s after games_start; try again once the game window is up.", game.ID, stopProcessInferenceWindow)
+		nextActions = append(nextActions, mcpNextAction("games_status", map[string]interface{}{"gameId": game.ID}, "Check whether the game has started."))
+	default:
+		text = fmt.Sprintf("Game '%s' uses %s, which GABS tracks without stopProcessName.", game.ID, game.LaunchMode)
+	}
+
+	structured := map[string]interface{}{
+		"gameId":      game.ID,
+		"candidates":  append([]string{}, candidates.Names...),
+		"nextActions": nextActions,
+	}
+	if candidates.InstallDir != "" {
+		structured["installDir"] = candidates.InstallDir
+		structured["installDirMatches"] = candidates.InstallDirMatches
+	}
+	if game.StopProcessName != "" {
+		structured["stopProcessName"] = game.StopProcessName
+	}
+	return &ToolResult{
+		Content:           []Content{{Type: "text", Text: text}},
+		StructuredContent: structured,
+	}
+}
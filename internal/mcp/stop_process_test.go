@@ -0,0 +1,242 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// finishStopProcessWatchForTest advances clock through the polls of a
+// watchForStopProcess run, starting after the given number of polls, and
+// waits for the watch to end.
+func finishStopProcessWatchForTest(clock *util.FakeClock, polls int, done <-chan struct{}) {
+	for ; polls < int(stopProcessInferenceWindow/stopProcessInferencePoll); polls++ {
+		clock.BlockUntil(1)
+		clock.Advance(stopProcessInferencePoll)
+	}
+	<-done
+}
+
+func TestInferStopProcessSuggestsNewProcessAndSavesOnConfirm(t *testing.T) {
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"adventure": {ID: "adventure", Name: "Adventure", LaunchMode: "SteamAppId", Target: "123456"},
+		},
+	}
+	server, configDir := newGamesTestServer(t, gamesConfig)
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	server.SetClock(clock)
+	stubGameInstallDir(t, "")
+
+	var mu sync.Mutex
+	table := map[int]string{100: "steam", 200: "explorer.exe"}
+	t.Cleanup(process.SetListProcessesForTesting(func() (map[int]string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		snapshot := make(map[int]string, len(table))
+		for pid, name := range table {
+			snapshot[pid] = name
+		}
+		return snapshot, nil
+	}))
+
+	game, _ := gamesConfig.GetGame("adventure")
+	before := server.snapshotForStopProcessInference(*game)
+	if before == nil {
+		t.Fatal("expected a process snapshot for a launcher game without stopProcessName")
+	}
+	done := make(chan struct{})
+	go func() {
+		server.watchForStopProcess(*game, before)
+		close(done)
+	}()
+
+	// Nothing new on the first poll; a helper shows up before the second and
+	// the game before the third.
+	clock.BlockUntil(1)
+	clock.Advance(stopProcessInferencePoll)
+	clock.BlockUntil(1)
+	mu.Lock()
+	table[300] = "steamwebhelper"
+	table[350] = "UnityCrashHandler64.exe"
+	mu.Unlock()
+	clock.Advance(stopProcessInferencePoll)
+	clock.BlockUntil(1)
+	mu.Lock()
+	table[400] = "AdventureGame.exe"
+	mu.Unlock()
+	finishStopProcessWatchForTest(clock, 2, done)
+
+	result := callToolForTest(t, server, "games_infer_stop_process", map[string]interface{}{"gameId": "adventure"})
+	candidates, _ := result.StructuredContent["candidates"].([]interface{})
+	if result.IsError || !reflect.DeepEqual(candidates, []interface{}{"UnityCrashHandler64.exe", "AdventureGame.exe"}) {
+		t.Fatalf("expected the helper and the game as candidates, got %#v", result)
+	}
+
+	result = callToolForTest(t, server, "games_infer_stop_process", map[string]interface{}{"gameId": "adventure", "processName": "AdventureGame.exe"})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "confirm") {
+		t.Fatalf("expected saving without confirm to be refused, got %#v", result)
+	}
+	if game, _ := gamesConfig.GetGame("adventure"); game.StopProcessName != "" {
+		t.Fatalf("expected config to stay unchanged without confirm, got %q", game.StopProcessName)
+	}
+
+	result = callToolForTest(t, server, "games_infer_stop_process", map[string]interface{}{"gameId": "adventure", "processName": "AdventureGame.exe", "confirm": true})
+	if result.IsError || result.StructuredContent["saved"] != true {
+		t.Fatalf("expected stopProcessName to be saved, got %#v", result)
+	}
+	saved, err := config.LoadGamesConfigFromDir(configDir)
+	if err != nil {
+		t.Fatalf("load saved config: %v", err)
+	}
+	if game, ok := saved.GetGame("adventure"); !ok || game.StopProcessName != "AdventureGame.exe" {
+		t.Fatalf("expected stopProcessName on disk, got %#v", game)
+	}
+	game, _ = gamesConfig.GetGame("adventure")
+	if server.snapshotForStopProcessInference(*game) != nil {
+		t.Fatal("expected no inference once stopProcessName is configured")
+	}
+}
+
+func TestInferStopProcessRejectsPaths(t *testing.T) {
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"adventure": {ID: "adventure", Name: "Adventure", LaunchMode: "SteamAppId", Target: "123456"},
+		},
+	})
+
+	result := callToolForTest(t, server, "games_infer_stop_process", map[string]interface{}{"gameId": "adventure", "processName": "C:\\Games\\Adventure.exe", "confirm": true})
+	if !result.IsError {
+		t.Fatalf("expected a path to be rejected, got %#v", result)
+	}
+}
+
+func stubGameInstallDir(t *testing.T, dir string) {
+	t.Helper()
+	previous := gameInstallDirFunc
+	gameInstallDirFunc = func(config.GameConfig) string { return dir }
+	t.Cleanup(func() { gameInstallDirFunc = previous })
+}
+
+func TestInferStopProcessRanksProcessesFromInstallDir(t *testing.T) {
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"adventure": {ID: "adventure", Name: "Adventure", LaunchMode: "EpicAppId", Target: "Heron"},
+		},
+	}
+	server, _ := newGamesTestServer(t, gamesConfig)
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	server.SetClock(clock)
+
+	installDir := t.TempDir()
+	binaries := filepath.Join(installDir, "Adventure", "Binaries", "Win64")
+	if err := os.MkdirAll(binaries, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(binaries, "Adventure-Win64-Shipping.exe"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	stubGameInstallDir(t, installDir)
+
+	snapshots := []map[int]string{
+		{100: "explorer"},
+		{100: "explorer", 200: "CrashReportClient", 300: "Adventure-Win64-Shipping"},
+	}
+	t.Cleanup(process.SetListProcessesForTesting(func() (map[int]string, error) {
+		snapshot := snapshots[0]
+		if len(snapshots) > 1 {
+			snapshots = snapshots[1:]
+		}
+		return snapshot, nil
+	}))
+
+	game, _ := gamesConfig.GetGame("adventure")
+	before := server.snapshotForStopProcessInference(*game)
+	done := make(chan struct{})
+	go func() {
+		server.watchForStopProcess(*game, before)
+		close(done)
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(stopProcessInferencePoll)
+	<-done
+
+	result := callToolForTest(t, server, "games_infer_stop_process", map[string]interface{}{"gameId": "adventure"})
+	candidates, _ := result.StructuredContent["candidates"].([]interface{})
+	if !reflect.DeepEqual(candidates, []interface{}{"Adventure-Win64-Shipping", "CrashReportClient"}) {
+		t.Fatalf("expected the install-dir process first, got %#v", result.StructuredContent)
+	}
+	matches, _ := result.StructuredContent["installDirMatches"].([]interface{})
+	if result.StructuredContent["installDir"] != installDir || !reflect.DeepEqual(matches, []interface{}{"Adventure-Win64-Shipping"}) {
+		t.Fatalf("unexpected install dir details: %#v", result.StructuredContent)
+	}
+	if !strings.Contains(result.Content[0].Text, "likely game process") {
+		t.Fatalf("expected the text to point out the likely game process, got %q", result.Content[0].Text)
+	}
+}
+
+func TestInferStopProcessWaitsForGameAfterLauncherHelper(t *testing.T) {
+	gamesConfig := &config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"adventure": {ID: "adventure", Name: "Adventure", LaunchMode: "EpicAppId", Target: "Heron"},
+		},
+	}
+	server, _ := newGamesTestServer(t, gamesConfig)
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	server.SetClock(clock)
+
+	installDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(installDir, "Adventure.exe"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	stubGameInstallDir(t, installDir)
+
+	snapshots := []map[int]string{
+		{100: "explorer"},
+		{100: "explorer", 200: "CrashReportClient"},
+		{100: "explorer", 200: "CrashReportClient", 300: "Adventure"},
+	}
+	t.Cleanup(process.SetListProcessesForTesting(func() (map[int]string, error) {
+		snapshot := snapshots[0]
+		if len(snapshots) > 1 {
+			snapshots = snapshots[1:]
+		}
+		return snapshot, nil
+	}))
+
+	game, _ := gamesConfig.GetGame("adventure")
+	before := server.snapshotForStopProcessInference(*game)
+	done := make(chan struct{})
+	go func() {
+		server.watchForStopProcess(*game, before)
+		close(done)
+	}()
+
+	// The helper alone does not end the watch
+	clock.BlockUntil(1)
+	clock.Advance(stopProcessInferencePoll)
+	clock.BlockUntil(1)
+	select {
+	case <-done:
+		t.Fatal("expected the watch to go on after a helper that is not in the install directory")
+	default:
+	}
+	if candidates := server.stopProcessCandidatesFor("adventure"); !reflect.DeepEqual(candidates.Names, []string{"CrashReportClient"}) {
+		t.Fatalf("expected the helper as an interim candidate, got %#v", candidates)
+	}
+
+	clock.Advance(stopProcessInferencePoll)
+	<-done
+	candidates := server.stopProcessCandidatesFor("adventure")
+	if !reflect.DeepEqual(candidates.Names, []string{"Adventure", "CrashReportClient"}) || !reflect.DeepEqual(candidates.InstallDirMatches, []string{"Adventure"}) {
+		t.Fatalf("expected the game from the install directory first, got %#v", candidates)
+	}
+}
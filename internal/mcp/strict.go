@@ -0,0 +1,190 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// JSON-RPC and MCP error codes used by strict mode.
+const (
+	jsonRPCParseError       = -32700
+	jsonRPCInvalidRequest   = -32600
+	mcpServerNotInitialized = -32002
+)
+
+// nullID serializes as "id": null for errors that cannot be tied to a request.
+var nullID = json.RawMessage("null")
+
+// mcpSession holds per-client protocol state. Each stdio connection has its
+// own session; HTTP clients get one per Mcp-Session-Id.
+type mcpSession struct {
+	initialized   atomic.Bool // initialize succeeded
+	ready         atomic.Bool // The client confirmed initialize with notifications/initialized
+	subsMu        sync.Mutex
+	subscriptions map[string]bool // Resource URIs the client subscribed to
+	callsMu       sync.Mutex
+	calls         map[string]context.CancelCauseFunc // In-flight tools/call requests by JSON-RPC ID
+	events        *sessionEventLog                   // Notifications SSE streams can resume from; HTTP sessions only
+	transport     string                             // clientTransportStdio or sessionTransportHTTP
+	infoMu        sync.Mutex
+	info          sessionInfo // Who the client is and what it did; set at initialize
+	lastRequest   time.Time   // Last request or keep-alive of an HTTP session; guarded by Server.mu
+}
+
+// SetStrictMCP enables strict MCP protocol checks: requests before
+// initialize, wrong jsonrpc versions and null or malformed IDs are answered
+// with JSON-RPC errors instead of being tolerated.
+func (s *Server) SetStrictMCP(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.strictMCP = enabled
+}
+
+func (s *Server) strictMCPEnabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.strictMCP
+}
+
+// handleRawMessage decodes and dispatches one framed message. The result is
+// nil when nothing should be written back, a *Message, or a []*Message for a
+// batch. Without strict mode a message that cannot be decoded returns an
+// error, matching the lenient behaviour transports had before.
+func (s *Server) handleRawMessage(data []byte, session *mcpSession, role string) (interface{}, error) {
+	if isBatch(data) {
+		return s.handleBatch(data, session, role)
+	}
+
+	msg, response, err := s.decodeMessage(data, session)
+	if err != nil {
+		return nil, err
+	}
+	if msg != nil {
+		response = s.dispatchMessage(msg, session, role)
+	}
+	if response == nil {
+		return nil, nil
+	}
+	return response, nil
+}
+
+// decodeMessage decodes one message and checks it against the lifecycle and,
+// in strict mode, the protocol. It returns the message to dispatch, or a nil
+// message and the error response to send instead, which is nil for messages
+// that get no answer.
+func (s *Server) decodeMessage(data []byte, session *mcpSession) (*Message, *Message, error) {
+	strict := s.strictMCPEnabled()
+
+	var msg Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		if !strict {
+			return nil, nil, err
+		}
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return nil, NewError(nullID, jsonRPCParseError, "Parse error", err.Error()), nil
+		}
+		return nil, NewError(nullID, jsonRPCInvalidRequest, "Invalid Request", err.Error()), nil
+	}
+
+	s.log.Debugw("received message", "method", msg.Method, "id", msg.ID)
+
+	if strict {
+		if response, handled := strictCheck(data, &msg, session); handled {
+			return nil, response, nil
+		}
+	}
+	if response := lifecycleCheck(&msg, session); response != nil {
+		return nil, response, nil
+	}
+	return &msg, nil, nil
+}
+
+// dispatchMessage handles a decoded message and marks the session initialized
+// once initialize succeeds. A repeated initialize is answered again and
+// updates the client's details; a session that was confirmed stays usable.
+func (s *Server) dispatchMessage(msg *Message, session *mcpSession, role string) *Message {
+	response := s.handleMessageAs(msg, session, role)
+	if response != nil && msg.Method == "initialize" && response.Error == nil {
+		s.openSession(session, msg, role)
+		session.initialized.Store(true)
+	}
+	session.noteActivity(msg.Method)
+	return response
+}
+
+// strictCheck validates the JSON-RPC envelope and the initialize handshake.
+// handled is true when the message must not be dispatched; response is then
+// the error to send, or nil for notifications and client responses.
+func strictCheck(data []byte, msg *Message, session *mcpSession) (*Message, bool) {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return NewError(nullID, jsonRPCInvalidRequest, "Invalid Request", "message must be a JSON object"), true
+	}
+
+	rawID, hasID := envelope["id"]
+	id := interface{}(nullID)
+	if hasID {
+		if bytes.Equal(bytes.TrimSpace(rawID), []byte("null")) {
+			return NewError(nullID, jsonRPCInvalidRequest, "Invalid Request", "id must not be null"), true
+		}
+		switch msg.ID.(type) {
+		case string, float64:
+			id = msg.ID
+		default:
+			return NewError(nullID, jsonRPCInvalidRequest, "Invalid Request", "id must be a string or number"), true
+		}
+	}
+
+	if msg.JSONRPC != "2.0" {
+		if !hasID {
+			return nil, true
+		}
+		return NewError(id, jsonRPCInvalidRequest, "Invalid Request", `jsonrpc must be "2.0"`), true
+	}
+
+	if msg.Method == "" {
+		_, hasResult := envelope["result"]
+		_, hasError := envelope["error"]
+		if hasResult || hasError || !hasID {
+			// Responses from the client need no answer.
+			return nil, true
+		}
+		return NewError(id, jsonRPCInvalidRequest, "Invalid Request", "method is required"), true
+	}
+
+	if !session.initialized.Load() && msg.Method != "initialize" && msg.Method != "ping" {
+		if !hasID {
+			return nil, true
+		}
+		return NewError(id, mcpServerNotInitialized, "Server not initialized", "send initialize before "+msg.Method), true
+	}
+
+	return nil, false
+}
+
+// lifecycleCheck holds a client that started the handshake to it in both
+// modes: after initialize, tool calls are refused until
+// notifications/initialized arrives.
+// Clients that never send initialize keep the lenient behaviour; strict mode
+// has already turned them away in strictCheck.
+func lifecycleCheck(msg *Message, session *mcpSession) *Message {
+	if msg.Method != "tools/call" || msg.ID == nil {
+		return nil
+	}
+	if !session.awaitingInitialized() {
+		return nil
+	}
+	return NewError(msg.ID, mcpServerNotInitialized, "Server not initialized", "send notifications/initialized before calling tools")
+}
+
+// awaitingInitialized reports whether initialize succeeded but the client has
+// not sent notifications/initialized yet.
+func (m *mcpSession) awaitingInitialized() bool {
+	return m.initialized.Load() && !m.ready.Load()
+}
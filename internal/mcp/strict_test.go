@@ -0,0 +1,266 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// serveLinesForTest runs newline-framed input through Serve and returns one
+// decoded JSON value per response line.
+func serveLinesForTest(t *testing.T, server *Server, lines ...string) []interface{} {
+	t.Helper()
+	var stdout bytes.Buffer
+	if err := server.Serve(strings.NewReader(strings.Join(lines, "\n")+"\n"), &stdout); err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+
+	var responses []interface{}
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		var response interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &response); err != nil {
+			t.Fatalf("invalid response line %q: %v", scanner.Text(), err)
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+func errorCodeForTest(t *testing.T, response interface{}) float64 {
+	t.Helper()
+	message, ok := response.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected response object, got %#v", response)
+	}
+	errObj, ok := message["error"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected error response, got %#v", message)
+	}
+	return errObj["code"].(float64)
+}
+
+const strictInitializeLine = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`
+
+func TestStrictMCPRejectsRequestsBeforeInitialize(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.SetStrictMCP(true)
+
+	responses := serveLinesForTest(t, server,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","method":"notifications/progress"}`,
+		strings.Replace(strictInitializeLine, `"id":1`, `"id":2`, 1),
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/list"}`,
+	)
+	if len(responses) != 3 {
+		t.Fatalf("expected three responses and none for notifications, got %d: %#v", len(responses), responses)
+	}
+	if code := errorCodeForTest(t, responses[0]); code != mcpServerNotInitialized {
+		t.Fatalf("expected not-initialized error, got %v", code)
+	}
+	for _, response := range responses[1:] {
+		if _, hasError := response.(map[string]interface{})["error"]; hasError {
+			t.Fatalf("expected success after initialize, got %#v", response)
+		}
+	}
+}
+
+func TestStrictMCPValidatesEnvelope(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.SetStrictMCP(true)
+
+	responses := serveLinesForTest(t, server,
+		strictInitializeLine,
+		`{"jsonrpc":"1.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":null,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":{"x":1},"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":5}`,
+		`{"jsonrpc":"2.0","id":6,"result":{}}`,
+		`{"jsonrpc":"1.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":7,`,
+	)
+	if len(responses) != 6 {
+		t.Fatalf("expected six responses, got %d: %#v", len(responses), responses)
+	}
+	want := []float64{jsonRPCInvalidRequest, jsonRPCInvalidRequest, jsonRPCInvalidRequest, jsonRPCInvalidRequest, jsonRPCParseError}
+	for i, code := range want {
+		if got := errorCodeForTest(t, responses[i+1]); got != code {
+			t.Errorf("response %d: expected error %v, got %v", i+1, code, got)
+		}
+	}
+	if id := responses[1].(map[string]interface{})["id"]; id != float64(2) {
+		t.Errorf("expected error to echo request id, got %#v", id)
+	}
+	parseError := responses[5].(map[string]interface{})
+	if id, present := parseError["id"]; !present || id != nil {
+		t.Errorf("expected parse error with id null, got %#v", parseError)
+	}
+}
+
+func TestStrictMCPChecksEachBatchEntry(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.SetStrictMCP(true)
+
+	responses := serveLinesForTest(t, server,
+		`[{"jsonrpc":"2.0","id":1,"method":"tools/list"},{"jsonrpc":"2.0","method":"notifications/initialized"},{"jsonrpc":"2.0","id":"b","method":"tools/list"}]`,
+		`[`+strings.Replace(strictInitializeLine, `"id":1`, `"id":2`, 1)+`,{"jsonrpc":"2.0","method":"notifications/initialized"},{"jsonrpc":"2.0","id":3,"method":"tools/list"},1]`,
+		`[]`,
+	)
+	if len(responses) != 3 {
+		t.Fatalf("expected three responses, got %#v", responses)
+	}
+	batch, ok := responses[0].([]interface{})
+	if !ok || len(batch) != 2 {
+		t.Fatalf("expected one error per batched request, got %#v", responses[0])
+	}
+	for i, wantID := range []interface{}{float64(1), "b"} {
+		if errorCodeForTest(t, batch[i]) != mcpServerNotInitialized || batch[i].(map[string]interface{})["id"] != wantID {
+			t.Errorf("unexpected batch entry %d: %#v", i, batch[i])
+		}
+	}
+
+	batch, ok = responses[1].([]interface{})
+	if !ok || len(batch) != 3 {
+		t.Fatalf("expected initialize, tools/list and an error for the number, got %#v", responses[1])
+	}
+	for i, wantID := range []float64{2, 3} {
+		entry := batch[i].(map[string]interface{})
+		if entry["id"] != wantID || entry["error"] != nil {
+			t.Errorf("expected batch entry %d to succeed, got %#v", i, entry)
+		}
+	}
+	if code := errorCodeForTest(t, batch[2]); code != jsonRPCInvalidRequest {
+		t.Errorf("expected invalid request for a batch entry that is not an object, got %v", code)
+	}
+
+	if code := errorCodeForTest(t, responses[2]); code != jsonRPCInvalidRequest {
+		t.Fatalf("expected invalid request for empty batch, got %v", code)
+	}
+}
+
+func TestMCPLifecycle(t *testing.T) {
+	for _, strict := range []bool{false, true} {
+		t.Run(fmt.Sprintf("strict=%v", strict), func(t *testing.T) {
+			server := NewServerForTesting(util.NewLogger("error"))
+			server.SetStrictMCP(strict)
+			server.RegisterTool(Tool{Name: "test.echo", InputSchema: map[string]interface{}{"type": "object"}}, func(args map[string]interface{}) (*ToolResult, error) {
+				return &ToolResult{Content: []Content{{Type: "text", Text: "echo"}}}, nil
+			})
+
+			callLine := `{"jsonrpc":"2.0","id":%d,"method":"tools/call","params":{"name":"test.echo"}}`
+			responses := serveLinesForTest(t, server,
+				`{"jsonrpc":"2.0","id":"p","method":"ping"}`,
+				strictInitializeLine,
+				fmt.Sprintf(callLine, 2),
+				`{"jsonrpc":"2.0","method":"notifications/unknown"}`,
+				`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+				fmt.Sprintf(callLine, 3),
+				strings.Replace(strictInitializeLine, `"id":1`, `"id":4`, 1),
+				fmt.Sprintf(callLine, 5),
+			)
+			if len(responses) != 6 {
+				t.Fatalf("expected six responses and none for notifications, got %d: %#v", len(responses), responses)
+			}
+			byID := map[interface{}]map[string]interface{}{}
+			for _, response := range responses {
+				message := response.(map[string]interface{})
+				byID[message["id"]] = message
+			}
+
+			if result, ok := byID["p"]["result"].(map[string]interface{}); !ok || len(result) != 0 {
+				t.Fatalf("expected ping to get an empty result before initialize, got %#v", byID["p"])
+			}
+			for _, id := range []float64{1, 3, 4, 5} {
+				if byID[id] == nil || byID[id]["error"] != nil {
+					t.Errorf("expected request %v to succeed, got %#v", id, byID[id])
+				}
+			}
+			if code := errorCodeForTest(t, byID[float64(2)]); code != mcpServerNotInitialized {
+				t.Errorf("expected a tool call before notifications/initialized to be refused, got %v", code)
+			}
+		})
+	}
+}
+
+func TestLenientModeKeepsPreviousBehaviour(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+
+	responses := serveLinesForTest(t, server,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+		`[{"jsonrpc":"2.0","id":2,"method":"tools/list"}]`,
+		`{"jsonrpc":"2.0","id":3,`,
+	)
+	if len(responses) != 2 {
+		t.Fatalf("expected the tools/list responses and nothing for malformed JSON, got %#v", responses)
+	}
+	if _, hasError := responses[0].(map[string]interface{})["error"]; hasError {
+		t.Fatalf("lenient mode should not require initialize, got %#v", responses[0])
+	}
+	if batch, ok := responses[1].([]interface{}); !ok || len(batch) != 1 || batch[0].(map[string]interface{})["error"] != nil {
+		t.Fatalf("expected the batch to be answered without initialize, got %#v", responses[1])
+	}
+}
+
+func TestStrictMCPOverHTTP(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.SetStrictMCP(true)
+
+	post := func(sessionID, body string) (*httptest.ResponseRecorder, interface{}) {
+		request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+		if sessionID != "" {
+			request.Header.Set(mcpSessionHeader, sessionID)
+		}
+		recorder := httptest.NewRecorder()
+		server.handleMCPHTTPRequest(recorder, request)
+		var response interface{}
+		json.Unmarshal(recorder.Body.Bytes(), &response)
+		return recorder, response
+	}
+
+	_, response := post("", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	if code := errorCodeForTest(t, response); code != mcpServerNotInitialized {
+		t.Fatalf("expected not-initialized error over HTTP, got %v", code)
+	}
+
+	recorder, _ := post("", strictInitializeLine)
+	sessionID := recorder.Header().Get(mcpSessionHeader)
+	if sessionID == "" {
+		t.Fatal("expected initialize to issue an Mcp-Session-Id")
+	}
+	if _, response = post(sessionID, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`); response.(map[string]interface{})["error"] != nil {
+		t.Fatalf("expected tools/list to succeed in the initialized session, got %#v", response)
+	}
+
+	// Another client's initialize must not count for a client that never sent one.
+	if _, response = post("", `{"jsonrpc":"2.0","id":3,"method":"tools/list"}`); errorCodeForTest(t, response) != mcpServerNotInitialized {
+		t.Fatalf("expected a request without a session to stay uninitialized, got %#v", response)
+	}
+	if recorder, _ = post("not-a-session", `{"jsonrpc":"2.0","id":4,"method":"tools/list"}`); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected unknown session to be rejected with 404, got %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder, response = post(sessionID, `not json`)
+	if recorder.Code != http.StatusBadRequest || errorCodeForTest(t, response) != jsonRPCParseError {
+		t.Fatalf("expected 400 with a JSON-RPC parse error, got %d %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestLenientHTTPParseErrorIsBadRequest(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`not json`))
+	request.Header.Set(mcpSessionHeader, "unknown-sessions-are-ignored")
+	server.handleMCPHTTPRequest(recorder, request)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for malformed JSON, got %d %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func gameStateURI(gameID string) string {
+	return fmt.Sprintf("gab://%s/state", gameID)
+}
+
+// handleResourcesSubscribe answers resources/subscribe and, with subscribe
+// false, resources/unsubscribe. Subscriptions belong to the client's session
+// and name the resource without its query, since updates are sent for the
+// resource as a whole. They outlive the resource, so a subscription to
+// gab://<gameId>/state keeps working across game restarts.
+func (s *Server) handleResourcesSubscribe(msg *Message, session *mcpSession, role string, subscribe bool) *Message {
+	var params ResourcesSubscribeParams
+	paramsBytes, err := json.Marshal(msg.Params)
+	if err != nil {
+		return NewError(msg.ID, -32602, "Invalid params", err.Error())
+	}
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		return NewError(msg.ID, -32602, "Invalid params", err.Error())
+	}
+	if params.URI == "" {
+		return NewError(msg.ID, -32602, "Invalid params", "uri is required")
+	}
+	if session == nil {
+		return NewError(msg.ID, -32603, "Subscriptions need a client session", msg.Method)
+	}
+
+	uri, _, _ := strings.Cut(params.URI, "?")
+	if !subscribe {
+		session.unsubscribe(uri)
+		return NewResponse(msg.ID, map[string]interface{}{})
+	}
+
+	s.mu.RLock()
+	_, exists := s.resources[uri]
+	s.mu.RUnlock()
+	// Denied resources look the same as missing ones, as in resources/read.
+	if !exists || !s.gamesConfig.ResourceAllowed(role, uri) {
+		return NewError(msg.ID, -32601, "Resource not found", params.URI)
+	}
+
+	session.subscribe(uri)
+	s.log.Debugw("resource subscribed", "uri", uri, "role", role)
+	return NewResponse(msg.ID, map[string]interface{}{})
+}
+
+// notifyGameResourcesUpdated tells subscribers that the resources describing
+// a game's state changed: its metrics and mirrored state, the health summary
+// and the dashboard. It only takes clientsMu, so it may be called with s.mu held.
+func (s *Server) notifyGameResourcesUpdated(gameID string) {
+	for _, uri := range []string{gameStateURI(gameID), gameMetricsURI(gameID), healthURI, dashboardURI} {
+		s.SendResourceUpdatedNotification(uri)
+	}
+}
@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func subscribeForTest(t *testing.T, server *Server, session *mcpSession, method, uri string) *Message {
+	t.Helper()
+	raw := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":%q,"params":{"uri":%q}}`, method, uri)
+	response, err := server.handleRawMessage([]byte(raw), session, config.AccessRoleLocal)
+	if err != nil {
+		t.Fatalf("%s failed: %v", method, err)
+	}
+	return response.(*Message)
+}
+
+func TestResourceSubscriptionsReceiveGameStateUpdates(t *testing.T) {
+	requireSleepForTest(t)
+
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{"factory": sleepingGameForTest("factory", "Factory")},
+	})
+	initialize := server.HandleMessage(&Message{JSONRPC: "2.0", ID: 1, Method: "initialize"})
+	if result := initialize.Result.(InitializeResult); !result.Capabilities.Resources.Subscribe {
+		t.Fatal("expected initialize to advertise resource subscriptions")
+	}
+
+	session := &mcpSession{}
+	client := server.addClient(clientTransportStdio, session)
+	bystander := server.addClient(clientTransportStdio, &mcpSession{})
+
+	if response := subscribeForTest(t, server, session, "resources/subscribe", "gab://unknown/state"); response.Error == nil {
+		t.Fatal("expected subscribing to an unknown resource to fail")
+	}
+	if response := subscribeForTest(t, server, session, "resources/subscribe", healthURI); response.Error != nil {
+		t.Fatalf("resources/subscribe failed: %#v", response.Error)
+	}
+
+	trackSleepingGameForTest(t, server, "factory")
+	if result := callToolForTest(t, server, "games_kill", map[string]interface{}{"gameId": "factory"}); result.IsError {
+		t.Fatalf("games_kill failed: %#v", result)
+	}
+
+	gotUpdate := false
+	timeout := time.After(2 * time.Second)
+	for !gotUpdate {
+		select {
+		case msg := <-client.outbox:
+			params, _ := msg.Params.(map[string]interface{})
+			gotUpdate = msg.Method == "notifications/resources/updated" && params["uri"] == healthURI
+		case <-timeout:
+			t.Fatal("expected a resources/updated notification for the health resource")
+		}
+	}
+	if len(bystander.outbox) != 0 {
+		t.Fatalf("expected no updates for a session without subscriptions, got %d", len(bystander.outbox))
+	}
+
+	if response := subscribeForTest(t, server, session, "resources/unsubscribe", healthURI); response.Error != nil {
+		t.Fatalf("resources/unsubscribe failed: %#v", response.Error)
+	}
+	for len(client.outbox) > 0 {
+		<-client.outbox
+	}
+	server.notifyGameResourcesUpdated("factory")
+	if len(client.outbox) != 0 {
+		t.Fatalf("expected no updates after unsubscribing, got %d", len(client.outbox))
+	}
+}
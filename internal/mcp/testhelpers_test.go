@@ -0,0 +1,146 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// newGamesTestServer returns a server with the game management tools
+// registered for gamesConfig and a private config directory. Restart backoff
+// is kept short so lifecycle tests do not wait on real delays.
+func newGamesTestServer(t *testing.T, gamesConfig *config.GamesConfig) (*Server, string) {
+	t.Helper()
+	configDir := t.TempDir()
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.SetConfigDir(configDir)
+	server.RegisterGameManagementTools(gamesConfig, 10*time.Millisecond, 100*time.Millisecond)
+	return server, configDir
+}
+
+// sleepingGameForTest describes a DirectPath game that just sleeps, which is
+// enough for tests that only need a live process.
+func sleepingGameForTest(id, name string) config.GameConfig {
+	return config.GameConfig{ID: id, Name: name, LaunchMode: "DirectPath", Target: "/bin/sleep", Args: []string{"30"}}
+}
+
+// requireSleepForTest skips tests that launch /bin/sleep where it is missing.
+func requireSleepForTest(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("test launches /bin/sleep")
+	}
+	if _, err := os.Stat("/bin/sleep"); err != nil {
+		t.Skip("/bin/sleep not available")
+	}
+}
+
+// trackSleepingGameForTest starts a sleeping process outside GABS and
+// registers its controller under gameID, as if GABS had launched it.
+func trackSleepingGameForTest(t *testing.T, server *Server, gameID string) {
+	t.Helper()
+	controller := process.NewController()
+	if err := controller.Configure(process.LaunchSpec{GameId: gameID, Mode: "DirectPath", PathOrId: "/bin/sleep", Args: []string{"30"}}); err != nil {
+		t.Fatalf("configure %s: %v", gameID, err)
+	}
+	if err := controller.Start(); err != nil {
+		t.Fatalf("start %s: %v", gameID, err)
+	}
+	t.Cleanup(func() { controller.Kill() })
+
+	server.mu.Lock()
+	server.trackGameLocked(gameID, controller)
+	server.mu.Unlock()
+	waitForGameStatus(t, server, gameID, "running")
+}
+
+func waitForGameStatus(t *testing.T, server *Server, gameID, want string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		status := server.checkGameStatus(gameID)
+		if status == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %s status %q, got %q", gameID, want, status)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func callToolForTest(t *testing.T, server *Server, name string, args map[string]interface{}) ToolResult {
+	t.Helper()
+	response := server.HandleMessage(&Message{
+		JSONRPC: "2.0",
+		Method:  "tools/call",
+		ID:      json.RawMessage(`"test"`),
+		Params: map[string]interface{}{
+			"name":      name,
+			"arguments": args,
+		},
+	})
+	if response == nil || response.Error != nil {
+		t.Fatalf("%s failed: %#v", name, response)
+	}
+	var result ToolResult
+	if err := decodeResult(response.Result, &result); err != nil {
+		t.Fatalf("decode %s result: %v", name, err)
+	}
+	return result
+}
+
+func postMCPForTest(t *testing.T, server *Server, key string, msg Message) (*http.Response, Message) {
+	t.Helper()
+	body, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	request := httptest.NewRequest(http.MethodPost, "/mcp", bytes.NewReader(body))
+	if key != "" {
+		request.Header.Set("Authorization", "Bearer "+key)
+	}
+	recorder := httptest.NewRecorder()
+	server.handleMCPHTTPRequest(recorder, request)
+
+	var response Message
+	if recorder.Code == http.StatusOK {
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+	}
+	return recorder.Result(), response
+}
+
+// gamesWithToolsForTest counts the games that have tools registered. Callers
+// hold server.mu.
+func gamesWithToolsForTest(server *Server) int {
+	count := 0
+	for _, session := range server.sessions {
+		if len(session.toolNames()) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// gamesWithResourcesForTest counts the games that have resources registered.
+// Callers hold server.mu.
+func gamesWithResourcesForTest(server *Server) int {
+	count := 0
+	for _, session := range server.sessions {
+		if len(session.resourceURIs()) > 0 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,195 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+const (
+	// tokenRotationCheckInterval is how often RunTokenRotation looks for
+	// bridge tokens older than timeouts.bridge.tokenTtlSeconds.
+	tokenRotationCheckInterval = time.Minute
+	// tokenReauthTimeout bounds the session/reauth request to the bridge.
+	tokenReauthTimeout = 10 * time.Second
+)
+
+// tokenRotation describes a finished token rotation.
+type tokenRotation struct {
+	port            int
+	issuedAt        time.Time
+	reauthenticated bool // The connected bridge switched to the new token
+}
+
+// rotateBridgeToken gives gameID's bridge endpoint a new token and keeps its
+// port. A connected bridge is handed the token with session/reauth first, so
+// the session stays up and reconnects use the new token. A game that runs
+// without a connected bridge is left alone, because its bridge could not
+// learn the new token until it is restarted.
+func (s *Server) rotateBridgeToken(gameID string) (tokenRotation, error) {
+	s.tokenRotationMu.Lock()
+	defer s.tokenRotationMu.Unlock()
+
+	status := s.checkGameStatus(gameID)
+	s.mu.RLock()
+	client, hasClient := s.sessions[gameID].gabpClient()
+	target, hasTarget := s.gabpTargets[gameID]
+	s.mu.RUnlock()
+
+	connected := hasClient && client.IsConnected()
+	if !connected && status != "stopped" {
+		return tokenRotation{}, fmt.Errorf("the game is %s but its bridge is not connected, so it cannot learn a new token; connect it first or restart it with resetEndpoint", status)
+	}
+	if connected && !gabp.SupportsReauth(client.GetCapabilities()) {
+		return tokenRotation{}, fmt.Errorf("the connected bridge does not support %s; restart the game with resetEndpoint to give it a new token", gabp.SessionReauthMethod)
+	}
+
+	port := 0
+	if bridge, err := config.ReadBridgeEndpoint(gameID, s.configDir); err == nil {
+		port = bridge.Port
+	} else if hasTarget {
+		port = target.port
+	}
+	if port <= 0 {
+		return tokenRotation{}, fmt.Errorf("the game has no bridge endpoint yet; GABS creates one when it starts the game")
+	}
+
+	token, err := config.NewBridgeToken()
+	if err != nil {
+		return tokenRotation{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+	if connected {
+		if err := client.Reauthenticate(token, tokenReauthTimeout); err != nil {
+			return tokenRotation{}, err
+		}
+		if hasTarget {
+			target.token = token
+			s.rememberGABPTarget(gameID, target)
+		}
+	}
+	if _, err := config.WriteBridgeJSONWithEndpoint(gameID, s.configDir, port, token); err != nil {
+		if connected {
+			return tokenRotation{}, fmt.Errorf("the bridge uses the new token, but bridge.json could not be updated: %w", err)
+		}
+		return tokenRotation{}, err
+	}
+
+	rotation := tokenRotation{port: port, issuedAt: time.Now().UTC(), reauthenticated: connected}
+	if bridge, err := config.ReadBridgeEndpoint(gameID, s.configDir); err == nil {
+		rotation.issuedAt = bridge.IssuedAt
+	}
+	s.log.Infow("rotated bridge token", "gameId", gameID, "port", port, "reauthenticated", connected)
+	return rotation, nil
+}
+
+// RunTokenRotation replaces expired bridge tokens of running games until ctx
+// is cancelled. Stopped games get a new token when they next start.
+func (s *Server) RunTokenRotation(ctx context.Context) {
+	s.mu.RLock()
+	clock := s.clock
+	s.mu.RUnlock()
+
+	ticker := clock.NewTicker(tokenRotationCheckInterval)
+	defer ticker.Stop()
+	warned := make(map[string]time.Time)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			s.rotateExpiredTokens(clock.Now(), warned)
+		}
+	}
+}
+
+// rotateExpiredTokens rotates the expired tokens of running games. warned
+// holds the issue time of tokens that could not be rotated, so each is only
+// reported once.
+func (s *Server) rotateExpiredTokens(now time.Time, warned map[string]time.Time) {
+	s.mu.RLock()
+	gamesConfig := s.gamesConfig
+	s.mu.RUnlock()
+	ttl := gamesConfig.GetBridgeTokenTTL()
+	if ttl <= 0 {
+		return
+	}
+
+	for _, game := range gamesConfig.ListGames() {
+		bridge, err := config.ReadBridgeEndpoint(game.ID, s.configDir)
+		if err != nil || !bridge.TokenExpired(ttl, now) {
+			continue
+		}
+		if s.checkGameStatus(game.ID) == "stopped" {
+			continue
+		}
+		if _, err := s.rotateBridgeToken(game.ID); err != nil {
+			if issuedAt, seen := warned[game.ID]; !seen || !issuedAt.Equal(bridge.IssuedAt) {
+				warned[game.ID] = bridge.IssuedAt
+				s.log.Warnw("bridge token expired but could not be rotated", "gameId", game.ID, "error", err)
+			}
+			continue
+		}
+		delete(warned, game.ID)
+	}
+}
+
+func (s *Server) registerRotateTokenTool(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.rotate_token",
+		Description: "Give a game's bridge a new GABP token on the same port and save it to bridge.json. A connected bridge must support session/reauth and keeps its connection; a stopped game uses the token from its next start.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gameId": map[string]interface{}{
+					"type":        "string",
+					"description": "Game ID or launch target",
+				},
+			},
+			"required": []string{"gameId"},
+		},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		gameIdOrTarget, ok := args["gameId"].(string)
+		if !ok {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: "gameId parameter is required"}},
+				IsError: true,
+			}, nil
+		}
+		game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
+		if !exists {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' not found. Use games_list to see available games.", gameIdOrTarget)}},
+				IsError: true,
+			}, nil
+		}
+
+		rotation, err := s.rotateBridgeToken(game.ID)
+		if err != nil {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("Token of game '%s' was not rotated: %v", game.ID, err)}},
+				IsError: true,
+			}, nil
+		}
+
+		text := fmt.Sprintf("Rotated the bridge token of game '%s'; the game uses it from its next start.", game.ID)
+		if rotation.reauthenticated {
+			text = fmt.Sprintf("Rotated the bridge token of game '%s'; the connected bridge switched to it without reconnecting.", game.ID)
+		}
+		structured := map[string]interface{}{
+			"gameId":          game.ID,
+			"port":            rotation.port,
+			"issuedAt":        rotation.issuedAt.UTC().Format(time.RFC3339),
+			"reauthenticated": rotation.reauthenticated,
+		}
+		if ttl := gamesConfig.GetBridgeTokenTTL(); ttl > 0 {
+			structured["expiresAt"] = rotation.issuedAt.Add(ttl).UTC().Format(time.RFC3339)
+		}
+		return &ToolResult{
+			Content:           []Content{{Type: "text", Text: text}},
+			StructuredContent: structured,
+		}, nil
+	}, normalizationConfig)
+}
@@ -0,0 +1,184 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// serveTestGabpReauthSession answers the handshake, an empty tools/list and
+// session/reauth requests that present the current token, and sends each
+// token it switched to on rotated.
+func serveTestGabpReauthSession(listener net.Listener, token string, rotated chan<- string, done chan<- error) {
+	conn, err := listener.Accept()
+	if err != nil {
+		done <- err
+		return
+	}
+	defer conn.Close()
+
+	reader := util.NewLSPFrameReader(conn)
+	writer := util.NewLSPFrameWriter(conn)
+	for {
+		data, err := reader.ReadMessage()
+		if err != nil {
+			done <- nil
+			return
+		}
+		var request util.GABPMessage
+		if err := json.Unmarshal(data, &request); err != nil {
+			done <- err
+			return
+		}
+		params, _ := request.Params.(map[string]interface{})
+
+		switch request.Method {
+		case "session/hello":
+			if presented, _ := params["token"].(string); presented != token {
+				done <- fmt.Errorf("unexpected handshake token: %q", presented)
+				return
+			}
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, gabp.SessionWelcomeResult{
+				AgentID:       "adventure",
+				Capabilities:  gabp.Capabilities{Methods: []string{"tools/list", gabp.SessionReauthMethod}},
+				SchemaVersion: "1.0",
+			}))
+		case "tools/list":
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"tools": []interface{}{}}))
+		case gabp.SessionReauthMethod:
+			presented, _ := params["token"].(string)
+			newToken, _ := params["newToken"].(string)
+			if presented != token || newToken == "" {
+				err = writer.WriteJSON(util.NewGABPError(request.ID, -32001, "invalid token", nil))
+				break
+			}
+			token = newToken
+			rotated <- newToken
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{}))
+		default:
+			err = fmt.Errorf("unexpected method: %s", request.Method)
+		}
+		if err != nil {
+			done <- err
+			return
+		}
+	}
+}
+
+func TestRotateTokenReauthenticatesConnectedBridge(t *testing.T) {
+	server, configDir := newGamesTestServer(t, roamingGamesConfig())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+	writeBridgeJSONForTest(t, configDir, "adventure", port, "launch-token")
+
+	rotated := make(chan string, 1)
+	session := make(chan error, 1)
+	go serveTestGabpReauthSession(listener, "launch-token", rotated, session)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_connect failed: %#v", result)
+	}
+
+	result := callToolForTest(t, server, "games_rotate_token", map[string]interface{}{"gameId": "adventure"})
+	if result.IsError {
+		t.Fatalf("games_rotate_token failed: %#v", result)
+	}
+	if reauthenticated, _ := result.StructuredContent["reauthenticated"].(bool); !reauthenticated {
+		t.Fatalf("expected the connected bridge to be re-authenticated, got %#v", result.StructuredContent)
+	}
+
+	var newToken string
+	select {
+	case newToken = <-rotated:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the bridge never received session/reauth")
+	}
+	bridge, err := config.ReadBridgeEndpoint("adventure", configDir)
+	if err != nil {
+		t.Fatalf("failed to read bridge.json: %v", err)
+	}
+	if bridge.Token != newToken || bridge.Port != port || bridge.IssuedAt.IsZero() {
+		t.Fatalf("expected bridge.json to hold the new token on the same port, got %#v", bridge)
+	}
+	server.mu.RLock()
+	target := server.gabpTargets["adventure"]
+	server.mu.RUnlock()
+	if target.token != newToken {
+		t.Fatal("expected reconnects to use the new token")
+	}
+	if status := server.checkGameStatus("adventure"); status != "connected" {
+		t.Fatalf("expected the session to stay connected, got %s", status)
+	}
+
+	server.CleanupGABPConnection("adventure")
+	if err := <-session; err != nil {
+		t.Fatalf("GABP session failed: %v", err)
+	}
+}
+
+func TestRotateTokenRefusesBridgeWithoutReauth(t *testing.T) {
+	server, configDir := newGamesTestServer(t, roamingGamesConfig())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	writeBridgeJSONForTest(t, configDir, "adventure", listener.Addr().(*net.TCPAddr).Port, "launch-token")
+
+	session := make(chan error, 1)
+	go serveTestGabpToolsSession(listener, "launch-token", nil, true, session)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_connect failed: %#v", result)
+	}
+
+	if result := callToolForTest(t, server, "games_rotate_token", map[string]interface{}{"gameId": "adventure"}); !result.IsError {
+		t.Fatalf("expected rotation to be refused for a bridge without session/reauth, got %#v", result)
+	}
+	if bridge, err := config.ReadBridgeEndpoint("adventure", configDir); err != nil || bridge.Token != "launch-token" {
+		t.Fatalf("expected bridge.json to keep the token the bridge knows, got %#v (%v)", bridge, err)
+	}
+
+	server.CleanupGABPConnection("adventure")
+	if err := <-session; err != nil {
+		t.Fatalf("GABP session failed: %v", err)
+	}
+}
+
+func TestRotateTokenOfStoppedGameRewritesBridgeFile(t *testing.T) {
+	server, configDir := newGamesTestServer(t, roamingGamesConfig())
+	writeBridgeJSONForTest(t, configDir, "adventure", 51234, "old-token")
+
+	if result := callToolForTest(t, server, "games_rotate_token", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_rotate_token failed: %#v", result)
+	}
+	bridge, err := config.ReadBridgeEndpoint("adventure", configDir)
+	if err != nil {
+		t.Fatalf("failed to read bridge.json: %v", err)
+	}
+	if bridge.Token == "old-token" || bridge.Port != 51234 {
+		t.Fatalf("expected a new token on the same port, got %#v", bridge)
+	}
+}
+
+func TestRotateExpiredTokensLeavesStoppedGamesForTheirNextStart(t *testing.T) {
+	gamesConfig := roamingGamesConfig()
+	gamesConfig.Timeouts = &config.TimeoutsConfig{Bridge: &config.BridgeTimeoutsConfig{TokenTTLSeconds: 60}}
+	server, configDir := newGamesTestServer(t, gamesConfig)
+	writeBridgeJSONForTest(t, configDir, "adventure", 51234, "old-token")
+
+	server.rotateExpiredTokens(time.Now(), map[string]time.Time{})
+	if bridge, err := config.ReadBridgeEndpoint("adventure", configDir); err != nil || bridge.Token != "old-token" {
+		t.Fatalf("expected the stopped game's token to wait for its next start, got %#v (%v)", bridge, err)
+	}
+}
@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// allGamesAdvertised is the advertiseTools entry that lists the mirrored tools
+// of every game in tools/list.
+const allGamesAdvertised = "*"
+
+// advertiseConfiguredTools starts out with the games of the advertiseTools
+// setting listed in tools/list.
+func (s *Server) advertiseConfiguredTools(gameIDs []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advertisedTools = make(map[string]bool, len(gameIDs))
+	for _, gameID := range gameIDs {
+		s.advertisedTools[strings.TrimSpace(gameID)] = true
+	}
+}
+
+// gameToolsAdvertisedLocked reports whether tools/list includes gameID's
+// mirrored tools. A game's own setting wins over "*". Callers hold s.mu.
+func (s *Server) gameToolsAdvertisedLocked(gameID string) bool {
+	if advertised, exists := s.advertisedTools[gameID]; exists {
+		return advertised
+	}
+	return s.advertisedTools[allGamesAdvertised]
+}
+
+// gameToolsAdvertised reports whether tools/list includes gameID's mirrored
+// tools.
+func (s *Server) gameToolsAdvertised(gameID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.gameToolsAdvertisedLocked(gameID)
+}
+
+// setGameToolsAdvertised lists gameID's mirrored tools in tools/list or stops
+// listing them, and reports whether that changed anything.
+func (s *Server) setGameToolsAdvertised(gameID string, advertised bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.gameToolsAdvertisedLocked(gameID) == advertised {
+		return false
+	}
+	if s.advertisedTools == nil {
+		s.advertisedTools = make(map[string]bool)
+	}
+	s.advertisedTools[gameID] = advertised
+	return true
+}
+
+// gameToolCount returns the number of mirrored tools gameID has right now.
+func (s *Server) gameToolCount(gameID string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.sessions[gameID].toolNames())
+}
+
+func (s *Server) registerToolAdvertisingTools(gamesConfig *config.GamesConfig, normalizationConfig *config.ToolNormalizationConfig) {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"gameId": map[string]interface{}{
+				"type":        "string",
+				"description": "Game ID or launch target",
+			},
+		},
+		"required": []string{"gameId"},
+	}
+
+	handler := func(advertised bool) func(args map[string]interface{}) (*ToolResult, error) {
+		return func(args map[string]interface{}) (*ToolResult, error) {
+			gameIdOrTarget, ok := args["gameId"].(string)
+			if !ok || strings.TrimSpace(gameIdOrTarget) == "" {
+				return &ToolResult{
+					Content: []Content{{Type: "text", Text: "gameId parameter is required"}},
+					IsError: true,
+				}, nil
+			}
+			game, exists := s.resolveGameId(gamesConfig, gameIdOrTarget)
+			if !exists {
+				return &ToolResult{
+					Content: []Content{{Type: "text", Text: fmt.Sprintf("Game '%s' not found. Use games_list to see available games.", gameIdOrTarget)}},
+					IsError: true,
+				}, nil
+			}
+
+			changed := s.setGameToolsAdvertised(game.ID, advertised)
+			toolCount := s.gameToolCount(game.ID)
+			if changed && toolCount > 0 {
+				s.SendToolsListChangedNotification()
+			}
+
+			var text string
+			switch {
+			case !advertised:
+				text = fmt.Sprintf("The tools of game '%s' are no longer listed in tools/list. games_tool_names and games_call_tool still reach them.", game.ID)
+			case toolCount == 0:
+				text = fmt.Sprintf("The tools of game '%s' will be listed in tools/list once its bridge is connected and mirrored.", game.ID)
+			default:
+				text = fmt.Sprintf("Listed the %d tools of game '%s' in tools/list.", toolCount, game.ID)
+			}
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: text}},
+				StructuredContent: map[string]interface{}{
+					"gameId":     game.ID,
+					"advertised": advertised,
+					"changed":    changed,
+					"toolCount":  toolCount,
+				},
+			}, nil
+		}
+	}
+
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.enable_tools",
+		Description: "List a game's mirrored tools in tools/list, so clients can call them by name, and send tools/list_changed. The setting lasts until games_disable_tools or a GABS restart and covers tools mirrored later.",
+		InputSchema: inputSchema,
+	}, handler(true), normalizationConfig)
+
+	s.RegisterToolWithConfig(Tool{
+		Name:        "games.disable_tools",
+		Description: "Stop listing a game's mirrored tools in tools/list to keep it small. games_tool_names, games_tools and games_call_tool still reach them.",
+		InputSchema: inputSchema,
+	}, handler(false), normalizationConfig)
+}
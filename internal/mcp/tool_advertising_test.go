@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func registerFactoryToolForTest(server *Server) {
+	server.RegisterGameTool("factory", Tool{
+		Name:        "factory.inventory.get",
+		InputSchema: map[string]interface{}{"type": "object"},
+		Meta:        map[string]interface{}{toolMetaGABPName: "inventory/get", toolMetaCategory: "inventory"},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		return &ToolResult{}, nil
+	}, nil)
+}
+
+func listsToolForTest(t *testing.T, server *Server, name string) bool {
+	t.Helper()
+	result, _ := listToolsForTest(t, server, nil)
+	for _, tool := range result.Tools {
+		if tool.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEnableToolsListsGameToolsUntilDisabled(t *testing.T) {
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{"factory": sleepingGameForTest("factory", "Factory")},
+	})
+	registerFactoryToolForTest(server)
+	if listsToolForTest(t, server, "factory.inventory.get") {
+		t.Fatal("expected mirrored tools to stay out of tools/list by default")
+	}
+
+	enabled := callToolForTest(t, server, "games_enable_tools", map[string]interface{}{"gameId": "factory"})
+	if enabled.IsError || enabled.StructuredContent["changed"] != true || enabled.StructuredContent["toolCount"] != float64(1) {
+		t.Fatalf("games_enable_tools failed: %#v", enabled)
+	}
+	if !listsToolForTest(t, server, "factory.inventory.get") {
+		t.Fatal("expected the enabled game's tools in tools/list")
+	}
+	tools := callToolForTest(t, server, "games_tools", map[string]interface{}{"gameId": "factory"})
+	if tools.StructuredContent["advertised"] != true {
+		t.Fatalf("expected games_tools to report the tools as advertised, got %#v", tools.StructuredContent)
+	}
+
+	if again := callToolForTest(t, server, "games_enable_tools", map[string]interface{}{"gameId": "factory"}); again.StructuredContent["changed"] != false {
+		t.Fatalf("expected enabling twice to change nothing, got %#v", again.StructuredContent)
+	}
+	if disabled := callToolForTest(t, server, "games_disable_tools", map[string]interface{}{"gameId": "factory"}); disabled.IsError {
+		t.Fatalf("games_disable_tools failed: %#v", disabled)
+	}
+	if listsToolForTest(t, server, "factory.inventory.get") {
+		t.Fatal("expected the disabled game's tools to leave tools/list")
+	}
+	if result := callToolForTest(t, server, "factory.inventory.get", nil); result.IsError {
+		t.Fatalf("expected a tool that is not listed to stay callable, got %#v", result)
+	}
+}
+
+func TestAdvertiseToolsSettingListsEveryGame(t *testing.T) {
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games:          map[string]config.GameConfig{"factory": sleepingGameForTest("factory", "Factory")},
+		AdvertiseTools: []string{"*"},
+	})
+	registerFactoryToolForTest(server)
+	if !listsToolForTest(t, server, "factory.inventory.get") {
+		t.Fatal(`expected "*" to list every game's tools`)
+	}
+	callToolForTest(t, server, "games_disable_tools", map[string]interface{}{"gameId": "factory"})
+	if listsToolForTest(t, server, "factory.inventory.get") {
+		t.Fatal(`expected games_disable_tools to win over "*"`)
+	}
+}
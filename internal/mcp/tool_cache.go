@@ -0,0 +1,99 @@
+package mcp
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+// cachedToolAnswer is a game's answer to a tool call that later calls with
+// the same arguments may reuse until expires.
+type cachedToolAnswer struct {
+	client   *gabp.Client // The connection that answered; a reconnect starts afresh
+	result   *ToolResult
+	storedAt time.Time
+	expires  time.Time
+}
+
+// toolCacheKey identifies calls of gameID's GABP tool with the same
+// arguments. json.Marshal sorts map keys, so equal arguments give equal keys.
+func toolCacheKey(gameID, gabpToolName string, args map[string]interface{}) (string, bool) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", false
+	}
+	return gameID + "\x00" + canonicalGABPToolName(gabpToolName) + "\x00" + string(data), true
+}
+
+// toolCacheTTL returns how long answers of gameID's GABP tool may be reused,
+// from the game's cache setting or the tool's cacheable tag, or 0 when they
+// are not cached.
+func (s *Server) toolCacheTTL(gameID, gabpToolName string, tags []string) time.Duration {
+	if s.gamesConfig == nil {
+		return 0
+	}
+	game, exists := s.lookupGame(s.gamesConfig, gameID)
+	if !exists {
+		return 0
+	}
+	return game.ToolCacheTTL(gabpToolName, tags)
+}
+
+// cachedToolResult returns a still valid answer client gave to the same
+// call, marked with _meta.cached, or nil.
+func (s *Server) cachedToolResult(client *gabp.Client, gameID, gabpToolName string, args map[string]interface{}, ttl time.Duration) *ToolResult {
+	if ttl <= 0 {
+		return nil
+	}
+	key, ok := toolCacheKey(gameID, gabpToolName, args)
+	if !ok {
+		return nil
+	}
+
+	s.mu.RLock()
+	now := s.clock.Now()
+	s.mu.RUnlock()
+
+	s.toolCacheMu.Lock()
+	defer s.toolCacheMu.Unlock()
+	answer, exists := s.toolCache[key]
+	if !exists || answer.client != client || !now.Before(answer.expires) {
+		delete(s.toolCache, key)
+		return nil
+	}
+	result := *answer.result
+	result.Meta = map[string]interface{}{
+		"cached":           true,
+		"cachedAgeSeconds": int(now.Sub(answer.storedAt) / time.Second),
+	}
+	return &result
+}
+
+// storeToolResult keeps a successful answer of client for ttl. It also drops
+// expired answers, so the cache does not grow with calls nobody repeats.
+func (s *Server) storeToolResult(client *gabp.Client, gameID, gabpToolName string, args map[string]interface{}, ttl time.Duration, result *ToolResult) {
+	if ttl <= 0 || result == nil || result.IsError {
+		return
+	}
+	key, ok := toolCacheKey(gameID, gabpToolName, args)
+	if !ok {
+		return
+	}
+
+	s.mu.RLock()
+	now := s.clock.Now()
+	s.mu.RUnlock()
+
+	s.toolCacheMu.Lock()
+	defer s.toolCacheMu.Unlock()
+	for existing, answer := range s.toolCache {
+		if !now.Before(answer.expires) {
+			delete(s.toolCache, existing)
+		}
+	}
+	if s.toolCache == nil {
+		s.toolCache = make(map[string]cachedToolAnswer)
+	}
+	s.toolCache[key] = cachedToolAnswer{client: client, result: result, storedAt: now, expires: now.Add(ttl)}
+}
@@ -0,0 +1,120 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// serveTestGabpCountingCallsSession mirrors world/scan, tagged cacheable:5s,
+// and world/stats, and counts the tools/call requests it answers.
+func serveTestGabpCountingCallsSession(listener net.Listener, calls *atomic.Int32, done chan<- error) {
+	conn, err := listener.Accept()
+	if err != nil {
+		done <- err
+		return
+	}
+	defer conn.Close()
+
+	reader := util.NewLSPFrameReader(conn)
+	writer := util.NewLSPFrameWriter(conn)
+	for {
+		data, err := reader.ReadMessage()
+		if err != nil {
+			done <- nil
+			return
+		}
+		var request util.GABPMessage
+		if err := json.Unmarshal(data, &request); err != nil {
+			done <- err
+			return
+		}
+
+		switch request.Method {
+		case "session/hello":
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, gabp.SessionWelcomeResult{
+				AgentID:       "adventure",
+				Capabilities:  gabp.Capabilities{Methods: []string{"tools/list", "tools/call"}},
+				SchemaVersion: "1.1",
+			}))
+		case "tools/list":
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"tools": []map[string]interface{}{
+				{"name": "world/scan", "inputSchema": map[string]interface{}{"type": "object"}, "tags": []string{"read-only", config.CacheableTagPrefix + "5s"}},
+				{"name": "world/stats", "inputSchema": map[string]interface{}{"type": "object"}},
+			}}))
+		case "tools/call":
+			count := calls.Add(1)
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"call": count}))
+		default:
+			err = fmt.Errorf("unexpected method: %s", request.Method)
+		}
+		if err != nil {
+			done <- err
+			return
+		}
+	}
+}
+
+func TestCacheableToolAnswersAreReusedUntilTheyExpire(t *testing.T) {
+	gamesConfig := roamingGamesConfig()
+	game := gamesConfig.Games["adventure"]
+	game.Cache = []config.ToolCacheConfig{{Tools: []string{"world/stats"}, TTL: "0s"}}
+	gamesConfig.Games["adventure"] = game
+	server, configDir := newGamesTestServer(t, gamesConfig)
+	clock := util.NewFakeClock(time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+	server.SetClock(clock)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	writeBridgeJSONForTest(t, configDir, "adventure", listener.Addr().(*net.TCPAddr).Port, "launch-token")
+
+	var calls atomic.Int32
+	session := make(chan error, 1)
+	go serveTestGabpCountingCallsSession(listener, &calls, session)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_connect failed: %#v", result)
+	}
+
+	scan := func(args map[string]interface{}) ToolResult {
+		return callToolForTest(t, server, "games_call_tool", map[string]interface{}{"gameId": "adventure", "tool": "world/scan", "arguments": args})
+	}
+	first := scan(map[string]interface{}{"radius": 8})
+	second := scan(map[string]interface{}{"radius": 8})
+	if first.IsError || second.IsError || calls.Load() != 1 {
+		t.Fatalf("expected the repeated scan to be answered from the cache, got %d calls (%#v, %#v)", calls.Load(), first, second)
+	}
+	if second.Meta["cached"] != true || second.StructuredContent["call"] != float64(1) {
+		t.Fatalf("expected the cached answer to be marked, got %#v", second)
+	}
+	if scan(map[string]interface{}{"radius": 16}); calls.Load() != 2 {
+		t.Fatalf("expected other arguments to reach the game, got %d calls", calls.Load())
+	}
+
+	clock.Advance(6 * time.Second)
+	if expired := scan(map[string]interface{}{"radius": 8}); expired.Meta != nil || calls.Load() != 3 {
+		t.Fatalf("expected the expired answer to be fetched again, got %d calls (%#v)", calls.Load(), expired)
+	}
+
+	// The game's cache setting wins over the tag, here turning caching off
+	for i := 0; i < 2; i++ {
+		callToolForTest(t, server, "games_call_tool", map[string]interface{}{"gameId": "adventure", "tool": "world/stats"})
+	}
+	if calls.Load() != 5 {
+		t.Fatalf("expected uncached tools to reach the game every time, got %d calls", calls.Load())
+	}
+
+	server.CleanupGABPConnection("adventure")
+	if err := <-session; err != nil {
+		t.Fatalf("GABP session failed: %v", err)
+	}
+}
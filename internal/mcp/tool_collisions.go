@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"sort"
+	"strings"
+)
+
+// toolNameCollision records a tool that was registered under a
+// disambiguated name because another tool already held the name it
+// normalized to, such as two long names that OpenAI normalization truncates
+// to the same prefix.
+type toolNameCollision struct {
+	Name         string `json:"name"`             // The contested name
+	Tool         string `json:"tool"`             // Name the later tool was registered with
+	Holder       string `json:"holder"`           // Name the tool that kept Name was registered with
+	RegisteredAs string `json:"registeredAs"`     // Name the later tool got instead
+	GameID       string `json:"gameId,omitempty"` // Game of the later tool, for mirrored tools
+}
+
+// disambiguatedToolName appends a hash of sourceName to name, shortening
+// name so the result fits maxLength. A tool that loses a name always gets
+// the same replacement.
+func disambiguatedToolName(name, sourceName string, maxLength int) string {
+	if maxLength <= 0 {
+		maxLength = 64
+	}
+	hash := stableToolNameHash(sourceName)
+	suffix := "_" + hash
+	if len(name)+len(suffix) <= maxLength {
+		return name + suffix
+	}
+	prefixLength := maxLength - len(suffix)
+	if prefixLength <= 0 {
+		return hash[:maxLength]
+	}
+	return strings.TrimRight(name[:prefixLength], "_-") + suffix
+}
+
+// disambiguateToolNameLocked returns the name tool, registered as
+// sourceName, may take: its own, unless a different tool already holds it.
+// Then the collision is logged and recorded for games_health, and a hashed
+// name is returned instead. Callers hold s.mu.
+func (s *Server) disambiguateToolNameLocked(tool Tool, sourceName string, maxLength int) string {
+	existing, taken := s.tools[tool.Name]
+	if !taken || existing.sourceName == "" || existing.sourceName == sourceName {
+		return tool.Name
+	}
+
+	collision := toolNameCollision{
+		Name:         tool.Name,
+		Tool:         sourceName,
+		Holder:       existing.sourceName,
+		RegisteredAs: disambiguatedToolName(tool.Name, sourceName, maxLength),
+		GameID:       toolMetaString(tool, toolMetaGameID),
+	}
+	if s.toolCollisions == nil {
+		s.toolCollisions = make(map[string]toolNameCollision)
+	}
+	if _, known := s.toolCollisions[collision.RegisteredAs]; !known {
+		s.log.Warnw("tool name collision, registering the later tool under a disambiguated name", "name", collision.Name, "tool", collision.Tool, "holder", collision.Holder, "registeredAs", collision.RegisteredAs)
+	}
+	s.toolCollisions[collision.RegisteredAs] = collision
+	return collision.RegisteredAs
+}
+
+// toolNameCollisions lists the collisions of tools that are still
+// registered, ordered by the name they got.
+func (s *Server) toolNameCollisions() []toolNameCollision {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	collisions := make([]toolNameCollision, 0, len(s.toolCollisions))
+	for name, collision := range s.toolCollisions {
+		if handler, exists := s.tools[name]; exists && handler.sourceName == collision.Tool {
+			collisions = append(collisions, collision)
+		}
+	}
+	sort.Slice(collisions, func(i, j int) bool {
+		return collisions[i].RegisteredAs < collisions[j].RegisteredAs
+	})
+	return collisions
+}
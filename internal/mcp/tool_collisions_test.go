@@ -0,0 +1,51 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestToolNameCollisionsAreDisambiguated(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	normalization := &config.ToolNormalizationConfig{EnableOpenAINormalization: true, MaxToolNameLength: 24}
+
+	register := func(name, answer string) {
+		server.RegisterToolWithConfig(Tool{Name: name, InputSchema: map[string]interface{}{"type": "object"}}, func(args map[string]interface{}) (*ToolResult, error) {
+			return &ToolResult{Content: []Content{{Type: "text", Text: answer}}}, nil
+		}, normalization)
+	}
+	register("factory.inventory.list_all_items", "first")
+	register("factory.inventory.list_all_stacks", "second")
+	// Registering a tool again replaces it instead of colliding with itself.
+	register("factory.inventory.list_all_items", "first")
+
+	collisions := server.toolNameCollisions()
+	if len(collisions) != 1 {
+		t.Fatalf("expected one collision, got %#v", collisions)
+	}
+	collision := collisions[0]
+	if collision.Tool != "factory.inventory.list_all_stacks" || collision.Holder != "factory.inventory.list_all_items" {
+		t.Fatalf("unexpected collision: %#v", collision)
+	}
+	if len(collision.RegisteredAs) > 24 || collision.RegisteredAs != disambiguatedToolName(collision.Name, collision.Tool, 24) {
+		t.Fatalf("expected a deterministic hashed name within the length limit, got %q", collision.RegisteredAs)
+	}
+
+	if result := callToolForTest(t, server, collision.Name, nil); result.Content[0].Text != "first" {
+		t.Fatalf("expected the first tool to keep %s, got %#v", collision.Name, result)
+	}
+	if result := callToolForTest(t, server, collision.RegisteredAs, nil); result.Content[0].Text != "second" {
+		t.Fatalf("expected the second tool under %s, got %#v", collision.RegisteredAs, result)
+	}
+
+	report := server.healthReport(nil)
+	if listed, _ := report["toolNameCollisions"].([]toolNameCollision); len(listed) != 1 {
+		t.Fatalf("expected games_health to list the collision, got %#v", report)
+	}
+	if text := healthSummaryText(report); !strings.Contains(text, collision.RegisteredAs) {
+		t.Fatalf("expected the summary to name the disambiguated tool, got %q", text)
+	}
+}
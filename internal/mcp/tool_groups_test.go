@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func TestToolCategoryPrefersTagsOverNamespace(t *testing.T) {
+	cases := []struct {
+		name string
+		tags []string
+		want string
+	}{
+		{"inventory/get", []string{"Diagnostics", "read-only"}, "Diagnostics"},
+		{"inventory/get", []string{" "}, "inventory"},
+		{"games.start", nil, "games"},
+		{"ping", nil, ""},
+	}
+	for _, tc := range cases {
+		if got := toolCategory(tc.name, tc.tags); got != tc.want {
+			t.Errorf("toolCategory(%q, %v) = %q, want %q", tc.name, tc.tags, got, tc.want)
+		}
+	}
+}
+
+func TestGameToolListingsGroupToolsByGameAndCategory(t *testing.T) {
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{"factory": sleepingGameForTest("factory", "Factory")},
+	})
+	handler := func(args map[string]interface{}) (*ToolResult, error) {
+		return &ToolResult{}, nil
+	}
+	for _, tool := range []struct{ name, category string }{
+		{"factory.inventory.get", "inventory"},
+		{"factory.inventory.drop", "inventory"},
+		{"factory.world.save", "world"},
+	} {
+		server.RegisterGameTool("factory", Tool{
+			Name:        tool.name,
+			InputSchema: map[string]interface{}{"type": "object"},
+			Meta:        map[string]interface{}{toolMetaGameID: "factory", toolMetaCategory: tool.category, toolMetaTitle: "Title of " + tool.name},
+		}, handler, nil)
+	}
+
+	for _, listing := range []string{"games_tool_names", "games_tools"} {
+		result := callToolForTest(t, server, listing, map[string]interface{}{"gameId": "factory"})
+		if result.IsError {
+			t.Fatalf("%s failed: %#v", listing, result)
+		}
+		groups, _ := result.StructuredContent["groups"].([]interface{})
+		if len(groups) != 2 {
+			t.Fatalf("%s: expected an inventory and a world group, got %#v", listing, result.StructuredContent["groups"])
+		}
+		counts := map[string]int{}
+		for _, raw := range groups {
+			group, _ := raw.(map[string]interface{})
+			if group["gameId"] != "factory" {
+				t.Fatalf("%s: expected groups of game factory, got %#v", listing, group)
+			}
+			category, _ := group["category"].(string)
+			tools, _ := group["tools"].([]interface{})
+			counts[category] = len(tools)
+		}
+		if counts["inventory"] != 2 || counts["world"] != 1 {
+			t.Fatalf("%s: unexpected group sizes %#v", listing, counts)
+		}
+		items, _ := result.StructuredContent["tools"].([]interface{})
+		if len(items) != 3 {
+			t.Fatalf("%s: expected three tools, got %#v", listing, items)
+		}
+		if item, _ := items[0].(map[string]interface{}); item["category"] == nil || item["title"] == nil {
+			t.Fatalf("%s: expected every tool to carry its category and title, got %#v", listing, items)
+		}
+	}
+}
+
+func TestToolsListCarriesCategoryOfCoreTools(t *testing.T) {
+	server, _ := newGamesTestServer(t, &config.GamesConfig{})
+	result, _ := listToolsForTest(t, server, map[string]interface{}{"prefix": "games."})
+	if len(result.Tools) == 0 {
+		t.Fatal("expected the games tools")
+	}
+	for _, tool := range result.Tools {
+		if tool.Meta[toolMetaCategory] != "games" {
+			t.Fatalf("expected %s to be in category games, got %#v", tool.Name, tool.Meta)
+		}
+	}
+}
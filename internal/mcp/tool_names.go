@@ -8,17 +8,38 @@ import (
 )
 
 const (
-	toolMetaGABPName          = "gabpName"
-	toolMetaQualifiedGABPName = "qualifiedGABPName"
-	toolMetaLegacyName        = "legacyName"
-	toolMetaAliases           = "aliases"
-	toolMetaTags              = "tags"
+	toolMetaGABPName             = "gabpName"
+	toolMetaQualifiedGABPName    = "qualifiedGABPName"
+	toolMetaLegacyName           = "legacyName"
+	toolMetaAliases              = "aliases"
+	toolMetaTags                 = "tags"
+	toolMetaRequiresConfirmation = "requiresConfirmation"
+	toolMetaGameID               = "gameId"
+	toolMetaCategory             = "category"
+	toolMetaTitle                = "title"
 )
 
+// toolCategory names the group a tool belongs to, for clients that present
+// tools grouped: its first tag, or else the namespace of its name, such as
+// "inventory" for "inventory/get" or "games" for "games.start". It is empty
+// for a tool with neither.
+func toolCategory(name string, tags []string) string {
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			return tag
+		}
+	}
+	if index := strings.IndexAny(name, "/."); index > 0 {
+		return name[:index]
+	}
+	return ""
+}
+
 type gameToolAlias struct {
-	GameID  string
-	GABP    string
-	Exposed string
+	GameID      string
+	GABP        string
+	Exposed     string
+	InputSchema map[string]interface{}
 }
 
 func safeMCPToolName(gameID, gabpName string, maxLength int) string {
@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"time"
+
+	"github.com/pardeike/gabs/internal/gabp"
+)
+
+const (
+	// toolResyncPollInterval is how often GABS asks a bridge that does not
+	// announce tool changes on gabp.ToolsListChangedChannel for its tools.
+	toolResyncPollInterval = time.Minute
+	// toolResyncTimeout bounds the tools/list request of a resync.
+	toolResyncTimeout = 10 * time.Second
+)
+
+// watchGABPToolChanges keeps gameID's mirrored tools in step with the bridge
+// while client is connected: on each tools/list_changed event when the
+// bridge announces changes, by polling every toolResyncPollInterval when it
+// does not. Calling it again for the same client does nothing.
+func (s *Server) watchGABPToolChanges(client *gabp.Client, gameID string) {
+	s.mu.Lock()
+	if s.toolWatches[gameID] == client {
+		s.mu.Unlock()
+		return
+	}
+	if s.toolWatches == nil {
+		s.toolWatches = make(map[string]*gabp.Client)
+	}
+	s.toolWatches[gameID] = client
+	clock := s.clock
+	s.mu.Unlock()
+
+	capabilities := client.GetCapabilities()
+	if gabp.SupportsEventSubscription(capabilities) && containsString(capabilities.Events, gabp.ToolsListChangedChannel) {
+		// exposeGABPEvents subscribes to the channel and calls
+		// resyncGABPTools for each event
+		return
+	}
+
+	go func() {
+		ticker := clock.NewTicker(toolResyncPollInterval)
+		defer ticker.Stop()
+		for range ticker.C() {
+			s.mu.RLock()
+			current := s.toolWatches[gameID]
+			s.mu.RUnlock()
+			if current != client || !client.IsConnected() {
+				return
+			}
+			if err := s.resyncGABPTools(client, gameID); err != nil {
+				s.gabpLog.Debugw("failed to poll GABP tools", "gameId", gameID, "error", err)
+			}
+		}
+	}()
+}
+
+// resyncGABPTools mirrors the bridge's current tools, unregisters the ones
+// it no longer has and sends a single tools/list_changed when the set of
+// tools changed.
+func (s *Server) resyncGABPTools(client *gabp.Client, gameID string) error {
+	s.toolResyncMu.Lock()
+	defer s.toolResyncMu.Unlock()
+
+	s.mu.RLock()
+	attached, _ := s.sessions[gameID].gabpClient()
+	previous := append([]string(nil), s.sessions[gameID].toolNames()...)
+	s.mu.RUnlock()
+	if attached != client {
+		return nil
+	}
+
+	current, err := s.syncGABPToolNames(client, gameID, toolResyncTimeout)
+	if err != nil {
+		return err
+	}
+
+	var removed []string
+	s.mu.Lock()
+	session := s.sessionLocked(gameID)
+	for _, name := range session.takeTools() {
+		if containsString(current, name) {
+			session.addTool(name)
+			continue
+		}
+		removed = append(removed, name)
+		delete(s.tools, name)
+	}
+	for alias, target := range s.gameToolAliases {
+		if target.GameID == gameID && containsString(removed, target.Exposed) {
+			delete(s.gameToolAliases, alias)
+		}
+	}
+	mirrored := append([]string(nil), session.toolNames()...)
+	s.mu.Unlock()
+
+	if !sameToolNames(previous, mirrored) {
+		s.gabpLog.Infow("GABP tools changed", "gameId", gameID, "count", len(mirrored), "removed", removed)
+		s.SendToolsListChangedNotification()
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// serveTestGabpChangingToolsSession answers tools/list with world/status and
+// world/reload until GABS subscribes to events, then replaces world/reload
+// with world/save and announces it on tools/list_changed.
+func serveTestGabpChangingToolsSession(listener net.Listener, token string, done chan<- error) {
+	conn, err := listener.Accept()
+	if err != nil {
+		done <- err
+		return
+	}
+	defer conn.Close()
+
+	reader := util.NewLSPFrameReader(conn)
+	writer := util.NewLSPFrameWriter(conn)
+	tools := []string{"world/status", "world/reload"}
+	for {
+		data, err := reader.ReadMessage()
+		if err != nil {
+			done <- nil
+			return
+		}
+		var request util.GABPMessage
+		if err := json.Unmarshal(data, &request); err != nil {
+			done <- err
+			return
+		}
+
+		switch request.Method {
+		case "session/hello":
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, gabp.SessionWelcomeResult{
+				AgentID: "adventure",
+				Capabilities: gabp.Capabilities{
+					Methods: []string{"tools/list", gabp.EventsSubscribeMethod},
+					Events:  []string{gabp.ToolsListChangedChannel},
+				},
+				SchemaVersion: "1.0",
+			}))
+		case "tools/list":
+			descriptors := []map[string]interface{}{}
+			for _, name := range tools {
+				descriptors = append(descriptors, map[string]interface{}{"name": name, "inputSchema": map[string]interface{}{"type": "object"}})
+			}
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{"tools": descriptors}))
+		case gabp.EventsSubscribeMethod:
+			err = writer.WriteJSON(util.NewGABPResponse(request.ID, map[string]interface{}{}))
+			if err == nil {
+				tools = []string{"world/status", "world/save"}
+				err = writer.WriteJSON(util.NewGABPEvent(gabp.ToolsListChangedChannel, 1, map[string]interface{}{}))
+			}
+		default:
+			err = fmt.Errorf("unexpected method: %s", request.Method)
+		}
+		if err != nil {
+			done <- err
+			return
+		}
+	}
+}
+
+func TestToolsListChangedEventResyncsMirroredTools(t *testing.T) {
+	server, configDir := newGamesTestServer(t, roamingGamesConfig())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	writeBridgeJSONForTest(t, configDir, "adventure", listener.Addr().(*net.TCPAddr).Port, "launch-token")
+
+	client := server.addClient(clientTransportStdio, nil)
+	session := make(chan error, 1)
+	go serveTestGabpChangingToolsSession(listener, "launch-token", session)
+	if result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "adventure"}); result.IsError {
+		t.Fatalf("games_connect failed: %#v", result)
+	}
+
+	mirrored := func() string {
+		var names []string
+		for _, tool := range server.getGameSpecificTools("adventure") {
+			names = append(names, gabpToolNameFromTool("adventure", tool))
+		}
+		sort.Strings(names)
+		return strings.Join(names, ",")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for mirrored() != "world/save,world/status" {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the tools to follow the bridge, got %s", mirrored())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, known := server.resolveKnownGABPToolAlias("adventure", "world/reload"); known {
+		t.Fatal("expected the aliases of the removed tool to be gone")
+	}
+
+	changed := 0
+	for drained := false; !drained; {
+		select {
+		case msg := <-client.outbox:
+			if msg.Method == "notifications/tools/list_changed" {
+				changed++
+			}
+		case <-time.After(200 * time.Millisecond):
+			drained = true
+		}
+	}
+	if changed == 0 {
+		t.Fatal("expected a tools/list_changed notification")
+	}
+
+	server.CleanupGABPConnection("adventure")
+	if err := <-session; err != nil {
+		t.Fatalf("GABP session failed: %v", err)
+	}
+}
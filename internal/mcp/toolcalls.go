@@ -0,0 +1,311 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// DefaultMaxConcurrentCalls is how many tools/call handlers run at once unless
+// SetToolCallLimits says otherwise. Further calls wait for a free slot.
+const DefaultMaxConcurrentCalls = 8
+
+var (
+	// errToolCallCancelled is the cause of a tool call's context once the
+	// client cancelled the request.
+	errToolCallCancelled = errors.New("tool call cancelled by the client")
+	// errToolCallTimeout is the cause once the per-call timeout expired.
+	errToolCallTimeout = errors.New("tool call timed out")
+)
+
+// toolCall is one tools/call request as handlers registered with
+// registerCallTool see it. A nil *toolCall stands for a call that cannot be
+// cancelled and reports no progress.
+type toolCall struct {
+	ctx       context.Context
+	cancel    context.CancelCauseFunc
+	stopTimer context.CancelFunc
+	timeout   time.Duration
+	progress  *mcpProgress
+	session   *mcpSession
+	key       string
+	role      string
+	request   ToolCallParams // The tools/call request, which games.confirm replays
+	confirmed bool           // Set on the replay, so it does not wait for confirmation again
+}
+
+// SetToolCallLimits sets how many tools/call handlers may run at once, with 0
+// meaning DefaultMaxConcurrentCalls, and how long each call may take before
+// the client gets a timeout result, with 0 meaning no limit.
+func (s *Server) SetToolCallLimits(maxConcurrent int, timeout time.Duration) {
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrentCalls
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callSlots = make(chan struct{}, maxConcurrent)
+	s.toolTimeout = timeout
+}
+
+// beginToolCall starts tracking a tools/call request made by a client holding
+// role. Calls made through a session can be cancelled with
+// notifications/cancelled until end is called.
+func (s *Server) beginToolCall(id interface{}, params ToolCallParams, session *mcpSession, role string) *toolCall {
+	s.mu.RLock()
+	timeout := s.toolTimeout
+	s.mu.RUnlock()
+
+	ctx, cancel := context.WithCancelCause(s.serveContext())
+	stopTimer := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, stopTimer = context.WithTimeoutCause(ctx, timeout, errToolCallTimeout)
+	}
+	call := &toolCall{
+		ctx:       ctx,
+		cancel:    cancel,
+		stopTimer: stopTimer,
+		timeout:   timeout,
+		progress:  s.newToolProgress(params.Meta, session),
+		session:   session,
+		role:      role,
+		request:   params,
+	}
+	if key, ok := requestKey(id); ok && session != nil {
+		call.key = key
+		session.trackCall(key, cancel)
+	}
+	return call
+}
+
+// end stops tracking the call and releases its context.
+func (c *toolCall) end() {
+	if c.session != nil && c.key != "" {
+		c.session.untrackCall(c.key)
+	}
+	c.stopTimer()
+	c.cancel(nil)
+}
+
+// context returns the context that ends when the client cancels the call or
+// GABS stops serving.
+func (c *toolCall) context() context.Context {
+	if c == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// accessRole returns the role of the client that made the call. Calls GABS
+// makes itself act as the local user.
+func (c *toolCall) accessRole() string {
+	if c == nil || c.role == "" {
+		return config.AccessRoleLocal
+	}
+	return c.role
+}
+
+// clientSession returns the session the call came from, or nil.
+func (c *toolCall) clientSession() *mcpSession {
+	if c == nil {
+		return nil
+	}
+	return c.session
+}
+
+// reporter returns where the call reports progress, or nil.
+func (c *toolCall) reporter() *mcpProgress {
+	if c == nil {
+		return nil
+	}
+	return c.progress
+}
+
+// cancelled reports whether the client cancelled the call.
+func (c *toolCall) cancelled() bool {
+	return c != nil && errors.Is(context.Cause(c.ctx), errToolCallCancelled)
+}
+
+// timedOut reports whether the call ran past the per-call timeout.
+func (c *toolCall) timedOut() bool {
+	return c != nil && errors.Is(context.Cause(c.ctx), errToolCallTimeout)
+}
+
+// runToolHandler runs a tools/call handler once one of the call slots is
+// free. When the client cancels the call or it times out, runToolHandler
+// returns at once; the handler finishes in the background, keeping its slot,
+// and its result is dropped. Once Shutdown began no handler is started.
+func (s *Server) runToolHandler(handler *ToolHandler, args map[string]interface{}, call *toolCall) (*ToolResult, error) {
+	s.mu.RLock()
+	slots := s.callSlots
+	s.mu.RUnlock()
+
+	select {
+	case slots <- struct{}{}:
+	case <-call.ctx.Done():
+		if call.cancelled() || call.timedOut() {
+			return abandonedCallResult(handler, call), nil
+		}
+		slots <- struct{}{}
+	}
+	if s.shuttingDown.Load() {
+		<-slots
+		return shuttingDownResult(handler.Tool.Name), nil
+	}
+
+	type outcome struct {
+		result *ToolResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() { <-slots }()
+		var out outcome
+		if handler.CallHandler != nil {
+			out.result, out.err = handler.CallHandler(args, call)
+		} else {
+			out.result, out.err = handler.Handler(args)
+		}
+		done <- out
+	}()
+
+	select {
+	case out := <-done:
+		return out.result, out.err
+	case <-call.ctx.Done():
+		if call.cancelled() || call.timedOut() {
+			return abandonedCallResult(handler, call), nil
+		}
+		// GABS is shutting down; let the handler finish.
+		out := <-done
+		return out.result, out.err
+	}
+}
+
+// abandonedCallResult is what a client that stopped waiting for handler gets.
+// A cancelled call gets no response at all, so only a timeout has a result.
+func abandonedCallResult(handler *ToolHandler, call *toolCall) *ToolResult {
+	if !call.timedOut() {
+		return nil
+	}
+	return &ToolResult{
+		Content: []Content{{Type: "text", Text: fmt.Sprintf("Tool '%s' did not finish within %s and GABS stopped waiting for it. It may still complete in the background; check with games_status before retrying.", handler.Tool.Name, call.timeout)}},
+		IsError: true,
+	}
+}
+
+// runsConcurrently reports whether Serve handles raw on its own goroutine:
+// a tools/call request, or a batch with one in it. A batch that initializes
+// the session stays in line, since the messages after it depend on that.
+func runsConcurrently(raw json.RawMessage) bool {
+	if !isBatch(raw) {
+		return isToolCallRequest(raw)
+	}
+	var items []json.RawMessage
+	if json.Unmarshal(raw, &items) != nil {
+		return false
+	}
+	hasToolCall := false
+	for _, item := range items {
+		var envelope struct {
+			Method string `json:"method"`
+		}
+		if json.Unmarshal(item, &envelope) == nil && envelope.Method == "initialize" {
+			return false
+		}
+		hasToolCall = hasToolCall || isToolCallRequest(item)
+	}
+	return hasToolCall
+}
+
+// isToolCallRequest reports whether raw is a single tools/call request.
+func isToolCallRequest(raw json.RawMessage) bool {
+	var envelope struct {
+		Method string          `json:"method"`
+		ID     json.RawMessage `json:"id"`
+	}
+	return json.Unmarshal(raw, &envelope) == nil && envelope.Method == "tools/call" && len(envelope.ID) > 0
+}
+
+// registerCallTool registers a tool whose handler can report progress and
+// stop early when the client cancels the call.
+func (s *Server) registerCallTool(tool Tool, handler func(args map[string]interface{}, call *toolCall) (*ToolResult, error), normalizationConfig *config.ToolNormalizationConfig) {
+	s.registerTool(tool, func(args map[string]interface{}) (*ToolResult, error) {
+		return handler(args, nil)
+	}, handler, normalizationConfig)
+}
+
+// requestKey turns a JSON-RPC request ID into a map key. 1 and "1" are
+// different IDs and get different keys.
+func requestKey(id interface{}) (string, bool) {
+	if id == nil {
+		return "", false
+	}
+	data, err := json.Marshal(id)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+func (m *mcpSession) trackCall(key string, cancel context.CancelCauseFunc) {
+	m.callsMu.Lock()
+	defer m.callsMu.Unlock()
+	if m.calls == nil {
+		m.calls = make(map[string]context.CancelCauseFunc)
+	}
+	m.calls[key] = cancel
+}
+
+func (m *mcpSession) untrackCall(key string) {
+	m.callsMu.Lock()
+	defer m.callsMu.Unlock()
+	delete(m.calls, key)
+}
+
+// cancelCall cancels the session's in-flight request with the given key and
+// reports whether there was one.
+func (m *mcpSession) cancelCall(key string) bool {
+	m.callsMu.Lock()
+	cancel, exists := m.calls[key]
+	m.callsMu.Unlock()
+	if exists {
+		cancel(errToolCallCancelled)
+	}
+	return exists
+}
+
+// handleCancelled handles notifications/cancelled, and the LSP-style
+// $/cancelRequest some clients send instead. Only the session that made a
+// request can cancel it.
+func (s *Server) handleCancelled(msg *Message, session *mcpSession) {
+	var params struct {
+		RequestID interface{} `json:"requestId"`
+		ID        interface{} `json:"id"`
+		Reason    string      `json:"reason"`
+	}
+	paramsBytes, err := json.Marshal(msg.Params)
+	if err == nil {
+		err = json.Unmarshal(paramsBytes, &params)
+	}
+	if err != nil {
+		s.log.Debugw("ignoring malformed cancellation", "method", msg.Method, "error", err)
+		return
+	}
+	id := params.RequestID
+	if id == nil {
+		id = params.ID
+	}
+	key, ok := requestKey(id)
+	if !ok || session == nil {
+		return
+	}
+	if session.cancelCall(key) {
+		s.log.Infow("tool call cancelled by client", "requestId", id, "reason", params.Reason)
+	} else {
+		s.log.Debugw("cancellation for a request that is not running", "requestId", id)
+	}
+}
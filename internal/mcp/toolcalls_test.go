@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// registerBlockingToolForTest registers test.block, which returns once
+// release is closed.
+func registerBlockingToolForTest(server *Server, release <-chan struct{}) {
+	server.RegisterTool(Tool{Name: "test.block", InputSchema: map[string]interface{}{"type": "object"}}, func(args map[string]interface{}) (*ToolResult, error) {
+		<-release
+		return &ToolResult{Content: []Content{{Type: "text", Text: "released"}}}, nil
+	})
+}
+
+func TestServeAnswersOtherRequestsWhileAToolCallRuns(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	release := make(chan struct{})
+	registerBlockingToolForTest(server, release)
+
+	stdinReader, stdin := io.Pipe()
+	stdoutReader, stdout := io.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(stdinReader, stdout)
+		stdout.Close()
+	}()
+
+	fmt.Fprintln(stdin, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test.block"}}`)
+	fmt.Fprintln(stdin, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+
+	responses := bufio.NewScanner(stdoutReader)
+	readID := func() interface{} {
+		t.Helper()
+		if !responses.Scan() {
+			t.Fatalf("expected a response: %v", responses.Err())
+		}
+		var msg Message
+		if err := json.Unmarshal(responses.Bytes(), &msg); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+		return msg.ID
+	}
+
+	if id := readID(); id != float64(2) {
+		t.Fatalf("expected tools/list to be answered while the tool call blocks, got response %v first", id)
+	}
+	close(release)
+	if id := readID(); id != float64(1) {
+		t.Fatalf("expected the tool call response next, got %v", id)
+	}
+
+	stdin.Close()
+	if err := <-served; err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+}
+
+func TestToolCallTimeoutAnswersWithoutWaitingForTheHandler(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.SetToolCallLimits(1, 100*time.Millisecond)
+	release := make(chan struct{})
+	defer close(release)
+	registerBlockingToolForTest(server, release)
+
+	started := time.Now()
+	response := server.HandleMessage(&Message{JSONRPC: "2.0", ID: 1, Method: "tools/call", Params: map[string]interface{}{"name": "test.block"}})
+	result, _ := response.Result.(*ToolResult)
+	if result == nil || !result.IsError || !strings.Contains(result.Content[0].Text, "did not finish within 100ms") {
+		t.Fatalf("expected a timeout result, got %#v", response)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("expected the timeout to answer promptly, took %v", elapsed)
+	}
+
+	// The abandoned handler still holds the only slot, so the next call
+	// times out waiting for it.
+	response = server.HandleMessage(&Message{JSONRPC: "2.0", ID: 2, Method: "tools/call", Params: map[string]interface{}{"name": "test.block"}})
+	if result, _ := response.Result.(*ToolResult); result == nil || !result.IsError {
+		t.Fatalf("expected the second call to time out waiting for a slot, got %#v", response)
+	}
+}
@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func listToolsForTest(t *testing.T, server *Server, params map[string]interface{}) (ToolsListResult, *Message) {
+	t.Helper()
+	response := server.HandleMessage(&Message{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "tools/list", Params: params})
+	var result ToolsListResult
+	if response.Error == nil {
+		if err := decodeResult(response.Result, &result); err != nil {
+			t.Fatalf("decode tools/list: %v", err)
+		}
+	}
+	return result, response
+}
+
+func TestToolsListPaginatesWithCursor(t *testing.T) {
+	unpaged, _ := newGamesTestServer(t, &config.GamesConfig{})
+	all, _ := listToolsForTest(t, unpaged, nil)
+	if all.NextCursor != "" || len(all.Tools) < 20 {
+		t.Fatalf("expected every tool on one page by default, got %d tools and cursor %q", len(all.Tools), all.NextCursor)
+	}
+
+	server, _ := newGamesTestServer(t, &config.GamesConfig{ToolsListPageSize: 7})
+	var names []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > len(all.Tools) {
+			t.Fatal("pagination never ended")
+		}
+		params := map[string]interface{}{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+		page, response := listToolsForTest(t, server, params)
+		if response.Error != nil {
+			t.Fatalf("tools/list failed: %#v", response.Error)
+		}
+		if len(page.Tools) > 7 {
+			t.Fatalf("expected at most 7 tools per page, got %d", len(page.Tools))
+		}
+		for _, tool := range page.Tools {
+			names = append(names, tool.Name)
+		}
+		if page.NextCursor == "" {
+			if page.Meta != nil {
+				t.Fatalf("expected no hint on the last page, got %#v", page.Meta)
+			}
+			break
+		}
+		if hint, _ := page.Meta["hint"].(string); !strings.Contains(hint, "games_tools") {
+			t.Fatalf("expected a hint pointing to games_tools, got %#v", page.Meta)
+		}
+		cursor = page.NextCursor
+	}
+
+	if len(names) != len(all.Tools) {
+		t.Fatalf("expected the pages to hold all %d tools, got %d", len(all.Tools), len(names))
+	}
+	for i, tool := range all.Tools {
+		if names[i] != tool.Name {
+			t.Fatalf("page order differs at %d: %s vs %s", i, names[i], tool.Name)
+		}
+	}
+
+	if _, response := listToolsForTest(t, server, map[string]interface{}{"cursor": "not-a-cursor"}); response.Error == nil || response.Error.Code != -32602 {
+		t.Fatalf("expected an invalid cursor to be rejected, got %#v", response)
+	}
+}
+
+func TestToolsListFiltersByPrefixAndTag(t *testing.T) {
+	server, _ := newGamesTestServer(t, &config.GamesConfig{})
+	server.RegisterTool(Tool{
+		Name:        "debug.dump_state",
+		InputSchema: map[string]interface{}{"type": "object"},
+		Meta:        map[string]interface{}{toolMetaTags: []string{"Diagnostics"}},
+	}, func(args map[string]interface{}) (*ToolResult, error) {
+		return &ToolResult{}, nil
+	})
+
+	byPrefix, _ := listToolsForTest(t, server, map[string]interface{}{"prefix": "system."})
+	if len(byPrefix.Tools) != 1 || byPrefix.Tools[0].Name != "system_budgets" {
+		t.Fatalf("expected only system_budgets for prefix system., got %#v", byPrefix.Tools)
+	}
+	byTag, _ := listToolsForTest(t, server, map[string]interface{}{"tag": "diagnostics"})
+	if len(byTag.Tools) != 1 || !strings.HasPrefix(byTag.Tools[0].Name, "debug") {
+		t.Fatalf("expected only the tagged tool, got %#v", byTag.Tools)
+	}
+}
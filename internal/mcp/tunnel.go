@@ -0,0 +1,119 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/tunnel"
+)
+
+// tunnelSetupLock returns the mutex that serializes tunnel setup for one
+// game, so concurrent connects share a single ssh forward.
+func (s *Server) tunnelSetupLock(gameID string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tunnelSetup == nil {
+		s.tunnelSetup = make(map[string]*sync.Mutex)
+	}
+	lock, exists := s.tunnelSetup[gameID]
+	if !exists {
+		lock = &sync.Mutex{}
+		s.tunnelSetup[gameID] = lock
+	}
+	return lock
+}
+
+// gabpDialAddress returns the address to dial for a game's GABP bridge. Games
+// whose bridge.json selects the unix transport are reached on their socket.
+// Games with an sshTunnel are reached through a local SSH port-forward, which
+// is opened on first use and reused while ssh keeps running.
+func (s *Server) gabpDialAddress(ctx context.Context, gameID string, port int) (string, error) {
+	local := fmt.Sprintf("127.0.0.1:%d", port)
+
+	s.mu.RLock()
+	gamesConfig := s.gamesConfig
+	s.mu.RUnlock()
+	var game *config.GameConfig
+	if gamesConfig != nil {
+		game, _ = gamesConfig.GetGame(gameID)
+	}
+
+	socket, err := config.BridgeSocket(gameID, s.configDir)
+	if err != nil {
+		return "", err
+	}
+	if socket != "" {
+		if game != nil && game.SSHTunnel != nil {
+			return "", fmt.Errorf("game '%s' has an sshTunnel, which cannot forward the unix transport its bridge.json selects", gameID)
+		}
+		return gabp.UnixAddress(socket), nil
+	}
+
+	if game == nil || game.SSHTunnel == nil {
+		return local, nil
+	}
+
+	lock := s.tunnelSetupLock(gameID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	s.mu.RLock()
+	existing := s.tunnels[gameID]
+	s.mu.RUnlock()
+
+	remotePort := port
+	if game.SSHTunnel.RemotePort > 0 {
+		remotePort = game.SSHTunnel.RemotePort
+	}
+	if existing != nil && existing.Alive() && existing.RemotePort() == remotePort {
+		return existing.LocalAddr(), nil
+	}
+
+	opened, err := tunnel.Open(ctx, *game.SSHTunnel, port, s.gameLogger(gameID))
+	if err != nil {
+		return "", fmt.Errorf("failed to open SSH tunnel to %s for game '%s': %w", game.SSHTunnel.Host, gameID, err)
+	}
+
+	s.mu.Lock()
+	previous := s.tunnels[gameID]
+	s.tunnels[gameID] = opened
+	s.mu.Unlock()
+	if previous != nil {
+		previous.Close()
+	}
+	return opened.LocalAddr(), nil
+}
+
+// closeFailedTunnel stops the tunnel at addr after a GABP connection through
+// it failed, so a broken forward is not left running. A tunnel that has been
+// replaced in the meantime is left alone.
+func (s *Server) closeFailedTunnel(gameID, addr string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, exists := s.tunnels[gameID]; exists && t.LocalAddr() == addr {
+		s.closeTunnelLocked(gameID)
+	}
+}
+
+// closeTunnelLocked stops the SSH tunnel of a game, if any. The caller must
+// hold s.mu.
+func (s *Server) closeTunnelLocked(gameID string) {
+	if t, exists := s.tunnels[gameID]; exists {
+		t.Close()
+		delete(s.tunnels, gameID)
+		s.log.Debugw("closed SSH tunnel", "gameId", gameID)
+	}
+}
+
+// CloseTunnels stops all SSH tunnels. Call it when the server shuts down so
+// no ssh processes outlive GABS.
+func (s *Server) CloseTunnels() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for gameID := range s.tunnels {
+		s.closeTunnelLocked(gameID)
+	}
+}
@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"context"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/tunnel"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestGABPDialAddressWithoutTunnelIsLocal(t *testing.T) {
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.RegisterGameManagementTools(&config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"factory": {ID: "factory", Name: "Factory", LaunchMode: "DirectPath", Target: "/opt/factory"},
+		},
+	}, 10*time.Millisecond, 100*time.Millisecond)
+
+	addr, err := server.gabpDialAddress(context.Background(), "factory", 39000)
+	if err != nil || addr != "127.0.0.1:39000" {
+		t.Fatalf("expected direct local address, got %q %v", addr, err)
+	}
+}
+
+func TestGamesConnectReportsSSHTunnelFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses /bin/sh as a failing ssh")
+	}
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("/bin/sh not available")
+	}
+	t.Cleanup(tunnel.SetSSHCommandForTesting(func(args []string) (string, []string) {
+		return "/bin/sh", []string{"-c", "echo 'ssh: connect to host games.example.net port 22: Connection refused' >&2; exit 255"}
+	}))
+
+	configDir := t.TempDir()
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.SetConfigDir(configDir)
+	server.RegisterGameManagementTools(&config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"factory": {
+				ID:         "factory",
+				Name:       "Factory",
+				LaunchMode: "DirectPath",
+				Target:     "/opt/factory",
+				SSHTunnel:  &config.SSHTunnelConfig{Host: "games.example.net", User: "deploy"},
+			},
+		},
+	}, 10*time.Millisecond, 100*time.Millisecond)
+	writeBridgeJSONForTest(t, configDir, "factory", 39000, "tunnel-token")
+
+	result := callToolForTest(t, server, "games_connect", map[string]interface{}{"gameId": "factory"})
+	if !result.IsError {
+		t.Fatalf("expected connect through a failing tunnel to fail, got %#v", result)
+	}
+	if text := result.Content[0].Text; !strings.Contains(text, "SSH tunnel") || !strings.Contains(text, "Connection refused") {
+		t.Fatalf("expected SSH tunnel failure in result, got %q", text)
+	}
+
+	server.mu.RLock()
+	tunnels := len(server.tunnels)
+	server.mu.RUnlock()
+	if tunnels != 0 {
+		t.Fatalf("expected no tunnel to be kept after failure, got %d", tunnels)
+	}
+}
+
+func TestGABPDialAddressSharesOneTunnelBetweenConcurrentConnects(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses /bin/sleep as a stand-in ssh")
+	}
+	if _, err := os.Stat("/bin/sleep"); err != nil {
+		t.Skip("/bin/sleep not available")
+	}
+
+	// Stand in for ssh: serve the local end of the -L forward from the test
+	// process and keep a sleeping process around as the "ssh" child.
+	var started int32
+	t.Cleanup(tunnel.SetSSHCommandForTesting(func(args []string) (string, []string) {
+		atomic.AddInt32(&started, 1)
+		for i, arg := range args {
+			if arg == "-L" && i+1 < len(args) {
+				parts := strings.SplitN(args[i+1], ":", 3)
+				if listener, err := net.Listen("tcp", parts[0]+":"+parts[1]); err == nil {
+					t.Cleanup(func() { listener.Close() })
+				}
+			}
+		}
+		return "/bin/sleep", []string{"30"}
+	}))
+
+	server := NewServerForTesting(util.NewLogger("error"))
+	server.RegisterGameManagementTools(&config.GamesConfig{
+		Games: map[string]config.GameConfig{
+			"factory": {
+				ID:         "factory",
+				Name:       "Factory",
+				LaunchMode: "DirectPath",
+				Target:     "/opt/factory",
+				SSHTunnel:  &config.SSHTunnelConfig{Host: "games.example.net"},
+			},
+		},
+	}, 10*time.Millisecond, 100*time.Millisecond)
+	t.Cleanup(server.CloseTunnels)
+
+	var wg sync.WaitGroup
+	addrs := make([]string, 4)
+	for i := range addrs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			addr, err := server.gabpDialAddress(context.Background(), "factory", 39000)
+			if err != nil {
+				t.Errorf("dial address %d: %v", i, err)
+			}
+			addrs[i] = addr
+		}(i)
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&started); n != 1 {
+		t.Fatalf("expected one ssh process for concurrent connects, got %d", n)
+	}
+	for _, addr := range addrs[1:] {
+		if addr != addrs[0] {
+			t.Fatalf("expected every connect to share %s, got %v", addrs[0], addrs)
+		}
+	}
+
+	server.closeFailedTunnel("factory", addrs[0])
+	server.mu.RLock()
+	tunnels := len(server.tunnels)
+	server.mu.RUnlock()
+	if tunnels != 0 {
+		t.Fatalf("expected the tunnel to be closed after a failed connect, got %d", tunnels)
+	}
+}
@@ -45,10 +45,11 @@ type ClientInfo struct {
 
 // InitializeResult represents the initialize response
 type InitializeResult struct {
-	ProtocolVersion string             `json:"protocolVersion"`
-	Capabilities    ServerCapabilities `json:"capabilities"`
-	ServerInfo      ServerInfo         `json:"serverInfo"`
-	Instructions    string             `json:"instructions,omitempty"`
+	ProtocolVersion string                 `json:"protocolVersion"`
+	Capabilities    ServerCapabilities     `json:"capabilities"`
+	ServerInfo      ServerInfo             `json:"serverInfo"`
+	Instructions    string                 `json:"instructions,omitempty"`
+	Meta            map[string]interface{} `json:"_meta,omitempty"` // Platform, commit and build date of GABS
 }
 
 // ServerCapabilities represents server capabilities
@@ -96,15 +97,32 @@ type Tool struct {
 	Meta map[string]interface{} `json:"_meta,omitempty"`
 }
 
+// ToolsListParams represents tools/list parameters. Prefix and Tag are GABS
+// extensions that narrow the list before it is paginated.
+type ToolsListParams struct {
+	Cursor string `json:"cursor,omitempty"`
+	Prefix string `json:"prefix,omitempty"` // Only tools whose name starts with this
+	Tag    string `json:"tag,omitempty"`    // Only tools carrying this tag in _meta.tags
+}
+
 // ToolsListResult represents the tools/list response
 type ToolsListResult struct {
-	Tools []Tool `json:"tools"`
+	Tools      []Tool                 `json:"tools"`
+	NextCursor string                 `json:"nextCursor,omitempty"`
+	Meta       map[string]interface{} `json:"_meta,omitempty"`
 }
 
 // ToolCallParams represents tool call parameters
 type ToolCallParams struct {
 	Name      string                 `json:"name"`
 	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Meta      *RequestMeta           `json:"_meta,omitempty"`
+}
+
+// RequestMeta represents the _meta field of a request
+type RequestMeta struct {
+	// ProgressToken asks for notifications/progress while the request runs.
+	ProgressToken interface{} `json:"progressToken,omitempty"`
 }
 
 // ToolResult represents a tool call result
@@ -112,12 +130,17 @@ type ToolResult struct {
 	Content           []Content              `json:"content,omitempty"`
 	StructuredContent map[string]interface{} `json:"structuredContent,omitempty"`
 	IsError           bool                   `json:"isError,omitempty"`
+	Meta              map[string]interface{} `json:"_meta,omitempty"`
 }
 
-// Content represents text or image content
+// Content represents text or image content. URI, MimeType and Blob are only
+// set in the contents of mirrored bridge resources.
 type Content struct {
-	Type string `json:"type"`
-	Text string `json:"text,omitempty"`
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	URI      string `json:"uri,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+	Blob     string `json:"blob,omitempty"`
 }
 
 // Resource represents a resource
@@ -138,6 +161,12 @@ type ResourcesReadParams struct {
 	URI string `json:"uri"`
 }
 
+// ResourcesSubscribeParams represents resources/subscribe and
+// resources/unsubscribe parameters
+type ResourcesSubscribeParams struct {
+	URI string `json:"uri"`
+}
+
 // ResourcesReadResult represents resource read result
 type ResourcesReadResult struct {
 	Contents []Content `json:"contents"`
@@ -0,0 +1,155 @@
+package mcp
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+)
+
+// webhookClient posts webhook deliveries. Its timeout covers one attempt.
+var webhookClient = &http.Client{Timeout: 10 * time.Second}
+
+// webhookRetryDelay is the wait before the first retry of a failed delivery.
+// It doubles with every further retry.
+var webhookRetryDelay = 2 * time.Second
+
+// Headers of a webhook delivery.
+const (
+	webhookEventHeader     = "X-GABS-Event"
+	webhookDeliveryHeader  = "X-GABS-Delivery"  // Same for every attempt of one delivery
+	webhookSignatureHeader = "X-GABS-Signature" // "sha256=" and the hex HMAC of the body
+)
+
+// emitWebhookEvent posts event of gameID to every webhook that wants it. For
+// config.WebhookEventGABP, channel names the GABP event channel. Deliveries
+// run in the background, so it may be called with s.mu held.
+func (s *Server) emitWebhookEvent(event, gameID, channel string, data map[string]interface{}) {
+	if s.gamesConfig == nil || len(s.gamesConfig.Webhooks) == 0 {
+		return
+	}
+	now := time.Now().UTC()
+	for _, webhook := range s.gamesConfig.Webhooks {
+		if !webhook.Wants(event, gameID, channel) {
+			continue
+		}
+		body, err := webhookBody(webhook, event, gameID, channel, data, now)
+		if err != nil {
+			s.log.Warnw("failed to encode webhook payload", "url", webhook.URL, "event", event, "gameId", gameID, "error", err)
+			continue
+		}
+		go s.deliverWebhook(webhook, event, body)
+	}
+}
+
+// webhookBody returns the payload of event in the webhook's format.
+func webhookBody(webhook config.WebhookConfig, event, gameID, channel string, data map[string]interface{}, at time.Time) ([]byte, error) {
+	switch webhook.Format {
+	case config.WebhookFormatSlack:
+		return json.Marshal(map[string]interface{}{"text": webhookSummary(event, gameID, channel)})
+	case config.WebhookFormatDiscord:
+		return json.Marshal(map[string]interface{}{"content": webhookSummary(event, gameID, channel)})
+	}
+	payload := map[string]interface{}{
+		"event":  event,
+		"gameId": gameID,
+		"time":   at.Format(time.RFC3339Nano),
+		"data":   data,
+	}
+	if channel != "" {
+		payload["channel"] = channel
+	}
+	return json.Marshal(payload)
+}
+
+// webhookSummary describes event in a sentence for chat webhooks.
+func webhookSummary(event, gameID, channel string) string {
+	switch event {
+	case config.WebhookEventGameStarted:
+		return fmt.Sprintf("Game '%s' started.", gameID)
+	case config.WebhookEventGameStopped:
+		return fmt.Sprintf("Game '%s' stopped.", gameID)
+	case config.WebhookEventGameCrashed:
+		return fmt.Sprintf("Game '%s' exited without being stopped; it may have crashed.", gameID)
+	default:
+		return fmt.Sprintf("Game '%s' reported %s.", gameID, channel)
+	}
+}
+
+// deliverWebhook posts body, retrying network errors, 429 and 5xx answers
+// with a doubling delay. Other answers are final. Pending retries are dropped
+// when GABS shuts down.
+func (s *Server) deliverWebhook(webhook config.WebhookConfig, event string, body []byte) {
+	secret, err := process.ExpandEnvValue(webhook.Secret, os.LookupEnv)
+	if err != nil {
+		s.log.Warnw("webhook secret could not be expanded", "url", webhook.URL, "error", err)
+		return
+	}
+	s.mu.RLock()
+	clock := s.clock
+	s.mu.RUnlock()
+	ctx := s.serveContext()
+
+	delivery := uuid.New().String()
+	delay := webhookRetryDelay
+	retries := webhook.RetryCount()
+	for attempt := 0; ; attempt++ {
+		status, err := postWebhook(webhook.URL, event, delivery, secret, body)
+		if err == nil && status < 300 {
+			s.log.Debugw("webhook delivered", "url", webhook.URL, "event", event, "delivery", delivery, "attempts", attempt+1)
+			return
+		}
+		retryable := err != nil || status == http.StatusTooManyRequests || status >= 500
+		if err == nil {
+			err = fmt.Errorf("HTTP %d", status)
+		}
+		if !retryable || attempt >= retries {
+			s.log.Warnw("webhook delivery failed", "url", webhook.URL, "event", event, "delivery", delivery, "attempts", attempt+1, "error", err)
+			return
+		}
+		s.log.Debugw("webhook delivery failed, retrying", "url", webhook.URL, "event", event, "delivery", delivery, "retryIn", delay, "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-clock.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func postWebhook(url, event, delivery, secret string, body []byte) (int, error) {
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(webhookEventHeader, event)
+	request.Header.Set(webhookDeliveryHeader, delivery)
+	if secret != "" {
+		request.Header.Set(webhookSignatureHeader, webhookSignature(secret, body))
+	}
+	response, err := webhookClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	return response.StatusCode, nil
+}
+
+// webhookSignature returns the X-GABS-Signature value of body.
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,142 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+// webhookRecorder is a webhook endpoint that fails the first failures
+// requests with 503 and records every request it accepts.
+type webhookRecorder struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+	accepted chan *http.Request
+	bodies   chan []byte
+}
+
+func newWebhookRecorder(t *testing.T, failures int) (*webhookRecorder, string) {
+	t.Helper()
+	recorder := &webhookRecorder{failures: failures, accepted: make(chan *http.Request, 10), bodies: make(chan []byte, 10)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder.mu.Lock()
+		recorder.attempts++
+		fail := recorder.attempts <= recorder.failures
+		recorder.mu.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		recorder.accepted <- r
+		recorder.bodies <- body
+	}))
+	t.Cleanup(server.Close)
+	return recorder, server.URL
+}
+
+func (w *webhookRecorder) next(t *testing.T) (*http.Request, map[string]interface{}) {
+	t.Helper()
+	select {
+	case request := <-w.accepted:
+		var payload map[string]interface{}
+		if err := json.Unmarshal(<-w.bodies, &payload); err != nil {
+			t.Fatalf("invalid webhook body: %v", err)
+		}
+		return request, payload
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a webhook delivery")
+		return nil, nil
+	}
+}
+
+func TestWebhookIsSignedAndRetried(t *testing.T) {
+	defer func(previous time.Duration) { webhookRetryDelay = previous }(webhookRetryDelay)
+	webhookRetryDelay = time.Millisecond
+
+	recorder, url := newWebhookRecorder(t, 2)
+	t.Setenv("GABS_TEST_WEBHOOK_SECRET", "s3cret")
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{"factory": sleepingGameForTest("factory", "Factory")},
+		Webhooks: []config.WebhookConfig{
+			{URL: url, Channels: []string{"player/*"}, Secret: "${GABS_TEST_WEBHOOK_SECRET}"},
+		},
+	})
+
+	server.emitWebhookEvent(config.WebhookEventGameStarted, "factory", "", map[string]interface{}{})
+	server.emitWebhookEvent(config.WebhookEventGABP, "factory", "player/died", map[string]interface{}{"seq": 7})
+
+	request, payload := recorder.next(t)
+	if payload["event"] != config.WebhookEventGABP || payload["channel"] != "player/died" || payload["gameId"] != "factory" {
+		t.Fatalf("unexpected payload: %#v", payload)
+	}
+	if !strings.HasPrefix(request.Header.Get(webhookSignatureHeader), "sha256=") || request.Header.Get(webhookEventHeader) != config.WebhookEventGABP {
+		t.Fatalf("expected signature and event headers, got %v", request.Header)
+	}
+	recorder.mu.Lock()
+	attempts := recorder.attempts
+	recorder.mu.Unlock()
+	if attempts != 3 {
+		t.Fatalf("expected two failed attempts before delivery, got %d attempts", attempts)
+	}
+	select {
+	case <-recorder.accepted:
+		t.Fatal("expected game.started not to be sent to a webhook that only wants channels")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWebhookSignatureCoversBody(t *testing.T) {
+	recorder, url := newWebhookRecorder(t, 0)
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Webhooks: []config.WebhookConfig{{URL: url, Events: []string{config.WebhookEventGameCrashed}, Secret: "key"}},
+	})
+	server.emitWebhookEvent(config.WebhookEventGameCrashed, "factory", "", map[string]interface{}{})
+
+	select {
+	case request := <-recorder.accepted:
+		body := <-recorder.bodies
+		if got, want := request.Header.Get(webhookSignatureHeader), webhookSignature("key", body); got != want {
+			t.Fatalf("signature %q does not match body, want %q", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a webhook delivery")
+	}
+}
+
+func TestGameExitIsReportedAsCrashUnlessStopped(t *testing.T) {
+	requireSleepForTest(t)
+	recorder, url := newWebhookRecorder(t, 0)
+	server, _ := newGamesTestServer(t, &config.GamesConfig{
+		Games: map[string]config.GameConfig{"factory": sleepingGameForTest("factory", "Factory")},
+		Webhooks: []config.WebhookConfig{{
+			URL:    url,
+			Events: []string{config.WebhookEventGameStopped, config.WebhookEventGameCrashed},
+			Format: config.WebhookFormatDiscord,
+		}},
+	})
+
+	trackSleepingGameForTest(t, server, "factory")
+	server.mu.Lock()
+	server.cleanupStoppedGameLocked("factory")
+	server.mu.Unlock()
+	if _, payload := recorder.next(t); payload["content"] != webhookSummary(config.WebhookEventGameCrashed, "factory", "") {
+		t.Fatalf("expected a crash report, got %#v", payload)
+	}
+
+	trackSleepingGameForTest(t, server, "factory")
+	if result := callToolForTest(t, server, "games_stop", map[string]interface{}{"gameId": "factory"}); result.IsError {
+		t.Fatalf("games_stop failed: %#v", result)
+	}
+	if _, payload := recorder.next(t); payload["content"] != webhookSummary(config.WebhookEventGameStopped, "factory", "") {
+		t.Fatalf("expected a stop report, got %#v", payload)
+	}
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistryWritesPrometheusText(t *testing.T) {
+	registry := NewRegistry()
+	calls := registry.NewCounterVec("gabs_tool_calls_total", "Tool calls by tool and result.", "tool", "result")
+	running := 2.0
+	registry.NewGaugeFunc("gabs_running_games", "Games started by GABS that are still running.", func() float64 { return running })
+	latency := registry.NewHistogramVec("gabs_tool_call_duration_seconds", "Tool call latency.", []float64{0.1, 1}, "tool")
+
+	calls.Inc("games.start", "ok")
+	calls.Inc("games.start", "ok")
+	calls.Inc("say \"hi\"", "error")
+	latency.Observe(0.05, "games.start")
+	latency.Observe(0.5, "games.start")
+	latency.Observe(3, "games.start")
+
+	var out strings.Builder
+	registry.WriteText(&out)
+	text := out.String()
+
+	for _, want := range []string{
+		"# TYPE gabs_tool_calls_total counter\n",
+		`gabs_tool_calls_total{tool="games.start",result="ok"} 2` + "\n",
+		`gabs_tool_calls_total{tool="say \"hi\"",result="error"} 1` + "\n",
+		"# TYPE gabs_running_games gauge\ngabs_running_games 2\n",
+		`gabs_tool_call_duration_seconds_bucket{tool="games.start",le="0.1"} 1` + "\n",
+		`gabs_tool_call_duration_seconds_bucket{tool="games.start",le="1"} 2` + "\n",
+		`gabs_tool_call_duration_seconds_bucket{tool="games.start",le="+Inf"} 3` + "\n",
+		`gabs_tool_call_duration_seconds_sum{tool="games.start"} 3.55` + "\n",
+		`gabs_tool_call_duration_seconds_count{tool="games.start"} 3` + "\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in output:\n%s", want, text)
+		}
+	}
+	if calls.Value("games.start", "ok") != 2 {
+		t.Errorf("Value() = %v, want 2", calls.Value("games.start", "ok"))
+	}
+}
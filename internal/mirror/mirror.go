@@ -66,6 +66,7 @@ func (m *Mirror) SyncTools() error {
 			OutputSchema: tool.OutputSchema,
 			Meta: map[string]interface{}{
 				"gabpName": tool.Name,
+				"gameId":   m.gameId,
 				"tags":     append([]string(nil), tool.Tags...),
 			},
 		}
@@ -215,8 +216,8 @@ func (m *Mirror) ExposeResources() error {
 	}
 
 	streamHandler := func() ([]mcp.Content, error) {
-		// This would be enhanced when GABP event subscription is fully implemented
-		// For now, return information about available event channels
+		// Buffered events are served by the server's events/recent resource;
+		// this one only describes the advertised channels
 		capabilities := m.client.GetCapabilities()
 		streamInfo := map[string]interface{}{
 			"gameId":      m.gameId,
@@ -224,7 +225,7 @@ func (m *Mirror) ExposeResources() error {
 			"status":      "available",
 			"description": "Real-time GABP events will appear here when the game bridge supports event streaming",
 			"channels":    capabilities.Events, // Available event channels
-			"note":        "Use GABP client event subscription to receive real-time updates",
+			"note":        fmt.Sprintf("Read gab://%s/events/recent for buffered events; it is updated as the bridge pushes new ones", m.gameId),
 		}
 
 		streamJson, err := json.Marshal(streamInfo)
@@ -0,0 +1,111 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// Steps of the TerminateEscalating ladder, as reported in Escalation.Step.
+const (
+	EscalationStepTerm = "term"
+	EscalationStepKill = "kill"
+)
+
+// escalationPoll is how often TerminateEscalating checks whether the process
+// is gone.
+const escalationPoll = 100 * time.Millisecond
+
+// Escalation reports how far TerminateEscalating had to go for one process.
+type Escalation struct {
+	PID    int    `json:"pid"`
+	Step   string `json:"step"` // Last step taken, EscalationStepTerm or EscalationStepKill
+	Exited bool   `json:"exited"`
+	Error  string `json:"error,omitempty"`
+}
+
+// TerminateEscalating asks pid to exit and kills it when it is still alive
+// after grace, then waits up to grace again. It polls instead of waiting on
+// the process because pid need not be a child of GABS, and counts a zombie
+// as exited since only its parent can reap it.
+func TerminateEscalating(pid int, grace time.Duration, clock util.Clock) Escalation {
+	result := Escalation{PID: pid, Step: EscalationStepTerm}
+	_ = signalTerminate(pid) // A failed request still leaves the kill
+	if waitForPIDExit(pid, grace, clock) {
+		result.Exited = true
+		return result
+	}
+
+	result.Step = EscalationStepKill
+	killErr := killProcess(pid)
+	result.Exited = waitForPIDExit(pid, grace, clock)
+	switch {
+	case result.Exited:
+	case killErr != nil:
+		result.Error = killErr.Error()
+	default:
+		result.Error = fmt.Sprintf("process %d is still running after being killed", pid)
+	}
+	return result
+}
+
+// signalTerminate sends pid the polite termination request of the platform.
+func signalTerminate(pid int) error {
+	if runtime.GOOS == "windows" {
+		return exec.Command("taskkill", "/PID", strconv.Itoa(pid)).Run()
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Signal(getTerminationSignal())
+}
+
+func waitForPIDExit(pid int, timeout time.Duration, clock util.Clock) bool {
+	deadline := clock.Now().Add(timeout)
+	for {
+		if processGone(pid) {
+			return true
+		}
+		if !clock.Now().Before(deadline) {
+			return false
+		}
+		clock.Sleep(escalationPoll)
+	}
+}
+
+// processGone reports whether pid no longer runs. On Linux a zombie counts as
+// gone: it holds no resources and no signal can remove it.
+func processGone(pid int) bool {
+	if !isProcessAlive(pid) {
+		return true
+	}
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return false
+	}
+	return procStatState(string(data)) == "Z"
+}
+
+// procStatState returns the state field of a /proc/<pid>/stat line. The
+// command name before it is in parentheses and may itself contain spaces.
+func procStatState(stat string) string {
+	end := strings.LastIndex(stat, ")")
+	if end < 0 {
+		return ""
+	}
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
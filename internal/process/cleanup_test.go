@@ -0,0 +1,57 @@
+package process
+
+import (
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestTerminateEscalatingStopsAtTermWhenProcessExits(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sleep")
+	}
+	cmd := exec.Command("sleep", "30")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start sleep: %v", err)
+	}
+	go cmd.Wait()
+
+	result := TerminateEscalating(cmd.Process.Pid, 2*time.Second, util.NewRealClock())
+	if !result.Exited || result.Step != EscalationStepTerm || result.Error != "" {
+		t.Fatalf("unexpected escalation: %#v", result)
+	}
+}
+
+func TestTerminateEscalatingKillsProcessIgnoringTerm(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("relies on zombie detection through /proc")
+	}
+	// An ignored signal stays ignored across exec. Nothing reaps the process,
+	// so it also has to be recognized as a zombie once killed.
+	cmd := exec.Command("sh", "-c", `trap "" TERM; exec sleep 30`)
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start sh: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+	time.Sleep(100 * time.Millisecond) // Let the trap take effect
+
+	result := TerminateEscalating(cmd.Process.Pid, 300*time.Millisecond, util.NewRealClock())
+	if !result.Exited || result.Step != EscalationStepKill {
+		t.Fatalf("unexpected escalation: %#v", result)
+	}
+}
+
+func TestProcStatState(t *testing.T) {
+	if got := procStatState("123 (Game (Server)) Z 1 123 123 0"); got != "Z" {
+		t.Fatalf("procStatState() = %q, want Z", got)
+	}
+	if got := procStatState("garbage"); got != "" {
+		t.Fatalf("procStatState(garbage) = %q, want empty", got)
+	}
+}
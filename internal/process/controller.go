@@ -1,19 +1,20 @@
 package process
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
 
-	"github.com/pardeike/gabs/internal/steam"
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
 )
 
 var (
@@ -24,28 +25,51 @@ var (
 
 type LaunchSpec struct {
 	GameId          string
-	Mode            string // DirectPath|SteamAppId|SteamManaged|EpicAppId|CustomCommand
+	Mode            string // DirectPath|SteamManaged|SteamAppId|EpicAppId|GOGGameId|XboxAppId|Flatpak|Proton|CustomCommand
 	PathOrId        string
 	Args            []string
 	WorkingDir      string
-	StopProcessName string // Optional process name for stopping the game
+	StopProcessName string               // Optional process name for stopping the game
+	Env             map[string]string    // Extra environment variables; values may reference ${NAME}
+	EnvFile         string               // Optional KEY=VALUE file read at start; Env entries win
+	Proton          *config.ProtonConfig // Proton build and prefix for Proton mode
+	BridgeMode      string               // "listen" when GABS waits for the bridge to connect in
 }
 
 type BridgeInfo struct {
-	Port  int
-	Token string
+	Port   int
+	Token  string
+	Socket string // Unix socket the bridge uses instead of Port, if any
 }
 
 // Controller implements a stateless approach to process management
 // It queries the actual system state rather than maintaining internal state
 type Controller struct {
 	spec       LaunchSpec
-	cmd        *exec.Cmd
+	cmd        *exec.Cmd      // prepared command, kept for inspection
+	proc       RunningProcess // handle returned by the runner once started
+	runner     ProcessRunner  // nil uses the package default
+	clock      util.Clock     // nil uses the package default
 	bridgeInfo *BridgeInfo
-	waitOnce   sync.Once // guards c.cmd.Wait() to prevent multiple calls
+	output     *GameLog  // captures stdout and stderr when set
+	waitOnce   sync.Once // guards c.proc.Wait() to prevent multiple calls
 	waitDone   chan struct{}
 }
 
+func (c *Controller) processRunner() ProcessRunner {
+	if c.runner != nil {
+		return c.runner
+	}
+	return currentProcessRunner()
+}
+
+func (c *Controller) timeSource() util.Clock {
+	if c.clock != nil {
+		return c.clock
+	}
+	return currentClock()
+}
+
 // Configure sets up the controller with the given launch specification
 func (c *Controller) Configure(spec LaunchSpec) error {
 	if spec.GameId == "" {
@@ -56,30 +80,24 @@ func (c *Controller) Configure(spec LaunchSpec) error {
 		}
 	}
 
-	switch spec.Mode {
-	case "DirectPath", "":
-		if spec.PathOrId == "" {
-			return &ProcessError{
-				Type:    ProcessErrorTypeConfiguration,
-				Context: fmt.Sprintf("PathOrId is required for mode %s", spec.Mode),
-				Err:     fmt.Errorf("PathOrId cannot be empty for DirectPath mode"),
-			}
-		}
-	case "SteamAppId", "SteamManaged", "EpicAppId", "CustomCommand":
-		if spec.PathOrId == "" {
-			return &ProcessError{
-				Type:    ProcessErrorTypeConfiguration,
-				Context: fmt.Sprintf("PathOrId is required for mode %s", spec.Mode),
-				Err:     fmt.Errorf("PathOrId cannot be empty for %s mode", spec.Mode),
-			}
-		}
-	default:
+	if _, exists := LauncherFor(spec.Mode); !exists {
 		return &ProcessError{
 			Type:    ProcessErrorTypeConfiguration,
 			Context: fmt.Sprintf("unsupported launch mode: %s", spec.Mode),
 			Err:     fmt.Errorf("unsupported launch mode: %s", spec.Mode),
 		}
 	}
+	if spec.PathOrId == "" {
+		mode := spec.Mode
+		if mode == "" {
+			mode = "DirectPath"
+		}
+		return &ProcessError{
+			Type:    ProcessErrorTypeConfiguration,
+			Context: fmt.Sprintf("PathOrId is required for mode %s", spec.Mode),
+			Err:     fmt.Errorf("PathOrId cannot be empty for %s mode", mode),
+		}
+	}
 
 	c.spec = spec
 	return nil
@@ -93,76 +111,78 @@ func (c *Controller) SetBridgeInfo(port int, token string) {
 	}
 }
 
+// SetBridgeSocket has the bridge use the Unix socket at path instead of the
+// TCP port. Call it after SetBridgeInfo.
+func (c *Controller) SetBridgeSocket(path string) {
+	if c.bridgeInfo != nil {
+		c.bridgeInfo.Socket = path
+	}
+}
+
+// SetOutputLog captures the stdout and stderr of the next started process in
+// log. Without a log the output is discarded.
+func (c *Controller) SetOutputLog(log *GameLog) {
+	c.output = log
+}
+
 // Start launches the process and waits for verification
 func (c *Controller) Start() error {
-	// Prepare command based on launch mode
-	var cmdName string
-	var cmdArgs []string
-
-	switch c.spec.Mode {
-	case "DirectPath", "":
-		cmdName = c.spec.PathOrId
-		cmdArgs = c.spec.Args
-	case "SteamAppId":
-		cmdName, cmdArgs = steamLaunchCommandFactory(c.spec.PathOrId)
-	case "SteamManaged":
-		app, err := steam.ResolveApp(c.spec.PathOrId)
-		if err != nil {
-			return &ProcessError{
-				Type:    ProcessErrorTypeConfiguration,
-				Context: fmt.Sprintf("failed to resolve Steam app %s", c.spec.PathOrId),
-				Err:     err,
-			}
-		}
-		if err := steam.EnsureClientRunning(); err != nil {
-			return &ProcessError{
-				Type:    ProcessErrorTypeStart,
-				Context: fmt.Sprintf("failed to prepare Steam client for %s", c.spec.GameId),
-				Err:     err,
-			}
-		}
-		if err := steam.EnsureAppIDFile(app); err != nil {
-			return &ProcessError{
-				Type:    ProcessErrorTypeConfiguration,
-				Context: fmt.Sprintf("failed to prepare Steam app id file for %s", c.spec.GameId),
-				Err:     err,
-			}
-		}
-		cmdName = app.Executable
-		cmdArgs = c.spec.Args
-		if c.spec.WorkingDir == "" {
-			c.spec.WorkingDir = app.WorkingDir
-		}
-	case "EpicAppId":
-		cmdName, cmdArgs = epicLaunchCommandFactory(c.spec.PathOrId)
-	case "CustomCommand":
-		cmdName = c.spec.PathOrId
-		cmdArgs = c.spec.Args
-	default:
+	launcher, exists := LauncherFor(c.spec.Mode)
+	if !exists {
 		return &ProcessError{
 			Type:    ProcessErrorTypeStart,
 			Context: fmt.Sprintf("unsupported launch mode: %s", c.spec.Mode),
 			Err:     fmt.Errorf("unsupported launch mode: %s", c.spec.Mode),
 		}
 	}
+	command, err := launcher.BuildCommand(c.spec)
+	if err != nil {
+		return err
+	}
+	cmdName := command.Name
+	if c.spec.WorkingDir == "" {
+		c.spec.WorkingDir = command.WorkingDir
+	}
 
 	// Create command
-	c.cmd = exec.Command(cmdName, cmdArgs...)
+	c.cmd = exec.Command(cmdName, command.Args...)
 	if c.spec.WorkingDir != "" {
 		c.cmd.Dir = c.spec.WorkingDir
 	}
 
 	// Set up environment variables
-	c.setupEnvironment()
+	if err := c.setupEnvironment(command.Env); err != nil {
+		return &ProcessError{
+			Type:    ProcessErrorTypeConfiguration,
+			Context: fmt.Sprintf("failed to prepare the environment of %s", c.spec.GameId),
+			Err:     err,
+		}
+	}
+
+	if c.output != nil {
+		c.cmd.Stdout = c.output.Writer(LogStreamStdout)
+		c.cmd.Stderr = c.output.Writer(LogStreamStderr)
+		// Launchers can hand the pipes on to processes that outlive them;
+		// do not let those keep Wait from returning.
+		c.cmd.WaitDelay = time.Second
+	}
 
 	// Start the process
-	if err := c.cmd.Start(); err != nil {
+	proc, err := c.processRunner().Start(c.cmd, StartOptions{ProcessTree: ownsProcessTree(c.spec.Mode)})
+	if err != nil {
+		if c.output != nil {
+			c.output.Mark("failed to start %s: %v", cmdName, err)
+		}
 		return &ProcessError{
 			Type:    ProcessErrorTypeStart,
 			Context: fmt.Sprintf("failed to start %s (mode: %s, target: %s)", c.spec.GameId, c.spec.Mode, c.spec.PathOrId),
 			Err:     err,
 		}
 	}
+	c.proc = proc
+	if c.output != nil {
+		c.output.Mark("started %s (pid %d)", cmdName, proc.Pid())
+	}
 
 	c.waitOnce = sync.Once{}
 	c.waitDone = make(chan struct{})
@@ -171,57 +191,58 @@ func (c *Controller) Start() error {
 	return nil
 }
 
-// setupEnvironment configures environment variables for the process
-func (c *Controller) setupEnvironment() {
+// setupEnvironment configures environment variables for the process. The
+// game's own variables cannot override the bridge variables GABS sets or the
+// launcherEnv its launcher needs.
+func (c *Controller) setupEnvironment(launcherEnv []string) error {
+	gameEnv, err := gameEnvironment(c.spec)
+	if err != nil {
+		return err
+	}
+
 	bridgePath := c.getBridgePath()
 	bridgeEnvVars := []string{
 		fmt.Sprintf("GABS_GAME_ID=%s", c.spec.GameId),
 		fmt.Sprintf("GABS_BRIDGE_PATH=%s", bridgePath),
 	}
-	if c.spec.Mode == "SteamManaged" {
-		bridgeEnvVars = append(bridgeEnvVars,
-			fmt.Sprintf("SteamAppId=%s", c.spec.PathOrId),
-			fmt.Sprintf("SteamGameId=%s", c.spec.PathOrId),
-		)
-	}
+	bridgeEnvVars = append(bridgeEnvVars, launcherEnv...)
 
 	if c.bridgeInfo != nil {
 		bridgeEnvVars = append(bridgeEnvVars,
 			fmt.Sprintf("GABP_SERVER_PORT=%d", c.bridgeInfo.Port),
 			fmt.Sprintf("GABP_TOKEN=%s", c.bridgeInfo.Token),
 		)
+		if c.bridgeInfo.Socket != "" {
+			bridgeEnvVars = append(bridgeEnvVars,
+				fmt.Sprintf("GABP_TRANSPORT=%s", config.BridgeTransportUnix),
+				fmt.Sprintf("GABP_SOCKET=%s", c.bridgeInfo.Socket),
+			)
+		}
+		if c.spec.BridgeMode == config.BridgeModeListen {
+			// The bridge connects to GABS at GABP_SERVER_PORT instead
+			bridgeEnvVars = append(bridgeEnvVars, fmt.Sprintf("GABS_BRIDGE_MODE=%s", config.BridgeModeListen))
+		}
 	}
 
 	env := os.Environ()
 	if os.Getenv("SystemRoot") == "" {
 		env = append(env, "SystemRoot=C:\\Windows", "WINDIR=C:\\Windows")
 	}
+	env = append(env, gameEnv...)
 	c.cmd.Env = append(env, bridgeEnvVars...)
+	return nil
 }
 
 // IsRunning queries the actual system state to determine if the process is running
 // This is stateless - it directly checks the real process state
 func (c *Controller) IsRunning() bool {
-	// For Steam/Epic launchers, check for the actual game process by name if configured
-	if c.spec.Mode == "SteamAppId" || c.spec.Mode == "EpicAppId" {
-		if c.spec.StopProcessName != "" {
-			pids, err := findProcessesByNameFunc(c.spec.StopProcessName)
-			if err != nil {
-				return false
-			}
-			return len(pids) > 0
-		}
-		// Without StopProcessName, we can't track launcher-based games
-		return false
+	// For store launchers, check for the actual game process by name
+	if pids, byName := c.launcher().DetectGameProcess(c.spec); byName {
+		return len(pids) > 0
 	}
 
 	// For direct processes, check the managed process
-	if c.cmd == nil || c.cmd.Process == nil {
-		return c.isRunningByName()
-	}
-
-	// Check if the process has already been waited for
-	if c.cmd.ProcessState != nil {
+	if c.proc == nil {
 		return c.isRunningByName()
 	}
 
@@ -233,7 +254,7 @@ func (c *Controller) IsRunning() bool {
 
 	// Check if the child process is still alive using a lightweight OS call
 	// (Windows: OpenProcess+GetExitCodeProcess, Unix: Signal(0))
-	if isProcessAlive(c.cmd.Process.Pid) {
+	if c.proc.Alive() {
 		return true
 	}
 
@@ -264,24 +285,20 @@ func (c *Controller) WaitForProcessStart(timeout time.Duration) error {
 		return c.waitForProcessNameStart(timeout)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	ticker := time.NewTicker(500 * time.Millisecond)
+	clock := c.timeSource()
+	deadline := clock.After(timeout)
+	ticker := clock.NewTicker(500 * time.Millisecond)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ctx.Done():
+		case <-deadline:
 			return &ProcessError{
 				Type:    ProcessErrorTypeStart,
 				Context: fmt.Sprintf("timed out waiting for %s to start", c.spec.GameId),
 				Err:     fmt.Errorf("process not found in system after %v", timeout),
 			}
-		case <-ticker.C:
-			if c.cmd != nil && c.cmd.ProcessState != nil {
-				return nil
-			}
+		case <-ticker.C():
 			select {
 			case <-c.waitDone:
 				return nil
@@ -294,26 +311,77 @@ func (c *Controller) WaitForProcessStart(timeout time.Duration) error {
 	}
 }
 
+// exitPollInterval is how often WaitForExit checks whether the game is still
+// running.
+const exitPollInterval = 100 * time.Millisecond
+
+// WaitForExit waits until the process has exited or timeout passes, and
+// reports whether it exited. With a StopProcessName the game counts as
+// running while a process of that name is.
+func (c *Controller) WaitForExit(timeout time.Duration) bool {
+	if !c.IsRunning() {
+		return true
+	}
+
+	clock := c.timeSource()
+	deadline := clock.After(timeout)
+	ticker := clock.NewTicker(exitPollInterval)
+	defer ticker.Stop()
+
+	// waitDone closes as soon as the child is reaped, which saves a tick
+	exited := c.waitDone
+	for {
+		select {
+		case <-deadline:
+			return !c.IsRunning()
+		case <-exited:
+			exited = nil
+			if !c.IsRunning() {
+				return true
+			}
+		case <-ticker.C():
+			if !c.IsRunning() {
+				return true
+			}
+		}
+	}
+}
+
 func (c *Controller) usesLauncherProcessNameTracking() bool {
-	return (c.spec.Mode == "SteamAppId" || c.spec.Mode == "EpicAppId") && c.spec.StopProcessName != ""
+	return c.launcher().StopHints().NeedsProcessName && c.spec.StopProcessName != ""
 }
 
-func (c *Controller) waitForProcessNameStart(timeout time.Duration) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+// launcher returns the launcher of the configured mode, falling back to
+// DirectPath for a controller that was never configured.
+func (c *Controller) launcher() Launcher {
+	if launcher, exists := LauncherFor(c.spec.Mode); exists {
+		return launcher
+	}
+	return directLauncher{}
+}
+
+// ownsProcessTree reports whether commands of mode are the game itself, so
+// stopping it should also reach the processes it spawned. Launcher modes only
+// hand the game to another client and must leave that client alone.
+func ownsProcessTree(mode string) bool {
+	return mode == "DirectPath" || mode == "CustomCommand"
+}
 
-	ticker := time.NewTicker(500 * time.Millisecond)
+func (c *Controller) waitForProcessNameStart(timeout time.Duration) error {
+	clock := c.timeSource()
+	deadline := clock.After(timeout)
+	ticker := clock.NewTicker(500 * time.Millisecond)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ctx.Done():
+		case <-deadline:
 			return &ProcessError{
 				Type:    ProcessErrorTypeStart,
 				Context: fmt.Sprintf("timed out waiting for %s to start", c.spec.GameId),
 				Err:     fmt.Errorf("process %q not found in system after %v", c.spec.StopProcessName, timeout),
 			}
-		case <-ticker.C:
+		case <-ticker.C():
 			if c.isRunningByName() {
 				return nil
 			}
@@ -330,7 +398,7 @@ func (c *Controller) Stop(grace time.Duration) error {
 		}
 	}
 
-	if c.cmd == nil || c.cmd.Process == nil {
+	if c.proc == nil {
 		return &ProcessError{
 			Type:    ProcessErrorTypeStop,
 			Context: "no process to stop",
@@ -339,9 +407,9 @@ func (c *Controller) Stop(grace time.Duration) error {
 	}
 
 	// Try graceful termination first
-	if err := c.cmd.Process.Signal(getTerminationSignal()); err != nil {
+	if err := c.proc.Signal(getTerminationSignal()); err != nil {
 		// If graceful termination fails, try force kill
-		killErr := c.cmd.Process.Kill()
+		killErr := c.proc.Kill()
 		if killErr != nil {
 			return &ProcessError{
 				Type:    ProcessErrorTypeStop,
@@ -356,9 +424,9 @@ func (c *Controller) Stop(grace time.Duration) error {
 	select {
 	case <-c.waitDone:
 		return nil
-	case <-time.After(grace):
+	case <-c.timeSource().After(grace):
 		// Grace period expired, force kill
-		if err := c.cmd.Process.Kill(); err != nil {
+		if err := c.proc.Kill(); err != nil {
 			return &ProcessError{
 				Type:    ProcessErrorTypeStop,
 				Context: fmt.Sprintf("failed to force kill %s after grace period", c.spec.GameId),
@@ -369,6 +437,33 @@ func (c *Controller) Stop(grace time.Duration) error {
 	}
 }
 
+// Terminate asks the process to exit and returns without waiting for it or
+// killing it, so the caller can check on its own whether it exited.
+func (c *Controller) Terminate() error {
+	if c.spec.StopProcessName != "" {
+		if err := c.stopByProcessName(c.spec.StopProcessName, false, 0); err == nil {
+			return nil
+		}
+	}
+
+	if c.proc == nil {
+		return &ProcessError{
+			Type:    ProcessErrorTypeStop,
+			Context: "no process to stop",
+			Err:     fmt.Errorf("no process available"),
+		}
+	}
+
+	if err := c.proc.Signal(getTerminationSignal()); err != nil {
+		return &ProcessError{
+			Type:    ProcessErrorTypeStop,
+			Context: fmt.Sprintf("failed to signal %s", c.spec.GameId),
+			Err:     err,
+		}
+	}
+	return nil
+}
+
 // Kill forcefully terminates the process
 func (c *Controller) Kill() error {
 	if c.spec.StopProcessName != "" {
@@ -377,7 +472,7 @@ func (c *Controller) Kill() error {
 		}
 	}
 
-	if c.cmd == nil || c.cmd.Process == nil {
+	if c.proc == nil {
 		return &ProcessError{
 			Type:    ProcessErrorTypeStop,
 			Context: "no process to kill",
@@ -385,7 +480,7 @@ func (c *Controller) Kill() error {
 		}
 	}
 
-	err := c.cmd.Process.Kill()
+	err := c.proc.Kill()
 	if err != nil {
 		return &ProcessError{
 			Type:    ProcessErrorTypeStop,
@@ -396,24 +491,12 @@ func (c *Controller) Kill() error {
 	return nil
 }
 
-// Restart stops and then starts the process
-func (c *Controller) Restart() error {
-	// Stop then Start, preserving spec
-	if err := c.Stop(3 * time.Second); err != nil {
-		// Log the stop error but continue with restart
-		// The failure might be because the process was already dead
-		// In that case, starting should still work
-		fmt.Fprintf(os.Stderr, "Warning: Stop failed during restart: %v\n", err)
-	}
-	return c.Start()
-}
-
 // GetPID returns the process ID if available
 func (c *Controller) GetPID() int {
-	if c.cmd == nil || c.cmd.Process == nil {
+	if c.proc == nil {
 		return 0
 	}
-	return c.cmd.Process.Pid
+	return c.proc.Pid()
 }
 
 // GetLaunchMode returns the launch mode
@@ -428,11 +511,7 @@ func (c *Controller) GetStopProcessName() string {
 
 // IsLauncherProcessRunning checks if the launcher process itself is still running
 func (c *Controller) IsLauncherProcessRunning() bool {
-	if c.cmd == nil || c.cmd.Process == nil {
-		return false
-	}
-
-	if c.cmd.ProcessState != nil {
+	if c.proc == nil {
 		return false
 	}
 
@@ -442,17 +521,25 @@ func (c *Controller) IsLauncherProcessRunning() bool {
 	default:
 	}
 
-	err := c.cmd.Process.Signal(syscall.Signal(0))
+	err := c.proc.Signal(syscall.Signal(0))
 	return err == nil
 }
 
 func (c *Controller) waitForExit() {
-	if c.cmd == nil {
+	if c.proc == nil {
 		return
 	}
 
 	c.waitOnce.Do(func() {
-		_ = c.cmd.Wait()
+		err := c.proc.Wait()
+		if c.output != nil {
+			c.output.Flush()
+			if err != nil {
+				c.output.Mark("process exited: %v", err)
+			} else {
+				c.output.Mark("process exited")
+			}
+		}
 		if c.waitDone != nil {
 			close(c.waitDone)
 		}
@@ -544,7 +631,7 @@ func (c *Controller) stopByProcessName(processName string, force bool, grace tim
 				stopped++
 			}
 		} else {
-			if err := terminateProcess(pid, grace); err != nil {
+			if err := terminateProcess(pid, grace, c.timeSource()); err != nil {
 				lastErr = err
 			} else {
 				stopped++
@@ -610,6 +697,9 @@ func getTerminationSignal() os.Signal {
 func killProcess(pid int) error {
 	switch runtime.GOOS {
 	case "windows":
+		if err := nativeKillProcess(pid); err == nil {
+			return nil
+		}
 		cmd := exec.Command("taskkill", "/F", "/PID", strconv.Itoa(pid))
 		return cmd.Run()
 	default:
@@ -623,7 +713,7 @@ func killProcess(pid int) error {
 }
 
 // terminateProcess gracefully terminates a process by PID with a timeout
-func terminateProcess(pid int, grace time.Duration) error {
+func terminateProcess(pid int, grace time.Duration, clock util.Clock) error {
 	switch runtime.GOOS {
 	case "windows":
 		// On Windows, try gentle termination first, then force kill if timeout
@@ -634,11 +724,8 @@ func terminateProcess(pid int, grace time.Duration) error {
 
 		// Wait for process to exit gracefully
 		if grace > 0 {
-			time.Sleep(grace)
-			// Check if process still exists
-			checkCmd := exec.Command("tasklist", "/FI", "PID eq "+strconv.Itoa(pid), "/FO", "CSV")
-			output, err := checkCmd.Output()
-			if err == nil && strings.Contains(string(output), strconv.Itoa(pid)) {
+			clock.Sleep(grace)
+			if isProcessAlive(pid) {
 				// Process still exists, force kill it
 				return killProcess(pid)
 			}
@@ -667,7 +754,7 @@ func terminateProcess(pid int, grace time.Duration) error {
 			select {
 			case <-done:
 				return nil
-			case <-time.After(grace):
+			case <-clock.After(grace):
 				// Grace period expired, force kill
 				return process.Kill()
 			}
@@ -679,37 +766,35 @@ func terminateProcess(pid int, grace time.Duration) error {
 
 // findProcessesByName finds all processes with the given name
 func findProcessesByName(name string) ([]int, error) {
-	var pids []int
-
 	switch runtime.GOOS {
 	case "windows":
-		// Use tasklist command on Windows
-		cmd := exec.Command("tasklist", "/FI", "IMAGENAME eq "+name, "/FO", "CSV", "/NH")
-		output, err := cmd.Output()
-		if err != nil {
-			return nil, err
-		}
-
-		lines := strings.Split(string(output), "\n")
-		for _, line := range lines {
-			if strings.Contains(line, name) {
-				// Parse CSV: "ProcessName","PID","SessionName","Session#","MemUsage"
-				parts := strings.Split(line, ",")
-				if len(parts) >= 2 {
-					pidStr := strings.Trim(parts[1], "\"")
-					if pid, err := strconv.Atoi(pidStr); err == nil {
-						pids = append(pids, pid)
-					}
-				}
-			}
-		}
+		return findWindowsProcessesByName(name)
 	case "linux":
 		return findLinuxProcessesByName(name)
 	default:
 		return findProcessesByNameWithPgrep(name)
 	}
+}
 
-	return pids, nil
+// findWindowsProcessesByName matches image names without regard to case, as
+// Windows does.
+func findWindowsProcessesByName(name string) ([]int, error) {
+	processes, err := listWindowsProcesses()
+	if err != nil {
+		return nil, err
+	}
+	return pidsNamed(processes, name), nil
+}
+
+func pidsNamed(processes map[int]string, name string) []int {
+	var pids []int
+	for pid, image := range processes {
+		if strings.EqualFold(image, name) {
+			pids = append(pids, pid)
+		}
+	}
+	sort.Ints(pids)
+	return pids
 }
 
 func findProcessesByNameWithPgrep(name string) ([]int, error) {
@@ -772,7 +857,20 @@ func linuxProcessMatchesName(pid int, name string) bool {
 	if argv0End < 0 {
 		argv0End = len(cmdline)
 	}
-	argv0 := string(cmdline[:argv0End])
+	return argv0MatchesName(string(cmdline[:argv0End]), name)
+}
 
-	return argv0 == name || filepath.Base(argv0) == name
+// argv0MatchesName reports whether a process whose argv[0] is argv0 is the
+// program called name. Wine, and so Proton inside Steam's pressure-vessel
+// container, shows a Windows program with a Windows path such as
+// "Z:\games\Adventure.exe", and Windows compares names without case.
+func argv0MatchesName(argv0, name string) bool {
+	if argv0 == name {
+		return true
+	}
+	base := argv0[strings.LastIndexAny(argv0, `/\`)+1:]
+	if base == name {
+		return true
+	}
+	return strings.HasSuffix(strings.ToLower(name), ".exe") && strings.EqualFold(base, name)
 }
@@ -1,26 +1,44 @@
 package process
 
-import "time"
+import (
+	"time"
 
-// ControllerInterface defines the interface for process controllers
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// ControllerInterface is the part of Controller the MCP server and the
+// serialized starter use. Besides Controller, only Remote games have their
+// own controller; tests swap in fakes through ProcessRunner rather than
+// through this interface.
 type ControllerInterface interface {
 	Configure(spec LaunchSpec) error
 	SetBridgeInfo(port int, token string)
+	SetBridgeSocket(path string)
+	SetOutputLog(log *GameLog)
 	Start() error
 	Stop(grace time.Duration) error
+	Terminate() error
 	Kill() error
 	IsRunning() bool
+	WaitForProcessStart(timeout time.Duration) error
+	WaitForExit(timeout time.Duration) bool
 	GetPID() int
 	GetLaunchMode() string
 	GetStopProcessName() string
 	IsLauncherProcessRunning() bool
 }
 
-// NewController creates a new controller instance
-// This maintains the existing API while using the consolidated implementation
+// NewController creates a controller that uses the package's default process
+// runner and clock.
 func NewController() ControllerInterface {
 	return &Controller{}
 }
 
+// NewControllerWithRunner creates a controller that starts processes through
+// runner and measures waits with clock. Nil arguments use the package defaults.
+func NewControllerWithRunner(runner ProcessRunner, clock util.Clock) ControllerInterface {
+	return &Controller{runner: runner, clock: clock}
+}
+
 // Ensure Controller implements ControllerInterface
 var _ ControllerInterface = (*Controller)(nil)
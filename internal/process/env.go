@@ -0,0 +1,121 @@
+package process
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ParseEnvFile reads KEY=VALUE lines as written in .env files. Blank lines
+// and lines starting with '#' are skipped, an "export " prefix is allowed,
+// and a value may be wrapped in single or double quotes.
+func ParseEnvFile(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNo)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// ExpandEnvValue replaces ${NAME} in value with lookup(NAME), so secrets can
+// stay in the environment GABS runs in instead of the config file. "$$" is a
+// literal '$'; any other '$' is kept as it is. A reference to an unset
+// variable is an error rather than an empty value.
+func ExpandEnvValue(value string, lookup func(string) (string, bool)) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 >= len(value) {
+			out.WriteByte(value[i])
+			continue
+		}
+		switch value[i+1] {
+		case '$':
+			out.WriteByte('$')
+			i++
+		case '{':
+			end := strings.IndexByte(value[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated ${ in %q", value)
+			}
+			name := value[i+2 : i+2+end]
+			if name == "" {
+				return "", fmt.Errorf("empty ${} in %q", value)
+			}
+			resolved, ok := lookup(name)
+			if !ok {
+				return "", fmt.Errorf("environment variable %s is not set", name)
+			}
+			out.WriteString(resolved)
+			i += end + 2
+		default:
+			out.WriteByte('$')
+		}
+	}
+	return out.String(), nil
+}
+
+// gameEnvironment returns the KEY=VALUE entries the spec adds to the game's
+// environment: the entries of EnvFile, then Env, with ${NAME} references
+// expanded from the GABS environment. A relative EnvFile is resolved against
+// WorkingDir.
+func gameEnvironment(spec LaunchSpec) ([]string, error) {
+	values := make(map[string]string)
+	if spec.EnvFile != "" {
+		path := spec.EnvFile
+		if !filepath.IsAbs(path) && spec.WorkingDir != "" {
+			path = filepath.Join(spec.WorkingDir, path)
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read envFile: %w", err)
+		}
+		fileValues, err := ParseEnvFile(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("envFile %s: %w", path, err)
+		}
+		for key, value := range fileValues {
+			values[key] = value
+		}
+	}
+	for key, value := range spec.Env {
+		values[key] = value
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, err := ExpandEnvValue(values[key], os.LookupEnv)
+		if err != nil {
+			return nil, fmt.Errorf("env %s: %w", key, err)
+		}
+		env = append(env, key+"="+value)
+	}
+	return env, nil
+}
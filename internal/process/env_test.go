@@ -0,0 +1,120 @@
+package process
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	values, err := ParseEnvFile(strings.NewReader("# server secrets\n\nexport RCON_PASSWORD='s3cret = yes'\nWORLD=\"main\"\nMOTD=Hello there\n"))
+	if err != nil {
+		t.Fatalf("ParseEnvFile failed: %v", err)
+	}
+	want := map[string]string{"RCON_PASSWORD": "s3cret = yes", "WORLD": "main", "MOTD": "Hello there"}
+	if !reflect.DeepEqual(values, want) {
+		t.Fatalf("ParseEnvFile() = %v, want %v", values, want)
+	}
+
+	if _, err := ParseEnvFile(strings.NewReader("WORLD=main\nnot a pair\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected an error naming line 2, got %v", err)
+	}
+}
+
+func TestExpandEnvValue(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		if name == "RCON_TOKEN" {
+			return "abc", true
+		}
+		return "", false
+	}
+	for value, want := range map[string]string{
+		"${RCON_TOKEN}":        "abc",
+		"key-${RCON_TOKEN}-x":  "key-abc-x",
+		"pa$$word":             "pa$word",
+		"$HOME and trailing $": "$HOME and trailing $",
+	} {
+		got, err := ExpandEnvValue(value, lookup)
+		if err != nil || got != want {
+			t.Errorf("ExpandEnvValue(%q) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"${MISSING}", "${RCON_TOKEN", "${}"} {
+		if _, err := ExpandEnvValue(value, lookup); err == nil {
+			t.Errorf("ExpandEnvValue(%q) succeeded, want an error", value)
+		}
+	}
+}
+
+func TestControllerStartAddsGameEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "server.env"), []byte("WORLD=file\nMOTD=from file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GABS_TEST_RCON", "secret")
+
+	runner := NewFakeProcessRunner(4000)
+	controller := NewControllerWithRunner(runner, nil)
+	if err := controller.Configure(LaunchSpec{
+		GameId:     "factory",
+		Mode:       "DirectPath",
+		PathOrId:   "/opt/factory/start.sh",
+		WorkingDir: dir,
+		EnvFile:    "server.env",
+		Env: map[string]string{
+			"WORLD":         "main",
+			"RCON_PASSWORD": "${GABS_TEST_RCON}",
+			"GABP_TOKEN":    "overridden",
+		},
+	}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	controller.SetBridgeInfo(43210, "bridge-token")
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	env := runner.Started()[0].Env
+	for _, want := range []string{"WORLD=main", "MOTD=from file", "RCON_PASSWORD=secret"} {
+		if !containsEnv(env, want) {
+			t.Errorf("expected env %s in %#v", want, env)
+		}
+	}
+	// The bridge token comes last, so it wins over the game's own value.
+	if last := env[len(env)-1]; last != "GABP_TOKEN=bridge-token" {
+		t.Errorf("expected the bridge token last, got %q", last)
+	}
+
+	failing := NewControllerWithRunner(NewFakeProcessRunner(5000), nil)
+	if err := failing.Configure(LaunchSpec{GameId: "factory", Mode: "DirectPath", PathOrId: "/opt/factory/start.sh", Env: map[string]string{"RCON_PASSWORD": "${GABS_TEST_UNSET}"}}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	err := failing.Start()
+	var processErr *ProcessError
+	if !errors.As(err, &processErr) || processErr.Type != ProcessErrorTypeConfiguration || !strings.Contains(err.Error(), "GABS_TEST_UNSET is not set") {
+		t.Fatalf("expected a configuration error naming the unset variable, got %v", err)
+	}
+}
+
+func TestControllerStartAddsBridgeSocket(t *testing.T) {
+	runner := NewFakeProcessRunner(4000)
+	controller := NewControllerWithRunner(runner, nil)
+	if err := controller.Configure(LaunchSpec{GameId: "factory", Mode: "DirectPath", PathOrId: "/opt/factory/start.sh"}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	controller.SetBridgeInfo(43210, "bridge-token")
+	controller.SetBridgeSocket("/home/me/.gabs/factory/gabp/bridge.sock")
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	env := runner.Started()[0].Env
+	for _, want := range []string{"GABP_TRANSPORT=unix", "GABP_SOCKET=/home/me/.gabs/factory/gabp/bridge.sock", "GABP_TOKEN=bridge-token"} {
+		if !containsEnv(env, want) {
+			t.Errorf("expected env %s in %#v", want, env)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/pardeike/gabs/internal/steam"
+)
+
+// Launcher is how GABS starts games in one launch mode and finds them once
+// they run. Controller picks the launcher by LaunchSpec.Mode.
+type Launcher interface {
+	// BuildCommand returns the command that starts the game.
+	BuildCommand(spec LaunchSpec) (LaunchCommand, error)
+	// DetectGameProcess returns the PIDs of the running game and true when
+	// the game runs apart from the process GABS starts, as with store
+	// clients. It returns false when the started process is the game.
+	DetectGameProcess(spec LaunchSpec) ([]int, bool)
+	// StopHints says how games started this way can be stopped.
+	StopHints() StopHints
+}
+
+// LaunchCommand is the command a Launcher has GABS start.
+type LaunchCommand struct {
+	Name       string
+	Args       []string
+	WorkingDir string   // Used when the game configures no working directory
+	Env        []string // KEY=VALUE pairs set on top of the game's environment
+}
+
+// StopHints describes how GABS can stop a game.
+type StopHints struct {
+	Store            string // Store client the launch goes through, e.g. "Steam"; empty when GABS starts the game itself
+	NeedsProcessName bool   // The started process only hands off to the store client, so the game is stopped by stopProcessName
+}
+
+var launchers = map[string]Launcher{
+	"":              directLauncher{},
+	"DirectPath":    directLauncher{},
+	"CustomCommand": directLauncher{},
+	"Flatpak":       flatpakLauncher{},
+	"Proton":        protonLauncher{},
+	"SteamManaged":  steamManagedLauncher{},
+	"SteamAppId": storeLauncher{store: "Steam", command: func(target string) (string, []string, error) {
+		name, args := steamLaunchCommandFactory(target)
+		return name, args, nil
+	}},
+	"EpicAppId": storeLauncher{store: "Epic Games Launcher", command: func(target string) (string, []string, error) {
+		name, args := epicLaunchCommandFactory(target)
+		return name, args, nil
+	}},
+	"GOGGameId": storeLauncher{store: "GOG Galaxy", command: gogGalaxyLaunchCommand},
+	"XboxAppId": storeLauncher{store: "Xbox app", command: xboxLaunchCommand},
+}
+
+// LauncherFor returns the launcher of a launch mode. An empty mode is
+// DirectPath.
+func LauncherFor(mode string) (Launcher, bool) {
+	launcher, exists := launchers[mode]
+	return launcher, exists
+}
+
+// directLauncher starts the configured executable or command itself.
+type directLauncher struct{}
+
+func (directLauncher) BuildCommand(spec LaunchSpec) (LaunchCommand, error) {
+	return LaunchCommand{Name: spec.PathOrId, Args: spec.Args}, nil
+}
+
+func (directLauncher) DetectGameProcess(spec LaunchSpec) ([]int, bool) {
+	return nil, false
+}
+
+func (directLauncher) StopHints() StopHints {
+	return StopHints{}
+}
+
+// flatpakLauncher runs the Flatpak app whose ID is the target. flatpak run
+// stays alive as long as the app does, so it is tracked like a direct launch.
+type flatpakLauncher struct{}
+
+func (flatpakLauncher) BuildCommand(spec LaunchSpec) (LaunchCommand, error) {
+	if runtime.GOOS != "linux" {
+		return LaunchCommand{}, &ProcessError{
+			Type:    ProcessErrorTypeConfiguration,
+			Context: fmt.Sprintf("cannot launch %s through Flatpak", spec.GameId),
+			Err:     fmt.Errorf("Flatpak apps can only be started on Linux"),
+		}
+	}
+	return LaunchCommand{Name: "flatpak", Args: append([]string{"run", spec.PathOrId}, spec.Args...)}, nil
+}
+
+func (flatpakLauncher) DetectGameProcess(spec LaunchSpec) ([]int, bool) {
+	return nil, false
+}
+
+func (flatpakLauncher) StopHints() StopHints {
+	return StopHints{Store: "Flatpak"}
+}
+
+// protonLauncher runs the Windows executable in the target with "proton run",
+// which stays alive until the game exits.
+type protonLauncher struct{}
+
+func (protonLauncher) BuildCommand(spec LaunchSpec) (LaunchCommand, error) {
+	if runtime.GOOS != "linux" {
+		return LaunchCommand{}, &ProcessError{
+			Type:    ProcessErrorTypeConfiguration,
+			Context: fmt.Sprintf("cannot launch %s through Proton", spec.GameId),
+			Err:     fmt.Errorf("Proton only runs on Linux"),
+		}
+	}
+	if spec.Proton == nil || spec.Proton.Path == "" {
+		return LaunchCommand{}, &ProcessError{
+			Type:    ProcessErrorTypeConfiguration,
+			Context: fmt.Sprintf("cannot launch %s through Proton", spec.GameId),
+			Err:     fmt.Errorf("proton.path is not set"),
+		}
+	}
+
+	script := spec.Proton.Path
+	if info, err := os.Stat(script); err != nil {
+		return LaunchCommand{}, &ProcessError{
+			Type:    ProcessErrorTypeConfiguration,
+			Context: fmt.Sprintf("cannot launch %s through Proton", spec.GameId),
+			Err:     fmt.Errorf("Proton not found: %w", err),
+		}
+	} else if info.IsDir() {
+		script = filepath.Join(script, "proton")
+	}
+
+	homeDir, _ := os.UserHomeDir()
+	compatData := spec.Proton.CompatDataPath
+	if compatData == "" {
+		compatData = filepath.Join(homeDir, ".gabs", spec.GameId, "proton")
+	}
+	steamPath := spec.Proton.SteamPath
+	if steamPath == "" {
+		steamPath = filepath.Join(homeDir, ".steam", "steam")
+	}
+	return LaunchCommand{
+		Name: script,
+		Args: append([]string{"run", spec.PathOrId}, spec.Args...),
+		Env: []string{
+			fmt.Sprintf("STEAM_COMPAT_DATA_PATH=%s", compatData),
+			fmt.Sprintf("STEAM_COMPAT_CLIENT_INSTALL_PATH=%s", steamPath),
+		},
+	}, nil
+}
+
+func (protonLauncher) DetectGameProcess(spec LaunchSpec) ([]int, bool) {
+	return nil, false
+}
+
+func (protonLauncher) StopHints() StopHints {
+	return StopHints{}
+}
+
+// steamManagedLauncher starts the executable of an installed Steam app
+// directly, with the Steam client running and the app id set, so the game
+// keeps the bridge environment.
+type steamManagedLauncher struct{}
+
+func (steamManagedLauncher) BuildCommand(spec LaunchSpec) (LaunchCommand, error) {
+	app, err := steam.ResolveApp(spec.PathOrId)
+	if err != nil {
+		return LaunchCommand{}, &ProcessError{
+			Type:    ProcessErrorTypeConfiguration,
+			Context: fmt.Sprintf("failed to resolve Steam app %s", spec.PathOrId),
+			Err:     err,
+		}
+	}
+	if err := steam.EnsureClientRunning(); err != nil {
+		return LaunchCommand{}, &ProcessError{
+			Type:    ProcessErrorTypeStart,
+			Context: fmt.Sprintf("failed to prepare Steam client for %s", spec.GameId),
+			Err:     err,
+		}
+	}
+	if err := steam.EnsureAppIDFile(app); err != nil {
+		return LaunchCommand{}, &ProcessError{
+			Type:    ProcessErrorTypeConfiguration,
+			Context: fmt.Sprintf("failed to prepare Steam app id file for %s", spec.GameId),
+			Err:     err,
+		}
+	}
+	return LaunchCommand{
+		Name:       app.Executable,
+		Args:       spec.Args,
+		WorkingDir: app.WorkingDir,
+		Env: []string{
+			fmt.Sprintf("SteamAppId=%s", spec.PathOrId),
+			fmt.Sprintf("SteamGameId=%s", spec.PathOrId),
+		},
+	}, nil
+}
+
+func (steamManagedLauncher) DetectGameProcess(spec LaunchSpec) ([]int, bool) {
+	return nil, false
+}
+
+func (steamManagedLauncher) StopHints() StopHints {
+	return StopHints{Store: "Steam"}
+}
+
+// storeLauncher asks a store client to start the game. The started process
+// exits soon after, so the game is found by its stopProcessName.
+type storeLauncher struct {
+	store   string
+	command func(target string) (string, []string, error)
+}
+
+func (l storeLauncher) BuildCommand(spec LaunchSpec) (LaunchCommand, error) {
+	name, args, err := l.command(spec.PathOrId)
+	if err != nil {
+		return LaunchCommand{}, &ProcessError{
+			Type:    ProcessErrorTypeConfiguration,
+			Context: fmt.Sprintf("cannot launch %s through %s", spec.GameId, l.store),
+			Err:     err,
+		}
+	}
+	return LaunchCommand{Name: name, Args: args}, nil
+}
+
+func (l storeLauncher) DetectGameProcess(spec LaunchSpec) ([]int, bool) {
+	if spec.StopProcessName == "" {
+		// Without a process name the game cannot be told apart
+		return nil, true
+	}
+	pids, err := findProcessesByNameFunc(spec.StopProcessName)
+	if err != nil {
+		return nil, true
+	}
+	return pids, true
+}
+
+func (l storeLauncher) StopHints() StopHints {
+	return StopHints{Store: l.store, NeedsProcessName: true}
+}
+
+// gogGalaxyLaunchCommand has GOG Galaxy start the game with the given GOG
+// product ID.
+func gogGalaxyLaunchCommand(target string) (string, []string, error) {
+	args := []string{"/command=runGame", "/gameId=" + target}
+	switch runtime.GOOS {
+	case "windows":
+		programFiles := os.Getenv("ProgramFiles(x86)")
+		if programFiles == "" {
+			programFiles = `C:\Program Files (x86)`
+		}
+		return filepath.Join(programFiles, "GOG Galaxy", "GalaxyClient.exe"), args, nil
+	case "darwin":
+		return "/Applications/GOG Galaxy.app/Contents/MacOS/GOG Galaxy", args, nil
+	default:
+		return "", nil, fmt.Errorf("GOG Galaxy is only available on Windows and macOS")
+	}
+}
+
+// xboxLaunchCommand has Windows start the Xbox app or Game Pass title with
+// the given application user model ID, such as
+// "Publisher.Game_8wekyb3d8bbwe!App".
+func xboxLaunchCommand(target string) (string, []string, error) {
+	if runtime.GOOS != "windows" {
+		return "", nil, fmt.Errorf("Xbox app titles can only be started on Windows")
+	}
+	return "explorer.exe", []string{`shell:AppsFolder\` + target}, nil
+}
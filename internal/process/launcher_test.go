@@ -0,0 +1,147 @@
+package process
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/pardeike/gabs/internal/config"
+)
+
+func TestEveryLaunchModeHasALauncher(t *testing.T) {
+	for _, mode := range config.LaunchModes {
+		if mode == "Remote" {
+			// Started by the agent on the game's machine
+			continue
+		}
+		launcher, exists := LauncherFor(mode)
+		if !exists {
+			t.Fatalf("launch mode %s has no launcher", mode)
+		}
+		if got, want := launcher.StopHints().NeedsProcessName, config.IsStoreLaunchMode(mode); got != want {
+			t.Errorf("launch mode %s: NeedsProcessName is %v, config says %v", mode, got, want)
+		}
+	}
+	if _, exists := LauncherFor("Itch"); exists {
+		t.Fatal("expected no launcher for an unknown mode")
+	}
+}
+
+func TestStoreLauncherFindsTheGameByProcessName(t *testing.T) {
+	prevFind := findProcessesByNameFunc
+	defer func() { findProcessesByNameFunc = prevFind }()
+	findProcessesByNameFunc = func(name string) ([]int, error) {
+		if name == "Adventure.exe" {
+			return []int{4242}, nil
+		}
+		return nil, nil
+	}
+
+	launcher, _ := LauncherFor("GOGGameId")
+	pids, byName := launcher.DetectGameProcess(LaunchSpec{GameId: "adventure", Mode: "GOGGameId", PathOrId: "1207664663", StopProcessName: "Adventure.exe"})
+	if !byName || len(pids) != 1 || pids[0] != 4242 {
+		t.Fatalf("expected the game to be found by name, got %v (byName %v)", pids, byName)
+	}
+	if _, byName := launcher.DetectGameProcess(LaunchSpec{GameId: "adventure", Mode: "GOGGameId", PathOrId: "1207664663"}); !byName {
+		t.Fatal("expected a store launch without stopProcessName to report the game as untracked")
+	}
+
+	direct, _ := LauncherFor("DirectPath")
+	if _, byName := direct.DetectGameProcess(LaunchSpec{GameId: "factory", PathOrId: "/opt/factory", StopProcessName: "Adventure.exe"}); byName {
+		t.Fatal("expected a direct launch to track the started process")
+	}
+}
+
+func TestStoreLauncherCommands(t *testing.T) {
+	gog, _ := LauncherFor("GOGGameId")
+	command, err := gog.BuildCommand(LaunchSpec{GameId: "adventure", Mode: "GOGGameId", PathOrId: "1207664663"})
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		if err != nil {
+			t.Fatalf("GOG Galaxy launch failed: %v", err)
+		}
+		if !strings.Contains(command.Name, "GOG Galaxy") || strings.Join(command.Args, " ") != "/command=runGame /gameId=1207664663" {
+			t.Fatalf("unexpected GOG Galaxy command: %s %v", command.Name, command.Args)
+		}
+	default:
+		if err == nil {
+			t.Fatalf("expected GOG Galaxy launches to fail on %s, got %s %v", runtime.GOOS, command.Name, command.Args)
+		}
+	}
+
+	xbox, _ := LauncherFor("XboxAppId")
+	command, err = xbox.BuildCommand(LaunchSpec{GameId: "adventure", Mode: "XboxAppId", PathOrId: "Publisher.Adventure_8wekyb3d8bbwe!App"})
+	if runtime.GOOS != "windows" {
+		if err == nil {
+			t.Fatalf("expected Xbox app launches to fail on %s", runtime.GOOS)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("Xbox app launch failed: %v", err)
+	}
+	if command.Name != "explorer.exe" || len(command.Args) != 1 || command.Args[0] != `shell:AppsFolder\Publisher.Adventure_8wekyb3d8bbwe!App` {
+		t.Fatalf("unexpected Xbox app command: %s %v", command.Name, command.Args)
+	}
+}
+
+func TestFlatpakAndProtonCommands(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Flatpak and Proton launches need Linux")
+	}
+
+	flatpak, _ := LauncherFor("Flatpak")
+	command, err := flatpak.BuildCommand(LaunchSpec{GameId: "factory", Mode: "Flatpak", PathOrId: "org.example.Factory", Args: []string{"--windowed"}})
+	if err != nil {
+		t.Fatalf("Flatpak launch failed: %v", err)
+	}
+	if command.Name != "flatpak" || strings.Join(command.Args, " ") != "run org.example.Factory --windowed" {
+		t.Fatalf("unexpected Flatpak command: %s %v", command.Name, command.Args)
+	}
+
+	protonDir := t.TempDir()
+	proton, _ := LauncherFor("Proton")
+	spec := LaunchSpec{
+		GameId:   "adventure",
+		Mode:     "Proton",
+		PathOrId: "/games/Adventure/Adventure.exe",
+		Args:     []string{"-windowed"},
+		Proton:   &config.ProtonConfig{Path: protonDir, CompatDataPath: "/games/Adventure/prefix", SteamPath: "/opt/steam"},
+	}
+	command, err = proton.BuildCommand(spec)
+	if err != nil {
+		t.Fatalf("Proton launch failed: %v", err)
+	}
+	if command.Name != protonDir+"/proton" || strings.Join(command.Args, " ") != "run /games/Adventure/Adventure.exe -windowed" {
+		t.Fatalf("unexpected Proton command: %s %v", command.Name, command.Args)
+	}
+	if strings.Join(command.Env, " ") != "STEAM_COMPAT_DATA_PATH=/games/Adventure/prefix STEAM_COMPAT_CLIENT_INSTALL_PATH=/opt/steam" {
+		t.Fatalf("unexpected Proton environment: %v", command.Env)
+	}
+
+	spec.Proton = &config.ProtonConfig{Path: protonDir + "/missing"}
+	if _, err := proton.BuildCommand(spec); err == nil {
+		t.Fatal("expected a missing Proton build to fail")
+	}
+}
+
+func TestArgv0MatchesWineProcesses(t *testing.T) {
+	cases := []struct {
+		argv0 string
+		name  string
+		want  bool
+	}{
+		{"/usr/bin/java", "java", true},
+		{"java", "java", true},
+		{`Z:\games\Adventure\Adventure.exe`, "Adventure.exe", true},
+		{`C:\Program Files\Adventure\ADVENTURE.EXE`, "Adventure.exe", true},
+		{`Z:\games\Adventure\Adventure.exe`, "Adventure", false},
+		{"/usr/bin/Java", "java", false},
+		{"/usr/bin/wine64-preloader", "Adventure.exe", false},
+	}
+	for _, tc := range cases {
+		if got := argv0MatchesName(tc.argv0, tc.name); got != tc.want {
+			t.Errorf("argv0MatchesName(%q, %q) = %v, want %v", tc.argv0, tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package process
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+const (
+	// DefaultLogLines is how many recent output lines a GameLog keeps in memory.
+	DefaultLogLines = 1000
+	// GameLogFileName is the current log file inside a game's log directory.
+	GameLogFileName = "game.log"
+
+	logFileMaxBytes = 1 << 20
+	// logLineMaxBytes splits output that never ends a line, such as progress
+	// bars, so it cannot grow without bound.
+	logLineMaxBytes = 4096
+)
+
+// Log streams recorded in LogLine.Stream.
+const (
+	LogStreamStdout = "stdout"
+	LogStreamStderr = "stderr"
+	LogStreamGABS   = "gabs"
+)
+
+// LogLine is one line of captured game output.
+type LogLine struct {
+	Seq    int64     `json:"seq"`
+	Time   time.Time `json:"time"`
+	Stream string    `json:"stream"`
+	Text   string    `json:"text"`
+}
+
+// GameLog captures the stdout and stderr of a game process. It keeps the most
+// recent lines in memory and appends all output to game.log in its directory
+// through a util.RotatingFile, which rotates it once it grows past 1 MiB.
+type GameLog struct {
+	mu      sync.Mutex
+	dir     string
+	lines   []LogLine
+	start   int
+	seq     int64
+	partial map[string][]byte
+	file    *util.RotatingFile // Opened on the first write
+	fileErr error
+}
+
+// NewGameLog returns a GameLog that keeps capacity lines in memory and writes
+// files to dir. An empty dir keeps output in memory only.
+func NewGameLog(dir string, capacity int) *GameLog {
+	if capacity <= 0 {
+		capacity = DefaultLogLines
+	}
+	return &GameLog{
+		dir:     dir,
+		lines:   make([]LogLine, 0, capacity),
+		partial: make(map[string][]byte),
+	}
+}
+
+// Dir returns the directory log files are written to.
+func (l *GameLog) Dir() string {
+	return l.dir
+}
+
+// Writer returns an io.Writer that records everything written to it as lines
+// of stream.
+func (l *GameLog) Writer(stream string) io.Writer {
+	return &gameLogWriter{log: l, stream: stream}
+}
+
+type gameLogWriter struct {
+	log    *GameLog
+	stream string
+}
+
+func (w *gameLogWriter) Write(p []byte) (int, error) {
+	w.log.write(w.stream, p)
+	return len(p), nil
+}
+
+// Mark records a line written by GABS itself, such as a start marker.
+func (l *GameLog) Mark(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.flushPartialLocked()
+	l.appendLocked(LogStreamGABS, fmt.Sprintf(format, args...))
+}
+
+// Flush records output that has not ended with a newline yet. Call it once
+// the process has exited.
+func (l *GameLog) Flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.flushPartialLocked()
+	if l.file != nil {
+		l.file.Sync()
+	}
+}
+
+// Close flushes pending output and closes the log file.
+func (l *GameLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.flushPartialLocked()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// Tail returns up to n of the most recent lines with a sequence number above
+// since, oldest first, and the sequence number to pass as since to read only
+// newer lines next time.
+func (l *GameLog) Tail(n int, since int64) ([]LogLine, int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var matched []LogLine
+	for i := 0; i < len(l.lines); i++ {
+		line := l.lines[(l.start+i)%len(l.lines)]
+		if line.Seq > since {
+			matched = append(matched, line)
+		}
+	}
+	if n > 0 && len(matched) > n {
+		matched = matched[len(matched)-n:]
+	}
+	return matched, l.seq
+}
+
+// FileError returns the last error from writing the log file, if any. Output
+// is still kept in memory when the file cannot be written.
+func (l *GameLog) FileError() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.fileErr
+}
+
+func (l *GameLog) write(stream string, p []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data := append(l.partial[stream], p...)
+	for {
+		end := bytes.IndexByte(data, '\n')
+		if end < 0 {
+			if len(data) < logLineMaxBytes {
+				break
+			}
+			end = logLineMaxBytes
+			l.appendLocked(stream, string(data[:end]))
+			data = data[end:]
+			continue
+		}
+		l.appendLocked(stream, string(bytes.TrimSuffix(data[:end], []byte("\r"))))
+		data = data[end+1:]
+	}
+	if len(data) == 0 {
+		delete(l.partial, stream)
+	} else {
+		l.partial[stream] = append([]byte(nil), data...)
+	}
+}
+
+func (l *GameLog) flushPartialLocked() {
+	for _, stream := range []string{LogStreamStdout, LogStreamStderr} {
+		if data := l.partial[stream]; len(data) > 0 {
+			l.appendLocked(stream, string(data))
+		}
+		delete(l.partial, stream)
+	}
+}
+
+func (l *GameLog) appendLocked(stream, text string) {
+	l.seq++
+	line := LogLine{Seq: l.seq, Time: currentClock().Now(), Stream: stream, Text: text}
+	if len(l.lines) < cap(l.lines) {
+		l.lines = append(l.lines, line)
+	} else {
+		l.lines[l.start] = line
+		l.start = (l.start + 1) % len(l.lines)
+	}
+	l.writeFileLocked(line)
+}
+
+func (l *GameLog) writeFileLocked(line LogLine) {
+	if l.dir == "" {
+		return
+	}
+	if l.file == nil {
+		file, err := util.OpenRotatingFile(filepath.Join(l.dir, GameLogFileName), logFileMaxBytes)
+		if err != nil {
+			l.fileErr = err
+			return
+		}
+		l.file = file
+	}
+	entry := fmt.Sprintf("%s [%s] %s\n", line.Time.UTC().Format(time.RFC3339Nano), line.Stream, line.Text)
+	if _, err := l.file.Write([]byte(entry)); err != nil {
+		l.fileErr = err
+	}
+}
@@ -0,0 +1,102 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGameLogSplitsLinesPerStreamAndKeepsTheNewest(t *testing.T) {
+	log := NewGameLog("", 3)
+	stdout := log.Writer(LogStreamStdout)
+	stderr := log.Writer(LogStreamStderr)
+
+	fmt.Fprint(stdout, "loading wor")
+	fmt.Fprint(stderr, "warning: slow disk\r\n")
+	fmt.Fprint(stdout, "ld\nworld loaded\n")
+	fmt.Fprint(stdout, "saving")
+	log.Flush()
+
+	lines, next := log.Tail(0, 0)
+	var got []string
+	for _, line := range lines {
+		got = append(got, line.Stream+":"+line.Text)
+	}
+	want := "stdout:loading world,stdout:world loaded,stdout:saving"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("expected %q, got %q", want, strings.Join(got, ","))
+	}
+	if next != 4 {
+		t.Fatalf("expected next sequence 4, got %d", next)
+	}
+
+	lines, _ = log.Tail(1, 0)
+	if len(lines) != 1 || lines[0].Text != "saving" {
+		t.Fatalf("expected only the newest line, got %+v", lines)
+	}
+	if lines, _ := log.Tail(0, next); len(lines) != 0 {
+		t.Fatalf("expected no lines after %d, got %+v", next, lines)
+	}
+}
+
+func TestGameLogRotatesFiles(t *testing.T) {
+	dir := t.TempDir()
+	log := NewGameLog(dir, 10)
+	defer log.Close()
+
+	chunk := strings.Repeat("x", logLineMaxBytes-1) + "\n"
+	for written := 0; written < 2*logFileMaxBytes; written += len(chunk) {
+		log.Writer(LogStreamStdout).Write([]byte(chunk))
+	}
+	if err := log.FileError(); err != nil {
+		t.Fatalf("unexpected file error: %v", err)
+	}
+
+	for _, name := range []string{GameLogFileName, GameLogFileName + ".1"} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("expected %s: %v", name, err)
+		}
+		if info.Size() > logFileMaxBytes {
+			t.Fatalf("expected %s to stay below %d bytes, got %d", name, logFileMaxBytes, info.Size())
+		}
+	}
+}
+
+func TestControllerCapturesProcessOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test runs /bin/sh")
+	}
+
+	log := NewGameLog(t.TempDir(), 10)
+	controller := &Controller{}
+	if err := controller.Configure(LaunchSpec{GameId: "factory", Mode: "DirectPath", PathOrId: "/bin/sh", Args: []string{"-c", "echo ready; echo broken >&2; exit 3"}}); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	controller.SetOutputLog(log)
+	if err := controller.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	select {
+	case <-controller.waitDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("process did not exit")
+	}
+
+	lines, _ := log.Tail(0, 0)
+	var got []string
+	for _, line := range lines {
+		got = append(got, line.Stream+":"+line.Text)
+	}
+	text := strings.Join(got, "\n")
+	for _, want := range []string{"gabs:started /bin/sh", "stdout:ready", "stderr:broken", "gabs:process exited: exit status 3"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in captured output:\n%s", want, text)
+		}
+	}
+}
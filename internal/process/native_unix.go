@@ -0,0 +1,19 @@
+//go:build !windows
+
+package process
+
+import "errors"
+
+var errNoNativeProcessAPI = errors.New("no native process API on this platform")
+
+// nativeListProcesses is only implemented on Windows; other platforms read
+// /proc or ask ps.
+func nativeListProcesses() (map[int]string, error) {
+	return nil, errNoNativeProcessAPI
+}
+
+// nativeKillProcess is only implemented on Windows; other platforms use
+// os.Process.Kill.
+func nativeKillProcess(pid int) error {
+	return errNoNativeProcessAPI
+}
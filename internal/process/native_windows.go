@@ -0,0 +1,42 @@
+package process
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// nativeListProcesses reads the process table through a Toolhelp32 snapshot.
+func nativeListProcesses() (map[int]string, error) {
+	snapshot, err := syscall.CreateToolhelp32Snapshot(syscall.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.CloseHandle(snapshot)
+
+	var entry syscall.ProcessEntry32
+	entry.Size = uint32(unsafe.Sizeof(entry))
+	if err := syscall.Process32First(snapshot, &entry); err != nil {
+		return nil, err
+	}
+
+	processes := make(map[int]string)
+	for {
+		processes[int(entry.ProcessID)] = syscall.UTF16ToString(entry.ExeFile[:])
+		if err := syscall.Process32Next(snapshot, &entry); err != nil {
+			if err == syscall.ERROR_NO_MORE_FILES {
+				return processes, nil
+			}
+			return nil, err
+		}
+	}
+}
+
+// nativeKillProcess ends pid with TerminateProcess.
+func nativeKillProcess(pid int) error {
+	handle, err := syscall.OpenProcess(syscall.PROCESS_TERMINATE, false, uint32(pid))
+	if err != nil {
+		return err
+	}
+	defer syscall.CloseHandle(handle)
+	return syscall.TerminateProcess(handle, 1)
+}
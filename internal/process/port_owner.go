@@ -0,0 +1,171 @@
+package process
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// PortListener is a process listening on a local TCP port.
+type PortListener struct {
+	PID  int
+	Name string // Empty when the process table does not name it
+}
+
+func (l PortListener) String() string {
+	if l.Name == "" {
+		return fmt.Sprintf("pid %d", l.PID)
+	}
+	return fmt.Sprintf("%s (pid %d)", l.Name, l.PID)
+}
+
+var findPortListenerFunc = findPortListener
+
+// FindPortListener returns the process listening on TCP port, if the
+// platform lets GABS see it. Processes of other users are often hidden, so
+// a port can be busy without a listener being found.
+func FindPortListener(port int) (PortListener, bool) {
+	return findPortListenerFunc(port)
+}
+
+// SetFindPortListenerForTesting overrides port owner lookup in tests.
+func SetFindPortListenerForTesting(fn func(int) (PortListener, bool)) func() {
+	previous := findPortListenerFunc
+	if fn != nil {
+		findPortListenerFunc = fn
+	}
+	return func() {
+		findPortListenerFunc = previous
+	}
+}
+
+func findPortListener(port int) (PortListener, bool) {
+	var pid int
+	switch runtime.GOOS {
+	case "windows":
+		output, err := exec.Command("netstat", "-ano", "-p", "TCP").Output()
+		if err != nil {
+			return PortListener{}, false
+		}
+		pid = parseNetstatListener(output, port)
+	case "linux":
+		pid = findLinuxPortListener(port)
+	default:
+		output, err := exec.Command("lsof", "-nP", fmt.Sprintf("-iTCP:%d", port), "-sTCP:LISTEN", "-Fp").Output()
+		if err != nil {
+			return PortListener{}, false
+		}
+		pid = parseLsofListener(output)
+	}
+	if pid <= 0 {
+		return PortListener{}, false
+	}
+	listener := PortListener{PID: pid}
+	if processes, err := ListProcesses(); err == nil {
+		listener.Name = processes[pid]
+	}
+	return listener, true
+}
+
+// findLinuxPortListener finds the socket inode listening on port in
+// /proc/net/tcp{,6} and then the process holding a descriptor to it.
+func findLinuxPortListener(port int) int {
+	inodes := make(map[string]bool)
+	for _, table := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		if data, err := os.ReadFile(table); err == nil {
+			for _, inode := range parseProcNetTCPListeners(data, port) {
+				inodes[inode] = true
+			}
+		}
+	}
+	if len(inodes) == 0 {
+		return 0
+	}
+
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0
+	}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join("/proc", entry.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			if inodes[strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]")] {
+				return pid
+			}
+		}
+	}
+	return 0
+}
+
+// parseProcNetTCPListeners returns the socket inodes of the LISTEN rows for
+// port in a /proc/net/tcp table, where addresses are hex ADDR:PORT and state
+// 0A is LISTEN.
+func parseProcNetTCPListeners(data []byte, port int) []string {
+	var inodes []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 || fields[3] != "0A" {
+			continue
+		}
+		_, hexPort, ok := strings.Cut(fields[1], ":")
+		if !ok {
+			continue
+		}
+		if p, err := strconv.ParseInt(hexPort, 16, 32); err != nil || int(p) != port {
+			continue
+		}
+		inodes = append(inodes, fields[9])
+	}
+	return inodes
+}
+
+// parseLsofListener reads the first pid of lsof -F p output.
+func parseLsofListener(output []byte) int {
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "p") {
+			if pid, err := strconv.Atoi(strings.TrimSpace(line[1:])); err == nil {
+				return pid
+			}
+		}
+	}
+	return 0
+}
+
+// parseNetstatListener reads the pid of the LISTENING row for port in
+// netstat -ano output. The state column is localized, so a listening row is
+// recognized by its foreign address of port 0 instead.
+func parseNetstatListener(output []byte, port int) int {
+	suffix := ":" + strconv.Itoa(port)
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 5 || !strings.EqualFold(fields[0], "TCP") {
+			continue
+		}
+		if !strings.HasSuffix(fields[1], suffix) || !strings.HasSuffix(fields[2], ":0") {
+			continue
+		}
+		if pid, err := strconv.Atoi(fields[4]); err == nil {
+			return pid
+		}
+	}
+	return 0
+}
@@ -0,0 +1,59 @@
+package process
+
+import (
+	"net"
+	"os"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestParseProcNetTCPListeners(t *testing.T) {
+	table := []byte(`  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0100007F:4E20 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 12345 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:4E20 0100007F:C350 01 00000000:00000000 00:00000000 00000000  1000        0 23456 1 0000000000000000 20 4 30 10 -1
+   2: 0100007F:4E21 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 34567 1 0000000000000000 100 0 0 10 0
+`)
+	if got := parseProcNetTCPListeners(table, 20000); !reflect.DeepEqual(got, []string{"12345"}) {
+		t.Fatalf("parseProcNetTCPListeners() = %v, want [12345]", got)
+	}
+}
+
+func TestParseLsofListener(t *testing.T) {
+	if got := parseLsofListener([]byte("p4321\nf12\n")); got != 4321 {
+		t.Fatalf("parseLsofListener() = %d, want 4321", got)
+	}
+	if got := parseLsofListener(nil); got != 0 {
+		t.Fatalf("parseLsofListener(nil) = %d, want 0", got)
+	}
+}
+
+func TestParseNetstatListener(t *testing.T) {
+	output := []byte(`
+Active Connections
+
+  Proto  Local Address          Foreign Address        State           PID
+  TCP    127.0.0.1:20000        127.0.0.1:50000        ESTABLISHED     111
+  TCP    127.0.0.1:20000        0.0.0.0:0              ABHÖREN         222
+  TCP    127.0.0.1:20001        0.0.0.0:0              LISTENING       333
+`)
+	if got := parseNetstatListener(output, 20000); got != 222 {
+		t.Fatalf("parseNetstatListener() = %d, want 222", got)
+	}
+}
+
+func TestFindPortListenerFindsOwnListener(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("uses /proc")
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	owner, found := FindPortListener(listener.Addr().(*net.TCPAddr).Port)
+	if !found || owner.PID != os.Getpid() {
+		t.Fatalf("FindPortListener() = %v, %v, want pid %d", owner, found, os.Getpid())
+	}
+}
@@ -0,0 +1,291 @@
+package process
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// ProcessRunner starts prepared commands for a Controller. The default runner
+// spawns real OS processes; tests and the simulator can use FakeProcessRunner.
+type ProcessRunner interface {
+	Start(cmd *exec.Cmd, opts StartOptions) (RunningProcess, error)
+}
+
+// StartOptions tell a ProcessRunner how to start a command.
+type StartOptions struct {
+	// ProcessTree makes the command the root of its own process tree, so
+	// signals also reach the processes it spawns. Launchers such as Steam hand
+	// the game to a client that is already running, so only commands that are
+	// the game themselves get one.
+	ProcessTree bool
+}
+
+// RunningProcess is the handle a Controller keeps for a started command.
+type RunningProcess interface {
+	Pid() int
+	Signal(sig os.Signal) error
+	Kill() error
+	Wait() error
+	Alive() bool
+}
+
+var (
+	defaultsMu           sync.RWMutex
+	defaultProcessRunner ProcessRunner = execProcessRunner{}
+	defaultClock         util.Clock    = util.NewRealClock()
+)
+
+func currentProcessRunner() ProcessRunner {
+	defaultsMu.RLock()
+	defer defaultsMu.RUnlock()
+	return defaultProcessRunner
+}
+
+func currentClock() util.Clock {
+	defaultsMu.RLock()
+	defer defaultsMu.RUnlock()
+	return defaultClock
+}
+
+// SetProcessRunnerForTesting overrides the runner used by controllers that were
+// not given one explicitly. It returns a restore function.
+func SetProcessRunnerForTesting(runner ProcessRunner) func() {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	prev := defaultProcessRunner
+	if runner != nil {
+		defaultProcessRunner = runner
+	}
+	return func() {
+		defaultsMu.Lock()
+		defer defaultsMu.Unlock()
+		defaultProcessRunner = prev
+	}
+}
+
+// SetClockForTesting overrides the clock used by controllers that were not
+// given one explicitly. It returns a restore function.
+func SetClockForTesting(clock util.Clock) func() {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	prev := defaultClock
+	if clock != nil {
+		defaultClock = clock
+	}
+	return func() {
+		defaultsMu.Lock()
+		defer defaultsMu.Unlock()
+		defaultClock = prev
+	}
+}
+
+type execProcessRunner struct{}
+
+type execProcess struct {
+	cmd    *exec.Cmd
+	tree   *processTree // nil without StartOptions.ProcessTree or if the tree could not be set up; signals then reach only the started process
+	exited atomic.Bool  // set once Wait returns; cmd.ProcessState is not safe to read concurrently
+}
+
+// Start runs cmd. With opts.ProcessTree it becomes the root of a process tree
+// (a process group on Unix, a job object on Windows) so Signal and Kill also
+// reach the processes it spawns.
+func (execProcessRunner) Start(cmd *exec.Cmd, opts StartOptions) (RunningProcess, error) {
+	if !opts.ProcessTree {
+		if err := cmd.Start(); err != nil {
+			return nil, err
+		}
+		return &execProcess{cmd: cmd}, nil
+	}
+	prepareProcessTree(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	tree, err := attachProcessTree(cmd)
+	if err != nil {
+		tree = nil
+	}
+	return &execProcess{cmd: cmd, tree: tree}, nil
+}
+
+func (p *execProcess) Pid() int { return p.cmd.Process.Pid }
+
+// Signal sends sig to the whole tree. Signal 0 only probes the started
+// process itself.
+func (p *execProcess) Signal(sig os.Signal) error {
+	if p.tree == nil || sig == syscall.Signal(0) {
+		return p.cmd.Process.Signal(sig)
+	}
+	return p.tree.signal(sig)
+}
+
+// Kill ends the whole tree, including children left behind after the started
+// process exited.
+func (p *execProcess) Kill() error {
+	if p.tree == nil {
+		return p.cmd.Process.Kill()
+	}
+	return p.tree.kill()
+}
+
+func (p *execProcess) Wait() error {
+	err := p.cmd.Wait()
+	p.exited.Store(true)
+	if p.tree != nil {
+		p.tree.release()
+	}
+	return err
+}
+
+func (p *execProcess) Alive() bool {
+	if p.exited.Load() {
+		return false
+	}
+	return isProcessAlive(p.cmd.Process.Pid)
+}
+
+// FakeProcessRunner records started commands and hands out FakeProcess
+// handles instead of spawning anything.
+type FakeProcessRunner struct {
+	mu       sync.Mutex
+	nextPID  int
+	started  []*exec.Cmd
+	options  []StartOptions
+	procs    []*FakeProcess
+	StartErr error
+}
+
+// NewFakeProcessRunner returns a runner whose processes get PIDs from firstPID upwards.
+func NewFakeProcessRunner(firstPID int) *FakeProcessRunner {
+	return &FakeProcessRunner{nextPID: firstPID}
+}
+
+// Start records cmd and opts and returns a live FakeProcess, or StartErr if
+// set.
+func (r *FakeProcessRunner) Start(cmd *exec.Cmd, opts StartOptions) (RunningProcess, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.StartErr != nil {
+		return nil, r.StartErr
+	}
+	proc := newFakeProcess(r.nextPID)
+	r.nextPID++
+	r.started = append(r.started, cmd)
+	r.options = append(r.options, opts)
+	r.procs = append(r.procs, proc)
+	return proc, nil
+}
+
+// Started returns the commands passed to Start, in order.
+func (r *FakeProcessRunner) Started() []*exec.Cmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*exec.Cmd(nil), r.started...)
+}
+
+// StartedOptions returns the options passed to Start, in order.
+func (r *FakeProcessRunner) StartedOptions() []StartOptions {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]StartOptions(nil), r.options...)
+}
+
+// Processes returns the fake processes handed out so far, in order.
+func (r *FakeProcessRunner) Processes() []*FakeProcess {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*FakeProcess(nil), r.procs...)
+}
+
+// FakeProcess is a RunningProcess that stays alive until it is told to exit.
+// Termination signals and Kill make it exit immediately unless IgnoreSignals
+// is set, which simulates a process that needs a forced kill.
+type FakeProcess struct {
+	mu            sync.Mutex
+	pid           int
+	exited        chan struct{}
+	exitErr       error
+	signals       []os.Signal
+	IgnoreSignals bool
+}
+
+func newFakeProcess(pid int) *FakeProcess {
+	return &FakeProcess{pid: pid, exited: make(chan struct{})}
+}
+
+// Pid returns the fake process ID.
+func (p *FakeProcess) Pid() int { return p.pid }
+
+// Signal records sig. Signal 0 only probes liveness.
+func (p *FakeProcess) Signal(sig os.Signal) error {
+	if !p.Alive() {
+		return os.ErrProcessDone
+	}
+	if sig == syscall.Signal(0) {
+		return nil
+	}
+
+	p.mu.Lock()
+	p.signals = append(p.signals, sig)
+	ignore := p.IgnoreSignals
+	p.mu.Unlock()
+
+	if !ignore {
+		p.Exit(errors.New("signal: " + sig.String()))
+	}
+	return nil
+}
+
+// Kill makes the process exit.
+func (p *FakeProcess) Kill() error {
+	if !p.Alive() {
+		return os.ErrProcessDone
+	}
+	p.Exit(errors.New("signal: killed"))
+	return nil
+}
+
+// Wait blocks until the process exits and returns its exit error.
+func (p *FakeProcess) Wait() error {
+	<-p.exited
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.exitErr
+}
+
+// Alive reports whether the process has not exited yet.
+func (p *FakeProcess) Alive() bool {
+	select {
+	case <-p.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+// Exit marks the process as exited with err. Later calls are ignored.
+func (p *FakeProcess) Exit(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	select {
+	case <-p.exited:
+		return
+	default:
+	}
+	p.exitErr = err
+	close(p.exited)
+}
+
+// Signals returns the non-probe signals the process received.
+func (p *FakeProcess) Signals() []os.Signal {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]os.Signal(nil), p.signals...)
+}
@@ -0,0 +1,209 @@
+package process
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func newFakeController(t *testing.T, spec LaunchSpec) (*Controller, *FakeProcessRunner, *util.FakeClock) {
+	t.Helper()
+	runner := NewFakeProcessRunner(4200)
+	clock := util.NewFakeClock(time.Unix(0, 0))
+	controller := NewControllerWithRunner(runner, clock).(*Controller)
+	if err := controller.Configure(spec); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	return controller, runner, clock
+}
+
+func TestControllerStartsThroughProcessRunner(t *testing.T) {
+	controller, runner, clock := newFakeController(t, LaunchSpec{
+		GameId:     "factory",
+		Mode:       "DirectPath",
+		PathOrId:   "/opt/factory/start.sh",
+		Args:       []string{"--headless"},
+		WorkingDir: "/opt/factory",
+	})
+	controller.SetBridgeInfo(43210, "fake-token")
+
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	started := runner.Started()
+	if len(started) != 1 {
+		t.Fatalf("expected one started command, got %d", len(started))
+	}
+	if started[0].Dir != "/opt/factory" || started[0].Args[1] != "--headless" {
+		t.Fatalf("unexpected command: %#v", started[0])
+	}
+	if !containsEnv(started[0].Env, "GABP_SERVER_PORT=43210") {
+		t.Fatalf("expected bridge env in %#v", started[0].Env)
+	}
+	if controller.GetPID() != 4200 {
+		t.Fatalf("expected fake PID 4200, got %d", controller.GetPID())
+	}
+	if !controller.IsRunning() {
+		t.Fatal("expected fake process to be running")
+	}
+
+	waitDone := make(chan error, 1)
+	go func() {
+		waitDone <- controller.WaitForProcessStart(time.Second)
+	}()
+	clock.BlockUntil(2)
+	clock.Advance(500 * time.Millisecond)
+	if err := <-waitDone; err != nil {
+		t.Fatalf("WaitForProcessStart failed: %v", err)
+	}
+
+	runner.Processes()[0].Exit(nil)
+	<-controller.waitDone
+	if controller.IsRunning() {
+		t.Fatal("expected exited fake process to be reported as stopped")
+	}
+}
+
+func TestControllerOwnsProcessTreeOnlyForDirectLaunches(t *testing.T) {
+	tests := []struct {
+		mode string
+		want bool
+	}{
+		{mode: "DirectPath", want: true},
+		{mode: "CustomCommand", want: true},
+		{mode: "SteamAppId", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			controller, runner, _ := newFakeController(t, LaunchSpec{GameId: "factory", Mode: tt.mode, PathOrId: "123456", StopProcessName: "factory"})
+			if err := controller.Start(); err != nil {
+				t.Fatalf("Start failed: %v", err)
+			}
+			options := runner.StartedOptions()
+			if len(options) != 1 || options[0].ProcessTree != tt.want {
+				t.Fatalf("expected ProcessTree %v, got %#v", tt.want, options)
+			}
+		})
+	}
+}
+
+func TestControllerStopEscalatesToKillAfterFakeGrace(t *testing.T) {
+	controller, runner, clock := newFakeController(t, LaunchSpec{
+		GameId:   "factory",
+		Mode:     "DirectPath",
+		PathOrId: "/opt/factory/start.sh",
+	})
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	proc := runner.Processes()[0]
+	proc.IgnoreSignals = true
+
+	done := make(chan error, 1)
+	go func() {
+		done <- controller.Stop(time.Minute)
+	}()
+
+	clock.BlockUntil(1)
+	if !proc.Alive() {
+		t.Fatal("process should survive the termination signal")
+	}
+	clock.Advance(time.Minute)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Stop failed: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not return after the fake grace period")
+	}
+	if proc.Alive() {
+		t.Fatal("expected process to be killed after grace period")
+	}
+	if len(proc.Signals()) != 1 {
+		t.Fatalf("expected one termination signal, got %v", proc.Signals())
+	}
+}
+
+func TestControllerTerminateOnlySignals(t *testing.T) {
+	controller, runner, _ := newFakeController(t, LaunchSpec{
+		GameId:   "factory",
+		Mode:     "DirectPath",
+		PathOrId: "/opt/factory/start.sh",
+	})
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	proc := runner.Processes()[0]
+	proc.IgnoreSignals = true
+
+	if err := controller.Terminate(); err != nil {
+		t.Fatalf("Terminate failed: %v", err)
+	}
+	if !proc.Alive() || len(proc.Signals()) != 1 {
+		t.Fatalf("expected one termination signal and no kill, got alive=%v signals=%v", proc.Alive(), proc.Signals())
+	}
+}
+
+func TestWaitForProcessStartTimesOutOnFakeClock(t *testing.T) {
+	restore := SetFindProcessesByNameForTesting(func(name string) ([]int, error) {
+		return nil, nil
+	})
+	defer restore()
+
+	controller, _, clock := newFakeController(t, LaunchSpec{
+		GameId:          "adventure",
+		Mode:            "SteamAppId",
+		PathOrId:        "123456",
+		StopProcessName: "GameName.exe",
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- controller.WaitForProcessStart(30 * time.Second)
+	}()
+
+	clock.BlockUntil(2)
+	clock.Advance(30 * time.Second)
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected timeout error")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("WaitForProcessStart did not time out on the fake clock")
+	}
+}
+
+func TestSetForTestingOverridesAreRaceFree(t *testing.T) {
+	runner := NewFakeProcessRunner(4000)
+	clock := util.NewFakeClock(time.Unix(0, 0))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		controller := &Controller{}
+		for i := 0; i < 100; i++ {
+			controller.processRunner()
+			controller.timeSource()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		SetProcessRunnerForTesting(runner)()
+		SetClockForTesting(clock)()
+	}
+	<-done
+
+	restore := SetProcessRunnerForTesting(runner)
+	if got := (&Controller{}).processRunner(); got != runner {
+		t.Fatalf("expected override runner, got %#v", got)
+	}
+	restore()
+	if _, ok := (&Controller{}).processRunner().(execProcessRunner); !ok {
+		t.Fatal("expected restore to bring back the exec runner")
+	}
+}
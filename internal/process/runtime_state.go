@@ -28,6 +28,7 @@ type RuntimeState struct {
 	OwnerLastActive time.Time `json:"ownerLastActive,omitempty"`
 	GamePID         int       `json:"gamePid,omitempty"`
 	StopProcessName string    `json:"stopProcessName,omitempty"`
+	Profile         string    `json:"profile,omitempty"` // Launch profile the game was started with
 	UpdatedAt       time.Time `json:"updatedAt"`
 }
 
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/pardeike/gabs/internal/config"
 )
 
 // ProcessStartResult represents the result of a process start operation
@@ -17,6 +19,8 @@ type ProcessStartResult struct {
 	GABPConnectWait         time.Duration
 	BackgroundGABPConnect   bool
 	BackgroundGABPWait      time.Duration
+	GABPToolsMirrored       bool // Game tools were mirrored before the start returned
+	GABPToolCount           int  // Number of mirrored game tools when GABPToolsMirrored
 	Error                   error
 }
 
@@ -93,13 +97,11 @@ func (s *SerializedStarter) StartWithVerificationWithTimeouts(
 
 	// Phase 2: Wait for process to be detectable in system
 	// This is important for launcher-based games where there's a delay
-	if controller, ok := controller.(*Controller); ok {
-		if err := controller.WaitForProcessStart(processStartTimeout); err != nil {
-			s.mu.Unlock() // Release lock before returning
-			// Process didn't start or isn't detectable
-			result.Error = err
-			return result
-		}
+	if err := controller.WaitForProcessStart(processStartTimeout); err != nil {
+		s.mu.Unlock() // Release lock before returning
+		// Process didn't start or isn't detectable
+		result.Error = err
+		return result
 	}
 
 	// If we reach here, the process is started and detectable
@@ -217,10 +219,8 @@ func controllerLooksAlive(controller ControllerInterface) bool {
 		return true
 	}
 
-	switch controller.GetLaunchMode() {
-	case "SteamAppId", "EpicAppId":
+	if config.IsStoreLaunchMode(controller.GetLaunchMode()) {
 		return controller.IsLauncherProcessRunning()
-	default:
-		return false
 	}
+	return false
 }
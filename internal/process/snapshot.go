@@ -0,0 +1,190 @@
+package process
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var listProcessesFunc = listProcesses
+
+// launcherHelperNames are processes that Steam and Epic start next to a game.
+// They are never offered as a game's stopProcessName.
+var launcherHelperNames = map[string]bool{
+	"steam":                      true,
+	"steam.exe":                  true,
+	"steamwebhelper":             true,
+	"steamwebhelper.exe":         true,
+	"steamservice.exe":           true,
+	"steamerrorreporter":         true,
+	"steamerrorreporter.exe":     true,
+	"gameoverlayui":              true,
+	"gameoverlayui.exe":          true,
+	"reaper":                     true,
+	"pressure-vessel-wrap":       true,
+	"pv-bwrap":                   true,
+	"srt-bwrap":                  true,
+	"bwrap":                      true,
+	"epicgameslauncher":          true,
+	"epicgameslauncher.exe":      true,
+	"epicwebhelper":              true,
+	"epicwebhelper.exe":          true,
+	"unrealcefsubprocess.exe":    true,
+	"crashreportclient.exe":      true,
+	"easyanticheat_launcher.exe": true,
+	"conhost.exe":                true,
+	"cmd.exe":                    true,
+	"open":                       true,
+	"xdg-open":                   true,
+	"sh":                         true,
+	"bash":                       true,
+}
+
+// ListProcesses returns the name of every running process keyed by PID. Names
+// use the same form FindProcessesByName matches against.
+func ListProcesses() (map[int]string, error) {
+	return listProcessesFunc()
+}
+
+// SetListProcessesForTesting overrides the process table snapshot in tests.
+func SetListProcessesForTesting(fn func() (map[int]string, error)) func() {
+	previous := listProcessesFunc
+	if fn != nil {
+		listProcessesFunc = fn
+	}
+	return func() {
+		listProcessesFunc = previous
+	}
+}
+
+// NewProcessNames compares two process table snapshots and returns the sorted
+// names of processes that only appear in after, leaving out known launcher
+// helpers. A PID that was reused by a different program counts as new.
+func NewProcessNames(before, after map[int]string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for pid, name := range after {
+		if name == "" || launcherHelperNames[strings.ToLower(name)] {
+			continue
+		}
+		if previous, existed := before[pid]; existed && previous == name {
+			continue
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func listProcesses() (map[int]string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		return listWindowsProcesses()
+	case "linux":
+		return listLinuxProcesses()
+	default:
+		return listProcessesWithPs()
+	}
+}
+
+func listLinuxProcesses() (map[int]string, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+
+	processes := make(map[int]string)
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		procDir := filepath.Join("/proc", entry.Name())
+		// argv[0] keeps the full executable name; comm is cut at 15 bytes.
+		if cmdline, err := os.ReadFile(filepath.Join(procDir, "cmdline")); err == nil && len(cmdline) > 0 {
+			argv0 := string(cmdline)
+			if end := strings.IndexByte(argv0, 0); end >= 0 {
+				argv0 = argv0[:end]
+			}
+			if argv0 != "" {
+				processes[pid] = filepath.Base(argv0)
+				continue
+			}
+		}
+		if comm, err := os.ReadFile(filepath.Join(procDir, "comm")); err == nil {
+			processes[pid] = strings.TrimSpace(string(comm))
+		}
+	}
+	return processes, nil
+}
+
+// listWindowsProcesses reads the process table through the Toolhelp API and
+// falls back to tasklist if that fails.
+func listWindowsProcesses() (map[int]string, error) {
+	if processes, err := nativeListProcesses(); err == nil {
+		return processes, nil
+	}
+	output, err := exec.Command("tasklist", "/FO", "CSV", "/NH").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseTasklistCSV(output), nil
+}
+
+// parseTasklistCSV reads "ProcessName","PID","SessionName","Session#","MemUsage"
+// rows. Image names and localized memory figures may contain commas, so the
+// rows are parsed as quoted CSV. Lines that are not process rows, such as the
+// localized "no tasks" notice, are skipped.
+func parseTasklistCSV(output []byte) map[int]string {
+	reader := csv.NewReader(bytes.NewReader(output))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	processes := make(map[int]string)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil || len(record) < 2 {
+			continue
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil {
+			continue
+		}
+		processes[pid] = record[0]
+	}
+	return processes
+}
+
+func listProcessesWithPs() (map[int]string, error) {
+	output, err := exec.Command("ps", "-axo", "pid=,comm=").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	processes := make(map[int]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.SplitN(strings.TrimSpace(line), " ", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		processes[pid] = filepath.Base(strings.TrimSpace(fields[1]))
+	}
+	return processes, nil
+}
@@ -0,0 +1,66 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestNewProcessNamesSkipsExistingAndLauncherProcesses(t *testing.T) {
+	before := map[int]string{
+		100: "steam",
+		200: "explorer.exe",
+		300: "old-tool",
+	}
+	after := map[int]string{
+		100: "steam",
+		200: "explorer.exe",
+		300: "AdventureGame.exe", // PID reused by the game
+		400: "steamwebhelper",
+		500: "AdventureGame.exe",
+		600: "CrashReportClient.exe",
+		700: "factory-server",
+	}
+
+	got := NewProcessNames(before, after)
+	want := []string{"AdventureGame.exe", "factory-server"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NewProcessNames() = %v, want %v", got, want)
+	}
+}
+
+func TestListProcessesIncludesCurrentProcess(t *testing.T) {
+	processes, err := ListProcesses()
+	if err != nil {
+		t.Fatalf("ListProcesses failed: %v", err)
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatalf("os.Executable: %v", err)
+	}
+	name := processes[os.Getpid()]
+	if name == "" {
+		t.Fatalf("expected the test process in the snapshot")
+	}
+	if runtime.GOOS == "linux" && name != filepath.Base(executable) {
+		t.Fatalf("expected %q for the test process, got %q", filepath.Base(executable), name)
+	}
+}
+
+func TestParseTasklistCSVHandlesCommasAndNotices(t *testing.T) {
+	output := []byte("\"System Idle Process\",\"0\",\"Services\",\"0\",\"8 K\"\r\n" +
+		"\"Factory, Deluxe.exe\",\"4242\",\"Console\",\"1\",\"1.234.567 K\"\r\n" +
+		"INFORMATION: Es werden keine Aufgaben ausgeführt.\r\n" +
+		"\"AdventureGame.exe\",\"5150\",\"Console\",\"1\",\"98,304 K\"\r\n")
+
+	got := parseTasklistCSV(output)
+	want := map[int]string{0: "System Idle Process", 4242: "Factory, Deluxe.exe", 5150: "AdventureGame.exe"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseTasklistCSV() = %v, want %v", got, want)
+	}
+	if pids := pidsNamed(got, "adventuregame.EXE"); !reflect.DeepEqual(pids, []int{5150}) {
+		t.Fatalf("expected a case-insensitive image name match, got %v", pids)
+	}
+}
@@ -0,0 +1,56 @@
+//go:build !windows
+
+package process
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// processTree is the process group a started game leads. Games that spawn
+// helpers (wrapper scripts, JVMs, crash reporters) keep them in the group, so
+// signalling the group reaches all of them.
+type processTree struct {
+	pgid int
+}
+
+// prepareProcessTree makes cmd the leader of a new process group once it
+// starts.
+func prepareProcessTree(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// attachProcessTree returns the group of a command started after
+// prepareProcessTree.
+func attachProcessTree(cmd *exec.Cmd) (*processTree, error) {
+	return &processTree{pgid: cmd.Process.Pid}, nil
+}
+
+// signal sends sig to every process in the group. It returns
+// os.ErrProcessDone once the whole group is gone.
+func (t *processTree) signal(sig os.Signal) error {
+	unixSig, ok := sig.(syscall.Signal)
+	if !ok {
+		return errors.New("unsupported signal: " + sig.String())
+	}
+	if err := syscall.Kill(-t.pgid, unixSig); err != nil {
+		if errors.Is(err, syscall.ESRCH) {
+			return os.ErrProcessDone
+		}
+		return err
+	}
+	return nil
+}
+
+// kill forcefully ends every process in the group.
+func (t *processTree) kill() error {
+	return t.signal(syscall.SIGKILL)
+}
+
+// release frees resources held for the tree once the leader has exited.
+func (t *processTree) release() {}
@@ -0,0 +1,75 @@
+//go:build !windows
+
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// orphanAlive reports whether pid still runs. Zombies count as gone: an
+// orphan's reaper may be slow to collect it in a container.
+func orphanAlive(pid int) bool {
+	if !isProcessAlive(pid) {
+		return false
+	}
+	if runtime.GOOS == "linux" {
+		stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+		if err != nil {
+			return false
+		}
+		fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+		return len(fields) == 0 || fields[0] != "Z"
+	}
+	return true
+}
+
+func TestControllerKillEndsChildProcesses(t *testing.T) {
+	pidFile := filepath.Join(t.TempDir(), "child.pid")
+	controller := &Controller{}
+	if err := controller.Configure(LaunchSpec{
+		GameId:   "factory",
+		Mode:     "DirectPath",
+		PathOrId: "/bin/sh",
+		Args:     []string{"-c", `sleep 30 & echo $! > "$0"; wait`, pidFile},
+	}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	var childPID int
+	deadline := time.Now().Add(5 * time.Second)
+	for childPID == 0 {
+		if data, err := os.ReadFile(pidFile); err == nil && strings.HasSuffix(string(data), "\n") {
+			childPID, _ = strconv.Atoi(strings.TrimSpace(string(data)))
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("child process did not start")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Cleanup(func() {
+		if p, err := os.FindProcess(childPID); err == nil {
+			_ = p.Kill()
+		}
+	})
+
+	if err := controller.Kill(); err != nil {
+		t.Fatalf("Kill failed: %v", err)
+	}
+
+	deadline = time.Now().Add(5 * time.Second)
+	for orphanAlive(childPID) {
+		if time.Now().After(deadline) {
+			t.Fatalf("child process %d survived Kill", childPID)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
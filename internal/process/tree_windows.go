@@ -0,0 +1,105 @@
+package process
+
+import (
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	processSetQuota                     = 0x0100
+	processTerminate                    = 0x0001
+	jobObjectBasicAccountingInformation = 1
+)
+
+var (
+	procCreateJobObjectW          = modkernel32.NewProc("CreateJobObjectW")
+	procAssignProcessToJobObject  = modkernel32.NewProc("AssignProcessToJobObject")
+	procTerminateJobObject        = modkernel32.NewProc("TerminateJobObject")
+	procQueryInformationJobObject = modkernel32.NewProc("QueryInformationJobObject")
+)
+
+// jobObjectBasicAccounting mirrors JOBOBJECT_BASIC_ACCOUNTING_INFORMATION.
+type jobObjectBasicAccounting struct {
+	TotalUserTime             int64
+	TotalKernelTime           int64
+	ThisPeriodTotalUserTime   int64
+	ThisPeriodTotalKernelTime int64
+	TotalPageFaultCount       uint32
+	TotalProcesses            uint32
+	ActiveProcesses           uint32
+	TotalTerminatedProcesses  uint32
+}
+
+// processTree is the job object a started game is assigned to. Child
+// processes join the job automatically, so terminating the job ends all of
+// them. The job does not kill the game when GABS exits; games may outlive the
+// server.
+type processTree struct {
+	mu  sync.Mutex
+	job syscall.Handle // Zero once released
+}
+
+// prepareProcessTree needs no setup on Windows; the job is created once the
+// process exists.
+func prepareProcessTree(cmd *exec.Cmd) {}
+
+// attachProcessTree puts the started command into a new job object. Children
+// the game spawns before it is assigned stay outside the job.
+func attachProcessTree(cmd *exec.Cmd) (*processTree, error) {
+	job, _, err := procCreateJobObjectW.Call(0, 0)
+	if job == 0 {
+		return nil, err
+	}
+	handle, err := syscall.OpenProcess(processSetQuota|processTerminate, false, uint32(cmd.Process.Pid))
+	if err != nil {
+		syscall.CloseHandle(syscall.Handle(job))
+		return nil, err
+	}
+	defer syscall.CloseHandle(handle)
+
+	if r, _, err := procAssignProcessToJobObject.Call(job, uintptr(handle)); r == 0 {
+		syscall.CloseHandle(syscall.Handle(job))
+		return nil, err
+	}
+	return &processTree{job: syscall.Handle(job)}, nil
+}
+
+// signal can only deliver os.Kill to a job. Other signals are unsupported on
+// Windows, as with os.Process.Signal, so callers fall back to kill.
+func (t *processTree) signal(sig os.Signal) error {
+	if sig == os.Kill {
+		return t.kill()
+	}
+	return syscall.EWINDOWS
+}
+
+// kill terminates every process in the job.
+func (t *processTree) kill() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.job == 0 {
+		return os.ErrProcessDone
+	}
+	if r, _, err := procTerminateJobObject.Call(uintptr(t.job), 1); r == 0 {
+		return err
+	}
+	return nil
+}
+
+// release closes the job handle once no process in it is left running.
+func (t *processTree) release() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.job == 0 {
+		return
+	}
+	var info jobObjectBasicAccounting
+	r, _, _ := procQueryInformationJobObject.Call(uintptr(t.job), jobObjectBasicAccountingInformation, uintptr(unsafe.Pointer(&info)), unsafe.Sizeof(info), 0)
+	if r != 0 && info.ActiveProcesses == 0 {
+		syscall.CloseHandle(t.job)
+		t.job = 0
+	}
+}
@@ -0,0 +1,112 @@
+package process
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// ResourceUsage is what the operating system reports about one process.
+type ResourceUsage struct {
+	PID       int
+	CPUTime   time.Duration // User plus system time since the process started
+	RSSBytes  uint64        // Resident memory
+	StartedAt time.Time     // Zero when the platform does not report it
+}
+
+var readResourceUsageFunc = readResourceUsage
+
+// ReadResourceUsage returns the current CPU time, resident memory and start
+// time of pid.
+func ReadResourceUsage(pid int) (ResourceUsage, error) {
+	return readResourceUsageFunc(pid)
+}
+
+// SetReadResourceUsageForTesting overrides resource usage lookup in tests.
+func SetReadResourceUsageForTesting(fn func(int) (ResourceUsage, error)) func() {
+	previous := readResourceUsageFunc
+	if fn != nil {
+		readResourceUsageFunc = fn
+	}
+	return func() {
+		readResourceUsageFunc = previous
+	}
+}
+
+// UsageSample is one reading of a process taken by a UsageMonitor.
+type UsageSample struct {
+	PID        int
+	At         time.Time
+	CPUPercent float64 // Share of one core since the previous sample; above 100 when several cores are busy
+	RSSBytes   uint64
+	Uptime     time.Duration // Zero when the start time is unknown
+}
+
+// UsageMonitor turns the cumulative CPU time the OS reports into a CPU
+// percentage by comparing each sample with the previous one of the same
+// process. The first sample of a process averages over its whole lifetime.
+type UsageMonitor struct {
+	mu    sync.Mutex
+	clock util.Clock
+	last  map[int]usagePoint
+}
+
+type usagePoint struct {
+	at        time.Time
+	cpu       time.Duration
+	startedAt time.Time
+}
+
+// NewUsageMonitor returns a monitor that timestamps samples with clock. A nil
+// clock uses the package default.
+func NewUsageMonitor(clock util.Clock) *UsageMonitor {
+	if clock == nil {
+		clock = currentClock()
+	}
+	return &UsageMonitor{clock: clock, last: make(map[int]usagePoint)}
+}
+
+// Sample reads pid and returns its usage since the previous sample.
+func (m *UsageMonitor) Sample(pid int) (UsageSample, error) {
+	usage, err := ReadResourceUsage(pid)
+	if err != nil {
+		m.Forget(pid)
+		return UsageSample{}, err
+	}
+	now := m.clock.Now()
+	sample := UsageSample{PID: pid, At: now, RSSBytes: usage.RSSBytes}
+	if !usage.StartedAt.IsZero() && now.After(usage.StartedAt) {
+		sample.Uptime = now.Sub(usage.StartedAt)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	previous, ok := m.last[pid]
+	// A different start time means the PID now belongs to another process.
+	if ok && !previous.startedAt.Equal(usage.StartedAt) {
+		ok = false
+	}
+	switch {
+	case ok && now.After(previous.at) && usage.CPUTime >= previous.cpu:
+		sample.CPUPercent = percentOf(usage.CPUTime-previous.cpu, now.Sub(previous.at))
+	case !ok && sample.Uptime > 0:
+		sample.CPUPercent = percentOf(usage.CPUTime, sample.Uptime)
+	}
+	m.last[pid] = usagePoint{at: now, cpu: usage.CPUTime, startedAt: usage.StartedAt}
+	return sample, nil
+}
+
+// Forget drops the previous sample of pid, for example once it exited.
+func (m *UsageMonitor) Forget(pid int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.last, pid)
+}
+
+func percentOf(cpu, wall time.Duration) float64 {
+	if wall <= 0 {
+		return 0
+	}
+	return float64(cpu) / float64(wall) * 100
+}
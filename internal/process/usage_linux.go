@@ -0,0 +1,92 @@
+package process
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// linuxClockTicks is USER_HZ, the unit of the times in /proc/<pid>/stat. It is
+// 100 on every architecture Go supports.
+const linuxClockTicks = 100
+
+var (
+	linuxBootTimeOnce sync.Once
+	linuxBootTime     time.Time
+)
+
+// readResourceUsage reads /proc/<pid>/stat.
+func readResourceUsage(pid int) (ResourceUsage, error) {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return ResourceUsage{}, err
+	}
+	usage, startTicks, err := parseLinuxStat(data)
+	if err != nil {
+		return ResourceUsage{}, fmt.Errorf("pid %d: %w", pid, err)
+	}
+	usage.PID = pid
+	usage.RSSBytes *= uint64(os.Getpagesize())
+	if boot := bootTime(); !boot.IsZero() {
+		usage.StartedAt = boot.Add(ticksToDuration(startTicks))
+	}
+	return usage, nil
+}
+
+// parseLinuxStat returns the CPU time and resident pages of a
+// /proc/<pid>/stat line, along with the start time in ticks after boot.
+func parseLinuxStat(data []byte) (ResourceUsage, uint64, error) {
+	// The command name may contain spaces and parentheses; fields restart
+	// after the last ')'.
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return ResourceUsage{}, 0, fmt.Errorf("malformed stat line")
+	}
+	fields := strings.Fields(string(data[end+1:]))
+	// fields[0] is field 3 (state) of proc(5).
+	const utime, stime, starttime, rss = 14 - 3, 15 - 3, 22 - 3, 24 - 3
+	if len(fields) <= rss {
+		return ResourceUsage{}, 0, fmt.Errorf("stat line has %d fields", len(fields)+2)
+	}
+	values := make(map[int]uint64, 4)
+	for _, index := range []int{utime, stime, starttime, rss} {
+		value, err := strconv.ParseUint(fields[index], 10, 64)
+		if err != nil {
+			return ResourceUsage{}, 0, fmt.Errorf("stat field %d: %w", index+3, err)
+		}
+		values[index] = value
+	}
+	return ResourceUsage{
+		CPUTime:  ticksToDuration(values[utime] + values[stime]),
+		RSSBytes: values[rss],
+	}, values[starttime], nil
+}
+
+func ticksToDuration(ticks uint64) time.Duration {
+	return time.Duration(ticks) * time.Second / linuxClockTicks
+}
+
+// bootTime reads btime from /proc/stat once.
+func bootTime() time.Time {
+	linuxBootTimeOnce.Do(func() {
+		data, err := os.ReadFile("/proc/stat")
+		if err != nil {
+			return
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 2 && fields[0] == "btime" {
+				if seconds, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+					linuxBootTime = time.Unix(seconds, 0)
+				}
+				return
+			}
+		}
+	})
+	return linuxBootTime
+}
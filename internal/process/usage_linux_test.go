@@ -0,0 +1,25 @@
+package process
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLinuxStatHandlesSpacesInCommandName(t *testing.T) {
+	line := []byte("4242 (Factory (Server) v2) S 1 4242 4242 0 -1 4194560 100 0 0 0 250 125 0 0 20 0 12 0 98765 1234567 2048 18446744073709551615")
+
+	usage, startTicks, err := parseLinuxStat(line)
+	if err != nil {
+		t.Fatalf("parseLinuxStat failed: %v", err)
+	}
+	if usage.CPUTime != 3750*time.Millisecond {
+		t.Fatalf("expected 375 ticks of CPU time, got %v", usage.CPUTime)
+	}
+	if usage.RSSBytes != 2048 || startTicks != 98765 {
+		t.Fatalf("unexpected rss pages %d or start ticks %d", usage.RSSBytes, startTicks)
+	}
+
+	if _, _, err := parseLinuxStat([]byte("4242 (short) S 1")); err == nil {
+		t.Fatal("expected an error for a truncated stat line")
+	}
+}
@@ -0,0 +1,83 @@
+//go:build !linux && !windows
+
+package process
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// readResourceUsage asks ps, which reports RSS in KiB and times as
+// [[DD-]HH:]MM:SS[.ss].
+func readResourceUsage(pid int) (ResourceUsage, error) {
+	output, err := exec.Command("ps", "-o", "rss=,time=,etime=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return ResourceUsage{}, fmt.Errorf("pid %d: %w", pid, err)
+	}
+	usage, err := parsePsUsage(string(output), time.Now())
+	if err != nil {
+		return ResourceUsage{}, fmt.Errorf("pid %d: %w", pid, err)
+	}
+	usage.PID = pid
+	return usage, nil
+}
+
+func parsePsUsage(output string, now time.Time) (ResourceUsage, error) {
+	fields := strings.Fields(output)
+	if len(fields) != 3 {
+		return ResourceUsage{}, fmt.Errorf("unexpected ps output %q", strings.TrimSpace(output))
+	}
+	rssKiB, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return ResourceUsage{}, fmt.Errorf("rss: %w", err)
+	}
+	cpu, err := parsePsDuration(fields[1])
+	if err != nil {
+		return ResourceUsage{}, fmt.Errorf("time: %w", err)
+	}
+	elapsed, err := parsePsDuration(fields[2])
+	if err != nil {
+		return ResourceUsage{}, fmt.Errorf("etime: %w", err)
+	}
+	return ResourceUsage{
+		CPUTime:   cpu,
+		RSSBytes:  rssKiB * 1024,
+		StartedAt: now.Add(-elapsed),
+	}, nil
+}
+
+func parsePsDuration(value string) (time.Duration, error) {
+	var days time.Duration
+	if dash := strings.IndexByte(value, '-'); dash >= 0 {
+		n, err := strconv.Atoi(value[:dash])
+		if err != nil {
+			return 0, err
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		value = value[dash+1:]
+	}
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil {
+		return 0, err
+	}
+	total := days + time.Duration(seconds*float64(time.Second))
+	for i, unit := range []time.Duration{time.Minute, time.Hour} {
+		index := len(parts) - 2 - i
+		if index < 0 {
+			break
+		}
+		n, err := strconv.Atoi(parts[index])
+		if err != nil {
+			return 0, err
+		}
+		total += time.Duration(n) * unit
+	}
+	return total, nil
+}
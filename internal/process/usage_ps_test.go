@@ -0,0 +1,22 @@
+//go:build !linux && !windows
+
+package process
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePsUsage(t *testing.T) {
+	now := time.Unix(100000, 0)
+	usage, err := parsePsUsage("  20480   1:02.50 2-03:04:05\n", now)
+	if err != nil {
+		t.Fatalf("parsePsUsage failed: %v", err)
+	}
+	if usage.RSSBytes != 20480*1024 || usage.CPUTime != 62500*time.Millisecond {
+		t.Fatalf("unexpected usage %+v", usage)
+	}
+	if elapsed := now.Sub(usage.StartedAt); elapsed != 51*time.Hour+4*time.Minute+5*time.Second {
+		t.Fatalf("unexpected elapsed time %v", elapsed)
+	}
+}
@@ -0,0 +1,69 @@
+package process
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestUsageMonitorComputesCPUPercentBetweenSamples(t *testing.T) {
+	start := time.Unix(1000, 0)
+	clock := util.NewFakeClock(start.Add(100 * time.Second))
+	usage := ResourceUsage{CPUTime: 50 * time.Second, RSSBytes: 64 << 20, StartedAt: start}
+	var readErr error
+	t.Cleanup(SetReadResourceUsageForTesting(func(pid int) (ResourceUsage, error) {
+		usage.PID = pid
+		return usage, readErr
+	}))
+	monitor := NewUsageMonitor(clock)
+
+	first, err := monitor.Sample(42)
+	if err != nil {
+		t.Fatalf("Sample failed: %v", err)
+	}
+	if first.CPUPercent != 50 || first.Uptime != 100*time.Second || first.RSSBytes != 64<<20 {
+		t.Fatalf("expected the lifetime average on the first sample, got %+v", first)
+	}
+
+	clock.Advance(10 * time.Second)
+	usage.CPUTime += 15 * time.Second
+	second, err := monitor.Sample(42)
+	if err != nil {
+		t.Fatalf("Sample failed: %v", err)
+	}
+	if second.CPUPercent != 150 {
+		t.Fatalf("expected 150%% over the last 10s, got %+v", second)
+	}
+
+	// The PID now belongs to a process that started later.
+	clock.Advance(10 * time.Second)
+	usage = ResourceUsage{CPUTime: time.Second, StartedAt: clock.Now().Add(-4 * time.Second)}
+	reused, err := monitor.Sample(42)
+	if err != nil {
+		t.Fatalf("Sample failed: %v", err)
+	}
+	if reused.CPUPercent != 25 {
+		t.Fatalf("expected a reused PID to start over, got %+v", reused)
+	}
+
+	readErr = errors.New("gone")
+	if _, err := monitor.Sample(42); err == nil {
+		t.Fatal("expected the read error")
+	}
+}
+
+func TestReadResourceUsageOfCurrentProcess(t *testing.T) {
+	usage, err := ReadResourceUsage(os.Getpid())
+	if err != nil {
+		t.Fatalf("ReadResourceUsage failed: %v", err)
+	}
+	if usage.RSSBytes == 0 {
+		t.Fatalf("expected resident memory for the test process, got %+v", usage)
+	}
+	if usage.StartedAt.IsZero() || usage.StartedAt.After(time.Now()) {
+		t.Fatalf("expected a start time in the past, got %v", usage.StartedAt)
+	}
+}
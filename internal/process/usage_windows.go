@@ -0,0 +1,58 @@
+package process
+
+import (
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+const processVMRead = 0x0010
+
+var procK32GetProcessMemoryInfo = modkernel32.NewProc("K32GetProcessMemoryInfo")
+
+// processMemoryCounters mirrors PROCESS_MEMORY_COUNTERS.
+type processMemoryCounters struct {
+	CB                         uint32
+	PageFaultCount             uint32
+	PeakWorkingSetSize         uintptr
+	WorkingSetSize             uintptr
+	QuotaPeakPagedPoolUsage    uintptr
+	QuotaPagedPoolUsage        uintptr
+	QuotaPeakNonPagedPoolUsage uintptr
+	QuotaNonPagedPoolUsage     uintptr
+	PagefileUsage              uintptr
+	PeakPagefileUsage          uintptr
+}
+
+// readResourceUsage uses GetProcessTimes and the working set size from
+// K32GetProcessMemoryInfo.
+func readResourceUsage(pid int) (ResourceUsage, error) {
+	handle, err := syscall.OpenProcess(processQueryLimitedInformation|processVMRead, false, uint32(pid))
+	if err != nil {
+		return ResourceUsage{}, err
+	}
+	defer syscall.CloseHandle(handle)
+
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return ResourceUsage{}, err
+	}
+	usage := ResourceUsage{
+		PID:       pid,
+		CPUTime:   filetimeDuration(kernel) + filetimeDuration(user),
+		StartedAt: time.Unix(0, creation.Nanoseconds()),
+	}
+
+	var counters processMemoryCounters
+	counters.CB = uint32(unsafe.Sizeof(counters))
+	if r, _, err := procK32GetProcessMemoryInfo.Call(uintptr(handle), uintptr(unsafe.Pointer(&counters)), uintptr(counters.CB)); r == 0 {
+		return ResourceUsage{}, err
+	}
+	usage.RSSBytes = uint64(counters.WorkingSetSize)
+	return usage, nil
+}
+
+// filetimeDuration converts a FILETIME holding an interval of 100ns units.
+func filetimeDuration(ft syscall.Filetime) time.Duration {
+	return time.Duration(uint64(ft.HighDateTime)<<32|uint64(ft.LowDateTime)) * 100
+}
@@ -0,0 +1,238 @@
+package remote
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// defaultStopGrace is how long a stop waits before it kills the game when
+// the request names no grace period.
+const defaultStopGrace = 3 * time.Second
+
+// Agent serves the remote control API for the games in one configuration.
+type Agent struct {
+	token       string
+	gamesConfig *config.GamesConfig
+	log         util.Logger
+
+	// newController creates the controller for a game start; tests swap in
+	// controllers with a fake process runner.
+	newController func() process.ControllerInterface
+
+	mu          sync.Mutex
+	controllers map[string]process.ControllerInterface // Games this agent started
+}
+
+// NewAgent returns an agent for gamesConfig that accepts requests carrying
+// token.
+func NewAgent(gamesConfig *config.GamesConfig, token string, log util.Logger) (*Agent, error) {
+	if strings.TrimSpace(token) == "" {
+		return nil, errors.New("the agent needs a token")
+	}
+	return &Agent{
+		token:         token,
+		gamesConfig:   gamesConfig,
+		log:           log,
+		newController: process.NewController,
+		controllers:   make(map[string]process.ControllerInterface),
+	}, nil
+}
+
+// Handler returns the HTTP handler of the agent API.
+func (a *Agent) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/games/{id}/start", a.authorized(a.handleStart))
+	mux.HandleFunc("POST /v1/games/{id}/stop", a.authorized(a.handleStop))
+	mux.HandleFunc("POST /v1/games/{id}/terminate", a.authorized(a.handleTerminate))
+	mux.HandleFunc("POST /v1/games/{id}/kill", a.authorized(a.handleKill))
+	mux.HandleFunc("GET /v1/games/{id}/status", a.authorized(a.handleStatus))
+	return mux
+}
+
+// Serve answers agent requests on addr until ctx is done.
+func (a *Agent) Serve(ctx context.Context, addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           a.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+		} else {
+			errCh <- nil
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err := <-errCh:
+		return err
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
+
+// authorized rejects requests without the agent's token and requests for
+// games the agent does not know.
+func (a *Agent) authorized(handler func(http.ResponseWriter, *http.Request, config.GameConfig)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+			a.log.Warnw("rejected agent request without a valid token", "remote", r.RemoteAddr, "path", r.URL.Path)
+			writeError(w, http.StatusUnauthorized, "invalid or missing token")
+			return
+		}
+		gameID := r.PathValue("id")
+		game, exists := a.gamesConfig.GetGame(gameID)
+		if !exists {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("game '%s' is not configured on this agent", gameID))
+			return
+		}
+		if game.LaunchMode == "Remote" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("game '%s' is itself a Remote game; an agent only controls local games", gameID))
+			return
+		}
+		handler(w, r, *game)
+	}
+}
+
+func (a *Agent) handleStart(w http.ResponseWriter, r *http.Request, game config.GameConfig) {
+	var request StartRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid start request: %v", err))
+			return
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if existing, tracked := a.controllers[game.ID]; tracked && existing.IsRunning() {
+		writeError(w, http.StatusConflict, fmt.Sprintf("game '%s' is already running", game.ID))
+		return
+	}
+
+	controller := a.newController()
+	if err := controller.Configure(launchSpec(game)); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if request.BridgePort > 0 {
+		controller.SetBridgeInfo(request.BridgePort, request.BridgeToken)
+	}
+	if err := controller.Start(); err != nil {
+		a.log.Warnw("agent failed to start game", "gameId", game.ID, "error", err)
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	a.controllers[game.ID] = controller
+	a.log.Infow("agent started game", "gameId", game.ID, "pid", controller.GetPID(), "remote", r.RemoteAddr)
+	writeJSON(w, http.StatusOK, statusOf(game.ID, controller))
+}
+
+func (a *Agent) handleStop(w http.ResponseWriter, r *http.Request, game config.GameConfig) {
+	var request StopRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid stop request: %v", err))
+			return
+		}
+	}
+	grace := time.Duration(request.GraceMs) * time.Millisecond
+	if grace <= 0 {
+		grace = defaultStopGrace
+	}
+	a.control(w, r, game, "stop", func(controller process.ControllerInterface) error {
+		return controller.Stop(grace)
+	})
+}
+
+func (a *Agent) handleTerminate(w http.ResponseWriter, r *http.Request, game config.GameConfig) {
+	a.control(w, r, game, "terminate", process.ControllerInterface.Terminate)
+}
+
+func (a *Agent) handleKill(w http.ResponseWriter, r *http.Request, game config.GameConfig) {
+	a.control(w, r, game, "kill", process.ControllerInterface.Kill)
+}
+
+func (a *Agent) handleStatus(w http.ResponseWriter, r *http.Request, game config.GameConfig) {
+	writeJSON(w, http.StatusOK, statusOf(game.ID, a.controllerFor(game)))
+}
+
+// control runs action on the game's controller and answers with the status
+// afterwards.
+func (a *Agent) control(w http.ResponseWriter, r *http.Request, game config.GameConfig, verb string, action func(process.ControllerInterface) error) {
+	controller := a.controllerFor(game)
+	if err := action(controller); err != nil {
+		a.log.Warnw("agent game control failed", "gameId", game.ID, "action", verb, "error", err)
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	a.log.Infow("agent controlled game", "gameId", game.ID, "action", verb, "remote", r.RemoteAddr)
+	writeJSON(w, http.StatusOK, statusOf(game.ID, controller))
+}
+
+// controllerFor returns the controller of the game the agent started, or one
+// that finds the game by its stopProcessName.
+func (a *Agent) controllerFor(game config.GameConfig) process.ControllerInterface {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if controller, tracked := a.controllers[game.ID]; tracked {
+		return controller
+	}
+	controller := a.newController()
+	_ = controller.Configure(launchSpec(game))
+	return controller
+}
+
+func statusOf(gameID string, controller process.ControllerInterface) Status {
+	status := Status{GameID: gameID, Running: controller.IsRunning()}
+	if !status.Running {
+		status.LauncherRunning = controller.IsLauncherProcessRunning()
+	}
+	if status.Running || status.LauncherRunning {
+		status.PID = controller.GetPID()
+	}
+	return status
+}
+
+func launchSpec(game config.GameConfig) process.LaunchSpec {
+	return process.LaunchSpec{
+		GameId:          game.ID,
+		Mode:            game.LaunchMode,
+		PathOrId:        game.Target,
+		Args:            game.Args,
+		WorkingDir:      game.WorkingDir,
+		StopProcessName: game.StopProcessName,
+		Env:             game.Env,
+		EnvFile:         game.EnvFile,
+		Proton:          game.Proton,
+		BridgeMode:      game.BridgeMode,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, ErrorResponse{Error: message})
+}
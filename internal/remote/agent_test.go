@@ -0,0 +1,132 @@
+package remote
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// newTestAgent serves an agent for one DirectPath game whose processes come
+// from a fake runner.
+func newTestAgent(t *testing.T) (*httptest.Server, *process.FakeProcessRunner) {
+	t.Helper()
+	gamesConfig := &config.GamesConfig{Version: "1.0", Games: map[string]config.GameConfig{}}
+	if err := gamesConfig.AddGame(config.GameConfig{ID: "factory", Name: "FactorySim", LaunchMode: "DirectPath", Target: "/opt/factory/start.sh"}); err != nil {
+		t.Fatalf("AddGame failed: %v", err)
+	}
+
+	agent, err := NewAgent(gamesConfig, "secret", util.NewLogger("error"))
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+	runner := process.NewFakeProcessRunner(4000)
+	agent.newController = func() process.ControllerInterface {
+		return process.NewControllerWithRunner(runner, nil)
+	}
+
+	server := httptest.NewServer(agent.Handler())
+	t.Cleanup(server.Close)
+	return server, runner
+}
+
+func TestNewAgentRequiresToken(t *testing.T) {
+	if _, err := NewAgent(&config.GamesConfig{}, "  ", util.NewLogger("error")); err == nil {
+		t.Fatal("Expected an agent without a token to be rejected")
+	}
+}
+
+func TestAgentRejectsWrongTokenAndUnknownGames(t *testing.T) {
+	server, runner := newTestAgent(t)
+
+	intruder := newTestController(t, server.URL, "wrong", "factory")
+	if err := intruder.Start(); err == nil || !strings.Contains(err.Error(), "invalid or missing token") {
+		t.Fatalf("Expected the wrong token to be rejected, got: %v", err)
+	}
+	if len(runner.Started()) != 0 {
+		t.Fatalf("Expected no game to start, started %d", len(runner.Started()))
+	}
+
+	unknown := newTestController(t, server.URL, "secret", "adventure")
+	if _, err := unknown.Status(); err == nil || !strings.Contains(err.Error(), "not configured on this agent") {
+		t.Fatalf("Expected an unknown game to be rejected, got: %v", err)
+	}
+}
+
+func TestControllerStartsAndStopsGameThroughAgent(t *testing.T) {
+	server, runner := newTestAgent(t)
+	controller := newTestController(t, server.URL, "secret", "factory")
+	controller.SetBridgeInfo(51234, "bridge-token")
+
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	started := runner.Started()
+	if len(started) != 1 || started[0].Path != "/opt/factory/start.sh" {
+		t.Fatalf("Expected the agent to start the configured target, got %v", started)
+	}
+	if !containsEnv(started[0].Env, "GABP_SERVER_PORT=51234") || !containsEnv(started[0].Env, "GABP_TOKEN=bridge-token") {
+		t.Errorf("Expected the bridge endpoint in the game's environment, got %v", started[0].Env)
+	}
+	if err := controller.WaitForProcessStart(time.Second); err != nil {
+		t.Fatalf("WaitForProcessStart failed: %v", err)
+	}
+	if !controller.IsRunning() {
+		t.Error("Expected the game to be reported running after start")
+	}
+	if controller.GetPID() != 0 {
+		t.Errorf("Expected no local PID for a remote game, got %d", controller.GetPID())
+	}
+
+	if err := controller.Start(); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Errorf("Expected a second start to be refused, got: %v", err)
+	}
+
+	if err := controller.Stop(time.Second); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if !controller.WaitForExit(time.Second) {
+		t.Fatal("Expected the game to be stopped")
+	}
+	if controller.IsRunning() {
+		t.Error("Expected the game to be reported stopped")
+	}
+}
+
+func TestControllerTreatsUnreachableAgentAsNotRunning(t *testing.T) {
+	server, _ := newTestAgent(t)
+	controller := newTestController(t, server.URL, "secret", "factory")
+	server.Close()
+
+	err := controller.Start()
+	var processErr *process.ProcessError
+	if !errors.As(err, &processErr) || processErr.Type != process.ProcessErrorTypeStart {
+		t.Fatalf("Expected a start error, got: %v", err)
+	}
+	if controller.IsRunning() {
+		t.Error("Expected an unreachable agent to report the game not running")
+	}
+}
+
+func newTestController(t *testing.T, url, token, gameID string) *Controller {
+	t.Helper()
+	controller := NewController(config.RemoteConfig{URL: url, Token: token}, nil)
+	if err := controller.Configure(process.LaunchSpec{GameId: gameID, Mode: "Remote", PathOrId: gameID}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	return controller
+}
+
+func containsEnv(env []string, pair string) bool {
+	for _, entry := range env {
+		if entry == pair {
+			return true
+		}
+	}
+	return false
+}
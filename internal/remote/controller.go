@@ -0,0 +1,266 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/process"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+const (
+	// requestTimeout bounds a single call to the agent.
+	requestTimeout = 10 * time.Second
+	// statusTimeout bounds a status query.
+	statusTimeout = 3 * time.Second
+	// statusMaxAge is how old the status IsRunning reports may get before it
+	// asks the agent again.
+	statusMaxAge = time.Second
+	// pollInterval is how often waits ask the agent for the game's status.
+	pollInterval = 500 * time.Millisecond
+)
+
+// Controller controls a game through the agent on its machine. The PID it
+// reports is 0, because the game's PID means nothing on this machine.
+type Controller struct {
+	remote     config.RemoteConfig
+	client     *http.Client
+	clock      util.Clock
+	spec       process.LaunchSpec
+	bridgeInfo *process.BridgeInfo
+
+	mu         sync.Mutex
+	lastStatus Status    // Last answer from the agent; zero after a failed query
+	statusAt   time.Time // When lastStatus was updated
+	refreshing bool      // A background status query is running
+}
+
+// NewController returns a controller for the agent remote describes. A nil
+// clock uses the real one.
+func NewController(remote config.RemoteConfig, clock util.Clock) *Controller {
+	if clock == nil {
+		clock = util.NewRealClock()
+	}
+	return &Controller{remote: remote, client: &http.Client{}, clock: clock}
+}
+
+// Configure records the game; spec.PathOrId is the game's ID on the agent.
+func (c *Controller) Configure(spec process.LaunchSpec) error {
+	if spec.PathOrId == "" {
+		return &process.ProcessError{
+			Type:    process.ProcessErrorTypeConfiguration,
+			Context: fmt.Sprintf("PathOrId is required for mode %s", spec.Mode),
+			Err:     fmt.Errorf("the target must name the game on the agent"),
+		}
+	}
+	c.spec = spec
+	return nil
+}
+
+// SetBridgeInfo sets the GABP endpoint the agent passes to the game.
+func (c *Controller) SetBridgeInfo(port int, token string) {
+	c.bridgeInfo = &process.BridgeInfo{Port: port, Token: token}
+}
+
+// SetBridgeSocket does nothing: a socket on this machine cannot be reached
+// from the agent's, so remote bridges always use TCP.
+func (c *Controller) SetBridgeSocket(path string) {}
+
+// SetOutputLog does nothing: the game's output stays on the agent's machine.
+func (c *Controller) SetOutputLog(log *process.GameLog) {}
+
+// Start asks the agent to start the game.
+func (c *Controller) Start() error {
+	request := StartRequest{}
+	if c.bridgeInfo != nil {
+		request.BridgePort = c.bridgeInfo.Port
+		request.BridgeToken = c.bridgeInfo.Token
+	}
+	if _, err := c.call(http.MethodPost, "start", request, requestTimeout); err != nil {
+		return &process.ProcessError{Type: process.ProcessErrorTypeStart, Context: fmt.Sprintf("failed to start %s on %s", c.spec.GameId, c.remote.URL), Err: err}
+	}
+	return nil
+}
+
+// Stop asks the agent to stop the game, killing it after grace.
+func (c *Controller) Stop(grace time.Duration) error {
+	if _, err := c.call(http.MethodPost, "stop", StopRequest{GraceMs: grace.Milliseconds()}, requestTimeout+grace); err != nil {
+		return &process.ProcessError{Type: process.ProcessErrorTypeStop, Context: fmt.Sprintf("failed to stop %s on %s", c.spec.GameId, c.remote.URL), Err: err}
+	}
+	return nil
+}
+
+// Terminate asks the agent to signal the game without waiting for it.
+func (c *Controller) Terminate() error {
+	if _, err := c.call(http.MethodPost, "terminate", nil, requestTimeout); err != nil {
+		return &process.ProcessError{Type: process.ProcessErrorTypeStop, Context: fmt.Sprintf("failed to signal %s on %s", c.spec.GameId, c.remote.URL), Err: err}
+	}
+	return nil
+}
+
+// Kill asks the agent to kill the game.
+func (c *Controller) Kill() error {
+	if _, err := c.call(http.MethodPost, "kill", nil, requestTimeout); err != nil {
+		return &process.ProcessError{Type: process.ProcessErrorTypeStop, Context: fmt.Sprintf("failed to kill %s on %s", c.spec.GameId, c.remote.URL), Err: err}
+	}
+	return nil
+}
+
+// IsRunning reports whether the game ran at the last status query and starts
+// a new query in the background once that answer is older than statusMaxAge.
+// GABS checks game status while holding its own lock, so this never waits
+// for the network. An agent that cannot be reached counts as the game not
+// running.
+func (c *Controller) IsRunning() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.refreshing && c.clock.Now().Sub(c.statusAt) > statusMaxAge {
+		c.refreshing = true
+		go func() {
+			_, _ = c.call(http.MethodGet, "status", nil, statusTimeout)
+			c.mu.Lock()
+			c.refreshing = false
+			c.mu.Unlock()
+		}()
+	}
+	return c.lastStatus.Running
+}
+
+// IsLauncherProcessRunning reports whether the agent saw the game's launcher
+// running at the last status query.
+func (c *Controller) IsLauncherProcessRunning() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastStatus.LauncherRunning
+}
+
+// WaitForProcessStart waits until the agent reports the game or its launcher
+// running.
+func (c *Controller) WaitForProcessStart(timeout time.Duration) error {
+	if c.waitFor(timeout, func(status Status) bool { return status.Running || status.LauncherRunning }) {
+		return nil
+	}
+	return &process.ProcessError{
+		Type:    process.ProcessErrorTypeStart,
+		Context: fmt.Sprintf("timed out waiting for %s to start", c.spec.GameId),
+		Err:     fmt.Errorf("the agent at %s did not report the game running after %v", c.remote.URL, timeout),
+	}
+}
+
+// WaitForExit waits until the agent reports the game stopped.
+func (c *Controller) WaitForExit(timeout time.Duration) bool {
+	return c.waitFor(timeout, func(status Status) bool { return !status.Running && !status.LauncherRunning })
+}
+
+// waitFor polls the game's status until done accepts it or timeout passes.
+func (c *Controller) waitFor(timeout time.Duration, done func(Status) bool) bool {
+	check := func() bool {
+		status, err := c.call(http.MethodGet, "status", nil, statusTimeout)
+		return err == nil && done(status)
+	}
+	if check() {
+		return true
+	}
+	deadline := c.clock.After(timeout)
+	ticker := c.clock.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-deadline:
+			return check()
+		case <-ticker.C():
+			if check() {
+				return true
+			}
+		}
+	}
+}
+
+// Status asks the agent for the game's status.
+func (c *Controller) Status() (Status, error) {
+	return c.call(http.MethodGet, "status", nil, statusTimeout)
+}
+
+// GetPID returns 0; see Controller.
+func (c *Controller) GetPID() int {
+	return 0
+}
+
+// GetLaunchMode returns the configured launch mode, "Remote".
+func (c *Controller) GetLaunchMode() string {
+	return c.spec.Mode
+}
+
+// GetStopProcessName returns "": the agent stops the game by its own
+// configuration.
+func (c *Controller) GetStopProcessName() string {
+	return ""
+}
+
+// call sends one request to the agent and decodes the status it answers with.
+func (c *Controller) call(method, action string, body interface{}, timeout time.Duration) (Status, error) {
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			return Status{}, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	endpoint := strings.TrimSuffix(c.remote.URL, "/") + "/v1/games/" + url.PathEscape(c.spec.PathOrId) + "/" + action
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, &payload)
+	if err != nil {
+		return Status{}, err
+	}
+	request.Header.Set("Authorization", "Bearer "+c.remote.Token)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		c.recordStatus(Status{}, action)
+		return Status{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		c.recordStatus(Status{}, action)
+		var failure ErrorResponse
+		if json.NewDecoder(response.Body).Decode(&failure) == nil && failure.Error != "" {
+			return Status{}, fmt.Errorf("agent: %s", failure.Error)
+		}
+		return Status{}, fmt.Errorf("agent answered %s", response.Status)
+	}
+	var status Status
+	if err := json.NewDecoder(response.Body).Decode(&status); err != nil {
+		return Status{}, fmt.Errorf("invalid agent response: %w", err)
+	}
+	c.recordStatus(status, action)
+	return status, nil
+}
+
+// recordStatus remembers the outcome of a status query. Other requests only
+// count when they got an answer, so a failed stop does not make the game look
+// stopped.
+func (c *Controller) recordStatus(status Status, action string) {
+	if action != "status" && status.GameID == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastStatus = status
+	c.statusAt = c.clock.Now()
+}
+
+// Ensure Controller implements process.ControllerInterface
+var _ process.ControllerInterface = (*Controller)(nil)
@@ -0,0 +1,43 @@
+// Package remote lets a GABS server control games on another machine.
+//
+// The gaming machine runs 'gabs agent', which serves a small HTTP API for the
+// games in its own configuration. A game with launchMode "Remote" on the
+// machine running the AI client uses Controller, which implements the process
+// controller on top of that API. Only games the agent's owner configured can
+// be started, so a leaked token cannot run arbitrary commands.
+//
+// Every request carries "Authorization: Bearer <token>". Requests and
+// responses are JSON:
+//
+//	POST /v1/games/{id}/start      StartRequest -> Status
+//	POST /v1/games/{id}/stop       StopRequest  -> Status
+//	POST /v1/games/{id}/terminate               -> Status
+//	POST /v1/games/{id}/kill                    -> Status
+//	GET  /v1/games/{id}/status                  -> Status
+//
+// Failures answer with a non-2xx status and an ErrorResponse.
+package remote
+
+// StartRequest passes the GABP endpoint the game's bridge should listen on.
+type StartRequest struct {
+	BridgePort  int    `json:"bridgePort,omitempty"`
+	BridgeToken string `json:"bridgeToken,omitempty"`
+}
+
+// StopRequest asks for a graceful stop that escalates after GraceMs.
+type StopRequest struct {
+	GraceMs int64 `json:"graceMs"`
+}
+
+// Status describes a game on the agent's machine.
+type Status struct {
+	GameID          string `json:"gameId"`
+	Running         bool   `json:"running"`
+	LauncherRunning bool   `json:"launcherRunning,omitempty"`
+	PID             int    `json:"pid,omitempty"` // PID on the agent's machine
+}
+
+// ErrorResponse is the body of a failed request.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
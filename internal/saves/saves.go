@@ -0,0 +1,288 @@
+// Package saves snapshots a game's save directory into timestamped zip
+// archives and restores them.
+package saves
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// timeLayout names backups so they sort by creation time.
+const timeLayout = "20060102-150405"
+
+// ErrNotFound is returned by Find for a backup that does not exist.
+var ErrNotFound = errors.New("backup not found")
+
+// Backup is one zip snapshot of a save directory.
+type Backup struct {
+	Name      string    `json:"name"` // File name, such as 20260314-101730-before-reset.zip
+	Path      string    `json:"path"`
+	Label     string    `json:"label,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	Size      int64     `json:"size"`
+}
+
+// Create zips dir into backupDir, naming the archive after now and label.
+// Label characters other than letters, digits, '-' and '_' become '-'.
+func Create(dir, backupDir, label string, now time.Time) (Backup, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return Backup{}, fmt.Errorf("save directory: %w", err)
+	}
+	if !info.IsDir() {
+		return Backup{}, fmt.Errorf("save directory %s is not a directory", dir)
+	}
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return Backup{}, err
+	}
+
+	label = cleanLabel(label)
+	base := now.UTC().Format(timeLayout)
+	if label != "" {
+		base += "-" + label
+	}
+	name := base + ".zip"
+	for n := 2; fileExists(filepath.Join(backupDir, name)); n++ {
+		name = fmt.Sprintf("%s-%d.zip", base, n)
+	}
+	path := filepath.Join(backupDir, name)
+
+	// Write next to the final name so a failed backup never looks complete
+	tmp, err := os.CreateTemp(backupDir, ".backup-*.tmp")
+	if err != nil {
+		return Backup{}, err
+	}
+	defer os.Remove(tmp.Name())
+	if err := writeZip(tmp, dir); err != nil {
+		tmp.Close()
+		return Backup{}, fmt.Errorf("zip %s: %w", dir, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return Backup{}, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return Backup{}, err
+	}
+	return backupFromFile(backupDir, name)
+}
+
+func writeZip(w io.Writer, dir string) error {
+	archive := zip.NewWriter(w)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if entry.IsDir() {
+			_, err := archive.Create(name + "/")
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		header.Method = zip.Deflate
+		out, err := archive.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		_, err = io.Copy(out, in)
+		return err
+	})
+	if err != nil {
+		archive.Close()
+		return err
+	}
+	return archive.Close()
+}
+
+// List returns the backups in backupDir, newest first. A missing directory
+// has no backups.
+func List(backupDir string) ([]Backup, error) {
+	entries, err := os.ReadDir(backupDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var backups []Backup
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".zip") {
+			continue
+		}
+		backup, err := backupFromFile(backupDir, entry.Name())
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup)
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].CreatedAt.Equal(backups[j].CreatedAt) {
+			return backups[i].CreatedAt.After(backups[j].CreatedAt)
+		}
+		return backups[i].Name > backups[j].Name
+	})
+	return backups, nil
+}
+
+// Find returns the backup called name in backupDir. The ".zip" suffix may be
+// left out.
+func Find(backupDir, name string) (Backup, error) {
+	name = filepath.Base(strings.TrimSpace(name))
+	if !strings.HasSuffix(name, ".zip") {
+		name += ".zip"
+	}
+	backup, err := backupFromFile(backupDir, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Backup{}, ErrNotFound
+	}
+	return backup, err
+}
+
+// Prune removes the oldest backups beyond keep and returns them.
+func Prune(backupDir string, keep int) ([]Backup, error) {
+	backups, err := List(backupDir)
+	if err != nil || keep <= 0 || len(backups) <= keep {
+		return nil, err
+	}
+	removed := backups[keep:]
+	for _, backup := range removed {
+		if err := os.Remove(backup.Path); err != nil {
+			return nil, err
+		}
+	}
+	return removed, nil
+}
+
+// Restore replaces the contents of dir with backup. The archive is unpacked
+// next to dir first and swapped in only when that succeeded, so a broken
+// archive leaves dir as it was.
+func Restore(dir string, backup Backup) error {
+	archive, err := zip.OpenReader(backup.Path)
+	if err != nil {
+		return fmt.Errorf("open backup %s: %w", backup.Name, err)
+	}
+	defer archive.Close()
+
+	parent := filepath.Dir(filepath.Clean(dir))
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return err
+	}
+	staging, err := os.MkdirTemp(parent, "."+filepath.Base(dir)+"-restore-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+	for _, file := range archive.File {
+		if err := extract(file, staging); err != nil {
+			return fmt.Errorf("unpack backup %s: %w", backup.Name, err)
+		}
+	}
+
+	previous := staging + "-previous"
+	if err := os.Rename(dir, previous); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Rename(staging, dir); err != nil {
+		// Put the old contents back
+		os.Rename(previous, dir)
+		return err
+	}
+	return os.RemoveAll(previous)
+}
+
+func extract(file *zip.File, root string) error {
+	name := filepath.FromSlash(file.Name)
+	if !filepath.IsLocal(name) {
+		return fmt.Errorf("entry %q points outside the save directory", file.Name)
+	}
+	path := filepath.Join(root, name)
+	if file.FileInfo().IsDir() {
+		return os.MkdirAll(path, 0755)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	in, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode().Perm()|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Chtimes(path, file.Modified, file.Modified)
+}
+
+// backupFromFile describes the archive name in backupDir. The creation time
+// comes from the name, falling back to the file's modification time.
+func backupFromFile(backupDir, name string) (Backup, error) {
+	path := filepath.Join(backupDir, name)
+	info, err := os.Stat(path)
+	if err != nil {
+		return Backup{}, err
+	}
+	backup := Backup{Name: name, Path: path, CreatedAt: info.ModTime().UTC(), Size: info.Size()}
+	base := strings.TrimSuffix(name, ".zip")
+	if len(base) >= len(timeLayout) {
+		if created, err := time.Parse(timeLayout, base[:len(timeLayout)]); err == nil {
+			backup.CreatedAt = created
+			backup.Label = strings.TrimPrefix(base[len(timeLayout):], "-")
+		}
+	}
+	return backup, nil
+}
+
+func cleanLabel(label string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(label) {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
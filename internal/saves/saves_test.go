@@ -0,0 +1,106 @@
+package saves
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFileForTest(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateAndRestoreRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "saves")
+	backupDir := filepath.Join(root, "backups")
+	writeFileForTest(t, filepath.Join(dir, "world", "level.dat"), "day 1")
+	writeFileForTest(t, filepath.Join(dir, "settings.ini"), "hard")
+
+	now := time.Date(2026, time.March, 14, 10, 17, 30, 0, time.UTC)
+	backup, err := Create(dir, backupDir, "before reset!", now)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if backup.Name != "20260314-101730-before-reset.zip" || backup.Label != "before-reset" || !backup.CreatedAt.Equal(now) {
+		t.Fatalf("unexpected backup %#v", backup)
+	}
+	second, err := Create(dir, backupDir, "before reset", now)
+	if err != nil || second.Name != "20260314-101730-before-reset-2.zip" {
+		t.Fatalf("expected a second backup in the same second to get its own name, got %#v, %v", second, err)
+	}
+
+	writeFileForTest(t, filepath.Join(dir, "world", "level.dat"), "day 99")
+	writeFileForTest(t, filepath.Join(dir, "new.dat"), "added later")
+	if err := Restore(dir, backup); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "world", "level.dat")); string(data) != "day 1" {
+		t.Fatalf("expected the restored level, got %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new.dat")); !os.IsNotExist(err) {
+		t.Fatalf("expected files newer than the backup to be gone, got %v", err)
+	}
+
+	found, err := Find(backupDir, "20260314-101730-before-reset")
+	if err != nil || found.Path != backup.Path {
+		t.Fatalf("expected Find without .zip to work, got %#v, %v", found, err)
+	}
+	if _, err := Find(backupDir, "../../etc/passwd"); err != ErrNotFound {
+		t.Fatalf("expected a path outside the backups to be not found, got %v", err)
+	}
+}
+
+func TestPruneKeepsNewestBackups(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "saves")
+	backupDir := filepath.Join(root, "backups")
+	writeFileForTest(t, filepath.Join(dir, "slot1.sav"), "x")
+
+	start := time.Date(2026, time.March, 14, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		if _, err := Create(dir, backupDir, "", start.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	removed, err := Prune(backupDir, 2)
+	if err != nil || len(removed) != 2 || removed[0].Name != "20260314-110000.zip" {
+		t.Fatalf("expected the two oldest to be removed, got %#v, %v", removed, err)
+	}
+	backups, _ := List(backupDir)
+	if len(backups) != 2 || backups[0].Name != "20260314-130000.zip" {
+		t.Fatalf("expected the two newest to stay, newest first, got %#v", backups)
+	}
+}
+
+func TestRestoreRejectsEntriesOutsideTheDirectory(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "saves")
+	writeFileForTest(t, filepath.Join(dir, "slot1.sav"), "keep")
+
+	path := filepath.Join(root, "evil.zip")
+	file, _ := os.Create(path)
+	archive := zip.NewWriter(file)
+	w, _ := archive.Create("../escaped.txt")
+	w.Write([]byte("x"))
+	archive.Close()
+	file.Close()
+
+	if err := Restore(dir, Backup{Name: "evil.zip", Path: path}); err == nil {
+		t.Fatal("expected an entry outside the directory to be rejected")
+	}
+	if _, err := os.Stat(filepath.Join(root, "escaped.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected nothing to be written outside, got %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "slot1.sav")); string(data) != "keep" {
+		t.Fatalf("expected the save directory to stay untouched, got %q", data)
+	}
+}
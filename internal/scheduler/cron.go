@@ -0,0 +1,203 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Spec tells when a schedule fires next.
+type Spec interface {
+	// Next returns the first firing time after t, or the zero time if the
+	// schedule never fires again.
+	Next(t time.Time) time.Time
+}
+
+// Cron is a parsed five-field cron expression: minute, hour, day of month,
+// month and day of week. Fields accept '*', numbers, ranges (1-5), lists
+// (1,15), steps (*/10, 0-30/5) and three-letter month and weekday names. Like
+// classic cron, a day matches when either the day of month or the day of
+// week matches if both are restricted.
+type Cron struct {
+	expr             string
+	minute, hour     uint64
+	dom, month, dow  uint64
+	domStar, dowStar bool
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames   = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+// cronSearchLimit bounds how far Next looks ahead, so expressions that can
+// never match, such as February 30th, give up.
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// ParseCron parses a five-field cron expression or one of @yearly,
+// @monthly, @weekly, @daily, @midnight and @hourly.
+func ParseCron(expr string) (Cron, error) {
+	trimmed := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(trimmed)]; ok {
+		trimmed = macro
+	}
+	fields := strings.Fields(trimmed)
+	if len(fields) != 5 {
+		return Cron{}, fmt.Errorf("cron expression '%s' needs 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(fields))
+	}
+
+	c := Cron{expr: strings.TrimSpace(expr)}
+	var err error
+	if c.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return Cron{}, fmt.Errorf("cron minute: %w", err)
+	}
+	if c.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return Cron{}, fmt.Errorf("cron hour: %w", err)
+	}
+	if c.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return Cron{}, fmt.Errorf("cron day of month: %w", err)
+	}
+	if c.month, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return Cron{}, fmt.Errorf("cron month: %w", err)
+	}
+	// 7 is accepted as another name for Sunday.
+	if c.dow, err = parseCronField(fields[4], 0, 7, weekdayNames); err != nil {
+		return Cron{}, fmt.Errorf("cron day of week: %w", err)
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domStar = strings.HasPrefix(fields[2], "*")
+	c.dowStar = strings.HasPrefix(fields[4], "*")
+	return c, nil
+}
+
+// String returns the expression as it was given.
+func (c Cron) String() string {
+	return c.expr
+}
+
+// Next returns the first minute after t that matches, in t's location.
+func (c Cron) Next(t time.Time) time.Time {
+	limit := t.Add(cronSearchLimit)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(limit) {
+		switch {
+		case !has(c.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !has(c.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !has(c.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (c Cron) dayMatches(t time.Time) bool {
+	domMatch := has(c.dom, t.Day())
+	dowMatch := has(c.dow, int(t.Weekday()))
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+func parseCronField(field string, min, max int, names []string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if slash := strings.IndexByte(part, '/'); slash >= 0 {
+			rangePart = part[:slash]
+			n, err := strconv.Atoi(part[slash+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in '%s'", part)
+			}
+			step = n
+		}
+
+		low, high := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = parseCronValue(bounds[0], min, max, names); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(bounds[1], min, max, names); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("range '%s' runs backwards", rangePart)
+			}
+		default:
+			value, err := parseCronValue(rangePart, min, max, names)
+			if err != nil {
+				return 0, err
+			}
+			low = value
+			if step == 1 {
+				high = value
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			set |= 1 << uint(value)
+		}
+	}
+	return set, nil
+}
+
+func parseCronValue(value string, min, max int, names []string) (int, error) {
+	for i, name := range names {
+		if strings.EqualFold(value, name) {
+			return i + min, nil
+		}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s'", value)
+	}
+	if n < min || n > max {
+		return 0, fmt.Errorf("value %d is outside %d-%d", n, min, max)
+	}
+	return n, nil
+}
+
+// Interval fires every Period, aligned to multiples of Period since the Unix
+// epoch. Every GABS process therefore computes the same firing times, and a
+// 6h interval fires at 00:00, 06:00, 12:00 and 18:00 UTC.
+type Interval struct {
+	Period time.Duration
+}
+
+// Next returns the first multiple of the period after t.
+func (i Interval) Next(t time.Time) time.Time {
+	if i.Period <= 0 {
+		return time.Time{}
+	}
+	period := int64(i.Period)
+	elapsed := t.UnixNano()
+	next := elapsed - elapsed%period + period
+	return time.Unix(0, next).In(t.Location())
+}
@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	base := time.Date(2026, time.March, 14, 10, 17, 30, 0, time.UTC) // A Saturday
+	for _, tc := range []struct {
+		expr string
+		want time.Time
+	}{
+		{"0 4 * * *", time.Date(2026, time.March, 15, 4, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, time.March, 14, 10, 30, 0, 0, time.UTC)},
+		{"30 9-17/4 * * *", time.Date(2026, time.March, 14, 13, 30, 0, 0, time.UTC)},
+		{"0 0 * * mon-fri", time.Date(2026, time.March, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2026, time.March, 15, 12, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		// Day of month OR day of week when both are restricted.
+		{"0 6 20 * sun", time.Date(2026, time.March, 15, 6, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, time.March, 14, 11, 0, 0, 0, time.UTC)},
+		{"0 0 30 feb *", time.Time{}},
+	} {
+		cron, err := ParseCron(tc.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) failed: %v", tc.expr, err)
+		}
+		if got := cron.Next(base); !got.Equal(tc.want) {
+			t.Errorf("%q: Next = %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestCronNextKeepsLocalHoursInHalfHourZones(t *testing.T) {
+	india := time.FixedZone("IST", 5*3600+1800)
+	cron, err := ParseCron("0 11 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := cron.Next(time.Date(2026, time.March, 14, 10, 30, 0, 0, india))
+	if want := time.Date(2026, time.March, 14, 11, 0, 0, 0, india); !got.Equal(want) {
+		t.Fatalf("Next = %v, want %v", got, want)
+	}
+}
+
+func TestParseCronRejectsInvalidExpressions(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "5-1 * * * *", "*/0 * * * *", "0 0 * foo *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want an error", expr)
+		} else if !strings.Contains(err.Error(), "cron") {
+			t.Errorf("ParseCron(%q) error %q does not mention cron", expr, err)
+		}
+	}
+}
+
+func TestIntervalAlignsToEpoch(t *testing.T) {
+	interval := Interval{Period: 6 * time.Hour}
+	got := interval.Next(time.Date(2026, time.March, 14, 10, 17, 0, 0, time.UTC))
+	if want := time.Date(2026, time.March, 14, 12, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("Next = %v, want %v", got, want)
+	}
+	if next := interval.Next(got); !next.Equal(got.Add(6 * time.Hour)) {
+		t.Fatalf("expected the following firing one period later, got %v", next)
+	}
+}
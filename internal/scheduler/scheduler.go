@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+// DefaultRescanInterval is how often a Runner re-reads its jobs when no job is
+// due sooner, so schedules added or removed at runtime take effect.
+const DefaultRescanInterval = 30 * time.Second
+
+// Job is one schedule a Runner fires.
+type Job struct {
+	// Key identifies the job across rescans. A job whose key changes is
+	// treated as new, so include everything that affects when it fires.
+	Key  string
+	Spec Spec
+	// Run is called with the firing time once it is due. It runs on the
+	// Runner's goroutine; start a goroutine for long work.
+	Run func(at time.Time)
+}
+
+// Runner fires jobs at the times their specs give.
+type Runner struct {
+	Clock  util.Clock   // Nil uses the real clock
+	Jobs   func() []Job // Called on every wakeup
+	Rescan time.Duration
+}
+
+// Run fires due jobs until ctx is cancelled. A job's first firing time is
+// computed when the Runner first sees it, so times that passed before then
+// are not caught up.
+func (r *Runner) Run(ctx context.Context) {
+	clock := r.Clock
+	if clock == nil {
+		clock = util.NewRealClock()
+	}
+	rescan := r.Rescan
+	if rescan <= 0 {
+		rescan = DefaultRescanInterval
+	}
+
+	next := make(map[string]time.Time)
+	for {
+		now := clock.Now()
+		wake := now.Add(rescan)
+		seen := make(map[string]bool)
+
+		for _, job := range r.Jobs() {
+			seen[job.Key] = true
+			at, known := next[job.Key]
+			if !known {
+				at = job.Spec.Next(now)
+			} else if !at.IsZero() && !at.After(now) {
+				job.Run(at)
+				at = job.Spec.Next(now)
+			}
+			next[job.Key] = at
+			if !at.IsZero() && at.Before(wake) {
+				wake = at
+			}
+		}
+		for key := range next {
+			if !seen[key] {
+				delete(next, key)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-clock.After(wake.Sub(now)):
+		}
+	}
+}
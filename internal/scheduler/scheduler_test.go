@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestRunnerFiresDueJobsAndPicksUpChanges(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 10, 0, 30, 0, time.UTC)
+	clock := util.NewFakeClock(start)
+
+	var mu sync.Mutex
+	var fired []time.Time
+	jobs := []Job{{
+		Key:  "every-minute",
+		Spec: Interval{Period: time.Minute},
+		Run: func(at time.Time) {
+			mu.Lock()
+			fired = append(fired, at)
+			mu.Unlock()
+		},
+	}}
+	runner := &Runner{
+		Clock:  clock,
+		Rescan: time.Hour,
+		Jobs: func() []Job {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]Job(nil), jobs...)
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		runner.Run(ctx)
+		close(done)
+	}()
+
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+	}
+	clock.BlockUntil(1)
+
+	mu.Lock()
+	want := []time.Time{start.Add(30 * time.Second), start.Add(90 * time.Second)}
+	if len(fired) != 2 || !fired[0].Equal(want[0]) || !fired[1].Equal(want[1]) {
+		mu.Unlock()
+		t.Fatalf("fired at %v, want %v", fired, want)
+	}
+	jobs = nil
+	mu.Unlock()
+
+	cancel()
+	<-done
+}
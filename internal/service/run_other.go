@@ -0,0 +1,14 @@
+//go:build !windows
+
+package service
+
+import (
+	"context"
+	"errors"
+)
+
+// RunService is only needed on Windows. systemd and launchd run GABS as a
+// plain process.
+func RunService(ctx context.Context, name string, run func(context.Context) int) (int, error) {
+	return 1, errors.New("running under the service manager is only supported on Windows; systemd and launchd run 'gabs server' directly")
+}
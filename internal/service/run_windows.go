@@ -0,0 +1,134 @@
+//go:build windows
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	serviceWin32OwnProcess = 0x10
+
+	serviceStopped     = 1
+	serviceStopPending = 3
+	serviceRunning     = 4
+
+	serviceAcceptStop     = 0x1
+	serviceAcceptShutdown = 0x4
+
+	serviceControlStop        = 1
+	serviceControlInterrogate = 4
+	serviceControlShutdown    = 5
+
+	errorCallNotImplemented   = 120
+	errorServiceSpecificError = 1066
+	stopWaitHintMilliseconds  = 35000
+)
+
+var (
+	modadvapi32                       = syscall.NewLazyDLL("advapi32.dll")
+	procStartServiceCtrlDispatcherW   = modadvapi32.NewProc("StartServiceCtrlDispatcherW")
+	procRegisterServiceCtrlHandlerExW = modadvapi32.NewProc("RegisterServiceCtrlHandlerExW")
+	procSetServiceStatus              = modadvapi32.NewProc("SetServiceStatus")
+)
+
+type serviceTableEntry struct {
+	name *uint16
+	proc uintptr
+}
+
+type serviceStatus struct {
+	serviceType             uint32
+	currentState            uint32
+	controlsAccepted        uint32
+	win32ExitCode           uint32
+	serviceSpecificExitCode uint32
+	checkPoint              uint32
+	waitHint                uint32
+}
+
+// current is the service RunService runs. The service manager calls
+// serviceMain and serviceControl on threads of its own, so they find it here.
+var current struct {
+	name   *uint16
+	handle uintptr
+	ctx    context.Context
+	stop   context.CancelFunc
+	run    func(context.Context) int
+	exit   chan int
+}
+
+// RunService hands the process to the Windows service control manager, which
+// started it as service name, and runs run until the service is stopped. It
+// returns run's exit code.
+func RunService(ctx context.Context, name string, run func(context.Context) int) (int, error) {
+	namePtr, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return 1, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	current.name, current.ctx, current.stop, current.run = namePtr, ctx, cancel, run
+	current.exit = make(chan int, 1)
+
+	table := []serviceTableEntry{{name: namePtr, proc: syscall.NewCallback(serviceMain)}, {}}
+	// Returns once serviceMain reported the service as stopped
+	r, _, err := procStartServiceCtrlDispatcherW.Call(uintptr(unsafe.Pointer(&table[0])))
+	if r == 0 {
+		return 1, fmt.Errorf("connect to the service manager: %w", err)
+	}
+	select {
+	case code := <-current.exit:
+		return code, nil
+	default:
+		return 1, fmt.Errorf("service %s stopped before it ran", name)
+	}
+}
+
+func serviceMain(argc, argv uintptr) uintptr {
+	handle, _, _ := procRegisterServiceCtrlHandlerExW.Call(uintptr(unsafe.Pointer(current.name)), syscall.NewCallback(serviceControl), 0)
+	if handle == 0 {
+		return 0
+	}
+	current.handle = handle
+	setServiceStatus(serviceRunning, serviceAcceptStop|serviceAcceptShutdown, 0, 0)
+
+	code := current.run(current.ctx)
+	current.exit <- code
+	if code != 0 {
+		// A specific error counts as a failure, so the recovery actions restart GABS
+		setServiceStatus(serviceStopped, 0, errorServiceSpecificError, uint32(code))
+	} else {
+		setServiceStatus(serviceStopped, 0, 0, 0)
+	}
+	return 0
+}
+
+func serviceControl(control, eventType, eventData, context uintptr) uintptr {
+	switch control {
+	case serviceControlStop, serviceControlShutdown:
+		setServiceStatus(serviceStopPending, 0, 0, 0)
+		current.stop()
+	case serviceControlInterrogate:
+	default:
+		return errorCallNotImplemented
+	}
+	return 0
+}
+
+func setServiceStatus(state, accepts, win32ExitCode, specificExitCode uint32) {
+	status := serviceStatus{
+		serviceType:             serviceWin32OwnProcess,
+		currentState:            state,
+		controlsAccepted:        accepts,
+		win32ExitCode:           win32ExitCode,
+		serviceSpecificExitCode: specificExitCode,
+	}
+	if state == serviceStopPending {
+		status.waitHint = stopWaitHintMilliseconds
+	}
+	procSetServiceStatus.Call(current.handle, uintptr(unsafe.Pointer(&status)))
+}
@@ -0,0 +1,310 @@
+// Package service installs GABS as a background service: a systemd unit on
+// Linux, a launchd job on macOS and a service of the Windows service control
+// manager. Each restarts GABS when it fails and starts it at boot or login.
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultName is the service name when none is given.
+const DefaultName = "gabs"
+
+// restartDelaySeconds is how long the service manager waits before starting
+// GABS again after it failed.
+const restartDelaySeconds = 5
+
+// Spec describes the service to install.
+type Spec struct {
+	Name       string   // Service name, e.g. gabs
+	Executable string   // Absolute path of the gabs binary
+	Args       []string // Arguments after the executable
+	LogFile    string   // The server's --log-file; captured output goes next to it
+	System     bool     // Install for the whole machine instead of the current user
+	User       string   // Account a system service runs as, if not root
+	Home       string   // Home directory of the user the service runs for
+}
+
+// Command is one service manager command Install, Uninstall or Status runs.
+type Command struct {
+	Name string
+	Args []string
+}
+
+func (c Command) String() string {
+	parts := append([]string{c.Name}, c.Args...)
+	for i, part := range parts {
+		if part == "" || strings.ContainsAny(part, " \t\"'") {
+			parts[i] = fmt.Sprintf("%q", part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Plan is what installing a service on one platform takes: a file to write,
+// if the platform uses one, and the commands to run afterwards.
+type Plan struct {
+	Manager  string    // systemd, launchd or windows
+	System   bool      // Installed for the whole machine
+	Path     string    // Where File goes; empty on Windows
+	File     string    // Unit file or plist
+	Install  []Command // Run after File is written
+	Remove   []Command // Run before Path is removed
+	StatusOf Command
+}
+
+// Run executes a service manager command and returns its combined output.
+// Tests replace it.
+var Run = defaultRun
+
+func defaultRun(cmd Command) (string, error) {
+	out, err := exec.Command(cmd.Name, cmd.Args...).CombinedOutput()
+	return string(out), err
+}
+
+// NewPlan returns the plan for spec on goos.
+func NewPlan(goos string, spec Spec) (Plan, error) {
+	if spec.Name == "" {
+		spec.Name = DefaultName
+	}
+	if strings.ContainsAny(spec.Name, `/\ `) {
+		return Plan{}, fmt.Errorf("service name '%s' must not contain spaces or slashes", spec.Name)
+	}
+	switch goos {
+	case "linux":
+		return systemdPlan(spec), nil
+	case "darwin":
+		return launchdPlan(spec), nil
+	case "windows":
+		return windowsPlan(spec), nil
+	default:
+		return Plan{}, fmt.Errorf("installing a service is not supported on %s", goos)
+	}
+}
+
+// Install writes the plan's file and runs its install commands.
+func Install(plan Plan) error {
+	if plan.Path != "" {
+		if err := os.MkdirAll(filepath.Dir(plan.Path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(plan.Path, []byte(plan.File), 0644); err != nil {
+			return err
+		}
+	}
+	return runAll(plan.Install)
+}
+
+// Uninstall stops the service, runs the plan's remove commands and deletes
+// its file. A service that is already gone is not an error.
+func Uninstall(plan Plan) error {
+	err := runAll(plan.Remove)
+	if plan.Path != "" {
+		if removeErr := os.Remove(plan.Path); removeErr != nil && !os.IsNotExist(removeErr) {
+			return removeErr
+		}
+		if plan.Manager == "systemd" {
+			// Forget the removed unit
+			Run(systemctl(plan.System, "daemon-reload"))
+		}
+	}
+	return err
+}
+
+// Status returns what the service manager reports about the service.
+func Status(plan Plan) (string, error) {
+	return Run(plan.StatusOf)
+}
+
+func runAll(commands []Command) error {
+	for _, cmd := range commands {
+		if out, err := Run(cmd); err != nil {
+			return fmt.Errorf("%s: %v: %s", cmd, err, strings.TrimSpace(out))
+		}
+	}
+	return nil
+}
+
+func systemdPlan(spec Spec) Plan {
+	unit := spec.Name + ".service"
+	plan := Plan{Manager: "systemd", System: spec.System}
+	if spec.System {
+		plan.Path = filepath.Join("/etc/systemd/system", unit)
+	} else {
+		plan.Path = filepath.Join(spec.Home, ".config", "systemd", "user", unit)
+	}
+
+	var b strings.Builder
+	b.WriteString("[Unit]\n")
+	b.WriteString("Description=GABS - Game Agent Bridge Server (" + spec.Name + ")\n")
+	b.WriteString("After=network-online.target\n")
+	b.WriteString("Wants=network-online.target\n\n")
+	b.WriteString("[Service]\n")
+	b.WriteString("Type=simple\n")
+	b.WriteString("ExecStart=" + systemdCommandLine(spec.Executable, spec.Args) + "\n")
+	if spec.System && spec.User != "" {
+		b.WriteString("User=" + spec.User + "\n")
+	}
+	b.WriteString("Restart=on-failure\n")
+	b.WriteString(fmt.Sprintf("RestartSec=%d\n", restartDelaySeconds))
+	// Output goes to the journal; --log-file keeps a rotated copy
+	b.WriteString("StandardOutput=journal\n")
+	b.WriteString("StandardError=journal\n\n")
+	b.WriteString("[Install]\n")
+	if spec.System {
+		b.WriteString("WantedBy=multi-user.target\n")
+	} else {
+		b.WriteString("WantedBy=default.target\n")
+	}
+	plan.File = b.String()
+
+	plan.Install = []Command{
+		systemctl(spec.System, "daemon-reload"),
+		systemctl(spec.System, "enable", "--now", unit),
+	}
+	plan.Remove = []Command{systemctl(spec.System, "disable", "--now", unit)}
+	plan.StatusOf = systemctl(spec.System, "status", "--no-pager", unit)
+	return plan
+}
+
+// systemctl builds a command for the system or the user's service manager.
+func systemctl(system bool, args ...string) Command {
+	if !system {
+		args = append([]string{"--user"}, args...)
+	}
+	return Command{Name: "systemctl", Args: args}
+}
+
+// systemdCommandLine quotes the executable and arguments for ExecStart.
+func systemdCommandLine(executable string, args []string) string {
+	parts := make([]string, 0, len(args)+1)
+	for _, part := range append([]string{executable}, args...) {
+		if part == "" || strings.ContainsAny(part, " \t\"'\\$%") {
+			part = strings.ReplaceAll(part, `\`, `\\`)
+			part = strings.ReplaceAll(part, `"`, `\"`)
+			part = strings.ReplaceAll(part, `$`, `$$`)
+			part = strings.ReplaceAll(part, `%`, `%%`)
+			part = `"` + part + `"`
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+// LaunchdLabel is the launchd job label of service name.
+func LaunchdLabel(name string) string {
+	return "com.pardeike." + name
+}
+
+func launchdPlan(spec Spec) Plan {
+	label := LaunchdLabel(spec.Name)
+	plan := Plan{Manager: "launchd", System: spec.System}
+	if spec.System {
+		plan.Path = filepath.Join("/Library/LaunchDaemons", label+".plist")
+	} else {
+		plan.Path = filepath.Join(spec.Home, "Library", "LaunchAgents", label+".plist")
+	}
+
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+	plistString(&b, "Label", label)
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range append([]string{spec.Executable}, spec.Args...) {
+		b.WriteString("\t\t<string>" + xmlEscape(arg) + "</string>\n")
+	}
+	b.WriteString("\t</array>\n")
+	if spec.System && spec.User != "" {
+		plistString(&b, "UserName", spec.User)
+	}
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	// Start again after a crash, but not after a clean exit
+	b.WriteString("\t<key>KeepAlive</key>\n\t<dict>\n\t\t<key>SuccessfulExit</key>\n\t\t<false/>\n\t</dict>\n")
+	b.WriteString(fmt.Sprintf("\t<key>ThrottleInterval</key>\n\t<integer>%d</integer>\n", restartDelaySeconds))
+	output := filepath.Join(filepath.Dir(spec.LogFile), spec.Name+".stderr.log")
+	plistString(&b, "StandardOutPath", output)
+	plistString(&b, "StandardErrorPath", output)
+	b.WriteString("</dict>\n</plist>\n")
+	plan.File = b.String()
+
+	plan.Install = []Command{{Name: "launchctl", Args: []string{"load", "-w", plan.Path}}}
+	plan.Remove = []Command{{Name: "launchctl", Args: []string{"unload", "-w", plan.Path}}}
+	plan.StatusOf = Command{Name: "launchctl", Args: []string{"list", label}}
+	return plan
+}
+
+func plistString(b *bytes.Buffer, key, value string) {
+	b.WriteString("\t<key>" + key + "</key>\n\t<string>" + xmlEscape(value) + "</string>\n")
+}
+
+func xmlEscape(value string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+// windowsPlan registers the service with sc.exe. Windows services always
+// run for the whole machine, as LocalSystem. --service-name makes the server
+// answer the service manager through RunService.
+func windowsPlan(spec Spec) Plan {
+	args := append([]string{spec.Executable}, spec.Args...)
+	binPath := windowsCommandLine(append(args, "--service-name="+spec.Name))
+	delay := fmt.Sprintf("%d", restartDelaySeconds*1000)
+	return Plan{
+		Manager: "windows",
+		System:  true,
+		Install: []Command{
+			{Name: "sc.exe", Args: []string{"create", spec.Name, "binPath=", binPath, "start=", "auto", "DisplayName=", "GABS (" + spec.Name + ")"}},
+			{Name: "sc.exe", Args: []string{"description", spec.Name, "GABS - Game Agent Bridge Server"}},
+			{Name: "sc.exe", Args: []string{"failure", spec.Name, "reset=", "86400", "actions=", "restart/" + delay + "/restart/" + delay + "/restart/60000"}},
+			// Also restart after GABS exits with an error, not only after a crash
+			{Name: "sc.exe", Args: []string{"failureflag", spec.Name, "1"}},
+			{Name: "sc.exe", Args: []string{"start", spec.Name}},
+		},
+		Remove: []Command{
+			{Name: "sc.exe", Args: []string{"stop", spec.Name}},
+			{Name: "sc.exe", Args: []string{"delete", spec.Name}},
+		},
+		StatusOf: Command{Name: "sc.exe", Args: []string{"query", spec.Name}},
+	}
+}
+
+// windowsCommandLine quotes arguments the way CommandLineToArgvW splits them.
+func windowsCommandLine(args []string) string {
+	parts := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\"") {
+			parts = append(parts, arg)
+			continue
+		}
+		var b strings.Builder
+		b.WriteByte('"')
+		backslashes := 0
+		for _, r := range arg {
+			switch r {
+			case '\\':
+				backslashes++
+				continue
+			case '"':
+				b.WriteString(strings.Repeat(`\`, backslashes*2+1))
+			default:
+				b.WriteString(strings.Repeat(`\`, backslashes))
+			}
+			backslashes = 0
+			b.WriteRune(r)
+		}
+		b.WriteString(strings.Repeat(`\`, backslashes*2))
+		b.WriteByte('"')
+		parts = append(parts, b.String())
+	}
+	return strings.Join(parts, " ")
+}
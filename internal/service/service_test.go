@@ -0,0 +1,132 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testSpec(home string) Spec {
+	return Spec{
+		Name:       "gabs",
+		Executable: "/opt/gabs/gabs",
+		Args:       []string{"server", "http", "--addr=0.0.0.0:8080", "--configDir=/srv/game data"},
+		LogFile:    "/srv/gabs/logs/gabs.log",
+		Home:       home,
+	}
+}
+
+func TestSystemdUnitRestartsOnFailure(t *testing.T) {
+	plan, err := NewPlan("linux", testSpec("/home/ops"))
+	if err != nil {
+		t.Fatalf("plan failed: %v", err)
+	}
+	if plan.Path != "/home/ops/.config/systemd/user/gabs.service" {
+		t.Fatalf("unexpected unit path %s", plan.Path)
+	}
+	for _, line := range []string{
+		`ExecStart=/opt/gabs/gabs server http --addr=0.0.0.0:8080 "--configDir=/srv/game data"`,
+		"Restart=on-failure",
+		"WantedBy=default.target",
+	} {
+		if !strings.Contains(plan.File, line+"\n") {
+			t.Fatalf("unit lacks %q:\n%s", line, plan.File)
+		}
+	}
+	if got := plan.Install[1].String(); got != "systemctl --user enable --now gabs.service" {
+		t.Fatalf("unexpected enable command %s", got)
+	}
+
+	spec := testSpec("/home/ops")
+	spec.System, spec.User = true, "ops"
+	plan, _ = NewPlan("linux", spec)
+	if plan.Path != "/etc/systemd/system/gabs.service" || !strings.Contains(plan.File, "User=ops\n") || plan.Install[1].Args[0] != "enable" {
+		t.Fatalf("unexpected system unit at %s:\n%s%v", plan.Path, plan.File, plan.Install)
+	}
+}
+
+func TestLaunchdPlistKeepsGABSAlive(t *testing.T) {
+	spec := testSpec("/Users/ops")
+	spec.Args = append(spec.Args, "--log-level=<debug>")
+	plan, err := NewPlan("darwin", spec)
+	if err != nil {
+		t.Fatalf("plan failed: %v", err)
+	}
+	if plan.Path != "/Users/ops/Library/LaunchAgents/com.pardeike.gabs.plist" {
+		t.Fatalf("unexpected plist path %s", plan.Path)
+	}
+	for _, part := range []string{
+		"<string>com.pardeike.gabs</string>",
+		"<string>--log-level=&lt;debug&gt;</string>",
+		"<key>SuccessfulExit</key>\n\t\t<false/>",
+		"<string>/srv/gabs/logs/gabs.stderr.log</string>",
+	} {
+		if !strings.Contains(plan.File, part) {
+			t.Fatalf("plist lacks %q:\n%s", part, plan.File)
+		}
+	}
+}
+
+func TestWindowsServiceAnswersTheServiceManager(t *testing.T) {
+	spec := testSpec("")
+	spec.Executable = `C:\Program Files\GABS\gabs.exe`
+	plan, err := NewPlan("windows", spec)
+	if err != nil {
+		t.Fatalf("plan failed: %v", err)
+	}
+	create := plan.Install[0].Args
+	want := `"C:\Program Files\GABS\gabs.exe" server http --addr=0.0.0.0:8080 "--configDir=/srv/game data" --service-name=gabs`
+	if create[0] != "create" || create[3] != want {
+		t.Fatalf("unexpected create command %v", create)
+	}
+	if !plan.System || plan.Path != "" {
+		t.Fatalf("windows services are machine-wide and have no file: %+v", plan)
+	}
+
+	if _, err := NewPlan("plan9", spec); err == nil {
+		t.Fatal("expected an unsupported platform to be rejected")
+	}
+	spec.Name = "my gabs"
+	if _, err := NewPlan("windows", spec); err == nil {
+		t.Fatal("expected a name with a space to be rejected")
+	}
+}
+
+func TestInstallAndUninstallRunTheServiceManager(t *testing.T) {
+	home := t.TempDir()
+	var ran []string
+	Run = func(cmd Command) (string, error) {
+		ran = append(ran, cmd.String())
+		return "", nil
+	}
+	t.Cleanup(func() { Run = defaultRun })
+
+	plan, err := NewPlan("linux", testSpec(home))
+	if err != nil {
+		t.Fatalf("plan failed: %v", err)
+	}
+	if err := Install(plan); err != nil {
+		t.Fatalf("install failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".config", "systemd", "user", "gabs.service"))
+	if err != nil || string(data) != plan.File {
+		t.Fatalf("unit not written: %v", err)
+	}
+
+	if err := Uninstall(plan); err != nil {
+		t.Fatalf("uninstall failed: %v", err)
+	}
+	if _, err := os.Stat(plan.Path); !os.IsNotExist(err) {
+		t.Fatalf("unit still present: %v", err)
+	}
+	want := []string{
+		"systemctl --user daemon-reload",
+		"systemctl --user enable --now gabs.service",
+		"systemctl --user disable --now gabs.service",
+		"systemctl --user daemon-reload",
+	}
+	if strings.Join(ran, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected commands:\n%s", strings.Join(ran, "\n"))
+	}
+}
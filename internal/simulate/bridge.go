@@ -0,0 +1,354 @@
+package simulate
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	gabpruntime "github.com/pardeike/gabp-runtime/runtime"
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/util"
+	"github.com/pardeike/gabs/internal/version"
+)
+
+// GABP error codes the simulated bridge answers with.
+const (
+	errorCodeInvalidRequest = -32600
+	errorCodeMethodNotFound = -32601
+	errorCodeInvalidParams  = -32602
+	errorCodeUnauthorized   = -32001
+)
+
+// helloTimeout bounds how long a session may take to send its session/hello.
+const helloTimeout = 10 * time.Second
+
+// Bridge serves a scenario to GABS the way a game's GABP bridge would.
+type Bridge struct {
+	gameID   string
+	scenario *Scenario
+	token    string
+	log      util.Logger
+	tools    map[string]*Tool
+}
+
+// NewBridge creates a bridge that serves scenario as gameID to sessions
+// that know token.
+func NewBridge(gameID string, scenario *Scenario, token string, log util.Logger) *Bridge {
+	tools := make(map[string]*Tool, len(scenario.Tools))
+	for i := range scenario.Tools {
+		tools[scenario.Tools[i].Name] = &scenario.Tools[i]
+	}
+	return &Bridge{gameID: gameID, scenario: scenario, token: token, log: log, tools: tools}
+}
+
+// Serve answers the sessions that connect to listener until ctx ends. Each
+// GABS connection is a separate session.
+func (b *Bridge) Serve(ctx context.Context, listener net.Listener) error {
+	stop := context.AfterFunc(ctx, func() {
+		_ = listener.Close()
+	})
+	defer stop()
+
+	var sessions sync.WaitGroup
+	defer sessions.Wait()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept GABS connection: %w", err)
+		}
+		sessions.Add(1)
+		go func() {
+			defer sessions.Done()
+			b.serveSession(ctx, conn)
+		}()
+	}
+}
+
+// session is one GABS connection.
+type session struct {
+	bridge     *Bridge
+	writer     *util.LSPFrameWriter
+	ctx        context.Context
+	mu         sync.Mutex
+	subscribed map[string]bool
+	sequences  map[string]int // Last event sequence number per channel
+}
+
+func (b *Bridge) serveSession(ctx context.Context, conn net.Conn) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	reader := util.NewLSPFrameReader(conn)
+	s := &session{bridge: b, writer: util.NewLSPFrameWriter(conn), ctx: ctx, subscribed: make(map[string]bool), sequences: make(map[string]int)}
+
+	_ = conn.SetReadDeadline(time.Now().Add(helloTimeout))
+	hello, err := s.read(reader)
+	if err != nil {
+		b.log.Warnw("simulated bridge got no session/hello", "error", err)
+		return
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+	if err := s.welcome(hello); err != nil {
+		b.log.Warnw("simulated bridge rejected session", "error", err)
+		// Leave hanging up to GABS, so the refusal arrives before the EOF
+		_ = conn.SetReadDeadline(time.Now().Add(helloTimeout))
+		for {
+			if _, err := reader.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}
+	b.log.Infow("GABS session started", "gameId", b.gameID)
+
+	for {
+		request, err := s.read(reader)
+		if err != nil {
+			b.log.Infow("GABS session ended", "gameId", b.gameID, "error", err)
+			return
+		}
+		s.handle(request)
+	}
+}
+
+// read returns the next request of the session.
+func (s *session) read(reader *util.LSPFrameReader) (*util.GABPMessage, error) {
+	for {
+		data, err := reader.ReadMessage()
+		if err != nil {
+			return nil, err
+		}
+		var msg util.GABPMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			s.bridge.log.Warnw("simulated bridge got an invalid message", "error", err)
+			continue
+		}
+		if msg.Type == gabpruntime.MessageTypeRequest {
+			return &msg, nil
+		}
+	}
+}
+
+func (s *session) send(msg *util.GABPMessage) {
+	if err := s.writer.WriteJSON(msg); err != nil && s.ctx.Err() == nil {
+		s.bridge.log.Warnw("simulated bridge failed to write", "error", err)
+	}
+}
+
+// welcome answers the session/hello that opens a session.
+func (s *session) welcome(hello *util.GABPMessage) error {
+	if hello.Method != gabpruntime.MethodSessionHello {
+		s.send(util.NewGABPError(hello.ID, errorCodeInvalidRequest, "expected session/hello", nil))
+		return fmt.Errorf("session started with %q", hello.Method)
+	}
+	params, _ := hello.Params.(map[string]interface{})
+	token, _ := params["token"].(string)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.bridge.token)) != 1 {
+		s.send(util.NewGABPError(hello.ID, errorCodeUnauthorized, "invalid token", nil))
+		return errors.New("invalid token")
+	}
+
+	scenario := s.bridge.scenario
+	name := scenario.Name
+	if name == "" {
+		name = s.bridge.gameID
+	}
+	schemaVersion := scenario.SchemaVersion
+	if schemaVersion == "" {
+		schemaVersion = gabpruntime.TargetGabpSchemaVersion
+	}
+	s.send(util.NewGABPResponse(hello.ID, gabp.SessionWelcomeResult{
+		AgentID:       s.bridge.gameID,
+		App:           gabp.AppInfo{Name: name, Version: scenario.Version},
+		Capabilities:  s.bridge.capabilities(),
+		SchemaVersion: schemaVersion,
+		ServerInfo:    &gabp.ServerInfo{Name: "gabs simulate", Version: version.Get()},
+	}))
+	return nil
+}
+
+// capabilities advertises the methods, resources and event channels the
+// scenario has.
+func (b *Bridge) capabilities() gabp.Capabilities {
+	capabilities := gabp.Capabilities{
+		Methods:   []string{gabpruntime.MethodToolsList, gabpruntime.MethodToolsCall},
+		Resources: []string{},
+		Events:    []string{},
+	}
+	if len(b.scenario.Resources) > 0 {
+		capabilities.Methods = append(capabilities.Methods, gabp.ResourcesListMethod, gabp.ResourcesReadMethod)
+		for _, resource := range b.scenario.Resources {
+			capabilities.Resources = append(capabilities.Resources, resource.URI)
+		}
+	}
+	if len(b.scenario.Events) > 0 {
+		capabilities.Methods = append(capabilities.Methods, gabp.EventsSubscribeMethod)
+		seen := make(map[string]bool)
+		for _, event := range b.scenario.Events {
+			if !seen[event.Channel] {
+				seen[event.Channel] = true
+				capabilities.Events = append(capabilities.Events, event.Channel)
+			}
+		}
+		sort.Strings(capabilities.Events)
+	}
+	return capabilities
+}
+
+func (s *session) handle(request *util.GABPMessage) {
+	params, _ := request.Params.(map[string]interface{})
+	switch request.Method {
+	case gabpruntime.MethodToolsList:
+		s.send(util.NewGABPResponse(request.ID, map[string]interface{}{"tools": s.bridge.toolDescriptors()}))
+	case gabpruntime.MethodToolsCall:
+		go s.callTool(request.ID, params)
+	case gabp.ResourcesListMethod:
+		s.send(util.NewGABPResponse(request.ID, map[string]interface{}{"resources": s.bridge.resourceDescriptors()}))
+	case gabp.ResourcesReadMethod:
+		s.readResource(request.ID, params)
+	case gabp.EventsSubscribeMethod:
+		s.subscribe(request.ID, params)
+	default:
+		s.send(util.NewGABPError(request.ID, errorCodeMethodNotFound, fmt.Sprintf("method not found: %s", request.Method), nil))
+	}
+}
+
+func (b *Bridge) toolDescriptors() []gabp.ToolDescriptor {
+	tools := make([]gabp.ToolDescriptor, 0, len(b.scenario.Tools))
+	for _, tool := range b.scenario.Tools {
+		inputSchema := tool.InputSchema
+		if inputSchema == nil {
+			inputSchema = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+		}
+		tools = append(tools, gabp.ToolDescriptor{
+			Name:        tool.Name,
+			Title:       tool.Title,
+			Description: tool.Description,
+			InputSchema: inputSchema,
+			Tags:        tool.Tags,
+		})
+	}
+	return tools
+}
+
+// callTool answers a tools/call after the tool's delay. Arguments are read
+// from "arguments", or "parameters" as legacy bridges do.
+func (s *session) callTool(requestID string, params map[string]interface{}) {
+	name, _ := params["name"].(string)
+	tool, exists := s.bridge.tools[name]
+	if !exists {
+		s.send(util.NewGABPError(requestID, errorCodeMethodNotFound, fmt.Sprintf("tool not found: %s", name), nil))
+		return
+	}
+	arguments, ok := params["arguments"].(map[string]interface{})
+	if !ok {
+		arguments, _ = params["parameters"].(map[string]interface{})
+	}
+
+	if tool.Delay > 0 {
+		select {
+		case <-time.After(tool.Delay):
+		case <-s.ctx.Done():
+			return
+		}
+	}
+	result, toolErr := tool.answer(arguments)
+	if toolErr != nil {
+		s.send(util.NewGABPError(requestID, toolErr.Code, toolErr.Message, nil))
+		return
+	}
+	if result == nil {
+		result = map[string]interface{}{}
+	}
+	s.send(util.NewGABPResponse(requestID, result))
+}
+
+func (b *Bridge) resourceDescriptors() []gabp.ResourceDescriptor {
+	resources := make([]gabp.ResourceDescriptor, 0, len(b.scenario.Resources))
+	for _, resource := range b.scenario.Resources {
+		resources = append(resources, gabp.ResourceDescriptor{
+			URI:         resource.URI,
+			Name:        resource.Name,
+			Description: resource.Description,
+			MimeType:    resource.MimeType,
+		})
+	}
+	return resources
+}
+
+func (s *session) readResource(requestID string, params map[string]interface{}) {
+	uri, _ := params["uri"].(string)
+	for _, resource := range s.bridge.scenario.Resources {
+		if resource.URI == uri {
+			s.send(util.NewGABPResponse(requestID, map[string]interface{}{
+				"contents": []gabp.ResourceContent{{URI: resource.URI, MimeType: resource.MimeType, Text: resource.Text}},
+			}))
+			return
+		}
+	}
+	s.send(util.NewGABPError(requestID, errorCodeInvalidParams, fmt.Sprintf("resource not found: %s", uri), nil))
+}
+
+// subscribe starts the scenario's events on the requested channels that the
+// session did not subscribe to yet.
+func (s *session) subscribe(requestID string, params map[string]interface{}) {
+	channels, _ := params["channels"].([]interface{})
+	var subscribed []string
+	s.mu.Lock()
+	for _, value := range channels {
+		channel, _ := value.(string)
+		if channel == "" || s.subscribed[channel] {
+			continue
+		}
+		s.subscribed[channel] = true
+		subscribed = append(subscribed, channel)
+	}
+	s.mu.Unlock()
+	s.send(util.NewGABPResponse(requestID, map[string]interface{}{"channels": subscribed}))
+
+	for _, channel := range subscribed {
+		for i := range s.bridge.scenario.Events {
+			if event := &s.bridge.scenario.Events[i]; event.Channel == channel {
+				go s.emit(event)
+			}
+		}
+	}
+}
+
+// emit sends event After the subscription and then Every interval until the
+// session ends.
+func (s *session) emit(event *Event) {
+	delay := event.After
+	for {
+		select {
+		case <-time.After(delay):
+		case <-s.ctx.Done():
+			return
+		}
+		s.send(util.NewGABPEvent(event.Channel, s.nextSeq(event.Channel), event.Payload))
+		if event.Every <= 0 {
+			return
+		}
+		delay = event.Every
+	}
+}
+
+func (s *session) nextSeq(channel string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sequences[channel]++
+	return s.sequences[channel]
+}
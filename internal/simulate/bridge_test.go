@@ -0,0 +1,134 @@
+package simulate
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/gabp"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+const testScenario = `
+name: Adventure
+version: "2.1"
+tools:
+  - name: inventory/get
+    description: Read the inventory
+    result:
+      items: [sword]
+  - name: world/move
+    delay: 10ms
+    responses:
+      - arguments: {x: 1}
+        result: {moved: true}
+    error:
+      code: -32602
+      message: blocked
+resources:
+  - uri: world/save_data
+    mimeType: application/json
+    text: '{"turn": 3}'
+events:
+  - channel: world/tick
+    after: 10ms
+    payload: {turn: 4}
+`
+
+func TestBridgeServesScenarioToGABPClient(t *testing.T) {
+	scenario, err := ParseScenario([]byte(testScenario))
+	if err != nil {
+		t.Fatalf("ParseScenario failed: %v", err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go NewBridge("adventure", scenario, "token", util.NewLogger("error")).Serve(ctx, listener)
+
+	client := gabp.NewClient(util.NewLogger("error"))
+	defer client.Close()
+	connectCtx, connectCancel := context.WithTimeout(ctx, 2*time.Second)
+	defer connectCancel()
+	if err := client.Connect(connectCtx, listener.Addr().String(), "token", 10*time.Millisecond, 50*time.Millisecond); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	if info := client.GetSessionInfo(); info.App.Name != "Adventure" || info.App.Version != "2.1" {
+		t.Fatalf("expected the scenario's app in the welcome, got %#v", info)
+	}
+
+	tools, err := client.ListTools()
+	if err != nil || len(tools) != 2 || tools[0].Name != "inventory/get" {
+		t.Fatalf("expected the scenario's tools, got %#v, %v", tools, err)
+	}
+	result, _, err := client.CallTool("inventory/get", nil)
+	if err != nil || len(result["items"].([]interface{})) != 1 {
+		t.Fatalf("expected the tool's result, got %#v, %v", result, err)
+	}
+	result, _, err = client.CallTool("world/move", map[string]any{"x": 1, "y": 2})
+	if err != nil || result["moved"] != true {
+		t.Fatalf("expected the response matching the arguments, got %#v, %v", result, err)
+	}
+	if _, _, err := client.CallTool("world/move", map[string]any{"x": 2}); gabp.ErrorKind(err) != gabp.ErrorKindInvalidArgs {
+		t.Fatalf("expected the tool's error for other arguments, got %v", err)
+	}
+	if _, _, err := client.CallTool("world/jump", nil); gabp.ErrorKind(err) != gabp.ErrorKindNotFound {
+		t.Fatalf("expected an unknown tool to be not found, got %v", err)
+	}
+
+	contents, err := client.ReadResource("world/save_data", time.Second)
+	if err != nil || len(contents) != 1 || contents[0].Text != `{"turn": 3}` {
+		t.Fatalf("expected the resource text, got %#v, %v", contents, err)
+	}
+
+	events := make(chan interface{}, 1)
+	if err := client.SubscribeEvents([]string{"world/tick"}, func(channel string, seq int, payload interface{}) {
+		events <- payload
+	}); err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	select {
+	case payload := <-events:
+		if payload.(map[string]interface{})["turn"] != float64(4) {
+			t.Fatalf("unexpected event payload: %#v", payload)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the scenario's event after subscribing")
+	}
+}
+
+func TestBridgeRejectsWrongToken(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go NewBridge("adventure", &Scenario{}, "token", util.NewLogger("error")).Serve(ctx, listener)
+
+	client := gabp.NewClient(util.NewLogger("error"))
+	defer client.Close()
+	connectCtx, connectCancel := context.WithTimeout(ctx, 2*time.Second)
+	defer connectCancel()
+	if err := client.Connect(connectCtx, listener.Addr().String(), "wrong", 10*time.Millisecond, 50*time.Millisecond); err == nil || client.IsConnected() {
+		t.Fatalf("expected the handshake to be refused, got %v", err)
+	}
+}
+
+func TestParseScenarioRejectsInvalidScenarios(t *testing.T) {
+	cases := map[string]string{
+		"unknown key":    "tools:\n  - name: a\n    reslut: {}\n",
+		"duplicate tool": "tools:\n  - name: a\n  - name: a\n",
+		"unnamed tool":   "tools:\n  - description: nameless\n",
+		"resource uri":   "resources:\n  - name: Save\n",
+		"event channel":  "events:\n  - every: 1s\n",
+	}
+	for name, data := range cases {
+		if _, err := ParseScenario([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
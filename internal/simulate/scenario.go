@@ -0,0 +1,167 @@
+// Package simulate is a stand-in GABP bridge that serves the tools, resources
+// and events of a scenario file, for testing GABS and agents without the
+// game.
+package simulate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario describes the game a simulated bridge pretends to be.
+type Scenario struct {
+	Name          string     `yaml:"name"`          // App name in the welcome, defaults to the game ID
+	Version       string     `yaml:"version"`       // App version in the welcome
+	SchemaVersion string     `yaml:"schemaVersion"` // GABP schema the bridge speaks, defaults to "1.0"
+	Tools         []Tool     `yaml:"tools"`
+	Resources     []Resource `yaml:"resources"`
+	Events        []Event    `yaml:"events"`
+}
+
+// Tool is a simulated tool. A call gets the result of the first response
+// whose arguments all match the call's, or else Result or Error.
+type Tool struct {
+	Name        string                 `yaml:"name"`
+	Title       string                 `yaml:"title"`
+	Description string                 `yaml:"description"`
+	InputSchema map[string]interface{} `yaml:"inputSchema"`
+	Tags        []string               `yaml:"tags"`
+	Delay       time.Duration          `yaml:"delay"` // How long each call takes
+	Result      interface{}            `yaml:"result"`
+	Error       *ToolError             `yaml:"error"`
+	Responses   []ToolResponse         `yaml:"responses"`
+}
+
+// ToolResponse answers the calls of a tool that pass at least Arguments.
+type ToolResponse struct {
+	Arguments map[string]interface{} `yaml:"arguments"`
+	Result    interface{}            `yaml:"result"`
+	Error     *ToolError             `yaml:"error"`
+}
+
+// ToolError makes a call fail with a GABP error.
+type ToolError struct {
+	Code    int    `yaml:"code"`
+	Message string `yaml:"message"`
+}
+
+// Resource is a simulated resource with fixed Text.
+type Resource struct {
+	URI         string `yaml:"uri"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	MimeType    string `yaml:"mimeType"`
+	Text        string `yaml:"text"`
+}
+
+// Event is sent on Channel to subscribed sessions: once After the
+// subscription, and then Every interval if set.
+type Event struct {
+	Channel string        `yaml:"channel"`
+	After   time.Duration `yaml:"after"`
+	Every   time.Duration `yaml:"every"`
+	Payload interface{}   `yaml:"payload"`
+}
+
+// LoadScenario reads and validates the YAML scenario at path.
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+	return ParseScenario(data)
+}
+
+// ParseScenario decodes and validates a YAML scenario. Unknown keys are
+// rejected so typos do not go unnoticed.
+func ParseScenario(data []byte) (*Scenario, error) {
+	var scenario Scenario
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&scenario); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid scenario: %w", err)
+	}
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+	return &scenario, nil
+}
+
+// Validate checks that tools, resources and events are named and unique.
+func (s *Scenario) Validate() error {
+	toolNames := make(map[string]bool)
+	for i, tool := range s.Tools {
+		if strings.TrimSpace(tool.Name) == "" {
+			return fmt.Errorf("tool %d has no name", i+1)
+		}
+		if toolNames[tool.Name] {
+			return fmt.Errorf("tool %q is listed twice", tool.Name)
+		}
+		toolNames[tool.Name] = true
+		if tool.Delay < 0 {
+			return fmt.Errorf("tool %q has a negative delay", tool.Name)
+		}
+	}
+	resourceURIs := make(map[string]bool)
+	for i, resource := range s.Resources {
+		if strings.TrimSpace(resource.URI) == "" {
+			return fmt.Errorf("resource %d has no uri", i+1)
+		}
+		if resourceURIs[resource.URI] {
+			return fmt.Errorf("resource %q is listed twice", resource.URI)
+		}
+		resourceURIs[resource.URI] = true
+	}
+	for i, event := range s.Events {
+		if strings.TrimSpace(event.Channel) == "" {
+			return fmt.Errorf("event %d has no channel", i+1)
+		}
+		if event.After < 0 || event.Every < 0 {
+			return fmt.Errorf("event on %q has a negative after or every", event.Channel)
+		}
+	}
+	return nil
+}
+
+// answer returns the result or error of a call to tool with arguments.
+func (t *Tool) answer(arguments map[string]interface{}) (interface{}, *ToolError) {
+	for _, response := range t.Responses {
+		if argumentsMatch(response.Arguments, arguments) {
+			return response.Result, response.Error
+		}
+	}
+	return t.Result, t.Error
+}
+
+// argumentsMatch reports whether arguments hold every value of want. Values
+// are compared as JSON, so 1 in the scenario matches 1.0 in a call.
+func argumentsMatch(want, arguments map[string]interface{}) bool {
+	for key, value := range want {
+		got, exists := arguments[key]
+		if !exists || !reflect.DeepEqual(asJSON(value), asJSON(got)) {
+			return false
+		}
+	}
+	return true
+}
+
+func asJSON(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return value
+	}
+	return decoded
+}
@@ -33,7 +33,17 @@ type App struct {
 	AppIDFilePath string
 }
 
+// ScanProgress is called by ResolveAppWithProgress after each Steam library
+// has been checked for the app manifest.
+type ScanProgress func(checked, total int, library string)
+
 func ResolveApp(appID string) (App, error) {
+	return ResolveAppWithProgress(appID, nil)
+}
+
+// ResolveAppWithProgress resolves appID like ResolveApp and reports each
+// scanned library to progress, which may be nil.
+func ResolveAppWithProgress(appID string, progress ScanProgress) (App, error) {
 	appID = strings.TrimSpace(appID)
 	if appID == "" {
 		return App{}, errors.New("Steam app id is required")
@@ -48,11 +58,15 @@ func ResolveApp(appID string) (App, error) {
 	}
 
 	var checked []string
-	for _, library := range libraries {
+	for i, library := range libraries {
 		steamapps := steamappsPath(library)
 		manifestPath := filepath.Join(steamapps, fmt.Sprintf("appmanifest_%s.acf", appID))
 		checked = append(checked, manifestPath)
-		if _, err := os.Stat(manifestPath); err != nil {
+		_, statErr := os.Stat(manifestPath)
+		if progress != nil {
+			progress(i+1, len(libraries), library)
+		}
+		if statErr != nil {
 			continue
 		}
 
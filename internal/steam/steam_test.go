@@ -1,6 +1,7 @@
 package steam
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -267,3 +268,31 @@ func mustWrite(t *testing.T, path string, content string) {
 		t.Fatal(err)
 	}
 }
+
+func TestResolveAppWithProgressReportsEachLibrary(t *testing.T) {
+	tempDir := t.TempDir()
+	first := filepath.Join(tempDir, "SteamA")
+	second := filepath.Join(tempDir, "SteamB")
+	vdf := filepath.Join(first, "steamapps", "libraryfolders.vdf")
+
+	mustWrite(t, vdf, `
+		"libraryfolders"
+		{
+			"0" { "path" "`+first+`" }
+			"1" { "path" "`+second+`" }
+		}
+	`)
+	t.Setenv("GABS_STEAM_LIBRARYFOLDERS", vdf)
+
+	var reported []string
+	_, err := ResolveAppWithProgress("123456", func(checked, total int, library string) {
+		reported = append(reported, fmt.Sprintf("%d/%d %s", checked, total, library))
+	})
+	if err == nil {
+		t.Fatal("expected missing app error")
+	}
+	want := []string{"1/2 " + first, "2/2 " + second}
+	if strings.Join(reported, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected progress reports %q, want %q", reported, want)
+	}
+}
@@ -0,0 +1,225 @@
+// Package tunnel opens SSH port-forwards so GABS can reach GABP bridges that
+// run on another machine. It drives the system ssh client instead of linking
+// an SSH implementation, so existing keys, agents and ~/.ssh/config entries
+// keep working.
+package tunnel
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+const (
+	defaultRemoteHost = "127.0.0.1"
+	readyPollInterval = 50 * time.Millisecond
+	defaultReadyWait  = 15 * time.Second
+)
+
+// sshCommand returns the program and arguments used to run ssh. Tests replace
+// it to avoid depending on a real SSH server.
+var sshCommand = func(args []string) (string, []string) {
+	return "ssh", args
+}
+
+// SetSSHCommandForTesting replaces the ssh command and returns a restore func.
+func SetSSHCommandForTesting(fn func(args []string) (string, []string)) func() {
+	previous := sshCommand
+	sshCommand = fn
+	return func() {
+		sshCommand = previous
+	}
+}
+
+// Tunnel is a running SSH port-forward from a local port to a remote bridge.
+type Tunnel struct {
+	localPort  int
+	remotePort int
+	cmd        *exec.Cmd
+	done       chan struct{}
+	closeOnce  sync.Once
+	log        util.Logger
+
+	mu      sync.Mutex
+	waitErr error
+}
+
+// Args builds the ssh arguments that forward localPort to the bridge port on
+// the remote side.
+func Args(cfg config.SSHTunnelConfig, localPort, remotePort int) []string {
+	remoteHost := cfg.RemoteHost
+	if remoteHost == "" {
+		remoteHost = defaultRemoteHost
+	}
+	args := []string{
+		"-N",
+		"-o", "ExitOnForwardFailure=yes",
+		"-o", "BatchMode=yes",
+		"-o", "ServerAliveInterval=15",
+		"-L", fmt.Sprintf("127.0.0.1:%d:%s:%d", localPort, remoteHost, remotePort),
+	}
+	if cfg.Port > 0 {
+		args = append(args, "-p", strconv.Itoa(cfg.Port))
+	}
+	if cfg.IdentityFile != "" {
+		args = append(args, "-i", cfg.IdentityFile)
+	}
+	destination := cfg.Host
+	if cfg.User != "" {
+		destination = cfg.User + "@" + cfg.Host
+	}
+	// "--" ends option parsing so the destination can never be read as an
+	// ssh option such as -oProxyCommand.
+	return append(args, "--", destination)
+}
+
+// Open starts ssh and waits until the local end of the forward accepts
+// connections. remotePort is used unless the config sets its own RemotePort.
+func Open(ctx context.Context, cfg config.SSHTunnelConfig, remotePort int, log util.Logger) (*Tunnel, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.RemotePort > 0 {
+		remotePort = cfg.RemotePort
+	}
+	if remotePort <= 0 {
+		return nil, fmt.Errorf("no remote GABP port known for SSH tunnel to %s", cfg.Host)
+	}
+
+	localPort, err := freeLocalPort()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve local tunnel port: %w", err)
+	}
+
+	name, args := sshCommand(Args(cfg, localPort, remotePort))
+	cmd := exec.Command(name, args...)
+	var stderr strings.Builder
+	cmd.Stderr = &limitedWriter{w: &stderr, remaining: 4096}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start ssh: %w", err)
+	}
+
+	t := &Tunnel{
+		localPort:  localPort,
+		remotePort: remotePort,
+		cmd:        cmd,
+		done:       make(chan struct{}),
+		log:        log,
+	}
+	go func() {
+		err := cmd.Wait()
+		t.mu.Lock()
+		t.waitErr = err
+		t.mu.Unlock()
+		close(t.done)
+	}()
+
+	readyCtx := ctx
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		readyCtx, cancel = context.WithTimeout(ctx, defaultReadyWait)
+		defer cancel()
+	}
+	if err := t.waitReady(readyCtx); err != nil {
+		t.Close()
+		<-t.done // ssh's stderr is complete once Wait returns
+		if detail := strings.TrimSpace(stderr.String()); detail != "" {
+			err = fmt.Errorf("%w: %s", err, detail)
+		}
+		return nil, err
+	}
+
+	log.Infow("SSH tunnel established", "host", cfg.Host, "localPort", localPort, "remotePort", remotePort)
+	return t, nil
+}
+
+func (t *Tunnel) waitReady(ctx context.Context) error {
+	addr := t.LocalAddr()
+	for {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		select {
+		case <-t.done:
+			t.mu.Lock()
+			waitErr := t.waitErr
+			t.mu.Unlock()
+			if waitErr == nil {
+				return fmt.Errorf("ssh exited before the tunnel was ready")
+			}
+			return fmt.Errorf("ssh exited before the tunnel was ready: %w", waitErr)
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for SSH tunnel on %s: %w", addr, ctx.Err())
+		case <-time.After(readyPollInterval):
+		}
+	}
+}
+
+// LocalAddr returns the local address that forwards to the remote bridge.
+func (t *Tunnel) LocalAddr() string {
+	return fmt.Sprintf("127.0.0.1:%d", t.localPort)
+}
+
+// RemotePort returns the bridge port the tunnel forwards to.
+func (t *Tunnel) RemotePort() int {
+	return t.remotePort
+}
+
+// Alive reports whether the ssh process is still running.
+func (t *Tunnel) Alive() bool {
+	select {
+	case <-t.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Close stops the ssh process. It does not wait for the process to exit.
+func (t *Tunnel) Close() {
+	t.closeOnce.Do(func() {
+		if t.Alive() && t.cmd.Process != nil {
+			if err := t.cmd.Process.Kill(); err != nil {
+				t.log.Debugw("failed to stop ssh tunnel", "localPort", t.localPort, "error", err)
+			}
+		}
+	})
+}
+
+func freeLocalPort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// limitedWriter keeps the first bytes of ssh's stderr for error messages
+// without growing without bound for a long-lived tunnel.
+type limitedWriter struct {
+	w         *strings.Builder
+	remaining int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.remaining > 0 {
+		n := len(p)
+		if n > l.remaining {
+			n = l.remaining
+		}
+		l.w.Write(p[:n])
+		l.remaining -= n
+	}
+	return len(p), nil
+}
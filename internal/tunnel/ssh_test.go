@@ -0,0 +1,203 @@
+package tunnel
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pardeike/gabs/internal/config"
+	"github.com/pardeike/gabs/internal/util"
+)
+
+func TestArgs(t *testing.T) {
+	args := Args(config.SSHTunnelConfig{
+		Host:         "games.example.net",
+		User:         "deploy",
+		Port:         2222,
+		IdentityFile: "/keys/id_ed25519",
+	}, 40001, 39000)
+
+	got := strings.Join(args, " ")
+	for _, want := range []string{
+		"-N",
+		"-o ExitOnForwardFailure=yes",
+		"-o BatchMode=yes",
+		"-L 127.0.0.1:40001:127.0.0.1:39000",
+		"-p 2222",
+		"-i /keys/id_ed25519",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in ssh args %q", want, got)
+		}
+	}
+	if args[len(args)-1] != "deploy@games.example.net" || args[len(args)-2] != "--" {
+		t.Errorf("expected \"--\" and then the destination last, got %q", args[len(args)-2:])
+	}
+
+	args = Args(config.SSHTunnelConfig{Host: "games.example.net", RemoteHost: "10.0.0.5"}, 40001, 39000)
+	got = strings.Join(args, " ")
+	if !strings.Contains(got, "-L 127.0.0.1:40001:10.0.0.5:39000") || strings.Contains(got, "-p ") || strings.Contains(got, "-i ") {
+		t.Errorf("unexpected ssh args for minimal config: %q", got)
+	}
+}
+
+func useHelperSSH(t *testing.T, mode string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses current test binary as ssh helper")
+	}
+	t.Cleanup(SetSSHCommandForTesting(func(args []string) (string, []string) {
+		return os.Args[0], append([]string{"-test.run=TestSSHHelperProcess", "--", mode}, args...)
+	}))
+}
+
+func TestOpenForwardsToRemotePort(t *testing.T) {
+	useHelperSSH(t, "forward")
+
+	remote, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer remote.Close()
+	go func() {
+		for {
+			conn, err := remote.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	tun, err := Open(ctx, config.SSHTunnelConfig{Host: "games.example.net"}, remote.Addr().(*net.TCPAddr).Port, util.NewLogger("error"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer tun.Close()
+
+	conn, err := net.Dial("tcp", tun.LocalAddr())
+	if err != nil {
+		t.Fatalf("dial tunnel: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	fmt.Fprintln(conn, "ping")
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil || line != "ping\n" {
+		t.Fatalf("expected echo through tunnel, got %q %v", line, err)
+	}
+
+	tun.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for tun.Alive() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected ssh to exit after Close")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestOpenReportsSSHFailure(t *testing.T) {
+	useHelperSSH(t, "fail")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := Open(ctx, config.SSHTunnelConfig{Host: "games.example.net"}, 39000, util.NewLogger("error"))
+	if err == nil {
+		t.Fatal("expected Open to fail when ssh exits")
+	}
+	if !strings.Contains(err.Error(), "Permission denied (publickey)") {
+		t.Fatalf("expected ssh stderr in error, got %v", err)
+	}
+}
+
+func TestOpenRequiresRemotePort(t *testing.T) {
+	if _, err := Open(context.Background(), config.SSHTunnelConfig{Host: "games.example.net"}, 0, util.NewLogger("error")); err == nil {
+		t.Fatal("expected an error without a remote port")
+	}
+}
+
+func TestOpenRejectsOptionLikeHostAndUser(t *testing.T) {
+	t.Cleanup(SetSSHCommandForTesting(func(args []string) (string, []string) {
+		t.Errorf("ssh must not be started for an option-like destination: %q", args)
+		return "false", nil
+	}))
+
+	for _, cfg := range []config.SSHTunnelConfig{
+		{Host: "-oProxyCommand=touch /tmp/pwned"},
+		{Host: "games.example.net", User: "-oProxyCommand=touch /tmp/pwned"},
+	} {
+		_, err := Open(context.Background(), cfg, 39000, util.NewLogger("error"))
+		if err == nil || !strings.Contains(err.Error(), "must not start with '-'") {
+			t.Errorf("expected %+v to be rejected, got %v", cfg, err)
+		}
+	}
+}
+
+// TestSSHHelperProcess stands in for ssh. In forward mode it serves the -L
+// forward itself; in fail mode it exits like ssh does on an auth failure.
+func TestSSHHelperProcess(t *testing.T) {
+	args := os.Args
+	separator := -1
+	for i, arg := range args {
+		if arg == "--" {
+			separator = i
+			break
+		}
+	}
+	if separator == -1 {
+		return
+	}
+	args = args[separator+1:]
+
+	if args[0] == "fail" {
+		fmt.Fprintln(os.Stderr, "deploy@games.example.net: Permission denied (publickey).")
+		os.Exit(255)
+	}
+
+	var forward string
+	for i := 1; i < len(args)-1; i++ {
+		if args[i] == "-L" {
+			forward = args[i+1]
+		}
+	}
+	parts := strings.Split(forward, ":")
+	if len(parts) != 4 {
+		fmt.Fprintf(os.Stderr, "bad forward spec %q\n", forward)
+		os.Exit(2)
+	}
+	listener, err := net.Listen("tcp", parts[0]+":"+parts[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(255)
+	}
+	target := parts[2] + ":" + parts[3]
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			os.Exit(0)
+		}
+		go func() {
+			defer conn.Close()
+			upstream, err := net.Dial("tcp", target)
+			if err != nil {
+				return
+			}
+			defer upstream.Close()
+			go io.Copy(upstream, conn)
+			io.Copy(conn, upstream)
+		}()
+	}
+}
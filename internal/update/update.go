@@ -0,0 +1,354 @@
+// Package update finds newer GABS releases on GitHub, downloads the archive
+// for this platform, checks it against its published SHA-256 and swaps the
+// running binary for the new one.
+package update
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Release channels. Stable only takes full releases; beta also takes
+// prereleases such as v1.2.0-rc.1.
+const (
+	ChannelStable = "stable"
+	ChannelBeta   = "beta"
+)
+
+// Channels lists the valid release channels.
+var Channels = []string{ChannelStable, ChannelBeta}
+
+// DefaultAPIURL is the GitHub API the releases are read from.
+const DefaultAPIURL = "https://api.github.com/repos/pardeike/GABS"
+
+// ErrNoRelease is returned by Latest when no release matches the channel.
+var ErrNoRelease = errors.New("no release found")
+
+// Release is one GitHub release of GABS.
+type Release struct {
+	Tag        string  `json:"tag_name"`
+	Draft      bool    `json:"draft"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset is one file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Client reads releases from APIURL.
+type Client struct {
+	HTTP   *http.Client
+	APIURL string
+	// Progress, if set, is told how many bytes of the release archive
+	// Download has received. total is -1 when the server does not say.
+	Progress func(done, total int64)
+}
+
+// ValidateChannel reports an error for an unknown release channel.
+func ValidateChannel(channel string) error {
+	for _, valid := range Channels {
+		if channel == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("channel '%s' must be one of: %s", channel, strings.Join(Channels, ", "))
+}
+
+// Latest returns the highest release on channel.
+func (c *Client) Latest(ctx context.Context, channel string) (Release, error) {
+	var releases []Release
+	if err := c.getJSON(ctx, c.APIURL+"/releases?per_page=30", &releases); err != nil {
+		return Release{}, err
+	}
+	var latest Release
+	found := false
+	for _, release := range releases {
+		if release.Draft || (release.Prerelease && channel != ChannelBeta) {
+			continue
+		}
+		if _, ok := parseVersion(release.Tag); !ok {
+			continue
+		}
+		if !found || Newer(latest.Tag, release.Tag) {
+			latest, found = release, true
+		}
+	}
+	if !found {
+		return Release{}, fmt.Errorf("%w on the %s channel", ErrNoRelease, channel)
+	}
+	return latest, nil
+}
+
+// ArchiveName is the release archive for a platform, as the release
+// workflow names it.
+func ArchiveName(tag, goos, goarch string) string {
+	return fmt.Sprintf("gabs-%s-%s-%s.zip", tag, goos, goarch)
+}
+
+// Download fetches the release archive for goos/goarch, verifies it against
+// its .sha256 asset and writes the gabs binary in it to dst.
+func (c *Client) Download(ctx context.Context, release Release, goos, goarch, dst string) error {
+	name := ArchiveName(release.Tag, goos, goarch)
+	archiveAsset, ok := release.asset(name)
+	if !ok {
+		return fmt.Errorf("release %s has no archive for %s/%s", release.Tag, goos, goarch)
+	}
+	sumAsset, ok := release.asset(name + ".sha256")
+	if !ok {
+		return fmt.Errorf("release %s publishes no checksum for %s, so it cannot be verified", release.Tag, name)
+	}
+
+	sumFile, err := c.get(ctx, sumAsset.URL)
+	if err != nil {
+		return err
+	}
+	want, err := parseChecksum(sumFile)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", sumAsset.Name, err)
+	}
+
+	archive, err := os.CreateTemp(filepath.Dir(dst), ".gabs-update-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archive.Name())
+	got, err := c.fetch(ctx, archiveAsset.URL, archive, c.Progress)
+	if closeErr := archive.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("checksum of %s is %s, expected %s", name, got, want)
+	}
+
+	binary := "gabs"
+	if goos == "windows" {
+		binary += ".exe"
+	}
+	return extractBinary(archive.Name(), strings.TrimSuffix(name, ".zip")+"/"+binary, dst)
+}
+
+// Replace swaps the binary at exe for newBinary, which must be in the same
+// directory. On Windows the running binary cannot be overwritten, so it is
+// moved aside to exe.old first.
+func Replace(exe, newBinary string) error {
+	if runtime.GOOS != "windows" {
+		return os.Rename(newBinary, exe)
+	}
+	old := exe + ".old"
+	os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		return err
+	}
+	if err := os.Rename(newBinary, exe); err != nil {
+		// Put the old binary back
+		os.Rename(old, exe)
+		return err
+	}
+	return nil
+}
+
+// Newer reports whether version b is newer than version a. Both may have a
+// leading "v". A prerelease is older than the release it leads up to.
+func Newer(a, b string) bool {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	if !okA || !okB {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		if va.parts[i] != vb.parts[i] {
+			return vb.parts[i] > va.parts[i]
+		}
+	}
+	switch {
+	case va.pre == vb.pre:
+		return false
+	case vb.pre == "":
+		return true
+	case va.pre == "":
+		return false
+	default:
+		return comparePrerelease(va.pre, vb.pre) < 0
+	}
+}
+
+type semver struct {
+	parts [3]int
+	pre   string
+}
+
+func parseVersion(value string) (semver, bool) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "v")
+	value, _, _ = strings.Cut(value, "+")
+	core, pre, _ := strings.Cut(value, "-")
+	fields := strings.Split(core, ".")
+	if len(fields) != 3 {
+		return semver{}, false
+	}
+	var v semver
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return semver{}, false
+		}
+		v.parts[i] = n
+	}
+	v.pre = pre
+	return v, true
+}
+
+// comparePrerelease orders prerelease identifiers such as rc.1 and rc.10 as
+// semantic versioning does: numeric identifiers by value, others by text.
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		na, errA := strconv.Atoi(as[i])
+		nb, errB := strconv.Atoi(bs[i])
+		switch {
+		case errA == nil && errB == nil:
+			if na != nb {
+				if na < nb {
+					return -1
+				}
+				return 1
+			}
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return len(as) - len(bs)
+}
+
+func (r Release) asset(name string) (Asset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// parseChecksum reads the first field of a sha256sum line.
+func parseChecksum(data []byte) (string, error) {
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", errors.New("empty checksum file")
+	}
+	sum := strings.ToLower(fields[0])
+	if decoded, err := hex.DecodeString(sum); err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("'%s' is not a SHA-256 checksum", fields[0])
+	}
+	return sum, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, url string, v interface{}) error {
+	data, err := c.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	var buf strings.Builder
+	if _, err := c.fetch(ctx, url, &buf, nil); err != nil {
+		return nil, err
+	}
+	return []byte(buf.String()), nil
+}
+
+// fetch writes the body at url to w and returns its hex SHA-256.
+func (c *Client) fetch(ctx context.Context, url string, w io.Writer, progress func(done, total int64)) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s answered %s", url, resp.Status)
+	}
+	hash := sha256.New()
+	w = io.MultiWriter(w, hash)
+	if progress != nil {
+		w = &progressWriter{w: w, total: resp.ContentLength, report: progress}
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// progressWriter reports how many bytes went through it after each write.
+type progressWriter struct {
+	w      io.Writer
+	done   int64
+	total  int64
+	report func(done, total int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+	p.report(p.done, p.total)
+	return n, err
+}
+
+func extractBinary(archivePath, name, dst string) error {
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+	for _, file := range archive.File {
+		if path.Clean(file.Name) != name {
+			continue
+		}
+		in, err := file.Open()
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+		return os.Chmod(dst, 0755)
+	}
+	return fmt.Errorf("archive has no %s", name)
+}
@@ -0,0 +1,127 @@
+package update
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// releaseServerForTest serves releases and an archive for linux/amd64 of
+// each release holding a gabs binary with the tag as content. checksum
+// overrides the published checksum when set.
+func releaseServerForTest(t *testing.T, releases []Release, checksum string) *httptest.Server {
+	t.Helper()
+	archives := make(map[string][]byte)
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	for i, release := range releases {
+		name := ArchiveName(release.Tag, "linux", "amd64")
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		entry, _ := w.Create(strings.TrimSuffix(name, ".zip") + "/gabs")
+		entry.Write([]byte(release.Tag))
+		w.Close()
+		archives[name] = buf.Bytes()
+		sum := sha256.Sum256(buf.Bytes())
+		archives[name+".sha256"] = []byte(hex.EncodeToString(sum[:]) + "  " + name + "\n")
+		if checksum != "" {
+			archives[name+".sha256"] = []byte(checksum)
+		}
+		releases[i].Assets = []Asset{
+			{Name: name, URL: server.URL + "/download/" + name},
+			{Name: name + ".sha256", URL: server.URL + "/download/" + name + ".sha256"},
+		}
+	}
+	mux.HandleFunc("/releases", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(releases)
+	})
+	mux.HandleFunc("/download/", func(w http.ResponseWriter, r *http.Request) {
+		data, ok := archives[strings.TrimPrefix(r.URL.Path, "/download/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	})
+	return server
+}
+
+func TestNewerOrdersReleasesAndPrereleases(t *testing.T) {
+	for _, tc := range []struct {
+		a, b  string
+		newer bool
+	}{
+		{"1.0.8", "v1.0.9", true},
+		{"1.0.9", "v1.0.9", false},
+		{"1.0.10", "v1.0.9", false},
+		{"1.0.9", "v1.1.0-rc.1", true},
+		{"1.1.0-rc.1", "v1.1.0", true},
+		{"1.1.0", "v1.1.0-rc.2", false},
+		{"1.1.0-rc.2", "v1.1.0-rc.10", true},
+		{"dev", "v1.1.0", false},
+	} {
+		if got := Newer(tc.a, tc.b); got != tc.newer {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.newer)
+		}
+	}
+}
+
+func TestLatestFollowsChannel(t *testing.T) {
+	server := releaseServerForTest(t, []Release{
+		{Tag: "v1.2.0-rc.1", Prerelease: true},
+		{Tag: "v1.1.0"},
+		{Tag: "v1.3.0", Draft: true},
+		{Tag: "v1.0.9"},
+	}, "")
+	client := &Client{HTTP: server.Client(), APIURL: server.URL}
+
+	stable, err := client.Latest(context.Background(), ChannelStable)
+	if err != nil || stable.Tag != "v1.1.0" {
+		t.Fatalf("expected v1.1.0 on stable, got %q, %v", stable.Tag, err)
+	}
+	beta, err := client.Latest(context.Background(), ChannelBeta)
+	if err != nil || beta.Tag != "v1.2.0-rc.1" {
+		t.Fatalf("expected v1.2.0-rc.1 on beta, got %q, %v", beta.Tag, err)
+	}
+}
+
+func TestDownloadVerifiesChecksum(t *testing.T) {
+	releases := []Release{{Tag: "v1.1.0"}}
+	server := releaseServerForTest(t, releases, "")
+	client := &Client{HTTP: server.Client(), APIURL: server.URL}
+	release, err := client.Latest(context.Background(), ChannelStable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var received, size int64
+	client.Progress = func(done, total int64) { received, size = done, total }
+	dst := filepath.Join(t.TempDir(), "gabs")
+	if err := client.Download(context.Background(), release, "linux", "amd64", dst); err != nil {
+		t.Fatalf("download: %v", err)
+	}
+	if data, _ := os.ReadFile(dst); string(data) != "v1.1.0" {
+		t.Fatalf("expected the binary from the archive, got %q", data)
+	}
+	if received == 0 || received != size {
+		t.Fatalf("expected progress up to the archive size, got %d of %d", received, size)
+	}
+
+	tampered := releaseServerForTest(t, []Release{{Tag: "v1.1.0"}}, strings.Repeat("0", 64))
+	client = &Client{HTTP: tampered.Client(), APIURL: tampered.URL}
+	release, _ = client.Latest(context.Background(), ChannelStable)
+	err = client.Download(context.Background(), release, "linux", "amd64", dst)
+	if err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Fatalf("expected a checksum mismatch, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClockFiresTimersOnlyWhenAdvanced(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	timer := clock.After(5 * time.Second)
+	clock.Advance(4 * time.Second)
+	select {
+	case <-timer:
+		t.Fatal("timer fired before its deadline")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case fired := <-timer:
+		if !fired.Equal(start.Add(5 * time.Second)) {
+			t.Fatalf("unexpected fire time %v", fired)
+		}
+	default:
+		t.Fatal("timer did not fire at its deadline")
+	}
+	if clock.Pending() != 0 {
+		t.Fatalf("expected no pending timers, got %d", clock.Pending())
+	}
+}
+
+func TestFakeClockTickerRepeatsUntilStopped(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ticker := clock.NewTicker(time.Second)
+
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Second)
+		select {
+		case <-ticker.C():
+		default:
+			t.Fatalf("tick %d did not fire", i+1)
+		}
+	}
+
+	ticker.Stop()
+	if clock.Pending() != 0 {
+		t.Fatalf("expected stopped ticker to be removed, got %d pending", clock.Pending())
+	}
+}
+
+func TestFakeClockSleepAndBlockUntil(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	done := make(chan struct{})
+	go func() {
+		clock.Sleep(time.Minute)
+		close(done)
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Sleep did not return after Advance")
+	}
+	if got := clock.Now(); !got.Equal(time.Unix(60, 0)) {
+		t.Fatalf("unexpected fake time %v", got)
+	}
+}
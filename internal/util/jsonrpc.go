@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 )
@@ -110,8 +111,76 @@ func NewGABPEvent(channel string, seq int, payload interface{}) *GABPMessage {
 	}
 }
 
+// DefaultMaxMessageSize is the largest message frame readers accept unless
+// they are given a limit of their own.
+const DefaultMaxMessageSize = 10 * 1024 * 1024
+
+// messageHeadSize is how much of a rejected message MessageTooLargeError
+// keeps, enough to find out what the message was.
+const messageHeadSize = 4096
+
+// maxHeaderLineSize bounds a Content-Length frame's header lines.
+const maxHeaderLineSize = 4096
+
+// MessageTooLargeError is returned for a message larger than the reader's or
+// writer's limit. Readers skip the rest of the message, so the next one can
+// still be read.
+type MessageTooLargeError struct {
+	Size  int    // Size of the message in bytes
+	Limit int    // Largest size allowed
+	Head  []byte // Start of the message
+}
+
+func (e *MessageTooLargeError) Error() string {
+	return fmt.Sprintf("message of %d bytes exceeds the %d byte limit", e.Size, e.Limit)
+}
+
+// messageSizeLimit is a reader's size limit, changeable while another
+// goroutine reads.
+type messageSizeLimit struct {
+	size atomic.Int64
+}
+
+// SetMaxMessageSize limits messages to size bytes; 0 or less restores
+// DefaultMaxMessageSize.
+func (l *messageSizeLimit) SetMaxMessageSize(size int) {
+	l.size.Store(int64(size))
+}
+
+// MaxMessageSize returns the largest message size accepted.
+func (l *messageSizeLimit) MaxMessageSize() int {
+	if size := int(l.size.Load()); size > 0 {
+		return size
+	}
+	return DefaultMaxMessageSize
+}
+
+// LeadingMessageID returns the raw "id" member of a JSON object from the
+// first bytes of it, so a message that was cut short can still be answered.
+func LeadingMessageID(head []byte) (json.RawMessage, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(head))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, false
+	}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, false
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, false
+		}
+		if key == "id" {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
 // LSPFrameReader reads LSP-framed messages (Content-Length header)
 type LSPFrameReader struct {
+	messageSizeLimit
 	reader *bufio.Reader
 }
 
@@ -122,44 +191,59 @@ func NewLSPFrameReader(r io.Reader) *LSPFrameReader {
 	}
 }
 
-// ReadMessage reads one LSP-framed message
+// ReadMessage reads one LSP-framed message. A message larger than
+// MaxMessageSize is skipped and reported as a *MessageTooLargeError.
 func (r *LSPFrameReader) ReadMessage() ([]byte, error) {
-	var contentLength int
+	contentLength := -1
 
 	// Read headers until empty line
 	for {
-		line, err := r.reader.ReadString('\n')
+		line, err := r.reader.ReadSlice('\n')
+		if err == bufio.ErrBufferFull || len(line) > maxHeaderLineSize {
+			return nil, fmt.Errorf("header line longer than %d bytes", maxHeaderLineSize)
+		}
 		if err != nil {
 			return nil, err
 		}
 
 		// Remove \r\n or \n
-		line = strings.TrimSuffix(line, "\r\n")
-		line = strings.TrimSuffix(line, "\n")
+		header := strings.TrimSuffix(string(line), "\n")
+		header = strings.TrimSuffix(header, "\r")
 
 		// Empty line indicates end of headers
-		if line == "" {
+		if header == "" {
 			break
 		}
 
 		// Parse Content-Length header
-		if strings.HasPrefix(line, "Content-Length:") {
-			parts := strings.SplitN(line, ":", 2)
+		if strings.HasPrefix(header, "Content-Length:") {
+			parts := strings.SplitN(header, ":", 2)
 			if len(parts) != 2 {
-				return nil, fmt.Errorf("invalid Content-Length header: %s", line)
+				return nil, fmt.Errorf("invalid Content-Length header: %s", header)
 			}
 			length, err := strconv.Atoi(strings.TrimSpace(parts[1]))
-			if err != nil {
+			if err != nil || length < 0 {
 				return nil, fmt.Errorf("invalid Content-Length value: %s", parts[1])
 			}
 			contentLength = length
 		}
 	}
 
-	if contentLength == 0 {
+	if contentLength <= 0 {
 		return nil, fmt.Errorf("missing Content-Length header")
 	}
 
+	if limit := r.MaxMessageSize(); contentLength > limit {
+		head := make([]byte, min(contentLength, messageHeadSize))
+		if _, err := io.ReadFull(r.reader, head); err != nil {
+			return nil, err
+		}
+		if _, err := io.CopyN(io.Discard, r.reader, int64(contentLength-len(head))); err != nil {
+			return nil, err
+		}
+		return nil, &MessageTooLargeError{Size: contentLength, Limit: limit, Head: head}
+	}
+
 	// Read the message body
 	body := make([]byte, contentLength)
 	_, err := io.ReadFull(r.reader, body)
@@ -172,8 +256,9 @@ func (r *LSPFrameReader) ReadMessage() ([]byte, error) {
 
 // LSPFrameWriter writes LSP-framed messages
 type LSPFrameWriter struct {
-	writer io.Writer
-	mu     sync.Mutex
+	writer  io.Writer
+	mu      sync.Mutex
+	maxSize int // Largest message WriteMessage sends, 0 for no limit
 }
 
 // NewLSPFrameWriter creates a new LSP frame writer
@@ -181,11 +266,24 @@ func NewLSPFrameWriter(w io.Writer) *LSPFrameWriter {
 	return &LSPFrameWriter{writer: w}
 }
 
+// SetMaxMessageSize makes WriteMessage refuse messages larger than size
+// bytes with a *MessageTooLargeError, such as when the peer announced the
+// largest message it accepts. 0 or less removes the limit.
+func (w *LSPFrameWriter) SetMaxMessageSize(size int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.maxSize = size
+}
+
 // WriteMessage writes a message with LSP framing
 func (w *LSPFrameWriter) WriteMessage(data []byte) error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
+	if w.maxSize > 0 && len(data) > w.maxSize {
+		return &MessageTooLargeError{Size: len(data), Limit: w.maxSize, Head: data[:min(len(data), messageHeadSize)]}
+	}
+
 	header := fmt.Sprintf("Content-Length: %d\r\n\r\n", len(data))
 	var frame bytes.Buffer
 	frame.Grow(len(header) + len(data))
@@ -234,32 +332,65 @@ func (w *NewlineFrameWriter) WriteJSON(obj interface{}) error {
 
 // NewlineFrameReader reads newline-delimited JSON messages
 type NewlineFrameReader struct {
-	scanner *bufio.Scanner
+	messageSizeLimit
+	reader *bufio.Reader
 }
 
 // NewNewlineFrameReader creates a newline frame reader
 func NewNewlineFrameReader(r io.Reader) *NewlineFrameReader {
-	scanner := bufio.NewScanner(r)
-	// Increase buffer size to handle large messages (MCP spec mentions 10MB+)
-	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
-	return &NewlineFrameReader{scanner: scanner}
+	return &NewlineFrameReader{reader: bufio.NewReader(r)}
+}
+
+// ReadMessage reads one line without its line ending. A line longer than
+// MaxMessageSize is skipped and reported as a *MessageTooLargeError.
+func (r *NewlineFrameReader) ReadMessage() ([]byte, error) {
+	limit := r.MaxMessageSize()
+	var line []byte
+	size := 0
+	for {
+		chunk, err := r.reader.ReadSlice('\n')
+		size += len(chunk)
+		if len(line) <= limit {
+			// Keep the line ending, so a line exactly at the limit is not
+			// taken for a longer one.
+			line = append(line, chunk[:min(len(chunk), limit+2-len(line))]...)
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == io.EOF && size > 0 {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	trimmed := bytes.TrimSuffix(line, []byte("\n"))
+	trimmed = bytes.TrimSuffix(trimmed, []byte("\r"))
+	size -= len(line) - len(trimmed)
+	if size > limit {
+		return nil, &MessageTooLargeError{Size: size, Limit: limit, Head: trimmed[:min(len(trimmed), messageHeadSize)]}
+	}
+	return trimmed, nil
 }
 
 // ReadJSON reads one newline-delimited JSON message
 func (r *NewlineFrameReader) ReadJSON(obj interface{}) error {
-	if !r.scanner.Scan() {
-		if err := r.scanner.Err(); err != nil {
-			return err
-		}
-		return io.EOF
+	data, err := r.ReadMessage()
+	if err != nil {
+		return err
 	}
-	return json.Unmarshal(r.scanner.Bytes(), obj)
+	return json.Unmarshal(data, obj)
 }
 
 // AutoFrameReader detects the incoming stream framing and reads messages accordingly.
 type AutoFrameReader struct {
+	messageSizeLimit
 	reader        *bufio.Reader
 	mode          FramingMode
+	lspReader     *LSPFrameReader
 	newlineReader *NewlineFrameReader
 }
 
@@ -289,7 +420,11 @@ func (r *AutoFrameReader) ReadJSON(obj interface{}) error {
 
 	switch r.mode {
 	case FramingLSP:
-		data, err := (&LSPFrameReader{reader: r.reader}).ReadMessage()
+		if r.lspReader == nil {
+			r.lspReader = &LSPFrameReader{reader: r.reader}
+		}
+		r.lspReader.SetMaxMessageSize(r.MaxMessageSize())
+		data, err := r.lspReader.ReadMessage()
 		if err != nil {
 			return err
 		}
@@ -298,6 +433,7 @@ func (r *AutoFrameReader) ReadJSON(obj interface{}) error {
 		if r.newlineReader == nil {
 			r.newlineReader = NewNewlineFrameReader(r.reader)
 		}
+		r.newlineReader.SetMaxMessageSize(r.MaxMessageSize())
 		return r.newlineReader.ReadJSON(obj)
 	default:
 		return fmt.Errorf("unsupported framing mode: %d", r.mode)
@@ -321,11 +457,25 @@ func (r *AutoFrameReader) detectMode() (FramingMode, error) {
 				return FramingUnknown, err
 			}
 		default:
+			// Skip the line, so the next read does not trip over it again.
+			if err := r.discardLine(); err != nil {
+				return FramingUnknown, err
+			}
 			return FramingUnknown, fmt.Errorf("unknown frame prefix %q", b[0])
 		}
 	}
 }
 
+// discardLine skips input up to and including the next newline.
+func (r *AutoFrameReader) discardLine() error {
+	for {
+		_, err := r.reader.ReadSlice('\n')
+		if err != bufio.ErrBufferFull {
+			return err
+		}
+	}
+}
+
 // AutoFrameWriter writes responses using the chosen framing mode.
 type AutoFrameWriter struct {
 	writer io.Writer
@@ -0,0 +1,139 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// fuzzMessageLimit keeps fuzzed frames small enough that size limits are hit.
+const fuzzMessageLimit = 256
+
+// fuzzMaxReads bounds how many messages a fuzz input is read for, in case a
+// reader stops making progress.
+const fuzzMaxReads = 1000
+
+var frameFuzzSeeds = []string{
+	"Content-Length: 2\r\n\r\n{}",
+	"Content-Length: 13\r\n\r\n{\"id\":\"abc\"}Content-Length: 2\r\n\r\n[]",
+	"Content-Length: 99999999999999999999\r\n\r\n{}",
+	"Content-Length: -1\r\n\r\n",
+	"Content-Length: 100\r\n\r\n{\"partial\":",
+	"Content-Length: 300\r\n\r\n{\"id\":1,\"x\":\"" + strings.Repeat("y", 286) + "\"}",
+	"Content-Length 2\r\n\r\n{}",
+	"Content-Length: 2\n\n{}",
+	"Content-Type: application/json\r\n\r\n",
+	"{\"jsonrpc\":\"2.0\",\"id\":1}\n{\"id\":2}\r\n",
+	"{\"id\":\"\xff\xfe\"}\n",
+	"\n\n\n",
+	strings.Repeat("x", fuzzMessageLimit*3) + "\n{}\n",
+	"",
+}
+
+// readFramesForTest reads input with read until it runs out, checking the
+// properties every frame reader keeps: each read returns a message within the
+// limit or an error, oversized messages carry at most messageHeadSize bytes
+// of their start, and every read but the last consumes input, so a caller
+// that goes on reading after an error cannot spin.
+func readFramesForTest(t *testing.T, input []byte, read func() ([]byte, error)) {
+	t.Helper()
+	for i := 0; i <= len(input); i++ {
+		data, err := read()
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return
+		}
+		var tooLarge *MessageTooLargeError
+		switch {
+		case errors.As(err, &tooLarge):
+			if tooLarge.Size <= tooLarge.Limit || len(tooLarge.Head) > messageHeadSize {
+				t.Fatalf("inconsistent MessageTooLargeError: size %d, limit %d, head %d bytes", tooLarge.Size, tooLarge.Limit, len(tooLarge.Head))
+			}
+			LeadingMessageID(tooLarge.Head)
+		case err == nil && len(data) > fuzzMessageLimit:
+			t.Fatalf("read a %d byte message past the %d byte limit", len(data), fuzzMessageLimit)
+		}
+	}
+	t.Fatalf("reader stopped making progress on %q", input)
+}
+
+func FuzzLSPFrameReader(f *testing.F) {
+	for _, seed := range frameFuzzSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, input []byte) {
+		reader := NewLSPFrameReader(bytes.NewReader(input))
+		reader.SetMaxMessageSize(fuzzMessageLimit)
+		readFramesForTest(t, input, reader.ReadMessage)
+	})
+}
+
+func FuzzNewlineFrameReader(f *testing.F) {
+	for _, seed := range frameFuzzSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, input []byte) {
+		reader := NewNewlineFrameReader(bytes.NewReader(input))
+		reader.SetMaxMessageSize(fuzzMessageLimit)
+		readFramesForTest(t, input, func() ([]byte, error) {
+			data, err := reader.ReadMessage()
+			if err == nil && bytes.IndexByte(data, '\n') >= 0 {
+				t.Fatalf("message %q spans lines", data)
+			}
+			return data, err
+		})
+	})
+}
+
+func FuzzAutoFrameReader(f *testing.F) {
+	for _, seed := range frameFuzzSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, input []byte) {
+		reader := NewAutoFrameReader(bytes.NewReader(input))
+		reader.SetMaxMessageSize(fuzzMessageLimit)
+		readFramesForTest(t, input, func() ([]byte, error) {
+			var raw json.RawMessage
+			err := reader.ReadJSON(&raw)
+			return raw, err
+		})
+	})
+}
+
+// FuzzLSPFrameRoundTrip checks that whatever LSPFrameWriter writes,
+// LSPFrameReader reads back unchanged.
+func FuzzLSPFrameRoundTrip(f *testing.F) {
+	f.Add([]byte(`{"jsonrpc":"2.0","id":1}`), []byte(`{"v":"gabp/1"}`))
+	f.Add([]byte("Content-Length: 5\r\n\r\n"), []byte("\r\n\r\n"))
+	f.Add([]byte("\xff\xfe\x00"), []byte{})
+	f.Fuzz(func(t *testing.T, first, second []byte) {
+		var stream bytes.Buffer
+		writer := NewLSPFrameWriter(&stream)
+		messages := [][]byte{first, second}
+		for _, message := range messages {
+			if err := writer.WriteMessage(message); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+		}
+
+		reader := NewLSPFrameReader(&stream)
+		for _, want := range messages {
+			if len(want) == 0 {
+				// An empty frame has no body to read back.
+				if _, err := reader.ReadMessage(); err == nil {
+					t.Fatal("expected an empty frame to be rejected")
+				}
+				continue
+			}
+			got, err := reader.ReadMessage()
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("read %q, wrote %q", got, want)
+			}
+		}
+	})
+}
@@ -3,7 +3,9 @@ package util
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -165,3 +167,66 @@ func (w *overlapDetectingWriter) Bytes() []byte {
 
 	return append([]byte(nil), w.data.Bytes()...)
 }
+
+func TestLSPFrameReaderSkipsOversizedMessages(t *testing.T) {
+	var stream bytes.Buffer
+	writer := NewLSPFrameWriter(&stream)
+	large := `{"id":"big","result":"` + strings.Repeat("x", 100) + `"}`
+	if err := writer.WriteMessage([]byte(large)); err != nil {
+		t.Fatalf("write large: %v", err)
+	}
+	if err := writer.WriteMessage([]byte(`{"id":"small"}`)); err != nil {
+		t.Fatalf("write small: %v", err)
+	}
+	stream.WriteString("Content-Length: -5\r\n\r\n")
+
+	reader := NewLSPFrameReader(&stream)
+	reader.SetMaxMessageSize(64)
+	_, err := reader.ReadMessage()
+	var tooLarge *MessageTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Size != len(large) || tooLarge.Limit != 64 {
+		t.Fatalf("expected a MessageTooLargeError, got %v", err)
+	}
+	if id, ok := LeadingMessageID(tooLarge.Head); !ok || string(id) != `"big"` {
+		t.Fatalf("expected the id of the oversized message, got %s", id)
+	}
+	if data, err := reader.ReadMessage(); err != nil || string(data) != `{"id":"small"}` {
+		t.Fatalf("expected the next message after the oversized one, got %q, %v", data, err)
+	}
+	if _, err := reader.ReadMessage(); err == nil || !strings.Contains(err.Error(), "invalid Content-Length") {
+		t.Fatalf("expected a negative Content-Length to be rejected, got %v", err)
+	}
+}
+
+func TestLSPFrameWriterRefusesOversizedMessages(t *testing.T) {
+	var stream bytes.Buffer
+	writer := NewLSPFrameWriter(&stream)
+	writer.SetMaxMessageSize(4)
+	var tooLarge *MessageTooLargeError
+	if err := writer.WriteMessage([]byte(`{"a":1}`)); !errors.As(err, &tooLarge) || stream.Len() != 0 {
+		t.Fatalf("expected the message to be refused unsent, got %v", err)
+	}
+}
+
+func TestNewlineFrameReaderSkipsOversizedLines(t *testing.T) {
+	stream := strings.NewReader(`{"id":7,"params":"` + strings.Repeat("x", 10000) + "\"}\n{\"id\":8}\r\n{\"id\":9}")
+	reader := NewNewlineFrameReader(stream)
+	reader.SetMaxMessageSize(9)
+
+	_, err := reader.ReadMessage()
+	var tooLarge *MessageTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 9 {
+		t.Fatalf("expected a MessageTooLargeError, got %v", err)
+	}
+	if id, ok := LeadingMessageID(tooLarge.Head); !ok || string(id) != "7" {
+		t.Fatalf("expected the id of the oversized line, got %s", id)
+	}
+	for _, want := range []string{`{"id":8}`, `{"id":9}`} {
+		if data, err := reader.ReadMessage(); err != nil || string(data) != want {
+			t.Fatalf("expected %s, got %q, %v", want, data, err)
+		}
+	}
+	if _, err := reader.ReadMessage(); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
@@ -1,6 +1,10 @@
 package util
 
 import (
+	"fmt"
+	"os"
+	"sync"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -13,41 +17,250 @@ type Logger interface {
 	Errorw(msg string, keysAndValues ...interface{})
 }
 
+// Log formats accepted by LogOptions.Format.
+const (
+	LogFormatConsole = "console"
+	LogFormatJSON    = "json"
+)
+
+// LogOptions configure NewLoggerWithOptions.
+type LogOptions struct {
+	Level  string // trace|debug|info|warn|error
+	Format string // LogFormatConsole (default) or LogFormatJSON
+	// File, when set, receives a copy of every entry and is rotated once it
+	// grows past MaxFileBytes (DefaultLogFileMaxBytes when 0)
+	File         string
+	MaxFileBytes int64
+	// Recent, when set, keeps the latest entries in memory
+	Recent *LogBuffer
+}
+
 type zapLogger struct {
 	*zap.SugaredLogger
+	levels *LogLevels
 }
 
 // NewLogger creates a structured logger that writes to stderr only
 func NewLogger(level string) Logger {
-	// Parse log level
-	var logLevel zapcore.Level
+	logger, err := NewLoggerWithOptions(LogOptions{Level: level})
+	if err != nil {
+		// Fallback logger if config fails
+		return &zapLogger{SugaredLogger: zap.NewExample().Sugar()}
+	}
+	return logger
+}
+
+// NewLoggerWithOptions creates a structured logger that writes to stderr and,
+// as configured, to a rotated log file and an in-memory buffer. Every output
+// uses the same format.
+func NewLoggerWithOptions(opts LogOptions) (Logger, error) {
+	levels := &LogLevels{global: zap.NewAtomicLevelAt(parseLogLevel(opts.Level))}
+	// The outputs take every entry; each logger filters by its own level,
+	// which SetLogLevel can change while GABS runs
+	level := zapcore.DebugLevel
+
+	var encoder zapcore.Encoder
+	switch opts.Format {
+	case "", LogFormatConsole:
+		encoder = zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	case LogFormatJSON:
+		encoderConfig := zap.NewProductionEncoderConfig()
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	default:
+		return nil, fmt.Errorf("unknown log format %q (use %s or %s)", opts.Format, LogFormatConsole, LogFormatJSON)
+	}
+
+	cores := []zapcore.Core{zapcore.NewCore(encoder, zapcore.Lock(os.Stderr), level)}
+	if opts.File != "" {
+		file, err := OpenRotatingFile(opts.File, opts.MaxFileBytes)
+		if err != nil {
+			return nil, err
+		}
+		cores = append(cores, zapcore.NewCore(encoder.Clone(), file, level))
+	}
+	if opts.Recent != nil {
+		cores = append(cores, zapcore.NewCore(encoder.Clone(), zapcore.AddSync(opts.Recent), level))
+	}
+
+	core := &levelCore{Core: zapcore.NewTee(cores...), enabler: levels.global}
+	logger := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1), zap.ErrorOutput(zapcore.Lock(os.Stderr)))
+	return &zapLogger{SugaredLogger: logger.Sugar(), levels: levels}, nil
+}
+
+// LogLevels holds the level of a logger created by NewLoggerWithOptions and
+// the levels of its subsystems. A subsystem without a level of its own
+// follows the logger's level.
+type LogLevels struct {
+	global     zap.AtomicLevel
+	mu         sync.RWMutex
+	subsystems map[string]zapcore.Level
+}
+
+// subsystemLevel is the level a Subsystem logger filters by.
+type subsystemLevel struct {
+	levels *LogLevels
+	name   string
+}
+
+func (l subsystemLevel) Enabled(level zapcore.Level) bool {
+	l.levels.mu.RLock()
+	own, hasOwn := l.levels.subsystems[l.name]
+	l.levels.mu.RUnlock()
+	if hasOwn {
+		return own.Enabled(level)
+	}
+	return l.levels.global.Enabled(level)
+}
+
+// levelCore filters the entries of the core it wraps by enabler.
+type levelCore struct {
+	zapcore.Core
+	enabler zapcore.LevelEnabler
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.enabler.Enabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), enabler: c.enabler}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// Subsystem returns a child logger for one part of GABS, such as "gabp". Its
+// entries carry the subsystem name, and SetLogLevel can give it a level of
+// its own.
+func Subsystem(log Logger, name string) Logger {
+	zl, ok := log.(*zapLogger)
+	if !ok || zl.levels == nil {
+		return WithFields(log, "subsystem", name)
+	}
+	enabler := subsystemLevel{levels: zl.levels, name: name}
+	logger := zl.Desugar().WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		if filtered, ok := core.(*levelCore); ok {
+			core = filtered.Core
+		}
+		return &levelCore{Core: core, enabler: enabler}
+	}))
+	return &zapLogger{SugaredLogger: logger.Sugar().With("subsystem", name), levels: zl.levels}
+}
+
+// SetLogLevel changes the level of log while GABS runs. An empty subsystem
+// sets the level of log and of every subsystem without a level of its own;
+// otherwise only that subsystem changes.
+func SetLogLevel(log Logger, subsystem, level string) error {
+	parsed, err := zapcore.ParseLevel(level)
+	if level == "trace" {
+		parsed, err = zapcore.DebugLevel, nil
+	}
+	if err != nil || parsed > zapcore.ErrorLevel {
+		return fmt.Errorf("unknown log level %q (use trace, debug, info, warn or error)", level)
+	}
+	levels := levelsOf(log)
+	if levels == nil {
+		return fmt.Errorf("this logger does not support changing its level")
+	}
+	if subsystem == "" {
+		levels.global.SetLevel(parsed)
+		return nil
+	}
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	if levels.subsystems == nil {
+		levels.subsystems = make(map[string]zapcore.Level)
+	}
+	levels.subsystems[subsystem] = parsed
+	return nil
+}
+
+// LogLevelsOf returns the current level of log under the key "" and the
+// levels subsystems were given with SetLogLevel, or nil when log does not
+// support changing levels.
+func LogLevelsOf(log Logger) map[string]string {
+	levels := levelsOf(log)
+	if levels == nil {
+		return nil
+	}
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+	result := map[string]string{"": levels.global.Level().String()}
+	for name, level := range levels.subsystems {
+		result[name] = level.String()
+	}
+	return result
+}
+
+func levelsOf(log Logger) *LogLevels {
+	switch l := log.(type) {
+	case *zapLogger:
+		return l.levels
+	case *fieldLogger:
+		return levelsOf(l.log)
+	}
+	return nil
+}
+
+func parseLogLevel(level string) zapcore.Level {
 	switch level {
 	case "trace", "debug":
-		logLevel = zapcore.DebugLevel
+		return zapcore.DebugLevel
 	case "info":
-		logLevel = zapcore.InfoLevel
+		return zapcore.InfoLevel
 	case "warn":
-		logLevel = zapcore.WarnLevel
+		return zapcore.WarnLevel
 	case "error":
-		logLevel = zapcore.ErrorLevel
+		return zapcore.ErrorLevel
 	default:
-		logLevel = zapcore.InfoLevel
+		return zapcore.InfoLevel
 	}
+}
 
-	// Configure to write to stderr only
-	config := zap.NewDevelopmentConfig()
-	config.Level = zap.NewAtomicLevelAt(logLevel)
-	config.OutputPaths = []string{"stderr"}
-	config.ErrorOutputPaths = []string{"stderr"}
-	config.DisableStacktrace = true
-
-	logger, err := config.Build()
-	if err != nil {
-		// Fallback logger if config fails
-		logger = zap.NewExample()
+// WithFields returns a child logger that adds keysAndValues to every entry,
+// such as "gameId" for the entries about one game.
+func WithFields(log Logger, keysAndValues ...interface{}) Logger {
+	if len(keysAndValues) == 0 {
+		return log
+	}
+	if zl, ok := log.(*zapLogger); ok {
+		return &zapLogger{SugaredLogger: zl.SugaredLogger.With(keysAndValues...), levels: zl.levels}
 	}
+	if fl, ok := log.(*fieldLogger); ok {
+		return &fieldLogger{log: fl.log, fields: append(append([]interface{}{}, fl.fields...), keysAndValues...)}
+	}
+	return &fieldLogger{log: log, fields: keysAndValues}
+}
+
+// fieldLogger adds fields to the entries of a Logger that is not backed by zap.
+type fieldLogger struct {
+	log    Logger
+	fields []interface{}
+}
+
+func (l *fieldLogger) with(keysAndValues []interface{}) []interface{} {
+	return append(append([]interface{}{}, l.fields...), keysAndValues...)
+}
+
+func (l *fieldLogger) Debugw(msg string, keysAndValues ...interface{}) {
+	l.log.Debugw(msg, l.with(keysAndValues)...)
+}
+
+func (l *fieldLogger) Infow(msg string, keysAndValues ...interface{}) {
+	l.log.Infow(msg, l.with(keysAndValues)...)
+}
+
+func (l *fieldLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	l.log.Warnw(msg, l.with(keysAndValues)...)
+}
 
-	return &zapLogger{logger.Sugar()}
+func (l *fieldLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	l.log.Errorw(msg, l.with(keysAndValues)...)
 }
 
 func (l *zapLogger) Debugw(msg string, keysAndValues ...interface{}) {
@@ -0,0 +1,164 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultLogFileMaxBytes is the size at which a log file is rotated.
+	DefaultLogFileMaxBytes = 10 << 20
+	// logFileBackups is how many rotated log files are kept beside the
+	// current one, such as gabs.log.1 to gabs.log.3 or game.log.1 to
+	// game.log.3.
+	logFileBackups = 3
+	// DefaultRecentLogLines is how many entries a LogBuffer keeps by default.
+	DefaultRecentLogLines = 500
+)
+
+// RotatingFile is a log file that is moved to <path>.1 once it grows past
+// its size limit. Older files shift to .2 and .3; the oldest is removed.
+type RotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	file     *os.File
+	size     int64
+}
+
+// OpenRotatingFile opens path for appending, creating it and its directory as
+// needed. maxBytes of 0 or less means DefaultLogFileMaxBytes.
+func OpenRotatingFile(path string, maxBytes int64) (*RotatingFile, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultLogFileMaxBytes
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	r := &RotatingFile{path: path, maxBytes: maxBytes}
+	if err := r.openLocked(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RotatingFile) openLocked() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// Write appends p, rotating the file first when p would take it past the
+// size limit. A single entry is never split across files.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file != nil && r.size > 0 && r.size+int64(len(p)) > r.maxBytes {
+		r.rotateLocked()
+	}
+	if r.file == nil {
+		if err := r.openLocked(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotateLocked shifts <path> to <path>.1, <path>.1 to <path>.2 and so on,
+// dropping the oldest. The next write opens a fresh file.
+func (r *RotatingFile) rotateLocked() {
+	r.file.Close()
+	r.file = nil
+	os.Remove(fmt.Sprintf("%s.%d", r.path, logFileBackups))
+	for i := logFileBackups - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+	}
+	os.Rename(r.path, r.path+".1")
+}
+
+// Sync flushes the file to disk.
+func (r *RotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Sync()
+}
+
+// Close closes the file.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// LogBuffer keeps the most recent log entries in memory, one per line, for
+// reading them back over MCP.
+type LogBuffer struct {
+	mu    sync.Mutex
+	lines []string
+	next  int
+	full  bool
+}
+
+// NewLogBuffer creates a buffer holding up to size entries, or
+// DefaultRecentLogLines when size is 0 or less.
+func NewLogBuffer(size int) *LogBuffer {
+	if size <= 0 {
+		size = DefaultRecentLogLines
+	}
+	return &LogBuffer{lines: make([]string, size)}
+}
+
+// Write stores each line of p as an entry, dropping the oldest when full.
+func (b *LogBuffer) Write(p []byte) (int, error) {
+	text := strings.TrimRight(string(p), "\n")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, line := range strings.Split(text, "\n") {
+		b.lines[b.next] = line
+		b.next = (b.next + 1) % len(b.lines)
+		if b.next == 0 {
+			b.full = true
+		}
+	}
+	return len(p), nil
+}
+
+// Lines returns up to the last n entries, oldest first. n of 0 or less
+// returns all of them.
+func (b *LogBuffer) Lines(n int) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var lines []string
+	if b.full {
+		lines = append(lines, b.lines[b.next:]...)
+	}
+	lines = append(lines, b.lines[:b.next]...)
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
@@ -0,0 +1,137 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestJSONLoggerTagsChildEntriesWithGameID(t *testing.T) {
+	recent := NewLogBuffer(10)
+	log, err := NewLoggerWithOptions(LogOptions{Level: "info", Format: LogFormatJSON, Recent: recent})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+
+	log.Debugw("hidden")
+	WithFields(log, "gameId", "factory").Infow("game started", "pid", 42)
+
+	lines := recent.Lines(0)
+	if len(lines) != 1 {
+		t.Fatalf("expected only the info entry, got %q", lines)
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("expected a JSON entry, got %q: %v", lines[0], err)
+	}
+	if entry["msg"] != "game started" || entry["gameId"] != "factory" || entry["pid"] != float64(42) {
+		t.Fatalf("unexpected entry %#v", entry)
+	}
+}
+
+func TestNewLoggerWithOptionsRejectsUnknownFormat(t *testing.T) {
+	if _, err := NewLoggerWithOptions(LogOptions{Format: "xml"}); err == nil {
+		t.Fatal("expected an unknown format to be rejected")
+	}
+}
+
+func TestWithFieldsWrapsOtherLoggers(t *testing.T) {
+	recorder := &recordingLogger{}
+	WithFields(WithFields(recorder, "gameId", "factory"), "attempt", 2).Warnw("retrying", "error", "refused")
+
+	want := []interface{}{"gameId", "factory", "attempt", 2, "error", "refused"}
+	if fmt.Sprint(recorder.fields) != fmt.Sprint(want) {
+		t.Fatalf("expected fields %v, got %v", want, recorder.fields)
+	}
+}
+
+type recordingLogger struct {
+	fields []interface{}
+}
+
+func (l *recordingLogger) Debugw(msg string, keysAndValues ...interface{}) {}
+func (l *recordingLogger) Infow(msg string, keysAndValues ...interface{})  {}
+func (l *recordingLogger) Warnw(msg string, keysAndValues ...interface{})  { l.fields = keysAndValues }
+func (l *recordingLogger) Errorw(msg string, keysAndValues ...interface{}) {}
+
+func TestRotatingFileKeepsThreeBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "gabs.log")
+	file, err := OpenRotatingFile(path, 10)
+	if err != nil {
+		t.Fatalf("failed to open log file: %v", err)
+	}
+	defer file.Close()
+
+	for i := 1; i <= 6; i++ {
+		if _, err := fmt.Fprintf(file, "entry %d\n", i); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+	}
+
+	for suffix, want := range map[string]string{"": "entry 6\n", ".1": "entry 5\n", ".3": "entry 3\n"} {
+		data, err := os.ReadFile(path + suffix)
+		if err != nil || string(data) != want {
+			t.Fatalf("expected gabs.log%s to hold %q, got %q (%v)", suffix, want, data, err)
+		}
+	}
+	if _, err := os.Stat(path + ".4"); !os.IsNotExist(err) {
+		t.Fatalf("expected no fourth backup, got %v", err)
+	}
+}
+
+func TestLogBufferKeepsNewestLines(t *testing.T) {
+	buffer := NewLogBuffer(3)
+	for i := 1; i <= 5; i++ {
+		fmt.Fprintf(buffer, "line %d\n", i)
+	}
+	if got := strings.Join(buffer.Lines(0), ","); got != "line 3,line 4,line 5" {
+		t.Fatalf("unexpected lines %q", got)
+	}
+	if got := strings.Join(buffer.Lines(2), ","); got != "line 4,line 5" {
+		t.Fatalf("unexpected tail %q", got)
+	}
+}
+
+func TestSetLogLevelChangesSubsystemsAtRuntime(t *testing.T) {
+	recent := NewLogBuffer(10)
+	log, err := NewLoggerWithOptions(LogOptions{Level: "info", Format: LogFormatJSON, Recent: recent})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	gabp := WithFields(Subsystem(log, "gabp"), "gameId", "factory")
+	process := Subsystem(log, "process")
+
+	gabp.Debugw("before")
+	if err := SetLogLevel(log, "gabp", "debug"); err != nil {
+		t.Fatalf("SetLogLevel failed: %v", err)
+	}
+	gabp.Debugw("gabp detail")
+	process.Debugw("process detail")
+
+	lines := recent.Lines(0)
+	if len(lines) != 1 || !strings.Contains(lines[0], `"msg":"gabp detail"`) || !strings.Contains(lines[0], `"subsystem":"gabp"`) || !strings.Contains(lines[0], `"gameId":"factory"`) {
+		t.Fatalf("expected only the gabp debug entry, got %q", lines)
+	}
+
+	if err := SetLogLevel(log, "", "error"); err != nil {
+		t.Fatalf("SetLogLevel failed: %v", err)
+	}
+	process.Warnw("process warning")
+	if len(recent.Lines(0)) != 1 {
+		t.Fatalf("expected the global level to silence process warnings, got %q", recent.Lines(0))
+	}
+	levels := LogLevelsOf(log)
+	if levels[""] != "error" || levels["gabp"] != "debug" {
+		t.Fatalf("unexpected levels %v", levels)
+	}
+
+	if err := SetLogLevel(log, "", "verbose"); err == nil {
+		t.Fatal("expected an unknown level to be rejected")
+	}
+	if err := SetLogLevel(&recordingLogger{}, "", "debug"); err == nil {
+		t.Fatal("expected a logger without levels to be rejected")
+	}
+}
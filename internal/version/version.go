@@ -2,7 +2,10 @@
 // The version information can be injected at build time using -ldflags.
 package version
 
-import "fmt"
+import (
+	"fmt"
+	"runtime"
+)
 
 var (
 	// Version is the main version string for GABS.
@@ -42,3 +45,15 @@ func GetFullVersionInfo() string {
 func GetDetailedVersionInfo() string {
 	return fmt.Sprintf("gabs %s (commit: %s, built: %s)", Version, Commit, BuildDate)
 }
+
+// Platform returns the operating system and architecture GABS runs on, such
+// as "linux/amd64".
+func Platform() string {
+	return runtime.GOOS + "/" + runtime.GOARCH
+}
+
+// ServerHeader returns the value of the HTTP Server header, such as
+// "gabs/1.0.8 (linux/amd64)".
+func ServerHeader() string {
+	return fmt.Sprintf("gabs/%s (%s)", Version, Platform())
+}